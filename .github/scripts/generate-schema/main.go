@@ -125,6 +125,39 @@ func customizeSpecProperties(properties *orderedmap.OrderedMap[string, *jsonsche
 		connProp.Required = nil
 	}
 
+	// Also fix required fields for networking
+	if networkingProp, ok := specProp.Properties.Get("networking"); ok && networkingProp != nil {
+		networkingProp.Required = nil
+
+		if networkingProp.Properties != nil {
+			if dockerNetworkProp, ok := networkingProp.Properties.Get("dockerNetwork"); ok && dockerNetworkProp != nil {
+				dockerNetworkProp.Required = nil
+			}
+		}
+	}
+
+	// Node customizations only require the key and effect of their taints
+	if nodesProp, ok := specProp.Properties.Get("nodes"); ok && nodesProp != nil && nodesProp.Items != nil {
+		nodesProp.Items.Required = nil
+
+		if nodesProp.Items.Properties != nil {
+			if taintsProp, ok := nodesProp.Items.Properties.Get("taints"); ok && taintsProp != nil &&
+				taintsProp.Items != nil {
+				taintsProp.Items.Required = []string{"key", "effect"}
+			}
+		}
+	}
+
+	// Mounts need a host and a container path
+	if mountsProp, ok := specProp.Properties.Get("mounts"); ok && mountsProp != nil && mountsProp.Items != nil {
+		mountsProp.Items.Required = []string{"hostPath", "containerPath"}
+	}
+
+	// Plugins need a name and a command
+	if pluginsProp, ok := specProp.Properties.Get("plugins"); ok && pluginsProp != nil && pluginsProp.Items != nil {
+		pluginsProp.Items.Required = []string{"name", "command"}
+	}
+
 	// Also fix required fields for options (all fields have omitzero so they're optional)
 	if optionsProp, ok := specProp.Properties.Get("options"); ok && optionsProp != nil {
 		optionsProp.Required = nil
@@ -134,6 +167,34 @@ func customizeSpecProperties(properties *orderedmap.OrderedMap[string, *jsonsche
 				// Ensure interval is optional so application code can apply defaults
 				fluxProp.Required = nil
 			}
+
+			// Options of the charts KSail installs are optional
+			for _, chart := range []string{
+				"cilium", "calico", "metricsServer", "metalLB", "helm", "gitea", "localStack", "cloudNativePG",
+				"kyverno", "gatewayAPI", "argoRollouts", "headlamp", "vault",
+			} {
+				if chartProp, ok := optionsProp.Properties.Get(chart); ok && chartProp != nil {
+					chartProp.Required = nil
+
+					if chartProp.Properties != nil {
+						if repositoryProp, ok := chartProp.Properties.Get("repository"); ok && repositoryProp != nil {
+							repositoryProp.Required = nil
+						}
+					}
+				}
+			}
+
+			// The install retry policy and its per-component overrides fall back to defaults
+			if installProp, ok := optionsProp.Properties.Get("install"); ok && installProp != nil {
+				installProp.Required = nil
+
+				if installProp.Properties != nil {
+					componentsProp, ok := installProp.Properties.Get("components")
+					if ok && componentsProp != nil && componentsProp.AdditionalProperties != nil {
+						componentsProp.AdditionalProperties.Required = nil
+					}
+				}
+			}
 		}
 	}
 }
@@ -173,6 +234,16 @@ func customTypeMapper(reflectType reflect.Type) *jsonschema.Schema {
 		return enumSchema("Enabled", "Disabled")
 	case reflect.TypeFor[v1alpha1.GitOpsEngine]():
 		return enumSchema("None")
+	case reflect.TypeFor[v1alpha1.IPFamily]():
+		return enumSchema("IPv4", "IPv6", "DualStack")
+	case reflect.TypeFor[v1alpha1.NodeRole]():
+		return enumSchema("ControlPlane", "Worker")
+	case reflect.TypeFor[v1alpha1.TaintEffect]():
+		return enumSchema("NoSchedule", "PreferNoSchedule", "NoExecute")
+	case reflect.TypeFor[v1alpha1.GatewayAPIChannel]():
+		return enumSchema("Standard", "Experimental")
+	case reflect.TypeFor[v1alpha1.PodSecurityStandard]():
+		return enumSchema("baseline", "restricted")
 	}
 
 	// Return nil to use default mapping for other types
@@ -0,0 +1,199 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// maxConcurrentFiles bounds how many files are encrypted or decrypted at once, so key
+// management systems are not flooded with data key requests.
+const maxConcurrentFiles = 8
+
+var (
+	errBatchFailed     = errors.New("some files could not be processed")
+	errNoMatchingFiles = errors.New("no files match the pattern")
+)
+
+// skipFileError is returned by a batch operation to leave a file untouched. Its message
+// is reported as the reason.
+type skipFileError struct {
+	reason string
+}
+
+func (err *skipFileError) Error() string {
+	return err.reason
+}
+
+// inputSet is the list of files the cipher commands operate on.
+type inputSet struct {
+	files []string
+	// batch is true when the arguments name more than a single file, i.e. multiple
+	// files, a directory or a glob pattern.
+	batch bool
+}
+
+// expandInputs resolves file, directory and glob pattern arguments into the files to
+// process. Directories are walked recursively for the formats SOPS supports, skipping
+// hidden directories and files other than .env files, as glob patterns do. Explicitly named files are kept
+// as given, so unsupported formats and missing files are reported by the command
+// instead of silently skipped.
+func expandInputs(args []string) (inputSet, error) {
+	inputs := inputSet{batch: len(args) > 1}
+	seen := map[string]bool{}
+
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			inputs.files = append(inputs.files, path)
+		}
+	}
+
+	for _, arg := range args {
+		paths := []string{arg}
+
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return inputSet{}, fmt.Errorf("invalid pattern %q: %w", arg, err)
+			}
+
+			if len(matches) == 0 {
+				return inputSet{}, fmt.Errorf("%w: %s", errNoMatchingFiles, arg)
+			}
+
+			inputs.batch = true
+			paths = slices.DeleteFunc(matches, func(match string) bool {
+				return isHiddenFile(filepath.Base(match))
+			})
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil || !info.IsDir() {
+				add(path)
+
+				continue
+			}
+
+			inputs.batch = true
+
+			err = walkSupportedFiles(path, add)
+			if err != nil {
+				return inputSet{}, err
+			}
+		}
+	}
+
+	return inputs, nil
+}
+
+func walkSupportedFiles(root string, add func(path string)) error {
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		hidden := path != root && strings.HasPrefix(entry.Name(), ".")
+
+		if entry.IsDir() {
+			if hidden {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if hidden && isHiddenFile(entry.Name()) {
+			return nil
+		}
+
+		_, _, storeErr := getStores(path)
+		if storeErr == nil {
+			add(path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	return nil
+}
+
+// isHiddenFile reports whether a directory walk or glob pattern skips the file. Like
+// shells, hidden files are skipped, which keeps .sops.yaml itself out of batches; .env
+// files are the exception.
+func isHiddenFile(name string) bool {
+	return strings.HasPrefix(name, ".") && filepath.Ext(name) != ".env"
+}
+
+// processFiles runs process for every file concurrently, then reports the outcome of
+// each file in input order followed by a summary that uses verb, e.g. "Encrypted".
+// Files for which process returns an *skipFileError are reported as skipped; other
+// errors are reported per file and fail the batch once all files are done.
+func processFiles(cmd *cobra.Command, files []string, verb string, process func(path string) error) error {
+	errs := make([]error, len(files))
+	slots := make(chan struct{}, maxConcurrentFiles)
+
+	var waiting sync.WaitGroup
+
+	for index, path := range files {
+		slots <- struct{}{}
+
+		waiting.Go(func() {
+			defer func() { <-slots }()
+
+			errs[index] = process(path)
+		})
+	}
+
+	waiting.Wait()
+
+	processed, failed := 0, 0
+	out := cmd.OutOrStdout()
+
+	for index, path := range files {
+		var skip *skipFileError
+
+		switch err := errs[index]; {
+		case err == nil:
+			processed++
+
+			_, _ = fmt.Fprintf(out, "%s %s\n", verb, path)
+		case errors.As(err, &skip):
+			_, _ = fmt.Fprintf(out, "Skipped %s: %s\n", path, skip.reason)
+		default:
+			failed++
+
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed %s: %v\n", path, err)
+		}
+	}
+
+	_, err := fmt.Fprintf(out, "%s %d of %d files\n", verb, processed, len(files))
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", errBatchFailed, failed, len(files))
+	}
+
+	return nil
+}
+
+// hasExitCode reports whether err carries the SOPS exit code.
+func hasExitCode(err error, code int) bool {
+	var exitErr interface{ ExitCode() int }
+
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == code
+}
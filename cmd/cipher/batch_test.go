@@ -0,0 +1,156 @@
+package cipher_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/cipher"
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+)
+
+const batchEnv = "PASSWORD=hunter2\n"
+
+func TestEncryptDecryptDirectory(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	dir := t.TempDir()
+	files := writeFiles(t, dir, map[string]string{
+		"secrets/app.yaml":    rotateSecret,
+		"secrets/nested/.env": batchEnv,
+		"secrets/notes.txt":   "not a secret\n",
+		"secrets/.git/x.yaml": rotateSecret,
+	})
+
+	out := runCipher(t, stubRuntime, "encrypt", filepath.Join(dir, "secrets"))
+
+	want := "Encrypted " + files["secrets/app.yaml"] + "\n" +
+		"Encrypted " + files["secrets/nested/.env"] + "\n" +
+		"Encrypted 2 of 2 files\n"
+	if out != want {
+		t.Errorf("unexpected output:\nwant:\n%s\ngot:\n%s", want, out)
+	}
+
+	for _, name := range []string{"secrets/app.yaml", "secrets/nested/.env"} {
+		if strings.Contains(readFile(t, files[name]), "hunter2") {
+			t.Errorf("expected %s to be encrypted", name)
+		}
+	}
+
+	if readFile(t, files["secrets/.git/x.yaml"]) != rotateSecret {
+		t.Error("expected files in hidden directories to be left unchanged")
+	}
+
+	out = runCipher(t, stubRuntime, "encrypt", filepath.Join(dir, "secrets"))
+	if !strings.Contains(out, "Skipped "+files["secrets/app.yaml"]+": already encrypted\n") {
+		t.Errorf("expected encrypted files to be skipped, got:\n%s", out)
+	}
+
+	runCipher(t, stubRuntime, "decrypt", filepath.Join(dir, "secrets"))
+
+	if readFile(t, files["secrets/app.yaml"]) != rotateSecret {
+		t.Errorf("expected app.yaml to be decrypted in place, got:\n%s", readFile(t, files["secrets/app.yaml"]))
+	}
+
+	if readFile(t, files["secrets/nested/.env"]) != batchEnv {
+		t.Errorf("expected .env to be decrypted in place, got:\n%s", readFile(t, files["secrets/nested/.env"]))
+	}
+}
+
+func TestEncryptGlobHonorsCreationRules(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	dir := t.TempDir()
+	recipient := newAgeRecipient(t)
+	files := writeFiles(t, dir, map[string]string{
+		".sops.yaml": "creation_rules:\n" +
+			"  - path_regex: \\.enc\\.yaml$\n" +
+			"    encrypted_regex: ^stringData$\n" +
+			"    age: " + recipient + "\n",
+		"db.enc.yaml":  rotateSecret,
+		"api.yaml":     rotateSecret,
+		"plain.json":   "{}\n",
+		"web.enc.yaml": rotateSecret,
+	})
+
+	out := runCipher(t, stubRuntime, "encrypt", filepath.Join(dir, "*.yaml"))
+
+	if !strings.Contains(out, "Skipped "+files["api.yaml"]+": no matching creation rule\n") ||
+		!strings.Contains(out, "Encrypted 2 of 3 files\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	encrypted := readFile(t, files["db.enc.yaml"])
+	if !strings.Contains(encrypted, recipient) || strings.Contains(encrypted, stubs.StubRecipient) {
+		t.Errorf("expected the creation rule recipient instead of the backend's, got:\n%s", encrypted)
+	}
+
+	if !strings.Contains(encrypted, "name: test") {
+		t.Errorf("expected encrypted_regex of the creation rule to leave metadata readable, got:\n%s", encrypted)
+	}
+
+	if readFile(t, files["plain.json"]) != "{}\n" {
+		t.Error("expected files not matching the pattern to be left unchanged")
+	}
+}
+
+func TestEncryptGlobWithoutMatches(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewCipherCmd(runtime.New(stubs.CipherModule()))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"encrypt", filepath.Join(t.TempDir(), "*.yaml")})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no files match the pattern") {
+		t.Errorf("expected no matching files error, got: %v", err)
+	}
+}
+
+func TestDecryptDirectoryRejectsOutputFlag(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"secret.yaml": rotateSecret})
+
+	cmd := cipher.NewCipherCmd(runtime.New(stubs.CipherModule()))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"decrypt", dir, "--output", filepath.Join(dir, "out.yaml")})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("expected --output to be rejected for a directory")
+	}
+}
+
+// writeFiles writes files relative to dir and returns their paths by name.
+func writeFiles(t *testing.T, dir string, files map[string]string) map[string]string {
+	t.Helper()
+
+	paths := make(map[string]string, len(files))
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o750)
+		if err != nil {
+			t.Fatalf("failed to create directory for %s: %v", name, err)
+		}
+
+		err = os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+
+		paths[name] = path
+	}
+
+	return paths
+}
@@ -2,16 +2,19 @@ package cipher
 
 import (
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	"github.com/spf13/cobra"
 )
 
 // NewCipherCmd creates the cipher command that integrates with SOPS.
-func NewCipherCmd(_ *runtime.Runtime) *cobra.Command {
+func NewCipherCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cipher",
 		Short: "Manage encrypted files with SOPS",
 		Long: `Cipher command provides access to SOPS (Secrets OPerationS) functionality
-for encrypting and decrypting files.
+for encrypting, decrypting and rotating the keys of files, diffing their
+plaintext, running commands with decrypted values, auditing which files are
+encrypted, and generating age keys to encrypt with.
 
 SOPS supports multiple key management systems:
   - age recipients
@@ -24,9 +27,32 @@ SOPS supports multiple key management systems:
 	}
 
 	// Add subcommands
-	cmd.AddCommand(NewEncryptCmd())
-	cmd.AddCommand(NewEditCmd())
-	cmd.AddCommand(NewDecryptCmd())
+	cmd.AddCommand(newEncryptCmd(runtimeContainer))
+	cmd.AddCommand(newEditCmd(runtimeContainer))
+	cmd.AddCommand(newDecryptCmd(runtimeContainer))
+	cmd.AddCommand(newDiffCmd(runtimeContainer))
+	cmd.AddCommand(newExecCmd(runtimeContainer))
+	cmd.AddCommand(newKeygenCmd())
+	cmd.AddCommand(newRotateCmd(runtimeContainer))
+	cmd.AddCommand(newStatusCmd())
 
 	return cmd
 }
+
+// runEWithBackend resolves the cipher backend from the runtime container and passes it,
+// together with the command arguments, to handler.
+func runEWithBackend(
+	runtimeContainer *runtime.Runtime,
+	handler func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return runtimeContainer.Invoke(func(injector runtime.Injector) error {
+			backend, err := runtime.ResolveCipherBackend(injector)
+			if err != nil {
+				return err
+			}
+
+			return handler(cmd, args, backend)
+		})
+	}
+}
@@ -6,8 +6,9 @@ import (
 	"os"
 	"strings"
 
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	"github.com/getsops/sops/v3"
-	"github.com/getsops/sops/v3/aes"
 	"github.com/getsops/sops/v3/cmd/sops/codes"
 	"github.com/getsops/sops/v3/cmd/sops/common"
 	"github.com/getsops/sops/v3/keyservice"
@@ -17,7 +18,12 @@ import (
 
 const notBinaryHint = "This is likely not an encrypted binary file."
 
-var errDumpingTree = errors.New("error dumping file")
+var (
+	errDumpingTree       = errors.New("error dumping file")
+	errBatchDecryptFlags = errors.New(
+		"--extract and --output require a single file; directories and patterns are decrypted in place",
+	)
+)
 
 // decryptOpts contains all options needed for the decryption operation.
 type decryptOpts struct {
@@ -135,6 +141,10 @@ func extract(tree *sops.Tree, path []any, outputStore sops.Store) ([]byte, error
 
 // NewDecryptCmd creates and returns the decrypt command.
 func NewDecryptCmd() *cobra.Command {
+	return newDecryptCmd(runtime.NewRuntime())
+}
+
+func newDecryptCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	var (
 		extract   string
 		ignoreMac bool
@@ -142,7 +152,7 @@ func NewDecryptCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "decrypt <file>",
+		Use:   "decrypt [file]...",
 		Short: "Decrypt a file with SOPS",
 		Long: `Decrypt a file using SOPS (Secrets OPerationS).
 
@@ -154,16 +164,25 @@ SOPS supports multiple key management systems:
   - Azure Key Vault
   - HashiCorp Vault
 
+A single file is decrypted to stdout, or to --output. Directories are decrypted
+recursively and glob patterns are expanded, decrypting matching .yaml, .yml,
+.json and .env files in place and concurrently. Files that are not encrypted
+are skipped.
+
 Example:
   ksail cipher decrypt secrets.yaml
+  ksail cipher decrypt secrets/
   ksail cipher decrypt secrets.yaml --extract '["data"]["password"]'
   ksail cipher decrypt secrets.yaml --output plaintext.yaml
   ksail cipher decrypt secrets.yaml --ignore-mac`,
 		SilenceUsage: true,
-		Args:         cobra.MaximumNArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return handleDecryptRunE(cmd, args, extract, ignoreMac, output)
-		},
+		Args:         cobra.ArbitraryArgs,
+		RunE: runEWithBackend(
+			runtimeContainer,
+			func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+				return handleDecryptRunE(cmd, args, backend, extract, ignoreMac, output)
+			},
+		),
 	}
 
 	cmd.Flags().StringVarP(
@@ -193,6 +212,7 @@ const decryptedFilePermissions = 0o600
 func handleDecryptRunE(
 	cmd *cobra.Command,
 	args []string,
+	backend ciphersvc.Backend,
 	extract string,
 	ignoreMac bool,
 	output string,
@@ -202,7 +222,16 @@ func handleDecryptRunE(
 	readFromStdin := len(args) == 0
 
 	if !readFromStdin {
-		inputPath = args[0]
+		inputs, err := expandInputs(args)
+		if err != nil {
+			return err
+		}
+
+		if inputs.batch {
+			return decryptFilesInPlace(cmd, inputs.files, backend, extract, ignoreMac, output)
+		}
+
+		inputPath = inputs.files[0]
 	}
 
 	inputStore, outputStore, err := getDecryptStores(inputPath, readFromStdin)
@@ -219,14 +248,14 @@ func handleDecryptRunE(
 	}
 
 	opts := decryptOpts{
-		Cipher:          aes.NewCipher(),
+		Cipher:          backend.Cipher,
 		InputStore:      inputStore,
 		OutputStore:     outputStore,
 		InputPath:       inputPath,
 		ReadFromStdin:   readFromStdin,
 		IgnoreMAC:       ignoreMac,
 		Extract:         extractPath,
-		KeyServices:     []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+		KeyServices:     backend.KeyServices,
 		DecryptionOrder: []string{},
 	}
 
@@ -238,6 +267,53 @@ func handleDecryptRunE(
 	return writeDecryptedOutput(cmd, decryptedData, output)
 }
 
+// decryptFilesInPlace decrypts a batch of files concurrently, replacing each encrypted
+// file with its plaintext. Files without SOPS metadata are skipped.
+func decryptFilesInPlace(
+	cmd *cobra.Command,
+	files []string,
+	backend ciphersvc.Backend,
+	extract string,
+	ignoreMac bool,
+	output string,
+) error {
+	if extract != "" || output != "" {
+		return errBatchDecryptFlags
+	}
+
+	return processFiles(cmd, files, "Decrypted", func(path string) error {
+		inputStore, outputStore, err := getStores(path)
+		if err != nil {
+			return err
+		}
+
+		decryptedData, err := decrypt(decryptOpts{
+			Cipher:          backend.Cipher,
+			InputStore:      inputStore,
+			OutputStore:     outputStore,
+			InputPath:       path,
+			ReadFromStdin:   false,
+			IgnoreMAC:       ignoreMac,
+			KeyServices:     backend.KeyServices,
+			DecryptionOrder: []string{},
+		})
+		if errors.Is(err, sops.MetadataNotFound) {
+			return &skipFileError{reason: "not encrypted"}
+		}
+
+		if err != nil {
+			return fmt.Errorf("decryption failed: %w", err)
+		}
+
+		err = os.WriteFile(path, decryptedData, decryptedFilePermissions)
+		if err != nil {
+			return fmt.Errorf("failed to write decrypted file: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // writeDecryptedOutput writes decrypted data to either a file or stdout.
 func writeDecryptedOutput(cmd *cobra.Command, data []byte, outputPath string) error {
 	if outputPath != "" {
@@ -0,0 +1,224 @@
+package cipher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultDiffRef     = "HEAD"
+	diffContextLines   = 3
+	diffArgsWithTarget = 2
+)
+
+var errGitFailed = errors.New("git failed")
+
+// NewDiffCmd creates and returns the diff command.
+func NewDiffCmd() *cobra.Command {
+	return newDiffCmd(runtime.NewRuntime())
+}
+
+func newDiffCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	var (
+		ref       string
+		ignoreMac bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <file> [new-file]",
+		Short: "Show a plaintext diff of an encrypted file",
+		Long: `Show a unified diff of the decrypted contents of a SOPS-encrypted file.
+
+With one file, its working-tree version is compared with the version at --ref
+(HEAD by default). With two files, they are compared with each other, so the
+command can be used as a git difftool. Both sides are only decrypted in memory,
+and SOPS metadata like the MAC and last-modified time is left out of the diff.
+
+Example:
+  ksail cipher diff secrets.enc.yaml
+  ksail cipher diff secrets.enc.yaml --ref main
+  ksail cipher diff old.enc.yaml new.enc.yaml
+  git difftool --no-prompt --extcmd 'ksail cipher diff' -- secrets.enc.yaml`,
+		SilenceUsage: true,
+		Args:         cobra.RangeArgs(1, diffArgsWithTarget),
+		RunE: runEWithBackend(
+			runtimeContainer,
+			func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+				return handleDiffRunE(cmd, args, backend, ref, ignoreMac)
+			},
+		),
+	}
+
+	cmd.Flags().StringVar(
+		&ref,
+		"ref",
+		defaultDiffRef,
+		"git revision to compare the working-tree file with",
+	)
+	cmd.Flags().BoolVar(
+		&ignoreMac,
+		"ignore-mac",
+		false,
+		"ignore Message Authentication Code (MAC) check",
+	)
+
+	return cmd
+}
+
+// handleDiffRunE decrypts both sides of the comparison and writes their unified diff.
+func handleDiffRunE(
+	cmd *cobra.Command,
+	args []string,
+	backend ciphersvc.Backend,
+	ref string,
+	ignoreMac bool,
+) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	newPath := args[len(args)-1]
+	fromName := "a/" + args[0]
+	toName := "b/" + newPath
+
+	var oldContent []byte
+
+	if len(args) == diffArgsWithTarget {
+		content, err := os.ReadFile(args[0]) //nolint:gosec // path is provided by the user
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+
+		oldContent = content
+	} else {
+		content, err := gitShowFile(ctx, ref, newPath)
+		if err != nil {
+			return err
+		}
+
+		oldContent = content
+		fromName = ref + ":" + newPath
+	}
+
+	newContent, err := os.ReadFile(newPath) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", newPath, err)
+	}
+
+	oldPlain, err := decryptForDiff(oldContent, newPath, backend, ignoreMac)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %w", fromName, err)
+	}
+
+	newPlain, err := decryptForDiff(newContent, newPath, backend, ignoreMac)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %w", toName, err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(oldPlain)),
+		B:        difflib.SplitLines(string(newPlain)),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %w", err)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), diff)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// decryptForDiff returns the plaintext of content, in the format of path. Content without
+// SOPS metadata is returned as is, and empty content, e.g. of a file missing at the
+// compared revision, stays empty.
+func decryptForDiff(content []byte, path string, backend ciphersvc.Backend, ignoreMac bool) ([]byte, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil
+	}
+
+	inputStore, outputStore, err := getStores(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := inputStore.LoadEncryptedFile(content)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return content, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encrypted file: %w", err)
+	}
+
+	_, err = common.DecryptTree(common.DecryptTreeOpts{
+		Cipher:      backend.Cipher,
+		IgnoreMac:   ignoreMac,
+		Tree:        &tree,
+		KeyServices: backend.KeyServices,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tree: %w", err)
+	}
+
+	plain, err := outputStore.EmitPlainFile(tree.Branches)
+
+	return handleEmitError(err, plain)
+}
+
+// gitShowFile returns the content of path at ref, or nil when path does not exist at ref,
+// e.g. because it was added after it.
+func gitShowFile(ctx context.Context, ref, path string) ([]byte, error) {
+	dir := filepath.Dir(path)
+	name := "./" + filepath.Base(path)
+
+	listed, err := runGit(ctx, dir, "ls-tree", "--name-only", ref, "--", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(string(listed)) == "" {
+		return nil, nil
+	}
+
+	return runGit(ctx, dir, "show", ref+":"+name)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	//nolint:gosec // fixed binary with a user-provided revision and path
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: git %s: %s",
+			errGitFailed,
+			strings.Join(args, " "),
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package cipher_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+)
+
+func TestDiffComparesTwoEncryptedFiles(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	oldFile := createTestFile(t, "old.yaml", "password: hunter2\nuser: admin\n")
+	newFile := createTestFile(t, "new.yaml", "password: correct-horse\nuser: admin\n")
+
+	runCipher(t, stubRuntime, "encrypt", oldFile, newFile)
+
+	out := runCipher(t, stubRuntime, "diff", oldFile, newFile)
+
+	for _, want := range []string{"-password: hunter2", "+password: correct-horse", " user: admin"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected diff to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "sops") {
+		t.Errorf("expected SOPS metadata to be left out of the diff, got:\n%s", out)
+	}
+}
+
+func TestDiffComparesWorkingTreeWithRevision(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	repo := t.TempDir()
+	secretFile := filepath.Join(repo, "secrets.yaml")
+
+	git(t, repo, "init", "--quiet")
+	writeSecret(t, secretFile, "token: first\n")
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+	git(t, repo, "add", "secrets.yaml")
+	git(t, repo, "commit", "--quiet", "-m", "Add secrets")
+
+	out := runCipher(t, stubRuntime, "diff", secretFile)
+	if out != "" {
+		t.Errorf("expected no diff for an unchanged file, got:\n%s", out)
+	}
+
+	writeSecret(t, secretFile, "token: second\n")
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	out = runCipher(t, stubRuntime, "diff", secretFile)
+	if !strings.Contains(out, "-token: first") || !strings.Contains(out, "+token: second") {
+		t.Errorf("expected the changed token in the diff, got:\n%s", out)
+	}
+
+	entries, err := os.ReadDir(repo)
+	if err != nil {
+		t.Fatalf("failed to list repository: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("expected no decrypted files to be left behind, got %d entries", len(entries))
+	}
+}
+
+func TestDiffShowsFileMissingAtRevisionAsAdded(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	repo := t.TempDir()
+	secretFile := filepath.Join(repo, "secrets.yaml")
+
+	git(t, repo, "init", "--quiet")
+	git(t, repo, "commit", "--quiet", "--allow-empty", "-m", "Initial commit")
+	writeSecret(t, secretFile, "token: first\n")
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	out := runCipher(t, stubRuntime, "diff", secretFile)
+	if !strings.Contains(out, "+token: first") {
+		t.Errorf("expected the new file as added lines, got:\n%s", out)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.CommandContext(
+		t.Context(),
+		"git",
+		append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...,
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func writeSecret(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -3,5 +3,8 @@
 // This package contains commands for managing encrypted files using the SOPS
 // (Secrets OPerationS) Go library, supporting multiple key management systems
 // including age recipients, PGP fingerprints, AWS KMS, GCP KMS, Azure Key Vault,
-// and HashiCorp Vault.
+// and HashiCorp Vault. It also generates age key pairs and registers their
+// recipients in .sops.yaml creation rules, diffs the plaintext of encrypted
+// files against git revisions, and reports files the creation rules cover that
+// are still plaintext.
 package cipher
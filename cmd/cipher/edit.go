@@ -12,8 +12,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	"github.com/getsops/sops/v3"
-	"github.com/getsops/sops/v3/aes"
 	"github.com/getsops/sops/v3/cmd/sops/codes"
 	"github.com/getsops/sops/v3/cmd/sops/common"
 	"github.com/getsops/sops/v3/keyservice"
@@ -491,6 +492,10 @@ func lookupAnyEditor(editorNames ...string) (string, error) {
 
 // NewEditCmd creates and returns the edit command.
 func NewEditCmd() *cobra.Command {
+	return newEditCmd(runtime.NewRuntime())
+}
+
+func newEditCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	var ignoreMac bool
 
 	var showMasterKeys bool
@@ -519,9 +524,12 @@ Example:
   SOPS_EDITOR="code --wait" ksail cipher edit secrets.yaml`,
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return handleEditRunE(cmd, args, ignoreMac, showMasterKeys)
-		},
+		RunE: runEWithBackend(
+			runtimeContainer,
+			func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+				return handleEditRunE(cmd, args, backend, ignoreMac, showMasterKeys)
+			},
+		),
 	}
 
 	cmd.Flags().BoolVar(
@@ -541,14 +549,14 @@ Example:
 }
 
 // editNewFile handles editing a new file that doesn't exist yet.
-func editNewFile(opts editOpts, inputStore sops.Store) ([]byte, error) {
+func editNewFile(opts editOpts, inputStore sops.Store, keyGroups []sops.KeyGroup) ([]byte, error) {
 	storeWithEx, ok := inputStore.(storeWithExample)
 	if !ok {
 		return nil, fmt.Errorf("%w", errStoreNoExampleGeneration)
 	}
 
 	encConfig := encryptConfig{
-		KeyGroups:      []sops.KeyGroup{},
+		KeyGroups:      keyGroups,
 		GroupThreshold: 0,
 	}
 
@@ -560,7 +568,12 @@ func editNewFile(opts editOpts, inputStore sops.Store) ([]byte, error) {
 }
 
 // handleEditRunE is the main handler for the edit command.
-func handleEditRunE(cmd *cobra.Command, args []string, ignoreMac, showMasterKeys bool) error {
+func handleEditRunE(
+	cmd *cobra.Command,
+	args []string,
+	backend ciphersvc.Backend,
+	ignoreMac, showMasterKeys bool,
+) error {
 	inputPath := args[0]
 
 	inputStore, outputStore, err := getStores(inputPath)
@@ -569,12 +582,12 @@ func handleEditRunE(cmd *cobra.Command, args []string, ignoreMac, showMasterKeys
 	}
 
 	opts := editOpts{
-		Cipher:          aes.NewCipher(),
+		Cipher:          backend.Cipher,
 		InputStore:      inputStore,
 		OutputStore:     outputStore,
 		InputPath:       inputPath,
 		IgnoreMAC:       ignoreMac,
-		KeyServices:     []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+		KeyServices:     backend.KeyServices,
 		DecryptionOrder: []string{},
 		ShowMasterKeys:  showMasterKeys,
 	}
@@ -588,7 +601,7 @@ func handleEditRunE(cmd *cobra.Command, args []string, ignoreMac, showMasterKeys
 	if fileExists {
 		output, err = edit(opts)
 	} else {
-		output, err = editNewFile(opts, inputStore)
+		output, err = editNewFile(opts, inputStore, backend.KeyGroups)
 	}
 
 	if err != nil {
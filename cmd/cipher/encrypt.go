@@ -7,12 +7,15 @@ import (
 	"os"
 	"path/filepath"
 
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	"github.com/getsops/sops/v3"
-	"github.com/getsops/sops/v3/aes"
 	"github.com/getsops/sops/v3/cmd/sops/codes"
 	"github.com/getsops/sops/v3/cmd/sops/common"
+	sopsconfig "github.com/getsops/sops/v3/config"
 	"github.com/getsops/sops/v3/keyservice"
 	"github.com/getsops/sops/v3/stores"
+	"github.com/getsops/sops/v3/stores/dotenv"
 	"github.com/getsops/sops/v3/stores/json"
 	"github.com/getsops/sops/v3/stores/yaml"
 	"github.com/getsops/sops/v3/version"
@@ -224,8 +227,12 @@ func loadFile(opts encryptOpts) ([]byte, error) {
 
 // NewEncryptCmd creates and returns the encrypt command.
 func NewEncryptCmd() *cobra.Command {
+	return newEncryptCmd(runtime.NewRuntime())
+}
+
+func newEncryptCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "encrypt <file>",
+		Use:   "encrypt <file>...",
 		Short: "Encrypt a file with SOPS",
 		Long: `Encrypt a file using SOPS (Secrets OPerationS).
 
@@ -237,11 +244,20 @@ SOPS supports multiple key management systems:
   - Azure Key Vault
   - HashiCorp Vault
 
+Directories are encrypted recursively and glob patterns are expanded, processing
+matching .yaml, .yml, .json and .env files concurrently. Files that are already
+encrypted, or that no creation rule in .sops.yaml matches, are skipped.
+
+The keys and settings of each file come from the creation rule in the nearest
+.sops.yaml that matches its path.
+
 Example:
-  ksail cipher encrypt secrets.yaml`,
+  ksail cipher encrypt secrets.yaml
+  ksail cipher encrypt secrets/
+  ksail cipher encrypt 'secrets/*.enc.yaml' 'apps/*/secret.yaml'`,
 		SilenceUsage: true,
-		Args:         cobra.ExactArgs(1),
-		RunE:         handleEncryptRunE,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         runEWithBackend(runtimeContainer, handleEncryptRunE),
 	}
 
 	return cmd
@@ -249,34 +265,73 @@ Example:
 
 const encryptedFilePermissions = 0o600
 
-var errUnsupportedFileFormat = errors.New("unsupported file format")
+var (
+	errUnsupportedFileFormat = errors.New("unsupported file format")
+	errNoCreationRule        = errors.New("no matching creation rule")
+)
 
 // handleEncryptRunE is the main handler for the encrypt command.
-// It orchestrates the encryption workflow: determining file stores,
-// setting up encryption options, encrypting the file, and writing
-// the encrypted content back to disk.
-func handleEncryptRunE(cmd *cobra.Command, args []string) error {
-	inputPath := args[0]
+// It expands the arguments into the files to encrypt, and encrypts a single
+// file directly or a batch of files concurrently.
+func handleEncryptRunE(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+	inputs, err := expandInputs(args)
+	if err != nil {
+		return err
+	}
+
+	if inputs.batch {
+		return processFiles(cmd, inputs.files, "Encrypted", func(path string) error {
+			return encryptFile(path, backend, true)
+		})
+	}
+
+	inputPath := inputs.files[0]
+
+	err = encryptFile(inputPath, backend, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Successfully encrypted %s\n", inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
 
+// encryptFile encrypts the file at inputPath in place. In a batch, files that are
+// already encrypted or match no creation rule are skipped instead of failing.
+func encryptFile(inputPath string, backend ciphersvc.Backend, batch bool) error {
 	inputStore, outputStore, err := getStores(inputPath)
 	if err != nil {
 		return err
 	}
 
+	config, err := encryptConfigForFile(inputPath, backend.KeyGroups)
+	if batch && errors.Is(err, errNoCreationRule) {
+		return &skipFileError{reason: "no matching creation rule"}
+	}
+
+	if err != nil {
+		return err
+	}
+
 	opts := encryptOpts{
-		encryptConfig: encryptConfig{
-			KeyGroups:      []sops.KeyGroup{},
-			GroupThreshold: 0,
-		},
-		Cipher:        aes.NewCipher(),
+		encryptConfig: config,
+		Cipher:        backend.Cipher,
 		InputStore:    inputStore,
 		OutputStore:   outputStore,
 		InputPath:     inputPath,
 		ReadFromStdin: false,
-		KeyServices:   []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+		KeyServices:   backend.KeyServices,
 	}
 
 	encryptedData, err := encrypt(opts)
+	if batch && hasExitCode(err, codes.FileAlreadyEncrypted) {
+		return &skipFileError{reason: "already encrypted"}
+	}
+
 	if err != nil {
 		return fmt.Errorf("encryption failed: %w", err)
 	}
@@ -286,16 +341,53 @@ func handleEncryptRunE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write encrypted file: %w", err)
 	}
 
-	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Successfully encrypted %s\n", inputPath)
+	return nil
+}
+
+// encryptConfigForFile returns the encryption settings of the creation rule in the
+// nearest .sops.yaml that matches inputPath. Without a config file, or one without
+// creation rules, the key groups of the backend are used.
+func encryptConfigForFile(inputPath string, keyGroups []sops.KeyGroup) (encryptConfig, error) {
+	fallback := encryptConfig{KeyGroups: keyGroups, GroupThreshold: 0}
+
+	absPath, err := filepath.Abs(inputPath)
 	if err != nil {
-		return fmt.Errorf("failed to write output: %w", err)
+		return encryptConfig{}, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	return nil
+	configPath, err := sopsconfig.FindConfigFile(absPath)
+	if err != nil {
+		return fallback, nil //nolint:nilerr // no .sops.yaml means no creation rules to honor
+	}
+
+	rule, err := sopsconfig.LoadCreationRuleForFile(configPath, absPath, nil)
+	if err != nil {
+		if isNoMatchingRuleError(err) {
+			return encryptConfig{}, fmt.Errorf("%w for %s in %s", errNoCreationRule, inputPath, configPath)
+		}
+
+		return encryptConfig{}, fmt.Errorf("failed to load %s: %w", configPath, err)
+	}
+
+	if rule == nil {
+		return fallback, nil
+	}
+
+	return encryptConfig{
+		UnencryptedSuffix:       rule.UnencryptedSuffix,
+		EncryptedSuffix:         rule.EncryptedSuffix,
+		UnencryptedRegex:        rule.UnencryptedRegex,
+		EncryptedRegex:          rule.EncryptedRegex,
+		UnencryptedCommentRegex: rule.UnencryptedCommentRegex,
+		EncryptedCommentRegex:   rule.EncryptedCommentRegex,
+		MACOnlyEncrypted:        rule.MACOnlyEncrypted,
+		KeyGroups:               rule.KeyGroups,
+		GroupThreshold:          rule.ShamirThreshold,
+	}, nil
 }
 
 // getStores returns the appropriate SOPS stores (input and output) based on file extension.
-// It supports YAML (.yaml, .yml) and JSON (.json) file formats.
+// It supports YAML (.yaml, .yml), JSON (.json) and dotenv (.env) file formats.
 func getStores(inputPath string) (sops.Store, sops.Store, error) {
 	ext := filepath.Ext(inputPath)
 
@@ -304,9 +396,11 @@ func getStores(inputPath string) (sops.Store, sops.Store, error) {
 		return &yaml.Store{}, &yaml.Store{}, nil
 	case ".json":
 		return &json.Store{}, &json.Store{}, nil
+	case ".env":
+		return &dotenv.Store{}, &dotenv.Store{}, nil
 	default:
 		return nil, nil, fmt.Errorf(
-			"%w: %s (supported: .yaml, .yml, .json)",
+			"%w: %s (supported: .yaml, .yml, .json, .env)",
 			errUnsupportedFileFormat,
 			ext,
 		)
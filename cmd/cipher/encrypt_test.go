@@ -2,10 +2,14 @@ package cipher_test
 
 import (
 	"bytes"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/devantler-tech/ksail-go/cmd/cipher"
+	"github.com/devantler-tech/ksail-go/integration/stubs"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
 )
 
 func TestNewEncryptCmd(t *testing.T) {
@@ -17,8 +21,8 @@ func TestNewEncryptCmd(t *testing.T) {
 		t.Fatal("expected non-nil command")
 	}
 
-	if cmd.Use != "encrypt <file>" {
-		t.Errorf("expected Use to be 'encrypt <file>', got %q", cmd.Use)
+	if cmd.Use != "encrypt <file>..." {
+		t.Errorf("expected Use to be 'encrypt <file>...', got %q", cmd.Use)
 	}
 
 	if cmd.Short == "" {
@@ -51,17 +55,12 @@ func TestEncryptCommandRequiresFile(t *testing.T) {
 	t.Parallel()
 
 	rt := runtime.NewRuntime()
-	cipherCmd := cipher.NewCipherCmd(rt)
-
-	var out, errOut bytes.Buffer
-	cipherCmd.SetOut(&out)
-	cipherCmd.SetErr(&errOut)
-	cipherCmd.SetArgs([]string{"encrypt"})
+	result := testutils.RunCLI(t, cipher.NewCipherCmd(rt), "encrypt")
 
-	err := cipherCmd.Execute()
-	if err == nil {
-		t.Error("expected error when no file argument provided")
-	}
+	testutils.AssertCLIResult(t, result, testutils.CLIExpectation{
+		Category: testutils.ExitUsageError,
+		Stderr:   "✗ requires at least 1 arg(s), only received 0",
+	})
 }
 
 // setupEncryptTest is a helper function to create a test file and execute encrypt command.
@@ -133,3 +132,54 @@ func TestEncryptCommandJSONFormat(t *testing.T) {
 `
 	testEncryptWithFormat(t, "test.json", jsonContent)
 }
+
+func TestEncryptDecryptRoundTripWithStubBackend(t *testing.T) {
+	t.Parallel()
+
+	plaintext := `apiVersion: v1
+kind: Secret
+metadata:
+    name: test
+stringData:
+    password: hunter2
+`
+	testFile := createTestFile(t, "secret.yaml", plaintext)
+	stubRuntime := runtime.New(stubs.CipherModule())
+
+	encryptCmd := cipher.NewCipherCmd(stubRuntime)
+	encryptCmd.SetOut(&bytes.Buffer{})
+	encryptCmd.SetArgs([]string{"encrypt", testFile})
+
+	err := encryptCmd.Execute()
+	if err != nil {
+		t.Fatalf("expected encrypt to succeed with stub backend, got: %v", err)
+	}
+
+	encrypted, err := os.ReadFile(testFile) //nolint:gosec // test file path
+	if err != nil {
+		t.Fatalf("failed to read encrypted file: %v", err)
+	}
+
+	if strings.Contains(string(encrypted), "hunter2") {
+		t.Fatalf("expected password to be encrypted, got:\n%s", encrypted)
+	}
+
+	if !strings.Contains(string(encrypted), stubs.StubRecipient) {
+		t.Fatalf("expected sops metadata to reference the stub recipient, got:\n%s", encrypted)
+	}
+
+	var out bytes.Buffer
+
+	decryptCmd := cipher.NewCipherCmd(stubRuntime)
+	decryptCmd.SetOut(&out)
+	decryptCmd.SetArgs([]string{"decrypt", testFile})
+
+	err = decryptCmd.Execute()
+	if err != nil {
+		t.Fatalf("expected decrypt to succeed with stub backend, got: %v", err)
+	}
+
+	if out.String() != plaintext {
+		t.Fatalf("expected decrypted output to match plaintext\nwant:\n%s\ngot:\n%s", plaintext, out.String())
+	}
+}
@@ -0,0 +1,187 @@
+package cipher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	errorhandler "github.com/devantler-tech/ksail-go/pkg/ui/error-handler"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/stores"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errMissingExecCommand = errors.New("missing command to run; pass it after --")
+	errInvalidEnvKey      = errors.New("invalid environment variable")
+)
+
+// NewExecCmd creates and returns the exec command.
+func NewExecCmd() *cobra.Command {
+	return newExecCmd(runtime.NewRuntime())
+}
+
+func newExecCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	var (
+		ignoreMac bool
+		pristine  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "exec <file> -- <command> [args...]",
+		Aliases: []string{"exec-env"},
+		Short:   "Run a command with decrypted values as environment variables",
+		Long: `Decrypt a SOPS-encrypted .env, .yaml or .json file and run a command with its
+top-level values as environment variables, like sops exec-env. The plaintext is
+only held in memory and never written to disk.
+
+The command inherits the environment of ksail, unless --pristine is set. Values
+of the file override inherited variables of the same name. ksail exits with the
+exit code of the command.
+
+Example:
+  ksail cipher exec secrets.env -- npm start
+  ksail cipher exec secrets.enc.yaml -- go run ./cmd/app --port 8080
+  ksail cipher exec secrets.env --pristine -- env`,
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		RunE: runEWithBackend(
+			runtimeContainer,
+			func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+				return handleExecRunE(cmd, args, backend, ignoreMac, pristine)
+			},
+		),
+	}
+
+	cmd.Flags().BoolVar(
+		&ignoreMac,
+		"ignore-mac",
+		false,
+		"ignore Message Authentication Code (MAC) check",
+	)
+	cmd.Flags().BoolVar(
+		&pristine,
+		"pristine",
+		false,
+		"pass only the decrypted values to the command, without the environment of ksail",
+	)
+
+	return cmd
+}
+
+// handleExecRunE decrypts the file named by the first argument and runs the command
+// after -- with its values in the environment.
+func handleExecRunE(
+	cmd *cobra.Command,
+	args []string,
+	backend ciphersvc.Backend,
+	ignoreMac, pristine bool,
+) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash != 1 || len(args) < 2 {
+		return errMissingExecCommand
+	}
+
+	inputPath := args[0]
+
+	inputStore, outputStore, err := getStores(inputPath)
+	if err != nil {
+		return err
+	}
+
+	tree, err := decryptTree(decryptOpts{
+		Cipher:          backend.Cipher,
+		InputStore:      inputStore,
+		OutputStore:     outputStore,
+		InputPath:       inputPath,
+		ReadFromStdin:   false,
+		IgnoreMAC:       ignoreMac,
+		KeyServices:     backend.KeyServices,
+		DecryptionOrder: []string{},
+	})
+	if err != nil {
+		return fmt.Errorf("decryption failed: %w", err)
+	}
+
+	secretEnv, err := envFromTree(tree)
+	if err != nil {
+		return err
+	}
+
+	var env []string
+	if !pristine {
+		env = os.Environ()
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	child := exec.CommandContext(ctx, args[1], args[2:]...) //nolint:gosec // the user runs their own command
+	child.Env = append(env, secretEnv...)
+	child.Stdin = cmd.InOrStdin()
+	child.Stdout = cmd.OutOrStdout()
+	child.Stderr = cmd.ErrOrStderr()
+
+	err = child.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Scripts and CI steps see the exit code of the command, not that of ksail
+		return &errorhandler.ExitCodeError{
+			Code: exitErr.ExitCode(),
+			Err:  fmt.Errorf("%s exited: %w", args[1], err),
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", args[1], err)
+	}
+
+	return nil
+}
+
+// envFromTree converts the top-level values of the decrypted tree into KEY=VALUE
+// pairs. Comments are skipped; nested maps and lists cannot be expressed as
+// environment variables and are rejected.
+func envFromTree(tree *sops.Tree) ([]string, error) {
+	if len(tree.Branches) == 0 {
+		return nil, nil
+	}
+
+	env := make([]string, 0, len(tree.Branches[0]))
+
+	for _, item := range tree.Branches[0] {
+		if _, isComment := item.Key.(sops.Comment); isComment {
+			continue
+		}
+
+		key, isString := item.Key.(string)
+		if !isString {
+			return nil, fmt.Errorf("%w: key %v is not a string", errInvalidEnvKey, item.Key)
+		}
+
+		if key == "" || strings.Contains(key, "=") {
+			return nil, fmt.Errorf("%w: key %q must be non-empty and cannot contain '='", errInvalidEnvKey, key)
+		}
+
+		if stores.IsComplexValue(item.Value) {
+			return nil, fmt.Errorf("%w: %s has a nested value", errInvalidEnvKey, key)
+		}
+
+		value, isString := item.Value.(string)
+		if !isString {
+			value = stores.ValToString(item.Value)
+		}
+
+		env = append(env, key+"="+value)
+	}
+
+	return env, nil
+}
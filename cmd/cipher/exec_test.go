@@ -0,0 +1,107 @@
+package cipher_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/cipher"
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	errorhandler "github.com/devantler-tech/ksail-go/pkg/ui/error-handler"
+)
+
+func TestExecPassesDecryptedValuesAsEnvironment(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secrets.env", "# database\nPASSWORD=hunter2\nPORT=5432\n")
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	out := runCipher(t, stubRuntime, "exec", secretFile, "--", "sh", "-c", `printf '%s:%s' "$PASSWORD" "$PORT"`)
+	if out != "hunter2:5432" {
+		t.Errorf("expected decrypted values in the environment, got %q", out)
+	}
+
+	if strings.Contains(readFile(t, secretFile), "hunter2") {
+		t.Error("expected the file to stay encrypted")
+	}
+}
+
+func TestExecPristineOmitsInheritedEnvironment(t *testing.T) {
+	t.Setenv("KSAIL_EXEC_TEST_INHERITED", "inherited")
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secrets.yaml", "password: hunter2\n")
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	script := `printf '%s:%s' "$password" "$KSAIL_EXEC_TEST_INHERITED"`
+
+	out := runCipher(t, stubRuntime, "exec", secretFile, "--", "/bin/sh", "-c", script)
+	if out != "hunter2:inherited" {
+		t.Errorf("expected inherited environment, got %q", out)
+	}
+
+	out = runCipher(t, stubRuntime, "exec", secretFile, "--pristine", "--", "/bin/sh", "-c", script)
+	if out != "hunter2:" {
+		t.Errorf("expected only decrypted values with --pristine, got %q", out)
+	}
+}
+
+func TestExecRejectsNestedValues(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secrets.yaml", rotateSecret)
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	cmd := cipher.NewCipherCmd(stubRuntime)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"exec", secretFile, "--", "true"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "metadata has a nested value") {
+		t.Errorf("expected nested value error, got: %v", err)
+	}
+}
+
+func TestExecPassesThroughExitCode(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secrets.env", "PASSWORD=hunter2\n")
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	cmd := cipher.NewCipherCmd(stubRuntime)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"exec", secretFile, "--", "sh", "-c", "exit 3"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected the failing command to fail exec")
+	}
+
+	if code := errorhandler.ExitCode(err); code != 3 {
+		t.Errorf("expected exit code 3 of the command, got %d (%v)", code, err)
+	}
+}
+
+func TestExecRequiresCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewCipherCmd(runtime.New(stubs.CipherModule()))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"exec", "secrets.env"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "missing command to run") {
+		t.Errorf("expected missing command error, got: %v", err)
+	}
+}
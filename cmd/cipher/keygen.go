@@ -0,0 +1,339 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/spf13/cobra"
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+const (
+	keysDirPermissions       = 0o700
+	keysFilePermissions      = 0o600
+	sopsConfigFilePermission = 0o644
+	sopsConfigIndent         = 2
+
+	creationRulesKey = "creation_rules"
+	pathRegexKey     = "path_regex"
+	ageKey           = "age"
+)
+
+var (
+	errInvalidSopsConfig   = errors.New("invalid SOPS config")
+	errInvalidCreationRule = errors.New("invalid creation rule")
+)
+
+func newKeygenCmd() *cobra.Command {
+	var (
+		output     string
+		sopsConfig string
+		pathRegex  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate an age key pair for SOPS",
+		Long: `Generate an age key pair and append it to the age keys file SOPS reads.
+
+The keys file defaults to $SOPS_AGE_KEY_FILE, or sops/age/keys.txt in the user
+config directory. With --sops-config, the public key is added as a recipient of
+the creation rule matching --path-regex, creating the rule or file as needed.
+
+Example:
+  ksail cipher keygen
+  ksail cipher keygen --sops-config .sops.yaml
+  ksail cipher keygen --sops-config .sops.yaml --path-regex '.*\.enc\.yaml$'
+  ksail cipher keygen --output ./age.key`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return handleKeygenRunE(cmd, output, sopsConfig, pathRegex)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"age keys file to append the key to (default: $SOPS_AGE_KEY_FILE or the SOPS user config path)",
+	)
+	cmd.Flags().StringVar(
+		&sopsConfig,
+		"sops-config",
+		"",
+		"SOPS config file (e.g. .sops.yaml) to add the public key to as an age recipient",
+	)
+	cmd.Flags().StringVar(
+		&pathRegex,
+		"path-regex",
+		"",
+		"path_regex of the creation rule to update (default: the rule without a path_regex)",
+	)
+
+	return cmd
+}
+
+// handleKeygenRunE generates an age identity, stores it in the keys file and
+// optionally registers its recipient in the SOPS config.
+func handleKeygenRunE(cmd *cobra.Command, output, sopsConfig, pathRegex string) error {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return fmt.Errorf("failed to generate age key: %w", err)
+	}
+
+	keysFile, err := resolveAgeKeysFile(output)
+	if err != nil {
+		return err
+	}
+
+	recipient := identity.Recipient().String()
+
+	err = appendAgeKey(keysFile, identity.String(), recipient, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Public key: %s\nSaved key to %s\n", recipient, keysFile)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if sopsConfig == "" {
+		return nil
+	}
+
+	err = addAgeRecipient(sopsConfig, pathRegex, recipient)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Added recipient to %s\n", sopsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// resolveAgeKeysFile returns the keys file to write to, following the lookup order of SOPS:
+// the explicit path, $SOPS_AGE_KEY_FILE, then the user config directory.
+func resolveAgeKeysFile(output string) (string, error) {
+	if output != "" {
+		return output, nil
+	}
+
+	keysFile, err := ciphersvc.AgeKeysFile()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve age keys file: %w", err)
+	}
+
+	return keysFile, nil
+}
+
+// appendAgeKey appends the secret key to keysFile in the format of age-keygen,
+// creating the file and its directory with owner-only permissions.
+func appendAgeKey(keysFile, secretKey, recipient string, created time.Time) error {
+	err := os.MkdirAll(filepath.Dir(keysFile), keysDirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create keys directory: %w", err)
+	}
+
+	file, err := os.OpenFile( //nolint:gosec // keys file path is provided by the user
+		keysFile,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		keysFilePermissions,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to open keys file: %w", err)
+	}
+
+	_, err = fmt.Fprintf(
+		file,
+		"# created: %s\n# public key: %s\n%s\n",
+		created.Format(time.RFC3339),
+		recipient,
+		secretKey,
+	)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write keys file: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close keys file: %w", err)
+	}
+
+	return nil
+}
+
+// addAgeRecipient adds recipient to the age recipients of the creation rule whose
+// path_regex equals pathRegex, appending a new rule when none matches. Comments and
+// unrelated settings in the config are preserved.
+func addAgeRecipient(configPath, pathRegex, recipient string) error {
+	var document yamlv3.Node
+
+	content, err := os.ReadFile(configPath) //nolint:gosec // config path is provided by the user
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read SOPS config: %w", err)
+	default:
+		err = yamlv3.Unmarshal(content, &document)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", errInvalidSopsConfig, configPath, err)
+		}
+	}
+
+	if document.Kind == 0 {
+		document = yamlv3.Node{
+			Kind:    yamlv3.DocumentNode,
+			Content: []*yamlv3.Node{{Kind: yamlv3.MappingNode}},
+		}
+	}
+
+	root := document.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("%w %s: expected a mapping", errInvalidSopsConfig, configPath)
+	}
+
+	rules := mappingValue(root, creationRulesKey)
+	if rules == nil {
+		rules = &yamlv3.Node{Kind: yamlv3.SequenceNode}
+		root.Content = append(root.Content, scalarNode(creationRulesKey), rules)
+	}
+
+	if rules.Kind != yamlv3.SequenceNode {
+		return fmt.Errorf("%w %s: %s must be a list", errInvalidSopsConfig, configPath, creationRulesKey)
+	}
+
+	err = upsertCreationRule(rules, pathRegex, recipient)
+	if err != nil {
+		return err
+	}
+
+	return writeSopsConfig(configPath, &document)
+}
+
+// upsertCreationRule adds recipient to the rule matching pathRegex, or appends a new rule.
+func upsertCreationRule(rules *yamlv3.Node, pathRegex, recipient string) error {
+	for _, rule := range rules.Content {
+		if rule.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		regex := ""
+		if node := mappingValue(rule, pathRegexKey); node != nil {
+			regex = node.Value
+		}
+
+		if regex != pathRegex {
+			continue
+		}
+
+		return addRecipientToRule(rule, recipient)
+	}
+
+	rule := &yamlv3.Node{Kind: yamlv3.MappingNode}
+	if pathRegex != "" {
+		rule.Content = append(rule.Content, scalarNode(pathRegexKey), scalarNode(pathRegex))
+	}
+
+	rule.Content = append(rule.Content, scalarNode(ageKey), scalarNode(recipient))
+	rules.Content = append(rules.Content, rule)
+
+	return nil
+}
+
+// addRecipientToRule adds recipient to the age recipients of rule, which SOPS accepts
+// either as a comma-separated string or as a list.
+func addRecipientToRule(rule *yamlv3.Node, recipient string) error {
+	recipients := mappingValue(rule, ageKey)
+
+	switch {
+	case recipients == nil:
+		rule.Content = append(rule.Content, scalarNode(ageKey), scalarNode(recipient))
+	case recipients.Kind == yamlv3.SequenceNode:
+		for _, item := range recipients.Content {
+			if strings.TrimSpace(item.Value) == recipient {
+				return nil
+			}
+		}
+
+		recipients.Content = append(recipients.Content, scalarNode(recipient))
+	case recipients.Kind == yamlv3.ScalarNode:
+		existing := splitRecipients(recipients.Value)
+		if slices.Contains(existing, recipient) {
+			return nil
+		}
+
+		recipients.Value = strings.Join(append(existing, recipient), ",")
+		recipients.Style = 0
+	default:
+		return fmt.Errorf("%w: %s must be a string or a list", errInvalidCreationRule, ageKey)
+	}
+
+	return nil
+}
+
+func writeSopsConfig(configPath string, document *yamlv3.Node) error {
+	var builder strings.Builder
+
+	encoder := yamlv3.NewEncoder(&builder)
+	encoder.SetIndent(sopsConfigIndent)
+
+	err := encoder.Encode(document)
+	if err != nil {
+		return fmt.Errorf("failed to encode SOPS config: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("failed to encode SOPS config: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(configPath, []byte(builder.String()), sopsConfigFilePermission)
+	if err != nil {
+		return fmt.Errorf("failed to write SOPS config: %w", err)
+	}
+
+	return nil
+}
+
+func splitRecipients(value string) []string {
+	recipients := []string{}
+
+	for _, recipient := range strings.Split(value, ",") {
+		recipient = strings.TrimSpace(recipient)
+		if recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	return recipients
+}
+
+func mappingValue(mapping *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarNode(value string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: value}
+}
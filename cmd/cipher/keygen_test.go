@@ -0,0 +1,170 @@
+package cipher_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestKeygenWritesKeyToOutput(t *testing.T) {
+	t.Parallel()
+
+	keysFile := filepath.Join(t.TempDir(), "age", "keys.txt")
+
+	out := runKeygen(t, "--output", keysFile)
+
+	recipient := recipientOf(t, keysFile)
+	if !strings.Contains(out, "Public key: "+recipient) {
+		t.Errorf("expected output to contain the public key, got %q", out)
+	}
+
+	info, err := os.Stat(keysFile)
+	if err != nil {
+		t.Fatalf("failed to stat keys file: %v", err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected keys file permissions 0600, got %o", info.Mode().Perm())
+	}
+}
+
+func TestKeygenAppendsToExistingKeysFile(t *testing.T) {
+	t.Parallel()
+
+	keysFile := filepath.Join(t.TempDir(), "keys.txt")
+
+	runKeygen(t, "--output", keysFile)
+	runKeygen(t, "--output", keysFile)
+
+	identities := parseKeysFile(t, keysFile)
+	if len(identities) != 2 {
+		t.Errorf("expected 2 identities, got %d", len(identities))
+	}
+}
+
+func TestKeygenUsesSopsAgeKeyFileEnv(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.txt")
+	t.Setenv("SOPS_AGE_KEY_FILE", keysFile)
+
+	runKeygen(t)
+
+	identities := parseKeysFile(t, keysFile)
+	if len(identities) != 1 {
+		t.Errorf("expected 1 identity, got %d", len(identities))
+	}
+}
+
+func TestKeygenCreatesSopsConfig(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	keysFile := filepath.Join(tmpDir, "keys.txt")
+	sopsConfig := filepath.Join(tmpDir, ".sops.yaml")
+
+	runKeygen(t, "--output", keysFile, "--sops-config", sopsConfig, "--path-regex", `.*\.enc\.yaml$`)
+
+	recipient := recipientOf(t, keysFile)
+	want := "creation_rules:\n  - path_regex: .*\\.enc\\.yaml$\n    age: " + recipient + "\n"
+
+	got := readFile(t, sopsConfig)
+	if got != want {
+		t.Errorf("unexpected SOPS config:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestKeygenUpdatesMatchingCreationRule(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	keysFile := filepath.Join(tmpDir, "keys.txt")
+	sopsConfig := filepath.Join(tmpDir, ".sops.yaml")
+	existing := "# managed by ksail\n" +
+		"creation_rules:\n" +
+		"  - path_regex: secrets/.*\n" +
+		"    age: age1existing\n" +
+		"  - age:\n" +
+		"      - age1other\n"
+
+	err := os.WriteFile(sopsConfig, []byte(existing), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write SOPS config: %v", err)
+	}
+
+	runKeygen(t, "--output", keysFile, "--sops-config", sopsConfig, "--path-regex", "secrets/.*")
+
+	recipient := recipientOf(t, keysFile)
+	got := readFile(t, sopsConfig)
+
+	if !strings.HasPrefix(got, "# managed by ksail\n") {
+		t.Errorf("expected comments to be preserved, got:\n%s", got)
+	}
+
+	if !strings.Contains(got, "age: age1existing,"+recipient+"\n") {
+		t.Errorf("expected recipient to be appended to the matching rule, got:\n%s", got)
+	}
+
+	if !strings.Contains(got, "      - age1other\n") || strings.Count(got, recipient) != 1 {
+		t.Errorf("expected other rules to be left unchanged, got:\n%s", got)
+	}
+}
+
+func TestKeygenRejectsArguments(t *testing.T) {
+	t.Parallel()
+
+	cipherCmd := setupCipherCommandTest(t, []string{"keygen", "unexpected"})
+
+	err := cipherCmd.Execute()
+	if err == nil {
+		t.Error("expected error for unexpected argument")
+	}
+}
+
+func runKeygen(t *testing.T, args ...string) string {
+	t.Helper()
+
+	cipherCmd := setupCipherCommandTest(t, append([]string{"keygen"}, args...))
+
+	var out bytes.Buffer
+	cipherCmd.SetOut(&out)
+
+	err := cipherCmd.Execute()
+	if err != nil {
+		t.Fatalf("expected keygen to succeed, got: %v", err)
+	}
+
+	return out.String()
+}
+
+func parseKeysFile(t *testing.T, keysFile string) []age.Identity {
+	t.Helper()
+
+	identities, err := age.ParseIdentities(strings.NewReader(readFile(t, keysFile)))
+	if err != nil {
+		t.Fatalf("failed to parse keys file: %v", err)
+	}
+
+	return identities
+}
+
+func recipientOf(t *testing.T, keysFile string) string {
+	t.Helper()
+
+	identities := parseKeysFile(t, keysFile)
+
+	return identities[0].(*age.X25519Identity).Recipient().String() //nolint:forcetypeassert // X25519 keys only
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path) //nolint:gosec // test file path
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return string(content)
+}
@@ -0,0 +1,391 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/azkv"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/gcpkms"
+	"github.com/getsops/sops/v3/hcvault"
+	"github.com/getsops/sops/v3/keys"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/kms"
+	"github.com/getsops/sops/v3/pgp"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidMasterKey = errors.New("invalid master key")
+
+// masterKeyType describes a key management system whose keys can be added to or
+// removed from encrypted files with --add-<name> and --rm-<name>.
+type masterKeyType struct {
+	name        string
+	description string
+	parse       func(value string) ([]keys.MasterKey, error)
+}
+
+//nolint:gochecknoglobals // static table of supported key management systems
+var masterKeyTypes = []masterKeyType{
+	{
+		name:        "age",
+		description: "age recipients",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			masterKeys, err := age.MasterKeysFromRecipients(value)
+
+			return toMasterKeys(masterKeys), err //nolint:wrapcheck // wrapped by parseMasterKeys
+		},
+	},
+	{
+		name:        "pgp",
+		description: "PGP fingerprints",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			return toMasterKeys(pgp.MasterKeysFromFingerprintString(value)), nil
+		},
+	},
+	{
+		name:        "kms",
+		description: "AWS KMS key ARNs",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			return toMasterKeys(kms.MasterKeysFromArnString(value, nil, "")), nil
+		},
+	},
+	{
+		name:        "gcp-kms",
+		description: "GCP KMS resource IDs",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			return toMasterKeys(gcpkms.MasterKeysFromResourceIDString(value)), nil
+		},
+	},
+	{
+		name:        "azure-kv",
+		description: "Azure Key Vault key URLs",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			masterKeys, err := azkv.MasterKeysFromURLs(value)
+
+			return toMasterKeys(masterKeys), err //nolint:wrapcheck // wrapped by parseMasterKeys
+		},
+	},
+	{
+		name:        "hc-vault-transit",
+		description: "HashiCorp Vault transit key URIs",
+		parse: func(value string) ([]keys.MasterKey, error) {
+			masterKeys, err := hcvault.NewMasterKeysFromURIs(value)
+
+			return toMasterKeys(masterKeys), err //nolint:wrapcheck // wrapped by parseMasterKeys
+		},
+	},
+}
+
+// rotateOpts contains all options needed to rotate the keys of encrypted files.
+type rotateOpts struct {
+	Cipher           sops.Cipher
+	KeyServices      []keyservice.KeyServiceClient
+	AddMasterKeys    []keys.MasterKey
+	RemoveMasterKeys []keys.MasterKey
+	IgnoreMAC        bool
+}
+
+// NewRotateCmd creates and returns the rotate command.
+func NewRotateCmd() *cobra.Command {
+	return newRotateCmd(runtime.NewRuntime())
+}
+
+func newRotateCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	var ignoreMac bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate <file>",
+		Short: "Rotate the keys of encrypted files with SOPS",
+		Long: `Re-encrypt SOPS-encrypted files with a new data key and an updated set of
+master keys. Given a directory, every encrypted .yaml, .yml, .json and .env file
+in it is rotated; plain files are skipped.
+
+Master keys are added with --add-<type> and removed with --rm-<type>. When
+keys are added or removed, only files whose master keys change are rotated.
+Without key changes, every encrypted file is rotated to a new data key.
+
+Example:
+  ksail cipher rotate secrets.enc.yaml
+  ksail cipher rotate k8s --add-age age1... --rm-age age1...
+  ksail cipher rotate k8s --add-kms arn:aws:kms:eu-west-1:111122223333:key/...`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+	}
+
+	addFlags := make(map[string]*[]string, len(masterKeyTypes))
+	rmFlags := make(map[string]*[]string, len(masterKeyTypes))
+
+	for _, keyType := range masterKeyTypes {
+		addFlags[keyType.name] = cmd.Flags().StringSlice(
+			"add-"+keyType.name,
+			nil,
+			"add "+keyType.description+" to the master keys",
+		)
+		rmFlags[keyType.name] = cmd.Flags().StringSlice(
+			"rm-"+keyType.name,
+			nil,
+			"remove "+keyType.description+" from the master keys",
+		)
+	}
+
+	cmd.Flags().BoolVar(
+		&ignoreMac,
+		"ignore-mac",
+		false,
+		"ignore Message Authentication Code (MAC) check",
+	)
+
+	cmd.RunE = runEWithBackend(
+		runtimeContainer,
+		func(cmd *cobra.Command, args []string, backend ciphersvc.Backend) error {
+			addKeys, err := parseMasterKeys(addFlags)
+			if err != nil {
+				return err
+			}
+
+			removeKeys, err := parseMasterKeys(rmFlags)
+			if err != nil {
+				return err
+			}
+
+			return handleRotateRunE(cmd, args[0], rotateOpts{
+				Cipher:           backend.Cipher,
+				KeyServices:      backend.KeyServices,
+				AddMasterKeys:    addKeys,
+				RemoveMasterKeys: removeKeys,
+				IgnoreMAC:        ignoreMac,
+			})
+		},
+	)
+
+	return cmd
+}
+
+// handleRotateRunE rotates the file or every encrypted file in the directory at path
+// and reports which files changed.
+func handleRotateRunE(cmd *cobra.Command, path string, opts rotateOpts) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		rotated, err := rotateFile(path, opts)
+		if err != nil {
+			return err
+		}
+
+		return reportRotation(cmd, path, rotated)
+	}
+
+	var files []string
+
+	err = walkSupportedFiles(path, func(filePath string) { files = append(files, filePath) })
+	if err != nil {
+		return err
+	}
+
+	encrypted, rotated := 0, 0
+
+	for _, filePath := range files {
+		fileRotated, err := rotateFile(filePath, opts)
+		if errors.Is(err, sops.MetadataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		encrypted++
+
+		if fileRotated {
+			rotated++
+		}
+
+		err = reportRotation(cmd, filePath, fileRotated)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Rotated %d of %d encrypted files\n", rotated, encrypted)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// rotateFile re-encrypts the file at inputPath with a new data key and the updated
+// master keys. It reports false, without touching the file, when master key changes
+// were requested but none of them apply to the file.
+func rotateFile(inputPath string, opts rotateOpts) (bool, error) {
+	inputStore, outputStore, err := getStores(inputPath)
+	if err != nil {
+		return false, err
+	}
+
+	tree, err := common.LoadEncryptedFileWithBugFixes(common.GenericDecryptOpts{
+		Cipher:      opts.Cipher,
+		InputStore:  inputStore,
+		InputPath:   inputPath,
+		IgnoreMAC:   opts.IgnoreMAC,
+		KeyServices: opts.KeyServices,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to load encrypted file %s: %w", inputPath, err)
+	}
+
+	keyGroups := updateKeyGroups(tree.Metadata.KeyGroups, opts.AddMasterKeys, opts.RemoveMasterKeys)
+
+	keysRequested := len(opts.AddMasterKeys) > 0 || len(opts.RemoveMasterKeys) > 0
+	if keysRequested && keyGroupsEqual(tree.Metadata.KeyGroups, keyGroups) {
+		return false, nil
+	}
+
+	_, err = common.DecryptTree(common.DecryptTreeOpts{
+		Cipher:      opts.Cipher,
+		IgnoreMac:   opts.IgnoreMAC,
+		Tree:        tree,
+		KeyServices: opts.KeyServices,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt %s: %w", inputPath, err)
+	}
+
+	tree.Metadata.KeyGroups = keyGroups
+
+	dataKey, errs := tree.GenerateDataKeyWithKeyServices(opts.KeyServices)
+	if len(errs) > 0 {
+		return false, fmt.Errorf("%w for %s: %s", errCouldNotGenerateDataKey, inputPath, errs)
+	}
+
+	encryptedFile, err := encryptTreeAndEmit(tree, dataKey, opts.Cipher, outputStore)
+	if err != nil {
+		return false, err
+	}
+
+	err = ksailio.WriteFileAtomic(inputPath, encryptedFile, encryptedFilePermissions)
+	if err != nil {
+		return false, fmt.Errorf("failed to write encrypted file %s: %w", inputPath, err)
+	}
+
+	return true, nil
+}
+
+// updateKeyGroups returns a copy of keyGroups with addKeys appended to the first group
+// and removeKeys removed from every group. Keys are compared by their string form.
+func updateKeyGroups(keyGroups []sops.KeyGroup, addKeys, removeKeys []keys.MasterKey) []sops.KeyGroup {
+	removed := make(map[string]bool, len(removeKeys))
+	for _, key := range removeKeys {
+		removed[key.ToString()] = true
+	}
+
+	updated := make([]sops.KeyGroup, 0, max(len(keyGroups), 1))
+	present := map[string]bool{}
+
+	for _, group := range keyGroups {
+		kept := sops.KeyGroup{}
+
+		for _, key := range group {
+			if removed[key.ToString()] {
+				continue
+			}
+
+			present[key.ToString()] = true
+			kept = append(kept, key)
+		}
+
+		updated = append(updated, kept)
+	}
+
+	if len(updated) == 0 {
+		updated = append(updated, sops.KeyGroup{})
+	}
+
+	for _, key := range addKeys {
+		if present[key.ToString()] {
+			continue
+		}
+
+		present[key.ToString()] = true
+		updated[0] = append(updated[0], key)
+	}
+
+	return updated
+}
+
+func keyGroupsEqual(left, right []sops.KeyGroup) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for i := range left {
+		if len(left[i]) != len(right[i]) {
+			return false
+		}
+
+		for j := range left[i] {
+			if left[i][j].ToString() != right[i][j].ToString() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// parseMasterKeys parses the values of the --add-<type> or --rm-<type> flags.
+func parseMasterKeys(values map[string]*[]string) ([]keys.MasterKey, error) {
+	var masterKeys []keys.MasterKey
+
+	for _, keyType := range masterKeyTypes {
+		for _, value := range *values[keyType.name] {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			parsed, err := keyType.parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s %q: %w", errInvalidMasterKey, keyType.name, value, err)
+			}
+
+			masterKeys = append(masterKeys, parsed...)
+		}
+	}
+
+	return masterKeys, nil
+}
+
+func reportRotation(cmd *cobra.Command, path string, rotated bool) error {
+	status := "Unchanged"
+	if rotated {
+		status = "Rotated"
+	}
+
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", status, path)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+func toMasterKeys[T keys.MasterKey](typed []T) []keys.MasterKey {
+	masterKeys := make([]keys.MasterKey, 0, len(typed))
+	for _, key := range typed {
+		masterKeys = append(masterKeys, key)
+	}
+
+	return masterKeys
+}
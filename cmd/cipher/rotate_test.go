@@ -0,0 +1,162 @@
+package cipher_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/devantler-tech/ksail-go/cmd/cipher"
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+)
+
+const rotateSecret = `apiVersion: v1
+kind: Secret
+metadata:
+    name: test
+stringData:
+    password: hunter2
+`
+
+func TestNewRotateCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewRotateCmd()
+
+	if cmd.Use != "rotate <file>" {
+		t.Errorf("expected Use to be 'rotate <file>', got %q", cmd.Use)
+	}
+
+	for _, name := range []string{"age", "pgp", "kms", "gcp-kms", "azure-kv", "hc-vault-transit"} {
+		if cmd.Flags().Lookup("add-"+name) == nil {
+			t.Errorf("expected add-%s flag to be registered", name)
+		}
+
+		if cmd.Flags().Lookup("rm-"+name) == nil {
+			t.Errorf("expected rm-%s flag to be registered", name)
+		}
+	}
+}
+
+func TestRotateAddsAndRemovesRecipients(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secret.yaml", rotateSecret)
+	recipient := newAgeRecipient(t)
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	out := runCipher(t, stubRuntime, "rotate", secretFile, "--add-age", recipient)
+	if out != "Rotated "+secretFile+"\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+
+	if !strings.Contains(readFile(t, secretFile), recipient) {
+		t.Fatalf("expected added recipient in sops metadata")
+	}
+
+	out = runCipher(t, stubRuntime, "rotate", secretFile, "--add-age", recipient)
+	if out != "Unchanged "+secretFile+"\n" {
+		t.Errorf("expected rotation with an existing recipient to be a no-op, got %q", out)
+	}
+
+	runCipher(t, stubRuntime, "rotate", secretFile, "--rm-age", recipient)
+
+	if strings.Contains(readFile(t, secretFile), recipient) {
+		t.Errorf("expected removed recipient to be gone from sops metadata")
+	}
+
+	decrypted := runCipher(t, stubRuntime, "decrypt", secretFile)
+	if decrypted != rotateSecret {
+		t.Errorf("expected rotated file to decrypt to the plaintext, got:\n%s", decrypted)
+	}
+}
+
+func TestRotateDirectoryReportsChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "apps", "secret.yaml")
+	plainFile := filepath.Join(dir, "apps", "deployment.yaml")
+
+	err := os.MkdirAll(filepath.Dir(secretFile), 0o750)
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	for path, content := range map[string]string{
+		secretFile:                      rotateSecret,
+		plainFile:                       "apiVersion: apps/v1\nkind: Deployment\n",
+		filepath.Join(dir, "README.md"): "# apps\n",
+	} {
+		err = os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	runCipher(t, stubRuntime, "encrypt", secretFile)
+
+	plainBefore := readFile(t, plainFile)
+
+	out := runCipher(t, stubRuntime, "rotate", dir, "--add-age", newAgeRecipient(t))
+
+	want := "Rotated " + secretFile + "\nRotated 1 of 1 encrypted files\n"
+	if out != want {
+		t.Errorf("unexpected output:\nwant:\n%s\ngot:\n%s", want, out)
+	}
+
+	if readFile(t, plainFile) != plainBefore {
+		t.Errorf("expected plain files to be left unchanged")
+	}
+}
+
+func TestRotateRejectsInvalidRecipient(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	secretFile := createTestFile(t, "secret.yaml", rotateSecret)
+
+	cmd := cipher.NewCipherCmd(stubRuntime)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"rotate", secretFile, "--add-age", "not-a-recipient"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("expected error for an invalid age recipient")
+	}
+}
+
+func runCipher(t *testing.T, rt *runtime.Runtime, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cmd := cipher.NewCipherCmd(rt)
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatalf("expected %s to succeed, got: %v", args[0], err)
+	}
+
+	return out.String()
+}
+
+func newAgeRecipient(t *testing.T) string {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("failed to generate age identity: %v", err)
+	}
+
+	return identity.Recipient().String()
+}
@@ -0,0 +1,250 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/getsops/sops/v3"
+	sopsconfig "github.com/getsops/sops/v3/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sopsConfigFileName = ".sops.yaml"
+
+	statusEncrypted = "encrypted"
+	statusPlaintext = "plaintext"
+)
+
+var (
+	errNoSopsConfig   = errors.New("no .sops.yaml found")
+	errPlaintextFiles = errors.New("files matching creation rules are not encrypted")
+)
+
+// fileStatus is the encryption state of a file matched by a creation rule.
+type fileStatus struct {
+	path       string
+	status     string
+	recipients []string
+}
+
+func newStatusCmd() *cobra.Command {
+	var sopsConfig string
+
+	cmd := &cobra.Command{
+		Use:   "status [directory]",
+		Short: "Report which files covered by .sops.yaml are encrypted",
+		Long: `Scan a directory for files matching the creation rules of .sops.yaml and report
+whether each is encrypted, and for which recipients. Files a rule covers that are
+still plaintext fail the command, so it can enforce encryption in CI.
+
+The directory defaults to the current directory, and .sops.yaml to the nearest
+one in it or its parents. Hidden files and directories are skipped, except .env
+files. A creation rule without a path_regex covers every file.
+
+Example:
+  ksail cipher status
+  ksail cipher status k8s --sops-config .sops.yaml`,
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := "."
+			if len(args) == 1 {
+				root = args[0]
+			}
+
+			return handleStatusRunE(cmd, root, sopsConfig)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&sopsConfig,
+		"sops-config",
+		"",
+		"SOPS config file with the creation rules (default: the nearest .sops.yaml)",
+	)
+
+	return cmd
+}
+
+// handleStatusRunE reports the encryption status of every file under root that a
+// creation rule covers, failing when any of them is plaintext.
+func handleStatusRunE(cmd *cobra.Command, root, sopsConfig string) error {
+	configPath, err := resolveSopsConfig(root, sopsConfig)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := collectFileStatuses(root, configPath)
+	if err != nil {
+		return err
+	}
+
+	plaintext := 0
+
+	table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "STATUS\tFILE\tRECIPIENTS")
+
+	for _, status := range statuses {
+		if status.status == statusPlaintext {
+			plaintext++
+		}
+
+		recipients := strings.Join(status.recipients, ",")
+		if recipients == "" {
+			recipients = "-"
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\n", status.status, status.path, recipients)
+	}
+
+	err = table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	_, err = fmt.Fprintf(
+		cmd.OutOrStdout(),
+		"%d of %d files matching %s are encrypted\n",
+		len(statuses)-plaintext,
+		len(statuses),
+		configPath,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if plaintext > 0 {
+		return fmt.Errorf("%d %w", plaintext, errPlaintextFiles)
+	}
+
+	return nil
+}
+
+// resolveSopsConfig returns the explicit config path, or the nearest .sops.yaml in root
+// or its parents.
+func resolveSopsConfig(root, sopsConfig string) (string, error) {
+	if sopsConfig != "" {
+		return sopsConfig, nil
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// FindConfigFile searches from the directory of the path it is given.
+	configPath, err := sopsconfig.FindConfigFile(filepath.Join(absRoot, sopsConfigFileName))
+	if err != nil {
+		return "", fmt.Errorf("%w in %s or its parents", errNoSopsConfig, root)
+	}
+
+	return configPath, nil
+}
+
+// collectFileStatuses walks root and returns the status of every supported file a creation
+// rule in configPath matches, in walk order.
+func collectFileStatuses(root, configPath string) ([]fileStatus, error) {
+	var (
+		statuses []fileStatus
+		statErr  error
+	)
+
+	err := walkSupportedFiles(root, func(path string) {
+		if statErr != nil {
+			return
+		}
+
+		status, matched, err := fileStatusOf(path, configPath)
+		if err != nil {
+			statErr = err
+
+			return
+		}
+
+		if matched {
+			statuses = append(statuses, status)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	if statErr != nil {
+		return nil, statErr
+	}
+
+	return statuses, nil
+}
+
+// fileStatusOf reports whether a creation rule matches path and, if so, whether the file is
+// encrypted and for which recipients.
+func fileStatusOf(path, configPath string) (fileStatus, bool, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fileStatus{}, false, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	_, err = sopsconfig.LoadCreationRuleForFile(configPath, absPath, nil)
+	if isNoMatchingRuleError(err) {
+		return fileStatus{}, false, nil
+	}
+
+	if err != nil {
+		return fileStatus{}, false, fmt.Errorf("failed to load %s: %w", configPath, err)
+	}
+
+	inputStore, _, err := getStores(path)
+	if err != nil {
+		return fileStatus{}, false, err
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from walking the scanned directory
+	if err != nil {
+		return fileStatus{}, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	tree, err := inputStore.LoadEncryptedFile(content)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return fileStatus{path: path, status: statusPlaintext}, true, nil
+	}
+
+	if err != nil {
+		return fileStatus{}, false, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	return fileStatus{
+		path:       path,
+		status:     statusEncrypted,
+		recipients: recipientsOf(tree.Metadata.KeyGroups),
+	}, true, nil
+}
+
+// recipientsOf returns the distinct master keys of keyGroups, e.g. age recipients or KMS
+// ARNs, in order of appearance.
+func recipientsOf(keyGroups []sops.KeyGroup) []string {
+	recipients := []string{}
+
+	for _, group := range keyGroups {
+		for _, key := range group {
+			recipient := key.ToString()
+			if !slices.Contains(recipients, recipient) {
+				recipients = append(recipients, recipient)
+			}
+		}
+	}
+
+	return recipients
+}
+
+// isNoMatchingRuleError reports whether err is the error SOPS returns when no creation
+// rule matches a file. SOPS does not export a sentinel for it.
+func isNoMatchingRuleError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no matching creation rules")
+}
@@ -0,0 +1,84 @@
+package cipher_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/cipher"
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+)
+
+func TestStatusReportsPlaintextFilesMatchingRules(t *testing.T) {
+	t.Parallel()
+
+	stubRuntime := runtime.New(stubs.CipherModule())
+	dir := t.TempDir()
+	recipient := newAgeRecipient(t)
+	files := writeFiles(t, dir, map[string]string{
+		".sops.yaml": "creation_rules:\n" +
+			"  - path_regex: \\.enc\\.yaml$\n" +
+			"    age: " + recipient + "\n",
+		"db.enc.yaml":  rotateSecret,
+		"web.enc.yaml": rotateSecret,
+		"app.yaml":     "replicas: 1\n",
+	})
+
+	runCipher(t, stubRuntime, "encrypt", files["db.enc.yaml"])
+
+	out, err := runStatus(dir)
+	if err == nil || !strings.Contains(err.Error(), "1 files matching creation rules are not encrypted") {
+		t.Errorf("expected a plaintext violation, got: %v", err)
+	}
+
+	for _, want := range []string{
+		"encrypted  " + files["db.enc.yaml"] + "   " + recipient,
+		"plaintext  " + files["web.enc.yaml"] + "  -",
+		"1 of 2 files matching " + files[".sops.yaml"] + " are encrypted",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, files["app.yaml"]) {
+		t.Errorf("expected files without a matching rule to be left out, got:\n%s", out)
+	}
+
+	runCipher(t, stubRuntime, "encrypt", files["web.enc.yaml"])
+
+	out, err = runStatus(dir)
+	if err != nil {
+		t.Errorf("expected no violations once every file is encrypted, got: %v", err)
+	}
+
+	if !strings.Contains(out, "2 of 2 files matching") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+}
+
+func TestStatusRequiresSopsConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"secret.yaml": "password: hunter2\n"})
+
+	_, err := runStatus(dir)
+	if err == nil || !strings.Contains(err.Error(), "no .sops.yaml found") {
+		t.Errorf("expected an error for a missing SOPS config, got: %v", err)
+	}
+}
+
+func runStatus(args ...string) (string, error) {
+	var out bytes.Buffer
+
+	cmd := cipher.NewCipherCmd(runtime.New(stubs.CipherModule()))
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"status"}, args...))
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	argocdinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argocd"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+)
+
+// installArgoCDIfConfigured installs ArgoCD with the Application that syncs the source
+// directory. A project pushed to Gitea during create is synced right away; artifacts of the
+// local registry only exist once `ksail workload reconcile` pushes them, which then creates
+// the Application.
+func installArgoCDIfConfigured(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+) error {
+	if !clusterCfg.Spec.Options.ArgoCD.Enabled {
+		return nil
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Install ArgoCD...",
+		Emoji:   "🐙",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	argoCDInstaller, err := newArgoCDInstallerForCluster(cmd, clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "installing argocd",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = audit.Track(cmd.Context(), audit.ActionComponentInstall, "argocd", func() error {
+		return argoCDInstaller.Install(cmd.Context())
+	})
+	if err != nil {
+		return fmt.Errorf("argocd installation failed: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "argocd installed",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// newArgoCDInstallerForCluster returns an ArgoCD installer that decrypts with the local age
+// keys, and bootstraps the project Application when its source is in Gitea. Otherwise the
+// local registry is registered as a repository for the Application created on reconcile.
+func newArgoCDInstallerForCluster(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+) (*argocdinstaller.ArgoCDInstaller, error) {
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepArgoCD)
+	if err != nil {
+		return nil, err
+	}
+
+	argoCDInstaller := argocdinstaller.NewArgoCDInstaller(
+		helmClient,
+		installer.GetComponentTimeout(clusterCfg, createStepArgoCD),
+	)
+	argoCDInstaller.SetVersion(clusterCfg.Spec.Options.ArgoCD.Version)
+
+	ageKeys, err := ciphersvc.LoadAgeKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load age keys: %w", err)
+	}
+
+	argoCDInstaller.SetDecryption(ageKeys)
+
+	application := argocdinstaller.ProjectApplication(clusterCfg)
+
+	switch {
+	case clusterCfg.Spec.Options.Gitea.Enabled:
+		dynamicClient, err := newDynamicClientForCluster(cmd, clusterCfg, kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+
+		argoCDInstaller.SetApplication(dynamicClient, application)
+	case clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled:
+		argoCDInstaller.SetLocalRegistryRepository(application.RepoURL)
+	}
+
+	return argoCDInstaller, nil
+}
+
+// uninstallArgoCD removes the project Application, then the ArgoCD release.
+func uninstallArgoCD(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepArgoCD)
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := newDynamicClientForCluster(cmd, clusterCfg, kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	argoCDInstaller := argocdinstaller.NewArgoCDInstaller(
+		helmClient,
+		installer.GetComponentTimeout(clusterCfg, createStepArgoCD),
+	)
+	argoCDInstaller.SetApplication(dynamicClient, argocdinstaller.ProjectApplication(clusterCfg))
+
+	//nolint:wrapcheck // The installer wraps its errors with the resource it failed to remove.
+	return argoCDInstaller.Uninstall(cmd.Context())
+}
+
+// newDynamicClientForCluster returns a dynamic client for the cluster's context in kubeconfig.
+func newDynamicClientForCluster(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	kubeconfig string,
+) (*dynamic.DynamicClient, error) {
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return dynamicClient, nil
+}
@@ -0,0 +1,483 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	argorolloutsinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argo-rollouts"
+	cnpginstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cnpg"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	gatewayapiinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gateway-api"
+	giteainstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gitea"
+	headlampinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/headlamp"
+	kyvernoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/kyverno"
+	localstackinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/localstack"
+	metallbinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metallb"
+	metricsserverinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metrics-server"
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/spf13/cobra"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/kubernetes"
+)
+
+// componentCleanup removes one component for ksail cluster clean.
+type componentCleanup struct {
+	name string
+	// enabled reports whether cleaning without arguments removes the component, which is the
+	// case for the components create installs.
+	enabled   bool
+	uninstall func(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error
+}
+
+// NewCleanCmd creates the clean command, which removes components KSail installed from a
+// running cluster.
+func NewCleanCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean [component...]",
+		Short: "Remove the components KSail installed from a running cluster",
+		Long: `Uninstall components that ksail cluster create installed, keeping the cluster running. ` +
+			`Components are removed before the components they depend on, and independent ` +
+			`components are removed concurrently.
+
+Without arguments every component enabled in ksail.yaml is removed, except the CNI, which the ` +
+			`cluster needs for pod networking. Name components to remove only those, including ` +
+			`components that are no longer enabled.
+
+Components: ` + strings.Join(cleanComponents(), ", ") + ` and plugin/<name> for plugins.`,
+		ValidArgs:    cleanComponents(),
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleCleanRunE)
+
+	cmdhelpers.MarkAudited(cmd, "cluster.clean")
+
+	return cmd
+}
+
+func handleCleanRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	deps.Timer.Start()
+
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	// Simulated clusters get no components to remove
+	if clusterCfg.Spec.Distribution.IsSimulated() {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "no components to clean in simulated %s cluster",
+			Args:    []any{clusterCfg.Spec.Distribution},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
+	}
+
+	cleanups, err := selectComponentCleanups(clusterCfg, cmd.Flags().Args())
+	if err != nil {
+		return err
+	}
+
+	if len(cleanups) == 0 {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "no components to clean",
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
+	}
+
+	pipeline, err := newCleanPipeline(cmd, clusterCfg, deps.Timer, cleanups)
+	if err != nil {
+		return err
+	}
+
+	err = pipeline.Run(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to clean components: %w", err)
+	}
+
+	return nil
+}
+
+// newCleanPipeline returns a pipeline that uninstalls cleanups in the reverse order create
+// installs them in.
+func newCleanPipeline(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	tmr timer.Timer,
+	cleanups map[string]componentCleanup,
+) (*installer.Pipeline, error) {
+	firstActivityShown := false
+	output := &componentOutput{cmd: cmd, tmr: tmr, firstActivityShown: &firstActivityShown}
+
+	// Helm clients of concurrent steps would otherwise overwrite each other's pins
+	cmdhelpers.SharePins(cmd)
+
+	var steps []installer.Step
+
+	// Every step is planned, so components that are kept still order the ones removed around them
+	for _, component := range postCreationSteps(clusterCfg, nil) {
+		cleanup := cleanups[component.name]
+
+		steps = append(steps, installer.Step{
+			Name:      component.name,
+			DependsOn: component.dependsOn,
+			Run: func(context.Context) error {
+				return output.run(component.name, func(cmd *cobra.Command, tmr timer.Timer, shown *bool) error {
+					return uninstallComponent(cmd, clusterCfg, cleanup, tmr, shown)
+				})
+			},
+		})
+	}
+
+	steps = installer.SelectSteps(steps, func(name string) bool {
+		_, ok := cleanups[name]
+
+		return ok
+	})
+
+	pipeline, err := installer.NewPipeline(
+		installer.ReverseDependencies(steps),
+		installer.WithMaxConcurrency(maxConcurrentInstalls),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan component removal: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+// selectComponentCleanups returns the cleanups of the named components, or of every enabled
+// component when no names are given.
+func selectComponentCleanups(
+	clusterCfg *v1alpha1.Cluster,
+	names []string,
+) (map[string]componentCleanup, error) {
+	available := componentCleanups(clusterCfg)
+	selected := make(map[string]componentCleanup, len(available))
+
+	if len(names) == 0 {
+		for _, cleanup := range available {
+			if cleanup.enabled {
+				selected[cleanup.name] = cleanup
+			}
+		}
+
+		return selected, nil
+	}
+
+	for _, name := range names {
+		index := slices.IndexFunc(available, func(cleanup componentCleanup) bool {
+			return strings.EqualFold(cleanup.name, name)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %s (supported: %s and plugin/<name>)", ErrUnknownComponent, name,
+				strings.Join(cleanComponents(), ", "))
+		}
+
+		selected[available[index].name] = available[index]
+	}
+
+	return selected, nil
+}
+
+// cleanComponents returns the names of KSail's own components ksail cluster clean removes.
+func cleanComponents() []string {
+	var components []string
+
+	for _, component := range postCreationSteps(&v1alpha1.Cluster{}, nil) {
+		components = append(components, component.name)
+	}
+
+	return components
+}
+
+// componentCleanups returns how to remove every component create can install, in the order of
+// postCreationSteps, marking the ones clusterCfg enables.
+//
+//nolint:funlen // One entry per component keeps the list next to the conditions it mirrors.
+func componentCleanups(clusterCfg *v1alpha1.Cluster) []componentCleanup {
+	options := clusterCfg.Spec.Options
+
+	cleanups := []componentCleanup{
+		{
+			name:      createStepGatewayAPI,
+			enabled:   options.GatewayAPI.Enabled,
+			uninstall: uninstallGatewayAPI,
+		},
+		{
+			// The CNI is only removed when named, as the cluster has no pod networking without it
+			name:      createStepCNI,
+			uninstall: uninstallCustomCNI,
+		},
+		{
+			name: createStepMetricsServer,
+			enabled: clusterCfg.Spec.MetricsServer == v1alpha1.MetricsServerEnabled &&
+				!clusterCfg.Spec.Distribution.ProvidesMetricsServerByDefault(),
+			uninstall: helmUninstall(createStepMetricsServer,
+				func(client helm.Interface, kubeconfig string, timeout time.Duration) installer.Installer {
+					return metricsserverinstaller.NewMetricsServerInstaller(
+						client, kubeconfig, clusterCfg.Spec.Connection.Context, timeout,
+					)
+				}),
+		},
+		{
+			name:    createStepMetalLB,
+			enabled: options.MetalLB.Enabled,
+			uninstall: helmUninstall(createStepMetalLB,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return metallbinstaller.NewMetalLBInstaller(client, nil, options.MetalLB.Addresses, timeout)
+				}),
+		},
+		{
+			name:    createStepGitea,
+			enabled: options.Gitea.Enabled,
+			uninstall: helmUninstall(createStepGitea,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return giteainstaller.NewGiteaInstaller(client, timeout)
+				}),
+		},
+		{
+			name:      createStepLocalStack,
+			enabled:   options.LocalStack.Enabled,
+			uninstall: uninstallLocalStack,
+		},
+		{
+			name:    createStepVault,
+			enabled: options.Vault.Enabled,
+			uninstall: helmUninstall(createStepVault,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return vaultinstaller.NewVaultInstaller(client, nil, timeout)
+				}),
+		},
+		{
+			name:    createStepCloudNativePG,
+			enabled: options.CloudNativePG.Enabled,
+			uninstall: helmUninstall(createStepCloudNativePG,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return cnpginstaller.NewCNPGInstaller(client, timeout)
+				}),
+		},
+		{
+			name:    createStepArgoRollouts,
+			enabled: options.ArgoRollouts.Enabled,
+			uninstall: helmUninstall(createStepArgoRollouts,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return argorolloutsinstaller.NewArgoRolloutsInstaller(client, timeout)
+				}),
+		},
+		{
+			name:    createStepHeadlamp,
+			enabled: options.Headlamp.Enabled,
+			uninstall: helmUninstall(createStepHeadlamp,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return headlampinstaller.NewHeadlampInstaller(client, timeout)
+				}),
+		},
+		{
+			name:    createStepKyverno,
+			enabled: options.Kyverno.Enabled,
+			uninstall: helmUninstall(createStepKyverno,
+				func(client helm.Interface, _ string, timeout time.Duration) installer.Installer {
+					return kyvernoinstaller.NewKyvernoInstaller(client, timeout)
+				}),
+		},
+		{
+			name:      createStepFlux,
+			enabled:   clusterCfg.Spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux,
+			uninstall: uninstallFlux,
+		},
+		{
+			name:      createStepArgoCD,
+			enabled:   options.ArgoCD.Enabled,
+			uninstall: uninstallArgoCD,
+		},
+	}
+
+	for _, plugin := range clusterCfg.Spec.Plugins {
+		cleanups = append(cleanups, componentCleanup{
+			name:    pluginStepPrefix + plugin.Name,
+			enabled: true,
+			uninstall: func(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+				pluginInstaller, err := newPluginInstaller(clusterCfg, plugin)
+				if err != nil {
+					return err
+				}
+
+				return pluginInstaller.Uninstall(cmd.Context())
+			},
+		})
+	}
+
+	return cleanups
+}
+
+// uninstallComponent removes a component in its own stage.
+func uninstallComponent(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	cleanup componentCleanup,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+) error {
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Uninstall %s...",
+		Args:    []any{cleanup.name},
+		Emoji:   "🗑️",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "uninstalling %s",
+		Args:    []any{cleanup.name},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err := audit.Track(cmd.Context(), audit.ActionComponentUninstall, cleanup.name, func() error {
+		return cleanup.uninstall(cmd, clusterCfg)
+	})
+	if err != nil {
+		return fmt.Errorf("%s uninstallation failed: %w", cleanup.name, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%s uninstalled",
+		Args:    []any{cleanup.name},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// helmUninstall returns an uninstall of a component whose installer only needs a Helm client.
+func helmUninstall(
+	component string,
+	newInstaller func(client helm.Interface, kubeconfig string, timeout time.Duration) installer.Installer,
+) func(*cobra.Command, *v1alpha1.Cluster) error {
+	return func(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+		helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, component)
+		if err != nil {
+			return err
+		}
+
+		timeout := installer.GetComponentTimeout(clusterCfg, component)
+
+		//nolint:wrapcheck // Installers wrap their errors with the release they failed to remove.
+		return newInstaller(helmClient, kubeconfig, timeout).Uninstall(cmd.Context())
+	}
+}
+
+func uninstallGatewayAPI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create apiextensions client: %w", err)
+	}
+
+	//nolint:wrapcheck // The installer wraps its errors with the CRD it failed to delete.
+	return gatewayapiinstaller.NewGatewayAPIInstaller(
+		clientset.ApiextensionsV1().CustomResourceDefinitions(),
+		gatewayAPIManifest(clusterCfg.Spec.Options.GatewayAPI),
+		installer.GetComponentTimeout(clusterCfg, createStepGatewayAPI),
+	).Uninstall(cmd.Context())
+}
+
+// uninstallCustomCNI removes Cilium or Calico. The default CNI is part of the distribution.
+func uninstallCustomCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	switch clusterCfg.Spec.CNI {
+	case v1alpha1.CNICilium, v1alpha1.CNICalico:
+		return uninstallCNI(cmd, clusterCfg, nil, clusterCfg.Spec.CNI)
+	default:
+		return fmt.Errorf("%w; KSail did not install it", errDefaultCNIBuiltIn)
+	}
+}
+
+func uninstallLocalStack(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepLocalStack)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	//nolint:wrapcheck // The installer wraps its errors with the resource it failed to remove.
+	return localstackinstaller.NewLocalStackInstaller(
+		helmClient,
+		clientset,
+		clusterCfg.Spec.Options.LocalStack.Endpoint,
+		installer.GetComponentTimeout(clusterCfg, createStepLocalStack),
+	).Uninstall(cmd.Context())
+}
+
+// uninstallFlux deletes the FluxInstance, so the operator removes the Flux controllers, then
+// uninstalls the operator.
+func uninstallFlux(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepFlux)
+	if err != nil {
+		return err
+	}
+
+	timeout := installer.GetComponentTimeout(clusterCfg, createStepFlux)
+
+	err = fluxinstaller.RemoveDefaultResources(cmd.Context(), kubeconfig, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to remove Flux resources: %w", err)
+	}
+
+	//nolint:wrapcheck // The installer wraps its errors with the release it failed to remove.
+	return fluxInstallerFactory(helmClient, clusterCfg).Uninstall(cmd.Context())
+}
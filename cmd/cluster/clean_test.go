@@ -0,0 +1,41 @@
+package cluster_test
+
+import (
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestCleanCmdWithoutEnabledComponentsRemovesNothing(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t, cmdtestutils.WithTempProjectConfig(
+		func(cluster *v1alpha1.Cluster) {
+			cluster.Spec.MetricsServer = v1alpha1.MetricsServerDisabled
+		},
+	))
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewCleanCmd(runtime.NewRuntime()))
+	require.NoError(t, result.Err)
+	assert.Contains(t, result.Stdout, "no components to clean")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestCleanCmdRejectsUnknownComponents(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(
+		t,
+		clusterpkg.NewCleanCmd(runtime.NewRuntime()),
+		"vault",
+		"plugin/platform-charts",
+	)
+	require.ErrorIs(t, result.Err, clusterpkg.ErrUnknownComponent)
+	assert.Contains(t, result.Err.Error(), "plugin/platform-charts")
+}
@@ -23,9 +23,15 @@ func NewClusterCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd.AddCommand(NewDeleteCmd(runtimeContainer))
 	cmd.AddCommand(NewStartCmd(runtimeContainer))
 	cmd.AddCommand(NewStopCmd(runtimeContainer))
+	cmd.AddCommand(NewScaleCmd(runtimeContainer))
+	cmd.AddCommand(NewSetCmd(runtimeContainer))
+	cmd.AddCommand(NewCleanCmd(runtimeContainer))
 	cmd.AddCommand(NewListCmd(runtimeContainer))
 	cmd.AddCommand(NewInfoCmd(runtimeContainer))
 	cmd.AddCommand(NewConnectCmd(runtimeContainer))
+	cmd.AddCommand(NewOpenCmd(runtimeContainer))
+	cmd.AddCommand(NewExportCmd(runtimeContainer))
+	cmd.AddCommand(NewDoctorCmd(runtimeContainer))
 
 	return cmd
 }
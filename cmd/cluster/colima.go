@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/colima"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+// startColimaVM starts the Colima VM configured in spec.options.colima and points Docker
+// clients at its socket, unless spec.dockerHost selects another Docker host.
+// It does nothing when Colima management is disabled.
+func startColimaVM(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	firstActivityShown *bool,
+) error {
+	options := clusterCfg.Spec.Options.Colima
+	if !options.Enabled {
+		return nil
+	}
+
+	deps.Timer.NewStage()
+
+	if *firstActivityShown {
+		cmd.Println()
+	}
+
+	*firstActivityShown = true
+
+	profile := colima.ProfileName(options.Profile)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Start Colima VM...",
+		Emoji:   "🐳",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "starting profile '%s'",
+		Args:    []any{profile},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	started, err := colima.NewService().Start(cmd.Context(), colima.VM{
+		Profile: profile,
+		CPUs:    options.CPUs,
+		Memory:  options.Memory,
+		Disk:    options.Disk,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start colima VM: %w", err)
+	}
+
+	err = useColimaDockerHost(clusterCfg, profile)
+	if err != nil {
+		return err
+	}
+
+	content := "colima VM started"
+	if !started {
+		content = "colima VM already running"
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: content,
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// stopColimaVM stops the Colima VM after the cluster is deleted, warning instead of failing
+// so the VM never blocks deleting the cluster.
+func stopColimaVM(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, deps cmdhelpers.LifecycleDeps) {
+	options := clusterCfg.Spec.Options.Colima
+	if !options.Enabled {
+		return
+	}
+
+	deps.Timer.NewStage()
+
+	cmd.Println()
+
+	profile := colima.ProfileName(options.Profile)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Stop Colima VM...",
+		Emoji:   "🐳",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "stopping profile '%s'",
+		Args:    []any{profile},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err := colima.NewService().Stop(cmd.Context(), profile)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: fmt.Sprintf("failed to stop colima VM: %v", err),
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "colima VM stopped",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+}
+
+// useColimaDockerHost points Docker clients at the socket of the Colima profile when no
+// Docker host is configured explicitly.
+func useColimaDockerHost(clusterCfg *v1alpha1.Cluster, profile string) error {
+	if strings.TrimSpace(clusterCfg.Spec.DockerHost) != "" {
+		return nil
+	}
+
+	host, err := colima.DockerHost(profile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve colima docker socket: %w", err)
+	}
+
+	err = os.Setenv(client.EnvOverrideHost, host)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", client.EnvOverrideHost, err)
+	}
+
+	return nil
+}
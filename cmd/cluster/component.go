@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	argorolloutsinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argo-rollouts"
+	cnpginstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cnpg"
+	headlampinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/headlamp"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/spf13/cobra"
+)
+
+// componentFactory creates the installer of an optional component with the Helm client and
+// kubeconfig resolved for the cluster, and the component's install timeout.
+type componentFactory func(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	helmClient *helm.Client,
+	kubeconfig string,
+	timeout time.Duration,
+) (installer.Installer, error)
+
+// optionalComponent is a component create installs in its own stage when spec.options enables
+// it.
+type optionalComponent struct {
+	// name identifies the component in the audit log and in errors.
+	name    string
+	title   string
+	emoji   string
+	enabled func(*v1alpha1.Cluster) bool
+	// activity and success describe the install before it starts and once it succeeded.
+	activity func(*v1alpha1.Cluster) string
+	success  func(*v1alpha1.Cluster) string
+	factory  componentFactory
+}
+
+// optionalComponents are the components installComponent installs, keyed by their create step,
+// which also selects their timeout and retry policy.
+//
+//nolint:gochecknoglobals // read-only table of the optional components
+var optionalComponents = map[string]optionalComponent{
+	createStepGitea:      giteaComponent,
+	createStepLocalStack: localStackComponent,
+	createStepVault:      vaultComponent,
+	// The operator provisions the PostgreSQL clusters of the source directory once the GitOps
+	// engine applies them.
+	createStepCloudNativePG: {
+		name:  "cloudnative-pg",
+		title: "Install CloudNativePG...",
+		emoji: "🐘",
+		enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+			return clusterCfg.Spec.Options.CloudNativePG.Enabled
+		},
+		activity: message("installing cloudnative-pg operator"),
+		success:  message("cloudnative-pg operator installed"),
+		factory: func(_ *cobra.Command, clusterCfg *v1alpha1.Cluster, helmClient *helm.Client, _ string,
+			timeout time.Duration,
+		) (installer.Installer, error) {
+			cnpgInstaller := cnpginstaller.NewCNPGInstaller(helmClient, timeout)
+			cnpgInstaller.SetVersion(clusterCfg.Spec.Options.CloudNativePG.Version)
+
+			return cnpgInstaller, nil
+		},
+	},
+	// The controller reconciles the Rollout resources of the source directory once the GitOps
+	// engine applies them.
+	createStepArgoRollouts: {
+		name:  "argo-rollouts",
+		title: "Install Argo Rollouts...",
+		emoji: "🚦",
+		enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+			return clusterCfg.Spec.Options.ArgoRollouts.Enabled
+		},
+		activity: message("installing argo-rollouts controller"),
+		success:  message("argo-rollouts controller installed"),
+		factory: func(_ *cobra.Command, clusterCfg *v1alpha1.Cluster, helmClient *helm.Client, _ string,
+			timeout time.Duration,
+		) (installer.Installer, error) {
+			rolloutsInstaller := argorolloutsinstaller.NewArgoRolloutsInstaller(helmClient, timeout)
+			rolloutsInstaller.SetVersion(clusterCfg.Spec.Options.ArgoRollouts.Version)
+
+			return rolloutsInstaller, nil
+		},
+	},
+	// The dashboard is what `ksail cluster open headlamp` opens in the browser.
+	createStepHeadlamp: {
+		name:  "headlamp",
+		title: "Install Headlamp...",
+		emoji: "📊",
+		enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+			return clusterCfg.Spec.Options.Headlamp.Enabled
+		},
+		activity: message("installing headlamp"),
+		success:  message("headlamp installed"),
+		factory: func(_ *cobra.Command, clusterCfg *v1alpha1.Cluster, helmClient *helm.Client, _ string,
+			timeout time.Duration,
+		) (installer.Installer, error) {
+			headlampInstaller := headlampinstaller.NewHeadlampInstaller(helmClient, timeout)
+			headlampInstaller.SetVersion(clusterCfg.Spec.Options.Headlamp.Version)
+
+			return headlampInstaller, nil
+		},
+	},
+	createStepKyverno: kyvernoComponent,
+}
+
+// installComponentStep returns the create step install function of the optional component
+// registered for step.
+func installComponentStep(step string) func(*cobra.Command, *v1alpha1.Cluster, timer.Timer, *bool) error {
+	return func(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer, firstActivityShown *bool) error {
+		return installComponent(cmd, clusterCfg, tmr, firstActivityShown, step)
+	}
+}
+
+// installComponent installs the optional component registered for step in its own stage,
+// unless the configuration leaves it disabled.
+func installComponent(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+	step string,
+) error {
+	component := optionalComponents[step]
+	if !component.enabled(clusterCfg) {
+		return nil
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: component.title,
+		Emoji:   component.emoji,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, step)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: component.activity(clusterCfg),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	componentInstaller, err := component.factory(
+		cmd,
+		clusterCfg,
+		helmClient,
+		kubeconfig,
+		installer.GetComponentTimeout(clusterCfg, step),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = audit.Track(cmd.Context(), audit.ActionComponentInstall, component.name, func() error {
+		return componentInstaller.Install(cmd.Context())
+	})
+	if err != nil {
+		return fmt.Errorf("%s installation failed: %w", component.name, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: component.success(clusterCfg),
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// message returns a component message that does not depend on the configuration.
+func message(content string) func(*v1alpha1.Cluster) string {
+	return func(*v1alpha1.Cluster) string {
+		return content
+	}
+}
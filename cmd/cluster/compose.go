@@ -0,0 +1,148 @@
+package cluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	composesvc "github.com/devantler-tech/ksail-go/pkg/svc/compose"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// startComposeServices starts the sidecar services from spec.composeFile on the cluster network.
+// configFile is the ksail.yaml the configuration was loaded from, if any.
+func startComposeServices(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	configFile string,
+	kindConfig *kindv1alpha4.Cluster,
+	k3dConfig *k3dv1alpha5.SimpleConfig,
+	firstActivityShown *bool,
+) error {
+	project, ok, err := newComposeProject(clusterCfg, configFile, kindConfig, k3dConfig)
+	if !ok || err != nil {
+		return err
+	}
+
+	deps.Timer.NewStage()
+
+	if *firstActivityShown {
+		cmd.Println()
+	}
+
+	*firstActivityShown = true
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Start compose services...",
+		Emoji:   "🐳",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "starting '%s' on network '%s'",
+		Args:    []any{project.Name, project.Network},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = composesvc.NewService().Up(cmd.Context(), project)
+	if err != nil {
+		return fmt.Errorf("failed to start compose services: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "compose services started",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// stopComposeServices removes the sidecar services started by startComposeServices.
+// Callers only invoke it when spec.composeFile is set.
+func stopComposeServices(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	configFile string,
+) error {
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err != nil {
+		return err
+	}
+
+	project, _, err := newComposeProject(clusterCfg, configFile, kindConfig, k3dConfig)
+	if err != nil {
+		return err
+	}
+
+	deps.Timer.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Stop compose services...",
+		Emoji:   "🐳",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "removing '%s'",
+		Args:    []any{project.Name},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = composesvc.NewService().Down(cmd.Context(), project)
+	if err != nil {
+		return fmt.Errorf("failed to stop compose services: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "compose services stopped",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// newComposeProject describes the compose project for the cluster.
+// It reports false when ksail.yaml does not reference a compose file. A relative compose file
+// is resolved against the directory of configFile, or the working directory without one.
+func newComposeProject(
+	clusterCfg *v1alpha1.Cluster,
+	configFile string,
+	kindConfig *kindv1alpha4.Cluster,
+	k3dConfig *k3dv1alpha5.SimpleConfig,
+) (composesvc.Project, bool, error) {
+	file := strings.TrimSpace(clusterCfg.Spec.ComposeFile)
+	if file == "" {
+		return composesvc.Project{}, false, nil
+	}
+
+	if !filepath.IsAbs(file) && configFile != "" {
+		file = filepath.Join(filepath.Dir(configFile), file)
+	}
+
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return composesvc.Project{}, false, fmt.Errorf("failed to resolve compose file path: %w", err)
+	}
+
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+
+	return composesvc.Project{
+		Name:    composesvc.ProjectName(clusterName),
+		File:    file,
+		Network: clusterprovisioner.DockerNetworkName(clusterCfg, clusterName),
+	}, true, nil
+}
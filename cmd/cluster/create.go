@@ -2,26 +2,24 @@ package cluster
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail-go/pkg/client/helm"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
 	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
 	kindconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kind"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/io/wsl"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
-	calicoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/calico"
-	ciliuminstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/cilium"
-	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
-	metricsserverinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metrics-server"
+	coreinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/core"
 	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
 	k3dprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k3d"
 	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
@@ -37,12 +35,14 @@ import (
 const (
 	// k3sDisableMetricsServerFlag is the K3s flag to disable metrics-server.
 	k3sDisableMetricsServerFlag = "--disable=metrics-server"
-	fluxResourcesActivity       = "applying custom resources"
 	fluxResourcesSuccess        = "flux installed"
 )
 
 // ErrUnsupportedCNI is returned when an unsupported CNI type is encountered.
-var ErrUnsupportedCNI = errors.New("unsupported CNI type")
+var ErrUnsupportedCNI = coreinstaller.ErrUnsupportedCNI
+
+// maxConcurrentInstalls bounds how many components are installed at the same time.
+const maxConcurrentInstalls = 4
 
 // newCreateLifecycleConfig creates the lifecycle configuration for cluster creation.
 func newCreateLifecycleConfig() cmdhelpers.LifecycleConfig {
@@ -81,8 +81,18 @@ func NewCreateCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 			"Configure mirror registries with format 'host=upstream' (e.g., docker.io=https://registry-1.docker.io)")
 	_ = cfgManager.Viper.BindPFlag("mirror-registry", cmd.Flags().Lookup("mirror-registry"))
 
+	cmd.Flags().Bool(prePullImagesFlag, true,
+		"Pull component images through the mirror registries while the cluster nodes boot")
+	cmdhelpers.AddRefreshChecksumsFlag(cmd)
+	cmdhelpers.AddFrozenLockfileFlag(cmd)
+	cmd.Flags().Bool(resumeFlag, true,
+		"Skip steps that a previous, partially failed create of the same configuration completed")
+	addDryRunFlags(cmd)
+
 	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleCreateRunE)
 
+	cmdhelpers.MarkAudited(cmd, "cluster.create")
+
 	return cmd
 }
 
@@ -96,7 +106,22 @@ func handleCreateRunE(
 
 	outputTimer := cmdhelpers.MaybeTimer(cmd, deps.Timer)
 
-	clusterCfg, kindConfig, k3dConfig, err := loadClusterConfiguration(cfgManager, outputTimer)
+	clusterCfg, kindConfig, k3dConfig, err := LoadClusterConfiguration(cfgManager, outputTimer)
+	if err != nil {
+		return err
+	}
+
+	dryRun, _ := cmd.Flags().GetBool(dryRunFlag)
+	if dryRun {
+		return renderComponents(cmd, clusterCfg)
+	}
+
+	err = useDockerHost(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	err = lockClusterImages(cmd, clusterCfg, kindConfig, k3dConfig)
 	if err != nil {
 		return err
 	}
@@ -104,6 +129,19 @@ func handleCreateRunE(
 	// Track whether first activity has been shown to manage blank line spacing
 	firstActivityShown := false
 
+	// Stages that run concurrently overlap, so their durations are reported per stage
+	breakdown := timer.NewBreakdown()
+
+	err = startColimaVM(cmd, clusterCfg, deps, &firstActivityShown)
+	if err != nil {
+		return err
+	}
+
+	err = ensureDockerNetwork(cmd, clusterCfg, deps, kindConfig, k3dConfig, &firstActivityShown)
+	if err != nil {
+		return err
+	}
+
 	err = ensureLocalRegistriesReady(
 		cmd,
 		clusterCfg,
@@ -111,47 +149,141 @@ func handleCreateRunE(
 		cfgManager,
 		kindConfig,
 		k3dConfig,
+		breakdown,
 		&firstActivityShown,
 	)
 	if err != nil {
 		return err
 	}
 
+	// Warm the mirror registries with component images while the nodes boot
+	prePull := startImagePrePull(cmd, clusterCfg)
+	defer prePull.stop()
+
 	// Configure metrics-server for K3d before cluster creation
 	setupK3dMetricsServer(clusterCfg, k3dConfig)
 
-	err = executeClusterLifecycle(cmd, clusterCfg, deps, &firstActivityShown)
+	createSteps := loadCreateProgress(cmd, clusterCfg, deps, cfgManager.Viper.ConfigFileUsed())
+
+	err = createSteps.run(createStepCluster, func() error {
+		defer breakdown.Track("cluster")()
+
+		return executeClusterLifecycle(cmd, clusterCfg, deps, &firstActivityShown)
+	})
 	if err != nil {
 		return err
 	}
 
-	connectMirrorRegistriesWithWarning(
+	err = openRemoteTunnel(cmd, clusterCfg, deps, kindConfig, k3dConfig, &firstActivityShown)
+	if err != nil {
+		return err
+	}
+
+	err = createSteps.run(createStepRegistries, func() error {
+		defer breakdown.Track("registry connect")()
+
+		connectMirrorRegistriesWithWarning(
+			cmd,
+			clusterCfg,
+			deps,
+			cfgManager,
+			kindConfig,
+			k3dConfig,
+			&firstActivityShown,
+		)
+
+		err := executeLocalRegistryStage(
+			cmd,
+			clusterCfg,
+			deps,
+			kindConfig,
+			k3dConfig,
+			localRegistryStageConnect,
+			&firstActivityShown,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect local registry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = startComposeServices(
 		cmd,
 		clusterCfg,
 		deps,
-		cfgManager,
+		cfgManager.Viper.ConfigFileUsed(),
 		kindConfig,
 		k3dConfig,
 		&firstActivityShown,
 	)
+	if err != nil {
+		return err
+	}
+
+	prePull.wait(cmd, deps.Timer, &firstActivityShown)
 
-	err = executeLocalRegistryStage(
+	err = handlePostCreationSetup(
 		cmd,
 		clusterCfg,
-		deps,
-		kindConfig,
-		k3dConfig,
-		localRegistryStageConnect,
+		deps.Timer,
+		createSteps,
+		breakdown,
 		&firstActivityShown,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to connect local registry: %w", err)
+		return err
+	}
+
+	createSteps.finish()
+
+	showStageBreakdown(cmd, deps.Timer, breakdown)
+
+	showWSLKubeconfigHint(cmd, clusterCfg)
+
+	return nil
+}
+
+// showStageBreakdown reports how long each stage of the run took when timing is enabled.
+func showStageBreakdown(cmd *cobra.Command, tmr timer.Timer, breakdown *timer.Breakdown) {
+	if cmdhelpers.MaybeTimer(cmd, tmr) == nil || len(breakdown.Stages()) == 0 {
+		return
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "stage breakdown: %s",
+		Args:    []any{breakdown.String()},
+		Writer:  cmd.OutOrStdout(),
+	})
+}
+
+// showWSLKubeconfigHint tells WSL2 users where Windows tools find the cluster's kubeconfig.
+func showWSLKubeconfigHint(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) {
+	if !wsl.Detect() {
+		return
+	}
+
+	kubeconfigPath, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return
 	}
 
-	return handlePostCreationSetup(cmd, clusterCfg, deps.Timer, &firstActivityShown)
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "WSL2 detected: Windows tools can use the kubeconfig at %s",
+		Args:    []any{wsl.ToWindowsPath(kubeconfigPath, os.Getenv(wsl.DistroNameEnvVar))},
+		Writer:  cmd.OutOrStdout(),
+	})
 }
 
-func loadClusterConfiguration(
+// LoadClusterConfiguration loads ksail.yaml through cfgManager along with the Kind or K3d
+// configuration of its distribution.
+func LoadClusterConfiguration(
 	cfgManager *ksailconfigmanager.ConfigManager,
 	tmr timer.Timer,
 ) (*v1alpha1.Cluster, *v1alpha4.Cluster, *v1alpha5.SimpleConfig, error) {
@@ -168,6 +300,8 @@ func loadClusterConfiguration(
 	return clusterCfg, kindConfig, k3dConfig, nil
 }
 
+// ensureLocalRegistriesReady creates the local and mirror registries and pulls the node
+// image. The three are independent of each other, so they run concurrently.
 func ensureLocalRegistriesReady(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
@@ -175,35 +309,54 @@ func ensureLocalRegistriesReady(
 	cfgManager *ksailconfigmanager.ConfigManager,
 	kindConfig *v1alpha4.Cluster,
 	k3dConfig *v1alpha5.SimpleConfig,
+	breakdown *timer.Breakdown,
 	firstActivityShown *bool,
 ) error {
-	err := executeLocalRegistryStage(
-		cmd,
-		clusterCfg,
-		deps,
-		kindConfig,
-		k3dConfig,
-		localRegistryStageProvision,
-		firstActivityShown,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to provision local registry: %w", err)
-	}
+	// Resolved up front; the mirror stage amends the distribution configs while it runs.
+	nodeImage := ClusterNodeImage(clusterCfg, kindConfig, k3dConfig)
 
-	err = setupMirrorRegistries(
+	return runParallelStages(
 		cmd,
-		clusterCfg,
 		deps,
-		cfgManager,
-		kindConfig,
-		k3dConfig,
+		breakdown,
 		firstActivityShown,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to setup mirror registries: %w", err)
-	}
+		parallelStage{
+			name: "local registry",
+			run: func(cmd *cobra.Command, deps cmdhelpers.LifecycleDeps, shown *bool) error {
+				err := executeLocalRegistryStage(
+					cmd,
+					clusterCfg,
+					deps,
+					kindConfig,
+					k3dConfig,
+					localRegistryStageProvision,
+					shown,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to provision local registry: %w", err)
+				}
 
-	return nil
+				return nil
+			},
+		},
+		parallelStage{
+			name: "mirror registries",
+			run: func(cmd *cobra.Command, deps cmdhelpers.LifecycleDeps, shown *bool) error {
+				err := setupMirrorRegistries(cmd, clusterCfg, deps, cfgManager, kindConfig, k3dConfig, shown)
+				if err != nil {
+					return fmt.Errorf("failed to setup mirror registries: %w", err)
+				}
+
+				return nil
+			},
+		},
+		parallelStage{
+			name: "node image",
+			run: func(cmd *cobra.Command, deps cmdhelpers.LifecycleDeps, shown *bool) error {
+				return pullNodeImage(cmd, nodeImage, deps, shown)
+			},
+		},
+	)
 }
 
 func executeClusterLifecycle(
@@ -255,38 +408,127 @@ func connectMirrorRegistriesWithWarning(
 	}
 }
 
-// handlePostCreationSetup installs CNI and metrics-server after cluster creation.
-// Order depends on CNI configuration to resolve dependencies.
+// handlePostCreationSetup installs the Gateway API CRDs, CNI, metrics-server, optional components
+// and Flux after cluster creation, skipping the components a previous run already installed.
+// Components are installed in the order of their dependencies on each other, and components
+// that do not depend on each other are installed concurrently.
 func handlePostCreationSetup(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
 	tmr timer.Timer,
+	steps *createProgress,
+	breakdown *timer.Breakdown,
 	firstActivityShown *bool,
 ) error {
-	var err error
-
-	// For custom CNI (Cilium or Calico), install CNI first as metrics-server needs networking
-	// For default CNI, install metrics-server first as it's independent
-	switch clusterCfg.Spec.CNI {
-	case v1alpha1.CNICilium:
-		err = installCustomCNIAndMetrics(cmd, clusterCfg, tmr, installCiliumCNI, firstActivityShown)
-	case v1alpha1.CNICalico:
-		err = installCustomCNIAndMetrics(cmd, clusterCfg, tmr, installCalicoCNI, firstActivityShown)
-	case v1alpha1.CNIDefault, "":
-		err = handleMetricsServer(cmd, clusterCfg, tmr, firstActivityShown)
-	default:
-		return fmt.Errorf("%w: %s", ErrUnsupportedCNI, clusterCfg.Spec.CNI)
+	// Simulated clusters have no kubelets or container runtime to run workloads on
+	if clusterCfg.Spec.Distribution.IsSimulated() {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "skipping CNI and component installation for simulated %s cluster",
+			Args:    []any{clusterCfg.Spec.Distribution},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
 	}
 
+	cniName, err := coreinstaller.CNIName(clusterCfg)
 	if err != nil {
-		return err
+		return err //nolint:wrapcheck // CNIName names the unsupported CNI.
+	}
+
+	// A custom CNI (Cilium or Calico) is installed before the components, which need networking
+	var installCustomCNIFunc func(*cobra.Command, *v1alpha1.Cluster, timer.Timer) error
+	if cniName != "" {
+		installCustomCNIFunc = installCNI
+	}
+
+	componentSteps := postCreationSteps(clusterCfg, installCustomCNIFunc)
+
+	// Helm clients of concurrent steps would otherwise overwrite each other's pins
+	cmdhelpers.SharePins(cmd)
+
+	output := &componentOutput{
+		cmd:                cmd,
+		tmr:                tmr,
+		breakdown:          breakdown,
+		firstActivityShown: firstActivityShown,
+	}
+
+	pipelineSteps := make([]installer.Step, 0, len(componentSteps))
+	for _, component := range componentSteps {
+		pipelineSteps = append(pipelineSteps, installer.Step{
+			Name:      component.name,
+			DependsOn: component.dependsOn,
+			Run: func(context.Context) error {
+				return steps.run(component.name, func() error {
+					return output.run(
+						component.name,
+						func(cmd *cobra.Command, tmr timer.Timer, shown *bool) error {
+							return component.install(cmd, clusterCfg, tmr, shown)
+						},
+					)
+				})
+			},
+		})
 	}
 
-	return installFluxIfConfigured(cmd, clusterCfg, tmr, firstActivityShown)
+	pipeline, err := installer.NewPipeline(
+		pipelineSteps,
+		installer.WithMaxConcurrency(maxConcurrentInstalls),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to plan component installation: %w", err)
+	}
+
+	err = pipeline.Run(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to install components: %w", err)
+	}
+
+	return nil
 }
 
-// installCustomCNIAndMetrics installs a custom CNI and then metrics-server.
-func installCustomCNIAndMetrics(
+// postCreationSteps returns the components create installs after the cluster is up, with the
+// dependencies that order them. installCNI installs the custom CNI, and is nil for the default.
+func postCreationSteps(
+	clusterCfg *v1alpha1.Cluster,
+	installCNI func(*cobra.Command, *v1alpha1.Cluster, timer.Timer) error,
+) []componentStep {
+	steps := []componentStep{
+		// The CRDs must exist before the CNI and ingress controllers start to serve Gateways
+		{createStepGatewayAPI, nil, installGatewayAPIIfConfigured},
+		{createStepCNI, []string{createStepGatewayAPI}, func(
+			cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer, firstActivityShown *bool,
+		) error {
+			if installCNI == nil {
+				return nil
+			}
+
+			return installCustomCNI(cmd, clusterCfg, tmr, installCNI, firstActivityShown)
+		}},
+		{createStepMetricsServer, []string{createStepCNI}, handleMetricsServer},
+		{createStepMetalLB, []string{createStepCNI}, installMetalLBIfConfigured},
+		{createStepGitea, []string{createStepCNI}, installComponentStep(createStepGitea)},
+		{createStepLocalStack, []string{createStepCNI}, installComponentStep(createStepLocalStack)},
+		{createStepVault, []string{createStepCNI}, installComponentStep(createStepVault)},
+		{createStepCloudNativePG, []string{createStepCNI}, installComponentStep(createStepCloudNativePG)},
+		{createStepArgoRollouts, []string{createStepCNI}, installComponentStep(createStepArgoRollouts)},
+		{createStepHeadlamp, []string{createStepCNI}, installComponentStep(createStepHeadlamp)},
+		// Policies are enforced only after KSail's own components are up, so they cannot block them
+		{createStepKyverno, []string{
+			createStepMetricsServer, createStepMetalLB, createStepGitea, createStepLocalStack,
+			createStepVault, createStepCloudNativePG, createStepArgoRollouts, createStepHeadlamp,
+		}, installComponentStep(createStepKyverno)},
+		{createStepFlux, []string{createStepKyverno}, installFluxIfConfigured},
+		{createStepArgoCD, []string{createStepKyverno}, installArgoCDIfConfigured},
+	}
+
+	return append(steps, pluginSteps(clusterCfg.Spec.Plugins)...)
+}
+
+// installCustomCNI installs a custom CNI in its own stage.
+func installCustomCNI(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
 	tmr timer.Timer,
@@ -301,13 +543,7 @@ func installCustomCNIAndMetrics(
 
 	tmr.NewStage()
 
-	err := installFunc(cmd, clusterCfg, tmr)
-	if err != nil {
-		return err
-	}
-
-	// Install metrics-server after CNI is ready
-	return handleMetricsServer(cmd, clusterCfg, tmr, firstActivityShown)
+	return installFunc(cmd, clusterCfg, tmr)
 }
 
 func loadDistributionConfigs(
@@ -339,6 +575,11 @@ func loadDistributionConfigs(
 			return nil, nil, fmt.Errorf("failed to load kind config: %w", err)
 		}
 
+		configmanager.ApplyClusterName(kindConfig, clusterCfg.Spec.ClusterName)
+		configmanager.ApplyNodeCounts(kindConfig, clusterCfg.Spec.ControlPlaneNodes, clusterCfg.Spec.WorkerNodes)
+		configmanager.ApplyNodes(kindConfig, clusterCfg.Spec.Nodes)
+		configmanager.ApplyMounts(kindConfig, clusterCfg.Spec.Mounts)
+
 		return kindConfig, nil, nil
 	case v1alpha1.DistributionK3d:
 		manager := k3dconfigmanager.NewConfigManager(configPath)
@@ -348,6 +589,16 @@ func loadDistributionConfigs(
 			return nil, nil, fmt.Errorf("failed to load k3d config: %w", err)
 		}
 
+		configmanager.ApplyClusterName(k3dConfig, clusterCfg.Spec.ClusterName)
+		configmanager.ApplyNodeCounts(k3dConfig, clusterCfg.Spec.ControlPlaneNodes, clusterCfg.Spec.WorkerNodes)
+		configmanager.ApplyNodes(k3dConfig, clusterCfg.Spec.Nodes)
+		configmanager.ApplyMounts(k3dConfig, clusterCfg.Spec.Mounts)
+		configmanager.ApplyDockerNetwork(
+			k3dConfig,
+			clusterCfg.Spec.Networking.DockerNetwork.Name,
+			clusterCfg.Spec.Networking.DockerNetwork.Subnet,
+		)
+
 		return nil, k3dConfig, nil
 	default:
 		return nil, nil, nil
@@ -402,9 +653,8 @@ const (
 	connectStageSuccess  = "registries connected"
 	connectStageFailure  = "failed to connect registries"
 
-	fluxStageTitle    = "Install Flux..."
-	fluxStageEmoji    = "☸️"
-	fluxStageActivity = "installing controllers"
+	fluxStageTitle = "Install Flux..."
+	fluxStageEmoji = "☸️"
 )
 
 var (
@@ -445,12 +695,7 @@ var (
 	connectRegistriesToClusterNetwork = makeRegistryStageRunner(registryStageRoleConnect)
 	// fluxInstallerFactory is overridden in tests to stub Flux installer creation.
 	//nolint:gochecknoglobals // dependency injection for tests
-	fluxInstallerFactory = func(client helm.Interface, timeout time.Duration) installer.Installer {
-		return fluxinstaller.NewFluxInstaller(client, timeout)
-	}
-	// ensureFluxResourcesFunc enforces default Flux resources post-install.
-	//nolint:gochecknoglobals // dependency injection for tests
-	ensureFluxResourcesFunc = fluxinstaller.EnsureDefaultResources
+	fluxInstallerFactory = coreinstaller.NewFluxInstaller
 	// dockerClientInvoker can be overridden in tests to avoid real Docker connections.
 	//nolint:gochecknoglobals // dependency injection for tests
 	dockerClientInvoker = cmdhelpers.WithDockerClient
@@ -572,6 +817,7 @@ func runKindMirrorAction(
 		execCtx,
 		ctx.kindConfig,
 		clusterName,
+		clusterprovisioner.DockerNetworkName(ctx.clusterCfg, clusterName),
 		dockerClient,
 		ctx.mirrorSpecs,
 		writer,
@@ -591,6 +837,7 @@ func runKindConnectAction(
 	err := kindprovisioner.ConnectRegistriesToNetwork(
 		execCtx,
 		ctx.kindConfig,
+		clusterprovisioner.DockerNetworkName(ctx.clusterCfg, ctx.kindConfig.Name),
 		dockerClient,
 		ctx.cmd.OutOrStdout(),
 	)
@@ -658,7 +905,6 @@ func runK3DRegistryAction(
 
 func newRegistryHandlers(
 	clusterCfg *v1alpha1.Cluster,
-	cfgManager *ksailconfigmanager.ConfigManager,
 	kindConfig *v1alpha4.Cluster,
 	k3dConfig *v1alpha5.SimpleConfig,
 	mirrorSpecs []registry.MirrorSpec,
@@ -667,7 +913,7 @@ func newRegistryHandlers(
 ) map[v1alpha1.Distribution]registryStageHandler {
 	return map[v1alpha1.Distribution]registryStageHandler{
 		v1alpha1.DistributionKind: {
-			prepare: func() bool { return prepareKindConfigWithMirrors(clusterCfg, cfgManager, kindConfig) },
+			prepare: func() bool { return prepareKindConfigWithMirrors(clusterCfg, kindConfig, mirrorSpecs) },
 			action:  kindAction,
 		},
 		v1alpha1.DistributionK3d: {
@@ -681,7 +927,6 @@ func handleRegistryStage(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
 	deps cmdhelpers.LifecycleDeps,
-	cfgManager *ksailconfigmanager.ConfigManager,
 	kindConfig *v1alpha4.Cluster,
 	k3dConfig *v1alpha5.SimpleConfig,
 	info registryStageInfo,
@@ -692,7 +937,6 @@ func handleRegistryStage(
 ) error {
 	handlers := newRegistryHandlers(
 		clusterCfg,
-		cfgManager,
 		kindConfig,
 		k3dConfig,
 		mirrorSpecs,
@@ -725,9 +969,7 @@ func runRegistryStageWithRole(
 	role registryStageRole,
 	firstActivityShown *bool,
 ) error {
-	mirrorSpecs := registry.ParseMirrorSpecs(
-		cfgManager.Viper.GetStringSlice("mirror-registry"),
-	)
+	mirrorSpecs := mirrorRegistrySpecs(clusterCfg, cfgManager)
 
 	definition, ok := registryStageDefinitions[role]
 	if !ok {
@@ -749,7 +991,6 @@ func runRegistryStageWithRole(
 		cmd,
 		clusterCfg,
 		deps,
-		cfgManager,
 		kindConfig,
 		k3dConfig,
 		definition.info,
@@ -812,7 +1053,9 @@ func runRegistryStage(
 	dockerClientInvokerMu.RUnlock()
 
 	err := invoker(cmd, func(dockerClient client.APIClient) error {
-		err := action(cmd.Context(), dockerClient)
+		err := audit.Track(cmd.Context(), audit.ActionRegistry, info.activity, func() error {
+			return action(cmd.Context(), dockerClient)
+		})
 		if err != nil {
 			return fmt.Errorf("%s: %w", info.failurePrefix, err)
 		}
@@ -835,8 +1078,14 @@ func runRegistryStage(
 	return nil
 }
 
-// createHelmClientForCluster creates a Helm client configured for the cluster.
-func createHelmClientForCluster(clusterCfg *v1alpha1.Cluster) (*helm.Client, string, error) {
+// createHelmClientForCluster creates a Helm client configured for the cluster that verifies
+// downloaded charts against the project's pinned checksums, installs the locked versions and
+// retries transient failures as spec.options.install sets for component.
+func createHelmClientForCluster(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	component string,
+) (*helm.Client, string, error) {
 	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get kubeconfig path: %w", err)
@@ -848,16 +1097,23 @@ func createHelmClientForCluster(clusterCfg *v1alpha1.Cluster) (*helm.Client, str
 		return nil, "", fmt.Errorf("failed to access kubeconfig file: %w", err)
 	}
 
-	helmClient, err := helm.NewClient(kubeconfig, clusterCfg.Spec.Connection.Context)
+	helmClient, err := coreinstaller.NewHelmClient(
+		clusterCfg,
+		kubeconfig,
+		component,
+		cmdhelpers.HelmChecksumOption(cmd),
+		cmdhelpers.HelmLockOption(cmd),
+	)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to create Helm client: %w", err)
+		return nil, "", err //nolint:wrapcheck // NewHelmClient describes the failure.
 	}
 
 	return helmClient, kubeconfig, nil
 }
 
-// installCiliumCNI installs Cilium CNI on the cluster.
-func installCiliumCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer) error {
+// installCNI installs the custom CNI of the cluster, Cilium or Calico, and waits for it to be
+// ready.
+func installCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer) error {
 	notify.WriteMessage(notify.Message{
 		Type:    notify.TitleType,
 		Content: "Install CNI...",
@@ -865,157 +1121,66 @@ func installCiliumCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr time
 		Writer:  cmd.OutOrStdout(),
 	})
 
-	helmClient, kubeconfig, err := createHelmClientForCluster(clusterCfg)
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepCNI)
 	if err != nil {
 		return err
 	}
 
-	err = helmClient.AddRepository(cmd.Context(), &helm.RepositoryEntry{
-		Name: "cilium",
-		URL:  "https://helm.cilium.io/",
-	})
+	err = coreinstaller.InstallCNI(cmd.Context(), helmClient, kubeconfig, clusterCfg, activityWriter(cmd))
 	if err != nil {
-		return fmt.Errorf("failed to add Cilium Helm repository: %w", err)
+		return err //nolint:wrapcheck // InstallCNI names the CNI that failed.
 	}
 
-	installer := newCiliumInstaller(helmClient, kubeconfig, clusterCfg)
-
-	return runCiliumInstallation(cmd, installer, tmr)
-}
-
-func newCiliumInstaller(
-	helmClient *helm.Client,
-	kubeconfig string,
-	clusterCfg *v1alpha1.Cluster,
-) *ciliuminstaller.CiliumInstaller {
-	timeout := installer.GetInstallTimeout(clusterCfg)
-
-	return ciliuminstaller.NewCiliumInstaller(
-		helmClient,
-		kubeconfig,
-		clusterCfg.Spec.Connection.Context,
-		timeout,
-	)
-}
-
-// cniInstaller defines the interface for CNI installers.
-type cniInstaller interface {
-	Install(ctx context.Context) error
-	WaitForReadiness(ctx context.Context) error
-}
-
-func runCiliumInstallation(
-	cmd *cobra.Command,
-	installer *ciliuminstaller.CiliumInstaller,
-	tmr timer.Timer,
-) error {
-	return runCNIInstallation(cmd, installer, "cilium", tmr)
-}
-
-// installCalicoCNI installs Calico CNI on the cluster.
-func installCalicoCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer) error {
 	notify.WriteMessage(notify.Message{
-		Type:    notify.TitleType,
-		Content: "Install CNI...",
-		Emoji:   "🌐",
+		Type:    notify.SuccessType,
+		Content: "cni installed",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
 		Writer:  cmd.OutOrStdout(),
 	})
 
-	helmClient, kubeconfig, err := createHelmClientForCluster(clusterCfg)
-	if err != nil {
-		return err
-	}
-
-	installer := newCalicoInstaller(helmClient, kubeconfig, clusterCfg)
+	return nil
+}
 
-	return runCalicoInstallation(cmd, installer, tmr)
+// activityWriter reports the activities of a core component installation on the output of cmd.
+func activityWriter(cmd *cobra.Command) coreinstaller.Activity {
+	return func(content string) {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: content,
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
 }
 
-func newCalicoInstaller(
-	helmClient *helm.Client,
-	kubeconfig string,
+// mirrorRegistrySpecs returns the mirrors of spec.mirrors in the KSail configuration,
+// overridden per host by the --mirror-registry flag.
+func mirrorRegistrySpecs(
 	clusterCfg *v1alpha1.Cluster,
-) *calicoinstaller.CalicoInstaller {
-	timeout := installer.GetInstallTimeout(clusterCfg)
-
-	return calicoinstaller.NewCalicoInstaller(
-		helmClient,
-		kubeconfig,
-		clusterCfg.Spec.Connection.Context,
-		timeout,
+	cfgManager *ksailconfigmanager.ConfigManager,
+) []registry.MirrorSpec {
+	return registry.MergeMirrorSpecs(
+		registry.MirrorSpecsFromConfig(clusterCfg.Spec.Mirrors),
+		registry.ParseMirrorSpecs(cfgManager.Viper.GetStringSlice("mirror-registry")),
 	)
 }
 
-func runCalicoInstallation(
-	cmd *cobra.Command,
-	installer *calicoinstaller.CalicoInstaller,
-	tmr timer.Timer,
-) error {
-	return runCNIInstallation(cmd, installer, "calico", tmr)
-}
-
-// runCNIInstallation is the generic implementation for running CNI installation.
-func runCNIInstallation(
-	cmd *cobra.Command,
-	installer cniInstaller,
-	cniName string,
-	tmr timer.Timer,
-) error {
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: "installing " + strings.ToLower(cniName),
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	installErr := installer.Install(cmd.Context())
-	if installErr != nil {
-		return fmt.Errorf("%s installation failed: %w", cniName, installErr)
-	}
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: "awaiting " + strings.ToLower(cniName) + " to be ready",
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	readinessErr := installer.WaitForReadiness(cmd.Context())
-	if readinessErr != nil {
-		return fmt.Errorf("%s readiness check failed: %w", cniName, readinessErr)
-	}
-
-	outputTimer := cmdhelpers.MaybeTimer(cmd, tmr)
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.SuccessType,
-		Content: "cni installed",
-		Timer:   outputTimer,
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	return nil
-}
-
 // prepareKindConfigWithMirrors prepares the Kind config by adding mirror registry patches if needed.
 // Returns true if there are containerd patches to process, false otherwise.
 func prepareKindConfigWithMirrors(
 	clusterCfg *v1alpha1.Cluster,
-	cfgManager *ksailconfigmanager.ConfigManager,
 	kindConfig *v1alpha4.Cluster,
+	mirrorSpecs []registry.MirrorSpec,
 ) bool {
 	// Only for Kind distribution
 	if clusterCfg.Spec.Distribution != v1alpha1.DistributionKind || kindConfig == nil {
 		return false
 	}
 
-	// Check for --mirror-registry flag overrides
-	mirrorRegistries := cfgManager.Viper.GetStringSlice("mirror-registry")
-	if len(mirrorRegistries) > 0 {
-		// Add containerd patches from flag
-		kindConfig.ContainerdConfigPatches = append(
-			kindConfig.ContainerdConfigPatches,
-			generateContainerdPatchesFromSpecs(mirrorRegistries)...,
-		)
-	}
+	// Add containerd patches for the mirrors of the KSail config and the --mirror-registry flag
+	kindConfig.ContainerdConfigPatches = append(
+		kindConfig.ContainerdConfigPatches,
+		generateContainerdPatchesFromSpecs(kindConfig, mirrorSpecs)...,
+	)
 
 	// Return true if there are containerd patches to process
 	return len(kindConfig.ContainerdConfigPatches) > 0
@@ -1051,14 +1216,18 @@ func prepareK3dConfigWithMirrors(
 }
 
 // generateContainerdPatchesFromSpecs generates containerd config patches from mirror registry specs.
-// Input format: "registry=endpoint" (e.g., "docker.io=http://localhost:5000")
-func generateContainerdPatchesFromSpecs(mirrorSpecs []string) []string {
-	parsed := registry.ParseMirrorSpecs(mirrorSpecs)
-	if len(parsed) == 0 {
+// Hosts the patches of kindConfig already mirror, e.g. those scaffolded by init, are skipped.
+func generateContainerdPatchesFromSpecs(kindConfig *v1alpha4.Cluster, mirrorSpecs []registry.MirrorSpec) []string {
+	if len(mirrorSpecs) == 0 {
 		return nil
 	}
 
-	entries := registry.BuildMirrorEntries(parsed, "", nil, nil, nil)
+	existingHosts := make(map[string]struct{})
+	for host := range kindprovisioner.RegistryMirrors(kindConfig) {
+		existingHosts[host] = struct{}{}
+	}
+
+	entries := registry.BuildMirrorEntries(mirrorSpecs, "", existingHosts, nil, nil)
 
 	patches := make([]string, 0, len(entries))
 	for _, entry := range entries {
@@ -1068,53 +1237,28 @@ func generateContainerdPatchesFromSpecs(mirrorSpecs []string) []string {
 	return patches
 }
 
-// handleMetricsServer manages metrics-server installation based on cluster configuration.
-// For K3d, metrics-server should be disabled via config (handled in setupK3dMetricsServer), not uninstalled.
+// handleMetricsServer installs metrics-server when the cluster enables it and the distribution
+// does not ship it. K3d disables its own metrics-server in the config before creation
+// (setupK3dMetricsServer), so a disabled metrics-server needs no action here.
 func handleMetricsServer(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
 	tmr timer.Timer,
 	firstActivityShown *bool,
 ) error {
-	// Check if distribution provides metrics-server by default
-	hasMetricsByDefault := clusterCfg.Spec.Distribution.ProvidesMetricsServerByDefault()
-
-	// Enabled: Install if not present by default
-	if clusterCfg.Spec.MetricsServer == v1alpha1.MetricsServerEnabled {
-		if hasMetricsByDefault {
-			// Already present, no action needed
-			return nil
-		}
-
-		if *firstActivityShown {
-			_, _ = fmt.Fprintln(cmd.OutOrStdout())
-		}
-
-		*firstActivityShown = true
-
-		tmr.NewStage()
-
-		return installMetricsServer(cmd, clusterCfg, tmr)
+	if !coreinstaller.NeedsMetricsServer(clusterCfg) {
+		return nil
 	}
 
-	// Disabled: For K3d, this is handled via config before cluster creation (setupK3dMetricsServer)
-	// No post-creation action needed for K3d
-	if clusterCfg.Spec.MetricsServer == v1alpha1.MetricsServerDisabled {
-		if clusterCfg.Spec.Distribution == v1alpha1.DistributionK3d {
-			// K3d metrics-server is disabled via config, no action needed here
-			return nil
-		}
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
 
-		if !hasMetricsByDefault {
-			// Not present, no action needed
-			return nil
-		}
+	*firstActivityShown = true
 
-		// For other distributions that have it by default, we would uninstall here
-		// But currently only K3d has it by default, and that's handled via config
-	}
+	tmr.NewStage()
 
-	return nil
+	return installMetricsServer(cmd, clusterCfg, tmr)
 }
 
 // installMetricsServer installs metrics-server on the cluster.
@@ -1126,45 +1270,22 @@ func installMetricsServer(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr
 		Writer:  cmd.OutOrStdout(),
 	})
 
-	helmClient, kubeconfig, err := createHelmClientForCluster(clusterCfg)
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepMetricsServer)
 	if err != nil {
 		return err
 	}
 
-	timeout := installer.GetInstallTimeout(clusterCfg)
-	msInstaller := metricsserverinstaller.NewMetricsServerInstaller(
-		helmClient,
-		kubeconfig,
-		clusterCfg.Spec.Connection.Context,
-		timeout,
+	err = coreinstaller.InstallMetricsServer(
+		cmd.Context(), helmClient, kubeconfig, clusterCfg, activityWriter(cmd),
 	)
-
-	return runMetricsServerInstallation(cmd, msInstaller, tmr)
-}
-
-// runMetricsServerInstallation performs the metrics-server installation.
-func runMetricsServerInstallation(
-	cmd *cobra.Command,
-	installer *metricsserverinstaller.MetricsServerInstaller,
-	tmr timer.Timer,
-) error {
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: "installing metrics-server",
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	installErr := installer.Install(cmd.Context())
-	if installErr != nil {
-		return fmt.Errorf("metrics-server installation failed: %w", installErr)
+	if err != nil {
+		return err //nolint:wrapcheck // InstallMetricsServer describes the failure.
 	}
 
-	outputTimer := cmdhelpers.MaybeTimer(cmd, tmr)
-
 	notify.WriteMessage(notify.Message{
 		Type:    notify.SuccessType,
 		Content: "metrics server installed",
-		Timer:   outputTimer,
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
 		Writer:  cmd.OutOrStdout(),
 	})
 
@@ -1181,59 +1302,11 @@ func installFluxIfConfigured(
 		return nil
 	}
 
-	helmClient, kubeconfig, err := createHelmClientForCluster(clusterCfg)
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepFlux)
 	if err != nil {
 		return err
 	}
 
-	fluxInstaller := newFluxInstallerForCluster(clusterCfg, helmClient)
-
-	err = runFluxInstallation(cmd, fluxInstaller, tmr, firstActivityShown)
-	if err != nil {
-		return err
-	}
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: fluxResourcesActivity,
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	err = ensureFluxResourcesFunc(cmd.Context(), kubeconfig, clusterCfg)
-	if err != nil {
-		return fmt.Errorf("failed to configure Flux resources: %w", err)
-	}
-
-	outputTimer := cmdhelpers.MaybeTimer(cmd, tmr)
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.SuccessType,
-		Content: fluxResourcesSuccess,
-		Timer:   outputTimer,
-		Writer:  cmd.OutOrStdout(),
-	})
-
-	return nil
-}
-
-// newFluxInstallerForCluster returns an installer tuned for the cluster context.
-//
-//nolint:ireturn // returns interface for dependency injection in tests
-func newFluxInstallerForCluster(
-	clusterCfg *v1alpha1.Cluster,
-	helmClient helm.Interface,
-) installer.Installer {
-	timeout := installer.GetInstallTimeout(clusterCfg)
-
-	return fluxInstallerFactory(helmClient, timeout)
-}
-
-func runFluxInstallation(
-	cmd *cobra.Command,
-	installer installer.Installer,
-	tmr timer.Timer,
-	firstActivityShown *bool,
-) error {
 	if *firstActivityShown {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout())
 	}
@@ -1249,21 +1322,23 @@ func runFluxInstallation(
 
 	tmr.NewStage()
 
-	ctx := cmd.Context()
-	if ctx == nil {
-		ctx = context.Background()
+	err = coreinstaller.InstallFlux(
+		cmd.Context(),
+		fluxInstallerFactory(helmClient, clusterCfg),
+		kubeconfig,
+		clusterCfg,
+		activityWriter(cmd),
+	)
+	if err != nil {
+		return err //nolint:wrapcheck // InstallFlux describes the failed step.
 	}
 
 	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: fluxStageActivity,
+		Type:    notify.SuccessType,
+		Content: fluxResourcesSuccess,
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
 		Writer:  cmd.OutOrStdout(),
 	})
 
-	err := installer.Install(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to install flux controllers: %w", err)
-	}
-
 	return nil
 }
@@ -3,11 +3,13 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
 	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
 	kindconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kind"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
@@ -55,6 +57,8 @@ func NewDeleteCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 
 	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleDeleteRunE)
 
+	cmdhelpers.MarkAudited(cmd, "cluster.delete")
+
 	return cmd
 }
 
@@ -64,15 +68,42 @@ func handleDeleteRunE(
 	cfgManager *ksailconfigmanager.ConfigManager,
 	deps cmdhelpers.LifecycleDeps,
 ) error {
-	config := newDeleteLifecycleConfig()
+	deps.Timer.Start()
+
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	err = useDockerHost(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	// Stop compose services first so the cluster network is free to be removed
+	if strings.TrimSpace(clusterCfg.Spec.ComposeFile) != "" {
+		err = stopComposeServices(cmd, clusterCfg, deps, cfgManager.Viper.ConfigFileUsed())
+		if err != nil {
+			notify.WriteMessage(notify.Message{
+				Type:    notify.WarningType,
+				Content: fmt.Sprintf("failed to stop compose services: %v", err),
+				Writer:  cmd.OutOrStdout(),
+			})
+		}
+
+		cmd.Println()
+	}
+
+	deps.Timer.NewStage()
 
 	// Execute cluster deletion
-	err := cmdhelpers.HandleLifecycleRunE(cmd, cfgManager, deps, config)
+	err = cmdhelpers.RunLifecycleWithConfig(cmd, deps, newDeleteLifecycleConfig(), clusterCfg)
 	if err != nil {
 		return fmt.Errorf("cluster deletion failed: %w", err)
 	}
 
-	clusterCfg := cfgManager.Config
+	closeRemoteTunnel(cmd, clusterCfg)
+	removeHostDNS(cmd, clusterCfg)
 
 	deleteVolumes, flagErr := cmd.Flags().GetBool("delete-volumes")
 	if flagErr != nil {
@@ -100,6 +131,10 @@ func handleDeleteRunE(
 		}
 	}
 
+	removeDockerNetwork(cmd, clusterCfg)
+
+	stopColimaVM(cmd, clusterCfg, deps)
+
 	return nil
 }
 
@@ -134,6 +169,14 @@ func cleanupKindMirrorRegistries(
 		return fmt.Errorf("failed to load kind config: %w", loadErr)
 	}
 
+	configmanager.ApplyClusterName(kindConfig, clusterCfg.Spec.ClusterName)
+
+	// Mirrors declared in ksail.yaml after init are not in the Kind config, but were created too
+	kindConfig.ContainerdConfigPatches = append(
+		kindConfig.ContainerdConfigPatches,
+		generateContainerdPatchesFromSpecs(kindConfig, registry.MirrorSpecsFromConfig(clusterCfg.Spec.Mirrors))...,
+	)
+
 	registriesInfo := kindprovisioner.ExtractRegistriesFromKindForTesting(kindConfig, nil)
 
 	registryNames := registry.CollectRegistryNames(registriesInfo)
@@ -150,6 +193,7 @@ func cleanupKindMirrorRegistries(
 				cmd.Context(),
 				kindConfig,
 				kindConfig.Name,
+				clusterprovisioner.DockerNetworkName(clusterCfg, kindConfig.Name),
 				dockerClient,
 				deleteVolumes,
 			)
@@ -174,6 +218,14 @@ func cleanupK3dMirrorRegistries(
 		return fmt.Errorf("failed to load k3d config: %w", loadErr)
 	}
 
+	configmanager.ApplyClusterName(k3dConfig, clusterCfg.Spec.ClusterName)
+	configmanager.ApplyDockerNetwork(
+		k3dConfig,
+		clusterCfg.Spec.Networking.DockerNetwork.Name,
+		clusterCfg.Spec.Networking.DockerNetwork.Subnet,
+	)
+	prepareK3dConfigWithMirrors(clusterCfg, k3dConfig, registry.MirrorSpecsFromConfig(clusterCfg.Spec.Mirrors))
+
 	registriesInfo := k3dprovisioner.ExtractRegistriesFromConfigForTesting(k3dConfig)
 
 	registryNames := registry.CollectRegistryNames(registriesInfo)
@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/svc/hostdns"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+// removeHostDNS removes the host resolver files written by `ksail dns install` for the
+// cluster, warning instead of failing so DNS cleanup never blocks deleting the cluster.
+func removeHostDNS(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) {
+	dnsManager, err := hostdns.NewHostManager()
+	if err != nil {
+		return
+	}
+
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err != nil {
+		return
+	}
+
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+
+	removed, err := dnsManager.Uninstall(cmd.Context(), clusterName)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: fmt.Sprintf("failed to remove host dns routing: %v", err),
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return
+	}
+
+	if removed {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "removed host dns routing for %s",
+			Args:    []any{clusterName},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+}
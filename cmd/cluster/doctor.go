@@ -0,0 +1,197 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/doctor"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+var errDoctorChecksFailed = errors.New("environment checks failed")
+
+// NewDoctorCmd creates the doctor command, which runs preflight checks of the host.
+func NewDoctorCmd(_ *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the host environment for cluster creation",
+		Long: `Run preflight checks of the host: the container engine (Docker or Podman), cgroup v2, ` +
+			`inotify limits, free disk space, conflicts on the host ports the cluster publishes and ` +
+			`the kernel modules the configured CNI needs. Problems are reported with a suggested fix; ` +
+			`the command fails when a cluster cannot be created. Run it before creating the cluster; the ` +
+			`ports of a running cluster are reported as in use.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return handleDoctorRunE(cmd, cfgManager, doctor.HostEnvironment(dockerPinger(cmd)))
+	}
+
+	return cmd
+}
+
+func handleDoctorRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	env doctor.Environment,
+) error {
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	// The distribution config only contributes ports; a broken one is reported by create.
+	kindConfig, k3dConfig, _ := loadDistributionConfigs(clusterCfg, nil)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Check environment...",
+		Emoji:   "🩺",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		workDir = "."
+	}
+
+	results := doctor.Run(cmd.Context(), env, []doctor.Check{
+		doctor.ContainerEngineCheck(),
+		doctor.CgroupV2Check(),
+		doctor.InotifyCheck(),
+		doctor.DiskSpaceCheck(workDir),
+		doctor.PortsCheck(clusterHostPorts(clusterCfg, kindConfig, k3dConfig)),
+		doctor.KernelModulesCheck(clusterCfg.Spec.CNI),
+	})
+
+	for _, result := range results {
+		writeDoctorResult(cmd, result)
+	}
+
+	if doctor.Failed(results) {
+		return errDoctorChecksFailed
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "environment ready for %s clusters",
+		Args:    []any{clusterCfg.Spec.Distribution},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+func writeDoctorResult(cmd *cobra.Command, result doctor.Result) {
+	messageType := notify.SuccessType
+
+	switch result.Status {
+	case doctor.StatusOK:
+	case doctor.StatusWarning:
+		messageType = notify.WarningType
+	case doctor.StatusFailed:
+		messageType = notify.ErrorType
+	case doctor.StatusSkipped:
+		messageType = notify.ActivityType
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    messageType,
+		Content: "%s: %s",
+		Args:    []any{result.Name, result.Message},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	if result.Fix != "" {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "fix: %s",
+			Args:    []any{result.Fix},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+}
+
+// dockerPinger returns a Pinger that queries the engine behind the Docker client of cmd,
+// which is Docker or Podman.
+func dockerPinger(cmd *cobra.Command) doctor.Pinger {
+	return func(ctx context.Context) (string, error) {
+		dockerClientInvokerMu.RLock()
+
+		invoker := dockerClientInvoker
+
+		dockerClientInvokerMu.RUnlock()
+
+		var engine string
+
+		err := invoker(cmd, func(dockerClient client.APIClient) error {
+			version, err := dockerClient.ServerVersion(ctx)
+			if err != nil {
+				return fmt.Errorf("query engine version: %w", err)
+			}
+
+			engine = strings.TrimSpace(version.Platform.Name + " " + version.Version)
+
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return engine, nil
+	}
+}
+
+// clusterHostPorts returns the fixed host ports the cluster publishes, mapped to what
+// publishes them.
+func clusterHostPorts(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) map[int]string {
+	ports := map[int]string{}
+
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		ports[resolveLocalRegistryPort(clusterCfg)] = "local registry"
+	}
+
+	if kindConfig != nil {
+		if kindConfig.Networking.APIServerPort > 0 {
+			ports[int(kindConfig.Networking.APIServerPort)] = "API server"
+		}
+
+		for _, node := range kindConfig.Nodes {
+			for _, mapping := range node.ExtraPortMappings {
+				if mapping.HostPort > 0 {
+					ports[int(mapping.HostPort)] = "port mapping of the " + string(node.Role) + " node"
+				}
+			}
+		}
+	}
+
+	if k3dConfig != nil {
+		port, err := strconv.Atoi(k3dConfig.ExposeAPI.HostPort)
+		if err == nil && port > 0 {
+			ports[port] = "API server"
+		}
+	}
+
+	return ports
+}
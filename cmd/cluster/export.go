@@ -0,0 +1,206 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	capigenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/capi"
+	cataloggenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/catalog"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	exportFormatFlag      = "format"
+	exportFormatCAPI      = "capi"
+	exportFormatBackstage = string(cataloggenerator.FormatBackstage)
+	exportFormatJSON      = string(cataloggenerator.FormatJSON)
+	exportOwnerFlag       = "owner"
+)
+
+var (
+	errUnsupportedExportFormat       = errors.New("unsupported export format")
+	errUnsupportedExportDistribution = errors.New("distribution cannot be exported")
+)
+
+// NewExportCmd creates the export command for clusters.
+func NewExportCmd(_ *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the cluster definition to another format",
+		Long: `Export the cluster defined by ksail.yaml and its distribution config to another format.
+
+Supported formats:
+  capi       Cluster API manifests for the Docker infrastructure provider (CAPD); Kind and K3d only
+  backstage  Backstage catalog entities describing the cluster, components and workloads
+  json       The same catalog metadata as generic JSON for other developer portals
+
+Examples:
+  ksail cluster export --format capi -o cluster.yaml
+  ksail cluster export --format backstage --owner team-a -o catalog-info.yaml`,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	bindExportLocalFlags(cmd, cfgManager)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return HandleExportRunE(cmd, cfgManager)
+	}
+
+	return cmd
+}
+
+// bindExportLocalFlags adds and binds flags that are specific to the export command only.
+func bindExportLocalFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager) {
+	cmd.Flags().String(exportFormatFlag, exportFormatCAPI, "Export format (capi, backstage, json)")
+	_ = cfgManager.Viper.BindPFlag(exportFormatFlag, cmd.Flags().Lookup(exportFormatFlag))
+	cmd.Flags().String(exportOwnerFlag, "", "Owner recorded in catalog metadata (backstage and json formats)")
+	_ = cfgManager.Viper.BindPFlag(exportOwnerFlag, cmd.Flags().Lookup(exportOwnerFlag))
+	cmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	_ = cfgManager.Viper.BindPFlag("output", cmd.Flags().Lookup("output"))
+	cmd.Flags().BoolP("force", "f", false, "Overwrite an existing output file")
+	_ = cfgManager.Viper.BindPFlag("force", cmd.Flags().Lookup("force"))
+}
+
+// HandleExportRunE handles the export command.
+// Exported for testing purposes.
+func HandleExportRunE(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager) error {
+	format := strings.ToLower(strings.TrimSpace(cfgManager.Viper.GetString(exportFormatFlag)))
+
+	switch format {
+	case exportFormatCAPI, exportFormatBackstage, exportFormatJSON:
+	default:
+		return fmt.Errorf(
+			"%w: %q (valid options: %s, %s, %s)",
+			errUnsupportedExportFormat,
+			format,
+			exportFormatCAPI,
+			exportFormatBackstage,
+			exportFormatJSON,
+		)
+	}
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	output := cfgManager.Viper.GetString("output")
+	opts := yamlgenerator.Options{
+		Output: output,
+		Force:  cfgManager.Viper.GetBool("force"),
+	}
+
+	var (
+		content     string
+		description string
+	)
+
+	if format == exportFormatCAPI {
+		spec, specErr := buildCAPISpec(clusterCfg)
+		if specErr != nil {
+			return specErr
+		}
+
+		content, err = capigenerator.NewCAPIGenerator().Generate(spec, opts)
+		description = "Cluster API manifests"
+	} else {
+		catalogSpec, specErr := buildCatalogSpec(cmd, clusterCfg)
+		if specErr != nil {
+			return specErr
+		}
+
+		catalogSpec.Owner = strings.TrimSpace(cfgManager.Viper.GetString(exportOwnerFlag))
+
+		content, err = cataloggenerator.NewCatalogGenerator(cataloggenerator.Format(format)).
+			Generate(catalogSpec, opts)
+		description = "catalog metadata"
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to export cluster: %w", err)
+	}
+
+	if output == "" {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), content)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", description, err)
+		}
+
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "exported %s to %s",
+		Args:    []any{description, output},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+func buildCAPISpec(clusterCfg *v1alpha1.Cluster) (*capigenerator.ClusterSpec, error) {
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load distribution configs: %w", err)
+	}
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		return capigenerator.SpecFromKind(kindConfig), nil
+	case v1alpha1.DistributionK3d:
+		return capigenerator.SpecFromK3d(k3dConfig), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedExportDistribution, clusterCfg.Spec.Distribution)
+	}
+}
+
+// buildCatalogSpec describes the cluster for the catalog formats, which every distribution
+// supports. The Kubernetes version is only known for Kind and K3d, whose node images pin it.
+func buildCatalogSpec(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+) (*cataloggenerator.ProjectSpec, error) {
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind, v1alpha1.DistributionK3d:
+		capiSpec, err := buildCAPISpec(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		spec := cataloggenerator.SpecFromCluster(clusterCfg, capiSpec.Name)
+		spec.KubernetesVersion = capiSpec.KubernetesVersion
+
+		return spec, nil
+	default:
+		_, distributionConfig, err := cmdhelpers.CreateClusterProvisioner(
+			cmd,
+			clusterprovisioner.DefaultFactory{},
+			clusterCfg,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("load distribution config: %w", err)
+		}
+
+		name, err := configmanager.GetClusterName(distributionConfig)
+		if err != nil {
+			return nil, fmt.Errorf("resolve cluster name: %w", err)
+		}
+
+		return cataloggenerator.SpecFromCluster(clusterCfg, name), nil
+	}
+}
@@ -0,0 +1,144 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdWritesCAPIManifestsToStdout(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewExportCmd(nil), "--format", "capi")
+	require.NoError(t, result.Err)
+
+	assert.Contains(t, result.Stdout, "kind: Cluster\n")
+	assert.Contains(t, result.Stdout, "name: "+project.ClusterName+"\n")
+	assert.Contains(t, result.Stdout, "kind: KubeadmControlPlane\n")
+	assert.Contains(t, result.Stdout, "kind: DockerMachineTemplate\n")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdWritesCAPIManifestsToFile(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t, cmdtestutils.WithTempProjectVariant(
+		cmdtestutils.KSailConfigVariant{
+			Distribution: v1alpha1.DistributionK3d,
+			CNI:          v1alpha1.CNIDefault,
+			GitOpsEngine: v1alpha1.GitOpsEngineNone,
+		},
+	))
+	t.Chdir(project.Dir)
+
+	output := filepath.Join(project.Dir, "capi.yaml")
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewExportCmd(nil), "--output", output)
+	require.NoError(t, result.Err)
+	assert.Contains(t, result.Stdout, "exported Cluster API manifests to "+output)
+
+	content, err := os.ReadFile(output) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: "+project.ClusterName+"-control-plane\n")
+	assert.Contains(t, string(content), "- 10.42.0.0/16\n")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdRejectsUnknownFormat(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewExportCmd(nil), "--format", "terraform")
+
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "unsupported export format")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdWritesBackstageCatalog(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(
+		t,
+		clusterpkg.NewExportCmd(nil),
+		"--format", "backstage",
+		"--owner", "team-a",
+	)
+	require.NoError(t, result.Err)
+
+	assert.Contains(t, result.Stdout, "kind: System\n")
+	assert.Contains(t, result.Stdout, "type: kubernetes-cluster\n")
+	assert.Contains(t, result.Stdout, "name: "+project.ClusterName+"-workloads\n")
+	assert.Contains(t, result.Stdout, "owner: team-a\n")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdWritesCatalogJSONToFile(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	output := filepath.Join(project.Dir, "catalog.json")
+
+	result := cmdtestutils.ExecuteCommand(
+		t,
+		clusterpkg.NewExportCmd(nil),
+		"--format", "json",
+		"--output", output,
+	)
+	require.NoError(t, result.Err)
+	assert.Contains(t, result.Stdout, "exported catalog metadata to "+output)
+
+	content, err := os.ReadFile(output) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+
+	var catalog struct {
+		Name         string `json:"name"`
+		Distribution string `json:"distribution"`
+		Context      string `json:"context"`
+	}
+
+	require.NoError(t, json.Unmarshal(content, &catalog))
+	assert.Equal(t, project.ClusterName, catalog.Name)
+	assert.Equal(t, string(v1alpha1.DistributionKind), catalog.Distribution)
+	assert.Equal(t, project.Context, catalog.Context)
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestExportCmdWritesCatalogForDistributionsWithoutCAPISupport(t *testing.T) {
+	for _, distribution := range []v1alpha1.Distribution{v1alpha1.DistributionTalos, v1alpha1.DistributionKwok} {
+		t.Run(string(distribution), func(t *testing.T) {
+			project := cmdtestutils.NewTempProject(t, cmdtestutils.WithTempProjectVariant(
+				cmdtestutils.KSailConfigVariant{
+					Distribution: distribution,
+					CNI:          v1alpha1.CNIDefault,
+					GitOpsEngine: v1alpha1.GitOpsEngineNone,
+				},
+			))
+			t.Chdir(project.Dir)
+
+			result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewExportCmd(nil), "--format", "json")
+			require.NoError(t, result.Err)
+
+			var catalog struct {
+				Name         string `json:"name"`
+				Distribution string `json:"distribution"`
+			}
+
+			require.NoError(t, json.Unmarshal([]byte(result.Stdout), &catalog))
+			assert.NotEmpty(t, catalog.Name)
+			assert.Equal(t, string(distribution), catalog.Distribution)
+
+			result = cmdtestutils.ExecuteCommand(t, clusterpkg.NewExportCmd(nil), "--format", "backstage")
+			require.NoError(t, result.Err)
+			assert.Contains(t, result.Stdout, "kind: System\n")
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	gatewayapiinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gateway-api"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/spf13/cobra"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+)
+
+// installGatewayAPIIfConfigured applies the Gateway API CRDs. It runs before the CNI, as Cilium
+// and Traefik only enable their Gateway API support when the CRDs exist at startup.
+func installGatewayAPIIfConfigured(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+) error {
+	options := clusterCfg.Spec.Options.GatewayAPI
+	if !options.Enabled {
+		return nil
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Install Gateway API CRDs...",
+		Emoji:   "🚪",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	channel, version := gatewayAPIRelease(options)
+
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create apiextensions client: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "applying %s channel crds of gateway api %s",
+		Args:    []any{strings.ToLower(string(channel)), version},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	gatewayAPIInstaller := gatewayapiinstaller.NewGatewayAPIInstaller(
+		clientset.ApiextensionsV1().CustomResourceDefinitions(),
+		gatewayAPIManifest(options),
+		installer.GetComponentTimeout(clusterCfg, createStepGatewayAPI),
+	)
+
+	err = audit.Track(cmd.Context(), audit.ActionComponentInstall, "gateway-api", func() error {
+		return gatewayAPIInstaller.Install(cmd.Context())
+	})
+	if err != nil {
+		return fmt.Errorf("gateway api installation failed: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "gateway api crds installed",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// gatewayAPIRelease returns the channel and version of the Gateway API CRDs to apply.
+func gatewayAPIRelease(options v1alpha1.OptionsGatewayAPI) (v1alpha1.GatewayAPIChannel, string) {
+	channel := options.Channel
+	if channel == "" {
+		channel = v1alpha1.GatewayAPIChannelStandard
+	}
+
+	version := options.Version
+	if version == "" {
+		version = gatewayapiinstaller.DefaultVersion
+	}
+
+	return channel, version
+}
+
+// gatewayAPIManifest returns the URL of the Gateway API CRDs to apply.
+func gatewayAPIManifest(options v1alpha1.OptionsGatewayAPI) string {
+	channel, version := gatewayAPIRelease(options)
+
+	return gatewayapiinstaller.ManifestURL(version, strings.ToLower(string(channel)))
+}
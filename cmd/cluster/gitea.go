@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	giteainstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gitea"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+// giteaComponent installs the in-cluster Gitea server and pushes the project's source
+// directory to it, so the GitOps engine installed afterwards can sync from Git.
+//
+//nolint:gochecknoglobals // entry of the optionalComponents table
+var giteaComponent = optionalComponent{
+	name:  "gitea",
+	title: "Install Gitea...",
+	emoji: "🍵",
+	enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+		return clusterCfg.Spec.Options.Gitea.Enabled
+	},
+	activity: message("installing gitea"),
+	success: func(clusterCfg *v1alpha1.Cluster) string {
+		repository := gitea.RepositoryName(giteaSourceDir(clusterCfg))
+
+		return "gitea installed and project pushed to " + gitea.InClusterRepositoryURL(repository)
+	},
+	factory: newGiteaProjectInstaller,
+}
+
+// giteaProjectInstaller installs Gitea and then pushes the project's source directory to it.
+type giteaProjectInstaller struct {
+	*giteainstaller.GiteaInstaller
+
+	cmd     *cobra.Command
+	options gitea.SyncOptions
+}
+
+func newGiteaProjectInstaller(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	helmClient *helm.Client,
+	kubeconfig string,
+	timeout time.Duration,
+) (installer.Installer, error) {
+	giteaInstaller := giteainstaller.NewGiteaInstaller(helmClient, timeout)
+	giteaInstaller.SetVersion(clusterCfg.Spec.Options.Gitea.Version)
+
+	return &giteaProjectInstaller{
+		GiteaInstaller: giteaInstaller,
+		cmd:            cmd,
+		options: gitea.SyncOptions{
+			Kubeconfig: kubeconfig,
+			Context:    clusterCfg.Spec.Connection.Context,
+			SourceDir:  giteaSourceDir(clusterCfg),
+		},
+	}, nil
+}
+
+// Install installs Gitea and pushes the source directory to its repository.
+func (i *giteaProjectInstaller) Install(ctx context.Context) error {
+	err := i.GiteaInstaller.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("install gitea: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "pushing '%s' to gitea",
+		Args:    []any{i.options.SourceDir},
+		Writer:  i.cmd.OutOrStdout(),
+	})
+
+	_, err = gitea.Sync(ctx, i.options)
+	if err != nil {
+		return fmt.Errorf("failed to push project to gitea: %w", err)
+	}
+
+	return nil
+}
+
+func giteaSourceDir(clusterCfg *v1alpha1.Cluster) string {
+	sourceDir := strings.TrimSpace(clusterCfg.Spec.SourceDirectory)
+	if sourceDir == "" {
+		sourceDir = v1alpha1.DefaultSourceDirectory
+	}
+
+	return sourceDir
+}
@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/svc/clusterinfo"
+	calicoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/calico"
+	ciliuminstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/cilium"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+)
+
+const (
+	k3sDisableTraefikFlag = "--disable=traefik"
+
+	kubeSystemNamespace = "kube-system"
+	fluxNamespace       = "flux-system"
+	deploymentResource  = "deployment"
+	daemonSetResource   = "daemonset"
+)
+
+// componentHealthChecks returns the workloads of the CNI, CSI, ingress, metrics server and
+// GitOps engine that KSail installed or that the distribution provides. Components without
+// workloads of their own, such as the flannel K3s embeds, are left out.
+func componentHealthChecks(
+	clusterCfg *v1alpha1.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) []clusterinfo.HealthCheck {
+	distribution := clusterCfg.Spec.Distribution
+	if distribution.IsSimulated() {
+		return nil
+	}
+
+	var checks []clusterinfo.HealthCheck
+
+	if cni, ok := cniHealthCheck(clusterCfg); ok {
+		checks = append(checks, cni)
+	}
+
+	if csi, ok := csiHealthCheck(distribution); ok {
+		checks = append(checks, csi)
+	}
+
+	if distribution == v1alpha1.DistributionK3d && !k3sDisables(k3dConfig, k3sDisableTraefikFlag) {
+		checks = append(checks, clusterinfo.HealthCheck{
+			Name:     "ingress",
+			Provider: "traefik",
+			Checks:   []k8s.ReadinessCheck{{Type: deploymentResource, Namespace: kubeSystemNamespace, Name: "traefik"}},
+		})
+	}
+
+	if clusterCfg.Spec.MetricsServer == v1alpha1.MetricsServerEnabled && distribution != v1alpha1.DistributionExternal {
+		checks = append(checks, clusterinfo.HealthCheck{
+			Name:     "metrics-server",
+			Provider: "metrics-server",
+			Checks: []k8s.ReadinessCheck{
+				{Type: deploymentResource, Namespace: kubeSystemNamespace, Name: "metrics-server"},
+			},
+		})
+	}
+
+	if clusterCfg.Spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
+		checks = append(checks, clusterinfo.HealthCheck{
+			Name:     "gitops-engine",
+			Provider: string(v1alpha1.GitOpsEngineFlux),
+			Checks: []k8s.ReadinessCheck{
+				{Type: deploymentResource, Namespace: fluxNamespace, Name: "flux-operator"},
+				{Type: deploymentResource, Namespace: fluxNamespace, Name: "source-controller"},
+				{Type: deploymentResource, Namespace: fluxNamespace, Name: "kustomize-controller"},
+				{Type: deploymentResource, Namespace: fluxNamespace, Name: "helm-controller"},
+				{Type: deploymentResource, Namespace: fluxNamespace, Name: "notification-controller"},
+			},
+		})
+	}
+
+	return checks
+}
+
+func cniHealthCheck(clusterCfg *v1alpha1.Cluster) (clusterinfo.HealthCheck, bool) {
+	check := clusterinfo.HealthCheck{Name: "cni", Provider: string(clusterCfg.Spec.CNI)}
+
+	switch clusterCfg.Spec.CNI {
+	case v1alpha1.CNICilium:
+		check.Checks = ciliuminstaller.ReadinessChecks()
+	case v1alpha1.CNICalico:
+		check.Checks = calicoinstaller.ReadinessChecks()
+	case v1alpha1.CNIDefault, "":
+		provider, workload, ok := defaultCNIWorkload(clusterCfg.Spec.Distribution)
+		if !ok {
+			return clusterinfo.HealthCheck{}, false
+		}
+
+		check.Provider = provider
+		check.Checks = []k8s.ReadinessCheck{
+			{Type: daemonSetResource, Namespace: kubeSystemNamespace, Name: workload},
+		}
+	}
+
+	return check, len(check.Checks) > 0
+}
+
+// defaultCNIWorkload returns the DaemonSet of the CNI the distribution installs by default.
+func defaultCNIWorkload(distribution v1alpha1.Distribution) (string, string, bool) {
+	switch distribution {
+	case v1alpha1.DistributionKind:
+		return "kindnet", "kindnet", true
+	case v1alpha1.DistributionTalos:
+		return "flannel", "kube-flannel", true
+	case v1alpha1.DistributionK0s:
+		return "kube-router", "kube-router", true
+	case v1alpha1.DistributionK3d, v1alpha1.DistributionExternal, v1alpha1.DistributionKwok:
+		return "", "", false
+	default:
+		return "", "", false
+	}
+}
+
+// csiHealthCheck returns the local-path provisioner that Kind and K3d ship as their storage.
+func csiHealthCheck(distribution v1alpha1.Distribution) (clusterinfo.HealthCheck, bool) {
+	namespace := ""
+
+	switch distribution {
+	case v1alpha1.DistributionKind:
+		namespace = "local-path-storage"
+	case v1alpha1.DistributionK3d:
+		namespace = kubeSystemNamespace
+	case v1alpha1.DistributionTalos, v1alpha1.DistributionK0s, v1alpha1.DistributionExternal,
+		v1alpha1.DistributionKwok:
+		return clusterinfo.HealthCheck{}, false
+	default:
+		return clusterinfo.HealthCheck{}, false
+	}
+
+	return clusterinfo.HealthCheck{
+		Name:     "csi",
+		Provider: "local-path-provisioner",
+		Checks: []k8s.ReadinessCheck{
+			{Type: deploymentResource, Namespace: namespace, Name: "local-path-provisioner"},
+		},
+	}, true
+}
+
+// k3sDisables reports whether the K3d config passes flag, e.g. --disable=traefik, to K3s.
+func k3sDisables(k3dConfig *v1alpha5.SimpleConfig, flag string) bool {
+	if k3dConfig == nil {
+		return false
+	}
+
+	for _, arg := range k3dConfig.Options.K3sOptions.ExtraArgs {
+		if arg.Arg == flag {
+			return true
+		}
+	}
+
+	return false
+}
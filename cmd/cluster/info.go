@@ -1,30 +1,322 @@
 package cluster
 
 import (
-	"os"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
-	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/svc/clusterinfo"
+	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/spf13/cobra"
-	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
 )
 
-// NewInfoCmd creates the cluster info command.
+const (
+	infoOutputTable = "table"
+	infoOutputJSON  = "json"
+	infoOutputYAML  = "yaml"
+
+	infoClusterTimeout = 10 * time.Second
+)
+
+var errUnsupportedInfoOutput = errors.New("unsupported output format")
+
+// NewInfoCmd creates the cluster info command, which describes what KSail created.
 func NewInfoCmd(_ *runtime.Runtime) *cobra.Command {
-	// Try to load config silently to get kubeconfig path
-	kubeconfigPath := cmdhelpers.GetKubeconfigPathSilently()
+	var (
+		output     string
+		components bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Display cluster information",
+		Long: `Describe the cluster KSail created: its distribution, kubeconfig and context, the CNI, ` +
+			`CSI, metrics server and GitOps engine, the node image, the local registry and registry ` +
+			`mirrors, and, when the cluster is reachable, its nodes and the Helm releases of the ` +
+			`installed components. Use --output json or yaml to introspect the cluster from scripts ` +
+			`and CI pipelines, and --components to report whether the CNI, CSI, ingress, metrics ` +
+			`server and GitOps engine are ready, degraded or missing.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().StringVarP(&output, "output", "o", infoOutputTable, "Output format (table, json or yaml)")
+	cmd.Flags().BoolVar(&components, "components", false,
+		"Report the health of the CNI, CSI, ingress, metrics server and GitOps engine")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return handleInfoRunE(cmd, cfgManager, output, components, collectClusterInfo)
+	}
+
+	return cmd
+}
+
+// clusterInfoCollector reads the running cluster into info, along with the health of the
+// components described by healthChecks.
+type clusterInfoCollector func(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	healthChecks []clusterinfo.HealthCheck,
+	info *clusterinfo.Info,
+) error
+
+func handleInfoRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	output string,
+	components bool,
+	collect clusterInfoCollector,
+) error {
+	if output != infoOutputTable && output != infoOutputJSON && output != infoOutputYAML {
+		return fmt.Errorf("%w: %q (use %s, %s or %s)",
+			errUnsupportedInfoOutput, output, infoOutputTable, infoOutputJSON, infoOutputYAML)
+	}
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err != nil {
+		return err
+	}
+
+	info := newClusterInfo(clusterCfg, kindConfig, k3dConfig)
+
+	var healthChecks []clusterinfo.HealthCheck
+	if components {
+		healthChecks = componentHealthChecks(clusterCfg, k3dConfig)
+	}
+
+	// The declared configuration is reported even when the cluster is down.
+	err = collect(cmd, clusterCfg, healthChecks, &info)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "cluster is not reachable: %v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	switch output {
+	case infoOutputJSON:
+		return writeInfoJSON(cmd.OutOrStdout(), info)
+	case infoOutputYAML:
+		return writeInfoYAML(cmd.OutOrStdout(), info)
+	default:
+		return writeInfoTable(cmd.OutOrStdout(), info)
+	}
+}
+
+func newClusterInfo(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) clusterinfo.Info {
+	info := clusterinfo.Info{
+		Name:          ResolveClusterName(clusterCfg, kindConfig, k3dConfig),
+		Distribution:  clusterCfg.Spec.Distribution,
+		Context:       clusterCfg.Spec.Connection.Context,
+		CNI:           clusterCfg.Spec.CNI,
+		CSI:           clusterCfg.Spec.CSI,
+		MetricsServer: clusterCfg.Spec.MetricsServer,
+		GitOpsEngine:  clusterCfg.Spec.GitOpsEngine,
+		NodeImage:     ClusterNodeImage(clusterCfg, kindConfig, k3dConfig),
+		Mirrors:       []clusterinfo.Mirror{},
+		Nodes:         []clusterinfo.Node{},
+		Components:    []clusterinfo.Component{},
+	}
+
+	kubeconfigPath, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err == nil {
+		info.Kubeconfig = kubeconfigPath
+	}
+
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		info.LocalRegistry = "localhost:" + strconv.Itoa(resolveLocalRegistryPort(clusterCfg))
+	}
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		info.Mirrors = clusterinfo.Mirrors(kindprovisioner.RegistryMirrors(kindConfig))
+	case v1alpha1.DistributionK3d:
+		if k3dConfig != nil {
+			info.Mirrors = clusterinfo.Mirrors(k3dconfigmanager.ParseRegistryConfig(k3dConfig.Registries.Config))
+		}
+	}
+
+	return info
+}
+
+// collectClusterInfo reads the nodes, Helm releases and component health of the running
+// cluster into info.
+func collectClusterInfo(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	healthChecks []clusterinfo.HealthCheck,
+	info *clusterinfo.Info,
+) error {
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, info.Kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	restConfig.Timeout = infoClusterTimeout
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), infoClusterTimeout)
+	defer cancel()
 
-	// Create IO streams for kubectl
-	ioStreams := genericiooptions.IOStreams{
-		In:     os.Stdin,
-		Out:    os.Stdout,
-		ErrOut: os.Stderr,
+	err = clusterinfo.Collect(ctx, clientset, info)
+	if err != nil {
+		return fmt.Errorf("failed to read cluster: %w", err)
 	}
 
-	// Create kubectl client and get the cluster-info command directly
-	client := kubectl.NewClient(ioStreams)
-	infoCmd := client.CreateClusterInfoCommand(kubeconfigPath)
+	if healthChecks == nil {
+		return nil
+	}
+
+	health, err := clusterinfo.CheckHealth(ctx, clientset, healthChecks)
+	if err != nil {
+		return fmt.Errorf("failed to read component health: %w", err)
+	}
+
+	info.Health = health
+
+	return nil
+}
+
+func writeInfoJSON(writer io.Writer, info clusterinfo.Info) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(info)
+	if err != nil {
+		return fmt.Errorf("encode cluster info: %w", err)
+	}
+
+	return nil
+}
+
+func writeInfoYAML(writer io.Writer, info clusterinfo.Info) error {
+	data, err := yaml.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("encode cluster info: %w", err)
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		return fmt.Errorf("write cluster info: %w", err)
+	}
+
+	return nil
+}
+
+func writeInfoTable(writer io.Writer, info clusterinfo.Info) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(table, "name\t%s\n", info.Name)
+	_, _ = fmt.Fprintf(table, "distribution\t%s\n", info.Distribution)
+	_, _ = fmt.Fprintf(table, "context\t%s\n", info.Context)
+	_, _ = fmt.Fprintf(table, "kubeconfig\t%s\n", info.Kubeconfig)
+	_, _ = fmt.Fprintf(table, "cni\t%s\n", info.CNI)
+	_, _ = fmt.Fprintf(table, "csi\t%s\n", info.CSI)
+	_, _ = fmt.Fprintf(table, "metrics server\t%s\n", info.MetricsServer)
+	_, _ = fmt.Fprintf(table, "gitops engine\t%s\n", info.GitOpsEngine)
+	_, _ = fmt.Fprintf(table, "node image\t%s\n", valueOrNone(info.NodeImage))
+	_, _ = fmt.Fprintf(table, "local registry\t%s\n", valueOrNone(info.LocalRegistry))
+	_, _ = fmt.Fprintf(table, "reachable\t%t\n", info.Reachable)
+
+	if len(info.Mirrors) > 0 {
+		_, _ = fmt.Fprintf(table, "\nMirrors:\n")
+
+		for _, mirror := range info.Mirrors {
+			_, _ = fmt.Fprintf(table, "  %s\t%s\n", mirror.Host, strings.Join(mirror.Endpoints, ", "))
+		}
+	}
+
+	if len(info.Nodes) > 0 {
+		_, _ = fmt.Fprintf(table, "\nNodes:\n")
+
+		for _, node := range info.Nodes {
+			_, _ = fmt.Fprintf(table, "  %s\t%s\t%s\t%s\n",
+				node.Name, valueOrNone(strings.Join(node.Roles, ",")), node.KubeletVersion, node.ContainerRuntime)
+		}
+	}
+
+	if len(info.Components) > 0 {
+		_, _ = fmt.Fprintf(table, "\nComponents:\n")
+
+		for _, component := range info.Components {
+			_, _ = fmt.Fprintf(table, "  %s\t%s\t%s-%s\t%s\n",
+				component.Name, component.Namespace, component.Chart, component.Version, component.Status)
+		}
+	}
+
+	if len(info.Health) > 0 {
+		_, _ = fmt.Fprintf(table, "\nHealth:\n")
+
+		for _, component := range info.Health {
+			_, _ = fmt.Fprintf(table, "  %s\t%s\t%s\t%s\n",
+				component.Name, component.Provider, component.Readiness, valueOrNone(unreadyWorkloads(component)))
+		}
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("write cluster info: %w", err)
+	}
+
+	return nil
+}
+
+// unreadyWorkloads lists the workloads of component that are not ready, e.g.
+// "daemonset kube-system/cilium degraded".
+func unreadyWorkloads(component clusterinfo.ComponentHealth) string {
+	var unready []string
+
+	for _, workload := range component.Workloads {
+		if workload.Readiness != k8s.ReadinessReady {
+			unready = append(unready, fmt.Sprintf("%s %s/%s %s",
+				workload.Type, workload.Namespace, workload.Name, workload.Readiness))
+		}
+	}
+
+	return strings.Join(unready, ", ")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
 
-	return infoCmd
+	return value
 }
@@ -0,0 +1,63 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/svc/clusterinfo"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestInfoCmdReportsDeclaredConfigAsJSON(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewInfoCmd(nil), "--output", "json")
+	require.NoError(t, result.Err)
+
+	var info clusterinfo.Info
+
+	require.NoError(t, json.Unmarshal([]byte(result.Stdout), &info))
+
+	assert.Equal(t, project.ClusterName, info.Name)
+	assert.Equal(t, v1alpha1.DistributionKind, info.Distribution)
+	assert.NotEmpty(t, info.NodeImage)
+	assert.False(t, info.Reachable)
+	assert.Empty(t, info.Nodes)
+	assert.Contains(t, result.Stderr, "cluster is not reachable")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestInfoCmdWritesYAMLAndTable(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewInfoCmd(nil), "-o", "yaml")
+	require.NoError(t, result.Err)
+
+	var info clusterinfo.Info
+
+	require.NoError(t, yaml.Unmarshal([]byte(result.Stdout), &info))
+	assert.Equal(t, project.ClusterName, info.Name)
+
+	result = cmdtestutils.ExecuteCommand(t, clusterpkg.NewInfoCmd(nil))
+	require.NoError(t, result.Err)
+
+	assert.Contains(t, result.Stdout, "distribution")
+	assert.Contains(t, result.Stdout, "reachable       false")
+}
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestInfoCmdRejectsUnknownOutput(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewInfoCmd(nil), "-o", "xml")
+	require.ErrorContains(t, result.Err, `unsupported output format: "xml" (use table, json or yaml)`)
+}
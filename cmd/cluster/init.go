@@ -48,6 +48,14 @@ func InitFieldSelectors() []ksailconfigmanager.FieldSelector[v1alpha1.Cluster] {
 	selectors = append(selectors, ksailconfigmanager.StandardSourceDirectoryFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultCNIFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultMetricsServerFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultControlPlaneNodesFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultWorkerNodesFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultIPFamilyFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultPodSubnetFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultServiceSubnetFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultDockerNetworkFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultDockerSubnetFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultDockerGatewayFieldSelector())
 
 	return selectors
 }
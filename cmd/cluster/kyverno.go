@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	kyvernoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/kyverno"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+// kyvernoComponent installs Kyverno and its Pod Security Standards policies. It runs before
+// the GitOps engine, so the workloads of the source directory are admitted against the
+// policies like on the production clusters that enforce them.
+//
+//nolint:gochecknoglobals // entry of the optionalComponents table
+var kyvernoComponent = optionalComponent{
+	name:  "kyverno",
+	title: "Install Kyverno...",
+	emoji: "🛡️",
+	enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+		return clusterCfg.Spec.Options.Kyverno.Enabled
+	},
+	activity: message("installing kyverno"),
+	success:  message("kyverno installed"),
+	factory:  newKyvernoInstaller,
+}
+
+func newKyvernoInstaller(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	helmClient *helm.Client,
+	_ string,
+	timeout time.Duration,
+) (installer.Installer, error) {
+	options := clusterCfg.Spec.Options.Kyverno
+
+	if options.PodSecurityStandard != "" {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "adding %s pod security policies",
+			Args:    []any{options.PodSecurityStandard},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	kyvernoInstaller := kyvernoinstaller.NewKyvernoInstaller(helmClient, timeout)
+	kyvernoInstaller.SetVersion(options.Version)
+	kyvernoInstaller.SetPolicies(string(options.PodSecurityStandard), options.Enforce)
+
+	return kyvernoInstaller, nil
+}
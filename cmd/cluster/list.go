@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
 	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
@@ -107,7 +108,7 @@ func listPrimaryClusters(
 	deps ListDeps,
 	includeDistribution bool,
 ) error {
-	provisioner, _, err := deps.Factory.Create(cmd.Context(), clusterCfg)
+	provisioner, _, err := cmdhelpers.CreateClusterProvisioner(cmd, deps.Factory, clusterCfg)
 	if err != nil {
 		return fmt.Errorf("failed to resolve cluster provisioner: %w", err)
 	}
@@ -130,6 +131,9 @@ func listAdditionalDistributionClusters(
 	for _, distribution := range []v1alpha1.Distribution{
 		v1alpha1.DistributionKind,
 		v1alpha1.DistributionK3d,
+		v1alpha1.DistributionTalos,
+		v1alpha1.DistributionK0s,
+		v1alpha1.DistributionKwok,
 	} {
 		if distribution == clusterCfg.Spec.Distribution {
 			continue
@@ -163,7 +167,7 @@ func listDistributionClusters(
 		)
 	}
 
-	otherProv, _, err := distributionFactory.Create(cmd.Context(), otherCluster)
+	otherProv, _, err := cmdhelpers.CreateClusterProvisioner(cmd, distributionFactory, otherCluster)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to create provisioner for distribution %s: %w",
@@ -211,6 +215,12 @@ func defaultDistributionConfigPath(distribution v1alpha1.Distribution) string {
 		return "kind.yaml"
 	case v1alpha1.DistributionK3d:
 		return "k3d.yaml"
+	case v1alpha1.DistributionTalos:
+		return "talos.yaml"
+	case v1alpha1.DistributionK0s:
+		return "k0s.yaml"
+	case v1alpha1.DistributionKwok:
+		return "kwok.yaml"
 	default:
 		return "kind.yaml"
 	}
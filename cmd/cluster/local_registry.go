@@ -10,6 +10,10 @@ import (
 	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	registry "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
 	"github.com/docker/docker/client"
 	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
@@ -364,13 +368,15 @@ func newLocalRegistryContext(
 	kindConfig *kindv1alpha4.Cluster,
 	k3dConfig *k3dv1alpha5.SimpleConfig,
 ) localRegistryContext {
-	clusterName := resolveLocalRegistryClusterName(clusterCfg, kindConfig, k3dConfig)
-	networkName := resolveLocalRegistryNetworkName(clusterCfg, clusterName)
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+	networkName := clusterprovisioner.DockerNetworkName(clusterCfg, clusterName)
 
 	return localRegistryContext{clusterName: clusterName, networkName: networkName}
 }
 
-func resolveLocalRegistryClusterName(
+// ResolveClusterName returns the name of the cluster the configuration describes, as its
+// distribution names it.
+func ResolveClusterName(
 	clusterCfg *v1alpha1.Cluster,
 	kindConfig *kindv1alpha4.Cluster,
 	k3dConfig *k3dv1alpha5.SimpleConfig,
@@ -384,6 +390,24 @@ func resolveLocalRegistryClusterName(
 		}
 	case v1alpha1.DistributionK3d:
 		return k3dconfigmanager.ResolveClusterName(clusterCfg, k3dConfig)
+	case v1alpha1.DistributionTalos:
+		if name := strings.TrimSpace(clusterCfg.Spec.ClusterName); name != "" {
+			return name
+		}
+
+		return talosprovisioner.DefaultClusterName
+	case v1alpha1.DistributionK0s:
+		if name := strings.TrimSpace(clusterCfg.Spec.ClusterName); name != "" {
+			return name
+		}
+
+		return k0sprovisioner.DefaultClusterName
+	case v1alpha1.DistributionKwok:
+		if name := strings.TrimSpace(clusterCfg.Spec.ClusterName); name != "" {
+			return name
+		}
+
+		return kwokprovisioner.DefaultClusterName
 	}
 
 	if name := strings.TrimSpace(clusterCfg.Spec.Connection.Context); name != "" {
@@ -393,25 +417,6 @@ func resolveLocalRegistryClusterName(
 	return "ksail"
 }
 
-func resolveLocalRegistryNetworkName(
-	clusterCfg *v1alpha1.Cluster,
-	clusterName string,
-) string {
-	switch clusterCfg.Spec.Distribution {
-	case v1alpha1.DistributionKind:
-		return "kind"
-	case v1alpha1.DistributionK3d:
-		trimmed := strings.TrimSpace(clusterName)
-		if trimmed == "" {
-			trimmed = "k3d"
-		}
-
-		return "k3d-" + trimmed
-	default:
-		return ""
-	}
-}
-
 func newLocalRegistryCreateOptions(
 	clusterCfg *v1alpha1.Cluster,
 	ctx localRegistryContext,
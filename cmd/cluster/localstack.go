@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	localstackinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/localstack"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// localStackComponent deploys or connects LocalStack and publishes its endpoint to workloads
+// in the localstack-endpoint ConfigMap.
+//
+//nolint:gochecknoglobals // entry of the optionalComponents table
+var localStackComponent = optionalComponent{
+	name:  "localstack",
+	title: "Install LocalStack...",
+	emoji: "☁️",
+	enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+		return clusterCfg.Spec.Options.LocalStack.Enabled
+	},
+	activity: func(clusterCfg *v1alpha1.Cluster) string {
+		if endpoint := clusterCfg.Spec.Options.LocalStack.Endpoint; endpoint != "" {
+			return "connecting localstack at " + endpoint
+		}
+
+		return "installing localstack"
+	},
+	success: message(fmt.Sprintf(
+		"localstack endpoint published in configmap %s/%s",
+		localstackinstaller.ConfigMapNamespace,
+		localstackinstaller.ConfigMapName,
+	)),
+	factory: newLocalStackInstaller,
+}
+
+func newLocalStackInstaller(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	helmClient *helm.Client,
+	kubeconfig string,
+	timeout time.Duration,
+) (installer.Installer, error) {
+	options := clusterCfg.Spec.Options.LocalStack
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	lsInstaller := localstackinstaller.NewLocalStackInstaller(helmClient, clientset, options.Endpoint, timeout)
+	lsInstaller.SetVersion(options.Version)
+
+	return lsInstaller, nil
+}
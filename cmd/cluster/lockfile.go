@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/devantler-tech/ksail-go/pkg/svc/prepull"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	k3dtypes "github.com/k3d-io/k3d/v5/pkg/types"
+	k3dversion "github.com/k3d-io/k3d/v5/version"
+	"github.com/spf13/cobra"
+	kinddefaults "sigs.k8s.io/kind/pkg/apis/config/defaults"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+//nolint:gochecknoglobals // Injected for testability to resolve image digests without a registry.
+var imageDigestResolver = prepull.ResolveDigest
+
+// lockClusterImages pins the node image and the digests of the component images of the
+// cluster in the project's lock. Frozen locks fail when a pin is missing or differs; otherwise
+// only unpinned images are resolved, and images that cannot be resolved are reported as
+// warnings so clusters can still be created offline.
+func lockClusterImages(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) error {
+	return LockImages(
+		cmd,
+		cmdhelpers.ProjectLock(cmd),
+		ClusterNodeImage(clusterCfg, kindConfig, k3dConfig),
+		prepull.ComponentImages(clusterCfg),
+	)
+}
+
+// LockImages pins nodeImage and the digests of images in lock, as the mode of lock allows.
+func LockImages(
+	cmd *cobra.Command,
+	lock *lockfile.Lock,
+	nodeImage string,
+	images []string,
+) error {
+	if nodeImage != "" {
+		err := lock.RecordNodeImage(nodeImage)
+		if err != nil {
+			return fmt.Errorf("failed to lock node image: %w", err)
+		}
+	}
+
+	for _, image := range images {
+		if lock.Mode() == lockfile.ModeRecord {
+			_, locked, err := lock.Image(image)
+			if err != nil {
+				return fmt.Errorf("failed to read lock file: %w", err)
+			}
+
+			if locked {
+				continue
+			}
+		}
+
+		if offline.Enabled(cmd.Context()) {
+			// Pins cannot be resolved offline; frozen locks must already pin every image.
+			if lock.Mode() == lockfile.ModeFrozen {
+				_, locked, err := lock.Image(image)
+				if err != nil {
+					return fmt.Errorf("failed to read lock file: %w", err)
+				}
+
+				if !locked {
+					return fmt.Errorf("%w: image %s is not locked", lockfile.ErrOutOfDate, image)
+				}
+
+				continue
+			}
+
+			guardErr := offline.Guard(cmd.Context(), "resolve the digest of image %s", image)
+			if lock.Mode() == lockfile.ModeUpdate {
+				return guardErr
+			}
+
+			continue
+		}
+
+		digest, err := imageDigestResolver(cmd.Context(), image)
+		if err != nil {
+			if lock.Mode() != lockfile.ModeRecord {
+				return fmt.Errorf("failed to pin image: %w", err)
+			}
+
+			notify.WriteMessage(notify.Message{
+				Type:    notify.WarningType,
+				Content: "failed to pin image in %s: %v",
+				Args:    []any{lockfile.DefaultPath, err},
+				Writer:  cmd.OutOrStdout(),
+			})
+
+			continue
+		}
+
+		err = lock.RecordImage(image, digest)
+		if err != nil {
+			return fmt.Errorf("failed to lock image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ClusterNodeImage returns the image the cluster's nodes are created from, falling back to
+// the default image of the distribution.
+func ClusterNodeImage(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) string {
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		if kindConfig != nil {
+			for _, node := range kindConfig.Nodes {
+				if node.Image != "" {
+					return node.Image
+				}
+			}
+		}
+
+		return kinddefaults.Image
+	case v1alpha1.DistributionK3d:
+		if k3dConfig != nil && k3dConfig.Image != "" {
+			return k3dConfig.Image
+		}
+
+		return k3dtypes.DefaultK3sImageRepo + ":" + k3dversion.K3sVersion
+	default:
+		return ""
+	}
+}
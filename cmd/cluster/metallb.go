@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	metallbinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metallb"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+)
+
+// installMetalLBIfConfigured installs MetalLB so Services of type LoadBalancer get an address
+// of the cluster's Docker network, which the host can reach.
+func installMetalLBIfConfigured(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+) error {
+	options := clusterCfg.Spec.Options.MetalLB
+	if !options.Enabled {
+		return nil
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Install MetalLB...",
+		Emoji:   "⚖️",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	addresses := options.Addresses
+	if addresses == "" {
+		var err error
+
+		addresses, err = dockerNetworkAddressRange(cmd, clusterCfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepMetalLB)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(
+		cmd,
+		kubeconfig,
+		clusterCfg.Spec.Connection.Context,
+	)
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "installing metallb with addresses %s",
+		Args:    []any{addresses},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	metalLBInstaller := metallbinstaller.NewMetalLBInstaller(
+		helmClient,
+		dynamicClient,
+		addresses,
+		installer.GetComponentTimeout(clusterCfg, createStepMetalLB),
+	)
+	metalLBInstaller.SetVersion(options.Version)
+
+	err = audit.Track(cmd.Context(), audit.ActionComponentInstall, "metallb", func() error {
+		return metalLBInstaller.Install(cmd.Context())
+	})
+	if err != nil {
+		return fmt.Errorf("metallb installation failed: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "metallb installed",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// dockerNetworkAddressRange returns an address range at the top of the cluster's Docker
+// network for MetalLB to assign.
+func dockerNetworkAddressRange(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) (string, error) {
+	// Kind names its network independently of the cluster.
+	networkName := clusterprovisioner.DockerNetworkName(clusterCfg, "")
+
+	dockerClientInvokerMu.RLock()
+
+	invoker := dockerClientInvoker
+
+	dockerClientInvokerMu.RUnlock()
+
+	var addresses string
+
+	err := invoker(cmd, func(dockerClient client.APIClient) error {
+		subnet, err := dockerclient.NetworkIPv4Subnet(cmd.Context(), dockerClient, networkName)
+		if err != nil {
+			return fmt.Errorf("detect subnet: %w", err)
+		}
+
+		addresses, err = metallbinstaller.AddressRange(subnet)
+		if err != nil {
+			return fmt.Errorf("pick address range: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to pick MetalLB addresses from docker network %q: %w", networkName, err)
+	}
+
+	return addresses, nil
+}
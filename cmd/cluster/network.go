@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// dockerNetworkManaged reports whether KSail creates the cluster's Docker network itself, which
+// it does when a subnet is configured so the nodes and registries get a deterministic range.
+func dockerNetworkManaged(clusterCfg *v1alpha1.Cluster) bool {
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind, v1alpha1.DistributionK3d:
+		return strings.TrimSpace(clusterCfg.Spec.Networking.DockerNetwork.Subnet) != ""
+	default:
+		return false
+	}
+}
+
+// ensureDockerNetwork creates the cluster's Docker network with the configured subnet and
+// gateway before registries and nodes attach to it. An existing network is reused when its
+// subnet matches, and a subnet overlapping another network is rejected.
+func ensureDockerNetwork(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+	firstActivityShown *bool,
+) error {
+	if !dockerNetworkManaged(clusterCfg) {
+		return nil
+	}
+
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+	networkConfig := dockerclient.NetworkConfig{
+		Name:    clusterprovisioner.DockerNetworkName(clusterCfg, clusterName),
+		Subnet:  clusterCfg.Spec.Networking.DockerNetwork.Subnet,
+		Gateway: strings.TrimSpace(clusterCfg.Spec.Networking.DockerNetwork.Gateway),
+	}
+
+	dockerClientInvokerMu.RLock()
+
+	invoker := dockerClientInvoker
+
+	dockerClientInvokerMu.RUnlock()
+
+	var created bool
+
+	err := invoker(cmd, func(dockerClient client.APIClient) error {
+		var err error
+
+		created, err = dockerclient.EnsureNetwork(cmd.Context(), dockerClient, networkConfig)
+		if err != nil {
+			return fmt.Errorf("ensure network %q: %w", networkConfig.Name, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create docker network: %w", err)
+	}
+
+	if !created {
+		return nil
+	}
+
+	deps.Timer.NewStage()
+
+	if *firstActivityShown {
+		cmd.Println()
+	}
+
+	*firstActivityShown = true
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Create network...",
+		Emoji:   "🌐",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "creating '%s' with subnet %s",
+		Args:    []any{networkConfig.Name, networkConfig.Subnet},
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "network created",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// removeDockerNetwork removes the Docker network KSail created for the cluster once no
+// containers use it, warning instead of failing so deletion always completes.
+func removeDockerNetwork(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) {
+	if !dockerNetworkManaged(clusterCfg) {
+		return
+	}
+
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err == nil {
+		clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+		networkName := clusterprovisioner.DockerNetworkName(clusterCfg, clusterName)
+
+		dockerClientInvokerMu.RLock()
+
+		invoker := dockerClientInvoker
+
+		dockerClientInvokerMu.RUnlock()
+
+		err = invoker(cmd, func(dockerClient client.APIClient) error {
+			_, err := dockerclient.RemoveNetworkIfUnused(cmd.Context(), dockerClient, networkName)
+			if err != nil {
+				return fmt.Errorf("remove network %q: %w", networkName, err)
+			}
+
+			return nil
+		})
+	}
+
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: fmt.Sprintf("failed to remove docker network: %v", err),
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+}
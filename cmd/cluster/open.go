@@ -0,0 +1,251 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
+	headlampinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/headlamp"
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	// ErrUnknownComponent is returned when ksail cluster open is asked for a component it cannot open.
+	ErrUnknownComponent = errors.New("unknown component")
+	// ErrComponentNotEnabled is returned when the component to open is not enabled in ksail.yaml.
+	ErrComponentNotEnabled = errors.New("component is not enabled")
+)
+
+// openTarget describes a web UI that ksail cluster open forwards to.
+type openTarget struct {
+	option    string
+	namespace string
+	service   string
+	port      int
+	enabled   func(options v1alpha1.Options) bool
+	// login returns how to log in to the UI, if it needs credentials.
+	login func(ctx context.Context, restConfig *rest.Config, options v1alpha1.Options) (string, error)
+}
+
+//nolint:gochecknoglobals // Catalog of the web UIs ksail cluster open supports.
+var openTargets = map[string]openTarget{
+	"headlamp": {
+		option:    "headlamp",
+		namespace: headlampinstaller.Namespace,
+		service:   headlampinstaller.ServiceName,
+		port:      headlampinstaller.ServicePort,
+		enabled:   func(options v1alpha1.Options) bool { return options.Headlamp.Enabled },
+		login:     headlampLogin,
+	},
+	"gitea": {
+		option:    "gitea",
+		namespace: gitea.Namespace,
+		service:   gitea.ServiceName,
+		port:      gitea.HTTPPort,
+		enabled:   func(options v1alpha1.Options) bool { return options.Gitea.Enabled },
+		login: func(context.Context, *rest.Config, v1alpha1.Options) (string, error) {
+			return fmt.Sprintf("log in as %s with password %s", gitea.AdminUsername, gitea.AdminPassword), nil
+		},
+	},
+	"vault": {
+		option:    "vault",
+		namespace: vaultinstaller.Namespace,
+		service:   vaultinstaller.ServiceName,
+		port:      vaultinstaller.ServicePort,
+		enabled:   func(options v1alpha1.Options) bool { return options.Vault.Enabled },
+		login: func(_ context.Context, _ *rest.Config, options v1alpha1.Options) (string, error) {
+			token := options.Vault.Token
+			if token == "" {
+				token = vaultinstaller.DefaultToken
+			}
+
+			return "log in with token " + token, nil
+		},
+	},
+}
+
+// NewOpenCmd creates the open command, which forwards a web UI of the cluster to the host.
+func NewOpenCmd(_ *runtime.Runtime) *cobra.Command {
+	var noBrowser bool
+
+	components := openComponents()
+
+	cmd := &cobra.Command{
+		Use:   "open <component>",
+		Short: "Open the web UI of a cluster component",
+		Long: `Forward a local port to the web UI of a component KSail installed and open it in the ` +
+			`browser, printing the credentials to log in with. Forwarding stops with Ctrl+C.
+
+Supported components: ` + strings.Join(components, ", ") + `. The component must be enabled ` +
+			`under spec.options in ksail.yaml, e.g. spec.options.headlamp.enabled.`,
+		Args:         cobra.ExactArgs(1),
+		ValidArgs:    components,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Print the URL without opening the browser")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return handleOpenRunE(cmd, cfgManager, args[0], noBrowser)
+	}
+
+	return cmd
+}
+
+func handleOpenRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	component string,
+	noBrowser bool,
+) error {
+	name := strings.ToLower(component)
+
+	target, ok := openTargets[name]
+	if !ok {
+		return fmt.Errorf("%w: %s (supported: %s)", ErrUnknownComponent, component,
+			strings.Join(openComponents(), ", "))
+	}
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	if !target.enabled(clusterCfg.Spec.Options) {
+		return fmt.Errorf("%w: %s (set spec.options.%s.enabled in ksail.yaml and create the cluster)",
+			ErrComponentNotEnabled, name, target.option)
+	}
+
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	forward, err := k8s.ForwardService(ctx, restConfig, target.namespace, target.service, target.port)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+
+	defer forward.Close()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%s is available at %s",
+		Args:    []any{name, forward.LocalURL},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	login, err := target.login(ctx, restConfig, clusterCfg.Spec.Options)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "%s",
+		Args:    []any{login},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	if !noBrowser {
+		err = openBrowser(ctx, forward.LocalURL)
+		if err != nil {
+			notify.WriteMessage(notify.Message{
+				Type:    notify.WarningType,
+				Content: "failed to open the browser, open %s manually: %v",
+				Args:    []any{forward.LocalURL, err},
+				Writer:  cmd.OutOrStdout(),
+			})
+		}
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "forwarding to %s, press Ctrl+C to stop",
+		Args:    []any{name},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func headlampLogin(ctx context.Context, restConfig *rest.Config, _ v1alpha1.Options) (string, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	token, err := headlampinstaller.LoginToken(ctx, clientset)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue headlamp login token: %w", err)
+	}
+
+	return "log in with token " + token, nil
+}
+
+// openComponents returns the names of the components ksail cluster open supports.
+func openComponents() []string {
+	components := make([]string, 0, len(openTargets))
+	for name := range openTargets {
+		components = append(components, name)
+	}
+
+	slices.Sort(components)
+
+	return components
+}
+
+// openBrowser opens url with the platform's URL handler.
+func openBrowser(ctx context.Context, url string) error {
+	var browser *exec.Cmd
+
+	switch goruntime.GOOS {
+	case "darwin":
+		browser = exec.CommandContext(ctx, "open", url)
+	case "windows":
+		browser = exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		browser = exec.CommandContext(ctx, "xdg-open", url)
+	}
+
+	err := browser.Start()
+	if err != nil {
+		return fmt.Errorf("start %s: %w", browser.Path, err)
+	}
+
+	go func() { _ = browser.Wait() }()
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package cluster_test
+
+import (
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestOpenCmdRejectsUnknownAndDisabledComponents(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t)
+	t.Chdir(project.Dir)
+
+	result := cmdtestutils.ExecuteCommand(t, clusterpkg.NewOpenCmd(runtime.NewRuntime()), "grafana")
+	require.ErrorIs(t, result.Err, clusterpkg.ErrUnknownComponent)
+	require.ErrorContains(t, result.Err, "supported: gitea, headlamp, vault")
+
+	result = cmdtestutils.ExecuteCommand(t, clusterpkg.NewOpenCmd(runtime.NewRuntime()), "Headlamp")
+	require.ErrorIs(t, result.Err, clusterpkg.ErrComponentNotEnabled)
+	require.ErrorContains(t, result.Err, "spec.options.headlamp.enabled")
+}
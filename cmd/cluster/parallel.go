@@ -0,0 +1,213 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+// parallelStage is a create stage that does not depend on the stages it runs alongside.
+type parallelStage struct {
+	name string
+	run  func(cmd *cobra.Command, deps cmdhelpers.LifecycleDeps, firstActivityShown *bool) error
+}
+
+// runParallelStages runs the stages concurrently and records how long each took in
+// breakdown. Every stage writes to its own buffer and times itself with a fork of the
+// command's timer; the buffers are flushed in declaration order once all stages finish, so
+// the output reads as if the stages ran one after another.
+func runParallelStages(
+	cmd *cobra.Command,
+	deps cmdhelpers.LifecycleDeps,
+	breakdown *timer.Breakdown,
+	firstActivityShown *bool,
+	stages ...parallelStage,
+) error {
+	outputs := make([]bytes.Buffer, len(stages))
+	errs := make([]error, len(stages))
+
+	var waiting sync.WaitGroup
+
+	for index, stage := range stages {
+		stageCmd := newStageCommand(cmd, &outputs[index])
+		stageDeps := deps
+		stageDeps.Timer = timer.Fork(deps.Timer)
+
+		waiting.Go(func() {
+			defer breakdown.Track(stage.name)()
+
+			// Each stage starts without a preceding activity; flushStageOutput adds the separator.
+			shown := false
+
+			errs[index] = stage.run(stageCmd, stageDeps, &shown)
+		})
+	}
+
+	waiting.Wait()
+
+	for index := range stages {
+		flushStageOutput(cmd.OutOrStdout(), &outputs[index], firstActivityShown)
+	}
+
+	return errors.Join(errs...)
+}
+
+// newStageCommand returns a command that shares the flags and context of cmd but writes its
+// output to out.
+func newStageCommand(cmd *cobra.Command, out io.Writer) *cobra.Command {
+	stageCmd := &cobra.Command{Use: cmd.Use}
+	stageCmd.Flags().AddFlagSet(cmd.Flags())
+	stageCmd.SetOut(out)
+	stageCmd.SetErr(out)
+	stageCmd.SetContext(cmd.Context())
+
+	return stageCmd
+}
+
+func flushStageOutput(writer io.Writer, output *bytes.Buffer, firstActivityShown *bool) {
+	if output.Len() == 0 {
+		return
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(writer)
+	}
+
+	*firstActivityShown = true
+
+	_, _ = output.WriteTo(writer)
+}
+
+// componentOutput serializes the output of component steps that install concurrently. Every
+// step writes to its own buffer and times itself with a fork of the command's timer; a step's
+// buffer is flushed as soon as it finishes, so components report in the order they complete
+// and their output never interleaves.
+type componentOutput struct {
+	mutex              sync.Mutex
+	cmd                *cobra.Command
+	tmr                timer.Timer
+	breakdown          *timer.Breakdown
+	firstActivityShown *bool
+}
+
+// run runs install with a command and timer of its own, then flushes its output. Components
+// that printed nothing, because they are not enabled, are left out of the breakdown.
+func (o *componentOutput) run(
+	name string,
+	install func(cmd *cobra.Command, tmr timer.Timer, firstActivityShown *bool) error,
+) error {
+	var output bytes.Buffer
+
+	stageCmd := newStageCommand(o.cmd, &output)
+	record := o.breakdown.Track(name)
+
+	// Each step starts without a preceding activity; flushStageOutput adds the separator.
+	shown := false
+
+	err := install(stageCmd, timer.Fork(o.tmr), &shown)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if output.Len() > 0 {
+		record()
+	}
+
+	flushStageOutput(o.cmd.OutOrStdout(), &output, o.firstActivityShown)
+
+	return err
+}
+
+// pullNodeImage pulls the image the cluster's nodes are created from unless it is present
+// locally, so the provisioner does not pull it after the registries are ready. Offline runs
+// and distributions without a known node image, which is then empty, are skipped.
+func pullNodeImage(
+	cmd *cobra.Command,
+	nodeImage string,
+	deps cmdhelpers.LifecycleDeps,
+	firstActivityShown *bool,
+) error {
+	if nodeImage == "" || offline.Enabled(cmd.Context()) {
+		return nil
+	}
+
+	dockerClientInvokerMu.RLock()
+
+	invoker := dockerClientInvoker
+
+	dockerClientInvokerMu.RUnlock()
+
+	return invoker(cmd, func(dockerClient client.APIClient) error {
+		_, err := dockerClient.ImageInspect(cmd.Context(), nodeImage)
+		if err == nil {
+			return nil
+		}
+
+		deps.Timer.NewStage()
+
+		if *firstActivityShown {
+			cmd.Println()
+		}
+
+		*firstActivityShown = true
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.TitleType,
+			Content: "Pull node image...",
+			Emoji:   "🐳",
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "pulling %s",
+			Args:    []any{nodeImage},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		err = pullImage(cmd.Context(), dockerClient, nodeImage)
+		if err != nil {
+			return fmt.Errorf("failed to pull node image %s: %w", nodeImage, err)
+		}
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.SuccessType,
+			Content: "node image pulled",
+			Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
+	})
+}
+
+func pullImage(ctx context.Context, dockerClient client.APIClient, ref string) error {
+	reader, err := dockerClient.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("docker pull: %w", err)
+	}
+
+	_, err = io.Copy(io.Discard, reader)
+	closeErr := reader.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to read image pull output: %w", err)
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close image pull reader: %w", closeErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	plugininstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/plugin"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/spf13/cobra"
+)
+
+// pluginStepPrefix keeps the create steps of plugins apart from KSail's own components.
+const pluginStepPrefix = "plugin/"
+
+// componentStep is a component install that create runs once the steps it depends on succeed.
+type componentStep struct {
+	name      string
+	dependsOn []string
+	install   func(*cobra.Command, *v1alpha1.Cluster, timer.Timer, *bool) error
+}
+
+// pluginSteps returns a create step per plugin. A plugin without dependencies is installed
+// after the CNI; DependsOn entries naming another plugin refer to that plugin's step.
+func pluginSteps(plugins []v1alpha1.Plugin) []componentStep {
+	names := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name)
+	}
+
+	steps := make([]componentStep, 0, len(plugins))
+
+	for _, plugin := range plugins {
+		dependsOn := []string{createStepCNI}
+		if len(plugin.DependsOn) > 0 {
+			dependsOn = make([]string, 0, len(plugin.DependsOn))
+
+			for _, dependency := range plugin.DependsOn {
+				if slices.Contains(names, dependency) {
+					dependency = pluginStepPrefix + dependency
+				}
+
+				dependsOn = append(dependsOn, dependency)
+			}
+		}
+
+		steps = append(steps, componentStep{
+			name:      pluginStepPrefix + plugin.Name,
+			dependsOn: dependsOn,
+			install: func(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr timer.Timer, shown *bool) error {
+				return installPlugin(cmd, clusterCfg, plugin, tmr, shown)
+			},
+		})
+	}
+
+	return steps
+}
+
+func installPlugin(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	plugin v1alpha1.Plugin,
+	tmr timer.Timer,
+	firstActivityShown *bool,
+) error {
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Install %s...",
+		Args:    []any{plugin.Name},
+		Emoji:   "🔌",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	pluginInstaller, err := newPluginInstaller(clusterCfg, plugin)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "running %s install",
+		Args:    []any{plugin.Command},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = audit.Track(cmd.Context(), audit.ActionComponentInstall, plugin.Name, func() error {
+		return pluginInstaller.Install(cmd.Context())
+	})
+	if err != nil {
+		return fmt.Errorf("%s installation failed: %w", plugin.Name, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%s installed",
+		Args:    []any{plugin.Name},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// newPluginInstaller creates the installer of plugin for the cluster, resolving its command
+// against the working directory, which is the project directory.
+func newPluginInstaller(
+	clusterCfg *v1alpha1.Cluster,
+	plugin v1alpha1.Plugin,
+) (*plugininstaller.PluginInstaller, error) {
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	return plugininstaller.NewPluginInstaller(
+		plugin,
+		plugininstaller.Cluster{
+			Kubeconfig:   kubeconfig,
+			Context:      clusterCfg.Spec.Connection.Context,
+			Distribution: clusterCfg.Spec.Distribution,
+			ProjectDir:   projectDir,
+		},
+		installer.GetComponentTimeout(clusterCfg, pluginStepPrefix+plugin.Name),
+	), nil
+}
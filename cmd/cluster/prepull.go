@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/devantler-tech/ksail-go/pkg/svc/prepull"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+const prePullImagesFlag = "prepull-images"
+
+// imagePrePull tracks image pre-pulling that runs in the background while nodes boot.
+type imagePrePull struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	result prepull.Result
+	err    error
+}
+
+// startImagePrePull starts fetching the images of declared components through the mirror
+// registries. It returns nil when pre-pulling is disabled, the run is offline or no component
+// needs images.
+func startImagePrePull(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) *imagePrePull {
+	enabled, err := cmd.Flags().GetBool(prePullImagesFlag)
+	if err != nil || !enabled || offline.Enabled(cmd.Context()) {
+		return nil
+	}
+
+	images := prepull.ComponentImages(clusterCfg)
+	if len(images) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	pull := &imagePrePull{cancel: cancel, done: make(chan struct{})}
+
+	dockerClientInvokerMu.RLock()
+
+	invoker := dockerClientInvoker
+
+	dockerClientInvokerMu.RUnlock()
+
+	go func() {
+		defer close(pull.done)
+
+		pull.err = invoker(cmd, func(dockerClient client.APIClient) error {
+			mirrors, err := prepull.DiscoverMirrors(ctx, dockerClient, prepull.RegistryHosts(images))
+			if err != nil {
+				return fmt.Errorf("failed to discover mirror registries: %w", err)
+			}
+
+			pull.result = prepull.NewPrePuller().Run(ctx, images, mirrors)
+
+			return nil
+		})
+	}()
+
+	return pull
+}
+
+// stop cancels pre-pulling that is still running, e.g. when cluster creation fails.
+func (p *imagePrePull) stop() {
+	if p == nil {
+		return
+	}
+
+	p.cancel()
+	<-p.done
+}
+
+// wait blocks until pre-pulling finishes and reports how many images were cached. Failures
+// are only warnings, since the nodes fall back to pulling images themselves.
+func (p *imagePrePull) wait(cmd *cobra.Command, tmr timer.Timer, firstActivityShown *bool) {
+	if p == nil {
+		return
+	}
+
+	<-p.done
+
+	if p.err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "skipped image pre-pull: %v",
+			Args:    []any{p.err},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return
+	}
+
+	if len(p.result.Pulled) == 0 && len(p.result.Failed) == 0 {
+		return
+	}
+
+	if *firstActivityShown {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	}
+
+	*firstActivityShown = true
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Pre-pull images...",
+		Emoji:   "📦",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	failed := make([]string, 0, len(p.result.Failed))
+	for image := range p.result.Failed {
+		failed = append(failed, image)
+	}
+
+	sort.Strings(failed)
+
+	for _, image := range failed {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to pre-pull %s: %v",
+			Args:    []any{image, p.result.Failed[image]},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%d images cached in mirror registries",
+		Args:    []any{len(p.result.Pulled)},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+}
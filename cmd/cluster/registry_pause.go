@@ -0,0 +1,189 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	k3dprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k3d"
+	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const pauseRegistriesFlag = "pause-registries"
+
+// registryToggle pauses or resumes the named registry on networkName and reports whether it
+// changed its state.
+type registryToggle func(
+	ctx context.Context,
+	registryMgr *dockerclient.RegistryManager,
+	name, networkName string,
+) (bool, error)
+
+// registryToggleStage describes the output of pausing or resuming the cluster's registries.
+type registryToggleStage struct {
+	title    string
+	emoji    string
+	activity string
+	success  string
+	failure  string
+	toggle   registryToggle
+}
+
+//nolint:gochecknoglobals // Stage definitions shared by cluster stop and start.
+var (
+	pauseRegistriesStage = registryToggleStage{
+		title:    "Pause registries...",
+		emoji:    "⏸️",
+		activity: "pausing '%s'",
+		success:  "registries paused",
+		failure:  "failed to pause registries",
+		toggle: func(
+			ctx context.Context,
+			registryMgr *dockerclient.RegistryManager,
+			name, networkName string,
+		) (bool, error) {
+			return registryMgr.PauseRegistry(ctx, name, networkName)
+		},
+	}
+	resumeRegistriesStage = registryToggleStage{
+		title:    "Resume registries...",
+		emoji:    "⏯️",
+		activity: "resuming '%s'",
+		success:  "registries resumed",
+		failure:  "failed to resume registries",
+		toggle: func(
+			ctx context.Context,
+			registryMgr *dockerclient.RegistryManager,
+			name, networkName string,
+		) (bool, error) {
+			return registryMgr.ResumeRegistry(ctx, name, networkName)
+		},
+	}
+)
+
+// clusterRegistryNames returns the container names of the mirror registries and the local
+// registry the cluster uses.
+func clusterRegistryNames(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) []string {
+	var infos []registry.Info
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		infos = kindprovisioner.ExtractRegistriesFromKindForTesting(kindConfig, nil)
+	case v1alpha1.DistributionK3d:
+		if k3dConfig != nil {
+			infos = k3dprovisioner.ExtractRegistriesFromConfigForTesting(k3dConfig)
+		}
+	}
+
+	names := registry.CollectRegistryNames(infos)
+
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		names = append(names, buildLocalRegistryName())
+	}
+
+	return names
+}
+
+// toggleClusterRegistries pauses or resumes the cluster's registries. The stage is only
+// reported when a registry changed its state; registries that were never created are skipped.
+func toggleClusterRegistries(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+	stage registryToggleStage,
+	firstActivityShown *bool,
+) error {
+	names := clusterRegistryNames(clusterCfg, kindConfig, k3dConfig)
+	if len(names) == 0 {
+		return nil
+	}
+
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+	networkName := clusterprovisioner.DockerNetworkName(clusterCfg, clusterName)
+
+	dockerClientInvokerMu.RLock()
+
+	invoker := dockerClientInvoker
+
+	dockerClientInvokerMu.RUnlock()
+
+	var toggled []string
+
+	err := invoker(cmd, func(dockerClient client.APIClient) error {
+		registryMgr, err := dockerclient.NewRegistryManager(dockerClient)
+		if err != nil {
+			return fmt.Errorf("create registry manager: %w", err)
+		}
+
+		for _, name := range names {
+			changed, err := stage.toggle(cmd.Context(), registryMgr, name, networkName)
+			if errors.Is(err, dockerclient.ErrRegistryNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			if changed {
+				toggled = append(toggled, name)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", stage.failure, err)
+	}
+
+	if len(toggled) == 0 {
+		return nil
+	}
+
+	if *firstActivityShown {
+		cmd.Println()
+	}
+
+	*firstActivityShown = true
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: stage.title,
+		Emoji:   stage.emoji,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	for _, name := range toggled {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: stage.activity,
+			Args:    []any{name},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: stage.success,
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/sshtunnel"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+var (
+	errKubeconfigContextNotFound = errors.New("kubeconfig context not found")
+	errAPIServerPortNotFound     = errors.New("API server port not found in kubeconfig")
+)
+
+// useDockerHost points every Docker client of this process, including the Kind and K3d
+// tooling, at spec.dockerHost when it is set.
+func useDockerHost(clusterCfg *v1alpha1.Cluster) error {
+	host := strings.TrimSpace(clusterCfg.Spec.DockerHost)
+	if host == "" {
+		return nil
+	}
+
+	err := os.Setenv(client.EnvOverrideHost, host)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", client.EnvOverrideHost, err)
+	}
+
+	return nil
+}
+
+// remoteDockerHost returns the ssh:// Docker host the cluster runs on, or "" when it runs
+// on a local or non-SSH host.
+func remoteDockerHost(clusterCfg *v1alpha1.Cluster) string {
+	host := strings.TrimSpace(clusterCfg.Spec.DockerHost)
+	if host == "" {
+		host = os.Getenv(client.EnvOverrideHost)
+	}
+
+	if !sshtunnel.IsSSHHost(host) {
+		return ""
+	}
+
+	return host
+}
+
+// openRemoteTunnel forwards the API server and local registry ports of a cluster running on
+// a remote Docker host to localhost, so the kubeconfig and registry endpoints work as usual.
+// It does nothing for clusters on a local Docker host.
+func openRemoteTunnel(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	kindConfig *kindv1alpha4.Cluster,
+	k3dConfig *k3dv1alpha5.SimpleConfig,
+	firstActivityShown *bool,
+) error {
+	host := remoteDockerHost(clusterCfg)
+	if host == "" {
+		return nil
+	}
+
+	ports, err := remoteTunnelPorts(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to resolve ports to tunnel: %w", err)
+	}
+
+	clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+	tunnel := sshtunnel.Tunnel{Host: host, Ports: ports, ControlPath: sshtunnel.ControlPath(clusterName)}
+
+	deps.Timer.NewStage()
+
+	if *firstActivityShown {
+		cmd.Println()
+	}
+
+	*firstActivityShown = true
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Tunnel remote ports...",
+		Emoji:   "🔌",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "forwarding %s from '%s' to localhost",
+		Args:    []any{formatPorts(ports), host},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = sshtunnel.NewService().Open(cmd.Context(), tunnel)
+	if err != nil {
+		return fmt.Errorf("failed to tunnel remote ports: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "remote ports tunneled",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// closeRemoteTunnel stops the tunnel opened by openRemoteTunnel, warning instead of failing
+// so a stale tunnel never blocks stopping or deleting the cluster.
+func closeRemoteTunnel(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) {
+	host := remoteDockerHost(clusterCfg)
+	if host == "" {
+		return
+	}
+
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+	if err == nil {
+		clusterName := ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+		tunnel := sshtunnel.Tunnel{Host: host, ControlPath: sshtunnel.ControlPath(clusterName)}
+
+		err = sshtunnel.NewService().Close(cmd.Context(), tunnel)
+	}
+
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: fmt.Sprintf("failed to close ssh tunnel: %v", err),
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+}
+
+// remoteTunnelPorts returns the API server port from the cluster's kubeconfig context and,
+// when the local registry is enabled, its host port.
+func remoteTunnelPorts(clusterCfg *v1alpha1.Cluster) ([]int, error) {
+	kubeconfigPath, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+
+	apiServerPort, err := apiServerPort(kubeconfigPath, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := []int{apiServerPort}
+
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		ports = append(ports, resolveLocalRegistryPort(clusterCfg))
+	}
+
+	return ports, nil
+}
+
+// apiServerPort reads the API server port of contextName (or the current context) from the
+// kubeconfig at path.
+func apiServerPort(path, contextName string) (int, error) {
+	config, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	kubeContext, ok := config.Contexts[contextName]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errKubeconfigContextNotFound, contextName)
+	}
+
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return 0, fmt.Errorf("%w: context %q", errAPIServerPortNotFound, contextName)
+	}
+
+	server, err := url.Parse(cluster.Server)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse API server URL: %w", err)
+	}
+
+	port, err := strconv.Atoi(server.Port())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errAPIServerPortNotFound, cluster.Server)
+	}
+
+	return port, nil
+}
+
+func formatPorts(ports []int) string {
+	formatted := make([]string, 0, len(ports))
+	for _, port := range ports {
+		formatted = append(formatted, strconv.Itoa(port))
+	}
+
+	label := "port "
+	if len(ports) > 1 {
+		label = "ports "
+	}
+
+	return label + strings.Join(formatted, ", ")
+}
@@ -0,0 +1,302 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	argorolloutsinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argo-rollouts"
+	argocdinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argocd"
+	cnpginstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cnpg"
+	coreinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/core"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	gatewayapiinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gateway-api"
+	giteainstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gitea"
+	headlampinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/headlamp"
+	kyvernoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/kyverno"
+	localstackinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/localstack"
+	metallbinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metallb"
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	dryRunFlag    = "dry-run"
+	dryRunDirFlag = "dry-run-dir"
+	// defaultDryRunDir is where dry runs render to, relative to the project directory.
+	defaultDryRunDir = "rendered"
+
+	renderedDirMode  = 0o750
+	renderedFileMode = 0o600
+)
+
+// componentRender renders one component for a dry run. render installs the component's charts
+// with the renderer and returns the resources its installer applies besides them.
+type componentRender struct {
+	name   string
+	render func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error)
+}
+
+// addDryRunFlags registers the flags that render components instead of creating the cluster.
+func addDryRunFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(dryRunFlag, false,
+		"Render the manifests of the components instead of creating the cluster")
+	cmd.Flags().String(dryRunDirFlag, defaultDryRunDir,
+		"Directory --dry-run renders the manifests into")
+}
+
+// renderComponents writes the manifests of the components create would install into the
+// directory of --dry-run-dir, without creating the cluster: every Helm release to
+// <dir>/<namespace>/<release>.yaml and the resources installers apply besides their charts to
+// <dir>/<component>.yaml.
+func renderComponents(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) error {
+	dir, _ := cmd.Flags().GetString(dryRunDirFlag)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Render components...",
+		Emoji:   "📄",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	renders, err := componentRenders(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	helmClient, err := helm.NewClient(
+		"",
+		"",
+		cmdhelpers.HelmChecksumOption(cmd),
+		cmdhelpers.HelmLockOption(cmd),
+		helm.WithChartMirror(clusterCfg.Spec.Options.Helm.ChartMirror),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create Helm client: %w", err)
+	}
+
+	renderer := helm.NewRenderer(helmClient, dir)
+
+	for _, component := range renders {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "rendering %s",
+			Args:    []any{component.name},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		objects, err := component.render(cmd.Context(), renderer)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", component.name, err)
+		}
+
+		err = writeRenderedObjects(filepath.Join(dir, component.name+".yaml"), objects)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", component.name, err)
+		}
+	}
+
+	if len(clusterCfg.Spec.Plugins) > 0 {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "%d plugins are not rendered, as they install by running their command",
+			Args:    []any{len(clusterCfg.Spec.Plugins)},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%d components rendered to %s",
+		Args:    []any{len(renders), dir},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// componentRenders returns the components create would install, in the order it installs them.
+//
+//nolint:funlen // One entry per component keeps the list next to the conditions it mirrors.
+func componentRenders(clusterCfg *v1alpha1.Cluster) ([]componentRender, error) {
+	// Simulated clusters get no CNI or components
+	if clusterCfg.Spec.Distribution.IsSimulated() {
+		return nil, nil
+	}
+
+	options := clusterCfg.Spec.Options
+	timeout := installer.GetInstallTimeout(clusterCfg)
+
+	var renders []componentRender
+
+	add := func(enabled bool, name string, render func(context.Context, *helm.Renderer) ([]runtime.Object, error)) {
+		if enabled {
+			renders = append(renders, componentRender{name: name, render: render})
+		}
+	}
+
+	add(options.GatewayAPI.Enabled, createStepGatewayAPI,
+		func(ctx context.Context, _ *helm.Renderer) ([]runtime.Object, error) {
+			return gatewayapiinstaller.NewGatewayAPIInstaller(nil, gatewayAPIManifest(options.GatewayAPI), timeout).
+				RenderManifests(ctx)
+		})
+
+	cniName, err := coreinstaller.CNIName(clusterCfg)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // CNIName names the unsupported CNI.
+	}
+
+	add(cniName != "", cniName, func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+		cniInstaller, err := coreinstaller.NewCNIInstaller(ctx, renderer, "", clusterCfg)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // NewCNIInstaller describes the failure.
+		}
+
+		return nil, cniInstaller.Install(ctx)
+	})
+
+	add(coreinstaller.NeedsMetricsServer(clusterCfg), createStepMetricsServer,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			msInstaller, err := coreinstaller.NewMetricsServerInstaller(renderer, "", clusterCfg)
+			if err != nil {
+				return nil, err //nolint:wrapcheck // NewMetricsServerInstaller names the values file.
+			}
+
+			return nil, msInstaller.Install(ctx)
+		})
+
+	// Create picks unset addresses from the Docker network of the cluster, so the address pool
+	// is only rendered when addresses are configured
+	add(options.MetalLB.Enabled, createStepMetalLB,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			metalLBInstaller := metallbinstaller.NewMetalLBInstaller(renderer, nil, options.MetalLB.Addresses, timeout)
+			metalLBInstaller.SetVersion(options.MetalLB.Version)
+
+			return metalLBInstaller.RenderManifests(ctx)
+		})
+
+	add(options.Gitea.Enabled, createStepGitea,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			giteaInstaller := giteainstaller.NewGiteaInstaller(renderer, timeout)
+			giteaInstaller.SetVersion(options.Gitea.Version)
+
+			return nil, giteaInstaller.Install(ctx)
+		})
+
+	add(options.LocalStack.Enabled, createStepLocalStack,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			lsInstaller := localstackinstaller.NewLocalStackInstaller(renderer, nil, options.LocalStack.Endpoint, timeout)
+			lsInstaller.SetVersion(options.LocalStack.Version)
+
+			return lsInstaller.RenderManifests(ctx)
+		})
+
+	// The KV mount and transit key are created through the Vault API, not as resources
+	add(options.Vault.Enabled, createStepVault,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			vaultInstaller := vaultinstaller.NewVaultInstaller(renderer, nil, timeout)
+			vaultInstaller.SetVersion(options.Vault.Version)
+			vaultInstaller.SetToken(options.Vault.Token)
+
+			return nil, vaultInstaller.Install(ctx)
+		})
+
+	add(options.CloudNativePG.Enabled, createStepCloudNativePG,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			cnpgInstaller := cnpginstaller.NewCNPGInstaller(renderer, timeout)
+			cnpgInstaller.SetVersion(options.CloudNativePG.Version)
+
+			return nil, cnpgInstaller.Install(ctx)
+		})
+
+	add(options.ArgoRollouts.Enabled, createStepArgoRollouts,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			rolloutsInstaller := argorolloutsinstaller.NewArgoRolloutsInstaller(renderer, timeout)
+			rolloutsInstaller.SetVersion(options.ArgoRollouts.Version)
+
+			return nil, rolloutsInstaller.Install(ctx)
+		})
+
+	add(options.Headlamp.Enabled, createStepHeadlamp,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			headlampInstaller := headlampinstaller.NewHeadlampInstaller(renderer, timeout)
+			headlampInstaller.SetVersion(options.Headlamp.Version)
+
+			return nil, headlampInstaller.Install(ctx)
+		})
+
+	add(options.Kyverno.Enabled, createStepKyverno,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			kyvernoInstaller := kyvernoinstaller.NewKyvernoInstaller(renderer, timeout)
+			kyvernoInstaller.SetVersion(options.Kyverno.Version)
+			kyvernoInstaller.SetPolicies(string(options.Kyverno.PodSecurityStandard), options.Kyverno.Enforce)
+
+			return nil, kyvernoInstaller.Install(ctx)
+		})
+
+	add(clusterCfg.Spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux, createStepFlux,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			err := coreinstaller.NewFluxInstaller(renderer, clusterCfg).Install(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to install Flux operator: %w", err)
+			}
+
+			return fluxinstaller.DefaultResources(clusterCfg)
+		})
+
+	// The project Application is rendered alongside the chart
+	add(options.ArgoCD.Enabled, createStepArgoCD,
+		func(ctx context.Context, renderer *helm.Renderer) ([]runtime.Object, error) {
+			argoCDInstaller := argocdinstaller.NewArgoCDInstaller(renderer, timeout)
+			argoCDInstaller.SetVersion(options.ArgoCD.Version)
+
+			err := argoCDInstaller.Install(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return []runtime.Object{argocdinstaller.ProjectApplication(clusterCfg).Manifest()}, nil
+		})
+
+	return renders, nil
+}
+
+// writeRenderedObjects writes objects to path as a multi-document YAML file. Nothing is
+// written when there are no objects.
+func writeRenderedObjects(path string, objects []runtime.Object) error {
+	if len(objects) == 0 {
+		return nil
+	}
+
+	documents := make([]string, 0, len(objects))
+
+	for _, object := range objects {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", object.GetObjectKind().GroupVersionKind().Kind, err)
+		}
+
+		documents = append(documents, string(data))
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), renderedDirMode)
+	if err != nil {
+		return fmt.Errorf("create render directory: %w", err)
+	}
+
+	err = os.WriteFile(path, []byte(strings.Join(documents, "---\n")), renderedFileMode)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return nil
+}
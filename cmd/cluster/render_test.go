@@ -0,0 +1,47 @@
+package cluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestCreateCmdDryRunRendersComponentsWithoutCreatingTheCluster(t *testing.T) {
+	project := cmdtestutils.NewTempProject(t, cmdtestutils.WithTempProjectConfig(
+		func(cluster *v1alpha1.Cluster) {
+			cluster.Spec.MetricsServer = v1alpha1.MetricsServerDisabled
+			// An external endpoint renders the endpoint ConfigMap without downloading a chart
+			cluster.Spec.Options.LocalStack = v1alpha1.OptionsLocalStack{
+				Enabled:  true,
+				Endpoint: "http://localstack.example:4566",
+			}
+			cluster.Spec.Plugins = []v1alpha1.Plugin{{Name: "platform-charts", Command: "ksail-platform-charts"}}
+		},
+	))
+	t.Chdir(project.Dir)
+
+	output := filepath.Join(project.Dir, "out")
+
+	result := cmdtestutils.ExecuteCommand(
+		t,
+		clusterpkg.NewCreateCmd(runtime.NewRuntime()),
+		"--dry-run",
+		"--dry-run-dir", output,
+	)
+	require.NoError(t, result.Err)
+	assert.Contains(t, result.Stdout, "1 components rendered to "+output)
+	assert.Contains(t, result.Stdout, "1 plugins are not rendered")
+
+	content, err := os.ReadFile(filepath.Join(output, "localstack.yaml")) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "kind: ConfigMap\n")
+	assert.Contains(t, string(content), "AWS_ENDPOINT_URL: http://localstack.example:4566\n")
+}
@@ -0,0 +1,155 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/progress"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	resumeFlag = "resume"
+	// createProgressPath records the completed create steps, relative to the directory of
+	// ksail.yaml.
+	createProgressPath = ".ksail/create-progress.json"
+)
+
+// Create steps recorded in the progress file.
+const (
+	createStepCluster       = "cluster"
+	createStepRegistries    = "registries"
+	createStepGatewayAPI    = "gateway-api"
+	createStepCNI           = "cni"
+	createStepMetricsServer = "metrics-server"
+	createStepMetalLB       = "metallb"
+	createStepGitea         = "gitea"
+	createStepLocalStack    = "localstack"
+	createStepVault         = "vault"
+	createStepCloudNativePG = "cloudnativepg"
+	createStepArgoRollouts  = "argo-rollouts"
+	createStepHeadlamp      = "headlamp"
+	createStepKyverno       = "kyverno"
+	createStepFlux          = "flux"
+	createStepArgoCD        = "argocd"
+)
+
+// createProgress skips create steps that a previous, partially failed run already completed.
+// A nil tracker records nothing and runs every step.
+type createProgress struct {
+	tracker *progress.Tracker
+}
+
+// loadCreateProgress loads the steps recorded for this configuration next to configFile, the
+// ksail.yaml it was loaded from. Recorded steps are only trusted while the cluster they were run
+// against still exists.
+func loadCreateProgress(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+	configFile string,
+) *createProgress {
+	fingerprint, err := createFingerprint(clusterCfg)
+	if err != nil {
+		return &createProgress{}
+	}
+
+	tracker, err := progress.Load(createProgressFile(configFile), fingerprint)
+	if err != nil {
+		return &createProgress{}
+	}
+
+	resume, _ := cmd.Flags().GetBool(resumeFlag)
+	if !resume || (tracker.Resuming() && !clusterStillExists(cmd, clusterCfg, deps)) {
+		_ = tracker.Reset()
+	}
+
+	if tracker.Resuming() {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "resuming from a previous run, completed steps are skipped (use --resume=false to start over)",
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	return &createProgress{tracker: tracker}
+}
+
+// createProgressFile returns the progress file of the project whose ksail.yaml is configFile.
+func createProgressFile(configFile string) string {
+	return filepath.Join(ksailconfigmanager.ProjectDir(configFile), createProgressPath)
+}
+
+// run executes fn unless step already completed, and records it once fn succeeds.
+func (p *createProgress) run(step string, fn func() error) error {
+	if p.tracker != nil && p.tracker.Completed(step) {
+		return nil
+	}
+
+	err := fn()
+	if err != nil {
+		return err
+	}
+
+	if p.tracker != nil {
+		_ = p.tracker.Complete(step)
+	}
+
+	return nil
+}
+
+// finish forgets the recorded steps once the whole create succeeded.
+func (p *createProgress) finish() {
+	if p.tracker != nil {
+		_ = p.tracker.Reset()
+	}
+}
+
+func clusterStillExists(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+) bool {
+	if deps.Factory == nil {
+		return false
+	}
+
+	provisioner, distributionConfig, err := cmdhelpers.CreateClusterProvisioner(
+		cmd,
+		deps.Factory,
+		clusterCfg,
+	)
+	if err != nil || provisioner == nil {
+		return false
+	}
+
+	clusterName, err := configmanager.GetClusterName(distributionConfig)
+	if err != nil {
+		return false
+	}
+
+	exists, err := provisioner.Exists(cmd.Context(), clusterName)
+
+	return err == nil && exists
+}
+
+// createFingerprint identifies the configuration a create run used, so progress recorded for
+// a different configuration is not reused.
+func createFingerprint(clusterCfg *v1alpha1.Cluster) (string, error) {
+	data, err := json.Marshal(clusterCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cluster configuration: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,187 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/helpers"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	k3dgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/k3d"
+	kindgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/kind"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+var errInvalidWorkerCount = errors.New("worker count must not be negative")
+
+// newScaleLifecycleConfig creates the lifecycle configuration for cluster scale.
+func newScaleLifecycleConfig(workers int) cmdhelpers.LifecycleConfig {
+	return cmdhelpers.LifecycleConfig{
+		TitleEmoji:         "📏",
+		TitleContent:       "Scale cluster...",
+		ActivityContent:    fmt.Sprintf("scaling to %d workers", workers),
+		SuccessContent:     "cluster scaled",
+		ErrorMessagePrefix: "failed to scale cluster",
+		Action: func(ctx context.Context, provisioner clusterprovisioner.ClusterProvisioner, clusterName string) error {
+			scaler, ok := provisioner.(clusterprovisioner.NodeScaler)
+			if !ok {
+				return clusterprovisioner.ErrScalingNotSupported
+			}
+
+			return scaler.Scale(ctx, clusterName, workers)
+		},
+	}
+}
+
+// NewScaleCmd creates the scale command, which changes the number of worker nodes of a
+// running cluster.
+func NewScaleCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	var workers int
+
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Change the number of worker nodes of a running cluster",
+		Long: `Add or remove worker nodes of a running Kind, K3d or kwok cluster and record the ` +
+			`new number of workers in the distribution configuration. Removed workers are the most ` +
+			`recently added ones.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().IntVar(&workers, "workers", 0, "Number of worker nodes the cluster should have")
+	_ = cmd.MarkFlagRequired("workers")
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager, deps cmdhelpers.LifecycleDeps) error {
+			return handleScaleRunE(cmd, cfgManager, deps, workers)
+		},
+	)
+
+	cmdhelpers.MarkAudited(cmd, "cluster.scale")
+
+	return cmd
+}
+
+func handleScaleRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+	workers int,
+) error {
+	if workers < 0 {
+		return fmt.Errorf("%w: %d", errInvalidWorkerCount, workers)
+	}
+
+	deps.Timer.Start()
+
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	err = useDockerHost(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	deps.Timer.NewStage()
+
+	err = cmdhelpers.RunLifecycleWithConfig(cmd, deps, newScaleLifecycleConfig(workers), clusterCfg)
+	if err != nil {
+		return fmt.Errorf("scale cluster lifecycle: %w", err)
+	}
+
+	// spec.workerNodes of ksail.yaml takes precedence over the distribution config.
+	if clusterCfg.Spec.WorkerNodes > 0 && int(clusterCfg.Spec.WorkerNodes) != workers {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "spec.workerNodes in ksail.yaml is %d; update it to keep %d workers when the cluster is recreated",
+			Args:    []any{clusterCfg.Spec.WorkerNodes, workers},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	return recordWorkerCount(clusterCfg, workers)
+}
+
+// recordWorkerCount writes the number of workers to the distribution configuration, so the
+// cluster is recreated at its new size. Other settings of the file are kept as they are.
+func recordWorkerCount(clusterCfg *v1alpha1.Cluster, workers int) error {
+	configPath := strings.TrimSpace(clusterCfg.Spec.DistributionConfig)
+	if configPath == "" || strings.EqualFold(configPath, "auto") {
+		configPath = defaultDistributionConfigPath(clusterCfg.Spec.Distribution)
+	}
+
+	resolvedPath, err := ksailio.FindFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", configPath, err)
+	}
+
+	opts := yamlgenerator.Options{Output: resolvedPath, Force: true}
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		kindConfig, err := helpers.LoadConfigFromFile(resolvedPath, func() *v1alpha4.Cluster {
+			return &v1alpha4.Cluster{}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load kind config: %w", err)
+		}
+
+		configmanager.SetKindNodeCount(kindConfig, v1alpha4.WorkerRole, workers)
+
+		_, err = kindgenerator.NewKindGenerator().Generate(kindConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update kind config: %w", err)
+		}
+	case v1alpha1.DistributionK3d:
+		k3dConfig, err := helpers.LoadConfigFromFile(resolvedPath, func() *v1alpha5.SimpleConfig {
+			return &v1alpha5.SimpleConfig{}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load k3d config: %w", err)
+		}
+
+		k3dConfig.Agents = workers
+
+		_, err = k3dgenerator.NewK3dGenerator().Generate(k3dConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update k3d config: %w", err)
+		}
+	case v1alpha1.DistributionKwok:
+		kwokConfig, err := helpers.LoadConfigFromFile(resolvedPath, func() *kwokprovisioner.Config {
+			return kwokprovisioner.NewConfig("")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load kwok config: %w", err)
+		}
+
+		kwokConfig.Nodes = workers
+
+		_, err = yamlgenerator.NewTypedYAMLGenerator[*kwokprovisioner.Config]().Generate(kwokConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update kwok config: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,423 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/helpers"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	k3dgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/k3d"
+	kindgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/kind"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer/cni"
+	calicoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/calico"
+	ciliuminstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/cilium"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+var (
+	errCNIUnchanged         = errors.New("the cluster already uses this CNI")
+	errDefaultCNIRestore    = errors.New("the default CNI cannot be restored on a running cluster")
+	errDefaultCNIBuiltIn    = errors.New("the default CNI is built into the distribution")
+	errCNISwitchUnsupported = errors.New("switching the CNI is not supported for this distribution")
+)
+
+// NewSetCmd creates the set command, which changes settings of a running cluster.
+func NewSetCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "set",
+		Short:        "Change settings of a running cluster",
+		Long:         `Change settings of a running cluster and record them in the project configuration.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewSetCNICmd(runtimeContainer))
+
+	return cmd
+}
+
+// NewSetCNICmd creates the set cni command, which replaces the CNI of a running cluster.
+func NewSetCNICmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cni <Cilium|Calico>",
+		Short: "Replace the CNI of a running cluster",
+		Long: `Remove the CNI of a running cluster, install another one and restart the pods that ` +
+			`were networked by the previous CNI, then record the new CNI in ksail.yaml. Kind's ` +
+			`default CNI can be replaced; the default CNIs of other distributions are built in ` +
+			`and require recreating the cluster.`,
+		Args:         cobra.ExactArgs(1),
+		ValidArgs:    []string{string(v1alpha1.CNICilium), string(v1alpha1.CNICalico)},
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleSetCNIRunE)
+
+	cmdhelpers.MarkAudited(cmd, "cluster.set.cni")
+
+	return cmd
+}
+
+func handleSetCNIRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	var target v1alpha1.CNI
+
+	err := target.Set(cmd.Flags().Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid CNI: %w", err)
+	}
+
+	deps.Timer.Start()
+
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	current := clusterCfg.Spec.CNI
+	if current == "" {
+		current = v1alpha1.CNIDefault
+	}
+
+	err = validateCNISwitch(clusterCfg, current, target)
+	if err != nil {
+		return err
+	}
+
+	// The new CNI is recorded in ksail.yaml once the switch is done, so refuse up front
+	// rather than leave a cluster that no longer matches its configuration.
+	err = cfgManager.EnsureWritable()
+	if err != nil {
+		return fmt.Errorf("cannot record the new CNI: %w", err)
+	}
+
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	err = removeCNI(cmd, clusterCfg, clientset, current, deps.Timer)
+	if err != nil {
+		return err
+	}
+
+	clusterCfg.Spec.CNI = target
+
+	cmd.Println()
+	deps.Timer.NewStage()
+
+	err = installCNI(cmd, clusterCfg, deps.Timer)
+	if err != nil {
+		return err
+	}
+
+	err = restartPodNetworking(cmd, clusterCfg, clientset, deps.Timer)
+	if err != nil {
+		return err
+	}
+
+	return recordCNI(cmd, cfgManager, clusterCfg)
+}
+
+// validateCNISwitch rejects switches that cannot be done on a running cluster: restoring a
+// default CNI, and replacing a default CNI that is part of the distribution. Kind's kindnet
+// runs as a regular DaemonSet, and K3s only runs without Flannel when k3d.yaml disables it.
+func validateCNISwitch(clusterCfg *v1alpha1.Cluster, current, target v1alpha1.CNI) error {
+	if clusterCfg.Spec.Distribution.IsSimulated() {
+		return fmt.Errorf("%w: %s", errCNISwitchUnsupported, clusterCfg.Spec.Distribution)
+	}
+
+	if target == v1alpha1.CNIDefault {
+		return errDefaultCNIRestore
+	}
+
+	if target == current {
+		return fmt.Errorf("%w: %s", errCNIUnchanged, current)
+	}
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		return nil
+	case v1alpha1.DistributionK3d:
+		_, k3dConfig, err := loadDistributionConfigs(clusterCfg, nil)
+		if err != nil {
+			return err
+		}
+
+		if hasK3sArg(k3dConfig, "--flannel-backend=none") {
+			return nil
+		}
+	default:
+		if current != v1alpha1.CNIDefault {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w; recreate the cluster with --cni %s", errDefaultCNIBuiltIn, target)
+}
+
+func hasK3sArg(k3dConfig *v1alpha5.SimpleConfig, arg string) bool {
+	return slices.ContainsFunc(
+		k3dConfig.Options.K3sOptions.ExtraArgs,
+		func(existing v1alpha5.K3sArgWithNodeFilters) bool { return existing.Arg == arg },
+	)
+}
+
+// removeCNI uninstalls the current CNI of the cluster in its own stage.
+func removeCNI(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	clientset kubernetes.Interface,
+	current v1alpha1.CNI,
+	tmr timer.Timer,
+) error {
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Remove CNI...",
+		Emoji:   "🗑️",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	name := strings.ToLower(string(current))
+	if current == v1alpha1.CNIDefault {
+		name = "kindnet"
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "uninstalling " + name,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err := audit.Track(cmd.Context(), audit.ActionComponentUninstall, name, func() error {
+		return uninstallCNI(cmd, clusterCfg, clientset, current)
+	})
+	if err != nil {
+		return fmt.Errorf("%s uninstallation failed: %w", name, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "cni removed",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+func uninstallCNI(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	clientset kubernetes.Interface,
+	current v1alpha1.CNI,
+) error {
+	switch current {
+	case v1alpha1.CNICilium:
+		helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepCNI)
+		if err != nil {
+			return err
+		}
+
+		return ciliuminstaller.NewCiliumInstaller(
+			helmClient, kubeconfig, clusterCfg.Spec.Connection.Context, installer.GetComponentTimeout(clusterCfg, createStepCNI),
+		).Uninstall(cmd.Context())
+	case v1alpha1.CNICalico:
+		helmClient, kubeconfig, err := createHelmClientForCluster(cmd, clusterCfg, createStepCNI)
+		if err != nil {
+			return err
+		}
+
+		return calicoinstaller.NewCalicoInstaller(
+			helmClient, kubeconfig, clusterCfg.Spec.Connection.Context, installer.GetComponentTimeout(clusterCfg, createStepCNI),
+		).Uninstall(cmd.Context())
+	case v1alpha1.CNIDefault:
+		// K3d clusters with Flannel disabled have no default CNI to remove.
+		if clusterCfg.Spec.Distribution != v1alpha1.DistributionKind {
+			return nil
+		}
+
+		return cni.RemoveKindnet(cmd.Context(), clientset, installer.GetComponentTimeout(clusterCfg, createStepCNI))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedCNI, current)
+	}
+}
+
+// restartPodNetworking waits for the nodes to become ready on the new CNI and restarts the
+// pods that still use addresses of the previous one.
+func restartPodNetworking(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	clientset kubernetes.Interface,
+	tmr timer.Timer,
+) error {
+	cmd.Println()
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Restart workloads...",
+		Emoji:   "🔄",
+		Writer:  cmd.OutOrStdout(),
+	})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "awaiting nodes to be ready",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err := cni.WaitForNodesReady(cmd.Context(), clientset, installer.GetComponentTimeout(clusterCfg, createStepCNI))
+	if err != nil {
+		return fmt.Errorf("nodes did not become ready: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "restarting pods",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	restarted, err := cni.RestartPodNetworking(cmd.Context(), clientset)
+	if err != nil {
+		return fmt.Errorf("failed to restart pods: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "%d pods restarted",
+		Args:    []any{restarted},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// recordCNI writes the new CNI to ksail.yaml and disables Kind's default CNI in kind.yaml, so
+// the cluster is recreated with the CNI it runs now. Other settings of the files are kept.
+func recordCNI(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	clusterCfg *v1alpha1.Cluster,
+) error {
+	configFile := cfgManager.Viper.ConfigFileUsed()
+	if configFile == "" {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "no ksail.yaml found; set spec.cni to %s to keep the CNI when the cluster is recreated",
+			Args:    []any{clusterCfg.Spec.CNI},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
+	}
+
+	ksailConfig, err := helpers.LoadConfigFromFile(configFile, func() v1alpha1.Cluster {
+		return v1alpha1.Cluster{}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load ksail config: %w", err)
+	}
+
+	ksailConfig.Spec.CNI = clusterCfg.Spec.CNI
+
+	opts := yamlgenerator.Options{Output: configFile, Force: true}
+
+	_, err = yamlgenerator.NewTypedYAMLGenerator[v1alpha1.Cluster]().Generate(ksailConfig, opts)
+	if err != nil {
+		return fmt.Errorf("failed to update ksail config: %w", err)
+	}
+
+	return recordDistributionCNI(clusterCfg)
+}
+
+// recordDistributionCNI aligns the distribution configuration with the new CNI: Kind's default
+// CNI is disabled, and K3s network policies are disabled for Cilium, which enforces them itself.
+func recordDistributionCNI(clusterCfg *v1alpha1.Cluster) error {
+	configPath := strings.TrimSpace(clusterCfg.Spec.DistributionConfig)
+	if configPath == "" || strings.EqualFold(configPath, "auto") {
+		configPath = defaultDistributionConfigPath(clusterCfg.Spec.Distribution)
+	}
+
+	resolvedPath, err := ksailio.FindFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", configPath, err)
+	}
+
+	opts := yamlgenerator.Options{Output: resolvedPath, Force: true}
+
+	switch clusterCfg.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		kindConfig, err := helpers.LoadConfigFromFile(resolvedPath, func() *v1alpha4.Cluster {
+			return &v1alpha4.Cluster{}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load kind config: %w", err)
+		}
+
+		if kindConfig.Networking.DisableDefaultCNI {
+			return nil
+		}
+
+		kindConfig.Networking.DisableDefaultCNI = true
+
+		_, err = kindgenerator.NewKindGenerator().Generate(kindConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update kind config: %w", err)
+		}
+	case v1alpha1.DistributionK3d:
+		k3dConfig, err := helpers.LoadConfigFromFile(resolvedPath, func() *v1alpha5.SimpleConfig {
+			return &v1alpha5.SimpleConfig{}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load k3d config: %w", err)
+		}
+
+		if clusterCfg.Spec.CNI != v1alpha1.CNICilium || hasK3sArg(k3dConfig, "--disable-network-policy") {
+			return nil
+		}
+
+		k3dConfig.Options.K3sOptions.ExtraArgs = append(k3dConfig.Options.K3sOptions.ExtraArgs,
+			v1alpha5.K3sArgWithNodeFilters{Arg: "--disable-network-policy", NodeFilters: []string{"server:*"}},
+		)
+
+		_, err = k3dgenerator.NewK3dGenerator().Generate(k3dConfig, opts)
+		if err != nil {
+			return fmt.Errorf("failed to update k3d config: %w", err)
+		}
+	}
+
+	return nil
+}
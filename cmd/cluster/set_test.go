@@ -0,0 +1,58 @@
+package cluster_test
+
+import (
+	"testing"
+
+	clusterpkg "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	cmdtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir so the command discovers the project's ksail.yaml.
+func TestSetCNICmdRejectsSwitchesThatNeedARecreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		variant cmdtestutils.KSailConfigVariant
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "UnknownCNI",
+			variant: cmdtestutils.KSailConfigVariant{Distribution: v1alpha1.DistributionKind, CNI: v1alpha1.CNIDefault},
+			args:    []string{"weave"},
+			wantErr: "invalid CNI",
+		},
+		{
+			name:    "SameCNI",
+			variant: cmdtestutils.KSailConfigVariant{Distribution: v1alpha1.DistributionKind, CNI: v1alpha1.CNICilium},
+			args:    []string{"cilium"},
+			wantErr: "the cluster already uses this CNI: Cilium",
+		},
+		{
+			name:    "DefaultCNI",
+			variant: cmdtestutils.KSailConfigVariant{Distribution: v1alpha1.DistributionKind, CNI: v1alpha1.CNICilium},
+			args:    []string{"Default"},
+			wantErr: "the default CNI cannot be restored on a running cluster",
+		},
+		{
+			name:    "K3sFlannel",
+			variant: cmdtestutils.KSailConfigVariant{Distribution: v1alpha1.DistributionK3d, CNI: v1alpha1.CNIDefault},
+			args:    []string{"Calico"},
+			wantErr: "the default CNI is built into the distribution; recreate the cluster with --cni Calico",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project := cmdtestutils.NewTempProject(t, cmdtestutils.WithTempProjectVariant(test.variant))
+			t.Chdir(project.Dir)
+
+			cmd := clusterpkg.NewSetCNICmd(runtime.NewRuntime())
+			result := cmdtestutils.ExecuteCommand(t, cmd, test.args...)
+
+			require.ErrorContains(t, result.Err, test.wantErr)
+		})
+	}
+}
@@ -29,9 +29,10 @@ func newStartLifecycleConfig() cmdhelpers.LifecycleConfig {
 // NewStartCmd creates and returns the start command.
 func NewStartCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "start",
-		Short:        "Start a stopped cluster",
-		Long:         `Start a previously stopped cluster.`,
+		Use:   "start",
+		Short: "Start a stopped cluster",
+		Long: `Start a previously stopped cluster. Registries paused by ksail cluster stop ` +
+			`--pause-registries are resumed first and reconnected to the cluster network.`,
 		SilenceUsage: true,
 	}
 
@@ -42,6 +43,8 @@ func NewStartCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 
 	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleStartRunE)
 
+	cmdhelpers.MarkAudited(cmd, "cluster.start")
+
 	return cmd
 }
 
@@ -50,16 +53,23 @@ func handleStartRunE(
 	cfgManager *ksailconfigmanager.ConfigManager,
 	deps cmdhelpers.LifecycleDeps,
 ) error {
-	config := newStartLifecycleConfig()
+	deps.Timer.Start()
 
-	err := cmdhelpers.HandleLifecycleRunE(cmd, cfgManager, deps, config)
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
 	if err != nil {
-		return fmt.Errorf("start cluster lifecycle: %w", err)
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
 	}
 
-	clusterCfg := cfgManager.Config
-	if clusterCfg == nil || clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
-		return nil
+	err = useDockerHost(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	colimaShown := false
+
+	err = startColimaVM(cmd, clusterCfg, deps, &colimaShown)
+	if err != nil {
+		return err
 	}
 
 	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, deps.Timer)
@@ -67,9 +77,50 @@ func handleStartRunE(
 		return fmt.Errorf("load distribution configs: %w", err)
 	}
 
-	// Start command's registry connection happens after cluster start, so use a dummy tracker
+	deps.Timer.NewStage()
+
+	// Resume paused registries before the nodes start pulling through them.
+	err = toggleClusterRegistries(
+		cmd,
+		clusterCfg,
+		deps,
+		kindConfig,
+		k3dConfig,
+		resumeRegistriesStage,
+		&colimaShown,
+	)
+	if err != nil {
+		return err
+	}
+
+	if colimaShown {
+		cmd.Println()
+	}
+
+	deps.Timer.NewStage()
+
+	err = cmdhelpers.RunLifecycleWithConfig(cmd, deps, newStartLifecycleConfig(), clusterCfg)
+	if err != nil {
+		return fmt.Errorf("start cluster lifecycle: %w", err)
+	}
+
+	remote := remoteDockerHost(clusterCfg) != ""
+	if !remote && clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
+		return nil
+	}
+
+	// Start command's follow-up stages happen after cluster start, so use a dummy tracker
 	dummyTracker := true
 
+	err = openRemoteTunnel(cmd, clusterCfg, deps, kindConfig, k3dConfig, &dummyTracker)
+	if err != nil {
+		return err
+	}
+
+	if clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
+		return nil
+	}
+
 	connectErr := executeLocalRegistryStage(
 		cmd,
 		clusterCfg,
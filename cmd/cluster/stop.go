@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
@@ -27,9 +28,11 @@ func newStopLifecycleConfig() cmdhelpers.LifecycleConfig {
 // NewStopCmd creates and returns the stop command.
 func NewStopCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "stop",
-		Short:        "Stop a running cluster",
-		Long:         `Stop a running Kubernetes cluster.`,
+		Use:   "stop",
+		Short: "Stop a running cluster",
+		Long: `Stop a running Kubernetes cluster. Mirror registries and the local registry keep ` +
+			`running unless --pause-registries is set; paused registries keep their cache volumes ` +
+			`and are resumed by ksail cluster start.`,
 		SilenceUsage: true,
 	}
 
@@ -38,11 +41,65 @@ func NewStopCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 		ksailconfigmanager.DefaultClusterFieldSelectors(),
 	)
 
-	cmd.RunE = cmdhelpers.NewStandardLifecycleRunE(
-		runtimeContainer,
-		cfgManager,
-		newStopLifecycleConfig(),
-	)
+	cmd.Flags().Bool(pauseRegistriesFlag, false,
+		"Also stop the cluster's mirror and local registries, keeping their cache volumes")
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleStopRunE)
+
+	cmdhelpers.MarkAudited(cmd, "cluster.stop")
 
 	return cmd
 }
+
+// handleStopRunE stops the cluster and closes its SSH tunnel when it runs on a remote Docker host.
+// With --pause-registries the cluster's registries are stopped as well.
+func handleStopRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	deps.Timer.Start()
+
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	err = useDockerHost(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	deps.Timer.NewStage()
+
+	err = cmdhelpers.RunLifecycleWithConfig(cmd, deps, newStopLifecycleConfig(), clusterCfg)
+	if err != nil {
+		return fmt.Errorf("stop cluster lifecycle: %w", err)
+	}
+
+	closeRemoteTunnel(cmd, clusterCfg)
+
+	pauseRegistries, _ := cmd.Flags().GetBool(pauseRegistriesFlag)
+	if !pauseRegistries {
+		return nil
+	}
+
+	kindConfig, k3dConfig, err := loadDistributionConfigs(clusterCfg, deps.Timer)
+	if err != nil {
+		return fmt.Errorf("load distribution configs: %w", err)
+	}
+
+	deps.Timer.NewStage()
+
+	firstActivityShown := true
+
+	return toggleClusterRegistries(
+		cmd,
+		clusterCfg,
+		deps,
+		kindConfig,
+		k3dConfig,
+		pauseRegistriesStage,
+		&firstActivityShown,
+	)
+}
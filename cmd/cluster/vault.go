@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/spf13/cobra"
+)
+
+// vaultComponent installs the Vault dev server and creates the configured KV mount and
+// transit key.
+//
+//nolint:gochecknoglobals // entry of the optionalComponents table
+var vaultComponent = optionalComponent{
+	name:  "vault",
+	title: "Install Vault...",
+	emoji: "🔐",
+	enabled: func(clusterCfg *v1alpha1.Cluster) bool {
+		return clusterCfg.Spec.Options.Vault.Enabled
+	},
+	activity: message("installing vault dev server"),
+	success: message(fmt.Sprintf(
+		"vault installed, reachable in-cluster at http://%s.%s.svc.cluster.local:%d",
+		vaultinstaller.ServiceName,
+		vaultinstaller.Namespace,
+		vaultinstaller.ServicePort,
+	)),
+	factory: newVaultInstaller,
+}
+
+func newVaultInstaller(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	helmClient *helm.Client,
+	kubeconfig string,
+	timeout time.Duration,
+) (installer.Installer, error) {
+	options := clusterCfg.Spec.Options.Vault
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultInstaller := vaultinstaller.NewVaultInstaller(helmClient, restConfig, timeout)
+	vaultInstaller.SetVersion(options.Version)
+	vaultInstaller.SetToken(options.Token)
+	vaultInstaller.SetBootstrap(vaultinstaller.Bootstrap{
+		KVMount:    options.KVMount,
+		TransitKey: options.TransitKey,
+	})
+
+	return vaultInstaller, nil
+}
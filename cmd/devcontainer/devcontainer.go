@@ -0,0 +1,334 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	devcontainersvc "github.com/devantler-tech/ksail-go/pkg/svc/devcontainer"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	kubeconfigOutputFlag = "kubeconfig-output"
+	// DefaultKubeconfigOutput is where the devcontainer kubeconfig is written, relative to the project.
+	DefaultKubeconfigOutput = ".ksail/kubeconfig"
+	defaultReadinessTimeout = 5 * time.Minute
+)
+
+// Stage identifies the devcontainer lifecycle hook a command runs in.
+type Stage int
+
+const (
+	// StageOnCreate runs once when the devcontainer is created. Existing clusters are reused.
+	StageOnCreate Stage = iota
+	// StagePostStart runs every time the devcontainer starts. Existing clusters are started.
+	StagePostStart
+)
+
+// Deps groups the collaborators used by the devcontainer commands.
+type Deps struct {
+	Timer    timer.Timer
+	Factory  clusterprovisioner.Factory
+	Detector devcontainersvc.Detector
+	// RunCreate creates the cluster, normally by running `ksail cluster create`.
+	RunCreate func(cmd *cobra.Command) error
+	// RunStart starts the cluster, normally by running `ksail cluster start`.
+	RunStart func(cmd *cobra.Command) error
+	// WithDockerClient runs an operation with a Docker client.
+	WithDockerClient func(cmd *cobra.Command, operation func(client.APIClient) error) error
+	// WaitForAPIServer blocks until the API server behind the exported kubeconfig answers.
+	WaitForAPIServer func(ctx context.Context, kubeconfig, contextName string, timeout time.Duration) error
+}
+
+// NewDevcontainerCmd creates the devcontainer command and its lifecycle hook subcommands.
+func NewDevcontainerCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "devcontainer",
+		Short: "Run the cluster inside devcontainers and Codespaces",
+		Long: `Lifecycle hooks for devcontainers and GitHub Codespaces.
+
+Reference the subcommands from devcontainer.json:
+
+  "onCreateCommand": "ksail devcontainer on-create",
+  "postStartCommand": "ksail devcontainer post-start",
+  "remoteEnv": { "KUBECONFIG": "${containerWorkspaceFolder}/.ksail/kubeconfig" }
+
+When the container uses the host's Docker daemon (docker-outside-of-docker), the
+container is attached to the cluster network and the kubeconfig points at the
+control plane container, since the API server is not reachable on localhost.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newStageCmd(runtimeContainer, StageOnCreate))
+	cmd.AddCommand(newStageCmd(runtimeContainer, StagePostStart))
+
+	return cmd
+}
+
+func newStageCmd(runtimeContainer *runtime.Runtime, stage Stage) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "on-create",
+		Short:        "Create the cluster when the devcontainer is created",
+		Long:         `Create the cluster unless it already exists, wait for it and export its kubeconfig.`,
+		SilenceUsage: true,
+	}
+
+	if stage == StagePostStart {
+		cmd.Use = "post-start"
+		cmd.Short = "Start the cluster when the devcontainer starts"
+		cmd.Long = `Start the cluster (creating it if missing), wait for it and export its kubeconfig.`
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().String(kubeconfigOutputFlag, DefaultKubeconfigOutput, "Where to write the devcontainer kubeconfig")
+	_ = cfgManager.Viper.BindPFlag(kubeconfigOutputFlag, cmd.Flags().Lookup(kubeconfigOutputFlag))
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, manager *ksailconfigmanager.ConfigManager, lifecycle cmdhelpers.LifecycleDeps) error {
+			deps := Deps{
+				Timer:    lifecycle.Timer,
+				Factory:  lifecycle.Factory,
+				Detector: devcontainersvc.NewDetector(),
+				RunCreate: func(cmd *cobra.Command) error {
+					return runClusterCommand(cmd, cluster.NewCreateCmd(runtimeContainer))
+				},
+				RunStart: func(cmd *cobra.Command) error {
+					return runClusterCommand(cmd, cluster.NewStartCmd(runtimeContainer))
+				},
+				WithDockerClient: cmdhelpers.WithDockerClient,
+				WaitForAPIServer: devcontainersvc.WaitForAPIServer,
+			}
+
+			return HandleStageRunE(cmd, manager, deps, stage)
+		},
+	)
+
+	return cmd
+}
+
+// HandleStageRunE ensures the cluster is running and exports a kubeconfig usable from the devcontainer.
+// Exported for testing purposes.
+func HandleStageRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps Deps,
+	stage Stage,
+) error {
+	if deps.Timer != nil {
+		deps.Timer.Start()
+	}
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	clusterName, err := ensureCluster(cmd, clusterCfg, deps, stage)
+	if err != nil {
+		return err
+	}
+
+	if deps.Timer != nil {
+		deps.Timer.NewStage()
+	}
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Configure devcontainer...",
+		Emoji:   "📦",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	output, err := exportKubeconfig(cmd, cfgManager, clusterCfg, clusterName, deps)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "kubeconfig written to %s",
+		Args:    []any{output},
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+func ensureCluster(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps Deps,
+	stage Stage,
+) (string, error) {
+	provisioner, distributionConfig, err := cmdhelpers.CreateClusterProvisioner(cmd, deps.Factory, clusterCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve cluster provisioner: %w", err)
+	}
+
+	if provisioner == nil {
+		return "", cmdhelpers.ErrMissingClusterProvisionerDependency
+	}
+
+	clusterName, err := configmanager.GetClusterName(distributionConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster name from config: %w", err)
+	}
+
+	exists, err := provisioner.Exists(cmd.Context(), clusterName)
+	if err != nil {
+		return "", fmt.Errorf("failed to check cluster existence: %w", err)
+	}
+
+	switch {
+	case !exists:
+		err = deps.RunCreate(cmd)
+	case stage == StagePostStart:
+		err = deps.RunStart(cmd)
+	default:
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "cluster '%s' already exists",
+			Args:    []any{clusterName},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return clusterName, nil
+}
+
+func exportKubeconfig(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	clusterCfg *v1alpha1.Cluster,
+	clusterName string,
+	deps Deps,
+) (string, error) {
+	server, err := resolveServerOverride(cmd, clusterCfg, clusterName, deps)
+	if err != nil {
+		return "", err
+	}
+
+	source, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+
+	contextName := clusterCfg.Spec.Connection.Context
+	if contextName == "" {
+		contextName = ksailconfigmanager.ContextNameForCluster(clusterCfg.Spec.Distribution, clusterName)
+	}
+
+	if contextName == "" {
+		contextName = clusterName
+	}
+
+	output, err := filepath.Abs(cfgManager.Viper.GetString(kubeconfigOutputFlag))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve kubeconfig output path: %w", err)
+	}
+
+	err = devcontainersvc.ExportKubeconfig(source, contextName, output, server)
+	if err != nil {
+		return "", fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "waiting for api server",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	timeout := clusterCfg.Spec.Connection.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
+
+	err = deps.WaitForAPIServer(cmd.Context(), output, contextName, timeout)
+	if err != nil {
+		return "", fmt.Errorf("cluster did not become ready: %w", err)
+	}
+
+	return output, nil
+}
+
+// resolveServerOverride attaches the devcontainer to the cluster network when it uses the
+// host's Docker daemon and returns the API server address to use from inside the container.
+func resolveServerOverride(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	clusterName string,
+	deps Deps,
+) (string, error) {
+	var server string
+
+	err := deps.WithDockerClient(cmd, func(dockerClient client.APIClient) error {
+		env, err := deps.Detector.Detect(cmd.Context(), dockerClient)
+		if err != nil {
+			return fmt.Errorf("failed to detect devcontainer environment: %w", err)
+		}
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "detected docker mode: %s",
+			Args:    []any{env.Mode},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		if env.Mode != devcontainersvc.ModeDockerOutsideOfDocker {
+			return nil
+		}
+
+		networkName := clusterprovisioner.DockerNetworkName(clusterCfg, clusterName)
+		if networkName == "" {
+			// External clusters are reached at the server of their kubeconfig
+			return nil
+		}
+
+		err = devcontainersvc.ConnectContainer(cmd.Context(), dockerClient, networkName, env.ContainerID)
+		if err != nil {
+			return fmt.Errorf("failed to attach devcontainer to cluster network: %w", err)
+		}
+
+		server = devcontainersvc.InternalServerURL(clusterCfg.Spec.Distribution, clusterName)
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return server, nil
+}
+
+// runClusterCommand runs a cluster subcommand with the parent's context and output streams.
+func runClusterCommand(parent, sub *cobra.Command) error {
+	sub.SetContext(parent.Context())
+	sub.SetOut(parent.OutOrStdout())
+	sub.SetErr(parent.ErrOrStderr())
+
+	return sub.RunE(sub, nil)
+}
@@ -0,0 +1,158 @@
+package devcontainer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/cmd/devcontainer"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	devcontainersvc "github.com/devantler-tech/ksail-go/pkg/svc/devcontainer"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+type stageHarness struct {
+	project     *testutils.TempProject
+	provisioner *testutils.StubProvisioner
+	docker      *dockerclient.MockAPIClient
+	createCalls int
+	startCalls  int
+	waitedFor   string
+	output      string
+}
+
+func newStageHarness(t *testing.T, exists bool, daemonName string) *stageHarness {
+	t.Helper()
+
+	project := testutils.NewTempProject(t)
+
+	return &stageHarness{
+		project:     project,
+		provisioner: &testutils.StubProvisioner{ClusterExists: exists},
+		docker:      mockDaemon(t, daemonName),
+		output:      filepath.Join(project.Dir, devcontainer.DefaultKubeconfigOutput),
+	}
+}
+
+func mockDaemon(t *testing.T, daemonName string) *dockerclient.MockAPIClient {
+	t.Helper()
+
+	docker := dockerclient.NewMockAPIClient(t)
+	docker.EXPECT().Info(mock.Anything).Return(system.Info{Name: daemonName}, nil).Once()
+
+	return docker
+}
+
+func (h *stageHarness) run(t *testing.T, stage devcontainer.Stage) error {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "stage"}
+	cmd.SetOut(&discardWriter{})
+	cmd.SetContext(context.Background())
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, ksailconfigmanager.DefaultClusterFieldSelectors())
+	cfgManager.Viper.SetConfigFile(h.project.ConfigPath)
+	cfgManager.Viper.Set("kubeconfig-output", h.output)
+
+	deps := devcontainer.Deps{
+		Factory: &testutils.StubFactory{
+			Provisioner:        h.provisioner,
+			DistributionConfig: &v1alpha4.Cluster{Name: h.project.ClusterName},
+		},
+		Detector: devcontainersvc.Detector{
+			Getenv:   func(string) string { return "true" },
+			Stat:     os.Stat,
+			Hostname: func() (string, error) { return "devcontainer-1", nil },
+		},
+		RunCreate: func(*cobra.Command) error {
+			h.createCalls++
+
+			return nil
+		},
+		RunStart: func(*cobra.Command) error {
+			h.startCalls++
+
+			return nil
+		},
+		WithDockerClient: func(_ *cobra.Command, operation func(client.APIClient) error) error {
+			return operation(h.docker)
+		},
+		WaitForAPIServer: func(_ context.Context, kubeconfig, _ string, _ time.Duration) error {
+			h.waitedFor = kubeconfig
+
+			return nil
+		},
+	}
+
+	return devcontainer.HandleStageRunE(cmd, cfgManager, deps, stage)
+}
+
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestOnCreateCreatesMissingClusterAndExportsKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	harness := newStageHarness(t, false, "devcontainer-1")
+
+	require.NoError(t, harness.run(t, devcontainer.StageOnCreate))
+
+	assert.Equal(t, 1, harness.createCalls)
+	assert.Equal(t, 0, harness.startCalls)
+	assert.Equal(t, harness.output, harness.waitedFor)
+
+	exported, err := clientcmd.LoadFromFile(harness.output)
+	require.NoError(t, err)
+	assert.Equal(t, harness.project.Context, exported.CurrentContext)
+	assert.Equal(t, "https://127.0.0.1:6443", exported.Clusters[harness.project.Context].Server)
+}
+
+func TestOnCreateReusesExistingCluster(t *testing.T) {
+	t.Parallel()
+
+	harness := newStageHarness(t, true, "devcontainer-1")
+
+	require.NoError(t, harness.run(t, devcontainer.StageOnCreate))
+
+	assert.Equal(t, 0, harness.createCalls)
+	assert.Equal(t, 0, harness.startCalls)
+}
+
+func TestPostStartWithHostDaemonAttachesToClusterNetwork(t *testing.T) {
+	t.Parallel()
+
+	harness := newStageHarness(t, true, "docker-host")
+	harness.docker.EXPECT().ContainerInspect(mock.Anything, "devcontainer-1").
+		Return(container.InspectResponse{NetworkSettings: &container.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{},
+		}}, nil).
+		Once()
+	harness.docker.EXPECT().NetworkConnect(mock.Anything, "kind", "devcontainer-1", mock.Anything).
+		Return(nil).
+		Once()
+
+	require.NoError(t, harness.run(t, devcontainer.StagePostStart))
+
+	assert.Equal(t, 1, harness.startCalls)
+
+	exported, err := clientcmd.LoadFromFile(harness.output)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"https://"+harness.project.ClusterName+"-control-plane:6443",
+		exported.Clusters[harness.project.Context].Server,
+	)
+}
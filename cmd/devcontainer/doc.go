@@ -0,0 +1,6 @@
+// Package devcontainer provides the devcontainer command namespace.
+//
+// Its subcommands are meant to be referenced from devcontainer.json lifecycle hooks
+// (onCreateCommand and postStartCommand) to bring the KSail cluster up inside a
+// devcontainer or Codespace and export a kubeconfig that works from the container.
+package devcontainer
@@ -0,0 +1,230 @@
+package dns
+
+import (
+	"fmt"
+
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/hostdns"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	domainFlag = "domain"
+	ipFlag     = "ip"
+)
+
+// NewDNSCmd creates the dns command and its install and uninstall subcommands.
+func NewDNSCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns",
+		Short: "Route a host DNS domain to the cluster",
+		Long: `Configure the resolvers on the host so every name below a domain, such as
+*.ksail.test, resolves to the cluster's ingress IP.
+
+KSail writes a dnsmasq address rule and, depending on the host, a systemd-resolved
+drop-in (Linux) or an /etc/resolver file (macOS) that sends queries for the domain to
+dnsmasq. dnsmasq must be installed, and the commands usually need to run with sudo.
+The configuration is removed again by 'ksail dns uninstall' or 'ksail cluster delete'.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newInstallCmd(runtimeContainer))
+	cmd.AddCommand(newUninstallCmd(runtimeContainer))
+
+	return cmd
+}
+
+func newInstallCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Route a domain to the cluster ingress",
+		Long: `Route a domain and all names below it to the cluster ingress IP.
+
+The IP defaults to 127.0.0.1, where Kind and K3d publish ingress ports on the host.`,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().String(domainFlag, hostdns.DefaultDomain, "Domain to route to the cluster")
+	cmd.Flags().String(ipFlag, hostdns.DefaultIP, "IP address the domain resolves to")
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, manager *ksailconfigmanager.ConfigManager, deps cmdhelpers.LifecycleDeps) error {
+			dnsManager, err := hostdns.NewHostManager()
+			if err != nil {
+				return fmt.Errorf("failed to detect host resolvers: %w", err)
+			}
+
+			return HandleInstallRunE(cmd, manager, deps, dnsManager)
+		},
+	)
+
+	return cmd
+}
+
+func newUninstallCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "uninstall",
+		Short:        "Remove the domain routing of the cluster",
+		Long:         "Remove every host resolver file written by 'ksail dns install' for the cluster.",
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, manager *ksailconfigmanager.ConfigManager, deps cmdhelpers.LifecycleDeps) error {
+			dnsManager, err := hostdns.NewHostManager()
+			if err != nil {
+				return fmt.Errorf("failed to detect host resolvers: %w", err)
+			}
+
+			return HandleUninstallRunE(cmd, manager, deps, dnsManager)
+		},
+	)
+
+	return cmd
+}
+
+// HandleInstallRunE routes the --domain flag to the --ip flag for the configured cluster.
+// Exported for testing purposes.
+func HandleInstallRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+	dnsManager *hostdns.Manager,
+) error {
+	deps.Timer.Start()
+
+	clusterName, err := resolveClusterName(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	domain, _ := cmd.Flags().GetString(domainFlag)
+	ip, _ := cmd.Flags().GetString(ipFlag)
+
+	record := hostdns.Record{Cluster: clusterName, Domain: domain, IP: ip}
+
+	err = record.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid dns record: %w", err)
+	}
+
+	deps.Timer.NewStage()
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Install DNS...",
+		Emoji:   "🧭",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	for _, backend := range dnsManager.Backends() {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "configuring %s in '%s'",
+			Args:    []any{backend.Name, backend.Dir},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	err = dnsManager.Install(cmd.Context(), record)
+	if err != nil {
+		return fmt.Errorf("failed to install dns: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "*.%s resolves to %s",
+		Args:    []any{record.Domain, record.IP},
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// HandleUninstallRunE removes the domain routing of the configured cluster.
+// Exported for testing purposes.
+func HandleUninstallRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+	dnsManager *hostdns.Manager,
+) error {
+	deps.Timer.Start()
+
+	clusterName, err := resolveClusterName(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	deps.Timer.NewStage()
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Uninstall DNS...",
+		Emoji:   "🧭",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	removed, err := dnsManager.Uninstall(cmd.Context(), clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall dns: %w", err)
+	}
+
+	content := "dns routing removed"
+	if !removed {
+		content = "no dns routing configured"
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: content,
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+func resolveClusterName(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) (string, error) {
+	clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, deps.Timer))
+	if err != nil {
+		return "", fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	_, distributionConfig, err := cmdhelpers.CreateClusterProvisioner(cmd, deps.Factory, clusterCfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve cluster provisioner: %w", err)
+	}
+
+	clusterName, err := configmanager.GetClusterName(distributionConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster name from config: %w", err)
+	}
+
+	return clusterName, nil
+}
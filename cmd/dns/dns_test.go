@@ -0,0 +1,110 @@
+package dns_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/dns"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/hostdns"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+type dnsFixture struct {
+	project    *testutils.TempProject
+	dnsmasqDir string
+	manager    *hostdns.Manager
+}
+
+func newDNSFixture(t *testing.T) *dnsFixture {
+	t.Helper()
+
+	dnsmasqDir := filepath.Join(t.TempDir(), "dnsmasq.d")
+	manager := hostdns.NewManager(
+		[]hostdns.Backend{hostdns.NewDnsmasqBackend(dnsmasqDir)},
+	)
+
+	return &dnsFixture{project: testutils.NewTempProject(t), dnsmasqDir: dnsmasqDir, manager: manager}
+}
+
+func (f *dnsFixture) run(
+	t *testing.T,
+	handler func(*cobra.Command, *ksailconfigmanager.ConfigManager, cmdhelpers.LifecycleDeps, *hostdns.Manager) error,
+	args ...string,
+) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cmd := &cobra.Command{Use: "dns"}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+	cmd.Flags().String("domain", hostdns.DefaultDomain, "")
+	cmd.Flags().String("ip", hostdns.DefaultIP, "")
+	require.NoError(t, cmd.Flags().Parse(args))
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, ksailconfigmanager.DefaultClusterFieldSelectors())
+	cfgManager.Viper.SetConfigFile(f.project.ConfigPath)
+
+	deps := cmdhelpers.LifecycleDeps{
+		Timer: &testutils.RecordingTimer{},
+		Factory: &testutils.StubFactory{
+			Provisioner:        &testutils.StubProvisioner{},
+			DistributionConfig: &v1alpha4.Cluster{Name: f.project.ClusterName},
+		},
+	}
+
+	err := handler(cmd, cfgManager, deps, f.manager)
+
+	return out.String(), err
+}
+
+func TestInstallRoutesDomainForCluster(t *testing.T) {
+	t.Parallel()
+
+	fixture := newDNSFixture(t)
+
+	out, err := fixture.run(t, dns.HandleInstallRunE, "--domain", "apps.ksail.test", "--ip", "172.18.0.2")
+	require.NoError(t, err)
+	assert.Contains(t, out, "*.apps.ksail.test resolves to 172.18.0.2")
+
+	data, err := os.ReadFile(filepath.Join(fixture.dnsmasqDir, "ksail-apps.ksail.test.conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "cluster "+fixture.project.ClusterName)
+	assert.Contains(t, string(data), "address=/apps.ksail.test/172.18.0.2")
+}
+
+func TestInstallRejectsInvalidIP(t *testing.T) {
+	t.Parallel()
+
+	fixture := newDNSFixture(t)
+
+	_, err := fixture.run(t, dns.HandleInstallRunE, "--ip", "not-an-ip")
+	require.ErrorIs(t, err, hostdns.ErrInvalidIP)
+}
+
+func TestUninstallRemovesClusterRouting(t *testing.T) {
+	t.Parallel()
+
+	fixture := newDNSFixture(t)
+
+	_, err := fixture.run(t, dns.HandleInstallRunE)
+	require.NoError(t, err)
+
+	out, err := fixture.run(t, dns.HandleUninstallRunE)
+	require.NoError(t, err)
+	assert.Contains(t, out, "dns routing removed")
+	assert.NoFileExists(t, filepath.Join(fixture.dnsmasqDir, "ksail-ksail.test.conf"))
+
+	out, err = fixture.run(t, dns.HandleUninstallRunE)
+	require.NoError(t, err)
+	assert.Contains(t, out, "no dns routing configured")
+}
@@ -0,0 +1,3 @@
+// Package dns provides the dns command, which routes a host DNS domain to the active KSail
+// cluster through the resolvers installed on the host.
+package dns
@@ -0,0 +1,2 @@
+// Package env provides the env command, which prints shell exports for the active KSail cluster.
+package env
@@ -0,0 +1,158 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/io/shellenv"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shellFlag   = "shell"
+	evalCommand = "ksail env"
+)
+
+// Names of the exported environment variables.
+const (
+	KubeconfigVar   = "KUBECONFIG"
+	ClusterNameVar  = "KSAIL_CLUSTER_NAME"
+	DistributionVar = "KSAIL_DISTRIBUTION"
+	ContextVar      = "KSAIL_CONTEXT"
+	RegistryVar     = "KSAIL_REGISTRY"
+)
+
+// NewEnvCmd creates the env command.
+func NewEnvCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print shell exports for the active cluster",
+		Long: `Print shell statements that export the kubeconfig, context, cluster name and
+local registry endpoint of the cluster defined by ksail.yaml.
+
+Evaluate the output to wire the cluster into the current shell:
+
+  bash/zsh:  eval "$(ksail env)"
+  fish:      ksail env --shell fish | source
+  direnv:    ksail env --shell direnv > .envrc
+
+The shell defaults to the one in $SHELL.`,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().String(shellFlag, "", "Shell syntax to print (bash, zsh, fish, direnv); defaults to $SHELL")
+	_ = cfgManager.Viper.BindPFlag(shellFlag, cmd.Flags().Lookup(shellFlag))
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, manager *ksailconfigmanager.ConfigManager, deps cmdhelpers.LifecycleDeps) error {
+			return HandleEnvRunE(cmd, manager, deps)
+		},
+	)
+
+	return cmd
+}
+
+// HandleEnvRunE prints the shell exports for the configured cluster.
+// Exported for testing purposes.
+func HandleEnvRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	shell := shellenv.DetectShell(os.Getenv("SHELL"))
+
+	if value := cfgManager.Viper.GetString(shellFlag); value != "" {
+		parsed, err := shellenv.ParseShell(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s value: %w", shellFlag, err)
+		}
+
+		shell = parsed
+	}
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	vars, err := buildVariables(cmd, clusterCfg, deps)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), shellenv.Render(shell, vars, evalCommand))
+	if err != nil {
+		return fmt.Errorf("failed to write environment: %w", err)
+	}
+
+	return nil
+}
+
+func buildVariables(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	deps cmdhelpers.LifecycleDeps,
+) ([]shellenv.Variable, error) {
+	_, distributionConfig, err := cmdhelpers.CreateClusterProvisioner(cmd, deps.Factory, clusterCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve cluster provisioner: %w", err)
+	}
+
+	clusterName, err := configmanager.GetClusterName(distributionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster name from config: %w", err)
+	}
+
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+
+	kubeconfig, err = filepath.Abs(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve kubeconfig path: %w", err)
+	}
+
+	vars := []shellenv.Variable{
+		{Name: KubeconfigVar, Value: kubeconfig},
+		{Name: ClusterNameVar, Value: clusterName},
+		{Name: DistributionVar, Value: string(clusterCfg.Spec.Distribution)},
+	}
+
+	if contextName := clusterCfg.Spec.Connection.Context; contextName != "" {
+		vars = append(vars, shellenv.Variable{Name: ContextVar, Value: contextName})
+	}
+
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		vars = append(vars, shellenv.Variable{Name: RegistryVar, Value: localRegistryEndpoint(clusterCfg)})
+	}
+
+	return vars, nil
+}
+
+// localRegistryEndpoint returns the host address of the local registry, matching the port
+// `ksail cluster create` publishes it on.
+func localRegistryEndpoint(clusterCfg *v1alpha1.Cluster) string {
+	port := dockerclient.DefaultRegistryPort
+	if clusterCfg.Spec.Options.LocalRegistry.HostPort > 0 {
+		port = int(clusterCfg.Spec.Options.LocalRegistry.HostPort)
+	}
+
+	return registry.DefaultEndpointHost + ":" + strconv.Itoa(port)
+}
@@ -0,0 +1,83 @@
+package env_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/env"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/io/shellenv"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func runEnv(t *testing.T, project *testutils.TempProject, shell string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cmd := &cobra.Command{Use: "env"}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, ksailconfigmanager.DefaultClusterFieldSelectors())
+	cfgManager.Viper.SetConfigFile(project.ConfigPath)
+	cfgManager.Viper.Set("shell", shell)
+
+	deps := cmdhelpers.LifecycleDeps{
+		Factory: &testutils.StubFactory{
+			Provisioner:        &testutils.StubProvisioner{},
+			DistributionConfig: &v1alpha4.Cluster{Name: project.ClusterName},
+		},
+	}
+
+	err := env.HandleEnvRunE(cmd, cfgManager, deps)
+
+	return out.String(), err
+}
+
+func TestEnvPrintsClusterExports(t *testing.T) {
+	t.Parallel()
+
+	project := testutils.NewTempProject(t)
+
+	out, err := runEnv(t, project, "bash")
+	require.NoError(t, err)
+
+	assert.Equal(t, shellenv.Render(shellenv.ShellBash, []shellenv.Variable{
+		{Name: env.KubeconfigVar, Value: project.KubeconfigPath},
+		{Name: env.ClusterNameVar, Value: project.ClusterName},
+		{Name: env.DistributionVar, Value: string(v1alpha1.DistributionKind)},
+		{Name: env.ContextVar, Value: project.Context},
+	}, "ksail env"), out)
+}
+
+func TestEnvExportsLocalRegistryEndpoint(t *testing.T) {
+	t.Parallel()
+
+	project := testutils.NewTempProject(t, testutils.WithTempProjectConfig(func(cluster *v1alpha1.Cluster) {
+		cluster.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+		cluster.Spec.Options.LocalRegistry.HostPort = 5123
+	}))
+
+	out, err := runEnv(t, project, "fish")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "set -gx "+env.RegistryVar+" 'localhost:5123';\n")
+	assert.Contains(t, out, "# ksail env | source\n")
+}
+
+func TestEnvRejectsUnknownShell(t *testing.T) {
+	t.Parallel()
+
+	project := testutils.NewTempProject(t)
+
+	_, err := runEnv(t, project, "powershell")
+	require.ErrorIs(t, err, shellenv.ErrUnsupportedShell)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/spf13/cobra"
+)
+
+const (
+	historyOutputText = "text"
+	historyOutputJSON = "json"
+
+	defaultHistoryLimit = 50
+)
+
+var (
+	errUnsupportedHistoryOutput  = errors.New("unsupported output format")
+	errUnsupportedHistoryOutcome = errors.New("unsupported outcome")
+)
+
+// newHistoryCmd creates the history command, which queries the audit log of mutating actions.
+func newHistoryCmd() *cobra.Command {
+	var (
+		query   audit.Query
+		outcome string
+		since   time.Duration
+		output  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the audit log of mutating actions",
+		Long: `Show the mutating actions KSail performed, such as cluster creates and deletes, ` +
+			`registry changes, workload applies and component installs, with their outcomes. ` +
+			`Entries are read from the audit log at $` + audit.PathEnvVar + ` or, by default, ` +
+			`audit.log in the ksail directory of the user's configuration directory. ` +
+			`Set ` + audit.PathEnvVar + `=` + audit.Disabled + ` to stop recording.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != historyOutputText && output != historyOutputJSON {
+				return fmt.Errorf("%w: %q (use %s or %s)",
+					errUnsupportedHistoryOutput, output, historyOutputText, historyOutputJSON)
+			}
+
+			switch audit.Outcome(outcome) {
+			case "", audit.OutcomeSuccess, audit.OutcomeFailure:
+				query.Outcome = audit.Outcome(outcome)
+			default:
+				return fmt.Errorf("%w: %q (use %s or %s)",
+					errUnsupportedHistoryOutcome, outcome, audit.OutcomeSuccess, audit.OutcomeFailure)
+			}
+
+			if since > 0 {
+				query.Since = time.Now().Add(-since)
+			}
+
+			entries, err := audit.DefaultLog().Entries()
+			if err != nil {
+				return fmt.Errorf("failed to read audit log: %w", err)
+			}
+
+			entries = query.Filter(entries)
+
+			if output == historyOutputJSON {
+				return writeHistoryJSON(cmd.OutOrStdout(), entries)
+			}
+
+			return writeHistoryText(cmd.OutOrStdout(), entries)
+		},
+	}
+
+	cmd.Flags().StringVar(&query.Action, "action", "",
+		"Only show entries of this action or action group (e.g. cluster, cluster.create, registry)")
+	cmd.Flags().StringVar(&query.Cluster, "cluster", "", "Only show entries that targeted this cluster")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "Only show entries with this outcome (success or failure)")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this duration (e.g. 24h)")
+	cmd.Flags().IntVar(&query.Limit, "limit", defaultHistoryLimit, "Show at most this many entries (0 for all)")
+	cmd.Flags().StringVarP(&output, "output", "o", historyOutputText, "Output format (text or json)")
+
+	return cmd
+}
+
+func writeHistoryJSON(writer io.Writer, entries []audit.Entry) error {
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(entries)
+	if err != nil {
+		return fmt.Errorf("encode audit entries: %w", err)
+	}
+
+	return nil
+}
+
+func writeHistoryText(writer io.Writer, entries []audit.Entry) error {
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintln(writer, "No recorded actions")
+
+		return nil
+	}
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "TIME\tRUN\tACTION\tCLUSTER\tOUTCOME\tDURATION\tDETAILS")
+
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format(time.DateTime),
+			entry.RunID,
+			entry.Action,
+			valueOrDash(entry.Cluster),
+			entry.Outcome,
+			(time.Duration(entry.DurationMS) * time.Millisecond).String(),
+			historyDetails(entry),
+		)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("write audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// historyDetails summarises what an entry acted on and why it failed.
+func historyDetails(entry audit.Entry) string {
+	var details []string
+
+	if entry.Target != "" {
+		details = append(details, entry.Target)
+	}
+
+	if entry.Command != "" {
+		details = append(details, entry.Command)
+	}
+
+	if len(entry.Resources) > 0 {
+		details = append(details, fmt.Sprintf("%d resources", len(entry.Resources)))
+	}
+
+	if entry.Error != "" {
+		details = append(details, "error: "+entry.Error)
+	}
+
+	return strings.Join(details, "; ")
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/imagearchive"
+	"github.com/devantler-tech/ksail-go/pkg/svc/prepull"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/docker/docker/client"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	k3dtypes "github.com/k3d-io/k3d/v5/pkg/types"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const defaultImageArchive = "ksail-images.tar"
+
+// newImagesCmd creates the images command, which moves the images of a cluster to machines
+// without internet access.
+func newImagesCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "Move cluster images to machines without internet access",
+		Long: `Export the node image and the component images of the cluster to an archive on a ` +
+			`machine with internet access, and import the archive on an air-gapped machine. ` +
+			`Combine it with spec.options.helm.chartMirror and --offline to create clusters ` +
+			`without reaching the internet.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newImagesExportCmd(runtimeContainer))
+	cmd.AddCommand(newImagesImportCmd(runtimeContainer))
+
+	return cmd
+}
+
+// newImagesExportCmd creates the images export command.
+func newImagesExportCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the images of the cluster to an archive",
+		Long: `Pull the node image and the images of the components declared in ksail.yaml, such as ` +
+			`the CNI, metrics-server and the GitOps engine, into a single archive in the format ` +
+			`of 'docker save'.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.Flags().StringVarP(&output, "output", "o", defaultImageArchive, "Path of the archive to write")
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(
+		runtimeContainer,
+		cfgManager,
+		func(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager, deps cmdhelpers.LifecycleDeps) error {
+			return handleImagesExportRunE(cmd, cfgManager, deps, output)
+		},
+	)
+
+	return cmd
+}
+
+func handleImagesExportRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+	output string,
+) error {
+	deps.Timer.Start()
+
+	clusterCfg, kindConfig, k3dConfig, err := cluster.LoadClusterConfiguration(
+		cfgManager,
+		cmdhelpers.MaybeTimer(cmd, deps.Timer),
+	)
+	if err != nil {
+		return err
+	}
+
+	images := archiveImages(clusterCfg, kindConfig, k3dConfig)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Export images...",
+		Emoji:   "📦",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "pulling %d images",
+		Args:    []any{len(images)},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = imagearchive.Export(cmd.Context(), output, images)
+	if err != nil {
+		return fmt.Errorf("failed to export images: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "images exported to '%s'",
+		Args:    []any{output},
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// archiveImages returns the images an air-gapped machine needs to create the cluster: the
+// node image, the helper images of K3d and the images of the declared components.
+func archiveImages(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	k3dConfig *v1alpha5.SimpleConfig,
+) []string {
+	var images []string
+
+	nodeImage := cluster.ClusterNodeImage(clusterCfg, kindConfig, k3dConfig)
+	if nodeImage != "" {
+		images = append(images, nodeImage)
+	}
+
+	if clusterCfg.Spec.Distribution == v1alpha1.DistributionK3d {
+		images = append(images, k3dtypes.GetLoadbalancerImage(), k3dtypes.GetToolsImage())
+	}
+
+	for _, image := range prepull.ComponentImages(clusterCfg) {
+		if !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+
+	return images
+}
+
+// newImagesImportCmd creates the images import command.
+func newImagesImportCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [archive]",
+		Short: "Import an archive of images",
+		Long: `Load an archive written by 'ksail images export' into Docker, where Kind and K3d find ` +
+			`their node images, and into the nodes of the running cluster of the project, so its ` +
+			`components start without pulling images. The archive defaults to ` + defaultImageArchive + `.`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleImagesImportRunE)
+
+	cmdhelpers.MarkAudited(cmd, "images.import")
+
+	return cmd
+}
+
+func handleImagesImportRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	deps.Timer.Start()
+
+	archive := cmd.Flags().Arg(0)
+	if archive == "" {
+		archive = defaultImageArchive
+	}
+
+	clusterCfg, kindConfig, k3dConfig, err := cluster.LoadClusterConfiguration(
+		cfgManager,
+		cmdhelpers.MaybeTimer(cmd, deps.Timer),
+	)
+	if err != nil {
+		return err
+	}
+
+	clusterName := cluster.ResolveClusterName(clusterCfg, kindConfig, k3dConfig)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Import images...",
+		Emoji:   "📦",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	var nodes []string
+
+	err = cmdhelpers.WithDockerClient(cmd, func(dockerClient client.APIClient) error {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "loading '%s' into docker",
+			Args:    []any{archive},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		err := imagearchive.LoadIntoDocker(cmd.Context(), dockerClient, archive)
+		if err != nil {
+			return fmt.Errorf("load into docker: %w", err)
+		}
+
+		nodes, err = imagearchive.NodeContainers(cmd.Context(), dockerClient, clusterName)
+		if err != nil {
+			return fmt.Errorf("find cluster nodes: %w", err)
+		}
+
+		for _, node := range nodes {
+			notify.WriteMessage(notify.Message{
+				Type:    notify.ActivityType,
+				Content: "loading '%s' into node %s",
+				Args:    []any{archive, node},
+				Writer:  cmd.OutOrStdout(),
+			})
+
+			err = imagearchive.LoadIntoNode(cmd.Context(), dockerClient, node, archive)
+			if err != nil {
+				return fmt.Errorf("load into node %s: %w", node, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import images: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "images imported into docker and %d nodes",
+		Args:    []any{len(nodes)},
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
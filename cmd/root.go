@@ -5,14 +5,22 @@ import (
 
 	"github.com/devantler-tech/ksail-go/cmd/cipher"
 	cluster "github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/cmd/devcontainer"
+	"github.com/devantler-tech/ksail-go/cmd/dns"
+	"github.com/devantler-tech/ksail-go/cmd/env"
 	"github.com/devantler-tech/ksail-go/cmd/workload"
+	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
 	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	"github.com/devantler-tech/ksail-go/pkg/svc/versioninfo"
 	"github.com/devantler-tech/ksail-go/pkg/ui/asciiart"
 	errorhandler "github.com/devantler-tech/ksail-go/pkg/ui/error-handler"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/spf13/cobra"
 )
 
+const kubectlPluginDisplayName = "kubectl ksail"
+
 // NewRootCmd creates and returns the root command with version info and subcommands.
 func NewRootCmd(version, commit, date string) *cobra.Command {
 	runtimeContainer := runtime.NewRuntime()
@@ -25,6 +33,25 @@ func NewRootCmd(version, commit, date string) *cobra.Command {
 			`from one simple command line tool.`,
 		RunE:         handleRootRunE,
 		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			err := pkgcmd.ConfigureCIOutput(cmd)
+			if err != nil {
+				return err
+			}
+
+			err = pkgcmd.ConfigureOffline(cmd)
+			if err != nil {
+				return err
+			}
+
+			pkgcmd.StartAudit(cmd)
+
+			return pkgcmd.StartProfiling(cmd)
+		},
+	}
+
+	if kubectl.RunningAsPlugin() {
+		ConfigureKubectlPlugin(cmd)
 	}
 
 	// Set version if available
@@ -36,19 +63,68 @@ func NewRootCmd(version, commit, date string) *cobra.Command {
 		"Show per-activity timing output",
 	)
 
+	cmd.PersistentFlags().String(
+		pkgcmd.CIFlagName,
+		"",
+		"Render output for a CI system (github: emit GitHub Actions annotations and log groups)",
+	)
+
+	cmd.PersistentFlags().Bool(
+		pkgcmd.OfflineFlagName,
+		false,
+		"Forbid network access (Helm repositories, upstream registries, remote manifests) and fail when it is needed",
+	)
+
+	pkgcmd.AddProfilingFlags(cmd)
+
 	// Add all subcommands
 	cmd.AddCommand(cluster.NewClusterCmd(runtimeContainer))
 	cmd.AddCommand(workload.NewWorkloadCmd(runtimeContainer))
 	cmd.AddCommand(cipher.NewCipherCmd(runtimeContainer))
+	cmd.AddCommand(devcontainer.NewDevcontainerCmd(runtimeContainer))
+	cmd.AddCommand(env.NewEnvCmd(runtimeContainer))
+	cmd.AddCommand(dns.NewDNSCmd(runtimeContainer))
+	cmd.AddCommand(newUpdateCmd(runtimeContainer))
+	cmd.AddCommand(newImagesCmd(runtimeContainer))
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newVersionCmd(versioninfo.Build{Version: version, Commit: commit, Date: date}))
 
 	return cmd
 }
 
+// ConfigureKubectlPlugin presents cmd as `kubectl ksail` in usage and help output,
+// as kubectl expects from plugins installed as kubectl-ksail.
+func ConfigureKubectlPlugin(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+
+	cmd.Annotations[cobra.CommandDisplayNameAnnotation] = kubectlPluginDisplayName
+}
+
 // Execute runs the provided root command and handles errors.
 func Execute(cmd *cobra.Command) error {
 	executor := errorhandler.NewExecutor()
 
 	err := executor.Execute(cmd)
+
+	// Record the outcome of mutating commands in the audit log
+	pkgcmd.FinishAudit(cmd, err)
+
+	// Stop profiling after the command so failing runs are profiled too
+	profileErr := pkgcmd.StopProfiling(cmd)
+	if profileErr != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to write profiles: %v",
+			Args:    []any{profileErr},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	// Close any CI log group left open by the last stage before the final result is reported
+	notify.EndGroup(cmd.OutOrStdout())
+
 	if err != nil {
 		return fmt.Errorf("command execution failed: %w", err)
 	}
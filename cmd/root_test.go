@@ -2,12 +2,17 @@ package cmd_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/devantler-tech/ksail-go/cmd"
 	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/devantler-tech/ksail-go/pkg/svc/versioninfo"
 	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
@@ -60,6 +65,176 @@ func TestExecuteShowsVersion(t *testing.T) {
 	snaps.MatchSnapshot(t, out.String())
 }
 
+// TestCLIOutputSnapshots locks down the help text, version output and error messages of the CLI.
+func TestVersionCommandPrintsMatrixAsJSON(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	root := cmd.NewRootCmd("1.2.3", "abc123", "2025-08-17")
+	root.SetOut(&out)
+	root.SetArgs([]string{"version", "--output", "json"})
+
+	err := root.Execute()
+	if err != nil {
+		t.Fatalf("version command failed: %v", err)
+	}
+
+	var matrix versioninfo.Matrix
+
+	err = json.Unmarshal(out.Bytes(), &matrix)
+	if err != nil {
+		t.Fatalf("version output is not JSON: %v\n%s", err, out.String())
+	}
+
+	if matrix.KSail.Version != "1.2.3" || matrix.KSail.Commit != "abc123" {
+		t.Fatalf("unexpected build info: %+v", matrix.KSail)
+	}
+
+	if len(matrix.Libraries) == 0 || len(matrix.NodeImages) == 0 || len(matrix.Charts) == 0 {
+		t.Fatalf("version matrix is incomplete: %+v", matrix)
+	}
+}
+
+func TestVersionCommandRejectsUnknownOutput(t *testing.T) {
+	t.Parallel()
+
+	root := cmd.NewRootCmd("1.2.3", "abc123", "2025-08-17")
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"version", "--output", "xml"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+		t.Fatalf("expected unsupported output error, got %v", err)
+	}
+}
+
+func TestOfflineVersionSkipsUpdateCheck(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+
+	root := cmd.NewRootCmd("1.2.3", "abc123", "2025-08-17")
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs([]string{"--offline", "version", "--check-updates"})
+
+	err := root.Execute()
+	if err != nil {
+		t.Fatalf("version command failed: %v", err)
+	}
+
+	if !strings.Contains(errOut.String(), offline.ErrNetworkDisabled.Error()) {
+		t.Fatalf("expected an offline warning, got:\n%s", errOut.String())
+	}
+
+	if !strings.Contains(out.String(), "1.2.3") {
+		t.Fatalf("expected the version matrix, got:\n%s", out.String())
+	}
+}
+
+func TestCLIOutputSnapshots(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "root help", args: []string{"--help"}},
+		{name: "cluster help", args: []string{"cluster", "--help"}},
+		{name: "workload help", args: []string{"workload", "--help"}},
+		{name: "cipher help", args: []string{"cipher", "--help"}},
+		{name: "version", args: []string{"--version"}},
+		{name: "unknown command", args: []string{"nonexistent"}},
+		{name: "unknown flag", args: []string{"cluster", "--nonexistent"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := cmd.NewRootCmd("1.2.3", "abc1234", "2025-08-17")
+
+			testutils.MatchCLISnapshot(t, root, testCase.args)
+		})
+	}
+}
+
+func TestCLIExitCategoriesAndStderr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		args []string
+		want testutils.CLIExpectation
+	}{
+		{
+			name: "help succeeds",
+			args: []string{"--help"},
+			want: testutils.CLIExpectation{Category: testutils.ExitSuccess},
+		},
+		{
+			name: "unknown command is a usage error",
+			args: []string{"nonexistent"},
+			want: testutils.CLIExpectation{
+				Category: testutils.ExitUsageError,
+				Stderr:   `✗ unknown command "nonexistent" for "ksail"`,
+			},
+		},
+		{
+			name: "unknown flag is a usage error",
+			args: []string{"cluster", "--nonexistent"},
+			want: testutils.CLIExpectation{
+				Category: testutils.ExitUsageError,
+				Stderr:   "✗ unknown flag: --nonexistent",
+			},
+		},
+		{
+			name: "missing argument is a usage error",
+			args: []string{"cipher", "encrypt"},
+			want: testutils.CLIExpectation{
+				Category:       testutils.ExitUsageError,
+				StderrContains: []string{"requires at least 1 arg(s), only received 0"},
+			},
+		},
+		{
+			name: "unsupported file is a runtime error",
+			args: []string{"cipher", "encrypt", "secrets.txt"},
+			want: testutils.CLIExpectation{
+				Category: testutils.ExitRuntimeError,
+				Stderr:   "✗ unsupported file format",
+			},
+		},
+		{
+			name: "github CI mode annotates runtime errors",
+			args: []string{"--ci", "github", "cipher", "encrypt", "secrets.txt"},
+			want: testutils.CLIExpectation{
+				Category: testutils.ExitRuntimeError,
+				Stderr:   "::error::unsupported file format",
+			},
+		},
+		{
+			name: "unknown CI format is a runtime error",
+			args: []string{"--ci", "jenkins", "cipher", "encrypt", "secrets.txt"},
+			want: testutils.CLIExpectation{
+				Category: testutils.ExitRuntimeError,
+				Stderr:   "✗ unsupported CI format",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := testutils.RunCLI(t, cmd.NewRootCmd("test", "test", "test"), testCase.args...)
+
+			testutils.AssertCLIResult(t, result, testCase.want)
+		})
+	}
+}
+
 func TestNewRootCmdTimingFlagDefaultFalse(t *testing.T) {
 	t.Parallel()
 
@@ -291,3 +466,71 @@ func TestExecuteWrapperError(t *testing.T) {
 		t.Fatalf("Expected error to wrap %v, got %v", errRootTest, err)
 	}
 }
+
+func TestConfigureKubectlPluginShowsPluginName(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	root := cmd.NewRootCmd("", "", "")
+	cmd.ConfigureKubectlPlugin(root)
+	root.SetOut(&out)
+	root.SetArgs([]string{"--help"})
+
+	err := root.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "kubectl ksail [command]") {
+		t.Fatalf("expected plugin usage line in help output, got:\n%s", out.String())
+	}
+}
+
+//nolint:paralleltest // Points the audit log at a temporary file through the environment.
+func TestHistoryShowsAuditedRuns(t *testing.T) {
+	t.Setenv(audit.PathEnvVar, filepath.Join(t.TempDir(), "audit.log"))
+
+	failing := newTestCommand("fail", func(_ *cobra.Command, _ []string) error {
+		return errRootTest
+	})
+	pkgcmd.MarkAudited(failing, "probe.fail")
+
+	root := cmd.NewRootCmd("test", "test", "test")
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.AddCommand(failing)
+	root.SetArgs([]string{"fail"})
+
+	err := cmd.Execute(root)
+	if !errors.Is(err, errRootTest) {
+		t.Fatalf("expected the probe error, got %v", err)
+	}
+
+	var out bytes.Buffer
+
+	root = cmd.NewRootCmd("test", "test", "test")
+	root.SetOut(&out)
+	root.SetArgs([]string{"history", "--action", "probe", "--output", "json"})
+
+	err = root.Execute()
+	if err != nil {
+		t.Fatalf("history command failed: %v", err)
+	}
+
+	var entries []audit.Entry
+
+	err = json.Unmarshal(out.Bytes(), &entries)
+	if err != nil {
+		t.Fatalf("history output is not JSON: %v\n%s", err, out.String())
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected one audited run, got %+v", entries)
+	}
+
+	if entries[0].Command != "ksail fail" || entries[0].Outcome != audit.OutcomeFailure ||
+		entries[0].Error != errRootTest.Error() {
+		t.Fatalf("unexpected audit entry: %+v", entries[0])
+	}
+}
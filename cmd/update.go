@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/devantler-tech/ksail-go/cmd/cluster"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/devantler-tech/ksail-go/pkg/svc/prepull"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+// newUpdateCmd creates the update command, which refreshes the pins in the project's lock.
+func newUpdateCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Refresh the pins in " + lockfile.DefaultPath,
+		Long: `Refresh the pins in ` + lockfile.DefaultPath + `: resolve every locked Helm chart to its ` +
+			`newest version, re-resolve the digests of the component images and record the node ` +
+			`image of the current distribution configuration. Runs with --frozen-lockfile deploy ` +
+			`exactly these pins until the next update.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(
+		cmd,
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+	)
+
+	cmdhelpers.AddRefreshChecksumsFlag(cmd)
+
+	cmd.RunE = cmdhelpers.WrapLifecycleHandler(runtimeContainer, cfgManager, handleUpdateRunE)
+
+	cmdhelpers.MarkAudited(cmd, "lockfile.update")
+
+	return cmd
+}
+
+func handleUpdateRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps cmdhelpers.LifecycleDeps,
+) error {
+	deps.Timer.Start()
+
+	outputTimer := cmdhelpers.MaybeTimer(cmd, deps.Timer)
+
+	clusterCfg, kindConfig, k3dConfig, err := cluster.LoadClusterConfiguration(cfgManager, outputTimer)
+	if err != nil {
+		return err
+	}
+
+	lock := lockfile.New(cmdhelpers.ProjectPath(cmd, lockfile.DefaultPath), lockfile.ModeUpdate)
+
+	pins, err := lock.File()
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Update " + lockfile.DefaultPath + "...",
+		Emoji:   "📌",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "resolving images",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	images := prepull.ComponentImages(clusterCfg)
+	for image := range pins.Images {
+		if !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+
+	err = cluster.LockImages(cmd, lock, cluster.ClusterNodeImage(clusterCfg, kindConfig, k3dConfig), images)
+	if err != nil {
+		return err
+	}
+
+	err = updateLockedCharts(cmd, lock, pins.Charts)
+	if err != nil {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: lockfile.DefaultPath + " updated",
+		Timer:   cmdhelpers.MaybeTimer(cmd, deps.Timer),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// updateLockedCharts resolves every locked chart to its newest version, which the lock pins.
+func updateLockedCharts(cmd *cobra.Command, lock *lockfile.Lock, charts map[string]lockfile.Chart) error {
+	if len(charts) == 0 {
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "resolving charts",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	helmClient, err := helm.NewClient(
+		"",
+		"",
+		cmdhelpers.HelmChecksumOption(cmd),
+		helm.WithChartLock(lock, checksum.DigestFile),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create Helm client: %w", err)
+	}
+
+	sources := make([]string, 0, len(charts))
+	for source := range charts {
+		sources = append(sources, source)
+	}
+
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		err = helmClient.ResolveChart(cmd.Context(), helm.ChartSpecForSource(source))
+		if err != nil {
+			return fmt.Errorf("failed to update chart %s: %w", source, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/devantler-tech/ksail-go/pkg/svc/versioninfo"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	versionOutputText = "text"
+	versionOutputJSON = "json"
+
+	updateCheckTimeout = 10 * time.Second
+)
+
+var errUnsupportedVersionOutput = errors.New("unsupported output format")
+
+// newVersionCmd creates the version command, which prints the version matrix of the build.
+func newVersionCmd(build versioninfo.Build) *cobra.Command {
+	var (
+		output       string
+		checkUpdates bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show KSail and component versions",
+		Long: `Show the version of KSail together with the versions of the components it embeds ` +
+			`(client-go, Helm, SOPS, Kind, K3d), the node images new clusters start from and the ` +
+			`Helm charts the component installers deploy.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if output != versionOutputText && output != versionOutputJSON {
+				return fmt.Errorf("%w: %q (use %s or %s)",
+					errUnsupportedVersionOutput, output, versionOutputText, versionOutputJSON)
+			}
+
+			matrix := versioninfo.NewMatrix(build)
+
+			if checkUpdates {
+				matrix.Update = checkForUpdate(cmd, build.Version)
+			}
+
+			if output == versionOutputJSON {
+				return writeVersionJSON(cmd.OutOrStdout(), matrix)
+			}
+
+			return writeVersionText(cmd.OutOrStdout(), matrix)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", versionOutputText, "Output format (text or json)")
+	cmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Check whether a newer KSail release is available")
+
+	return cmd
+}
+
+// checkForUpdate looks up the latest release. Failures are reported as a warning so the
+// version matrix is still printed offline.
+func checkForUpdate(cmd *cobra.Command, current string) *versioninfo.UpdateCheck {
+	ctx, cancel := context.WithTimeout(cmd.Context(), updateCheckTimeout)
+	defer cancel()
+
+	err := offline.Guard(ctx, "check %s for updates", versioninfo.LatestReleaseURL)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "%v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+
+		return nil
+	}
+
+	update, err := versioninfo.CheckForUpdate(ctx, nil, versioninfo.LatestReleaseURL, current)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to check for updates: %v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+
+		return nil
+	}
+
+	return update
+}
+
+func writeVersionJSON(writer io.Writer, matrix versioninfo.Matrix) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(matrix)
+	if err != nil {
+		return fmt.Errorf("encode version matrix: %w", err)
+	}
+
+	return nil
+}
+
+func writeVersionText(writer io.Writer, matrix versioninfo.Matrix) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(table, "ksail\t%s\n", matrix.KSail.Version)
+	_, _ = fmt.Fprintf(table, "commit\t%s\n", matrix.KSail.Commit)
+	_, _ = fmt.Fprintf(table, "built\t%s\n", matrix.KSail.Date)
+	_, _ = fmt.Fprintf(table, "go\t%s\n", matrix.Go)
+
+	writeComponentSection(table, "Libraries", matrix.Libraries)
+	writeComponentSection(table, "Node images", matrix.NodeImages)
+	writeComponentSection(table, "Charts", matrix.Charts)
+
+	if matrix.Update != nil {
+		_, _ = fmt.Fprintln(table)
+
+		if matrix.Update.UpdateAvailable {
+			_, _ = fmt.Fprintf(table, "update available\t%s\t%s\n",
+				matrix.Update.Latest, matrix.Update.ReleaseURL)
+		} else {
+			_, _ = fmt.Fprintf(table, "latest release\t%s\n", matrix.Update.Latest)
+		}
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("write version matrix: %w", err)
+	}
+
+	return nil
+}
+
+func writeComponentSection(writer io.Writer, title string, components []versioninfo.Component) {
+	_, _ = fmt.Fprintf(writer, "\n%s:\n", title)
+
+	for _, component := range components {
+		if component.Source == "" {
+			_, _ = fmt.Fprintf(writer, "  %s\t%s\n", component.Name, component.Version)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\t%s\n", component.Name, component.Version, component.Source)
+	}
+}
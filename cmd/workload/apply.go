@@ -2,10 +2,13 @@ package workload
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/applycache"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 )
@@ -13,6 +16,11 @@ import (
 // NewApplyCmd creates the workload apply command.
 // The runtime parameter is kept for consistency with other workload command constructors,
 // though it's currently unused as this command wraps kubectl directly.
+//
+// Apply keeps a content hash of every applied resource in .ksail/apply-cache.json, next to the
+// project's ksail.yaml. With --skip-unchanged it skips resources whose manifest and live object
+// are unchanged since they were last applied to the same cluster. Manifests, including those
+// piped through "-f -", are decoded and applied one document at a time.
 func NewApplyCmd(_ *runtime.Runtime) *cobra.Command {
 	// Try to load config silently to get kubeconfig path
 	kubeconfigPath := cmdhelpers.GetKubeconfigPathSilently()
@@ -25,8 +33,16 @@ func NewApplyCmd(_ *runtime.Runtime) *cobra.Command {
 	}
 
 	// Create kubectl client and get the apply command directly
-	client := kubectl.NewClient(ioStreams)
+	client := kubectl.NewClient(
+		ioStreams,
+		kubectl.WithApplyCache(
+			applycache.NewStore(filepath.Join(ksailconfigmanager.ProjectDir(""), applycache.DefaultPath)),
+			applycache.Hash,
+		),
+	)
 	applyCmd := client.CreateApplyCommand(kubeconfigPath)
 
+	cmdhelpers.MarkAudited(applyCmd, "workload.apply")
+
 	return applyCmd
 }
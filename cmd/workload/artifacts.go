@@ -0,0 +1,204 @@
+package workload
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/spf13/cobra"
+)
+
+const (
+	shortDigestLength = 12
+	sizeUnit          = 1024
+)
+
+// NewArtifactsCmd creates the workload artifacts command group.
+func NewArtifactsCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "artifacts",
+		Short: "Inspect OCI artifacts in the local registry",
+		Long:  "Group commands that show the OCI artifacts pushed to the local registry.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewArtifactsListCmd(runtimeContainer))
+
+	return cmd
+}
+
+// NewArtifactsListCmd creates the workload artifacts list command.
+func NewArtifactsListCmd(_ *runtime.Runtime) *cobra.Command {
+	var registry string
+
+	cmd := &cobra.Command{
+		Use:   "list [repository]",
+		Short: "List the artifact tags in the local registry",
+		Long: `List every tag of every repository in the local registry, or of the given repository,
+with the digest, size, creation time and annotations of the artifact it points to, and whether
+a cosign signature is stored alongside it. Signature tags themselves are not listed.
+
+The registry defaults to the local registry of ksail.yaml.
+
+Example:
+  ksail workload artifacts list
+  ksail workload artifacts list k8s --registry localhost:5050`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&registry, "registry", "",
+		"Registry host:port to list (default: the local registry of ksail.yaml)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		endpoint := registry
+		if endpoint == "" {
+			var err error
+
+			endpoint, err = localRegistryEndpoint(cmd)
+			if err != nil {
+				return err
+			}
+		}
+
+		repositories := args
+		if len(repositories) == 0 {
+			var err error
+
+			repositories, err = oci.ListRepositories(cmd.Context(), endpoint)
+			if err != nil {
+				return fmt.Errorf("list artifacts: %w", err)
+			}
+		}
+
+		var artifacts []oci.ArtifactInfo
+
+		for _, repository := range repositories {
+			tags, err := oci.ListArtifacts(cmd.Context(), endpoint, repository)
+			if err != nil {
+				return fmt.Errorf("list artifacts: %w", err)
+			}
+
+			artifacts = append(artifacts, tags...)
+		}
+
+		return writeArtifacts(cmd, artifacts)
+	}
+
+	return cmd
+}
+
+// localRegistryEndpoint returns the host endpoint of the local registry of ksail.yaml.
+func localRegistryEndpoint(cmd *cobra.Command) (string, error) {
+	fieldSelectors := ksailconfigmanager.DefaultClusterFieldSelectors()
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, fieldSelectors)
+
+	clusterCfg, err := cfgManager.LoadConfigSilent()
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+
+	if clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
+		return "", errLocalRegistryRequired
+	}
+
+	return hostRegistryEndpoint(clusterCfg), nil
+}
+
+// hostRegistryEndpoint returns the endpoint the local registry of clusterCfg is reachable at
+// from the host.
+func hostRegistryEndpoint(clusterCfg *v1alpha1.Cluster) string {
+	registryPort := clusterCfg.Spec.Options.LocalRegistry.HostPort
+	if registryPort == 0 {
+		registryPort = v1alpha1.DefaultLocalRegistryPort
+	}
+
+	return fmt.Sprintf("localhost:%d", registryPort)
+}
+
+func writeArtifacts(cmd *cobra.Command, artifacts []oci.ArtifactInfo) error {
+	table := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "REPOSITORY\tTAG\tDIGEST\tSIZE\tCREATED\tSIGNED\tANNOTATIONS")
+
+	for _, artifact := range artifacts {
+		_, _ = fmt.Fprintf(
+			table,
+			"%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+			artifact.Repository,
+			artifact.Tag,
+			shortDigest(artifact.Digest),
+			formatSize(artifact.Size),
+			formatCreated(artifact.Created),
+			artifact.Signed,
+			formatAnnotations(artifact.Annotations),
+		)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
+
+// shortDigest trims digest to the length docker shows image IDs with.
+func shortDigest(digest string) string {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found || len(hex) <= shortDigestLength {
+		return digest
+	}
+
+	return algorithm + ":" + hex[:shortDigestLength]
+}
+
+func formatSize(size int64) string {
+	if size < sizeUnit {
+		return fmt.Sprintf("%dB", size)
+	}
+
+	value := float64(size)
+	units := []string{"KiB", "MiB", "GiB"}
+
+	unit := ""
+	for _, unit = range units {
+		value /= sizeUnit
+		if value < sizeUnit {
+			break
+		}
+	}
+
+	return fmt.Sprintf("%.1f%s", value, unit)
+}
+
+func formatCreated(created time.Time) string {
+	if created.IsZero() {
+		return "-"
+	}
+
+	return created.Format(time.RFC3339)
+}
+
+func formatAnnotations(annotations map[string]string) string {
+	if len(annotations) == 0 {
+		return "-"
+	}
+
+	pairs := make([]string, 0, len(annotations))
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		pairs = append(pairs, key+"="+annotations[key])
+	}
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,59 @@
+package workload_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/cmd/workload"
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactsListShowsTagsOfEveryRepository(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	sourceDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(sourceDir, "app.yaml"),
+		[]byte("kind: ConfigMap\nmetadata:\n  name: app\n"),
+		0o600,
+	))
+
+	for _, repository := range []string{"web", "api"} {
+		_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+			SourcePath:       sourceDir,
+			RegistryEndpoint: endpoint,
+			Repository:       repository,
+			Version:          "1.2.3",
+		})
+		require.NoError(t, err)
+	}
+
+	var out bytes.Buffer
+
+	cmd := workload.NewArtifactsCmd(runtime.NewRuntime())
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"list", "--registry", endpoint})
+
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "REPOSITORY  TAG    DIGEST")
+	assert.True(t, strings.HasPrefix(lines[1], "api "), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "web "), lines[2])
+	assert.Contains(t, lines[2], "1.2.3  sha256:")
+	assert.Contains(t, lines[2], "false")
+	assert.Contains(t, lines[2], "org.opencontainers.image.version=1.2.3")
+}
@@ -38,5 +38,7 @@ func NewCreateCmd(_ *runtime.Runtime) *cobra.Command {
 		createCmd.AddCommand(subCmd)
 	}
 
+	cmdhelpers.MarkAudited(createCmd, "workload.create")
+
 	return createCmd
 }
@@ -28,5 +28,7 @@ func NewDeleteCmd(_ *runtime.Runtime) *cobra.Command {
 	client := kubectl.NewClient(ioStreams)
 	deleteCmd := client.CreateDeleteCommand(kubeconfigPath)
 
+	cmdhelpers.MarkAudited(deleteCmd, "workload.delete")
+
 	return deleteCmd
 }
@@ -28,5 +28,7 @@ func NewEditCmd(_ *runtime.Runtime) *cobra.Command {
 	client := kubectl.NewClient(ioStreams)
 	editCmd := client.CreateEditCommand(kubeconfigPath)
 
+	cmdhelpers.MarkAudited(editCmd, "workload.edit")
+
 	return editCmd
 }
@@ -0,0 +1,176 @@
+package gen
+
+import (
+	"fmt"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	argocdgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/argocd"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/spf13/cobra"
+)
+
+const argoCDExamples = `  # Generate an Application that syncs a path of a Git repository automatically
+  ksail workload gen argocd application podinfo \
+    --repo=https://github.com/stefanprodan/podinfo \
+    --path=kustomize \
+    --dest-namespace=podinfo \
+    --sync-policy=automated \
+    --auto-prune \
+    --self-heal \
+    --sync-option=CreateNamespace=true > k8s/podinfo.yaml
+
+  # Generate an AppProject that limits Applications to the apps-* namespaces
+  ksail workload gen argocd appproject apps \
+    --src=https://github.com/example/* \
+    --dest=https://kubernetes.default.svc,apps-*`
+
+// NewArgoCDCmd creates the workload gen argocd command group.
+func NewArgoCDCmd(_ *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "argocd",
+		Short: "Generate ArgoCD Application and AppProject resources",
+		Long: "Generate ArgoCD Application and AppProject resources, which kubectl create cannot produce. " +
+			"The generated YAML is printed to stdout.",
+		Example: argoCDExamples,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newArgoCDApplicationCmd())
+	cmd.AddCommand(newArgoCDAppProjectCmd())
+
+	return cmd
+}
+
+func newArgoCDApplicationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "application NAME",
+		Aliases: []string{"app"},
+		Short:   "Generate an ArgoCD Application",
+		Long: "Generate an ArgoCD Application that syncs a path of a Git repository, or a chart of a " +
+			"Helm repository, to a destination cluster and namespace.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			namespace, _ := flags.GetString("namespace")
+			project, _ := flags.GetString("project")
+			repoURL, _ := flags.GetString("repo")
+			path, _ := flags.GetString("path")
+			chart, _ := flags.GetString("helm-chart")
+			revision, _ := flags.GetString("revision")
+			destServer, _ := flags.GetString("dest-server")
+			destName, _ := flags.GetString("dest-name")
+			destNamespace, _ := flags.GetString("dest-namespace")
+			syncPolicy, _ := flags.GetString("sync-policy")
+			autoPrune, _ := flags.GetBool("auto-prune")
+			selfHeal, _ := flags.GetBool("self-heal")
+			syncOptions, _ := flags.GetStringSlice("sync-option")
+
+			application, err := argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+				Name:           args[0],
+				Namespace:      namespace,
+				Project:        project,
+				RepoURL:        repoURL,
+				Path:           path,
+				Chart:          chart,
+				TargetRevision: revision,
+				DestServer:     destServer,
+				DestName:       destName,
+				DestNamespace:  destNamespace,
+				SyncPolicy:     syncPolicy,
+				AutoPrune:      autoPrune,
+				SelfHeal:       selfHeal,
+				SyncOptions:    syncOptions,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate application: %w", err)
+			}
+
+			return writeArgoCDResource(cmd, application)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringP("namespace", "n", argocdgenerator.DefaultNamespace, "namespace ArgoCD watches for Applications")
+	flags.String("project", argocdgenerator.DefaultProject, "project of the Application")
+	flags.String("repo", "", "Git repository, Helm repository or OCI registry to sync from")
+	flags.String("path", "", "directory of the manifests in a Git repository")
+	flags.String("helm-chart", "", "chart of a Helm repository to sync, instead of --path")
+	flags.String("revision", argocdgenerator.DefaultTargetRevision, "branch, tag, commit or chart version to sync")
+	flags.String("dest-server", "", "API server of the destination cluster (defaults to the in-cluster server)")
+	flags.String("dest-name", "", "name of the destination cluster, instead of --dest-server")
+	flags.String("dest-namespace", "", "namespace of manifests that do not set one")
+	flags.String("sync-policy", argocdgenerator.SyncPolicyNone, "sync policy (automated, none)")
+	flags.Bool("auto-prune", false, "delete resources removed from the source during automated syncs")
+	flags.Bool("self-heal", false, "revert changes made in the cluster during automated syncs")
+	flags.StringSlice("sync-option", nil, "sync option, e.g. CreateNamespace=true (repeatable)")
+
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
+
+func newArgoCDAppProjectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "appproject NAME",
+		Aliases: []string{"proj"},
+		Short:   "Generate an ArgoCD AppProject",
+		Long: "Generate an ArgoCD AppProject that restricts the source repositories, destinations and " +
+			"cluster-scoped resources of its Applications.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			namespace, _ := flags.GetString("namespace")
+			description, _ := flags.GetString("description")
+			sourceRepos, _ := flags.GetStringSlice("src")
+			destinations, _ := flags.GetStringArray("dest")
+			clusterResources, _ := flags.GetStringSlice("allow-cluster-resource")
+
+			project, err := argocdgenerator.NewAppProject(argocdgenerator.AppProjectOptions{
+				Name:             args[0],
+				Namespace:        namespace,
+				Description:      description,
+				SourceRepos:      sourceRepos,
+				Destinations:     destinations,
+				ClusterResources: clusterResources,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate appproject: %w", err)
+			}
+
+			return writeArgoCDResource(cmd, project)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringP("namespace", "n", argocdgenerator.DefaultNamespace, "namespace ArgoCD watches for AppProjects")
+	flags.String("description", "", "description of the project")
+	flags.StringSlice("src", nil, "permitted source repository, globs allowed (defaults to any)")
+	flags.StringArray("dest", nil,
+		"permitted destination as server,namespace, globs allowed (defaults to any namespace in-cluster)")
+	flags.StringSlice("allow-cluster-resource", nil,
+		"permitted cluster-scoped resource as group/kind, e.g. /Namespace (repeatable)")
+
+	return cmd
+}
+
+func writeArgoCDResource[T argocdgenerator.Application | argocdgenerator.AppProject](
+	cmd *cobra.Command,
+	resource T,
+) error {
+	out, err := argocdgenerator.Generate(resource, yamlgenerator.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to generate argocd resource: %w", err)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+	if err != nil {
+		return fmt.Errorf("failed to write YAML: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package gen
+
+import (
+	"fmt"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	fluxgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/flux"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/spf13/cobra"
+)
+
+const fluxExamples = `  # Generate a GitRepository that tracks a tag
+  ksail workload gen flux gitrepository podinfo \
+    --url=https://github.com/stefanprodan/podinfo \
+    --tag=6.9.0 > k8s/podinfo-source.yaml
+
+  # Generate an OCIRepository for an artifact in the local registry
+  ksail workload gen flux ocirepository podinfo \
+    --url=oci://local-registry:5000/podinfo \
+    --insecure
+
+  # Generate a Kustomization that applies a path of the source
+  ksail workload gen flux kustomization podinfo \
+    --source=GitRepository/podinfo \
+    --path=./kustomize \
+    --prune`
+
+// NewFluxCmd creates the workload gen flux command group.
+func NewFluxCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flux",
+		Short: "Generate Flux source and sync resources",
+		Long: "Generate Flux GitRepository, OCIRepository, Kustomization and HelmRelease resources, " +
+			"which kubectl create cannot produce. The generated YAML is printed to stdout.",
+		Example: fluxExamples,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newFluxGitRepositoryCmd())
+	cmd.AddCommand(NewHelmReleaseCmd(runtimeContainer))
+	cmd.AddCommand(newFluxKustomizationCmd())
+	cmd.AddCommand(newFluxOCIRepositoryCmd())
+
+	return cmd
+}
+
+func newFluxGitRepositoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "gitrepository NAME",
+		Aliases:      []string{"git"},
+		Short:        "Generate a Flux GitRepository",
+		Long:         "Generate a Flux GitRepository that fetches a Git repository at a branch, tag, semver range or commit.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			namespace, _ := flags.GetString("namespace")
+			url, _ := flags.GetString("url")
+			branch, _ := flags.GetString("branch")
+			tag, _ := flags.GetString("tag")
+			semver, _ := flags.GetString("semver")
+			commit, _ := flags.GetString("commit")
+			secretRef, _ := flags.GetString("secret-ref")
+			interval, _ := flags.GetDuration("interval")
+
+			repository, err := fluxgenerator.NewGitRepository(fluxgenerator.GitRepositoryOptions{
+				Name:      args[0],
+				Namespace: namespace,
+				URL:       url,
+				Branch:    branch,
+				Tag:       tag,
+				SemVer:    semver,
+				Commit:    commit,
+				SecretRef: secretRef,
+				Interval:  interval,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate gitrepository: %w", err)
+			}
+
+			return writeFluxResource(cmd, *repository)
+		},
+	}
+
+	flags := cmd.Flags()
+	addFluxCommonFlags(cmd)
+	flags.String("url", "", "http(s):// or ssh:// address of the Git repository")
+	flags.String("branch", "", "branch to track (defaults to main without another reference)")
+	flags.String("tag", "", "tag to check out")
+	flags.String("semver", "", "semver range of the tags to check out")
+	flags.String("commit", "", "commit SHA to check out")
+	flags.String("secret-ref", "", "Secret with the credentials of the repository")
+
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func newFluxOCIRepositoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "ocirepository NAME",
+		Aliases:      []string{"oci"},
+		Short:        "Generate a Flux OCIRepository",
+		Long:         "Generate a Flux OCIRepository that pulls an OCI artifact at a tag, semver range or digest.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			namespace, _ := flags.GetString("namespace")
+			url, _ := flags.GetString("url")
+			tag, _ := flags.GetString("tag")
+			semver, _ := flags.GetString("semver")
+			digest, _ := flags.GetString("digest")
+			provider, _ := flags.GetString("provider")
+			secretRef, _ := flags.GetString("secret-ref")
+			insecure, _ := flags.GetBool("insecure")
+			interval, _ := flags.GetDuration("interval")
+
+			repository, err := fluxgenerator.NewOCIRepository(fluxgenerator.OCIRepositoryOptions{
+				Name:      args[0],
+				Namespace: namespace,
+				URL:       url,
+				Tag:       tag,
+				SemVer:    semver,
+				Digest:    digest,
+				Provider:  provider,
+				SecretRef: secretRef,
+				Insecure:  insecure,
+				Interval:  interval,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate ocirepository: %w", err)
+			}
+
+			return writeFluxResource(cmd, *repository)
+		},
+	}
+
+	flags := cmd.Flags()
+	addFluxCommonFlags(cmd)
+	flags.String("url", "", "oci:// address of the artifact repository")
+	flags.String("tag", "", "tag to pull (defaults to latest without another reference)")
+	flags.String("semver", "", "semver range of the tags to pull")
+	flags.String("digest", "", "digest to pull")
+	flags.String("provider", "", "OIDC provider to authenticate with (generic, aws, azure, gcp)")
+	flags.String("secret-ref", "", "docker-registry Secret with the credentials of the registry")
+	flags.Bool("insecure", false, "allow plain HTTP registries, such as the local registry")
+
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func newFluxKustomizationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "kustomization NAME",
+		Aliases:      []string{"ks"},
+		Short:        "Generate a Flux Kustomization",
+		Long:         "Generate a Flux Kustomization that applies a path of a GitRepository, OCIRepository or Bucket.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			namespace, _ := flags.GetString("namespace")
+			source, _ := flags.GetString("source")
+			path, _ := flags.GetString("path")
+			targetNamespace, _ := flags.GetString("target-namespace")
+			dependsOn, _ := flags.GetStringSlice("depends-on")
+			prune, _ := flags.GetBool("prune")
+			wait, _ := flags.GetBool("wait")
+			interval, _ := flags.GetDuration("interval")
+			timeout, _ := flags.GetDuration("timeout")
+
+			kustomization, err := fluxgenerator.NewKustomization(fluxgenerator.KustomizationOptions{
+				Name:            args[0],
+				Namespace:       namespace,
+				Source:          source,
+				Path:            path,
+				TargetNamespace: targetNamespace,
+				DependsOn:       dependsOn,
+				Prune:           prune,
+				Wait:            wait,
+				Interval:        interval,
+				Timeout:         timeout,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate kustomization: %w", err)
+			}
+
+			return writeFluxResource(cmd, *kustomization)
+		},
+	}
+
+	flags := cmd.Flags()
+	addFluxCommonFlags(cmd)
+	flags.String("source", "", "source to apply (GitRepository/name, OCIRepository/name.namespace, Bucket/name)")
+	flags.String("path", fluxgenerator.DefaultPath, "path to the manifests in the source")
+	flags.String("target-namespace", "", "namespace of the applied resources")
+	flags.StringSlice("depends-on", nil, "Kustomizations (name or namespace/name) to apply first")
+	flags.Bool("prune", false, "delete resources removed from the source")
+	flags.Bool("wait", false, "wait for the applied resources to become ready")
+	flags.Duration("timeout", 0, "timeout for applying and health checks")
+
+	_ = cmd.MarkFlagRequired("source")
+
+	return cmd
+}
+
+func addFluxCommonFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("namespace", "n", fluxgenerator.DefaultNamespace, "namespace of the resource")
+	cmd.Flags().Duration("interval", fluxgenerator.DefaultInterval, "reconciliation interval")
+}
+
+func writeFluxResource[T any](cmd *cobra.Command, resource T) error {
+	out, err := fluxgenerator.Generate(resource, yamlgenerator.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to generate flux resource: %w", err)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+	if err != nil {
+		return fmt.Errorf("failed to write YAML: %w", err)
+	}
+
+	return nil
+}
@@ -31,15 +31,18 @@ func NewGenCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 		SilenceUsage: true,
 	}
 
+	cmd.AddCommand(NewArgoCDCmd(runtimeContainer))
 	cmd.AddCommand(NewClusterRoleCmd(runtimeContainer))
 	cmd.AddCommand(NewClusterRoleBindingCmd(runtimeContainer))
 	cmd.AddCommand(NewConfigMapCmd(runtimeContainer))
 	cmd.AddCommand(NewCronJobCmd(runtimeContainer))
 	cmd.AddCommand(NewDeploymentCmd(runtimeContainer))
+	cmd.AddCommand(NewFluxCmd(runtimeContainer))
 	cmd.AddCommand(NewHelmReleaseCmd(runtimeContainer))
 	cmd.AddCommand(NewIngressCmd(runtimeContainer))
 	cmd.AddCommand(NewJobCmd(runtimeContainer))
 	cmd.AddCommand(NewNamespaceCmd(runtimeContainer))
+	cmd.AddCommand(NewPGClusterCmd(runtimeContainer))
 	cmd.AddCommand(NewPodDisruptionBudgetCmd(runtimeContainer))
 	cmd.AddCommand(NewPriorityClassCmd(runtimeContainer))
 	cmd.AddCommand(NewQuotaCmd(runtimeContainer))
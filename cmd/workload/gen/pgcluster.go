@@ -0,0 +1,76 @@
+package gen
+
+import (
+	"fmt"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	cnpggenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/cnpg"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/spf13/cobra"
+)
+
+const pgClusterExamples = `  # Generate a single-instance PostgreSQL cluster with an "app" database
+  ksail workload gen pgcluster orders-db
+
+  # Generate a three-instance cluster with a dedicated database and more storage
+  ksail workload gen pgcluster orders-db \
+    --namespace=orders \
+    --instances=3 \
+    --storage-size=10Gi \
+    --database=orders > k8s/orders-db.yaml`
+
+// NewPGClusterCmd creates the workload gen pgcluster command.
+func NewPGClusterCmd(_ *runtime.Runtime) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pgcluster NAME",
+		Aliases: []string{"postgres"},
+		Short:   "Generate a CloudNativePG PostgreSQL cluster",
+		Long: "Generate a CloudNativePG Cluster resource that declares a PostgreSQL instance. " +
+			"The CloudNativePG operator must be installed in the cluster, " +
+			"for example by enabling spec.options.cloudNativePG in ksail.yaml.",
+		Example:      pgClusterExamples,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runPGClusterGen,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.Flags()
+	flags.StringP("namespace", "n", "default", "namespace of the PostgreSQL cluster")
+	flags.Int("instances", cnpggenerator.DefaultInstances, "number of PostgreSQL instances")
+	flags.String("storage-size", cnpggenerator.DefaultStorageSize, "volume size of each instance")
+	flags.String("image-name", "", "PostgreSQL image (defaults to the operator's image)")
+	flags.String("database", cnpggenerator.DefaultDatabase, "application database to create")
+	flags.String("owner", "", "owner of the application database (defaults to the database name)")
+
+	return cmd
+}
+
+func runPGClusterGen(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	namespace, _ := flags.GetString("namespace")
+	instances, _ := flags.GetInt("instances")
+	storageSize, _ := flags.GetString("storage-size")
+	imageName, _ := flags.GetString("image-name")
+	database, _ := flags.GetString("database")
+	owner, _ := flags.GetString("owner")
+
+	out, err := cnpggenerator.NewCNPGGenerator().Generate(cnpggenerator.Options{
+		Name:        args[0],
+		Namespace:   namespace,
+		Instances:   instances,
+		StorageSize: storageSize,
+		ImageName:   imageName,
+		Database:    database,
+		Owner:       owner,
+	}, yamlgenerator.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to generate pgcluster: %w", err)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+	if err != nil {
+		return fmt.Errorf("failed to write YAML: %w", err)
+	}
+
+	return nil
+}
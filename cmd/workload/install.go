@@ -20,14 +20,21 @@ func NewInstallCmd(_ *runtime.Runtime) *cobra.Command {
 		Use:   "install [NAME] [CHART]",
 		Short: "Install Helm charts",
 		Long: "Install Helm charts to provision workloads through KSail. " +
-			"This command provides native Helm chart installation capabilities.",
+			"This command provides native Helm chart installation capabilities. " +
+			"Downloaded charts are verified against the digests pinned in ksail.sum, and the " +
+			"chart version is pinned in ksail.lock.",
 		Args: cobra.MinimumNArgs(minInstallArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			releaseName := args[0]
 			chartName := args[1]
 
 			// Create helm client
-			client, err := helm.NewClient(kubeconfigPath, "")
+			client, err := helm.NewClient(
+				kubeconfigPath,
+				"",
+				cmdhelpers.HelmChecksumOption(cmd),
+				cmdhelpers.HelmLockOption(cmd),
+			)
 			if err != nil {
 				return fmt.Errorf("create helm client: %w", err)
 			}
@@ -46,6 +53,8 @@ func NewInstallCmd(_ *runtime.Runtime) *cobra.Command {
 				Timeout:     helm.DefaultTimeout,
 			}
 
+			spec.Keyring, _ = cmd.Flags().GetString("keyring")
+
 			// Get other flags
 			if createNamespace, _ := cmd.Flags().GetBool("create-namespace"); createNamespace {
 				spec.CreateNamespace = true
@@ -75,6 +84,11 @@ func NewInstallCmd(_ *runtime.Runtime) *cobra.Command {
 	flags.Bool("create-namespace", false, "create the release namespace if not present")
 	flags.Bool("wait", false, "wait until resources are ready")
 	flags.Bool("atomic", false, "if set, the installation deletes on failure")
+	flags.String("keyring", "", "verify the chart's provenance against this public keyring")
+	cmdhelpers.AddRefreshChecksumsFlag(cmd)
+	cmdhelpers.AddFrozenLockfileFlag(cmd)
+
+	cmdhelpers.MarkAudited(cmd, "workload.install")
 
 	return cmd
 }
@@ -1,35 +1,89 @@
 package workload
 
 import (
+	"crypto"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail-go/pkg/client/oci"
 	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	argocdinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argocd"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 )
 
-const defaultArtifactTag = "latest"
+const (
+	defaultArtifactTag  = "latest"
+	defaultWatchTimeout = 5 * time.Minute
+)
 
-var errLocalRegistryRequired = errors.New("local registry must be enabled to reconcile workloads")
+var (
+	errLocalRegistryRequired = errors.New("local registry must be enabled to reconcile workloads")
+	errWatchRequiresFlux     = errors.New("--watch requires Flux as the GitOps engine")
+	errSigningRequiresOCI    = errors.New("--signing-key requires pushing an OCI artifact, not Gitea")
+	errKustomizeRequiresOCI  = errors.New("--kustomize requires pushing an OCI artifact, not Gitea")
+	errCheckRequiresOCI      = errors.New("--check requires pushing an OCI artifact, not Gitea")
+)
 
 // NewReconcileCmd creates the workload reconcile command.
-//
-//nolint:funlen // Cobra command RunE functions typically combine setup, validation, and execution
 func NewReconcileCmd(_ *runtime.Runtime) *cobra.Command {
+	var (
+		watch        bool
+		watchTimeout time.Duration
+		signingKey   string
+		kustomize    bool
+		check        bool
+	)
+
 	cmd := &cobra.Command{
-		Use:          "reconcile",
-		Short:        "Reconcile workloads with the cluster",
-		Long:         "Trigger reconciliation tooling to sync local workloads with your cluster.",
+		Use:   "reconcile",
+		Short: "Reconcile workloads with the cluster",
+		Long: `Push local workloads to Gitea when it is enabled, or otherwise as an OCI artifact to the
+local registry. With Flux as the GitOps engine, the OCIRepository and Kustomization that sync the
+artifact are created or updated and reconciled immediately, without a Git server. With ArgoCD
+enabled, the Application that syncs the workloads is created or updated and refreshed, and the
+command waits until it is synced and healthy.
+
+With --watch, the status of the Flux source and Kustomization is streamed until the pushed
+revision is applied, or Flux reports a failure.
+
+With --kustomize, the source directory is rendered with a kustomize build and the flattened
+output is pushed instead of the raw files, so overlays are resolved before Flux applies them.
+
+With --signing-key, the artifact is signed with a cosign private key and the signature is
+pushed alongside it. The signature is verified before reconciling, and Flux is configured to
+verify it with the matching public key before applying the artifact. Encrypted keys from
+cosign generate-key-pair are decrypted with the password in COSIGN_PASSWORD. Keyless signing
+is not supported.
+
+With --check, the artifact is built but not pushed or reconciled, and the command fails when
+its digest differs from the artifact already pushed, so CI can skip unchanged pushes. Builds
+are reproducible: only SOURCE_DATE_EPOCH adds a creation time to the digest.`,
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false,
+		"Stream the Flux sync status until the pushed revision is applied")
+	cmd.Flags().DurationVar(&watchTimeout, "watch-timeout", defaultWatchTimeout,
+		"How long --watch waits for the pushed revision to be applied")
+	cmd.Flags().BoolVar(&kustomize, "kustomize", false,
+		"Push the kustomize build of the source directory instead of its raw files")
+	cmd.Flags().StringVar(&signingKey, "signing-key", "",
+		"Cosign private key to sign the OCI artifact with, e.g. cosign.key")
+	cmd.Flags().BoolVar(&check, "check", false,
+		"Fail if the built OCI artifact differs from the pushed one, without pushing")
+
 	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
 		tmr := timer.New()
 		tmr.Start()
@@ -37,15 +91,18 @@ func NewReconcileCmd(_ *runtime.Runtime) *cobra.Command {
 		fieldSelectors := ksailconfigmanager.DefaultClusterFieldSelectors()
 		cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, fieldSelectors)
 
-		outputTimer := cmdhelpers.MaybeTimer(cmd, tmr)
-
-		clusterCfg, err := cfgManager.LoadConfig(outputTimer)
+		clusterCfg, err := cfgManager.LoadConfig(cmdhelpers.MaybeTimer(cmd, tmr))
 		if err != nil {
 			return fmt.Errorf("load config: %w", err)
 		}
 
-		if clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
-			return errLocalRegistryRequired
+		fluxEnabled := clusterCfg.Spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux
+		if watch && !fluxEnabled {
+			return errWatchRequiresFlux
+		}
+
+		if kustomize && clusterCfg.Spec.Options.Gitea.Enabled {
+			return errKustomizeRequiresOCI
 		}
 
 		sourceDir := clusterCfg.Spec.SourceDirectory
@@ -53,60 +110,416 @@ func NewReconcileCmd(_ *runtime.Runtime) *cobra.Command {
 			sourceDir = v1alpha1.DefaultSourceDirectory
 		}
 
-		repoName := sourceDir
-		artifactVersion := defaultArtifactTag
+		if check {
+			return checkArtifact(cmd, clusterCfg, sourceDir, kustomize, tmr)
+		}
+
+		signer, err := loadSigningKey(signingKey, clusterCfg)
+		if err != nil {
+			return err
+		}
+
+		var reconcileOpts []fluxinstaller.ReconcileOption
 
-		registryPort := clusterCfg.Spec.Options.LocalRegistry.HostPort
-		if registryPort == 0 {
-			registryPort = v1alpha1.DefaultLocalRegistryPort
+		if clusterCfg.Spec.Options.Gitea.Enabled {
+			err = pushToGitea(cmd, clusterCfg, sourceDir, tmr)
+		} else {
+			reconcileOpts, err = pushArtifact(cmd, clusterCfg, sourceDir, kustomize, signer, tmr)
 		}
 
-		builder := oci.NewWorkloadArtifactBuilder()
+		argoCDEnabled := clusterCfg.Spec.Options.ArgoCD.Enabled
+		if err != nil || (!fluxEnabled && !argoCDEnabled) {
+			return err
+		}
+
+		kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+		if err != nil {
+			return fmt.Errorf("get kubeconfig path: %w", err)
+		}
+
+		if argoCDEnabled {
+			return reconcileArgoCD(cmd, clusterCfg, kubeconfig, tmr)
+		}
+
+		err = reconcileFlux(cmd, clusterCfg, kubeconfig, tmr, reconcileOpts...)
+		if err != nil || !watch {
+			return err
+		}
+
+		return watchFlux(cmd, kubeconfig, watchTimeout, tmr)
+	}
+
+	return cmd
+}
+
+// loadSigningKey loads the cosign private key at path, or returns nil when no path is set.
+func loadSigningKey(path string, clusterCfg *v1alpha1.Cluster) (crypto.Signer, error) {
+	if path == "" {
+		return nil, nil //nolint:nilnil // no signing key means the artifact is not signed
+	}
 
-		cmd.Println()
+	if clusterCfg.Spec.Options.Gitea.Enabled {
+		return nil, errSigningRequiresOCI
+	}
+
+	signer, err := oci.LoadSigningKey(path, []byte(os.Getenv(oci.CosignPasswordEnv)))
+	if err != nil {
+		return nil, fmt.Errorf("load signing key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// pushArtifact builds sourceDir into an OCI artifact and pushes it to the local registry. With
+// a signer, the artifact is signed and its signature verified, and the returned options make
+// Flux verify it too.
+func pushArtifact(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	sourceDir string,
+	kustomize bool,
+	signer crypto.Signer,
+	tmr timer.Timer,
+) ([]fluxinstaller.ReconcileOption, error) {
+	if clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
+		return nil, errLocalRegistryRequired
+	}
+
+	outputTimer := cmdhelpers.MaybeTimer(cmd, tmr)
+
+	builder := oci.NewWorkloadArtifactBuilder()
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "📦",
+		Content: "Build and Push OCI Artifact...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "building oci artifact",
+		Timer:   outputTimer,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "pushing oci artifact",
+		Timer:   outputTimer,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	buildOpts := workloadArtifactOptions(clusterCfg, sourceDir, kustomize)
+	buildOpts.SigningKey = signer
+
+	_, err := builder.Build(cmd.Context(), buildOpts)
+	if err != nil {
+		return nil, fmt.Errorf("build and push oci artifact: %w", err)
+	}
+
+	if signer == nil {
 		notify.WriteMessage(notify.Message{
-			Type:    notify.TitleType,
-			Emoji:   "📦",
-			Content: "Build and Push OCI Artifact...",
+			Type:    notify.SuccessType,
+			Content: "oci artifact pushed",
+			Timer:   outputTimer,
 			Writer:  cmd.OutOrStdout(),
 		})
 
-		tmr.NewStage()
+		return nil, nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "verifying oci artifact signature",
+		Timer:   outputTimer,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	reference := fmt.Sprintf("%s/%s:%s", buildOpts.RegistryEndpoint, buildOpts.Repository, buildOpts.Version)
+
+	err = oci.VerifyArtifact(cmd.Context(), reference, signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("verify oci artifact: %w", err)
+	}
+
+	publicKey, err := oci.MarshalVerificationKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("verify oci artifact: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "oci artifact pushed and signed",
+		Timer:   outputTimer,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return []fluxinstaller.ReconcileOption{fluxinstaller.WithCosignVerification(publicKey)}, nil
+}
 
+// checkArtifact builds sourceDir like pushArtifact, and fails unless the artifact pushed to the
+// local registry has the same digest. Nothing is pushed.
+func checkArtifact(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	sourceDir string,
+	kustomize bool,
+	tmr timer.Timer,
+) error {
+	if clusterCfg.Spec.Options.Gitea.Enabled {
+		return errCheckRequiresOCI
+	}
+
+	if clusterCfg.Spec.LocalRegistry != v1alpha1.LocalRegistryEnabled {
+		return errLocalRegistryRequired
+	}
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "🔎",
+		Content: "Check OCI Artifact...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "building oci artifact",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	buildOpts := workloadArtifactOptions(clusterCfg, sourceDir, kustomize)
+	buildOpts.Check = true
+
+	result, err := oci.NewWorkloadArtifactBuilder().Build(cmd.Context(), buildOpts)
+	if err != nil {
+		return fmt.Errorf("check oci artifact: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "oci artifact matches pushed %s",
+		Args:    []any{result.Digest},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// workloadArtifactOptions returns the options to build sourceDir into the artifact Flux syncs
+// from the local registry.
+func workloadArtifactOptions(
+	clusterCfg *v1alpha1.Cluster,
+	sourceDir string,
+	kustomize bool,
+) oci.BuildOptions {
+	repoName := fluxinstaller.WorkloadRepositoryName(sourceDir)
+
+	return oci.BuildOptions{
+		Name:             repoName,
+		SourcePath:       sourceDir,
+		RegistryEndpoint: hostRegistryEndpoint(clusterCfg),
+		Repository:       repoName,
+		Version:          defaultArtifactTag,
+		Kustomize:        kustomize,
+	}
+}
+
+// reconcileFlux requests an immediate sync of the pushed workloads. Pushed OCI artifacts get
+// their OCIRepository and Kustomization created or updated first.
+func reconcileFlux(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	kubeconfig string,
+	tmr timer.Timer,
+	opts ...fluxinstaller.ReconcileOption,
+) error {
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "🔄",
+		Content: "Reconcile Flux...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
+
+	var err error
+
+	if clusterCfg.Spec.Options.Gitea.Enabled {
 		notify.WriteMessage(notify.Message{
 			Type:    notify.ActivityType,
-			Content: "building oci artifact",
-			Timer:   outputTimer,
+			Content: "requesting reconciliation of GitRepository and Kustomization",
 			Writer:  cmd.OutOrStdout(),
 		})
 
+		err = fluxinstaller.RequestWorkloadReconcile(cmd.Context(), kubeconfig)
+	} else {
 		notify.WriteMessage(notify.Message{
 			Type:    notify.ActivityType,
-			Content: "pushing oci artifact",
-			Timer:   outputTimer,
+			Content: "applying OCIRepository and Kustomization",
 			Writer:  cmd.OutOrStdout(),
 		})
 
-		_, err = builder.Build(cmd.Context(), oci.BuildOptions{
-			Name:             repoName,
-			SourcePath:       sourceDir,
-			RegistryEndpoint: fmt.Sprintf("localhost:%d", registryPort),
-			Repository:       repoName,
-			Version:          artifactVersion,
-		})
-		if err != nil {
-			return fmt.Errorf("build and push oci artifact: %w", err)
-		}
+		err = fluxinstaller.ReconcileWorkloadArtifact(cmd.Context(), kubeconfig, clusterCfg, opts...)
+	}
+
+	if err != nil {
+		return fmt.Errorf("reconcile flux: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "flux reconciliation requested",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// reconcileArgoCD creates or updates the Application that syncs the pushed workloads, and
+// waits until ArgoCD has synced the pushed revision.
+func reconcileArgoCD(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	kubeconfig string,
+	tmr timer.Timer,
+) error {
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "🐙",
+		Content: "Reconcile ArgoCD...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "applying and refreshing Application",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, kubeconfig, clusterCfg.Spec.Connection.Context)
+	if err != nil {
+		return fmt.Errorf("reconcile argocd: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("reconcile argocd: create dynamic client: %w", err)
+	}
+
+	err = argocdinstaller.ReconcileApplication(
+		cmd.Context(),
+		dynamicClient,
+		argocdinstaller.ProjectApplication(clusterCfg),
+		installer.GetComponentTimeout(clusterCfg, "argocd"),
+	)
+	if err != nil {
+		return fmt.Errorf("reconcile argocd: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "argocd application synced",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// watchFlux streams the status of the Flux source and Kustomization until the pushed revision
+// is applied.
+func watchFlux(cmd *cobra.Command, kubeconfig string, timeout time.Duration, tmr timer.Timer) error {
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "👀",
+		Content: "Watch Flux Sync...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
 
+	err := fluxinstaller.WatchWorkloadSync(cmd.Context(), kubeconfig, timeout, func(status string) {
 		notify.WriteMessage(notify.Message{
-			Type:    notify.SuccessType,
-			Content: "oci artifact pushed",
-			Timer:   outputTimer,
+			Type:    notify.ActivityType,
+			Content: "%s",
+			Args:    []any{status},
 			Writer:  cmd.OutOrStdout(),
 		})
+	})
+	if err != nil {
+		return fmt.Errorf("watch flux: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "workloads synced",
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
 
-		return nil
+// pushToGitea publishes sourceDir to the in-cluster Gitea server, from which the GitOps
+// engine syncs when Gitea is enabled.
+func pushToGitea(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	sourceDir string,
+	tmr timer.Timer,
+) error {
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("get kubeconfig path: %w", err)
 	}
 
-	return cmd
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   "🍵",
+		Content: "Push to Gitea...",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	tmr.NewStage()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "pushing '%s' to gitea",
+		Args:    []any{sourceDir},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	repository, err := gitea.Sync(cmd.Context(), gitea.SyncOptions{
+		Kubeconfig: kubeconfig,
+		Context:    clusterCfg.Spec.Connection.Context,
+		SourceDir:  sourceDir,
+	})
+	if err != nil {
+		return fmt.Errorf("push to gitea: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "project pushed to %s",
+		Args:    []any{gitea.InClusterRepositoryURL(repository)},
+		Timer:   cmdhelpers.MaybeTimer(cmd, tmr),
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
 }
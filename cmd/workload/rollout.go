@@ -28,5 +28,7 @@ func NewRolloutCmd(_ *runtime.Runtime) *cobra.Command {
 	client := kubectl.NewClient(ioStreams)
 	rolloutCmd := client.CreateRolloutCommand(kubeconfigPath)
 
+	cmdhelpers.MarkAudited(rolloutCmd, "workload.rollout")
+
 	return rolloutCmd
 }
@@ -28,5 +28,7 @@ func NewScaleCmd(_ *runtime.Runtime) *cobra.Command {
 	client := kubectl.NewClient(ioStreams)
 	scaleCmd := client.CreateScaleCommand(kubeconfigPath)
 
+	cmdhelpers.MarkAudited(scaleCmd, "workload.scale")
+
 	return scaleCmd
 }
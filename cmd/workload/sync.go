@@ -0,0 +1,245 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	"github.com/devantler-tech/ksail-go/pkg/svc/filesync"
+	"github.com/devantler-tech/ksail-go/pkg/svc/metrics"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	syncCommandName     = "workload sync"
+	defaultSyncDebounce = 300 * time.Millisecond
+)
+
+var errSyncPathRequired = errors.New("at least one --path is required")
+
+// SyncOptions holds the flag values of the workload sync command.
+type SyncOptions struct {
+	Paths     []string
+	Selector  string
+	Namespace string
+	Container string
+	Signal    string
+	Debounce  time.Duration
+	Once      bool
+	// MetricsAddr enables a Prometheus endpoint on this localhost address when non-empty.
+	MetricsAddr string
+}
+
+// SyncDeps groups the collaborators used by the workload sync command.
+type SyncDeps struct {
+	Clientset kubernetes.Interface
+	Executor  filesync.Executor
+	// Watch blocks and reports batches of changed files; defaults to filesync.Watch.
+	Watch func(ctx context.Context, mappings []filesync.Mapping, debounce time.Duration,
+		onChange func([]string) error) error
+}
+
+// NewSyncCmd creates the workload sync command.
+func NewSyncCmd(_ *runtime.Runtime) *cobra.Command {
+	var options SyncOptions
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync local files into running pods",
+		Long: `Watch local directories and copy changed files into the containers of the pods
+selected by --selector, without rebuilding images.
+
+Files are streamed as a tar archive over exec, so the container needs tar. Deleted
+files are removed from the container. With --signal, the signal is sent to the
+container's main process after each sync so runtimes with hot reload pick up changes.
+With --metrics-addr, counters for syncs, sync durations and the time spent finding
+running pods are served in the Prometheus format on localhost while the command runs.
+
+Example:
+  ksail workload sync -l app=web --path ./src:/app/src --signal HUP`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			cmd.SetContext(ctx)
+
+			deps, err := newSyncDeps(cmd)
+			if err != nil {
+				return err
+			}
+
+			return HandleSyncRunE(cmd, options, deps)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&options.Paths, "path", nil, "Directory to sync as local:remote (repeatable)")
+	flags.StringVarP(&options.Selector, "selector", "l", "", "Label selector of the pods to sync into")
+	flags.StringVarP(&options.Namespace, "namespace", "n", "default", "Namespace of the pods")
+	flags.StringVarP(&options.Container, "container", "c", "", "Container to sync into (defaults to the pod's default)")
+	flags.StringVar(&options.Signal, "signal", "", "Signal sent to the container's main process after a sync (e.g. HUP)")
+	flags.DurationVar(&options.Debounce, "debounce", defaultSyncDebounce, "Quiet period before changes are synced")
+	flags.BoolVar(&options.Once, "once", false, "Sync all files once and exit instead of watching")
+	flags.StringVar(&options.MetricsAddr, "metrics-addr", "",
+		"Serve Prometheus metrics of this sync session on this localhost address (e.g. :9090)")
+
+	_ = cmd.MarkFlagRequired("selector")
+
+	return cmd
+}
+
+// HandleSyncRunE performs an initial full sync and then syncs changes until the context ends.
+// Exported for testing purposes.
+func HandleSyncRunE(cmd *cobra.Command, options SyncOptions, deps SyncDeps) error {
+	if len(options.Paths) == 0 {
+		return errSyncPathRequired
+	}
+
+	mappings, err := filesync.ParseMappings(options.Paths)
+	if err != nil {
+		return fmt.Errorf("parse --path: %w", err)
+	}
+
+	if deps.Watch == nil {
+		deps.Watch = filesync.Watch
+	}
+
+	syncer := filesync.NewSyncer(deps.Executor, mappings, options.Signal)
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Sync files...",
+		Emoji:   "🔄",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	recorder, err := startSyncMetrics(cmd, options.MetricsAddr)
+	if err != nil {
+		return err
+	}
+
+	err = runSync(cmd, options, deps, recorder, func(ctx context.Context, targets []filesync.Target) (filesync.Result, error) {
+		return syncer.SyncAll(ctx, targets)
+	})
+	if err != nil || options.Once {
+		return err
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "watching for changes (press Ctrl+C to stop)",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	err = deps.Watch(cmd.Context(), mappings, options.Debounce, func(paths []string) error {
+		return runSync(cmd, options, deps, recorder, func(ctx context.Context, targets []filesync.Target) (filesync.Result, error) {
+			return syncer.Sync(ctx, targets, paths)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("watch files: %w", err)
+	}
+
+	return nil
+}
+
+// runSync resolves the target pods again, since they may have been replaced, and runs syncFiles.
+func runSync(
+	cmd *cobra.Command,
+	options SyncOptions,
+	deps SyncDeps,
+	recorder *metrics.Recorder,
+	syncFiles func(context.Context, []filesync.Target) (filesync.Result, error),
+) error {
+	start := time.Now()
+
+	targets, err := filesync.ResolveTargets(
+		cmd.Context(),
+		deps.Clientset,
+		options.Namespace,
+		options.Selector,
+		options.Container,
+	)
+	recorder.ObserveReadinessWait(syncCommandName, time.Since(start))
+
+	if err != nil {
+		recorder.ObserveApply(syncCommandName, err)
+
+		return fmt.Errorf("resolve pods: %w", err)
+	}
+
+	result, err := syncFiles(cmd.Context(), targets)
+	recorder.ObserveApply(syncCommandName, err)
+
+	if err != nil {
+		return fmt.Errorf("sync files: %w", err)
+	}
+
+	recorder.ObserveReconcile(syncCommandName, time.Since(start))
+
+	if result.Copied+result.Removed == 0 {
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "synced %d file(s), removed %d, in %d pod(s)",
+		Args:    []any{result.Copied, result.Removed, len(targets)},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// startSyncMetrics serves sync metrics on addr until the command's context ends.
+// It returns a nil recorder, which records nothing, when addr is empty.
+func startSyncMetrics(cmd *cobra.Command, addr string) (*metrics.Recorder, error) {
+	if addr == "" {
+		return nil, nil //nolint:nilnil // a nil recorder disables metrics
+	}
+
+	recorder := metrics.NewRecorder()
+
+	listenAddr, err := metrics.Serve(cmd.Context(), addr, recorder)
+	if err != nil {
+		return nil, fmt.Errorf("serve metrics: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "serving metrics on http://%s%s",
+		Args:    []any{listenAddr, metrics.MetricsPath},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return recorder, nil
+}
+
+func newSyncDeps(cmd *cobra.Command) (SyncDeps, error) {
+	restConfig, err := cmdhelpers.BuildRESTConfig(cmd, cmdhelpers.GetKubeconfigPathSilently(), "")
+	if err != nil {
+		return SyncDeps{}, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return SyncDeps{}, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	executor, err := filesync.NewPodExecutor(restConfig)
+	if err != nil {
+		return SyncDeps{}, fmt.Errorf("create pod executor: %w", err)
+	}
+
+	return SyncDeps{Clientset: clientset, Executor: executor, Watch: filesync.Watch}, nil
+}
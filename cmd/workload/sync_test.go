@@ -0,0 +1,170 @@
+package workload_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/cmd/workload"
+	"github.com/devantler-tech/ksail-go/pkg/svc/filesync"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type countingExecutor struct {
+	commands [][]string
+}
+
+func (c *countingExecutor) Exec(_ context.Context, _ filesync.Target, command []string, stdin io.Reader) error {
+	if stdin != nil {
+		_, _ = io.Copy(io.Discard, stdin)
+	}
+
+	c.commands = append(c.commands, command)
+
+	return nil
+}
+
+func newSyncTestCommand() (*cobra.Command, *bytes.Buffer) {
+	var out bytes.Buffer
+
+	cmd := &cobra.Command{Use: "sync"}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+
+	return cmd, &out
+}
+
+func runningPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestSyncCommandSyncsInitiallyAndOnChange(t *testing.T) {
+	t.Parallel()
+
+	local := t.TempDir()
+	changed := filepath.Join(local, "main.py")
+	require.NoError(t, os.WriteFile(changed, []byte("print(1)"), 0o600))
+
+	executor := &countingExecutor{}
+	cmd, out := newSyncTestCommand()
+
+	err := workload.HandleSyncRunE(cmd, workload.SyncOptions{
+		Paths:     []string{local + ":/app"},
+		Selector:  "app=web",
+		Namespace: "default",
+		Signal:    "HUP",
+		Debounce:  time.Millisecond,
+	}, workload.SyncDeps{
+		Clientset: fake.NewClientset(runningPod()),
+		Executor:  executor,
+		Watch: func(_ context.Context, mappings []filesync.Mapping, _ time.Duration,
+			onChange func([]string) error,
+		) error {
+			assert.Equal(t, []filesync.Mapping{{Local: local, Remote: "/app"}}, mappings)
+
+			return onChange([]string{changed})
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"tar", "-xmf", "-", "-C", "/app"},
+		{"kill", "-HUP", "1"},
+		{"tar", "-xmf", "-", "-C", "/app"},
+		{"kill", "-HUP", "1"},
+	}, executor.commands)
+	assert.Contains(t, out.String(), "synced 1 file(s), removed 0, in 1 pod(s)")
+	assert.Contains(t, out.String(), "watching for changes")
+}
+
+func TestSyncCommandOnceSkipsWatch(t *testing.T) {
+	t.Parallel()
+
+	cmd, out := newSyncTestCommand()
+
+	err := workload.HandleSyncRunE(cmd, workload.SyncOptions{
+		Paths:     []string{t.TempDir() + ":/app"},
+		Selector:  "app=web",
+		Namespace: "default",
+		Once:      true,
+	}, workload.SyncDeps{
+		Clientset: fake.NewClientset(runningPod()),
+		Executor:  &countingExecutor{},
+		Watch: func(context.Context, []filesync.Mapping, time.Duration, func([]string) error) error {
+			t.Fatal("watch must not run with --once")
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, out.String(), "watching for changes")
+}
+
+func TestSyncCommandRequiresPathsAndPods(t *testing.T) {
+	t.Parallel()
+
+	cmd, _ := newSyncTestCommand()
+
+	err := workload.HandleSyncRunE(cmd, workload.SyncOptions{Selector: "app=web"}, workload.SyncDeps{})
+	require.ErrorContains(t, err, "at least one --path is required")
+
+	err = workload.HandleSyncRunE(cmd, workload.SyncOptions{
+		Paths:     []string{t.TempDir() + ":/app"},
+		Selector:  "app=api",
+		Namespace: "default",
+	}, workload.SyncDeps{Clientset: fake.NewClientset(runningPod()), Executor: &countingExecutor{}})
+	require.ErrorIs(t, err, filesync.ErrNoTargets)
+}
+
+func TestSyncCommandServesMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	local := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(local, "main.py"), []byte("print(1)"), 0o600))
+
+	cmd, out := newSyncTestCommand()
+	cmd.SetContext(ctx)
+
+	err := workload.HandleSyncRunE(cmd, workload.SyncOptions{
+		Paths:       []string{local + ":/app"},
+		Selector:    "app=web",
+		Namespace:   "default",
+		Once:        true,
+		MetricsAddr: "127.0.0.1:0",
+	}, workload.SyncDeps{Clientset: fake.NewClientset(runningPod()), Executor: &countingExecutor{}})
+	require.NoError(t, err)
+
+	url := regexp.MustCompile(`http://\S+/metrics`).FindString(out.String())
+	require.NotEmpty(t, url, out.String())
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `ksail_applies_total{command="workload sync",result="success"} 1`)
+	assert.Contains(t, string(body), `ksail_reconcile_duration_seconds_count{command="workload sync"} 1`)
+	assert.Contains(t, string(body), `ksail_readiness_wait_seconds_count{command="workload sync"} 1`)
+}
@@ -11,8 +11,8 @@ func NewWorkloadCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "workload",
 		Short: "Manage workload operations",
-		Long: "Group workload commands under a single namespace to reconcile, apply, create, delete, describe, edit, exec, " +
-			"explain, expose, get, gen, install, logs, rollout, scale, or wait for workloads.",
+		Long: "Group workload commands under a single namespace to reconcile, apply, list artifacts, create, delete, " +
+			"describe, edit, exec, explain, expose, get, gen, install, logs, rollout, scale, sync, or wait for workloads.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
@@ -21,6 +21,7 @@ func NewWorkloadCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 
 	cmd.AddCommand(NewReconcileCmd(runtimeContainer))
 	cmd.AddCommand(NewApplyCmd(runtimeContainer))
+	cmd.AddCommand(NewArtifactsCmd(runtimeContainer))
 	cmd.AddCommand(NewCreateCmd(runtimeContainer))
 	cmd.AddCommand(NewDeleteCmd(runtimeContainer))
 	cmd.AddCommand(NewDescribeCmd(runtimeContainer))
@@ -34,6 +35,7 @@ func NewWorkloadCmd(runtimeContainer *runtime.Runtime) *cobra.Command {
 	cmd.AddCommand(NewLogsCmd(runtimeContainer))
 	cmd.AddCommand(NewRolloutCmd(runtimeContainer))
 	cmd.AddCommand(NewScaleCmd(runtimeContainer))
+	cmd.AddCommand(NewSyncCmd(runtimeContainer))
 	cmd.AddCommand(NewWaitCmd(runtimeContainer))
 
 	return cmd
@@ -29,6 +29,8 @@ func TestWorkloadHelpSnapshots(t *testing.T) {
 		{name: "namespace", args: []string{"workload", "--help"}},
 		{name: "reconcile", args: []string{"workload", "reconcile", "--help"}},
 		{name: "apply", args: []string{"workload", "apply", "--help"}},
+		{name: "artifacts", args: []string{"workload", "artifacts", "--help"}},
+		{name: "artifacts_list", args: []string{"workload", "artifacts", "list", "--help"}},
 		{name: "create", args: []string{"workload", "create", "--help"}},
 		{name: "create_source", args: []string{"workload", "create", "source", "--help"}},
 		{
@@ -48,6 +50,7 @@ func TestWorkloadHelpSnapshots(t *testing.T) {
 		{name: "logs", args: []string{"workload", "logs", "--help"}},
 		{name: "rollout", args: []string{"workload", "rollout", "--help"}},
 		{name: "scale", args: []string{"workload", "scale", "--help"}},
+		{name: "sync", args: []string{"workload", "sync", "--help"}},
 		{name: "wait", args: []string{"workload", "wait", "--help"}},
 	}
 
@@ -0,0 +1,11 @@
+// Package e2e contains opt-in end-to-end tests that drive the KSail CLI against real Docker.
+//
+// The tests are gated by the e2e build tag so they never run as part of `go test ./...`:
+//
+//	go test -tags e2e -timeout 30m ./integration/e2e/...
+//
+// Each test runs in its own temporary project directory with a uniquely named cluster, so
+// existing clusters on the host are never touched. Tests always delete the clusters they
+// create, and on failure collect node container logs, project files and the command
+// transcript into KSAIL_E2E_ARTIFACTS_DIR (defaulting to a directory under os.TempDir).
+package e2e
@@ -0,0 +1,398 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	binaryEnvVar       = "KSAIL_E2E_BINARY"
+	artifactsEnvVar    = "KSAIL_E2E_ARTIFACTS_DIR"
+	kindClusterLabel   = "io.x-k8s.kind.cluster"
+	dockerPingTimeout  = 5 * time.Second
+	cleanupTimeout     = 5 * time.Minute
+	artifactDirPerm    = 0o750
+	artifactFilePerm   = 0o600
+	defaultCommandWait = 20 * time.Minute
+)
+
+//nolint:gochecknoglobals // the binary is built once in TestMain and shared by all tests
+var ksailBinary string
+
+func TestMain(m *testing.M) {
+	os.Exit(runMain(m))
+}
+
+func runMain(m *testing.M) int {
+	binary, cleanup, err := resolveBinary()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+
+		return 1
+	}
+
+	defer cleanup()
+
+	ksailBinary = binary
+
+	return m.Run()
+}
+
+// resolveBinary returns the ksail binary under test, building it from source unless
+// KSAIL_E2E_BINARY points at a prebuilt binary.
+func resolveBinary() (string, func(), error) {
+	if binary := os.Getenv(binaryEnvVar); binary != "" {
+		absBinary, err := filepath.Abs(binary)
+		if err != nil {
+			return "", nil, fmt.Errorf("resolve %s: %w", binaryEnvVar, err)
+		}
+
+		return absBinary, func() {}, nil
+	}
+
+	buildDir, err := os.MkdirTemp("", "ksail-e2e-bin-")
+	if err != nil {
+		return "", nil, fmt.Errorf("create build dir: %w", err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(buildDir) }
+	binary := filepath.Join(buildDir, "ksail")
+
+	build := exec.Command("go", "build", "-o", binary, "../..") //nolint:gosec // fixed arguments
+	build.Stdout = os.Stderr
+	build.Stderr = os.Stderr
+
+	err = build.Run()
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("build ksail binary: %w", err)
+	}
+
+	return binary, cleanup, nil
+}
+
+// Harness runs the ksail binary inside an isolated project directory against real Docker.
+type Harness struct {
+	t           *testing.T
+	dir         string
+	clusterName string
+	docker      client.APIClient
+
+	mu         sync.Mutex
+	transcript bytes.Buffer
+	created    bool
+}
+
+// NewHarness creates a project directory for a Kind cluster with a name unique to the test, so
+// the tests never touch clusters that already exist on the host, such as Kind's default "kind".
+//
+// The test is skipped when Docker is not reachable. On cleanup the harness collects
+// artifacts if the test failed and then deletes the cluster if the harness created it.
+func NewHarness(t *testing.T) *Harness {
+	t.Helper()
+
+	dockerClient, err := dockerclient.GetDockerClient()
+	if err != nil {
+		t.Skipf("docker client unavailable: %v", err)
+	}
+
+	pingCtx, cancel := context.WithTimeout(context.Background(), dockerPingTimeout)
+	defer cancel()
+
+	_, err = dockerClient.Ping(pingCtx)
+	if err != nil {
+		t.Skipf("docker daemon unreachable: %v", err)
+	}
+
+	harness := &Harness{
+		t:           t,
+		dir:         t.TempDir(),
+		clusterName: testutils.UniqueClusterName(t),
+		docker:      dockerClient,
+	}
+
+	// Cleanups run in reverse order: delete the cluster only after artifacts are collected.
+	t.Cleanup(harness.deleteCluster)
+	t.Cleanup(func() {
+		if t.Failed() {
+			harness.collectArtifacts()
+		}
+	})
+
+	return harness
+}
+
+// Dir returns the project directory commands run in.
+func (h *Harness) Dir() string {
+	return h.dir
+}
+
+// ClusterName returns the name of the cluster the harness creates.
+func (h *Harness) ClusterName() string {
+	return h.clusterName
+}
+
+// Init runs cluster init with args and sets spec.clusterName of the generated ksail.yaml to
+// the cluster name of the harness.
+func (h *Harness) Init(args ...string) {
+	h.t.Helper()
+
+	h.MustRun(append([]string{"cluster", "init"}, args...)...)
+
+	configPath := filepath.Join(h.dir, "ksail.yaml")
+
+	content, err := os.ReadFile(configPath) //nolint:gosec // path inside the test project
+	if err != nil {
+		h.t.Fatalf("read ksail.yaml: %v", err)
+	}
+
+	marshaller := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+
+	var cluster v1alpha1.Cluster
+
+	err = marshaller.Unmarshal(content, &cluster)
+	if err != nil {
+		h.t.Fatalf("parse ksail.yaml: %v", err)
+	}
+
+	cluster.Spec.ClusterName = h.clusterName
+
+	output, err := marshaller.Marshal(cluster)
+	if err != nil {
+		h.t.Fatalf("marshal ksail.yaml: %v", err)
+	}
+
+	err = os.WriteFile(configPath, []byte(output), artifactFilePerm)
+	if err != nil {
+		h.t.Fatalf("write ksail.yaml: %v", err)
+	}
+}
+
+// Run executes ksail with args in the project directory and returns its combined output.
+func (h *Harness) Run(args ...string) (string, error) {
+	h.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCommandWait)
+	defer cancel()
+
+	return h.run(ctx, args...)
+}
+
+// MustRun executes ksail with args and fails the test when the command fails.
+func (h *Harness) MustRun(args ...string) string {
+	h.t.Helper()
+
+	output, err := h.Run(args...)
+	if err != nil {
+		h.t.Fatalf("ksail %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+
+	return output
+}
+
+func (h *Harness) run(ctx context.Context, args ...string) (string, error) {
+	if len(args) >= 2 && args[0] == "cluster" && args[1] == "create" {
+		// Set before running, as a failed create can leave nodes behind.
+		h.mu.Lock()
+		h.created = true
+		h.mu.Unlock()
+	}
+
+	cmd := exec.CommandContext(ctx, ksailBinary, args...) //nolint:gosec // args are test controlled
+	cmd.Dir = h.dir
+
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+
+	h.mu.Lock()
+	_, _ = fmt.Fprintf(&h.transcript, "$ ksail %s\n%s\n", strings.Join(args, " "), output.String())
+	h.mu.Unlock()
+
+	if err != nil {
+		return output.String(), fmt.Errorf("run ksail: %w", err)
+	}
+
+	return output.String(), nil
+}
+
+func (h *Harness) deleteCluster() {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	h.mu.Lock()
+	created := h.created
+	h.mu.Unlock()
+
+	if !created {
+		return
+	}
+
+	nodes, err := h.listNodes(ctx)
+	if err == nil && len(nodes) == 0 {
+		return
+	}
+
+	output, err := h.run(ctx, "cluster", "delete")
+	if err != nil {
+		h.t.Logf("cleanup: cluster delete failed: %v\n%s", err, output)
+	}
+}
+
+// NodeCount returns the number of Docker containers belonging to the harness cluster.
+func (h *Harness) NodeCount() int {
+	h.t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerPingTimeout)
+	defer cancel()
+
+	nodes, err := h.listNodes(ctx)
+	if err != nil {
+		h.t.Fatalf("list cluster nodes: %v", err)
+	}
+
+	return len(nodes)
+}
+
+func (h *Harness) listNodes(ctx context.Context) ([]container.Summary, error) {
+	nodes, err := h.docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", kindClusterLabel+"="+h.clusterName)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// --- artifacts ---
+
+func (h *Harness) collectArtifacts() {
+	dir := artifactsDir(h.t)
+
+	err := os.MkdirAll(dir, artifactDirPerm)
+	if err != nil {
+		h.t.Logf("artifacts: create %s: %v", dir, err)
+
+		return
+	}
+
+	h.mu.Lock()
+	h.writeArtifact(dir, "transcript.txt", h.transcript.Bytes())
+	h.mu.Unlock()
+
+	h.copyProjectFiles(dir)
+	h.collectNodeLogs(dir)
+
+	h.t.Logf("artifacts collected in %s", dir)
+}
+
+func (h *Harness) copyProjectFiles(dir string) {
+	for _, name := range []string{"ksail.yaml", "kind.yaml", filepath.Join("k8s", "kustomization.yaml")} {
+		content, err := os.ReadFile(filepath.Join(h.dir, name)) //nolint:gosec // paths are fixed
+		if err != nil {
+			continue
+		}
+
+		h.writeArtifact(dir, filepath.Join("project", name), content)
+	}
+}
+
+func (h *Harness) collectNodeLogs(dir string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	nodes, err := h.listNodes(ctx)
+	if err != nil {
+		h.t.Logf("artifacts: list cluster nodes: %v", err)
+
+		return
+	}
+
+	for _, node := range nodes {
+		name := strings.TrimPrefix(firstOrEmpty(node.Names), "/")
+		if name == "" {
+			name = node.ID
+		}
+
+		logs, err := h.docker.ContainerLogs(ctx, node.ID, container.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Timestamps: true,
+		})
+		if err != nil {
+			h.t.Logf("artifacts: logs for %s: %v", name, err)
+
+			continue
+		}
+
+		var buffer bytes.Buffer
+
+		_, err = stdcopy.StdCopy(&buffer, &buffer, logs)
+		_ = logs.Close()
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			h.t.Logf("artifacts: read logs for %s: %v", name, err)
+		}
+
+		h.writeArtifact(dir, filepath.Join("nodes", name+".log"), buffer.Bytes())
+	}
+}
+
+func (h *Harness) writeArtifact(dir, name string, content []byte) {
+	path := filepath.Join(dir, name)
+
+	err := os.MkdirAll(filepath.Dir(path), artifactDirPerm)
+	if err == nil {
+		err = os.WriteFile(path, content, artifactFilePerm)
+	}
+
+	if err != nil {
+		h.t.Logf("artifacts: write %s: %v", path, err)
+	}
+}
+
+func artifactsDir(t *testing.T) string {
+	t.Helper()
+
+	base := os.Getenv(artifactsEnvVar)
+	if base == "" {
+		base = filepath.Join(os.TempDir(), "ksail-e2e-artifacts")
+	}
+
+	return filepath.Join(base, strings.ReplaceAll(t.Name(), "/", "_"))
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,38 @@
+//go:build e2e
+
+package e2e_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKindClusterLifecycle(t *testing.T) {
+	workloadDir, err := filepath.Abs(filepath.Join("testdata", "workload"))
+	if err != nil {
+		t.Fatalf("resolve workload dir: %v", err)
+	}
+
+	harness := NewHarness(t)
+
+	harness.Init("--distribution", "Kind")
+	harness.MustRun("cluster", "create")
+
+	if harness.NodeCount() == 0 {
+		t.Fatal("expected kind node containers after cluster create")
+	}
+
+	harness.MustRun("workload", "apply", "-k", workloadDir)
+
+	output := harness.MustRun("workload", "get", "configmap", "ksail-e2e", "-o", "name")
+	if !strings.Contains(output, "configmap/ksail-e2e") {
+		t.Fatalf("expected applied configmap to exist, got:\n%s", output)
+	}
+
+	harness.MustRun("cluster", "delete")
+
+	if nodes := harness.NodeCount(); nodes != 0 {
+		t.Fatalf("expected no kind node containers after cluster delete, found %d", nodes)
+	}
+}
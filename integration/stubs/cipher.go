@@ -0,0 +1,217 @@
+package stubs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"google.golang.org/grpc"
+)
+
+// StubRecipient is the age recipient recorded in the metadata of files encrypted by the stub backend.
+// It is never used for real encryption.
+const StubRecipient = "age1ksailstubrecipient"
+
+const (
+	stubDataKeyPrefix = "stub-data-key:"
+	stubValueFormat   = "ENC[STUB,data:%s,type:%s]"
+)
+
+var (
+	// ErrInvalidStubCiphertext is returned when a value or data key was not produced by a stub.
+	ErrInvalidStubCiphertext = errors.New("invalid stub ciphertext")
+	// ErrUnsupportedStubType is returned when a value of an unsupported type is encrypted.
+	ErrUnsupportedStubType = errors.New("unsupported value type for stub cipher")
+)
+
+//nolint:gochecknoglobals // compiled once and shared by all stub ciphers
+var stubValuePattern = regexp.MustCompile(`^ENC\[STUB,data:([A-Za-z0-9+/=]*),type:(\w+)\]$`)
+
+// NewCipherBackend returns a cipher backend that encrypts reversibly without real keys.
+//
+// New files are encrypted for a single age master key with StubRecipient, whose data key
+// is wrapped by a KeyService. Values are encoded by Cipher.
+func NewCipherBackend() ciphersvc.Backend {
+	return ciphersvc.Backend{
+		Cipher:      Cipher{},
+		KeyServices: []keyservice.KeyServiceClient{&KeyService{}},
+		KeyGroups: []sops.KeyGroup{
+			{&age.MasterKey{Recipient: StubRecipient}},
+		},
+	}
+}
+
+// CipherModule returns a DI module that registers the stub cipher backend.
+// Combine it with the other modules a command needs when building a test runtime.
+func CipherModule() runtime.Module {
+	return runtime.ProvideDependency(func(runtime.Injector) (ciphersvc.Backend, error) {
+		return NewCipherBackend(), nil
+	})
+}
+
+// KeyService is a keyservice.KeyServiceClient that wraps data keys with a reversible encoding
+// instead of contacting a key management system. It accepts any master key.
+type KeyService struct {
+	mu           sync.Mutex
+	encryptCalls int
+	decryptCalls int
+}
+
+// Encrypt implements keyservice.KeyServiceClient.
+func (s *KeyService) Encrypt(
+	_ context.Context,
+	req *keyservice.EncryptRequest,
+	_ ...grpc.CallOption,
+) (*keyservice.EncryptResponse, error) {
+	s.mu.Lock()
+	s.encryptCalls++
+	s.mu.Unlock()
+
+	encoded := stubDataKeyPrefix + base64.StdEncoding.EncodeToString(req.GetPlaintext())
+
+	return &keyservice.EncryptResponse{Ciphertext: []byte(encoded)}, nil
+}
+
+// Decrypt implements keyservice.KeyServiceClient.
+func (s *KeyService) Decrypt(
+	_ context.Context,
+	req *keyservice.DecryptRequest,
+	_ ...grpc.CallOption,
+) (*keyservice.DecryptResponse, error) {
+	s.mu.Lock()
+	s.decryptCalls++
+	s.mu.Unlock()
+
+	encoded, found := bytes.CutPrefix(req.GetCiphertext(), []byte(stubDataKeyPrefix))
+	if !found {
+		return nil, fmt.Errorf("%w: data key is not stub-encrypted", ErrInvalidStubCiphertext)
+	}
+
+	plaintext, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStubCiphertext, err)
+	}
+
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// Calls returns how many data keys the service has encrypted and decrypted.
+func (s *KeyService) Calls() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.encryptCalls, s.decryptCalls
+}
+
+// Cipher is a sops.Cipher that base64-encodes values instead of encrypting them.
+// The encoding is deterministic and ignores the data key, so encrypted files are stable
+// across runs and readable in test failures.
+type Cipher struct{}
+
+// Encrypt implements sops.Cipher.
+func (Cipher) Encrypt(plaintext any, _ []byte, _ string) (string, error) {
+	var (
+		data     string
+		dataType string
+	)
+
+	switch value := plaintext.(type) {
+	case string:
+		if value == "" {
+			return "", nil
+		}
+
+		data, dataType = value, "str"
+	case []byte:
+		if len(value) == 0 {
+			return "", nil
+		}
+
+		data, dataType = string(value), "bytes"
+	case int:
+		data, dataType = strconv.Itoa(value), "int"
+	case float64:
+		data, dataType = strconv.FormatFloat(value, 'f', -1, 64), "float"
+	case bool:
+		data, dataType = strconv.FormatBool(value), "bool"
+	case time.Time:
+		data, dataType = value.Format(time.RFC3339Nano), "time"
+	case sops.Comment:
+		if value.Value == "" {
+			return "", nil
+		}
+
+		data, dataType = value.Value, "comment"
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedStubType, plaintext)
+	}
+
+	return fmt.Sprintf(stubValueFormat, base64.StdEncoding.EncodeToString([]byte(data)), dataType), nil
+}
+
+// Decrypt implements sops.Cipher.
+func (Cipher) Decrypt(ciphertext string, _ []byte, _ string) (any, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	matches := stubValuePattern.FindStringSubmatch(ciphertext)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStubCiphertext, ciphertext)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStubCiphertext, err)
+	}
+
+	return decodeStubValue(string(decoded), matches[2])
+}
+
+func decodeStubValue(data, dataType string) (any, error) {
+	var (
+		value any
+		err   error
+	)
+
+	switch dataType {
+	case "str":
+		value = data
+	case "bytes":
+		value = []byte(data)
+	case "int":
+		value, err = strconv.Atoi(data)
+	case "float":
+		value, err = strconv.ParseFloat(data, 64)
+	case "bool":
+		value, err = strconv.ParseBool(data)
+	case "time":
+		value, err = time.Parse(time.RFC3339Nano, data)
+	case "comment":
+		value = sops.Comment{Value: data}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedStubType, dataType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStubCiphertext, err)
+	}
+
+	return value, nil
+}
+
+var (
+	_ sops.Cipher                 = Cipher{}
+	_ keyservice.KeyServiceClient = (*KeyService)(nil)
+)
@@ -0,0 +1,78 @@
+package stubs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher_RoundTripsSupportedTypes(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2025, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		value any
+	}{
+		{name: "string", value: "hunter2"},
+		{name: "bytes", value: []byte{0x01, 0x02}},
+		{name: "int", value: 42},
+		{name: "float", value: 3.14},
+		{name: "bool", value: true},
+		{name: "time", value: timestamp},
+		{name: "comment", value: sops.Comment{Value: "secret note"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cipher := stubs.Cipher{}
+
+			ciphertext, err := cipher.Encrypt(testCase.value, nil, "path:")
+			require.NoError(t, err)
+			assert.Contains(t, ciphertext, "ENC[STUB,")
+
+			plaintext, err := cipher.Decrypt(ciphertext, nil, "path:")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.value, plaintext)
+		})
+	}
+}
+
+func TestCipher_RejectsForeignCiphertext(t *testing.T) {
+	t.Parallel()
+
+	_, err := stubs.Cipher{}.Decrypt("ENC[AES256_GCM,data:abc,iv:def,tag:ghi,type:str]", nil, "")
+	require.ErrorIs(t, err, stubs.ErrInvalidStubCiphertext)
+}
+
+func TestKeyService_RoundTripsDataKey(t *testing.T) {
+	t.Parallel()
+
+	service := &stubs.KeyService{}
+	ctx := context.Background()
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypted, err := service.Encrypt(ctx, &keyservice.EncryptRequest{Plaintext: dataKey})
+	require.NoError(t, err)
+	assert.NotEqual(t, dataKey, encrypted.GetCiphertext())
+
+	decrypted, err := service.Decrypt(ctx, &keyservice.DecryptRequest{Ciphertext: encrypted.GetCiphertext()})
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, decrypted.GetPlaintext())
+
+	encryptCalls, decryptCalls := service.Calls()
+	assert.Equal(t, 1, encryptCalls)
+	assert.Equal(t, 1, decryptCalls)
+
+	_, err = service.Decrypt(ctx, &keyservice.DecryptRequest{Ciphertext: []byte("real-kms-blob")})
+	require.ErrorIs(t, err, stubs.ErrInvalidStubCiphertext)
+}
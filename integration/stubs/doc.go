@@ -0,0 +1,8 @@
+// Package stubs provides reversible stand-ins for external services so that command
+// workflows can be integration-tested without provisioning real infrastructure.
+//
+// The cipher stubs replace SOPS key management (age, PGP, KMS, Vault) and value
+// encryption with transparent, deterministic encodings. Files encrypted with the stub
+// backend have the same structure and SOPS metadata as real encrypted files, but can
+// be decrypted without any keys.
+package stubs
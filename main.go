@@ -9,6 +9,7 @@ import (
 	"runtime/debug"
 
 	"github.com/devantler-tech/ksail-go/cmd"
+	errorhandler "github.com/devantler-tech/ksail-go/pkg/ui/error-handler"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 )
 
@@ -60,10 +61,10 @@ func runWithArgs(args []string) int {
 			Type:    notify.ErrorType,
 			Content: "%v",
 			Args:    []any{rootErr},
-			Writer:  rootCmd.ErrOrStderr(),
+			Writer:  notify.ErrorWriter(rootCmd.OutOrStdout(), rootCmd.ErrOrStderr()),
 		})
 
-		return 1
+		return errorhandler.ExitCode(err)
 	}
 
 	return 0
@@ -51,6 +51,10 @@ func NewClusterOptions() Options {
 		LocalRegistry: NewClusterOptionsLocalRegistry(),
 		Helm:          NewClusterOptionsHelm(),
 		Kustomize:     NewClusterOptionsKustomize(),
+		Colima:        NewClusterOptionsColima(),
+		Gitea:         NewClusterOptionsGitea(),
+		LocalStack:    NewClusterOptionsLocalStack(),
+		CloudNativePG: NewClusterOptionsCloudNativePG(),
 	}
 }
 
@@ -94,6 +98,26 @@ func NewClusterOptionsKustomize() OptionsKustomize {
 	return OptionsKustomize{}
 }
 
+// NewClusterOptionsColima creates a new OptionsColima with default values.
+func NewClusterOptionsColima() OptionsColima {
+	return OptionsColima{}
+}
+
+// NewClusterOptionsGitea creates a new OptionsGitea with default values.
+func NewClusterOptionsGitea() OptionsGitea {
+	return OptionsGitea{}
+}
+
+// NewClusterOptionsLocalStack creates a new OptionsLocalStack with default values.
+func NewClusterOptionsLocalStack() OptionsLocalStack {
+	return OptionsLocalStack{}
+}
+
+// NewClusterOptionsCloudNativePG creates a new OptionsCloudNativePG with default values.
+func NewClusterOptionsCloudNativePG() OptionsCloudNativePG {
+	return OptionsCloudNativePG{}
+}
+
 // NewOCIRegistry creates a new OCIRegistry with default lifecycle state.
 func NewOCIRegistry() OCIRegistry {
 	return OCIRegistry{
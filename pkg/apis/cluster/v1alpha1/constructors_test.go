@@ -72,6 +72,10 @@ func TestDistributionIsValid(t *testing.T) {
 	validCases := []v1alpha1.Distribution{
 		v1alpha1.DistributionKind,
 		v1alpha1.DistributionK3d,
+		v1alpha1.DistributionTalos,
+		v1alpha1.DistributionK0s,
+		v1alpha1.DistributionExternal,
+		v1alpha1.DistributionKwok,
 	}
 
 	for _, dist := range validCases {
@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// clusterFields has the fields of Cluster without its marshalling methods, which prune
+// defaults and so cannot round-trip a configuration.
+type clusterFields Cluster
+
+// DeepCopy returns a copy of c that shares no slices, maps or pointers with it, so defaults
+// applied to the copy do not leak into c.
+func (c *Cluster) DeepCopy() *Cluster {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal((*clusterFields)(c))
+	if err != nil {
+		// Cluster only holds strings, numbers, durations, slices and maps of them.
+		panic(fmt.Sprintf("failed to copy cluster configuration: %v", err))
+	}
+
+	var out clusterFields
+
+	err = json.Unmarshal(data, &out)
+	if err != nil {
+		panic(fmt.Sprintf("failed to copy cluster configuration: %v", err))
+	}
+
+	return (*Cluster)(&out)
+}
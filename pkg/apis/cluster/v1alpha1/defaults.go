@@ -11,12 +11,21 @@ const (
 	DefaultDistributionConfig = "kind.yaml"
 	// DefaultK3dDistributionConfig is the default K3d cluster distribution configuration filename.
 	DefaultK3dDistributionConfig = "k3d.yaml"
+	// DefaultTalosDistributionConfig is the default Talos cluster distribution configuration filename.
+	DefaultTalosDistributionConfig = "talos.yaml"
+	// DefaultK0sDistributionConfig is the default k0s cluster distribution configuration filename.
+	DefaultK0sDistributionConfig = "k0s.yaml"
+	// DefaultKwokDistributionConfig is the default kwok cluster distribution configuration filename.
+	DefaultKwokDistributionConfig = "kwok.yaml"
 	// DefaultSourceDirectory is the default directory for Kubernetes manifests.
 	DefaultSourceDirectory = "k8s"
 	// DefaultKubeconfigPath is the default path to the kubeconfig file.
 	DefaultKubeconfigPath = "~/.kube/config"
 	// DefaultLocalRegistryPort is the default port for the local registry.
 	DefaultLocalRegistryPort int32 = 5111
+	// DefaultK3sImage is the K3s image scaffolded K3d clusters run, pinned to a Flux-compatible
+	// Kubernetes version.
+	DefaultK3sImage = "rancher/k3s:v1.29.4-k3s1"
 )
 
 // DefaultFluxInterval is the default reconciliation interval for Flux.
@@ -24,13 +33,22 @@ const (
 //nolint:gochecknoglobals // Default configuration value
 var DefaultFluxInterval = metav1.Duration{Duration: time.Minute}
 
-// ExpectedDistributionConfigName returns the default config filename for a distribution.
+// ExpectedDistributionConfigName returns the default config filename for a distribution, or an
+// empty string for External clusters, which have no distribution configuration.
 func ExpectedDistributionConfigName(distribution Distribution) string {
 	switch distribution {
 	case DistributionKind:
 		return DefaultDistributionConfig
 	case DistributionK3d:
 		return DefaultK3dDistributionConfig
+	case DistributionTalos:
+		return DefaultTalosDistributionConfig
+	case DistributionK0s:
+		return DefaultK0sDistributionConfig
+	case DistributionKwok:
+		return DefaultKwokDistributionConfig
+	case DistributionExternal:
+		return ""
 	default:
 		return DefaultDistributionConfig
 	}
@@ -43,6 +61,12 @@ func ExpectedContextName(distribution Distribution) string {
 		return "kind-kind"
 	case DistributionK3d:
 		return "k3d-k3d-default"
+	case DistributionTalos:
+		return "admin@talos-default"
+	case DistributionK0s:
+		return "k0s-k0s-default"
+	case DistributionKwok:
+		return "kwok-kwok-default"
 	default:
 		return ""
 	}
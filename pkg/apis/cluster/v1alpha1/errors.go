@@ -19,3 +19,6 @@ var ErrInvalidMetricsServer = errors.New("invalid metrics server")
 
 // ErrInvalidLocalRegistry is returned when an invalid local registry mode is specified.
 var ErrInvalidLocalRegistry = errors.New("invalid local registry mode")
+
+// ErrInvalidIPFamily is returned when an invalid IP family is specified.
+var ErrInvalidIPFamily = errors.New("invalid IP family")
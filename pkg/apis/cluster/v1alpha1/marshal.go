@@ -10,19 +10,12 @@ import (
 
 // MarshalYAML trims default values before emitting YAML.
 func (c Cluster) MarshalYAML() (any, error) {
-	pruned := pruneClusterDefaults(c)
-	out := buildClusterOutput(pruned)
-
-	return out, nil
+	return c.JSONProjection(), nil
 }
 
 // MarshalJSON trims default values before emitting JSON (used by YAML library).
 func (c Cluster) MarshalJSON() ([]byte, error) {
-	pruned := pruneClusterDefaults(c)
-
-	out := buildClusterOutput(pruned)
-
-	b, err := json.Marshal(out)
+	b, err := json.Marshal(c.JSONProjection())
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal cluster to JSON: %w", err)
 	}
@@ -30,6 +23,12 @@ func (c Cluster) MarshalJSON() ([]byte, error) {
 	return b, nil
 }
 
+// JSONProjection returns the cluster with default values trimmed, as MarshalJSON encodes it.
+// It lets the typed YAML marshaller encode clusters without a JSON round trip.
+func (c Cluster) JSONProjection() any {
+	return buildClusterOutput(pruneClusterDefaults(c))
+}
+
 // buildClusterOutput converts a Cluster into a YAML/JSON-friendly projection with omitempty tags.
 type clusterOutput struct {
 	APIVersion string             `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
@@ -38,15 +37,25 @@ type clusterOutput struct {
 }
 
 type clusterSpecOutput struct {
+	ClusterName        string                   `json:"clusterName,omitempty"        yaml:"clusterName,omitempty"`
 	Distribution       string                   `json:"distribution,omitempty"       yaml:"distribution,omitempty"`
 	DistributionConfig string                   `json:"distributionConfig,omitempty" yaml:"distributionConfig,omitempty"`
 	SourceDirectory    string                   `json:"sourceDirectory,omitempty"    yaml:"sourceDirectory,omitempty"`
+	ComposeFile        string                   `json:"composeFile,omitempty"        yaml:"composeFile,omitempty"`
+	DockerHost         string                   `json:"dockerHost,omitempty"         yaml:"dockerHost,omitempty"`
 	Connection         *clusterConnectionOutput `json:"connection,omitempty"         yaml:"connection,omitempty"`
 	CNI                string                   `json:"cni,omitempty"                yaml:"cni,omitempty"`
 	CSI                string                   `json:"csi,omitempty"                yaml:"csi,omitempty"`
 	MetricsServer      string                   `json:"metricsServer,omitempty"      yaml:"metricsServer,omitempty"`
 	LocalRegistry      string                   `json:"localRegistry,omitempty"      yaml:"localRegistry,omitempty"`
 	GitOpsEngine       string                   `json:"gitOpsEngine,omitempty"       yaml:"gitOpsEngine,omitempty"`
+	ControlPlaneNodes  int32                    `json:"controlPlaneNodes,omitempty"  yaml:"controlPlaneNodes,omitempty"`
+	WorkerNodes        int32                    `json:"workerNodes,omitempty"        yaml:"workerNodes,omitempty"`
+	Networking         *clusterNetworkingOutput `json:"networking,omitempty"         yaml:"networking,omitempty"`
+	Nodes              []nodeOutput             `json:"nodes,omitempty"              yaml:"nodes,omitempty"`
+	Mounts             []mountOutput            `json:"mounts,omitempty"             yaml:"mounts,omitempty"`
+	Mirrors            []mirrorOutput           `json:"mirrors,omitempty"            yaml:"mirrors,omitempty"`
+	Plugins            []pluginOutput           `json:"plugins,omitempty"            yaml:"plugins,omitempty"`
 	Options            *clusterOptionsOutput    `json:"options,omitempty"            yaml:"options,omitempty"`
 }
 
@@ -56,25 +65,218 @@ type clusterConnectionOutput struct {
 	Timeout    string `json:"timeout,omitempty"    yaml:"timeout,omitempty"`
 }
 
+type clusterNetworkingOutput struct {
+	IPFamily      string               `json:"ipFamily,omitempty"      yaml:"ipFamily,omitempty"`
+	PodSubnet     string               `json:"podSubnet,omitempty"     yaml:"podSubnet,omitempty"`
+	ServiceSubnet string               `json:"serviceSubnet,omitempty" yaml:"serviceSubnet,omitempty"`
+	DockerNetwork *dockerNetworkOutput `json:"dockerNetwork,omitempty" yaml:"dockerNetwork,omitempty"`
+}
+
+type dockerNetworkOutput struct {
+	Name    string `json:"name,omitempty"    yaml:"name,omitempty"`
+	Subnet  string `json:"subnet,omitempty"  yaml:"subnet,omitempty"`
+	Gateway string `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+}
+
+type nodeOutput struct {
+	Role   string            `json:"role,omitempty"   yaml:"role,omitempty"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints []taintOutput     `json:"taints,omitempty" yaml:"taints,omitempty"`
+}
+
+type mountOutput struct {
+	HostPath      string `json:"hostPath,omitempty"      yaml:"hostPath,omitempty"`
+	ContainerPath string `json:"containerPath,omitempty" yaml:"containerPath,omitempty"`
+	ReadOnly      bool   `json:"readOnly,omitempty"      yaml:"readOnly,omitempty"`
+}
+
+type mirrorOutput struct {
+	Host     string `json:"host,omitempty"     yaml:"host,omitempty"`
+	Upstream string `json:"upstream,omitempty" yaml:"upstream,omitempty"`
+}
+
+type pluginOutput struct {
+	Name      string            `json:"name,omitempty"      yaml:"name,omitempty"`
+	Command   string            `json:"command,omitempty"   yaml:"command,omitempty"`
+	Args      []string          `json:"args,omitempty"      yaml:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"       yaml:"env,omitempty"`
+	DependsOn []string          `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+}
+
+type taintOutput struct {
+	Key    string `json:"key,omitempty"    yaml:"key,omitempty"`
+	Value  string `json:"value,omitempty"  yaml:"value,omitempty"`
+	Effect string `json:"effect,omitempty" yaml:"effect,omitempty"`
+}
+
 type clusterOptionsOutput struct {
+	GatewayAPI    *gatewayAPIOptionsOutput    `json:"gatewayAPI,omitempty"    yaml:"gatewayAPI,omitempty"`
+	Cilium        *chartOptionsOutput         `json:"cilium,omitempty"        yaml:"cilium,omitempty"`
+	Calico        *chartOptionsOutput         `json:"calico,omitempty"        yaml:"calico,omitempty"`
+	MetricsServer *chartOptionsOutput         `json:"metricsServer,omitempty" yaml:"metricsServer,omitempty"`
+	MetalLB       *metalLBOptionsOutput       `json:"metalLB,omitempty"       yaml:"metalLB,omitempty"`
 	Flux          *fluxOptionsOutput          `json:"flux,omitempty"          yaml:"flux,omitempty"`
+	ArgoCD        *argoCDOptionsOutput        `json:"argocd,omitempty"        yaml:"argocd,omitempty"`
+	ArgoRollouts  *argoRolloutsOptionsOutput  `json:"argoRollouts,omitempty"  yaml:"argoRollouts,omitempty"`
 	LocalRegistry *localRegistryOptionsOutput `json:"localRegistry,omitempty" yaml:"localRegistry,omitempty"`
+	Helm          *helmOptionsOutput          `json:"helm,omitempty"          yaml:"helm,omitempty"`
+	Install       *installOptionsOutput       `json:"install,omitempty"       yaml:"install,omitempty"`
+	Colima        *colimaOptionsOutput        `json:"colima,omitempty"        yaml:"colima,omitempty"`
+	Gitea         *giteaOptionsOutput         `json:"gitea,omitempty"         yaml:"gitea,omitempty"`
+	Headlamp      *headlampOptionsOutput      `json:"headlamp,omitempty"      yaml:"headlamp,omitempty"`
+	LocalStack    *localStackOptionsOutput    `json:"localStack,omitempty"    yaml:"localStack,omitempty"`
+	Vault         *vaultOptionsOutput         `json:"vault,omitempty"         yaml:"vault,omitempty"`
+	CloudNativePG *cloudNativePGOptionsOutput `json:"cloudNativePG,omitempty" yaml:"cloudNativePG,omitempty"`
+	Kyverno       *kyvernoOptionsOutput       `json:"kyverno,omitempty"       yaml:"kyverno,omitempty"`
+}
+
+type gatewayAPIOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Channel string `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type metalLBOptionsOutput struct {
+	Enabled   bool   `json:"enabled,omitempty"   yaml:"enabled,omitempty"`
+	Version   string `json:"version,omitempty"   yaml:"version,omitempty"`
+	Addresses string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+}
+
+type helmOptionsOutput struct {
+	ChartMirror string `json:"chartMirror,omitempty" yaml:"chartMirror,omitempty"`
+}
+
+type installOptionsOutput struct {
+	Attempts   int32                          `json:"attempts,omitempty"   yaml:"attempts,omitempty"`
+	Interval   string                         `json:"interval,omitempty"   yaml:"interval,omitempty"`
+	Timeout    string                         `json:"timeout,omitempty"    yaml:"timeout,omitempty"`
+	Components map[string]installPolicyOutput `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type installPolicyOutput struct {
+	Attempts int32  `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Timeout  string `json:"timeout,omitempty"  yaml:"timeout,omitempty"`
+}
+
+type chartOptionsOutput struct {
+	Version    string                 `json:"version,omitempty"    yaml:"version,omitempty"`
+	ValuesFile string                 `json:"valuesFile,omitempty" yaml:"valuesFile,omitempty"`
+	Values     string                 `json:"values,omitempty"     yaml:"values,omitempty"`
+	Repository *chartRepositoryOutput `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+type chartRepositoryOutput struct {
+	URL                   string `json:"url,omitempty"                   yaml:"url,omitempty"`
+	Username              string `json:"username,omitempty"              yaml:"username,omitempty"`
+	Password              string `json:"password,omitempty"              yaml:"password,omitempty"`
+	PlainHTTP             bool   `json:"plainHTTP,omitempty"             yaml:"plainHTTP,omitempty"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify,omitempty" yaml:"insecureSkipTLSVerify,omitempty"`
 }
 
 type fluxOptionsOutput struct {
+	Version  string `json:"version,omitempty"  yaml:"version,omitempty"`
 	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
 }
 
+type argoCDOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type argoRolloutsOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
 type localRegistryOptionsOutput struct {
 	HostPort int32 `json:"hostPort,omitempty" yaml:"hostPort,omitempty"`
 }
 
+type giteaOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type headlampOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type localStackOptionsOutput struct {
+	Enabled  bool   `json:"enabled,omitempty"  yaml:"enabled,omitempty"`
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	Version  string `json:"version,omitempty"  yaml:"version,omitempty"`
+}
+
+type vaultOptionsOutput struct {
+	Enabled    bool   `json:"enabled,omitempty"    yaml:"enabled,omitempty"`
+	Version    string `json:"version,omitempty"    yaml:"version,omitempty"`
+	Token      string `json:"token,omitempty"      yaml:"token,omitempty"`
+	KVMount    string `json:"kvMount,omitempty"    yaml:"kvMount,omitempty"`
+	TransitKey string `json:"transitKey,omitempty" yaml:"transitKey,omitempty"`
+}
+
+type cloudNativePGOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+type kyvernoOptionsOutput struct {
+	Enabled             bool   `json:"enabled,omitempty"             yaml:"enabled,omitempty"`
+	Version             string `json:"version,omitempty"             yaml:"version,omitempty"`
+	PodSecurityStandard string `json:"podSecurityStandard,omitempty" yaml:"podSecurityStandard,omitempty"`
+	Enforce             bool   `json:"enforce,omitempty"             yaml:"enforce,omitempty"`
+}
+
+type colimaOptionsOutput struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	CPUs    int32  `json:"cpus,omitempty"    yaml:"cpus,omitempty"`
+	Memory  int32  `json:"memory,omitempty"  yaml:"memory,omitempty"`
+	Disk    int32  `json:"disk,omitempty"    yaml:"disk,omitempty"`
+}
+
+func buildChartOptionsOutput(version string, values HelmValues) *chartOptionsOutput {
+	if version == "" && values == (HelmValues{}) {
+		return nil
+	}
+
+	return &chartOptionsOutput{Version: version, ValuesFile: values.ValuesFile, Values: values.Values}
+}
+
+// withChartRepository adds repository to the chart options output, creating it if needed.
+func withChartRepository(output *chartOptionsOutput, repository ChartRepository) *chartOptionsOutput {
+	if repository == (ChartRepository{}) {
+		return output
+	}
+
+	if output == nil {
+		output = &chartOptionsOutput{}
+	}
+
+	output.Repository = &chartRepositoryOutput{
+		URL:                   repository.URL,
+		Username:              repository.Username,
+		Password:              repository.Password,
+		PlainHTTP:             repository.PlainHTTP,
+		InsecureSkipTLSVerify: repository.InsecureSkipTLSVerify,
+	}
+
+	return output
+}
+
 //nolint:cyclop,funlen // marshalling logic requires checking multiple optional fields
 func buildClusterOutput(cluster Cluster) clusterOutput {
 	var spec clusterSpecOutput
 
 	hasSpec := false
 
+	if trimmed := strings.TrimSpace(cluster.Spec.ClusterName); trimmed != "" {
+		spec.ClusterName = trimmed
+		hasSpec = true
+	}
+
 	if cluster.Spec.Distribution != "" {
 		spec.Distribution = string(cluster.Spec.Distribution)
 		hasSpec = true
@@ -90,6 +292,16 @@ func buildClusterOutput(cluster Cluster) clusterOutput {
 		hasSpec = true
 	}
 
+	if trimmed := strings.TrimSpace(cluster.Spec.ComposeFile); trimmed != "" {
+		spec.ComposeFile = trimmed
+		hasSpec = true
+	}
+
+	if trimmed := strings.TrimSpace(cluster.Spec.DockerHost); trimmed != "" {
+		spec.DockerHost = trimmed
+		hasSpec = true
+	}
+
 	var conn clusterConnectionOutput
 	if cluster.Spec.Connection.Kubeconfig != "" {
 		conn.Kubeconfig = cluster.Spec.Connection.Kubeconfig
@@ -133,14 +345,125 @@ func buildClusterOutput(cluster Cluster) clusterOutput {
 		hasSpec = true
 	}
 
+	if cluster.Spec.ControlPlaneNodes != 0 {
+		spec.ControlPlaneNodes = cluster.Spec.ControlPlaneNodes
+		hasSpec = true
+	}
+
+	if cluster.Spec.WorkerNodes != 0 {
+		spec.WorkerNodes = cluster.Spec.WorkerNodes
+		hasSpec = true
+	}
+
+	if cluster.Spec.Networking != (Networking{}) {
+		spec.Networking = &clusterNetworkingOutput{
+			IPFamily:      string(cluster.Spec.Networking.IPFamily),
+			PodSubnet:     strings.TrimSpace(cluster.Spec.Networking.PodSubnet),
+			ServiceSubnet: strings.TrimSpace(cluster.Spec.Networking.ServiceSubnet),
+		}
+
+		if dockerNetwork := cluster.Spec.Networking.DockerNetwork; dockerNetwork != (DockerNetwork{}) {
+			spec.Networking.DockerNetwork = &dockerNetworkOutput{
+				Name:    strings.TrimSpace(dockerNetwork.Name),
+				Subnet:  strings.TrimSpace(dockerNetwork.Subnet),
+				Gateway: strings.TrimSpace(dockerNetwork.Gateway),
+			}
+		}
+
+		hasSpec = true
+	}
+
+	if len(cluster.Spec.Nodes) > 0 {
+		spec.Nodes = buildNodesOutput(cluster.Spec.Nodes)
+		hasSpec = true
+	}
+
+	for _, mount := range cluster.Spec.Mounts {
+		spec.Mounts = append(spec.Mounts, mountOutput{
+			HostPath:      strings.TrimSpace(mount.HostPath),
+			ContainerPath: strings.TrimSpace(mount.ContainerPath),
+			ReadOnly:      mount.ReadOnly,
+		})
+		hasSpec = true
+	}
+
+	for _, mirror := range cluster.Spec.Mirrors {
+		spec.Mirrors = append(spec.Mirrors, mirrorOutput{
+			Host:     strings.TrimSpace(mirror.Host),
+			Upstream: strings.TrimSpace(mirror.Upstream),
+		})
+		hasSpec = true
+	}
+
+	for _, plugin := range cluster.Spec.Plugins {
+		spec.Plugins = append(spec.Plugins, pluginOutput{
+			Name:      strings.TrimSpace(plugin.Name),
+			Command:   strings.TrimSpace(plugin.Command),
+			Args:      plugin.Args,
+			Env:       plugin.Env,
+			DependsOn: plugin.DependsOn,
+		})
+		hasSpec = true
+	}
+
 	var opts clusterOptionsOutput
 
-	hasOpts := false
+	options := cluster.Spec.Options
+	opts.Cilium = withChartRepository(
+		buildChartOptionsOutput(options.Cilium.Version, options.Cilium.HelmValues()),
+		options.Cilium.Repository,
+	)
+	opts.Calico = withChartRepository(
+		buildChartOptionsOutput(options.Calico.Version, options.Calico.HelmValues()),
+		options.Calico.Repository,
+	)
+	opts.MetricsServer = buildChartOptionsOutput(options.MetricsServer.Version, options.MetricsServer.HelmValues())
+	hasOpts := opts.Cilium != nil || opts.Calico != nil || opts.MetricsServer != nil
+
+	if options.GatewayAPI != (OptionsGatewayAPI{}) {
+		opts.GatewayAPI = &gatewayAPIOptionsOutput{
+			Enabled: options.GatewayAPI.Enabled,
+			Channel: string(options.GatewayAPI.Channel),
+			Version: options.GatewayAPI.Version,
+		}
+
+		hasOpts = true
+	}
 
-	if cluster.Spec.Options.Flux.Interval.Duration != 0 {
-		opts.Flux = &fluxOptionsOutput{
-			Interval: cluster.Spec.Options.Flux.Interval.Duration.String(),
+	if options.MetalLB != (OptionsMetalLB{}) {
+		opts.MetalLB = &metalLBOptionsOutput{
+			Enabled:   options.MetalLB.Enabled,
+			Version:   options.MetalLB.Version,
+			Addresses: options.MetalLB.Addresses,
 		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Flux.Version != "" || cluster.Spec.Options.Flux.Interval.Duration != 0 {
+		opts.Flux = &fluxOptionsOutput{Version: cluster.Spec.Options.Flux.Version}
+		if cluster.Spec.Options.Flux.Interval.Duration != 0 {
+			opts.Flux.Interval = cluster.Spec.Options.Flux.Interval.Duration.String()
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.ArgoCD != (OptionsArgoCD{}) {
+		opts.ArgoCD = &argoCDOptionsOutput{
+			Enabled: cluster.Spec.Options.ArgoCD.Enabled,
+			Version: cluster.Spec.Options.ArgoCD.Version,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.ArgoRollouts != (OptionsArgoRollouts{}) {
+		opts.ArgoRollouts = &argoRolloutsOptionsOutput{
+			Enabled: cluster.Spec.Options.ArgoRollouts.Enabled,
+			Version: cluster.Spec.Options.ArgoRollouts.Version,
+		}
+
 		hasOpts = true
 	}
 
@@ -152,6 +475,111 @@ func buildClusterOutput(cluster Cluster) clusterOutput {
 		hasOpts = true
 	}
 
+	if cluster.Spec.Options.Helm.ChartMirror != "" {
+		opts.Helm = &helmOptionsOutput{ChartMirror: cluster.Spec.Options.Helm.ChartMirror}
+
+		hasOpts = true
+	}
+
+	if install := cluster.Spec.Options.Install; install.Attempts != 0 || install.Interval.Duration != 0 ||
+		install.Timeout.Duration != 0 || len(install.Components) > 0 {
+		policy := buildInstallPolicyOutput(InstallPolicy{
+			Attempts: install.Attempts,
+			Interval: install.Interval,
+			Timeout:  install.Timeout,
+		})
+		opts.Install = &installOptionsOutput{
+			Attempts: policy.Attempts,
+			Interval: policy.Interval,
+			Timeout:  policy.Timeout,
+		}
+
+		for component, policy := range install.Components {
+			if opts.Install.Components == nil {
+				opts.Install.Components = map[string]installPolicyOutput{}
+			}
+
+			opts.Install.Components[component] = buildInstallPolicyOutput(policy)
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Colima != (OptionsColima{}) {
+		colima := cluster.Spec.Options.Colima
+		opts.Colima = &colimaOptionsOutput{
+			Enabled: colima.Enabled,
+			Profile: colima.Profile,
+			CPUs:    colima.CPUs,
+			Memory:  colima.Memory,
+			Disk:    colima.Disk,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Gitea != (OptionsGitea{}) {
+		opts.Gitea = &giteaOptionsOutput{
+			Enabled: cluster.Spec.Options.Gitea.Enabled,
+			Version: cluster.Spec.Options.Gitea.Version,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Headlamp != (OptionsHeadlamp{}) {
+		opts.Headlamp = &headlampOptionsOutput{
+			Enabled: cluster.Spec.Options.Headlamp.Enabled,
+			Version: cluster.Spec.Options.Headlamp.Version,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.LocalStack != (OptionsLocalStack{}) {
+		opts.LocalStack = &localStackOptionsOutput{
+			Enabled:  cluster.Spec.Options.LocalStack.Enabled,
+			Endpoint: cluster.Spec.Options.LocalStack.Endpoint,
+			Version:  cluster.Spec.Options.LocalStack.Version,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Vault != (OptionsVault{}) {
+		vault := cluster.Spec.Options.Vault
+		opts.Vault = &vaultOptionsOutput{
+			Enabled:    vault.Enabled,
+			Version:    vault.Version,
+			Token:      vault.Token,
+			KVMount:    vault.KVMount,
+			TransitKey: vault.TransitKey,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.CloudNativePG != (OptionsCloudNativePG{}) {
+		opts.CloudNativePG = &cloudNativePGOptionsOutput{
+			Enabled: cluster.Spec.Options.CloudNativePG.Enabled,
+			Version: cluster.Spec.Options.CloudNativePG.Version,
+		}
+
+		hasOpts = true
+	}
+
+	if cluster.Spec.Options.Kyverno != (OptionsKyverno{}) {
+		kyverno := cluster.Spec.Options.Kyverno
+		opts.Kyverno = &kyvernoOptionsOutput{
+			Enabled:             kyverno.Enabled,
+			Version:             kyverno.Version,
+			PodSecurityStandard: string(kyverno.PodSecurityStandard),
+			Enforce:             kyverno.Enforce,
+		}
+
+		hasOpts = true
+	}
+
 	if hasOpts {
 		spec.Options = &opts
 		hasSpec = true
@@ -169,6 +597,40 @@ func buildClusterOutput(cluster Cluster) clusterOutput {
 	}
 }
 
+func buildInstallPolicyOutput(policy InstallPolicy) installPolicyOutput {
+	output := installPolicyOutput{Attempts: policy.Attempts}
+
+	if policy.Interval.Duration != 0 {
+		output.Interval = policy.Interval.Duration.String()
+	}
+
+	if policy.Timeout.Duration != 0 {
+		output.Timeout = policy.Timeout.Duration.String()
+	}
+
+	return output
+}
+
+func buildNodesOutput(nodes []Node) []nodeOutput {
+	output := make([]nodeOutput, 0, len(nodes))
+
+	for _, node := range nodes {
+		out := nodeOutput{Role: string(node.Role), Labels: node.Labels}
+
+		for _, taint := range node.Taints {
+			out.Taints = append(out.Taints, taintOutput{
+				Key:    taint.Key,
+				Value:  taint.Value,
+				Effect: string(taint.Effect),
+			})
+		}
+
+		output = append(output, out)
+	}
+
+	return output
+}
+
 // pruneClusterDefaults zeroes fields that match default values so they are omitted when marshalled.
 //
 //nolint:cyclop,funlen // default pruning requires checking multiple fields
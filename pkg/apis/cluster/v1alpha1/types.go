@@ -31,8 +31,13 @@ type Cluster struct {
 
 // Spec defines the desired state of a KSail cluster.
 type Spec struct {
+	// ClusterName overrides the cluster name from the distribution config. It is a Go template
+	// over .Project, .GitBranch, .Distribution and .Unique, or "auto" for a collision-safe name.
+	ClusterName        string        `json:"clusterName,omitzero"`
 	DistributionConfig string        `json:"distributionConfig,omitzero"`
 	SourceDirectory    string        `json:"sourceDirectory,omitzero"`
+	ComposeFile        string        `json:"composeFile,omitzero"`
+	DockerHost         string        `json:"dockerHost,omitzero"`
 	Connection         Connection    `json:"connection,omitzero"`
 	Distribution       Distribution  `json:"distribution,omitzero"`
 	CNI                CNI           `json:"cni,omitzero"`
@@ -41,6 +46,22 @@ type Spec struct {
 	LocalRegistry      LocalRegistry `json:"localRegistry,omitzero"`
 	GitOpsEngine       GitOpsEngine  `json:"gitOpsEngine,omitzero"`
 	Options            Options       `json:"options,omitzero"`
+	// ControlPlaneNodes is the number of control-plane nodes; more than one yields a highly
+	// available control plane behind a load-balanced API endpoint. Zero keeps the count of
+	// the distribution config.
+	ControlPlaneNodes int32 `json:"controlPlaneNodes,omitzero"`
+	// WorkerNodes is the number of worker nodes. Zero keeps the count of the distribution config.
+	WorkerNodes int32 `json:"workerNodes,omitzero"`
+	// Networking sets the IP families and subnets of the cluster network.
+	Networking Networking `json:"networking,omitzero"`
+	// Nodes sets the labels and taints of individual Kind or K3d nodes.
+	Nodes []Node `json:"nodes,omitzero"`
+	// Mounts maps host directories into every node of a Kind or K3d cluster.
+	Mounts []Mount `json:"mounts,omitzero"`
+	// Mirrors are the upstream registries a Kind or K3d cluster pulls through local mirrors.
+	Mirrors []Mirror `json:"mirrors,omitzero"`
+	// Plugins are third-party installers that cluster create runs besides KSail's components.
+	Plugins []Plugin `json:"plugins,omitzero"`
 }
 
 // Connection defines connection options for a KSail cluster.
@@ -157,21 +178,38 @@ const (
 	DistributionKind Distribution = "Kind"
 	// DistributionK3d is the K3d distribution.
 	DistributionK3d Distribution = "K3d"
+	// DistributionTalos is the Talos distribution, running Talos Linux nodes in Docker.
+	DistributionTalos Distribution = "Talos"
+	// DistributionK0s is the k0s distribution, running k0s nodes in Docker.
+	DistributionK0s Distribution = "K0s"
+	// DistributionExternal is an existing cluster KSail attaches to through its kube context
+	// instead of provisioning it.
+	DistributionExternal Distribution = "External"
+	// DistributionKwok is a simulated cluster whose fake nodes are managed by kwok, running in
+	// Docker.
+	DistributionKwok Distribution = "Kwok"
 )
 
 // ProvidesMetricsServerByDefault returns true if the distribution includes metrics-server by default.
-// K3d (based on K3s) includes metrics-server, Kind does not.
+// K3d (based on K3s) and k0s include metrics-server, Kind and Talos do not. External clusters
+// are not provisioned by KSail, so nothing is assumed about them.
 func (d *Distribution) ProvidesMetricsServerByDefault() bool {
 	switch *d {
-	case DistributionK3d:
+	case DistributionK3d, DistributionK0s:
 		return true
-	case DistributionKind:
+	case DistributionKind, DistributionTalos, DistributionExternal, DistributionKwok:
 		return false
 	default:
 		return false
 	}
 }
 
+// IsSimulated returns true if the nodes of the distribution are simulated and run no
+// containers, so components such as the CNI are not installed on the cluster.
+func (d *Distribution) IsSimulated() bool {
+	return *d == DistributionKwok
+}
+
 // --- CNI Types ---
 
 // CNI defines the CNI options for a KSail cluster.
@@ -210,6 +248,158 @@ const (
 	MetricsServerDisabled MetricsServer = "Disabled"
 )
 
+// --- Networking Types ---
+
+// Networking defines the cluster network of a KSail cluster. Empty fields keep the defaults of
+// the distribution. IPv6 and DualStack clusters need a Docker engine with IPv6 enabled.
+type Networking struct {
+	IPFamily IPFamily `json:"ipFamily,omitzero"`
+	// PodSubnet is the CIDR pods get their addresses from; DualStack clusters take an IPv4
+	// and an IPv6 CIDR separated by a comma.
+	PodSubnet string `json:"podSubnet,omitzero"`
+	// ServiceSubnet is the CIDR services get their addresses from, in the format of PodSubnet.
+	ServiceSubnet string `json:"serviceSubnet,omitzero"`
+	// DockerNetwork is the Docker network the nodes and registry containers join.
+	DockerNetwork DockerNetwork `json:"dockerNetwork,omitzero"`
+}
+
+// DockerNetwork defines the Docker network of a Kind or K3d cluster. Empty fields keep the
+// defaults of the distribution: Kind clusters share the kind network and K3d clusters get a
+// k3d-<cluster name> network, both with an address range Docker picks.
+type DockerNetwork struct {
+	// Name is the name of the Docker network.
+	Name string `json:"name,omitzero"`
+	// Subnet is the CIDR the containers get their addresses from, in the format of
+	// Networking.PodSubnet. A network with the subnet is created unless it exists.
+	Subnet string `json:"subnet,omitzero"`
+	// Gateway is the address of the gateway within Subnet.
+	Gateway string `json:"gateway,omitzero"`
+}
+
+// Node customizes a node of a Kind or K3d cluster. Nodes are matched to the nodes of the
+// distribution config by role and position: the first ControlPlane entry customizes the first
+// control-plane node, the second Worker entry the second worker, and so on. The node counts are
+// raised when there are more entries of a role than nodes.
+type Node struct {
+	// Role is the role of the node. Empty means Worker.
+	Role NodeRole `json:"role,omitzero"`
+	// Labels are the Kubernetes labels the node registers with.
+	Labels map[string]string `json:"labels,omitzero"`
+	// Taints are the taints the node registers with.
+	Taints []Taint `json:"taints,omitzero"`
+}
+
+// Taint is a taint a node registers with.
+type Taint struct {
+	Key    string      `json:"key,omitzero"`
+	Value  string      `json:"value,omitzero"`
+	Effect TaintEffect `json:"effect,omitzero"`
+}
+
+// String returns the taint in the key=value:Effect format of kubectl taint.
+func (t Taint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + string(t.Effect)
+	}
+
+	return t.Key + "=" + t.Value + ":" + string(t.Effect)
+}
+
+// Mount maps a host directory into the nodes of a Kind or K3d cluster, e.g. for hostPath
+// volumes during local development.
+type Mount struct {
+	// HostPath is the directory on the host. Relative paths are resolved against the working
+	// directory.
+	HostPath string `json:"hostPath,omitzero"`
+	// ContainerPath is the absolute path the directory is mounted at inside the nodes.
+	ContainerPath string `json:"containerPath,omitzero"`
+	// ReadOnly mounts the directory read-only.
+	ReadOnly bool `json:"readOnly,omitzero"`
+}
+
+// Mirror is an upstream registry, such as ghcr.io or registry.k8s.io, that cluster create puts
+// a pull-through cache container in front of. The nodes pull images of Host through the cache,
+// which fetches them from Upstream.
+type Mirror struct {
+	// Host is the registry host images are pulled from, e.g. docker.io or quay.io.
+	Host string `json:"host,omitzero"`
+	// Upstream is the URL the cache fetches images from. It defaults to https://<host>, or
+	// https://registry-1.docker.io for docker.io.
+	Upstream string `json:"upstream,omitzero"`
+}
+
+// Plugin is an executable that installs a component KSail does not ship, such as in-house
+// charts. Cluster create runs `<command> install` with KUBECONFIG pointing at the cluster; see
+// the plugininstaller package for the full contract.
+type Plugin struct {
+	// Name identifies the plugin in output and in the DependsOn of other plugins.
+	Name string `json:"name,omitzero"`
+	// Command is the executable. It is looked up on PATH unless it contains a path separator,
+	// in which case relative paths are resolved against the working directory.
+	Command string `json:"command,omitzero"`
+	// Args are passed to the command after the install or uninstall action.
+	Args []string `json:"args,omitzero"`
+	// Env sets additional environment variables for the command.
+	Env map[string]string `json:"env,omitzero"`
+	// DependsOn lists the components, e.g. cni or flux, and the plugins that must be installed
+	// first. Empty means after the CNI.
+	DependsOn []string `json:"dependsOn,omitzero"`
+}
+
+// NodeRole defines the role of a node.
+type NodeRole string
+
+const (
+	// NodeRoleControlPlane is a node running the Kubernetes control plane, a K3s server.
+	NodeRoleControlPlane NodeRole = "ControlPlane"
+	// NodeRoleWorker is a node running workloads only, a K3s agent.
+	NodeRoleWorker NodeRole = "Worker"
+)
+
+// TaintEffect defines what happens to pods that do not tolerate a taint.
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule keeps new pods off the node.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+	// TaintEffectPreferNoSchedule avoids scheduling new pods on the node.
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	// TaintEffectNoExecute keeps new pods off the node and evicts running ones.
+	TaintEffectNoExecute TaintEffect = "NoExecute"
+)
+
+// GatewayAPIChannel defines a release channel of the Gateway API CRDs.
+type GatewayAPIChannel string
+
+const (
+	// GatewayAPIChannelStandard contains the GA and beta Gateway API resources.
+	GatewayAPIChannelStandard GatewayAPIChannel = "Standard"
+	// GatewayAPIChannelExperimental adds the experimental resources and fields, such as TLSRoute.
+	GatewayAPIChannelExperimental GatewayAPIChannel = "Experimental"
+)
+
+// PodSecurityStandard defines a level of the Kubernetes Pod Security Standards.
+type PodSecurityStandard string
+
+const (
+	// PodSecurityStandardBaseline prevents known privilege escalations.
+	PodSecurityStandardBaseline PodSecurityStandard = "baseline"
+	// PodSecurityStandardRestricted additionally enforces pod hardening best practices.
+	PodSecurityStandardRestricted PodSecurityStandard = "restricted"
+)
+
+// IPFamily defines the IP families of the cluster network.
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 gives pods and services IPv4 addresses.
+	IPFamilyIPv4 IPFamily = "IPv4"
+	// IPFamilyIPv6 gives pods and services IPv6 addresses.
+	IPFamilyIPv6 IPFamily = "IPv6"
+	// IPFamilyDualStack gives pods and services an IPv4 and an IPv6 address.
+	IPFamilyDualStack IPFamily = "DualStack"
+)
+
 // --- Local Registry Types ---
 
 // LocalRegistry defines how the host-local OCI registry should behave.
@@ -238,19 +428,38 @@ const (
 // --- Options Types ---
 
 // Options holds optional settings for distributions, networking, and deployment tools.
+//
+// The Version field of a component pins the version of the Helm chart KSail installs it from.
+// Without it, KSail installs the version pinned in the project's lock, or the newest one.
 type Options struct {
 	Kind OptionsKind `json:"kind,omitzero"`
 	K3d  OptionsK3d  `json:"k3d,omitzero"`
 
+	GatewayAPI OptionsGatewayAPI `json:"gatewayAPI,omitzero"`
+
 	Cilium OptionsCilium `json:"cilium,omitzero"`
 	Calico OptionsCalico `json:"calico,omitzero"`
 
+	MetricsServer OptionsMetricsServer `json:"metricsServer,omitzero"`
+	MetalLB       OptionsMetalLB       `json:"metalLB,omitzero"`
+
 	Flux          OptionsFlux          `json:"flux,omitzero"`
 	ArgoCD        OptionsArgoCD        `json:"argocd,omitzero"`
+	ArgoRollouts  OptionsArgoRollouts  `json:"argoRollouts,omitzero"`
 	LocalRegistry OptionsLocalRegistry `json:"localRegistry,omitzero"`
 
 	Helm      OptionsHelm      `json:"helm,omitzero"`
 	Kustomize OptionsKustomize `json:"kustomize,omitzero"`
+	Install   OptionsInstall   `json:"install,omitzero"`
+
+	Colima   OptionsColima   `json:"colima,omitzero"`
+	Gitea    OptionsGitea    `json:"gitea,omitzero"`
+	Headlamp OptionsHeadlamp `json:"headlamp,omitzero"`
+
+	LocalStack    OptionsLocalStack    `json:"localStack,omitzero"`
+	Vault         OptionsVault         `json:"vault,omitzero"`
+	CloudNativePG OptionsCloudNativePG `json:"cloudNativePG,omitzero"`
+	Kyverno       OptionsKyverno       `json:"kyverno,omitzero"`
 }
 
 // OptionsKind defines options specific to the Kind distribution.
@@ -261,24 +470,110 @@ type OptionsKind struct {
 // OptionsK3d defines options specific to the K3d distribution.
 type OptionsK3d struct{}
 
+// OptionsGatewayAPI defines options for the Gateway API CRDs.
+//
+// When enabled, KSail applies the CRDs of the Channel release channel, Standard by default,
+// before it installs the CNI, so Cilium and Traefik can serve Gateway resources. Version is a
+// Gateway API release such as v1.3.0.
+type OptionsGatewayAPI struct {
+	Enabled bool              `json:"enabled,omitzero"`
+	Channel GatewayAPIChannel `json:"channel,omitzero"`
+	Version string            `json:"version,omitzero"`
+}
+
 // OptionsCilium defines options for the Cilium CNI.
 type OptionsCilium struct {
-	// Add any specific fields for the Cilium CNI here.
+	Version    string          `json:"version,omitzero"`
+	ValuesFile string          `json:"valuesFile,omitzero"`
+	Values     string          `json:"values,omitzero"`
+	Repository ChartRepository `json:"repository,omitzero"`
 }
 
 // OptionsCalico defines options for the Calico CNI.
 type OptionsCalico struct {
-	// Add any specific fields for the Calico CNI here.
+	Version    string          `json:"version,omitzero"`
+	ValuesFile string          `json:"valuesFile,omitzero"`
+	Values     string          `json:"values,omitzero"`
+	Repository ChartRepository `json:"repository,omitzero"`
+}
+
+// ChartRepository overrides the repository KSail installs a chart from, e.g. with an internal
+// oci:// registry that mirrors it. URL is an index-based Helm repository or an oci:// registry
+// path that serves the chart by name. Username and Password authenticate against it; OCI
+// registries without them use the credentials stored by `helm registry login`.
+type ChartRepository struct {
+	URL                   string `json:"url,omitzero"`
+	Username              string `json:"username,omitzero"`
+	Password              string `json:"password,omitzero"`
+	PlainHTTP             bool   `json:"plainHTTP,omitzero"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify,omitzero"`
+}
+
+// OptionsMetricsServer defines options for the metrics-server KSail installs.
+type OptionsMetricsServer struct {
+	Version    string `json:"version,omitzero"`
+	ValuesFile string `json:"valuesFile,omitzero"`
+	Values     string `json:"values,omitzero"`
+}
+
+// OptionsMetalLB defines options for MetalLB, which gives Services of type LoadBalancer an
+// address on Kind clusters.
+//
+// When enabled, KSail installs MetalLB and lets it assign the addresses of Addresses, a CIDR or
+// a range such as 172.18.255.200-172.18.255.250. Without Addresses, KSail takes a range from
+// the top of the cluster's Docker network.
+type OptionsMetalLB struct {
+	Enabled   bool   `json:"enabled,omitzero"`
+	Version   string `json:"version,omitzero"`
+	Addresses string `json:"addresses,omitzero"`
+}
+
+// HelmValues are user-supplied Helm values for a chart KSail installs: the path of a values
+// file and a YAML document of values. Both are merged over the chart values KSail sets, and
+// Values take precedence over the values of ValuesFile.
+type HelmValues struct {
+	ValuesFile string
+	Values     string
+}
+
+// HelmValues returns the user-supplied Helm values of the Cilium chart.
+func (o OptionsCilium) HelmValues() HelmValues {
+	return HelmValues{ValuesFile: o.ValuesFile, Values: o.Values}
+}
+
+// HelmValues returns the user-supplied Helm values of the Calico chart.
+func (o OptionsCalico) HelmValues() HelmValues {
+	return HelmValues{ValuesFile: o.ValuesFile, Values: o.Values}
+}
+
+// HelmValues returns the user-supplied Helm values of the metrics-server chart.
+func (o OptionsMetricsServer) HelmValues() HelmValues {
+	return HelmValues{ValuesFile: o.ValuesFile, Values: o.Values}
 }
 
 // OptionsFlux defines options for the Flux deployment tool.
 type OptionsFlux struct {
+	Version  string          `json:"version,omitzero"`
 	Interval metav1.Duration `json:"interval,omitzero"`
 }
 
-// OptionsArgoCD defines options for the ArgoCD deployment tool.
+// OptionsArgoCD defines options for the ArgoCD GitOps controller.
+//
+// When enabled, KSail installs ArgoCD and an Application that syncs the source directory from
+// the in-cluster Gitea server, or from its artifact in the local registry once it is pushed
+// with `ksail workload reconcile`.
 type OptionsArgoCD struct {
-	// Add any specific fields for the ArgoCD tool here.
+	Enabled bool   `json:"enabled,omitzero"`
+	Version string `json:"version,omitzero"`
+}
+
+// OptionsArgoRollouts defines options for the Argo Rollouts progressive delivery controller.
+//
+// When enabled, KSail installs the controller and its CRDs, so Rollout resources can be
+// applied and promoted with the `kubectl argo rollouts` plugin.
+type OptionsArgoRollouts struct {
+	Enabled bool   `json:"enabled,omitzero"`
+	Version string `json:"version,omitzero"`
 }
 
 // OptionsLocalRegistry defines options for the host-local OCI registry integration.
@@ -287,8 +582,135 @@ type OptionsLocalRegistry struct {
 }
 
 // OptionsHelm defines options for the Helm tool.
+//
+// ChartMirror makes KSail install the charts of its components from an oci:// registry path
+// or a directory of chart archives instead of their upstream repositories, so clusters can
+// be created on networks without internet access.
 type OptionsHelm struct {
-	// Add any specific fields for the Helm tool here.
+	ChartMirror string `json:"chartMirror,omitzero"`
+}
+
+// OptionsInstall defines how persistently cluster create installs components.
+//
+// Helm operations and repository downloads that fail with a transient error, such as a
+// registry rate limit or a restarting API server, are retried with exponential backoff.
+// Readiness checks keep polling through transient errors until the timeout. Components
+// overrides the policy per component, keyed by its create step, e.g. cni, flux or
+// plugin/<name>; unset fields of an override keep the values above.
+type OptionsInstall struct {
+	// Attempts is how many times a failing operation is tried. Zero means 3.
+	Attempts int32 `json:"attempts,omitzero"`
+	// Interval is the delay before the first retry, doubled for every further retry. Zero
+	// means 2s.
+	Interval metav1.Duration `json:"interval,omitzero"`
+	// Timeout bounds each Helm operation and readiness wait of a component. Zero means
+	// spec.connection.timeout, or 5m when that is unset too.
+	Timeout metav1.Duration `json:"timeout,omitzero"`
+
+	Components map[string]InstallPolicy `json:"components,omitzero"`
+}
+
+// InstallPolicy overrides the attempts, interval and timeout of OptionsInstall for a component.
+type InstallPolicy struct {
+	Attempts int32           `json:"attempts,omitzero"`
+	Interval metav1.Duration `json:"interval,omitzero"`
+	Timeout  metav1.Duration `json:"timeout,omitzero"`
+}
+
+// Policy returns the policy of component: the override in Components merged over the
+// policy set for all components.
+func (o OptionsInstall) Policy(component string) InstallPolicy {
+	policy := InstallPolicy{Attempts: o.Attempts, Interval: o.Interval, Timeout: o.Timeout}
+
+	override := o.Components[component]
+	if override.Attempts != 0 {
+		policy.Attempts = override.Attempts
+	}
+
+	if override.Interval.Duration != 0 {
+		policy.Interval = override.Interval
+	}
+
+	if override.Timeout.Duration != 0 {
+		policy.Timeout = override.Timeout
+	}
+
+	return policy
+}
+
+// OptionsColima defines options for managing a Colima VM that provides the Docker runtime.
+//
+// When enabled, KSail starts the VM before provisioning the cluster and stops it after the
+// cluster is deleted. Zero resource values leave Colima's own defaults in place.
+type OptionsColima struct {
+	Enabled bool   `json:"enabled,omitzero"`
+	Profile string `json:"profile,omitzero"`
+	CPUs    int32  `json:"cpus,omitzero"`
+	Memory  int32  `json:"memory,omitzero"`
+	Disk    int32  `json:"disk,omitzero"`
+}
+
+// OptionsGitea defines options for the in-cluster Gitea server.
+//
+// When enabled, KSail installs Gitea, pushes the source directory to a repository on it,
+// and configures the GitOps engine to sync from that repository instead of the local OCI
+// registry.
+type OptionsGitea struct {
+	Enabled bool   `json:"enabled,omitzero"`
+	Version string `json:"version,omitzero"`
+}
+
+// OptionsHeadlamp defines options for the Headlamp web dashboard.
+//
+// When enabled, KSail installs Headlamp, which `ksail cluster open headlamp` forwards to and
+// opens in the browser with a login token.
+type OptionsHeadlamp struct {
+	Enabled bool   `json:"enabled,omitzero"`
+	Version string `json:"version,omitzero"`
+}
+
+// OptionsLocalStack defines options for the LocalStack AWS cloud emulator.
+//
+// When enabled, KSail deploys LocalStack into the cluster, or connects the instance at
+// Endpoint when it is set, and publishes the endpoint in the localstack-endpoint ConfigMap.
+type OptionsLocalStack struct {
+	Enabled  bool   `json:"enabled,omitzero"`
+	Endpoint string `json:"endpoint,omitzero"`
+	Version  string `json:"version,omitzero"`
+}
+
+// OptionsVault defines options for the HashiCorp Vault dev server.
+//
+// When enabled, KSail installs Vault in dev mode, unsealed and in memory, with Token as its
+// root token ("root" by default). KVMount enables a KV version 2 secrets engine at that path,
+// and TransitKey creates an encryption key in the transit engine for SOPS' hc_vault backend.
+type OptionsVault struct {
+	Enabled    bool   `json:"enabled,omitzero"`
+	Version    string `json:"version,omitzero"`
+	Token      string `json:"token,omitzero"`
+	KVMount    string `json:"kvMount,omitzero"`
+	TransitKey string `json:"transitKey,omitzero"`
+}
+
+// OptionsCloudNativePG defines options for the CloudNativePG PostgreSQL operator.
+//
+// When enabled, KSail installs the operator so that Cluster resources generated with
+// `ksail workload gen pgcluster` are provisioned as PostgreSQL instances.
+type OptionsCloudNativePG struct {
+	Enabled bool   `json:"enabled,omitzero"`
+	Version string `json:"version,omitzero"`
+}
+
+// OptionsKyverno defines options for the Kyverno policy engine.
+//
+// When enabled, KSail installs Kyverno and, when PodSecurityStandard is set, the policies of
+// that Pod Security Standards level. Violations are reported in policy reports, or rejected
+// at admission when Enforce is set.
+type OptionsKyverno struct {
+	Enabled             bool                `json:"enabled,omitzero"`
+	Version             string              `json:"version,omitzero"`
+	PodSecurityStandard PodSecurityStandard `json:"podSecurityStandard,omitzero"`
+	Enforce             bool                `json:"enforce,omitzero"`
 }
 
 // OptionsKustomize defines options for the Kustomize tool.
@@ -301,7 +723,7 @@ type OptionsKustomize struct {
 // Set for Distribution.
 func (d *Distribution) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, dist := range validDistributions() {
+	for _, dist := range ValidDistributions() {
 		if strings.EqualFold(value, string(dist)) {
 			*d = dist
 
@@ -309,14 +731,15 @@ func (d *Distribution) Set(value string) error {
 		}
 	}
 
-	return fmt.Errorf("%w: %s (valid options: %s, %s)",
-		ErrInvalidDistribution, value, DistributionKind, DistributionK3d)
+	return fmt.Errorf("%w: %s (valid options: %s, %s, %s, %s, %s, %s)",
+		ErrInvalidDistribution, value, DistributionKind, DistributionK3d, DistributionTalos, DistributionK0s,
+		DistributionExternal, DistributionKwok)
 }
 
 // Set for GitOpsEngine.
 func (g *GitOpsEngine) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, tool := range validGitOpsEngines() {
+	for _, tool := range ValidGitOpsEngines() {
 		if strings.EqualFold(value, string(tool)) {
 			*g = tool
 
@@ -335,7 +758,7 @@ func (g *GitOpsEngine) Set(value string) error {
 // Set for CNI.
 func (c *CNI) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, cni := range validCNIs() {
+	for _, cni := range ValidCNIs() {
 		if strings.EqualFold(value, string(cni)) {
 			*c = cni
 
@@ -350,7 +773,7 @@ func (c *CNI) Set(value string) error {
 // Set for CSI.
 func (c *CSI) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, csi := range validCSIs() {
+	for _, csi := range ValidCSIs() {
 		if strings.EqualFold(value, string(csi)) {
 			*c = csi
 
@@ -365,7 +788,7 @@ func (c *CSI) Set(value string) error {
 // Set for MetricsServer.
 func (m *MetricsServer) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, ms := range validMetricsServers() {
+	for _, ms := range ValidMetricsServers() {
 		if strings.EqualFold(value, string(ms)) {
 			*m = ms
 
@@ -385,7 +808,7 @@ func (m *MetricsServer) Set(value string) error {
 // Set for LocalRegistry.
 func (l *LocalRegistry) Set(value string) error {
 	// Check against constant values with case-insensitive comparison
-	for _, mode := range validLocalRegistryModes() {
+	for _, mode := range ValidLocalRegistryModes() {
 		if strings.EqualFold(value, string(mode)) {
 			*l = mode
 
@@ -402,6 +825,37 @@ func (l *LocalRegistry) Set(value string) error {
 	)
 }
 
+// Set for IPFamily.
+func (f *IPFamily) Set(value string) error {
+	// Check against constant values with case-insensitive comparison
+	for _, family := range ValidIPFamilies() {
+		if strings.EqualFold(value, string(family)) {
+			*f = family
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"%w: %s (valid options: %s, %s, %s)",
+		ErrInvalidIPFamily,
+		value,
+		IPFamilyIPv4,
+		IPFamilyIPv6,
+		IPFamilyDualStack,
+	)
+}
+
+// String returns the string representation of the IPFamily.
+func (f *IPFamily) String() string {
+	return string(*f)
+}
+
+// Type returns the type of the IPFamily.
+func (f *IPFamily) Type() string {
+	return "IPFamily"
+}
+
 // String returns the string representation of the LocalRegistry.
 func (l *LocalRegistry) String() string {
 	return string(*l)
@@ -414,7 +868,7 @@ func (l *LocalRegistry) Type() string {
 
 // IsValid checks if the distribution value is supported.
 func (d *Distribution) IsValid() bool {
-	return slices.Contains(validDistributions(), *d)
+	return slices.Contains(ValidDistributions(), *d)
 }
 
 // String returns the string representation of the Distribution.
@@ -54,3 +54,23 @@ func TestDistribution_ProvidesMetricsServerByDefault(t *testing.T) {
 		assert.False(t, result, "Empty distribution should not provide metrics-server by default")
 	})
 }
+
+func TestClusterDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	original := v1alpha1.NewCluster()
+	original.Spec.Nodes = []v1alpha1.Node{{Labels: map[string]string{"tier": "web"}}}
+	original.Spec.Mirrors = []v1alpha1.Mirror{{Host: "docker.io"}}
+	original.Spec.Plugins = []v1alpha1.Plugin{{Name: "certs", Env: map[string]string{"A": "1"}}}
+
+	copied := original.DeepCopy()
+	assert.Equal(t, original, copied)
+
+	copied.Spec.Nodes[0].Labels["tier"] = "db"
+	copied.Spec.Mirrors[0].Host = "ghcr.io"
+	copied.Spec.Plugins[0].Env["A"] = "2"
+
+	assert.Equal(t, "web", original.Spec.Nodes[0].Labels["tier"])
+	assert.Equal(t, "docker.io", original.Spec.Mirrors[0].Host)
+	assert.Equal(t, "1", original.Spec.Plugins[0].Env["A"])
+}
@@ -1,37 +1,69 @@
 package v1alpha1
 
-// validDistributions returns supported distribution values.
-func validDistributions() []Distribution {
-	return []Distribution{DistributionK3d, DistributionKind}
+// ValidDistributions returns the supported distribution values.
+func ValidDistributions() []Distribution {
+	return []Distribution{
+		DistributionK3d,
+		DistributionKind,
+		DistributionTalos,
+		DistributionK0s,
+		DistributionExternal,
+		DistributionKwok,
+	}
 }
 
-// validGitOpsEngines enumerates supported GitOps engine values.
-func validGitOpsEngines() []GitOpsEngine {
+// ValidGitOpsEngines enumerates supported GitOps engine values.
+func ValidGitOpsEngines() []GitOpsEngine {
 	return []GitOpsEngine{
 		GitOpsEngineNone,
 		GitOpsEngineFlux,
 	}
 }
 
-// validCNIs returns supported CNI values.
-func validCNIs() []CNI {
+// ValidCNIs returns supported CNI values.
+func ValidCNIs() []CNI {
 	return []CNI{CNIDefault, CNICilium, CNICalico}
 }
 
-// validCSIs returns supported CSI values.
-func validCSIs() []CSI {
+// ValidCSIs returns supported CSI values.
+func ValidCSIs() []CSI {
 	return []CSI{CSIDefault, CSILocalPathStorage}
 }
 
-// validMetricsServers returns supported metrics server values.
-func validMetricsServers() []MetricsServer {
+// ValidMetricsServers returns supported metrics server values.
+func ValidMetricsServers() []MetricsServer {
 	return []MetricsServer{
 		MetricsServerEnabled,
 		MetricsServerDisabled,
 	}
 }
 
-// validLocalRegistryModes returns supported local registry configuration modes.
-func validLocalRegistryModes() []LocalRegistry {
+// ValidLocalRegistryModes returns supported local registry configuration modes.
+func ValidLocalRegistryModes() []LocalRegistry {
 	return []LocalRegistry{LocalRegistryEnabled, LocalRegistryDisabled}
 }
+
+// ValidIPFamilies returns supported IP family values.
+func ValidIPFamilies() []IPFamily {
+	return []IPFamily{IPFamilyIPv4, IPFamilyIPv6, IPFamilyDualStack}
+}
+
+// ValidNodeRoles returns supported node role values.
+func ValidNodeRoles() []NodeRole {
+	return []NodeRole{NodeRoleControlPlane, NodeRoleWorker}
+}
+
+// ValidGatewayAPIChannels returns supported Gateway API release channels.
+func ValidGatewayAPIChannels() []GatewayAPIChannel {
+	return []GatewayAPIChannel{GatewayAPIChannelStandard, GatewayAPIChannelExperimental}
+}
+
+// ValidPodSecurityStandards returns supported Pod Security Standards levels.
+func ValidPodSecurityStandards() []PodSecurityStandard {
+	return []PodSecurityStandard{PodSecurityStandardBaseline, PodSecurityStandardRestricted}
+}
+
+// ValidTaintEffects returns supported taint effect values.
+func ValidTaintEffects() []TaintEffect {
+	return []TaintEffect{TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute}
+}
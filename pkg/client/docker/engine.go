@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/docker/client"
 )
 
@@ -42,11 +44,37 @@ func NewContainerEngine(apiClient client.APIClient) (*ContainerEngine, error) {
 type ClientCreator func() (client.APIClient, error)
 
 // GetDockerClient creates a Docker client using environment configuration.
+// Remote hosts given as ssh:// URLs in DOCKER_HOST are supported; see NewClientForHost.
 func GetDockerClient() (client.APIClient, error) {
-	dockerClient, err := client.NewClientWithOpts(
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	)
+	return NewClientForHost(os.Getenv(client.EnvOverrideHost))
+}
+
+// NewClientForHost creates a Docker client for host, falling back to the environment
+// configuration when host is empty.
+//
+// Besides the schemes supported by the Docker API client, host may be an ssh://user@host URL,
+// in which case the daemon is reached by running `docker system dial-stdio` over ssh, as the
+// docker CLI does.
+func NewClientForHost(host string) (client.APIClient, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+
+	if host != "" {
+		helper, err := connhelper.GetConnectionHelper(host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve Docker host %q: %w", host, err)
+		}
+
+		if helper == nil {
+			opts = append(opts, client.WithHost(host))
+		} else {
+			opts = append(opts,
+				client.WithHost(helper.Host),
+				client.WithDialContext(helper.Dialer),
+			)
+		}
+	}
+
+	dockerClient, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
 	}
@@ -0,0 +1,254 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// Network error definitions.
+var (
+	// ErrNetworkSubnetMismatch is returned when a network exists with other subnets than requested.
+	ErrNetworkSubnetMismatch = errors.New("network exists with a different subnet")
+	// ErrNetworkSubnetOverlap is returned when a requested subnet overlaps another network.
+	ErrNetworkSubnetOverlap = errors.New("subnet overlaps an existing network")
+	// ErrInvalidNetworkGateway is returned when the gateway is not an address inside the subnet.
+	ErrInvalidNetworkGateway = errors.New("gateway is not an address inside the subnet")
+	// ErrNoIPv4Subnet is returned when a network has no IPv4 subnet.
+	ErrNoIPv4Subnet = errors.New("network has no IPv4 subnet")
+)
+
+// NetworkLabelKey marks networks created by ksail.
+const NetworkLabelKey = "io.ksail.network"
+
+// NetworkConfig describes a bridge network for cluster nodes and registries.
+type NetworkConfig struct {
+	// Name is the name of the network.
+	Name string
+	// Subnet is a CIDR, or an IPv4 and an IPv6 CIDR separated by a comma.
+	Subnet string
+	// Gateway is the gateway address inside the subnet of its IP family. Empty lets Docker pick.
+	Gateway string
+}
+
+// EnsureNetwork creates the bridge network described by config unless it exists, and reports
+// whether it was created. An existing network must use the configured subnets, and a new one
+// must not overlap the subnets of any other network.
+func EnsureNetwork(ctx context.Context, apiClient client.APIClient, config NetworkConfig) (bool, error) {
+	subnets, err := parseSubnets(config.Subnet)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := apiClient.NetworkInspect(ctx, config.Name, network.InspectOptions{})
+	if err == nil {
+		if len(subnets) > 0 && !sameSubnets(existing, subnets) {
+			return false, fmt.Errorf("%w: %q uses %s, not %s",
+				ErrNetworkSubnetMismatch, config.Name, strings.Join(networkSubnets(existing), ","), config.Subnet)
+		}
+
+		return false, nil
+	}
+
+	if !cerrdefs.IsNotFound(err) {
+		return false, fmt.Errorf("inspect network %q: %w", config.Name, err)
+	}
+
+	err = checkSubnetOverlap(ctx, apiClient, config.Name, subnets)
+	if err != nil {
+		return false, err
+	}
+
+	ipam, enableIPv6, err := networkIPAM(subnets, config.Gateway)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = apiClient.NetworkCreate(ctx, config.Name, network.CreateOptions{
+		Driver:     "bridge",
+		EnableIPv6: &enableIPv6,
+		IPAM:       ipam,
+		Labels:     map[string]string{NetworkLabelKey: "true"},
+	})
+	if err != nil {
+		return false, fmt.Errorf("create network %q: %w", config.Name, err)
+	}
+
+	return true, nil
+}
+
+// RemoveNetworkIfUnused removes the named network when ksail created it and no containers are
+// attached to it anymore, and reports whether it was removed.
+func RemoveNetworkIfUnused(ctx context.Context, apiClient client.APIClient, name string) (bool, error) {
+	existing, err := apiClient.NetworkInspect(ctx, name, network.InspectOptions{})
+	if cerrdefs.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("inspect network %q: %w", name, err)
+	}
+
+	if existing.Labels[NetworkLabelKey] != "true" || len(existing.Containers) > 0 {
+		return false, nil
+	}
+
+	err = apiClient.NetworkRemove(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("remove network %q: %w", name, err)
+	}
+
+	return true, nil
+}
+
+// NetworkIPv4Subnet returns the IPv4 subnet of the named network, e.g. to hand out addresses
+// of the network to LoadBalancer services.
+func NetworkIPv4Subnet(ctx context.Context, apiClient client.APIClient, name string) (*net.IPNet, error) {
+	inspect, err := apiClient.NetworkInspect(ctx, name, network.InspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("inspect network %q: %w", name, err)
+	}
+
+	subnets, err := parseSubnets(strings.Join(networkSubnets(inspect), ","))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subnet := range subnets {
+		if subnet.IP.To4() != nil {
+			return subnet, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrNoIPv4Subnet, name)
+}
+
+func parseSubnets(subnet string) ([]*net.IPNet, error) {
+	if strings.TrimSpace(subnet) == "" {
+		return nil, nil
+	}
+
+	var subnets []*net.IPNet
+
+	for cidr := range strings.SplitSeq(subnet, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("parse subnet %q: %w", strings.TrimSpace(cidr), err)
+		}
+
+		subnets = append(subnets, ipNet)
+	}
+
+	return subnets, nil
+}
+
+// networkSubnets returns the subnets configured in the IPAM of a network.
+func networkSubnets(inspect network.Inspect) []string {
+	subnets := make([]string, 0, len(inspect.IPAM.Config))
+	for _, config := range inspect.IPAM.Config {
+		if config.Subnet != "" {
+			subnets = append(subnets, config.Subnet)
+		}
+	}
+
+	return subnets
+}
+
+func sameSubnets(inspect network.Inspect, subnets []*net.IPNet) bool {
+	existing := networkSubnets(inspect)
+	if len(existing) != len(subnets) {
+		return false
+	}
+
+	for _, subnet := range subnets {
+		if !slices.Contains(existing, subnet.String()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkSubnetOverlap returns ErrNetworkSubnetOverlap when a subnet overlaps a network other
+// than the named one.
+func checkSubnetOverlap(ctx context.Context, apiClient client.APIClient, name string, subnets []*net.IPNet) error {
+	if len(subnets) == 0 {
+		return nil
+	}
+
+	networks, err := apiClient.NetworkList(ctx, network.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list networks: %w", err)
+	}
+
+	for _, other := range networks {
+		if other.Name == name {
+			continue
+		}
+
+		for _, cidr := range networkSubnets(other) {
+			_, otherNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+
+			for _, subnet := range subnets {
+				if subnet.Contains(otherNet.IP) || otherNet.Contains(subnet.IP) {
+					return fmt.Errorf("%w: %s overlaps %s of network %q",
+						ErrNetworkSubnetOverlap, subnet, otherNet, other.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// networkIPAM builds the IPAM configuration of the subnets, assigning the gateway to the subnet
+// that contains it, and reports whether one of the subnets is IPv6.
+func networkIPAM(subnets []*net.IPNet, gateway string) (*network.IPAM, bool, error) {
+	if len(subnets) == 0 {
+		return nil, false, nil
+	}
+
+	var gatewayIP net.IP
+
+	if gateway != "" {
+		gatewayIP = net.ParseIP(gateway)
+		if gatewayIP == nil {
+			return nil, false, fmt.Errorf("%w: %q", ErrInvalidNetworkGateway, gateway)
+		}
+	}
+
+	ipam := &network.IPAM{Driver: "default"}
+	enableIPv6 := false
+	gatewayAssigned := gatewayIP == nil
+
+	for _, subnet := range subnets {
+		config := network.IPAMConfig{Subnet: subnet.String()}
+
+		if gatewayIP != nil && subnet.Contains(gatewayIP) {
+			config.Gateway = gatewayIP.String()
+			gatewayAssigned = true
+		}
+
+		if subnet.IP.To4() == nil {
+			enableIPv6 = true
+		}
+
+		ipam.Config = append(ipam.Config, config)
+	}
+
+	if !gatewayAssigned {
+		return nil, false, fmt.Errorf("%w: %q", ErrInvalidNetworkGateway, gateway)
+	}
+
+	return ipam, enableIPv6, nil
+}
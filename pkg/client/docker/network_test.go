@@ -0,0 +1,100 @@
+package docker_test
+
+import (
+	"context"
+	"testing"
+
+	docker "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureNetwork_CreatesAndRemovesNetwork(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	apiClient := fake.Client(t)
+	ctx := context.Background()
+	config := docker.NetworkConfig{Name: "team-a", Subnet: "172.30.0.0/16", Gateway: "172.30.0.1"}
+
+	created, err := docker.EnsureNetwork(ctx, apiClient, config)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.True(t, fake.HasNetwork("team-a"))
+
+	// Ensuring again keeps the existing network.
+	created, err = docker.EnsureNetwork(ctx, apiClient, config)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	removed, err := docker.RemoveNetworkIfUnused(ctx, apiClient, "team-a")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.False(t, fake.HasNetwork("team-a"))
+}
+
+func TestNetworkIPv4Subnet(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	apiClient := fake.Client(t)
+	ctx := context.Background()
+
+	_, err := docker.EnsureNetwork(ctx, apiClient, docker.NetworkConfig{
+		Name:   "kind",
+		Subnet: "fc00:f853:ccd:e793::/64,172.18.0.0/16",
+	})
+	require.NoError(t, err)
+
+	subnet, err := docker.NetworkIPv4Subnet(ctx, apiClient, "kind")
+	require.NoError(t, err)
+	assert.Equal(t, "172.18.0.0/16", subnet.String())
+
+	_, err = docker.EnsureNetwork(ctx, apiClient, docker.NetworkConfig{Name: "ipv6", Subnet: "fd00:1::/64"})
+	require.NoError(t, err)
+
+	_, err = docker.NetworkIPv4Subnet(ctx, apiClient, "ipv6")
+	require.ErrorIs(t, err, docker.ErrNoIPv4Subnet)
+}
+
+func TestEnsureNetwork_RejectsConflictingSubnets(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetworkWithSubnet("kind", "172.18.0.0/16")
+	fake.AddNetworkWithSubnet("team-b", "172.31.0.0/16")
+
+	apiClient := fake.Client(t)
+	ctx := context.Background()
+
+	_, err := docker.EnsureNetwork(ctx, apiClient, docker.NetworkConfig{Name: "team-a", Subnet: "172.18.5.0/24"})
+	require.ErrorIs(t, err, docker.ErrNetworkSubnetOverlap)
+
+	_, err = docker.EnsureNetwork(ctx, apiClient, docker.NetworkConfig{Name: "team-b", Subnet: "172.30.0.0/16"})
+	require.ErrorIs(t, err, docker.ErrNetworkSubnetMismatch)
+
+	_, err = docker.EnsureNetwork(ctx, apiClient, docker.NetworkConfig{
+		Name:    "team-a",
+		Subnet:  "172.30.0.0/16",
+		Gateway: "10.0.0.1",
+	})
+	require.ErrorIs(t, err, docker.ErrInvalidNetworkGateway)
+	assert.False(t, fake.HasNetwork("team-a"))
+}
+
+func TestRemoveNetworkIfUnused_KeepsForeignNetworks(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetwork("kind")
+
+	removed, err := docker.RemoveNetworkIfUnused(context.Background(), fake.Client(t), "kind")
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.True(t, fake.HasNetwork("kind"))
+
+	removed, err = docker.RemoveNetworkIfUnused(context.Background(), fake.Client(t), "missing")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
@@ -249,6 +249,90 @@ func (rm *RegistryManager) ListRegistries(ctx context.Context) ([]string, error)
 	return registries, nil
 }
 
+// PauseRegistry stops a registry container without removing it, so its cache volume and
+// network attachments are kept for ResumeRegistry. Registries that are also attached to the
+// network of another cluster keep running. It reports whether the registry was stopped.
+func (rm *RegistryManager) PauseRegistry(ctx context.Context, name, networkName string) (bool, error) {
+	containers, err := rm.listRegistryContainers(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to list registry containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return false, ErrRegistryNotFound
+	}
+
+	registryContainer := containers[0]
+	if !strings.EqualFold(registryContainer.State, "running") {
+		return false, nil
+	}
+
+	inspect, err := inspectContainer(ctx, rm.client, registryContainer.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if registryAttachedToOtherClusters(inspect, strings.TrimSpace(networkName)) {
+		return false, nil
+	}
+
+	err = rm.stopRegistryContainer(ctx, registryContainer)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ResumeRegistry starts a registry container paused by PauseRegistry and reconnects it to
+// networkName when the network was recreated while it was stopped. It reports whether the
+// registry was started.
+func (rm *RegistryManager) ResumeRegistry(ctx context.Context, name, networkName string) (bool, error) {
+	containers, err := rm.listRegistryContainers(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("failed to list registry containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		return false, ErrRegistryNotFound
+	}
+
+	registryContainer := containers[0]
+	started := false
+
+	if !strings.EqualFold(registryContainer.State, "running") {
+		err = rm.client.ContainerStart(ctx, registryContainer.ID, container.StartOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to start registry container: %w", err)
+		}
+
+		started = true
+	}
+
+	trimmedNetwork := strings.TrimSpace(networkName)
+	if trimmedNetwork == "" {
+		return started, nil
+	}
+
+	inspect, err := inspectContainer(ctx, rm.client, registryContainer.ID)
+	if err != nil {
+		return started, err
+	}
+
+	if inspect.NetworkSettings != nil {
+		if _, attached := inspect.NetworkSettings.Networks[trimmedNetwork]; attached {
+			return started, nil
+		}
+	}
+
+	err = rm.client.NetworkConnect(ctx, trimmedNetwork, registryContainer.ID, &network.EndpointSettings{})
+	if err != nil {
+		return started, fmt.Errorf("failed to connect registry %s to network %s: %w", name, trimmedNetwork, err)
+	}
+
+	return started, nil
+}
+
 // IsRegistryInUse checks if a registry is being used by any clusters.
 // A registry is considered in use if it exists and is running.
 func (rm *RegistryManager) IsRegistryInUse(ctx context.Context, name string) (bool, error) {
@@ -0,0 +1,170 @@
+package docker_test
+
+import (
+	"context"
+	"testing"
+
+	docker "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryManager_LifecycleAgainstFakeDaemon(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetwork("kind")
+
+	manager, err := docker.NewRegistryManager(fake.Client(t))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	config := docker.RegistryConfig{
+		Name:        "kind-docker.io",
+		Port:        5001,
+		UpstreamURL: "https://registry-1.docker.io",
+		ClusterName: "test-cluster",
+		NetworkName: "kind",
+	}
+
+	require.NoError(t, manager.CreateRegistry(ctx, config))
+
+	assert.True(t, fake.HasImage(docker.RegistryImageName), "registry image should be pulled")
+	assert.True(t, fake.HasVolume("docker.io"), "volume should use the normalized registry name")
+
+	state, exists := fake.ContainerState("kind-docker.io")
+	require.True(t, exists)
+	assert.Equal(t, "running", state)
+	assert.Equal(t, []string{"kind"}, fake.ContainerNetworks("kind-docker.io"))
+
+	// Creating again is idempotent.
+	require.NoError(t, manager.CreateRegistry(ctx, config))
+
+	registries, err := manager.ListRegistries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kind-docker.io"}, registries)
+
+	port, err := manager.GetRegistryPort(ctx, "kind-docker.io")
+	require.NoError(t, err)
+	assert.Equal(t, 5001, port)
+
+	inUse, err := manager.IsRegistryInUse(ctx, "kind-docker.io")
+	require.NoError(t, err)
+	assert.True(t, inUse)
+
+	require.NoError(t, manager.DeleteRegistry(ctx, "kind-docker.io", "test-cluster", true, "kind", ""))
+
+	_, exists = fake.ContainerState("kind-docker.io")
+	assert.False(t, exists, "registry container should be removed")
+	assert.False(t, fake.HasVolume("docker.io"), "registry volume should be removed")
+
+	registries, err = manager.ListRegistries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, registries)
+
+	err = manager.DeleteRegistry(ctx, "kind-docker.io", "test-cluster", false, "kind", "")
+	require.ErrorIs(t, err, docker.ErrRegistryNotFound)
+}
+
+func TestRegistryManager_KeepsRegistryAttachedToOtherClusters(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetwork("kind")
+	fake.AddNetwork("k3d-other")
+	fake.AddImage(docker.RegistryImageName)
+
+	manager, err := docker.NewRegistryManager(fake.Client(t))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	config := docker.RegistryConfig{Name: "shared", Port: 5002, NetworkName: "kind"}
+
+	require.NoError(t, manager.CreateRegistry(ctx, config))
+
+	dockerClient := fake.Client(t)
+	require.NoError(t, dockerClient.NetworkConnect(ctx, "k3d-other", "shared", nil))
+
+	require.NoError(t, manager.DeleteRegistry(ctx, "shared", "kind", true, "kind", ""))
+
+	state, exists := fake.ContainerState("shared")
+	require.True(t, exists, "registry still used by another cluster should be kept")
+	assert.Equal(t, "running", state)
+	assert.Equal(t, []string{"k3d-other"}, fake.ContainerNetworks("shared"))
+	assert.True(t, fake.HasVolume("shared"))
+}
+
+func TestRegistryManager_PauseAndResumeKeepVolumeAndNetwork(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetwork("k3d-dev")
+	fake.AddImage(docker.RegistryImageName)
+
+	manager, err := docker.NewRegistryManager(fake.Client(t))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, manager.CreateRegistry(ctx, docker.RegistryConfig{
+		Name:        "k3d-dev-docker.io",
+		Port:        5003,
+		NetworkName: "k3d-dev",
+	}))
+
+	paused, err := manager.PauseRegistry(ctx, "k3d-dev-docker.io", "k3d-dev")
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	state, exists := fake.ContainerState("k3d-dev-docker.io")
+	require.True(t, exists, "paused registry should be kept")
+	assert.Equal(t, "exited", state)
+	assert.True(t, fake.HasVolume("dev-docker.io"), "cache volume should be kept")
+
+	paused, err = manager.PauseRegistry(ctx, "k3d-dev-docker.io", "k3d-dev")
+	require.NoError(t, err)
+	assert.False(t, paused, "pausing a stopped registry is a no-op")
+
+	// The network was recreated while the registry was stopped.
+	require.NoError(t, fake.Client(t).NetworkDisconnect(ctx, "k3d-dev", "k3d-dev-docker.io", true))
+
+	resumed, err := manager.ResumeRegistry(ctx, "k3d-dev-docker.io", "k3d-dev")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+
+	state, _ = fake.ContainerState("k3d-dev-docker.io")
+	assert.Equal(t, "running", state)
+	assert.Equal(t, []string{"k3d-dev"}, fake.ContainerNetworks("k3d-dev-docker.io"))
+
+	_, err = manager.ResumeRegistry(ctx, "missing", "k3d-dev")
+	require.ErrorIs(t, err, docker.ErrRegistryNotFound)
+}
+
+func TestRegistryManager_PauseKeepsRegistrySharedWithOtherClusters(t *testing.T) {
+	t.Parallel()
+
+	fake := testutils.NewFakeDockerServer(t)
+	fake.AddNetwork("k3d-dev")
+	fake.AddNetwork("k3d-other")
+	fake.AddImage(docker.RegistryImageName)
+
+	manager, err := docker.NewRegistryManager(fake.Client(t))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, manager.CreateRegistry(ctx, docker.RegistryConfig{
+		Name:        "shared",
+		Port:        5004,
+		NetworkName: "k3d-dev",
+	}))
+	require.NoError(t, fake.Client(t).NetworkConnect(ctx, "k3d-other", "shared", nil))
+
+	paused, err := manager.PauseRegistry(ctx, "shared", "k3d-dev")
+	require.NoError(t, err)
+	assert.False(t, paused)
+
+	state, _ := fake.ContainerState("shared")
+	assert.Equal(t, "running", state)
+}
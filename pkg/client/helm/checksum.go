@@ -0,0 +1,215 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	helmclientlib "github.com/mittwald/go-helm-client"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// ChecksumVerifier checks the digest of a downloaded asset against a pinned value.
+type ChecksumVerifier interface {
+	Verify(asset, digest string) error
+}
+
+// DigestFunc computes the digest of the file at path.
+type DigestFunc func(path string) (string, error)
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithChecksums makes the client download remote charts itself, verify their archive digest
+// with verifier and install exactly the verified archive.
+func WithChecksums(verifier ChecksumVerifier, digest DigestFunc) ClientOption {
+	return func(c *Client) {
+		c.checksums = verifier
+		c.digest = digest
+	}
+}
+
+// ChartLocker pins the chart versions a project installs.
+type ChartLocker interface {
+	// ChartVersion returns the version of the chart from source to install, given the
+	// requested one, which is empty for the newest version.
+	ChartVersion(source, requested string) (string, error)
+	// RecordChart pins the version and archive digest the chart from source resolved to.
+	RecordChart(source, version, digest string) error
+}
+
+// WithChartLock makes the client install the chart versions pinned by locker and pin the
+// version and archive digest of every remote chart it downloads.
+func WithChartLock(locker ChartLocker, digest DigestFunc) ClientOption {
+	return func(c *Client) {
+		c.chartLock = locker
+		c.digest = digest
+	}
+}
+
+// lockedChartSpec returns spec with the version the chart lock pins for it. Local charts are
+// not locked.
+func (c *Client) lockedChartSpec(spec *ChartSpec) (*ChartSpec, error) {
+	if c.chartLock == nil {
+		return spec, nil
+	}
+
+	_, statErr := os.Stat(spec.ChartName)
+	if statErr == nil {
+		return spec, nil
+	}
+
+	source := chartSource(spec)
+
+	version, err := c.chartLock.ChartVersion(source, spec.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve locked version of chart %s: %w", source, err)
+	}
+
+	locked := *spec
+	locked.Version = version
+
+	return &locked, nil
+}
+
+// ResolveChart downloads the chart referenced by spec without installing it, so the chart
+// lock pins the version it resolves to.
+func (c *Client) ResolveChart(ctx context.Context, spec *ChartSpec) error {
+	_, cleanup, err := c.prepareChartSpec(ctx, spec, false)
+	if err != nil {
+		return err
+	}
+
+	cleanup()
+
+	return nil
+}
+
+// verifyChart downloads the chart referenced by chartSpec, verifies its digest, pins it in
+// the chart lock and points chartSpec at the downloaded archive. Local charts are not
+// downloaded and are left as is.
+func (c *Client) verifyChart(spec *ChartSpec, chartSpec *helmclientlib.ChartSpec) error {
+	if (c.checksums == nil && c.chartLock == nil) || c.digest == nil || c.chartMirror != "" {
+		return nil
+	}
+
+	_, statErr := os.Stat(chartSpec.ChartName)
+	if statErr == nil {
+		return nil
+	}
+
+	helmClient, err := c.concreteClient()
+	if err != nil {
+		return err
+	}
+
+	install := action.NewInstall(helmClient.ActionConfig)
+	install.Version = chartSpec.Version
+	install.Username = spec.Username
+	install.Password = spec.Password
+	install.CertFile = spec.CertFile
+	install.KeyFile = spec.KeyFile
+	install.CaFile = spec.CaFile
+	install.InsecureSkipTLSverify = spec.InsecureSkipTLSverify
+	install.PlainHTTP = spec.PlainHTTP
+	install.Keyring = spec.Keyring
+	install.Verify = spec.Keyring != ""
+
+	path, err := install.LocateChart(chartSpec.ChartName, helmClient.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to download chart %q: %w", chartSpec.ChartName, err)
+	}
+
+	chart, err := loader.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to load chart %q: %w", path, err)
+	}
+
+	digest, err := c.digest(path)
+	if err != nil {
+		return fmt.Errorf("failed to compute digest of chart %q: %w", path, err)
+	}
+
+	if c.checksums != nil {
+		asset := chartAssetName(spec, chart.Metadata.Name, chart.Metadata.Version)
+
+		err = c.checksums.Verify(asset, digest)
+		if err != nil {
+			return fmt.Errorf("failed to verify chart %s: %w", asset, err)
+		}
+	}
+
+	if c.chartLock != nil {
+		source := chartSource(spec)
+
+		err = c.chartLock.RecordChart(source, chart.Metadata.Version, digest)
+		if err != nil {
+			return fmt.Errorf("failed to lock chart %s: %w", source, err)
+		}
+	}
+
+	chartSpec.ChartName = path
+
+	return nil
+}
+
+// chartSource identifies a chart independently of its version, e.g.
+// "https://helm.cilium.io/cilium" or "oci://ghcr.io/org/charts/app".
+func chartSource(spec *ChartSpec) string {
+	switch {
+	case registry.IsOCI(spec.RepoURL):
+		return ociChartRef(spec.RepoURL, spec.ChartName)
+	case spec.RepoURL != "":
+		_, name := parseChartRef(spec.ChartName)
+
+		return strings.TrimSuffix(spec.RepoURL, "/") + "/" + name
+	case registry.IsOCI(spec.ChartName):
+		return trimOCITag(spec.ChartName)
+	default:
+		return spec.ChartName
+	}
+}
+
+// ChartSpecForSource returns a chart spec that installs the chart identified by source, as
+// recorded in a chart lock. Sources without a URL scheme, such as "gitea/gitea", name a
+// chart of a locally added repository.
+func ChartSpecForSource(source string) *ChartSpec {
+	index := strings.LastIndex(source, "/")
+	if registry.IsOCI(source) || !strings.Contains(source, "://") || index < 0 {
+		return &ChartSpec{ChartName: source}
+	}
+
+	return &ChartSpec{RepoURL: source[:index], ChartName: source[index+1:]}
+}
+
+// chartAssetName identifies a chart version by where it came from, e.g.
+// "https://helm.cilium.io/cilium@1.16.0" or "oci://ghcr.io/org/charts/app@1.0.0".
+func chartAssetName(spec *ChartSpec, name, version string) string {
+	source := spec.ChartName
+
+	switch {
+	case registry.IsOCI(spec.RepoURL):
+		source = ociChartRef(spec.RepoURL, spec.ChartName)
+	case spec.RepoURL != "":
+		source = strings.TrimSuffix(spec.RepoURL, "/") + "/" + name
+	case registry.IsOCI(spec.ChartName):
+		source = trimOCITag(spec.ChartName)
+	}
+
+	return source + "@" + version
+}
+
+// trimOCITag drops the tag or digest from an oci:// chart reference.
+func trimOCITag(ref string) string {
+	ref, _, _ = strings.Cut(ref, "@")
+
+	lastSlash := strings.LastIndex(ref, "/")
+	if colon := strings.LastIndex(ref, ":"); colon > lastSlash {
+		return ref[:colon]
+	}
+
+	return ref
+}
@@ -14,10 +14,13 @@ import (
 	"time"
 
 	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
 	helmclientlib "github.com/mittwald/go-helm-client"
 	valueslib "github.com/mittwald/go-helm-client/values"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
 )
@@ -43,6 +46,8 @@ var (
 // stderrCaptureMu protects process-wide stderr redirection from concurrent access.
 var stderrCaptureMu sync.Mutex //nolint:gochecknoglobals // global lock required to coordinate stderr interception
 
+var repositoryFileMu sync.Mutex //nolint:gochecknoglobals // global lock for the repository file all clients share
+
 // ChartSpec mirrors the mittwald chart specification while keeping KSail
 // specific convenience fields.
 type ChartSpec struct {
@@ -65,13 +70,23 @@ type ChartSpec struct {
 	SetFileVals map[string]string
 	SetJSONVals map[string]string
 
-	RepoURL               string
+	// RepoURL is an index-based repository URL or an oci:// registry path. When it is an
+	// OCI path, ChartName is resolved to RepoURL/ChartName. ChartName may also be a full
+	// oci:// reference on its own.
+	RepoURL string
+	// Username and Password authenticate against the chart repository or OCI registry.
+	// OCI pulls without them fall back to credentials stored by `helm registry login`.
 	Username              string
 	Password              string
 	CertFile              string
 	KeyFile               string
 	CaFile                string
 	InsecureSkipTLSverify bool
+	// PlainHTTP pulls OCI charts over HTTP, e.g. from a local registry.
+	PlainHTTP bool
+	// Keyring verifies the chart's provenance file against the given public keyring. It is
+	// only used when the client verifies checksums (see WithChecksums).
+	Keyring string
 }
 
 // RepositoryEntry describes a Helm repository that should be added locally
@@ -112,14 +127,28 @@ type Interface interface {
 
 // Client represents the default helm implementation used by KSail.
 type Client struct {
-	inner helmclientlib.Client
+	inner       helmclientlib.Client
+	checksums   ChecksumVerifier
+	chartLock   ChartLocker
+	digest      DigestFunc
+	chartMirror string
+	retry       k8s.RetryPolicy
 }
 
 var _ Interface = (*Client)(nil)
 
 // NewClient creates a Helm client using the provided kubeconfig and context.
-func NewClient(kubeConfig, kubeContext string) (*Client, error) {
-	return newClient(kubeConfig, kubeContext, nil)
+func NewClient(kubeConfig, kubeContext string, opts ...ClientOption) (*Client, error) {
+	client, err := newClient(kubeConfig, kubeContext, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
 }
 
 // NewClientWithDebug creates a Helm client with a custom debug logger.
@@ -258,7 +287,8 @@ func (c *Client) InstallOrUpgradeChart(ctx context.Context, spec *ChartSpec) (*R
 	return c.installRelease(ctx, spec, true)
 }
 
-// UninstallRelease removes a Helm release by name within the provided namespace.
+// UninstallRelease removes a Helm release by name within the provided namespace. A release that
+// is not installed is not an error, so uninstalls can be repeated.
 func (c *Client) UninstallRelease(ctx context.Context, releaseName, namespace string) error {
 	if releaseName == "" {
 		return errReleaseNameRequired
@@ -276,8 +306,9 @@ func (c *Client) UninstallRelease(ctx context.Context, releaseName, namespace st
 	defer cleanup()
 
 	chartSpec := &helmclientlib.ChartSpec{
-		ReleaseName: releaseName,
-		Namespace:   namespace,
+		ReleaseName:    releaseName,
+		Namespace:      namespace,
+		IgnoreNotFound: true,
 	}
 
 	uninstallErr := c.inner.UninstallRelease(chartSpec)
@@ -290,11 +321,28 @@ func (c *Client) UninstallRelease(ctx context.Context, releaseName, namespace st
 
 // AddRepository registers a Helm repository for the current client instance.
 func (c *Client) AddRepository(ctx context.Context, entry *RepositoryEntry) error {
+	return c.withRetry(ctx, func(ctx context.Context) error {
+		return c.addRepository(ctx, entry)
+	})
+}
+
+func (c *Client) addRepository(ctx context.Context, entry *RepositoryEntry) error {
 	requestErr := validateRepositoryRequest(ctx, entry)
 	if requestErr != nil {
 		return requestErr
 	}
 
+	// OCI registries have no index to download; charts are pulled by reference instead.
+	// Charts of a chart mirror are installed without the upstream repositories.
+	if registry.IsOCI(entry.URL) || c.chartMirror != "" {
+		return nil
+	}
+
+	offlineErr := offline.GuardURL(ctx, entry.URL, "fetch the index of Helm repository %s (%s)", entry.Name, entry.URL)
+	if offlineErr != nil {
+		return offlineErr
+	}
+
 	settings := c.inner.GetSettings()
 
 	repoFile, err := ensureRepositoryConfig(settings)
@@ -302,7 +350,6 @@ func (c *Client) AddRepository(ctx context.Context, entry *RepositoryEntry) erro
 		return err
 	}
 
-	repositoryFile := loadOrInitRepositoryFile(repoFile)
 	repoEntry := convertRepositoryEntry(entry)
 
 	repoCache, err := ensureRepositoryCache(settings)
@@ -320,6 +367,11 @@ func (c *Client) AddRepository(ctx context.Context, entry *RepositoryEntry) erro
 		return downloadErr
 	}
 
+	// Clients installing concurrently share the file, so it is read and written back as one step
+	repositoryFileMu.Lock()
+	defer repositoryFileMu.Unlock()
+
+	repositoryFile := loadOrInitRepositoryFile(repoFile)
 	repositoryFile.Update(repoEntry)
 
 	writeErr := repositoryFile.WriteFile(repoFile, repoFileMode)
@@ -446,18 +498,28 @@ func (c *Client) installRelease(
 	spec *ChartSpec,
 	upgrade bool,
 ) (*ReleaseInfo, error) {
-	return c.executeReleaseOp(
-		ctx,
-		spec,
-		true,
-		func(ctx context.Context, chartSpec *helmclientlib.ChartSpec) (*release.Release, error) {
-			if upgrade {
-				return c.inner.InstallOrUpgradeChart(ctx, chartSpec, nil)
-			}
+	var info *ReleaseInfo
+
+	err := c.withRetry(ctx, func(ctx context.Context) error {
+		var err error
+
+		info, err = c.executeReleaseOp(
+			ctx,
+			spec,
+			true,
+			func(ctx context.Context, chartSpec *helmclientlib.ChartSpec) (*release.Release, error) {
+				if upgrade {
+					return c.inner.InstallOrUpgradeChart(ctx, chartSpec, nil)
+				}
+
+				return c.inner.InstallChart(ctx, chartSpec, nil)
+			},
+		)
 
-			return c.inner.InstallChart(ctx, chartSpec, nil)
-		},
-	)
+		return err
+	})
+
+	return info, err
 }
 
 func (c *Client) switchNamespace(namespace string) (func(), error) {
@@ -513,7 +575,7 @@ func (c *Client) executeReleaseOp(
 	applyDefaultTimeout bool,
 	operation func(context.Context, *helmclientlib.ChartSpec) (*release.Release, error),
 ) (*ReleaseInfo, error) {
-	chartSpec, cleanup, err := c.prepareChartSpec(spec, applyDefaultTimeout)
+	chartSpec, cleanup, err := c.prepareChartSpec(ctx, spec, applyDefaultTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -538,6 +600,7 @@ func (c *Client) executeReleaseOp(
 }
 
 func (c *Client) prepareChartSpec(
+	ctx context.Context,
 	spec *ChartSpec,
 	applyDefaultTimeout bool,
 ) (*helmclientlib.ChartSpec, func(), error) {
@@ -545,6 +608,21 @@ func (c *Client) prepareChartSpec(
 		return nil, nil, errChartSpecRequired
 	}
 
+	spec, err := c.lockedChartSpec(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spec, err = c.mirroredChartSpec(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offlineErr := guardChartDownload(ctx, spec)
+	if offlineErr != nil {
+		return nil, nil, offlineErr
+	}
+
 	chartSpec := convertChartSpec(spec)
 	if applyDefaultTimeout && chartSpec.Timeout == 0 {
 		chartSpec.Timeout = DefaultTimeout
@@ -555,12 +633,53 @@ func (c *Client) prepareChartSpec(
 		return nil, nil, ensureErr
 	}
 
-	cleanup, err := c.switchNamespace(chartSpec.Namespace)
+	restoreRegistry, err := c.useRegistryClient(spec, chartSpec.ChartName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = c.verifyChart(spec, chartSpec)
 	if err != nil {
+		restoreRegistry()
+
+		return nil, nil, err
+	}
+
+	restoreNamespace, err := c.switchNamespace(chartSpec.Namespace)
+	if err != nil {
+		restoreRegistry()
+
 		return nil, nil, err
 	}
 
-	return chartSpec, cleanup, nil
+	return chartSpec, func() {
+		restoreNamespace()
+		restoreRegistry()
+	}, nil
+}
+
+// guardChartDownload fails offline runs that would download the chart, i.e. every chart that
+// is neither a local path nor served by the local host.
+func guardChartDownload(ctx context.Context, spec *ChartSpec) error {
+	if !offline.Enabled(ctx) {
+		return nil
+	}
+
+	location := spec.RepoURL
+	if location == "" {
+		_, statErr := os.Stat(spec.ChartName)
+		if statErr == nil {
+			return nil
+		}
+
+		location = spec.ChartName
+	}
+
+	if strings.Contains(location, "://") && !offline.IsRemote(location) {
+		return nil
+	}
+
+	return offline.Guard(ctx, "download chart %s", chartSource(spec))
 }
 
 func (c *Client) ensureRepository(spec *ChartSpec, chartSpec *helmclientlib.ChartSpec) error {
@@ -568,6 +687,12 @@ func (c *Client) ensureRepository(spec *ChartSpec, chartSpec *helmclientlib.Char
 		return nil
 	}
 
+	if registry.IsOCI(spec.RepoURL) {
+		chartSpec.ChartName = ociChartRef(spec.RepoURL, spec.ChartName)
+
+		return nil
+	}
+
 	_, chartName := parseChartRef(spec.ChartName)
 
 	if chartName == "" {
@@ -4,10 +4,20 @@ package helm
 type RepoConfig struct {
 	// Name is the repository identifier used in Helm commands.
 	Name string
-	// URL is the Helm repository URL.
+	// URL is the Helm repository URL, or an oci:// registry path that serves the chart by name.
 	URL string
 	// RepoName is the human-readable name used in error messages.
 	RepoName string
+	// Username and Password authenticate against the repository or OCI registry. OCI pulls
+	// without them fall back to credentials stored by `helm registry login`.
+	Username string
+	Password string
+	// CaFile verifies the TLS certificate of the repository against a CA bundle.
+	CaFile string
+	// InsecureSkipTLSverify skips verification of the TLS certificate of the repository.
+	InsecureSkipTLSverify bool
+	// PlainHTTP pulls OCI charts over HTTP, e.g. from a registry without TLS.
+	PlainHTTP bool
 }
 
 // ChartConfig holds chart installation configuration.
@@ -18,10 +28,15 @@ type ChartConfig struct {
 	ChartName string
 	// Namespace is the Kubernetes namespace for installation.
 	Namespace string
-	// RepoURL is the Helm repository URL.
+	// RepoURL is the Helm repository URL or oci:// registry path, normally the URL of the
+	// RepoConfig the chart is installed with.
 	RepoURL string
+	// Version is the chart version to install. Empty installs the newest version.
+	Version string
 	// CreateNamespace determines if the namespace should be created.
 	CreateNamespace bool
+	// ValuesYaml contains the chart values as a YAML document.
+	ValuesYaml string
 	// SetJSONVals contains JSON values to set during installation.
 	SetJSONVals map[string]string
 }
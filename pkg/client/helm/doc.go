@@ -2,4 +2,10 @@
 //
 // This package wraps the Helm Go SDK and provides utilities for managing Helm
 // charts, repositories, and releases, including installation and repository management.
+// Charts can come from index-based repositories or from OCI registries (oci:// references),
+// authenticated with the chart spec's credentials or those stored by `helm registry login`.
+// With WithChecksums, remote charts are downloaded first, verified against pinned digests
+// (and provenance, when a keyring is given) and installed from the verified archive. With
+// WithChartLock, charts are installed at the versions a lock pins, and the version and
+// archive digest of every downloaded chart are pinned in it.
 package helm
@@ -0,0 +1,135 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+var errChartNotMirrored = errors.New("helm: chart not found in chart mirror")
+
+// WithChartMirror makes the client install every chart from mirror instead of its upstream
+// repository, e.g. on networks without internet access. The mirror is either an oci://
+// registry path that serves the charts by name, or a directory of chart archives as written
+// by `helm pull` or `helm package`. Repositories are not added while a mirror is set, and
+// mirrored charts are not verified against checksums or pinned in the chart lock. An empty
+// mirror leaves the client unchanged.
+func WithChartMirror(mirror string) ClientOption {
+	return func(c *Client) {
+		c.chartMirror = mirror
+	}
+}
+
+// mirroredChartSpec returns spec pointing at the copy of its chart in the chart mirror.
+// Local charts are installed as is.
+func (c *Client) mirroredChartSpec(spec *ChartSpec) (*ChartSpec, error) {
+	if c.chartMirror == "" {
+		return spec, nil
+	}
+
+	_, statErr := os.Stat(spec.ChartName)
+	if statErr == nil {
+		return spec, nil
+	}
+
+	name := mirroredChartName(spec)
+
+	// The credentials and TLS settings of the upstream repository do not apply to the mirror.
+	mirrored := *spec
+	mirrored.RepoURL = ""
+	mirrored.Username = ""
+	mirrored.Password = ""
+	mirrored.CertFile = ""
+	mirrored.KeyFile = ""
+	mirrored.CaFile = ""
+	mirrored.InsecureSkipTLSverify = false
+	mirrored.Keyring = ""
+
+	if registry.IsOCI(c.chartMirror) {
+		mirrored.RepoURL = c.chartMirror
+		mirrored.ChartName = name
+		// Local registries, such as the one KSail runs next to the cluster, serve plain HTTP.
+		mirrored.PlainHTTP = !offline.IsRemote(c.chartMirror)
+
+		return &mirrored, nil
+	}
+
+	archive, err := findChartArchive(c.chartMirror, name, spec.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrored.ChartName = archive
+	mirrored.Version = ""
+
+	return &mirrored, nil
+}
+
+// mirroredChartName returns the name of the chart spec installs, without the repository
+// prefix or OCI registry path, e.g. "cilium" for "cilium/cilium".
+func mirroredChartName(spec *ChartSpec) string {
+	if registry.IsOCI(spec.ChartName) {
+		ref := trimOCITag(spec.ChartName)
+
+		return ref[strings.LastIndex(ref, "/")+1:]
+	}
+
+	_, name := parseChartRef(spec.ChartName)
+	if name == "" {
+		return spec.ChartName
+	}
+
+	return name
+}
+
+// findChartArchive returns the path of the newest <name>-<version>.tgz archive in dir whose
+// version satisfies the requested version or constraint. An empty request matches every
+// stable version.
+func findChartArchive(dir, name, requested string) (string, error) {
+	constraint := "*"
+	if requested != "" {
+		constraint = requested
+	}
+
+	versions, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q of chart %s: %w", requested, name, err)
+	}
+
+	archives, err := filepath.Glob(filepath.Join(dir, name+"-*.tgz"))
+	if err != nil {
+		return "", fmt.Errorf("list chart archives in %s: %w", dir, err)
+	}
+
+	var (
+		newest     *semver.Version
+		newestPath string
+	)
+
+	for _, archive := range archives {
+		raw := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(archive), name+"-"), ".tgz")
+
+		// Archives of other charts that share the prefix, e.g. cilium-operator, do not parse.
+		version, parseErr := semver.NewVersion(raw)
+		if parseErr != nil || !versions.Check(version) {
+			continue
+		}
+
+		if newest == nil || version.GreaterThan(newest) {
+			newest = version
+			newestPath = archive
+		}
+	}
+
+	if newest == nil {
+		return "", fmt.Errorf("%w: no archive of %s %s in %s", errChartNotMirrored, name, constraint, dir)
+	}
+
+	return newestPath, nil
+}
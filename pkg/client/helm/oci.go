@@ -0,0 +1,80 @@
+package helm
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// ociChartRef joins an oci:// repository URL and a chart name into a chart reference.
+// Chart names may carry the "repo/" prefix used for index-based repositories.
+func ociChartRef(repoURL, chartName string) string {
+	_, name := parseChartRef(chartName)
+	if name == "" {
+		name = chartName
+	}
+
+	return strings.TrimSuffix(repoURL, "/") + "/" + name
+}
+
+// needsRegistryClient reports whether pulling an OCI chart requires a registry client other
+// than the default one, which only knows credentials stored by `helm registry login`.
+func needsRegistryClient(spec *ChartSpec) bool {
+	return spec.Username != "" || spec.PlainHTTP || spec.InsecureSkipTLSverify
+}
+
+// useRegistryClient swaps in a registry client authenticated with the credentials and
+// transport settings of spec for the duration of a release operation on an OCI chart. The
+// returned function restores the previous client.
+func (c *Client) useRegistryClient(spec *ChartSpec, chartName string) (func(), error) {
+	if !registry.IsOCI(chartName) || !needsRegistryClient(spec) {
+		return func() {}, nil
+	}
+
+	helmClient, err := c.concreteClient()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []registry.ClientOption{
+		registry.ClientOptDebug(helmClient.Settings.Debug),
+		registry.ClientOptCredentialsFile(helmClient.Settings.RegistryConfig),
+	}
+
+	if spec.Username != "" {
+		options = append(options, registry.ClientOptBasicAuth(spec.Username, spec.Password))
+	}
+
+	if spec.PlainHTTP {
+		options = append(options, registry.ClientOptPlainHTTP())
+	}
+
+	if spec.InsecureSkipTLSverify {
+		options = append(options, registry.ClientOptHTTPClient(insecureHTTPClient()))
+	}
+
+	registryClient, err := registry.NewClient(options...)
+	if err != nil {
+		return nil, fmt.Errorf("create registry client for %s: %w", chartName, err)
+	}
+
+	previous := helmClient.ActionConfig.RegistryClient
+	helmClient.ActionConfig.RegistryClient = registryClient
+
+	return func() {
+		helmClient.ActionConfig.RegistryClient = previous
+	}, nil
+}
+
+// insecureHTTPClient returns an HTTP client that skips TLS verification, for registries the
+// chart spec explicitly marks as insecure.
+func insecureHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // always *http.Transport
+	//nolint:gosec // Verification is skipped only when InsecureSkipTLSverify is requested.
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	return &http.Client{Transport: transport}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"helm.sh/helm/v3/pkg/registry"
 )
 
-// InstallOrUpgradeChart performs a Helm install or upgrade operation.
+// InstallOrUpgradeChart performs a Helm install or upgrade operation. Index-based repositories
+// are added first; charts of oci:// registries are pulled directly, authenticated with the
+// credentials of repoConfig.
 func InstallOrUpgradeChart(
 	ctx context.Context,
 	client Interface,
@@ -14,14 +18,20 @@ func InstallOrUpgradeChart(
 	chartConfig ChartConfig,
 	timeout time.Duration,
 ) error {
-	repoEntry := &RepositoryEntry{
-		Name: repoConfig.Name,
-		URL:  repoConfig.URL,
-	}
+	if !registry.IsOCI(repoConfig.URL) {
+		repoEntry := &RepositoryEntry{
+			Name:                  repoConfig.Name,
+			URL:                   repoConfig.URL,
+			Username:              repoConfig.Username,
+			Password:              repoConfig.Password,
+			CaFile:                repoConfig.CaFile,
+			InsecureSkipTLSverify: repoConfig.InsecureSkipTLSverify,
+		}
 
-	addRepoErr := client.AddRepository(ctx, repoEntry)
-	if addRepoErr != nil {
-		return fmt.Errorf("failed to add %s repository: %w", repoConfig.RepoName, addRepoErr)
+		addRepoErr := client.AddRepository(ctx, repoEntry)
+		if addRepoErr != nil {
+			return fmt.Errorf("failed to add %s repository: %w", repoConfig.RepoName, addRepoErr)
+		}
 	}
 
 	spec := &ChartSpec{
@@ -29,6 +39,7 @@ func InstallOrUpgradeChart(
 		ChartName:       chartConfig.ChartName,
 		Namespace:       chartConfig.Namespace,
 		RepoURL:         chartConfig.RepoURL,
+		Version:         chartConfig.Version,
 		CreateNamespace: chartConfig.CreateNamespace,
 		Atomic:          true,
 		Silent:          true,
@@ -36,7 +47,14 @@ func InstallOrUpgradeChart(
 		Timeout:         timeout,
 		Wait:            true,
 		WaitForJobs:     true,
+		ValuesYaml:      chartConfig.ValuesYaml,
 		SetJSONVals:     chartConfig.SetJSONVals,
+
+		Username:              repoConfig.Username,
+		Password:              repoConfig.Password,
+		CaFile:                repoConfig.CaFile,
+		InsecureSkipTLSverify: repoConfig.InsecureSkipTLSverify,
+		PlainHTTP:             repoConfig.PlainHTTP,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -0,0 +1,104 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	renderDirMode  = 0o750
+	renderFileMode = 0o600
+)
+
+// TemplateChart renders the manifests of the chart referenced by spec without contacting a
+// cluster, like helm template. Chart locks, mirrors and checksums apply as they do to installs.
+func (c *Client) TemplateChart(ctx context.Context, spec *ChartSpec) (string, error) {
+	chartSpec, cleanup, err := c.prepareChartSpec(ctx, spec, false)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	manifests, err := c.inner.TemplateChart(chartSpec, nil)
+	if err != nil {
+		return "", fmt.Errorf("template chart %s: %w", spec.ChartName, err)
+	}
+
+	return string(manifests), nil
+}
+
+// Renderer is an Interface that renders charts into a directory instead of installing them.
+// Installers handed a Renderer write the manifests they would install, for dry runs.
+type Renderer struct {
+	client *Client
+	dir    string
+}
+
+var _ Interface = (*Renderer)(nil)
+
+// NewRenderer creates a Renderer that templates charts with client and writes every release
+// to <dir>/<namespace>/<release>.yaml.
+func NewRenderer(client *Client, dir string) *Renderer {
+	return &Renderer{client: client, dir: dir}
+}
+
+// InstallChart renders the chart instead of installing it.
+func (r *Renderer) InstallChart(ctx context.Context, spec *ChartSpec) (*ReleaseInfo, error) {
+	return r.render(ctx, spec)
+}
+
+// InstallOrUpgradeChart renders the chart instead of installing or upgrading it.
+func (r *Renderer) InstallOrUpgradeChart(ctx context.Context, spec *ChartSpec) (*ReleaseInfo, error) {
+	return r.render(ctx, spec)
+}
+
+// UninstallRelease does nothing, as nothing was installed.
+func (r *Renderer) UninstallRelease(context.Context, string, string) error {
+	return nil
+}
+
+// AddRepository adds the repository, which templating downloads charts from.
+func (r *Renderer) AddRepository(ctx context.Context, entry *RepositoryEntry) error {
+	return r.client.AddRepository(ctx, entry)
+}
+
+func (r *Renderer) render(ctx context.Context, spec *ChartSpec) (*ReleaseInfo, error) {
+	if spec == nil {
+		return nil, errChartSpecRequired
+	}
+
+	if spec.ReleaseName == "" {
+		return nil, errReleaseNameRequired
+	}
+
+	manifests, err := r.client.TemplateChart(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	path := filepath.Join(r.dir, namespace, spec.ReleaseName+".yaml")
+
+	err = os.MkdirAll(filepath.Dir(path), renderDirMode)
+	if err != nil {
+		return nil, fmt.Errorf("create render directory: %w", err)
+	}
+
+	err = os.WriteFile(path, []byte(manifests), renderFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("write rendered release %s: %w", spec.ReleaseName, err)
+	}
+
+	return &ReleaseInfo{
+		Name:      spec.ReleaseName,
+		Namespace: namespace,
+		Status:    "rendered",
+		Chart:     spec.ChartName,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package helm
+
+import (
+	"context"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+)
+
+// WithRetry makes the client retry installs and repository index downloads that fail with a
+// transient error, such as a registry rate limit or a restarting API server, following policy.
+// Without it every operation is tried once.
+func WithRetry(policy k8s.RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// withRetry runs operation under the client's retry policy.
+func (c *Client) withRetry(ctx context.Context, operation func(context.Context) error) error {
+	//nolint:wrapcheck // Errors of the operation are returned as the operation wrapped them.
+	return k8s.Retry(ctx, c.retry, operation)
+}
@@ -0,0 +1,56 @@
+package helm
+
+import (
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MergeValuesYAML deep-merges YAML values documents into a single document. Later documents
+// take precedence: nested maps are merged key by key, and any other value replaces the value
+// of earlier documents. Empty documents are skipped.
+func MergeValuesYAML(documents ...string) (string, error) {
+	merged := map[string]any{}
+
+	for i, document := range documents {
+		values := map[string]any{}
+
+		err := yaml.Unmarshal([]byte(document), &values)
+		if err != nil {
+			return "", fmt.Errorf("parse values document %d: %w", i+1, err)
+		}
+
+		merged = mergeValues(merged, values)
+	}
+
+	if len(merged) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("marshal merged values: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func mergeValues(base, overrides map[string]any) map[string]any {
+	out := maps.Clone(base)
+
+	for key, override := range overrides {
+		overrideMap, overrideIsMap := override.(map[string]any)
+		baseMap, baseIsMap := out[key].(map[string]any)
+
+		if overrideIsMap && baseIsMap {
+			out[key] = mergeValues(baseMap, overrideMap)
+
+			continue
+		}
+
+		out[key] = override
+	}
+
+	return out
+}
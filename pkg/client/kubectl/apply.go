@@ -0,0 +1,351 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/kubectl/pkg/cmd/apply"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	applyBaseName     = "ksail workload"
+	skipUnchangedFlag = "skip-unchanged"
+	stdinFilename     = "-"
+	// clusterIdentityNamespace is a namespace every cluster has; its UID identifies the cluster,
+	// so a recreated cluster with the same context name starts with an empty cache.
+	clusterIdentityNamespace = "kube-system"
+)
+
+// ErrNoObjectsToApply is returned when the given manifests contain no objects.
+var ErrNoObjectsToApply = errors.New("no objects passed to apply")
+
+// ApplyCache remembers the content hash of the manifests last applied to each cluster.
+type ApplyCache interface {
+	Unchanged(cluster, key, hash string) (bool, error)
+	Record(cluster, key, hash string) error
+	Save() error
+}
+
+// HashFunc computes the content hash of a rendered manifest.
+type HashFunc func(manifest []byte) string
+
+// WithApplyCache lets apply commands skip, with --skip-unchanged, resources whose rendered
+// manifest has not changed since it was last applied to the same cluster.
+func WithApplyCache(cache ApplyCache, hash HashFunc) ClientOption {
+	return func(c *Client) {
+		c.applyCache = cache
+		c.applyHash = hash
+	}
+}
+
+// newCachedApplyCommand builds kubectl apply with an opt-in --skip-unchanged flag backed by
+// the client's apply cache.
+func (c *Client) newCachedApplyCommand(factory cmdutil.Factory) *cobra.Command {
+	base := apply.NewCmdApply(applyBaseName, factory, c.ioStreams)
+	flags := apply.NewApplyFlags(c.ioStreams)
+
+	applyCmd := &cobra.Command{
+		Use:                   base.Use,
+		DisableFlagsInUseLine: true,
+		Short:                 base.Short,
+		Long:                  base.Long,
+		Example:               base.Example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runCachedApply(cmd, factory, flags, args)
+		},
+	}
+
+	flags.AddFlags(applyCmd)
+	applyCmd.Flags().Bool(
+		skipUnchangedFlag,
+		false,
+		"Skip resources whose manifest is unchanged since KSail last applied it to this cluster "+
+			"and whose live object has not changed since. Ignored with --prune, --applyset and --dry-run",
+	)
+
+	for _, sub := range base.Commands() {
+		base.RemoveCommand(sub)
+		applyCmd.AddCommand(sub)
+	}
+
+	return applyCmd
+}
+
+func (c *Client) runCachedApply(
+	cmd *cobra.Command,
+	factory cmdutil.Factory,
+	flags *apply.ApplyFlags,
+	args []string,
+) error {
+	opts, err := flags.ToOptions(factory, cmd, applyBaseName, args)
+	if err != nil {
+		return fmt.Errorf("failed to prepare apply: %w", err)
+	}
+
+	err = opts.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid apply options: %w", err)
+	}
+
+	for _, filename := range opts.DeleteOptions.FilenameOptions.Filenames {
+		err = offline.GuardURL(cmd.Context(), filename, "fetch manifest %s", filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Pruning and list output need the complete object set, so only then is it loaded at once.
+	if opts.Prune || opts.ApplySet != nil || printsObjectList(opts) {
+		err = runApply(opts)
+		if err != nil {
+			return err
+		}
+
+		objects, _ := opts.GetObjects()
+		for _, info := range objects {
+			audit.AddResources(cmd.Context(), []string{resourceKey(info)})
+		}
+
+		return nil
+	}
+
+	cluster := ""
+
+	skipUnchanged, _ := cmd.Flags().GetBool(skipUnchangedFlag)
+	if skipUnchanged && opts.DryRunStrategy == cmdutil.DryRunNone {
+		// Without a stable cluster identity the cache cannot be scoped, so apply everything.
+		cluster, _ = clusterIdentity(cmd.Context(), factory)
+	}
+
+	return c.streamApply(cmd.Context(), opts, cluster)
+}
+
+// streamApply decodes and applies one document at a time from files, directories, URLs or
+// stdin, so memory use does not grow with the size of the manifest set. Unchanged resources
+// are skipped when a cluster identity is given.
+func (c *Client) streamApply(ctx context.Context, opts *apply.ApplyOptions, cluster string) error {
+	printer, err := opts.ToPrinter("unchanged")
+	if err != nil {
+		return fmt.Errorf("failed to create printer: %w", err)
+	}
+
+	visited := 0
+	recorded := false
+
+	err = c.buildApplyResult(opts).
+		Visit(func(info *resource.Info, err error) error {
+			if err != nil {
+				return err
+			}
+
+			visited++
+
+			hashed, err := c.applyStreamed(ctx, opts, printer, cluster, info)
+			recorded = recorded || hashed
+
+			return err
+		})
+
+	if recorded {
+		saveErr := c.applyCache.Save()
+		if saveErr != nil {
+			return fmt.Errorf("failed to save apply cache: %w", saveErr)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("apply failed: %w", err)
+	}
+
+	if visited == 0 {
+		return ErrNoObjectsToApply
+	}
+
+	return nil
+}
+
+// buildApplyResult mirrors how kubectl apply resolves its objects, without collecting them.
+func (c *Client) buildApplyResult(opts *apply.ApplyOptions) *resource.Result {
+	filenames := opts.DeleteOptions.FilenameOptions
+	filenames.Filenames = slices.DeleteFunc(slices.Clone(filenames.Filenames), func(name string) bool {
+		return name == stdinFilename
+	})
+
+	builder := opts.Builder.
+		Unstructured().
+		Schema(opts.Validator).
+		ContinueOnError().
+		NamespaceParam(opts.Namespace).DefaultNamespace()
+
+	// kubectl reads "-" from os.Stdin; read it from the client's input stream instead.
+	if len(filenames.Filenames) != len(opts.DeleteOptions.FilenameOptions.Filenames) {
+		builder = builder.Stream(c.ioStreams.In, "STDIN")
+	}
+
+	return builder.
+		FilenameParam(opts.EnforceNamespace, &filenames).
+		LabelSelectorParam(opts.Selector).
+		Flatten().
+		Do()
+}
+
+// applyStreamed applies a single resource unless the cache shows it is unchanged, and reports
+// whether its hash was recorded in the cache. A resource is only unchanged when both its
+// manifest and its live object match what was recorded, so objects deleted or edited outside
+// KSail are applied again. Applied resources are added to the audit entry
+// of the run.
+func (c *Client) applyStreamed(
+	ctx context.Context,
+	opts *apply.ApplyOptions,
+	printer printers.ResourcePrinter,
+	cluster string,
+	info *resource.Info,
+) (bool, error) {
+	key := resourceKey(info)
+
+	if cluster == "" {
+		opts.SetObjects([]*resource.Info{info})
+
+		err := opts.Run()
+		if err != nil {
+			return false, err
+		}
+
+		audit.AddResources(ctx, []string{key})
+
+		return false, nil
+	}
+
+	manifest, err := json.Marshal(info.Object)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	// The apply mode changes what ends up in the cluster, so it is part of the hash.
+	mode := fmt.Sprintf("server-side=%t;field-manager=%s\n", opts.ServerSideApply, opts.FieldManager)
+	hash := c.applyHash(append([]byte(mode), manifest...))
+
+	live, err := liveState(info)
+	if err != nil {
+		return false, err
+	}
+
+	unchanged, err := c.applyCache.Unchanged(cluster, key, hash+"@"+live)
+	if err != nil {
+		return false, fmt.Errorf("failed to read apply cache: %w", err)
+	}
+
+	if unchanged {
+		err = printer.PrintObj(info.Object, opts.Out)
+		if err != nil {
+			return false, fmt.Errorf("failed to print %s: %w", key, err)
+		}
+
+		return false, nil
+	}
+
+	opts.SetObjects([]*resource.Info{info})
+
+	err = opts.Run()
+	if err != nil {
+		return false, err
+	}
+
+	audit.AddResources(ctx, []string{key})
+
+	// Apply refreshes info with the object the cluster returned, which is what the next
+	// apply compares the live object with.
+	err = c.applyCache.Record(cluster, key, hash+"@"+objectState(info.Object))
+	if err != nil {
+		return false, fmt.Errorf("failed to record applied manifest: %w", err)
+	}
+
+	return true, nil
+}
+
+// liveState returns the state of the live object of info as objectState describes it, or an
+// empty state when the object does not exist.
+func liveState(info *resource.Info) (string, error) {
+	live, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get live %s: %w", resourceKey(info), err)
+	}
+
+	return objectState(live), nil
+}
+
+// objectState identifies the revision of obj that matters for the apply cache: its generation
+// when the object tracks one, which ignores status updates, and otherwise its resourceVersion.
+func objectState(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+
+	generation := accessor.GetGeneration()
+	if generation > 0 {
+		return fmt.Sprintf("generation=%d", generation)
+	}
+
+	return "resourceVersion=" + accessor.GetResourceVersion()
+}
+
+// printsObjectList reports whether apply prints the applied objects as one list at the end.
+func printsObjectList(opts *apply.ApplyOptions) bool {
+	if opts.PrintFlags == nil || opts.PrintFlags.OutputFormat == nil {
+		return false
+	}
+
+	output := *opts.PrintFlags.OutputFormat
+
+	return output != "" && output != "name"
+}
+
+func runApply(opts *apply.ApplyOptions) error {
+	err := opts.Run()
+	if err != nil {
+		return fmt.Errorf("apply failed: %w", err)
+	}
+
+	return nil
+}
+
+func resourceKey(info *resource.Info) string {
+	gvk := info.Object.GetObjectKind().GroupVersionKind()
+	if info.Mapping != nil {
+		gvk = info.Mapping.GroupVersionKind
+	}
+
+	return gvk.Group + "/" + gvk.Kind + "/" + info.Namespace + "/" + info.Name
+}
+
+func clusterIdentity(ctx context.Context, factory cmdutil.Factory) (string, error) {
+	clientset, err := factory.KubernetesClientSet()
+	if err != nil {
+		return "", fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, clusterIdentityNamespace, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to identify cluster: %w", err)
+	}
+
+	return string(namespace.UID), nil
+}
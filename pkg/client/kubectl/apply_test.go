@@ -0,0 +1,177 @@
+package kubectl_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
+	"github.com/devantler-tech/ksail-go/pkg/svc/applycache"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+const unreachableKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`
+
+func TestCreateApplyCommandWithApplyCacheHasSkipUnchangedFlag(t *testing.T) {
+	t.Parallel()
+
+	store := applycache.NewStore(filepath.Join(t.TempDir(), "apply-cache.json"))
+	client := kubectl.NewClient(createTestIOStreams(), kubectl.WithApplyCache(store, applycache.Hash))
+
+	cmd := client.CreateApplyCommand("/path/to/kubeconfig")
+
+	flag := cmd.Flags().Lookup("skip-unchanged")
+	require.NotNil(t, flag, "expected --skip-unchanged flag to be present")
+	assert.Equal(t, "false", flag.DefValue)
+	require.NotNil(t, cmd.Flags().Lookup("filename"), "expected kubectl apply flags to be kept")
+	assert.Equal(t, "apply", cmd.Use)
+	assert.Len(t, cmd.Commands(), 3, "expected the last-applied subcommands to be kept")
+}
+
+func TestCachedApplySkipsUnchangedResources(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "configmap.yaml")
+	cachePath := filepath.Join(dir, ".ksail", "apply-cache.json")
+
+	writeConfigMap := func(value string) {
+		t.Helper()
+
+		content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n  namespace: default\n" +
+			"data:\n  key: " + value + "\n"
+		require.NoError(t, os.WriteFile(manifest, []byte(content), 0o600))
+	}
+
+	apply := func() string {
+		t.Helper()
+
+		var out bytes.Buffer
+
+		client := kubectl.NewClient(
+			genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: &out, ErrOut: &out},
+			kubectl.WithPluginMode(false),
+			kubectl.WithApplyCache(applycache.NewStore(cachePath), applycache.Hash),
+		)
+		cmd := client.CreateApplyCommand(server.KubeconfigPath)
+		cmd.SetArgs([]string{"-f", manifest, "--skip-unchanged"})
+		require.NoError(t, cmd.Execute())
+
+		return out.String()
+	}
+
+	writeConfigMap("one")
+	assert.Contains(t, apply(), "configmap/app created")
+	assert.FileExists(t, cachePath)
+	assert.Contains(t, apply(), "configmap/app unchanged")
+
+	writeConfigMap("two")
+	assert.Contains(t, apply(), "configmap/app configured")
+}
+
+func TestCachedApplyRecreatesResourcesDeletedSinceLastApply(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "configmap.yaml")
+	cachePath := filepath.Join(dir, ".ksail", "apply-cache.json")
+
+	content := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n  namespace: default\n" +
+		"data:\n  key: value\n"
+	require.NoError(t, os.WriteFile(manifest, []byte(content), 0o600))
+
+	apply := func() string {
+		t.Helper()
+
+		var out bytes.Buffer
+
+		client := kubectl.NewClient(
+			genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: &out, ErrOut: &out},
+			kubectl.WithPluginMode(false),
+			kubectl.WithApplyCache(applycache.NewStore(cachePath), applycache.Hash),
+		)
+		cmd := client.CreateApplyCommand(server.KubeconfigPath)
+		cmd.SetArgs([]string{"-f", manifest, "--skip-unchanged"})
+		require.NoError(t, cmd.Execute())
+
+		return out.String()
+	}
+
+	assert.Contains(t, apply(), "configmap/app created")
+
+	err := server.Clientset.CoreV1().ConfigMaps("default").Delete(t.Context(), "app", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	assert.Contains(t, apply(), "configmap/app created")
+	assert.Contains(t, apply(), "configmap/app unchanged")
+}
+
+func TestCachedApplyReportsEmptyManifests(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte("---\n# nothing to apply\n"), 0o600))
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(unreachableKubeconfig), 0o600))
+
+	store := applycache.NewStore(filepath.Join(dir, "apply-cache.json"))
+	client := kubectl.NewClient(
+		createTestIOStreams(),
+		kubectl.WithPluginMode(false),
+		kubectl.WithApplyCache(store, applycache.Hash),
+	)
+
+	cmd := client.CreateApplyCommand(kubeconfig)
+	cmd.SetArgs([]string{"-f", manifest, "--skip-unchanged=false"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, kubectl.ErrNoObjectsToApply)
+}
+
+func TestCachedApplyStreamsManifestsFromStdin(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+	cachePath := filepath.Join(t.TempDir(), "apply-cache.json")
+
+	var out bytes.Buffer
+
+	stdin := bytes.NewBufferString(
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  namespace: default\n---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n  namespace: default\n",
+	)
+	client := kubectl.NewClient(
+		genericiooptions.IOStreams{In: stdin, Out: &out, ErrOut: &out},
+		kubectl.WithPluginMode(false),
+		kubectl.WithApplyCache(applycache.NewStore(cachePath), applycache.Hash),
+	)
+
+	cmd := client.CreateApplyCommand(server.KubeconfigPath)
+	cmd.SetArgs([]string{"-f", "-", "--skip-unchanged"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "configmap/first created")
+	assert.Contains(t, out.String(), "configmap/second created")
+}
@@ -37,14 +37,42 @@ var (
 
 // Client wraps kubectl command functionality.
 type Client struct {
-	ioStreams genericiooptions.IOStreams
+	ioStreams  genericiooptions.IOStreams
+	pluginMode bool
+	context    string
+
+	completionCache *CompletionCache
+	applyCache      ApplyCache
+	applyHash       HashFunc
+}
+
+// ClientOption customizes a Client.
+type ClientOption func(*Client)
+
+// WithPluginMode overrides whether commands follow kubectl plugin conventions.
+// By default this is detected from the name the binary was invoked with; see IsPluginInvocation.
+func WithPluginMode(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.pluginMode = enabled
+	}
+}
+
+// WithContext makes commands target the named kubeconfig context instead of the current one.
+func WithContext(name string) ClientOption {
+	return func(c *Client) {
+		c.context = name
+	}
 }
 
 // NewClient creates a new kubectl client instance.
-func NewClient(streams genericiooptions.IOStreams) *Client {
-	client := &Client{}
+func NewClient(streams genericiooptions.IOStreams, opts ...ClientOption) *Client {
+	client := &Client{pluginMode: RunningAsPlugin(), completionCache: DefaultCompletionCache()}
 	client.ioStreams = streams
 
+	for _, opt := range opts {
+		opt(client)
+	}
+
 	return client
 }
 
@@ -59,11 +87,16 @@ func NewClientWithStdio() *Client {
 
 // CreateApplyCommand creates a kubectl apply command with all its flags and behavior.
 func (c *Client) CreateApplyCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
-	applyCmd := apply.NewCmdApply("ksail workload", factory, c.ioStreams)
+	factory, configFlags := c.createFactory(kubeConfigPath)
+
+	applyCmd := apply.NewCmdApply(applyBaseName, factory, c.ioStreams)
+	if c.applyCache != nil && c.applyHash != nil {
+		applyCmd = c.newCachedApplyCommand(factory)
+	}
 
 	c.customizeCommand(
 		applyCmd,
+		configFlags,
 		"apply",
 		"Apply manifests",
 		"Apply local Kubernetes manifests to your cluster.",
@@ -74,11 +107,12 @@ func (c *Client) CreateApplyCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateCreateCommand creates a kubectl create command with all its flags and behavior.
 func (c *Client) CreateCreateCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	createCmd := create.NewCmdCreate(factory, c.ioStreams)
 
 	c.customizeCommand(
 		createCmd,
+		configFlags,
 		"create",
 		"Create resources",
 		"Create Kubernetes resources from files or stdin.",
@@ -89,11 +123,12 @@ func (c *Client) CreateCreateCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateEditCommand creates a kubectl edit command with all its flags and behavior.
 func (c *Client) CreateEditCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	editCmd := edit.NewCmdEdit(factory, c.ioStreams)
 
 	c.customizeCommand(
 		editCmd,
+		configFlags,
 		"edit",
 		"Edit a resource",
 		"Edit a Kubernetes resource from the default editor.",
@@ -104,11 +139,12 @@ func (c *Client) CreateEditCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateDeleteCommand creates a kubectl delete command with all its flags and behavior.
 func (c *Client) CreateDeleteCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	deleteCmd := delete.NewCmdDelete(factory, c.ioStreams)
 
 	c.customizeCommand(
 		deleteCmd,
+		configFlags,
 		"delete",
 		"Delete resources",
 		"Delete Kubernetes resources by file names, stdin, resources and names, or by resources and label selector.",
@@ -119,26 +155,30 @@ func (c *Client) CreateDeleteCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateDescribeCommand creates a kubectl describe command with all its flags and behavior.
 func (c *Client) CreateDescribeCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	describeCmd := describe.NewCmdDescribe("ksail workload", factory, c.ioStreams)
 
 	c.customizeCommand(
 		describeCmd,
+		configFlags,
 		"describe",
 		"Describe resources",
 		"Show details of a specific resource or group of resources.",
 	)
 
+	c.registerResourceCompletion(describeCmd, factory)
+
 	return describeCmd
 }
 
 // CreateExplainCommand creates a kubectl explain command with all its flags and behavior.
 func (c *Client) CreateExplainCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	explainCmd := explain.NewCmdExplain("ksail workload", factory, c.ioStreams)
 
 	c.customizeCommand(
 		explainCmd,
+		configFlags,
 		"explain",
 		"Get documentation for a resource",
 		"Get documentation for Kubernetes resources, including field descriptions and structure.",
@@ -149,42 +189,49 @@ func (c *Client) CreateExplainCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateGetCommand creates a kubectl get command with all its flags and behavior.
 func (c *Client) CreateGetCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	getCmd := get.NewCmdGet("ksail workload", factory, c.ioStreams)
 
 	c.customizeCommand(
 		getCmd,
+		configFlags,
 		"get",
 		"Get resources",
 		"Display one or many Kubernetes resources from your cluster.",
 	)
 
+	c.registerResourceCompletion(getCmd, factory)
+
 	return getCmd
 }
 
 // CreateLogsCommand creates a kubectl logs command with all its flags and behavior.
 func (c *Client) CreateLogsCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	logsCmd := logs.NewCmdLogs(factory, c.ioStreams)
 
 	c.customizeCommand(
 		logsCmd,
+		configFlags,
 		"logs",
 		"Print container logs",
 		"Print the logs for a container in a pod or specified resource. "+
 			"If the pod has only one container, the container name is optional.",
 	)
 
+	c.registerPodCompletion(logsCmd, factory, true)
+
 	return logsCmd
 }
 
 // CreateRolloutCommand creates a kubectl rollout command with all its flags and behavior.
 func (c *Client) CreateRolloutCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	rolloutCmd := rollout.NewCmdRollout(factory, c.ioStreams)
 
 	c.customizeCommand(
 		rolloutCmd,
+		configFlags,
 		"rollout",
 		"Manage the rollout of a resource",
 		"Manage the rollout of one or many resources.",
@@ -195,11 +242,12 @@ func (c *Client) CreateRolloutCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateScaleCommand creates a kubectl scale command with all its flags and behavior.
 func (c *Client) CreateScaleCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	scaleCmd := scale.NewCmdScale(factory, c.ioStreams)
 
 	c.customizeCommand(
 		scaleCmd,
+		configFlags,
 		"scale",
 		"Scale resources",
 		"Set a new size for a deployment, replica set, replication controller, or stateful set.",
@@ -210,11 +258,12 @@ func (c *Client) CreateScaleCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateExposeCommand creates a kubectl expose command with all its flags and behavior.
 func (c *Client) CreateExposeCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	exposeCmd := expose.NewCmdExposeService(factory, c.ioStreams)
 
 	c.customizeCommand(
 		exposeCmd,
+		configFlags,
 		"expose",
 		"Expose a resource as a service",
 		"Expose a resource as a new Kubernetes service.",
@@ -225,10 +274,7 @@ func (c *Client) CreateExposeCommand(kubeConfigPath string) *cobra.Command {
 
 // CreateClusterInfoCommand wires kubectl's cluster-info with minimal guarding.
 func (c *Client) CreateClusterInfoCommand(kubeConfigPath string) *cobra.Command {
-	configFlags := genericclioptions.NewConfigFlags(true)
-	if kubeConfigPath != "" {
-		configFlags.KubeConfig = &kubeConfigPath
-	}
+	configFlags := c.newConfigFlags(kubeConfigPath)
 
 	restClientGetter := cmdutil.NewMatchVersionFlags(configFlags)
 	options := &clusterinfo.ClusterInfoOptions{IOStreams: c.ioStreams}
@@ -262,26 +308,26 @@ func (c *Client) CreateClusterInfoCommand(kubeConfigPath string) *cobra.Command
 
 // CreateExecCommand creates a kubectl exec command with all its flags and behavior.
 func (c *Client) CreateExecCommand(kubeConfigPath string) *cobra.Command {
-	factory := c.createFactory(kubeConfigPath)
+	factory, configFlags := c.createFactory(kubeConfigPath)
 	execCmd := exec.NewCmdExec(factory, c.ioStreams)
 
 	c.customizeCommand(
 		execCmd,
+		configFlags,
 		"exec",
 		"Execute a command in a container",
 		"Execute a command in a container in a pod.",
 	)
 
+	c.registerPodCompletion(execCmd, factory, false)
+
 	return execCmd
 }
 
 // CreateWaitCommand creates a kubectl wait command with all its flags and behavior.
 func (c *Client) CreateWaitCommand(kubeConfigPath string) *cobra.Command {
 	// Create config flags with kubeconfig path
-	configFlags := genericclioptions.NewConfigFlags(true)
-	if kubeConfigPath != "" {
-		configFlags.KubeConfig = &kubeConfigPath
-	}
+	configFlags := c.newConfigFlags(kubeConfigPath)
 
 	// Create the wait command using kubectl's NewCmdWait
 	waitCmd := wait.NewCmdWait(configFlags, c.ioStreams)
@@ -292,7 +338,8 @@ func (c *Client) CreateWaitCommand(kubeConfigPath string) *cobra.Command {
 	waitCmd.Long = "Wait for a specific condition on one or many resources. " +
 		"The command takes multiple resources and waits until the specified condition " +
 		"is seen in the Status field of every given resource."
-	replaceKubectlInExamples(waitCmd)
+	c.replaceKubectlInExamples(waitCmd)
+	c.bindPluginFlags(waitCmd, configFlags)
 
 	return waitCmd
 }
@@ -380,30 +427,89 @@ func (c *Client) CreatePriorityClassCmd() (*cobra.Command, error) {
 // Factory and command customization helpers.
 
 // createFactory creates a kubectl factory with the given kubeconfig path.
-func (c *Client) createFactory(kubeConfigPath string) cmdutil.Factory {
+// The returned config flags back the factory and can be bound to the command using it.
+func (c *Client) createFactory(kubeConfigPath string) (cmdutil.Factory, *genericclioptions.ConfigFlags) {
+	configFlags := c.newConfigFlags(kubeConfigPath)
+	matchVersionKubeConfigFlags := cmdutil.NewMatchVersionFlags(configFlags)
+
+	return cmdutil.NewFactory(matchVersionKubeConfigFlags), configFlags
+}
+
+// newConfigFlags returns kubeconfig flags defaulting to kubeConfigPath.
+//
+// In plugin mode a set $KUBECONFIG takes precedence over kubeConfigPath, as it does for kubectl.
+func (c *Client) newConfigFlags(kubeConfigPath string) *genericclioptions.ConfigFlags {
 	configFlags := genericclioptions.NewConfigFlags(true)
+
+	if c.context != "" {
+		configFlags.Context = &c.context
+	}
+
+	if c.pluginMode && os.Getenv(kubeconfigEnvVar) != "" {
+		return configFlags
+	}
+
 	if kubeConfigPath != "" {
 		configFlags.KubeConfig = &kubeConfigPath
 	}
 
-	matchVersionKubeConfigFlags := cmdutil.NewMatchVersionFlags(configFlags)
-
-	return cmdutil.NewFactory(matchVersionKubeConfigFlags)
+	return configFlags
 }
 
 // customizeCommand applies standard customizations to a kubectl command.
-func (c *Client) customizeCommand(cmd *cobra.Command, use, short, long string) {
+func (c *Client) customizeCommand(
+	cmd *cobra.Command,
+	configFlags *genericclioptions.ConfigFlags,
+	use, short, long string,
+) {
 	cmd.Use = use
 	cmd.Short = short
 	cmd.Long = long
-	replaceKubectlInExamples(cmd)
+	c.replaceKubectlInExamples(cmd)
+	c.bindPluginFlags(cmd, configFlags)
 }
 
-// replaceKubectlInExamples replaces "kubectl" with "ksail workload" in command examples.
-func replaceKubectlInExamples(cmd *cobra.Command) {
-	if cmd.Example != "" {
-		cmd.Example = strings.ReplaceAll(cmd.Example, "kubectl", "ksail workload")
+// bindPluginFlags adds kubectl's connection flags (--kubeconfig, --context, --cluster, --user
+// and -n/--namespace) to cmd in plugin mode, so `kubectl ksail` honors them like kubectl does.
+func (c *Client) bindPluginFlags(cmd *cobra.Command, configFlags *genericclioptions.ConfigFlags) {
+	if !c.pluginMode {
+		return
+	}
+
+	pluginFlags := &genericclioptions.ConfigFlags{
+		KubeConfig:   configFlags.KubeConfig,
+		Context:      configFlags.Context,
+		ClusterName:  configFlags.ClusterName,
+		AuthInfoName: configFlags.AuthInfoName,
+		Namespace:    configFlags.Namespace,
 	}
+
+	flags := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	pluginFlags.AddFlags(flags)
+
+	// Commands may already define some of these flags; keep their definitions.
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if cmd.Flags().Lookup(flag.Name) != nil ||
+			(flag.Shorthand != "" && cmd.Flags().ShorthandLookup(flag.Shorthand) != nil) {
+			return
+		}
+
+		cmd.Flags().AddFlag(flag)
+	})
+}
+
+// replaceKubectlInExamples replaces "kubectl" with the ksail workload invocation in command examples.
+func (c *Client) replaceKubectlInExamples(cmd *cobra.Command) {
+	if cmd.Example == "" {
+		return
+	}
+
+	invocation := "ksail workload"
+	if c.pluginMode {
+		invocation = "kubectl ksail workload"
+	}
+
+	cmd.Example = strings.ReplaceAll(cmd.Example, "kubectl", invocation)
 }
 
 // newResourceCmd creates a gen command that wraps kubectl create with forced --dry-run=client -o yaml.
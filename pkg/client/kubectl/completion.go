@@ -0,0 +1,315 @@
+package kubectl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/completion"
+)
+
+// DefaultCompletionCacheTTL is how long completion candidates fetched from a cluster are reused.
+// Shells run one completion process per key press, so the cache lives on disk.
+const DefaultCompletionCacheTTL = 30 * time.Second
+
+const (
+	namespaceFlagName = "namespace"
+	completionDirPerm = 0o750
+)
+
+// CompletionCache keeps completion candidates fetched from a cluster on disk for a short time,
+// so repeated completions do not each query the API server.
+type CompletionCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type completionCacheEntry struct {
+	Fetched time.Time `json:"fetched"`
+	Items   []string  `json:"items"`
+}
+
+// NewCompletionCache creates a cache storing candidates in dir for ttl.
+func NewCompletionCache(dir string, ttl time.Duration) *CompletionCache {
+	return &CompletionCache{dir: dir, ttl: ttl}
+}
+
+// DefaultCompletionCache returns the cache in the user's cache directory, or nil when there
+// is none; a nil cache queries the cluster on every completion.
+func DefaultCompletionCache() *CompletionCache {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+
+	return NewCompletionCache(filepath.Join(cacheDir, "ksail", "completion"), DefaultCompletionCacheTTL)
+}
+
+// WithCompletionCache overrides where completion candidates are cached. A nil cache disables
+// caching.
+func WithCompletionCache(cache *CompletionCache) ClientOption {
+	return func(c *Client) {
+		c.completionCache = cache
+	}
+}
+
+// Get returns the candidates cached for key, calling load and caching its result when they
+// are missing or expired. Failed loads are not cached.
+func (c *CompletionCache) Get(key string, load func() ([]string, error)) ([]string, error) {
+	if c == nil {
+		return load()
+	}
+
+	path := filepath.Join(c.dir, cacheFileName(key))
+
+	// #nosec G304 -- path is built from the cache directory and a hashed key
+	data, err := os.ReadFile(path)
+	if err == nil {
+		var entry completionCacheEntry
+
+		if json.Unmarshal(data, &entry) == nil && time.Since(entry.Fetched) < c.ttl {
+			return entry.Items, nil
+		}
+	}
+
+	items, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(path, items)
+
+	return items, nil
+}
+
+// store writes items best-effort; completion still works when the cache cannot be written.
+func (c *CompletionCache) store(path string, items []string) {
+	data, err := json.Marshal(completionCacheEntry{Fetched: time.Now(), Items: items})
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(c.dir, completionDirPerm)
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+func cacheFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// registerResourceCompletion completes resource types, then names of the chosen type, the
+// way kubectl get and describe do.
+func (c *Client) registerResourceCompletion(cmd *cobra.Command, factory cmdutil.Factory) {
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) (
+		[]string, cobra.ShellCompDirective,
+	) {
+		if resourceType, _, found := strings.Cut(toComplete, "/"); found && len(args) == 0 {
+			names := c.completeNames(cmd, factory, resourceType)
+
+			return prefixAll(resourceType+"/", names), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		if len(args) == 0 {
+			return c.completeResourceTypes(factory), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		if strings.Contains(args[0], "/") {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		names := c.completeNames(cmd, factory, args[0])
+
+		return withoutAlreadyGiven(names, args[1:]), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	c.registerNamespaceCompletion(cmd, factory)
+}
+
+// registerPodCompletion completes pod names for the first argument, as kubectl logs and exec
+// do, and with containers the names of the chosen pod's containers for the second.
+func (c *Client) registerPodCompletion(cmd *cobra.Command, factory cmdutil.Factory, containers bool) {
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) (
+		[]string, cobra.ShellCompDirective,
+	) {
+		switch {
+		case len(args) == 0:
+			return c.completeNames(cmd, factory, "pods"), cobra.ShellCompDirectiveNoFileComp
+		case len(args) == 1 && containers:
+			return completion.CompGetContainers(factory, args[0], toComplete), cobra.ShellCompDirectiveNoFileComp
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+
+	c.registerNamespaceCompletion(cmd, factory)
+}
+
+// registerNamespaceCompletion completes -n/--namespace, which commands have in plugin mode.
+func (c *Client) registerNamespaceCompletion(cmd *cobra.Command, factory cmdutil.Factory) {
+	if cmd.Flags().Lookup(namespaceFlagName) == nil {
+		return
+	}
+
+	_ = cmd.RegisterFlagCompletionFunc(
+		namespaceFlagName,
+		func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return c.completeClusterScoped(factory, "namespaces"), cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+}
+
+func (c *Client) completeResourceTypes(factory cmdutil.Factory) []string {
+	items, err := c.completionCache.Get(c.completionKey(factory, "", "api-resources"), func() ([]string, error) {
+		discovery, err := factory.ToDiscoveryClient()
+		if err != nil {
+			return nil, fmt.Errorf("create discovery client: %w", err)
+		}
+
+		lists, err := discovery.ServerPreferredResources()
+		if err != nil && len(lists) == 0 {
+			return nil, fmt.Errorf("discover resources: %w", err)
+		}
+
+		var types []string
+
+		for _, list := range lists {
+			groupVersion, err := schema.ParseGroupVersion(list.GroupVersion)
+			if err != nil {
+				continue
+			}
+
+			for _, resource := range list.APIResources {
+				if !slices.Contains(resource.Verbs, "list") {
+					continue
+				}
+
+				name := resource.Name
+				if groupVersion.Group != "" {
+					name += "." + groupVersion.Group
+				}
+
+				types = append(types, name)
+			}
+		}
+
+		slices.Sort(types)
+
+		return types, nil
+	})
+	if err != nil {
+		return nil
+	}
+
+	return items
+}
+
+func (c *Client) completeNames(cmd *cobra.Command, factory cmdutil.Factory, resourceType string) []string {
+	namespace := completionNamespace(cmd, factory)
+
+	return c.listNames(factory, namespace, resourceType)
+}
+
+func (c *Client) completeClusterScoped(factory cmdutil.Factory, resourceType string) []string {
+	return c.listNames(factory, "", resourceType)
+}
+
+func (c *Client) listNames(factory cmdutil.Factory, namespace, resourceType string) []string {
+	key := c.completionKey(factory, namespace, resourceType)
+
+	items, err := c.completionCache.Get(key, func() ([]string, error) {
+		result := factory.NewBuilder().
+			Unstructured().
+			NamespaceParam(namespace).DefaultNamespace().
+			ResourceTypeOrNameArgs(true, resourceType).
+			ContinueOnError().
+			Flatten().
+			Do()
+
+		infos, err := result.Infos()
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", resourceType, err)
+		}
+
+		names := make([]string, 0, len(infos))
+
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+
+		slices.Sort(names)
+
+		return names, nil
+	})
+	if err != nil {
+		return nil
+	}
+
+	return items
+}
+
+// completionKey identifies the cluster, namespace and resource candidates were fetched for.
+func (c *Client) completionKey(factory cmdutil.Factory, namespace, resourceType string) string {
+	loader := factory.ToRawKubeConfigLoader()
+
+	server := ""
+	if restConfig, err := loader.ClientConfig(); err == nil {
+		server = restConfig.Host
+	}
+
+	contextName := c.context
+	if rawConfig, err := loader.RawConfig(); err == nil && contextName == "" {
+		contextName = rawConfig.CurrentContext
+	}
+
+	return strings.Join([]string{server, contextName, namespace, resourceType}, "\x00")
+}
+
+func completionNamespace(cmd *cobra.Command, factory cmdutil.Factory) string {
+	if flag := cmd.Flags().Lookup(namespaceFlagName); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+
+	namespace, _, err := factory.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return ""
+	}
+
+	return namespace
+}
+
+func prefixAll(prefix string, values []string) []string {
+	prefixed := make([]string, 0, len(values))
+
+	for _, value := range values {
+		prefixed = append(prefixed, prefix+value)
+	}
+
+	return prefixed
+}
+
+func withoutAlreadyGiven(candidates, given []string) []string {
+	remaining := make([]string, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if !slices.Contains(given, candidate) {
+			remaining = append(remaining, candidate)
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,165 @@
+package kubectl_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCompletionLoad = errors.New("cluster unreachable")
+
+func TestCompletionCacheReusesFreshCandidates(t *testing.T) {
+	t.Parallel()
+
+	cache := kubectl.NewCompletionCache(t.TempDir(), time.Minute)
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+
+		return []string{"web"}, nil
+	}
+
+	first, err := cache.Get("pods", load)
+	require.NoError(t, err)
+
+	second, err := cache.Get("pods", load)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"web"}, first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, loads)
+}
+
+func TestCompletionCacheReloadsExpiredCandidates(t *testing.T) {
+	t.Parallel()
+
+	cache := kubectl.NewCompletionCache(t.TempDir(), 0)
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+
+		return []string{"web"}, nil
+	}
+
+	_, err := cache.Get("pods", load)
+	require.NoError(t, err)
+
+	_, err = cache.Get("pods", load)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, loads)
+}
+
+func TestCompletionCacheDoesNotCacheFailures(t *testing.T) {
+	t.Parallel()
+
+	cache := kubectl.NewCompletionCache(t.TempDir(), time.Minute)
+
+	_, err := cache.Get("pods", func() ([]string, error) { return nil, errCompletionLoad })
+	require.ErrorIs(t, err, errCompletionLoad)
+
+	items, err := cache.Get("pods", func() ([]string, error) { return []string{"web"}, nil })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, items)
+}
+
+//nolint:paralleltest // Sets KUBECACHEDIR so discovery caches stay inside the test directory.
+func TestGetCommandCompletesFromCluster(t *testing.T) {
+	t.Setenv("KUBECACHEDIR", t.TempDir())
+
+	var podLists atomic.Int32
+
+	server := httptest.NewServer(fakeAPIServer(&podLists))
+	t.Cleanup(server.Close)
+
+	kubeconfigPath := writeCompletionKubeconfig(t, server.URL)
+	client := kubectl.NewClient(
+		createTestIOStreams(),
+		kubectl.WithPluginMode(false),
+		kubectl.WithCompletionCache(kubectl.NewCompletionCache(t.TempDir(), time.Minute)),
+	)
+
+	getCmd := client.CreateGetCommand(kubeconfigPath)
+
+	types, directive := getCmd.ValidArgsFunction(getCmd, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []string{"namespaces", "pods"}, types)
+
+	names, _ := getCmd.ValidArgsFunction(getCmd, []string{"pods"}, "")
+	assert.Equal(t, []string{"api", "web"}, names)
+
+	remaining, _ := getCmd.ValidArgsFunction(getCmd, []string{"pods", "web"}, "")
+	assert.Equal(t, []string{"api"}, remaining)
+
+	slashed, _ := getCmd.ValidArgsFunction(getCmd, nil, "pods/")
+	assert.Equal(t, []string{"pods/api", "pods/web"}, slashed)
+
+	assert.Equal(t, int32(1), podLists.Load(), "pod names are served from the cache")
+}
+
+func fakeAPIServer(podLists *atomic.Int32) http.Handler {
+	responses := map[string]string{
+		"/api":  `{"kind":"APIVersions","versions":["v1"]}`,
+		"/apis": `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`,
+		"/api/v1": `{"kind":"APIResourceList","groupVersion":"v1","resources":[` +
+			`{"name":"pods","singularName":"pod","namespaced":true,"kind":"Pod","verbs":["get","list"]},` +
+			`{"name":"namespaces","singularName":"namespace","namespaced":false,"kind":"Namespace",` +
+			`"verbs":["get","list"]},` +
+			`{"name":"bindings","namespaced":true,"kind":"Binding","verbs":["create"]}]}`,
+		"/api/v1/namespaces/default/pods": `{"kind":"PodList","apiVersion":"v1","metadata":{},"items":[` +
+			`{"metadata":{"name":"web","namespace":"default"}},` +
+			`{"metadata":{"name":"api","namespace":"default"}}]}`,
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, ok := responses[request.URL.Path]
+		if !ok {
+			http.NotFound(writer, request)
+
+			return
+		}
+
+		if request.URL.Path == "/api/v1/namespaces/default/pods" {
+			podLists.Add(1)
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(body))
+	})
+}
+
+func writeCompletionKubeconfig(t *testing.T, server string) string {
+	t.Helper()
+
+	kubeconfig := "apiVersion: v1\n" +
+		"kind: Config\n" +
+		"clusters:\n" +
+		"- name: test\n" +
+		"  cluster:\n" +
+		"    server: " + server + "\n" +
+		"contexts:\n" +
+		"- name: test\n" +
+		"  context:\n" +
+		"    cluster: test\n" +
+		"    user: test\n" +
+		"    namespace: default\n" +
+		"current-context: test\n" +
+		"users:\n" +
+		"- name: test\n" +
+		"  user: {}\n"
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+
+	return path
+}
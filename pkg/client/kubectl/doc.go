@@ -3,4 +3,15 @@
 // This package wraps kubectl command functionality and provides client methods
 // for executing kubectl operations programmatically, including apply, create,
 // delete, get, describe, and other standard kubectl commands.
+//
+// When the binary is invoked as kubectl-ksail (see IsPluginInvocation), commands follow
+// kubectl plugin conventions and accept --context, --namespace and related flags.
+//
+// With WithApplyCache, apply skips resources whose rendered manifest is unchanged since it
+// was last applied to the same cluster. Such apply commands also decode and apply one document
+// at a time, including from stdin, instead of loading the whole manifest set into memory.
+//
+// The get, describe, logs and exec commands complete resource types, object names and
+// namespaces from the live cluster. Candidates are cached on disk for a short time (see
+// CompletionCache), since shells start a completion process for every key press.
 package kubectl
@@ -0,0 +1,27 @@
+package kubectl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// PluginBinaryName is the binary name kubectl looks up for `kubectl ksail`.
+	PluginBinaryName = "kubectl-ksail"
+
+	kubeconfigEnvVar = "KUBECONFIG"
+)
+
+// IsPluginInvocation reports whether argv0 names the kubectl plugin binary, as happens when
+// kubectl executes the plugin or the binary is linked as kubectl-ksail.
+func IsPluginInvocation(argv0 string) bool {
+	name := strings.TrimSuffix(filepath.Base(argv0), ".exe")
+
+	return name == PluginBinaryName
+}
+
+// RunningAsPlugin reports whether the current process was invoked as a kubectl plugin.
+func RunningAsPlugin() bool {
+	return IsPluginInvocation(os.Args[0])
+}
@@ -0,0 +1,77 @@
+package kubectl_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPluginInvocation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		argv0 string
+		want  bool
+	}{
+		{name: "plugin binary", argv0: "kubectl-ksail", want: true},
+		{name: "plugin binary with path", argv0: "/usr/local/bin/kubectl-ksail", want: true},
+		{name: "windows plugin binary", argv0: "kubectl-ksail.exe", want: true},
+		{name: "ksail binary", argv0: "/usr/local/bin/ksail", want: false},
+		{name: "other plugin", argv0: "kubectl-ksail-extra", want: false},
+		{name: "empty", argv0: "", want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, testCase.want, kubectl.IsPluginInvocation(testCase.argv0))
+		})
+	}
+}
+
+func TestPluginModeAddsKubectlConnectionFlags(t *testing.T) {
+	t.Parallel()
+
+	client := kubectl.NewClient(createTestIOStreams(), kubectl.WithPluginMode(true))
+
+	for _, cmd := range []*cobra.Command{
+		client.CreateApplyCommand("/path/to/kubeconfig"),
+		client.CreateGetCommand("/path/to/kubeconfig"),
+		client.CreateWaitCommand("/path/to/kubeconfig"),
+	} {
+		flags := cmd.Flags()
+		for _, name := range []string{"kubeconfig", "context", "cluster", "user", "namespace"} {
+			require.NotNil(t, flags.Lookup(name), "expected --%s flag on %s", name, cmd.Name())
+		}
+
+		require.NotNil(t, flags.ShorthandLookup("n"), "expected -n shorthand on %s", cmd.Name())
+		require.Nil(t, flags.Lookup("token"), "expected --token to be omitted on %s", cmd.Name())
+		require.Contains(t, cmd.Example, "kubectl ksail workload")
+	}
+}
+
+func TestNonPluginModeOmitsKubectlConnectionFlags(t *testing.T) {
+	t.Parallel()
+
+	client := kubectl.NewClient(createTestIOStreams(), kubectl.WithPluginMode(false))
+	cmd := client.CreateGetCommand("/path/to/kubeconfig")
+
+	require.Nil(t, cmd.Flags().Lookup("context"))
+	require.Nil(t, cmd.Flags().Lookup("namespace"))
+	require.NotContains(t, cmd.Example, "kubectl ksail")
+}
+
+func TestPluginModeNamespaceFlagSetsNamespace(t *testing.T) {
+	t.Parallel()
+
+	client := kubectl.NewClient(createTestIOStreams(), kubectl.WithPluginMode(true))
+	cmd := client.CreateGetCommand("/path/to/kubeconfig")
+
+	require.NoError(t, cmd.Flags().Parse([]string{"-n", "team-a", "--context", "kind-dev"}))
+	require.Equal(t, "team-a", cmd.Flag("namespace").Value.String())
+	require.Equal(t, "kind-dev", cmd.Flag("context").Value.String())
+}
@@ -2,6 +2,8 @@ package oci
 
 import (
 	"context"
+	"crypto"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 )
@@ -29,6 +31,11 @@ type WorkloadArtifactBuilder interface {
 //
 // All fields are optional except SourcePath, RegistryEndpoint, and Version.
 // Name and Repository default to source directory basename if not provided.
+//
+// Builds are reproducible: identical content yields an identical digest. See SourceDateEpochEnv.
+//
+// A SourcePath with a Chart.yaml is pushed as a Helm OCI chart, like helm push does, unless
+// Kustomize is set. Name and Repository then default to the chart name.
 type BuildOptions struct {
 	// Name is the artifact name (defaults to repository's last segment if empty).
 	Name string
@@ -38,8 +45,19 @@ type BuildOptions struct {
 	RegistryEndpoint string
 	// Repository is the repository path (defaults to source directory basename if empty).
 	Repository string
-	// Version is the artifact tag (required, must be semver or "latest").
+	// Version is the artifact tag (required, must be semver or "latest"). For Helm charts it
+	// defaults to, and must match, the chart version.
 	Version string
+	// Kustomize runs a kustomize build of SourcePath, which must contain a kustomization, and
+	// packages its output as a single manifest instead of the raw files, so overlays are
+	// flattened before pushing.
+	Kustomize bool
+	// Check builds the artifact without pushing or signing it, and fails with
+	// ErrDigestMismatch unless the tag already points to an artifact with the same digest.
+	Check bool
+	// SigningKey, when set, signs the pushed artifact in the key-based cosign format, storing
+	// the signature alongside the artifact. See LoadSigningKey.
+	SigningKey crypto.Signer
 }
 
 // ValidatedBuildOptions represents sanitized inputs ready for use by the builder implementation.
@@ -56,6 +74,12 @@ type ValidatedBuildOptions struct {
 	Repository string
 	// Version is the validated version string.
 	Version string
+	// Kustomize reports whether the source path is packaged as its kustomize build output.
+	Kustomize bool
+	// HelmChart reports whether the source path is a Helm chart, pushed as a Helm OCI chart.
+	HelmChart bool
+	// Created is the creation time recorded in the artifact from SOURCE_DATE_EPOCH, or zero.
+	Created time.Time
 }
 
 // BuildResult describes the outcome of a successful artifact build.
@@ -64,4 +88,9 @@ type ValidatedBuildOptions struct {
 type BuildResult struct {
 	// Artifact contains the complete OCI artifact metadata after successful push.
 	Artifact v1alpha1.OCIArtifact
+	// Digest is the manifest digest of the pushed artifact.
+	Digest string
+	// Signature is the reference of the pushed signature, or empty when no SigningKey was set
+	// or Check was.
+	Signature string
 }
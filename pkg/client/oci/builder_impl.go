@@ -2,14 +2,12 @@ package oci
 
 import (
 	"archive/tar"
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -71,12 +69,16 @@ type builder struct {
 //
 // The build process follows these steps:
 //  1. Validates build options and normalizes inputs
-//  2. Discovers and collects manifest files from the source directory
+//  2. Discovers and collects manifest files from the source directory, or renders its
+//     kustomize build when Kustomize is set
 //  3. Packages manifests into a tarball layer
-//  4. Builds an OCI image with the layer and metadata labels
+//  4. Builds an OCI image with the layer and metadata labels, or packages the source
+//     directory as a Helm OCI chart when it contains a Chart.yaml
 //  5. Constructs a registry reference from endpoint, repository, and version
-//  6. Pushes the image to the registry
-//  7. Returns artifact metadata on success
+//  6. With Check, compares the digest with the pushed tag instead of pushing
+//  7. Pushes the image to the registry
+//  8. Signs the pushed image when a signing key is set
+//  9. Returns artifact metadata on success
 //
 // Returns BuildResult with complete artifact metadata, or an error if any step fails.
 func (b *builder) Build(ctx context.Context, opts BuildOptions) (BuildResult, error) {
@@ -85,23 +87,9 @@ func (b *builder) Build(ctx context.Context, opts BuildOptions) (BuildResult, er
 		return BuildResult{}, err
 	}
 
-	manifestFiles, err := collectManifestFiles(validated.SourcePath)
+	img, err := buildArtifactImage(validated)
 	if err != nil {
-		return BuildResult{}, fmt.Errorf("discover manifests: %w", err)
-	}
-
-	if len(manifestFiles) == 0 {
-		return BuildResult{}, ErrNoManifestFiles
-	}
-
-	layer, err := newManifestLayer(validated.SourcePath, manifestFiles)
-	if err != nil {
-		return BuildResult{}, fmt.Errorf("package manifests: %w", err)
-	}
-
-	img, err := buildImage(layer, validated)
-	if err != nil {
-		return BuildResult{}, fmt.Errorf("build image: %w", err)
+		return BuildResult{}, err
 	}
 
 	ref, err := name.ParseReference(
@@ -118,6 +106,28 @@ func (b *builder) Build(ctx context.Context, opts BuildOptions) (BuildResult, er
 		return BuildResult{}, fmt.Errorf("parse reference: %w", err)
 	}
 
+	digest, err := img.Digest()
+	if err != nil {
+		return BuildResult{}, fmt.Errorf("compute artifact digest: %w", err)
+	}
+
+	result := BuildResult{
+		Artifact: v1alpha1.OCIArtifact{
+			Name:             validated.Name,
+			Version:          validated.Version,
+			RegistryEndpoint: validated.RegistryEndpoint,
+			Repository:       validated.Repository,
+			Tag:              validated.Version,
+			SourcePath:       validated.SourcePath,
+			CreatedAt:        metav1.NewTime(time.Now().UTC()),
+		},
+		Digest: digest.String(),
+	}
+
+	if opts.Check {
+		return result, checkPushedDigest(ctx, ref, digest)
+	}
+
 	pusher := b.ensurePusher()
 
 	err = pusher.Push(ctx, ref, img)
@@ -125,17 +135,68 @@ func (b *builder) Build(ctx context.Context, opts BuildOptions) (BuildResult, er
 		return BuildResult{}, fmt.Errorf("push artifact: %w", err)
 	}
 
-	artifact := v1alpha1.OCIArtifact{
-		Name:             validated.Name,
-		Version:          validated.Version,
-		RegistryEndpoint: validated.RegistryEndpoint,
-		Repository:       validated.Repository,
-		Tag:              validated.Version,
-		SourcePath:       validated.SourcePath,
-		CreatedAt:        metav1.NewTime(time.Now().UTC()),
+	if opts.SigningKey != nil {
+		result.Signature, err = signArtifact(ctx, pusher, ref, digest, opts.SigningKey)
+		if err != nil {
+			return BuildResult{}, fmt.Errorf("sign artifact: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// buildArtifactImage builds the Helm OCI chart of the source path when it is a chart, or
+// otherwise an image with its manifests.
+func buildArtifactImage(opts ValidatedBuildOptions) (v1.Image, error) {
+	if opts.HelmChart {
+		return buildChartImage(opts)
+	}
+
+	layer, err := buildManifestLayer(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := buildImage(layer, opts)
+	if err != nil {
+		return nil, fmt.Errorf("build image: %w", err)
+	}
+
+	return img, nil
+}
+
+// buildManifestLayer packages the manifests in the source path, or its kustomize build output
+// when Kustomize is set, into a layer.
+func buildManifestLayer(opts ValidatedBuildOptions) (v1.Layer, error) {
+	if opts.Kustomize {
+		manifests, err := kustomizeBuild(opts.SourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		layer, err := newKustomizedLayer(manifests, archiveTime(opts.Created))
+		if err != nil {
+			return nil, fmt.Errorf("package manifests: %w", err)
+		}
+
+		return layer, nil
+	}
+
+	manifestFiles, err := collectManifestFiles(opts.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("discover manifests: %w", err)
+	}
+
+	if len(manifestFiles) == 0 {
+		return nil, ErrNoManifestFiles
 	}
 
-	return BuildResult{Artifact: artifact}, nil
+	layer, err := newManifestLayer(opts.SourcePath, manifestFiles, archiveTime(opts.Created))
+	if err != nil {
+		return nil, fmt.Errorf("package manifests: %w", err)
+	}
+
+	return layer, nil
 }
 
 // ensurePusher returns the configured pusher or initializes a default remote pusher.
@@ -207,28 +268,13 @@ func collectManifestFiles(root string) ([]string, error) {
 // Files are added to the tar archive with their relative paths from the root directory.
 // File permissions are set to 0o644 for consistency.
 //
-// Returns an OCI v1.Layer suitable for inclusion in an OCI image.
+// The tarball is streamed from disk each time the layer is opened instead of being held in
+// memory, so large manifest sets do not need to fit in memory.
 //
-
-func newManifestLayer(root string, files []string) (v1.Layer, error) {
-	buf := bytes.NewBuffer(nil)
-	tarWriter := tar.NewWriter(buf)
-
-	var err error
-	for _, path := range files {
-		err = addFileToArchive(tarWriter, root, path)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	err = tarWriter.Close()
-	if err != nil {
-		return nil, fmt.Errorf("close tar writer: %w", err)
-	}
-
+// Returns an OCI v1.Layer suitable for inclusion in an OCI image.
+func newManifestLayer(root string, files []string, modTime time.Time) (v1.Layer, error) {
 	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
-		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		return streamManifestArchive(root, files, modTime), nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create layer from tar: %w", err)
@@ -237,13 +283,43 @@ func newManifestLayer(root string, files []string) (v1.Layer, error) {
 	return layer, nil
 }
 
+// streamManifestArchive writes the manifest tarball into a pipe as it is read.
+func streamManifestArchive(root string, files []string, modTime time.Time) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		tarWriter := tar.NewWriter(writer)
+
+		for _, path := range files {
+			err := addFileToArchive(tarWriter, root, path, modTime)
+			if err != nil {
+				writer.CloseWithError(err)
+
+				return
+			}
+		}
+
+		err := tarWriter.Close()
+		if err != nil {
+			writer.CloseWithError(fmt.Errorf("close tar writer: %w", err))
+
+			return
+		}
+
+		_ = writer.Close()
+	}()
+
+	return reader
+}
+
 // addFileToArchive adds a single file to the tar archive with its relative path from root.
 //
 // The file is added with:
 //   - Relative path from root (converted to forward slashes)
-//   - Fixed permissions of 0o644
+//   - Fixed permissions of 0o644 and modification time modTime
+//   - No owner or access and change times, so the archive does not depend on the host
 //   - Original file content
-func addFileToArchive(tarWriter *tar.Writer, root, path string) error {
+func addFileToArchive(tarWriter *tar.Writer, root, path string, modTime time.Time) error {
 	info, err := os.Stat(path)
 	if err != nil {
 		return fmt.Errorf("stat file %s: %w", path, err)
@@ -254,14 +330,14 @@ func addFileToArchive(tarWriter *tar.Writer, root, path string) error {
 		return fmt.Errorf("get relative path for %s: %w", path, err)
 	}
 
-	header, err := tar.FileInfoHeader(info, "")
-	if err != nil {
-		return fmt.Errorf("create tar header for %s: %w", path, err)
+	header := &tar.Header{
+		Name:     filepath.ToSlash(rel),
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     info.Size(),
+		ModTime:  modTime,
 	}
 
-	header.Name = filepath.ToSlash(rel)
-	header.Mode = 0o644
-
 	err = tarWriter.WriteHeader(header)
 	if err != nil {
 		return fmt.Errorf("write tar header for %s: %w", path, err)
@@ -287,19 +363,20 @@ func addFileToArchive(tarWriter *tar.Writer, root, path string) error {
 // buildImage creates an OCI image from a manifest layer with appropriate metadata labels.
 //
 // The image is constructed with:
-//   - Current OS and architecture
-//   - Creation timestamp
+//   - The creation timestamp from SOURCE_DATE_EPOCH, if set
 //   - OCI standard labels (title, version, source)
 //   - KSail-specific labels (repository, registry endpoint)
+//   - OCI standard manifest annotations (created, title, version, source)
+//
+// Nothing about the host or the time of the build is recorded, as manifests are not platform
+// specific and identical inputs must yield identical digests.
 //
 // Returns a complete OCI v1.Image ready for push to a registry.
 //
 
 func buildImage(layer v1.Layer, opts ValidatedBuildOptions) (v1.Image, error) {
 	cfg := &v1.ConfigFile{
-		Architecture: runtime.GOARCH,
-		OS:           runtime.GOOS,
-		Created:      v1.Time{Time: time.Now().UTC()},
+		Created: v1.Time{Time: opts.Created},
 		Config: v1.Config{
 			Labels: map[string]string{
 				"org.opencontainers.image.title":        opts.Name,
@@ -321,5 +398,18 @@ func buildImage(layer v1.Layer, opts ValidatedBuildOptions) (v1.Image, error) {
 		return nil, fmt.Errorf("append layer: %w", err)
 	}
 
-	return finalImg, nil
+	// Mirror the OCI labels as manifest annotations, which registries and tools like
+	// ListArtifacts read without fetching the config.
+	annotations := map[string]string{
+		"org.opencontainers.image.title":   opts.Name,
+		"org.opencontainers.image.version": opts.Version,
+		"org.opencontainers.image.source":  opts.SourcePath,
+	}
+
+	if !opts.Created.IsZero() {
+		annotations[createdAnnotation] = opts.Created.Format(time.RFC3339)
+	}
+
+	//nolint:forcetypeassert // Annotations returns an image when given one
+	return mutate.Annotations(finalImg, annotations).(v1.Image), nil
 }
@@ -0,0 +1,195 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// isHelmChart reports whether dir is the root of a Helm chart.
+func isHelmChart(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, chartutil.ChartfileName))
+
+	return err == nil
+}
+
+// loadChartMetadata reads and validates the Chart.yaml of the chart in dir.
+func loadChartMetadata(dir string) (*chart.Metadata, error) {
+	metadata, err := chartutil.LoadChartfile(filepath.Join(dir, chartutil.ChartfileName))
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", chartutil.ChartfileName, err)
+	}
+
+	err = metadata.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("validate %s: %w", chartutil.ChartfileName, err)
+	}
+
+	return metadata, nil
+}
+
+// resolveChartVersion returns the tag of a chart artifact, which Helm requires to be the chart
+// version. An explicit version must match it.
+func resolveChartVersion(raw string, metadata *chart.Metadata) (string, error) {
+	if raw == "" {
+		return metadata.Version, nil
+	}
+
+	version, err := normalizeVersion(raw)
+	if err != nil {
+		return "", err
+	}
+
+	chartVersion, err := normalizeVersion(metadata.Version)
+	if err != nil || version != chartVersion {
+		return "", fmt.Errorf(
+			"%w: %s does not match chart version %s",
+			ErrChartVersionMismatch,
+			raw,
+			metadata.Version,
+		)
+	}
+
+	return metadata.Version, nil
+}
+
+// buildChartImage packages the chart in opts.SourcePath like helm package and returns it as a
+// Helm OCI chart artifact, as helm push creates it: the chart metadata as config and the
+// chart archive as its single layer.
+func buildChartImage(opts ValidatedBuildOptions) (v1.Image, error) {
+	helmChart, err := loader.LoadDir(opts.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("load helm chart: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ksail-chart-*")
+	if err != nil {
+		return nil, fmt.Errorf("create chart package directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	archivePath, err := chartutil.Save(helmChart, tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("package helm chart: %w", err)
+	}
+
+	archive, err := os.ReadFile(archivePath) //nolint:gosec // path is created by chartutil.Save
+	if err != nil {
+		return nil, fmt.Errorf("read chart package: %w", err)
+	}
+
+	archive, err = normalizeChartArchive(archive, archiveTime(opts.Created))
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := json.Marshal(helmChart.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshal chart metadata: %w", err)
+	}
+
+	annotations := map[string]string{
+		"org.opencontainers.image.title":   helmChart.Metadata.Name,
+		"org.opencontainers.image.version": helmChart.Metadata.Version,
+		"org.opencontainers.image.source":  opts.SourcePath,
+	}
+
+	if !opts.Created.IsZero() {
+		annotations[createdAnnotation] = opts.Created.Format(time.RFC3339)
+	}
+
+	if helmChart.Metadata.Description != "" {
+		annotations["org.opencontainers.image.description"] = helmChart.Metadata.Description
+	}
+
+	img, err := partial.CompressedToImage(&chartImage{
+		config:      config,
+		layer:       static.NewLayer(archive, registry.ChartLayerMediaType),
+		annotations: annotations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build chart image: %w", err)
+	}
+
+	return img, nil
+}
+
+// chartImage is a Helm OCI chart. go-containerregistry only builds images with container
+// configs, so the manifest is assembled here.
+type chartImage struct {
+	config      []byte
+	layer       v1.Layer
+	annotations map[string]string
+}
+
+func (c *chartImage) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+func (c *chartImage) RawConfigFile() ([]byte, error) {
+	return c.config, nil
+}
+
+func (c *chartImage) RawManifest() ([]byte, error) {
+	configDigest, configSize, err := v1.SHA256(bytes.NewReader(c.config))
+	if err != nil {
+		return nil, fmt.Errorf("digest chart config: %w", err)
+	}
+
+	layerDigest, err := c.layer.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("digest chart layer: %w", err)
+	}
+
+	layerSize, err := c.layer.Size()
+	if err != nil {
+		return nil, fmt.Errorf("size chart layer: %w", err)
+	}
+
+	manifest, err := json.Marshal(v1.Manifest{
+		SchemaVersion: 2, //nolint:mnd // OCI image manifests are schema version 2
+		MediaType:     types.OCIManifestSchema1,
+		Config: v1.Descriptor{
+			MediaType: registry.ConfigMediaType,
+			Size:      configSize,
+			Digest:    configDigest,
+		},
+		Layers: []v1.Descriptor{{
+			MediaType: registry.ChartLayerMediaType,
+			Size:      layerSize,
+			Digest:    layerDigest,
+		}},
+		Annotations: c.annotations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal chart manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func (c *chartImage) LayerByDigest(digest v1.Hash) (partial.CompressedLayer, error) {
+	layerDigest, err := c.layer.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("digest chart layer: %w", err)
+	}
+
+	if digest != layerDigest {
+		return nil, fmt.Errorf("%w: %s", ErrLayerNotFound, digest)
+	}
+
+	return c.layer, nil
+}
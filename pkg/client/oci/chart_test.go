@@ -0,0 +1,63 @@
+package oci_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+func TestBuildPushesHelmChart(t *testing.T) {
+	t.Parallel()
+
+	chartDir, err := chartutil.Create("podinfo", t.TempDir())
+	require.NoError(t, err)
+
+	endpoint := startRegistry(t)
+
+	result, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       chartDir,
+		RegistryEndpoint: endpoint,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "podinfo", result.Artifact.Repository)
+	assert.Equal(t, "0.1.0", result.Artifact.Tag)
+
+	client, err := registry.NewClient(
+		registry.ClientOptPlainHTTP(),
+		registry.ClientOptCredentialsFile(filepath.Join(t.TempDir(), "config.json")),
+	)
+	require.NoError(t, err)
+
+	pulled, err := client.Pull(endpoint + "/podinfo:0.1.0")
+	require.NoError(t, err)
+
+	assert.Equal(t, "podinfo", pulled.Chart.Meta.Name)
+	assert.Equal(t, "0.1.0", pulled.Chart.Meta.Version)
+
+	helmChart, err := loader.LoadArchive(bytes.NewReader(pulled.Chart.Data))
+	require.NoError(t, err)
+	assert.NotEmpty(t, helmChart.Templates)
+}
+
+func TestBuildRejectsVersionOtherThanChartVersion(t *testing.T) {
+	t.Parallel()
+
+	chartDir, err := chartutil.Create("podinfo", t.TempDir())
+	require.NoError(t, err)
+
+	_, err = oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       chartDir,
+		RegistryEndpoint: "localhost:5000",
+		Version:          "latest",
+	})
+
+	require.ErrorIs(t, err, oci.ErrChartVersionMismatch)
+}
@@ -7,9 +7,14 @@
 //
 // Key functionality:
 //   - Manifest collection from directories (.yaml, .yml, .json files)
+//   - Optional kustomize builds that flatten overlays before packaging
+//   - Helm chart packaging, pushing directories with a Chart.yaml as Helm OCI charts
 //   - OCI artifact packaging using go-containerregistry
+//   - Reproducible builds, and checking a build against the pushed tag without pushing
 //   - Registry push operations with validation
 //   - Build options validation and normalization
+//   - Key-based cosign signing of pushed artifacts and signature verification
+//   - Listing and inspecting the repositories and tags of a registry
 //
 // Example usage:
 //
@@ -18,4 +18,27 @@ var (
 	ErrVersionInvalid = errors.New("version must follow semantic versioning")
 	// ErrNoManifestFiles indicates that the source directory does not contain manifest files.
 	ErrNoManifestFiles = errors.New("no manifest files found in source directory")
+	// ErrKustomizationNotFound indicates that a kustomize build was requested for a source directory without a
+	// kustomization file.
+	ErrKustomizationNotFound = errors.New("no kustomization file found in source directory")
+	// ErrChartVersionMismatch indicates that the version of a Helm chart artifact differs from its chart version.
+	ErrChartVersionMismatch = errors.New("version must match the chart version")
+	// ErrLayerNotFound indicates that an artifact has no layer with a requested digest.
+	ErrLayerNotFound = errors.New("layer not found")
+	// ErrSourceDateEpochInvalid indicates that SOURCE_DATE_EPOCH is not a Unix timestamp.
+	ErrSourceDateEpochInvalid = errors.New("invalid source date epoch")
+	// ErrDigestMismatch indicates that a checked build differs from the artifact pushed to its tag.
+	ErrDigestMismatch = errors.New("artifact digest differs from the pushed tag")
+)
+
+// Artifact signing errors.
+var (
+	// ErrUnsupportedKey indicates that a signing or verification key has an unsupported format or type.
+	ErrUnsupportedKey = errors.New("unsupported key")
+	// ErrWrongKeyPassword indicates that an encrypted signing key could not be decrypted with the password.
+	ErrWrongKeyPassword = errors.New("failed to decrypt signing key, wrong password?")
+	// ErrSignatureNotFound indicates that no signature is stored for an artifact.
+	ErrSignatureNotFound = errors.New("no signature found for artifact")
+	// ErrSignatureInvalid indicates that no stored signature of an artifact matches the key and digest.
+	ErrSignatureInvalid = errors.New("no valid signature found for artifact")
 )
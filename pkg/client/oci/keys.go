@@ -0,0 +1,167 @@
+package oci
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// CosignPasswordEnv is the environment variable cosign reads the password of encrypted
+// private keys from.
+const CosignPasswordEnv = "COSIGN_PASSWORD"
+
+const (
+	encryptedSigstoreKeyType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+	encryptedCosignKeyType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	scryptKDF                = "scrypt"
+	secretboxCipher          = "nacl/secretbox"
+	secretboxKeySize         = 32
+	secretboxNonceSize       = 24
+)
+
+// encryptedKey is the envelope cosign generate-key-pair encrypts private keys in.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadSigningKey reads the private key at path to sign artifacts with. Keys created by
+// cosign generate-key-pair are decrypted with password; unencrypted PKCS #8 and EC keys in
+// PEM format are read as is. ECDSA, Ed25519 and RSA keys are supported.
+func LoadSigningKey(path string, password []byte) (crypto.Signer, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // key path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s is not PEM encoded", ErrUnsupportedKey, path)
+	}
+
+	var key any
+
+	switch block.Type {
+	case encryptedSigstoreKeyType, encryptedCosignKeyType:
+		der, decryptErr := decryptCosignKey(block.Bytes, password)
+		if decryptErr != nil {
+			return nil, decryptErr
+		}
+
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+
+	return signer, nil
+}
+
+// LoadVerificationKey reads the PEM encoded public key at path, e.g. cosign.pub, to verify
+// artifact signatures with.
+func LoadVerificationKey(path string) (crypto.PublicKey, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // key path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("read verification key: %w", err)
+	}
+
+	return ParseVerificationKey(content)
+}
+
+// ParseVerificationKey parses a PEM encoded public key to verify artifact signatures with.
+func ParseVerificationKey(content []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%w: expected a PEM encoded public key", ErrUnsupportedKey)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse verification key: %w", err)
+	}
+
+	return key, nil
+}
+
+// MarshalVerificationKey returns publicKey PEM encoded, in the format of cosign.pub.
+func MarshalVerificationKey(publicKey crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal verification key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// decryptCosignKey opens the scrypt and secretbox envelope of a cosign private key and
+// returns the PKCS #8 key inside it.
+func decryptCosignKey(envelope, password []byte) ([]byte, error) {
+	var encrypted encryptedKey
+
+	err := json.Unmarshal(envelope, &encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid encrypted key: %w", ErrUnsupportedKey, err)
+	}
+
+	if encrypted.KDF.Name != scryptKDF || encrypted.Cipher.Name != secretboxCipher ||
+		len(encrypted.Cipher.Nonce) != secretboxNonceSize {
+		return nil, fmt.Errorf(
+			"%w: encrypted with %s and %s",
+			ErrUnsupportedKey,
+			encrypted.KDF.Name,
+			encrypted.Cipher.Name,
+		)
+	}
+
+	params := encrypted.KDF.Params
+
+	derived, err := scrypt.Key(password, encrypted.KDF.Salt, params.N, params.R, params.P, secretboxKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key encryption key: %w", err)
+	}
+
+	var (
+		key   [secretboxKeySize]byte
+		nonce [secretboxNonceSize]byte
+	)
+
+	copy(key[:], derived)
+	copy(nonce[:], encrypted.Cipher.Nonce)
+
+	der, ok := secretbox.Open(nil, encrypted.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, ErrWrongKeyPassword
+	}
+
+	return der, nil
+}
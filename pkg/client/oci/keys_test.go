@@ -0,0 +1,118 @@
+package oci_test
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestLoadSigningKeyReadsPKCS8Key(t *testing.T) {
+	t.Parallel()
+
+	key := newECDSAKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := writeKey(t, "cosign.key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	signer, err := oci.LoadSigningKey(path, nil)
+	require.NoError(t, err)
+
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+}
+
+func TestLoadSigningKeyDecryptsCosignKey(t *testing.T) {
+	t.Parallel()
+
+	key := newECDSAKey(t)
+	path := writeKey(t, "cosign.key", encryptCosignKey(t, key, []byte("hunter2")))
+
+	signer, err := oci.LoadSigningKey(path, []byte("hunter2"))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(signer.Public()))
+
+	_, err = oci.LoadSigningKey(path, []byte("wrong"))
+	require.ErrorIs(t, err, oci.ErrWrongKeyPassword)
+}
+
+func TestLoadSigningKeyRejectsPublicKey(t *testing.T) {
+	t.Parallel()
+
+	publicKey, err := oci.MarshalVerificationKey(newECDSAKey(t).Public())
+	require.NoError(t, err)
+
+	_, err = oci.LoadSigningKey(writeKey(t, "cosign.pub", publicKey), nil)
+
+	require.ErrorIs(t, err, oci.ErrUnsupportedKey)
+}
+
+func TestVerificationKeyRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	key := newECDSAKey(t)
+
+	content, err := oci.MarshalVerificationKey(key.Public())
+	require.NoError(t, err)
+
+	publicKey, err := oci.LoadVerificationKey(writeKey(t, "cosign.pub", content))
+	require.NoError(t, err)
+
+	assert.True(t, key.PublicKey.Equal(publicKey))
+}
+
+func writeKey(t *testing.T, name string, content []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	return path
+}
+
+// encryptCosignKey encrypts key the way cosign generate-key-pair does, with cheap scrypt
+// parameters.
+func encryptCosignKey(t *testing.T, key any, password []byte) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	salt := make([]byte, 32)
+	_, err = rand.Read(salt)
+	require.NoError(t, err)
+
+	derived, err := scrypt.Key(password, salt, 1024, 8, 1, 32)
+	require.NoError(t, err)
+
+	var (
+		secret [32]byte
+		nonce  [24]byte
+	)
+
+	copy(secret[:], derived)
+	_, err = rand.Read(nonce[:])
+	require.NoError(t, err)
+
+	envelope, err := json.Marshal(map[string]any{
+		"kdf": map[string]any{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 1024, "r": 8, "p": 1},
+			"salt":   salt,
+		},
+		"cipher":     map[string]any{"name": "nacl/secretbox", "nonce": nonce[:]},
+		"ciphertext": secretbox.Seal(nil, der, &nonce, &secret),
+	})
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: envelope})
+}
@@ -0,0 +1,94 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// KustomizedManifestsFile is the file the output of a kustomize build is packaged as. Flux
+// applies it as is, as the artifact has no kustomization file left to build.
+const KustomizedManifestsFile = "manifests.yaml"
+
+// hasKustomization reports whether dir contains a file kustomize recognizes as a
+// kustomization.
+func hasKustomization(dir string) bool {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		_, err := os.Stat(filepath.Join(dir, fileName))
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kustomizeBuild renders the kustomization in root like kustomize build does, flattening its
+// bases, overlays, patches and generators into a single multi-document YAML stream.
+func kustomizeBuild(root string) ([]byte, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resources, err := kustomizer.Run(filesys.MakeFsOnDisk(), root)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %s: %w", root, err)
+	}
+
+	manifests, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("render kustomize build of %s: %w", root, err)
+	}
+
+	if len(bytes.TrimSpace(manifests)) == 0 {
+		return nil, ErrNoManifestFiles
+	}
+
+	return manifests, nil
+}
+
+// newKustomizedLayer creates an OCI layer with manifests as KustomizedManifestsFile, modified
+// at modTime.
+func newKustomizedLayer(manifests []byte, modTime time.Time) (v1.Layer, error) {
+	var archive bytes.Buffer
+
+	tarWriter := tar.NewWriter(&archive)
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:     KustomizedManifestsFile,
+		Typeflag: tar.TypeReg,
+		Mode:     0o644,
+		Size:     int64(len(manifests)),
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("write tar header for %s: %w", KustomizedManifestsFile, err)
+	}
+
+	_, err = tarWriter.Write(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("write %s to tar: %w", KustomizedManifestsFile, err)
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close tar writer: %w", err)
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(archive.Bytes())), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create layer from tar: %w", err)
+	}
+
+	return layer, nil
+}
@@ -0,0 +1,106 @@
+package oci_test
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPushesKustomizeBuildOfOverlay(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "base/kustomization.yaml", "resources:\n  - configmap.yaml\n")
+	writeFile(t, root, "base/configmap.yaml",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\ndata:\n  mode: base\n")
+	writeFile(t, root, "overlay/kustomization.yaml",
+		"resources:\n  - ../base\nnamePrefix: dev-\nnamespace: dev\n")
+
+	endpoint := startRegistry(t)
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       filepath.Join(root, "overlay"),
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "latest",
+		Kustomize:        true,
+	})
+	require.NoError(t, err)
+
+	files := pullFiles(t, endpoint+"/app:latest")
+
+	assert.Equal(t, []string{oci.KustomizedManifestsFile}, slices.Sorted(maps.Keys(files)))
+	assert.Contains(t, files[oci.KustomizedManifestsFile], "name: dev-app")
+	assert.Contains(t, files[oci.KustomizedManifestsFile], "namespace: dev")
+}
+
+func TestBuildRequiresKustomizationForKustomize(t *testing.T) {
+	t.Parallel()
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n"),
+		RegistryEndpoint: "localhost:5000",
+		Version:          "latest",
+		Kustomize:        true,
+	})
+
+	require.ErrorIs(t, err, oci.ErrKustomizationNotFound)
+}
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+
+	fullPath := filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+	require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+}
+
+// pullFiles returns the files in the single layer of the artifact at reference.
+func pullFiles(t *testing.T, reference string) map[string]string {
+	t.Helper()
+
+	ref, err := name.ParseReference(reference, name.Insecure)
+	require.NoError(t, err)
+
+	img, err := remote.Image(ref, remote.WithContext(t.Context()))
+	require.NoError(t, err)
+
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	reader, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+
+	defer func() { _ = reader.Close() }()
+
+	files := map[string]string{}
+	archive := tar.NewReader(reader)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(archive)
+		require.NoError(t, err)
+
+		files[header.Name] = string(content)
+	}
+
+	return files
+}
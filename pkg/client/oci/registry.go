@@ -0,0 +1,179 @@
+package oci
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// createdAnnotation is the OCI annotation with the creation time of an artifact, used when its
+// config does not record one, e.g. for artifacts pushed with flux push artifact.
+const createdAnnotation = "org.opencontainers.image.created"
+
+// ArtifactInfo describes a tagged artifact in a registry repository.
+type ArtifactInfo struct {
+	// Repository is the repository path within the registry.
+	Repository string
+	// Tag is the tag the artifact was resolved from.
+	Tag string
+	// Digest is the manifest digest of the artifact.
+	Digest string
+	// MediaType is the media type of the manifest.
+	MediaType string
+	// Size is the size in bytes of the manifest, config and layers. Indexes only count their
+	// own manifest.
+	Size int64
+	// Created is the creation time recorded by the artifact, or zero when it has none.
+	Created time.Time
+	// Annotations are the annotations of the manifest.
+	Annotations map[string]string
+	// Signed reports whether a cosign signature is stored alongside the artifact.
+	Signed bool
+}
+
+// ListRepositories returns the repositories in the catalog of the registry at endpoint, e.g.
+// localhost:5000, sorted by name.
+func ListRepositories(ctx context.Context, endpoint string) ([]string, error) {
+	endpoint, err := normalizeRegistryEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := name.NewRegistry(endpoint, name.WeakValidation, name.Insecure)
+	if err != nil {
+		return nil, fmt.Errorf("parse registry: %w", err)
+	}
+
+	repositories, err := remote.Catalog(ctx, registry)
+	if err != nil {
+		return nil, fmt.Errorf("list repositories: %w", err)
+	}
+
+	sort.Strings(repositories)
+
+	return repositories, nil
+}
+
+// ListArtifacts inspects every tag of repository in the registry at endpoint, sorted by tag.
+// Cosign signature tags are reported through ArtifactInfo.Signed instead of as artifacts.
+func ListArtifacts(ctx context.Context, endpoint, repository string) ([]ArtifactInfo, error) {
+	endpoint, err := normalizeRegistryEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := name.NewRepository(endpoint+"/"+repository, name.WeakValidation, name.Insecure)
+	if err != nil {
+		return nil, fmt.Errorf("parse repository: %w", err)
+	}
+
+	tags, err := remote.List(repo, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list tags of %s: %w", repository, err)
+	}
+
+	sort.Strings(tags)
+
+	artifacts := make([]ArtifactInfo, 0, len(tags))
+
+	for _, tag := range tags {
+		if strings.HasSuffix(tag, signatureTagSuffix) {
+			continue
+		}
+
+		artifact, err := inspectReference(ctx, repo.Tag(tag))
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// InspectArtifact returns the manifest details of the artifact at reference, e.g.
+// localhost:5000/app:latest.
+func InspectArtifact(ctx context.Context, reference string) (ArtifactInfo, error) {
+	ref, err := name.ParseReference(reference, name.WeakValidation, name.Insecure)
+	if err != nil {
+		return ArtifactInfo{}, fmt.Errorf("parse reference: %w", err)
+	}
+
+	return inspectReference(ctx, ref)
+}
+
+// --- internals ---
+
+func inspectReference(ctx context.Context, ref name.Reference) (ArtifactInfo, error) {
+	desc, err := remote.Get(ref, remote.WithContext(ctx))
+	if err != nil {
+		return ArtifactInfo{}, fmt.Errorf("fetch manifest of %s: %w", ref, err)
+	}
+
+	artifact := ArtifactInfo{
+		Repository: ref.Context().RepositoryStr(),
+		Tag:        ref.Identifier(),
+		Digest:     desc.Digest.String(),
+		MediaType:  string(desc.MediaType),
+		Size:       desc.Size,
+	}
+
+	if !desc.MediaType.IsIndex() {
+		err = addImageDetails(&artifact, desc)
+		if err != nil {
+			return ArtifactInfo{}, fmt.Errorf("inspect %s: %w", ref, err)
+		}
+	}
+
+	_, err = remote.Head(ref.Context().Tag(SignatureTag(desc.Digest)), remote.WithContext(ctx))
+
+	switch {
+	case err == nil:
+		artifact.Signed = true
+	case !isNotFound(err):
+		return ArtifactInfo{}, fmt.Errorf("look up signature of %s: %w", ref, err)
+	}
+
+	return artifact, nil
+}
+
+// addImageDetails adds the config and layer sizes, creation time and annotations of the image
+// manifest in desc to artifact.
+func addImageDetails(artifact *ArtifactInfo, desc *remote.Descriptor) error {
+	img, err := desc.Image()
+	if err != nil {
+		return fmt.Errorf("read image: %w", err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("read manifest: %w", err)
+	}
+
+	artifact.Size += manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		artifact.Size += layer.Size
+	}
+
+	artifact.Annotations = manifest.Annotations
+
+	config, err := img.ConfigFile()
+	if err == nil && !config.Created.IsZero() {
+		artifact.Created = config.Created.UTC()
+
+		return nil
+	}
+
+	created, err := time.Parse(time.RFC3339, manifest.Annotations[createdAnnotation])
+	if err == nil {
+		artifact.Created = created.UTC()
+	}
+
+	return nil
+}
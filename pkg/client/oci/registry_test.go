@@ -0,0 +1,54 @@
+package oci_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListArtifactsDescribesTags(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+	signed := buildSigned(t, endpoint, newECDSAKey(t))
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n"),
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "1.0.0",
+	})
+	require.NoError(t, err)
+
+	repositories, err := oci.ListRepositories(t.Context(), endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app"}, repositories)
+
+	artifacts, err := oci.ListArtifacts(t.Context(), endpoint, "app")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+
+	assert.Equal(t, "1.0.0", artifacts[0].Tag)
+	assert.False(t, artifacts[0].Signed)
+
+	latest := artifacts[1]
+	assert.Equal(t, "app", latest.Repository)
+	assert.Equal(t, "latest", latest.Tag)
+	assert.Equal(t, signed.Digest, latest.Digest)
+	assert.True(t, latest.Signed)
+	assert.Positive(t, latest.Size)
+	assert.True(t, latest.Created.IsZero(), "builds record no creation time without SOURCE_DATE_EPOCH")
+	assert.Equal(t, "latest", latest.Annotations["org.opencontainers.image.version"])
+}
+
+func TestInspectArtifactFailsForMissingTag(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+
+	_, err := oci.InspectArtifact(t.Context(), endpoint+"/app:missing")
+
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// SourceDateEpochEnv is the environment variable with the Unix timestamp builds record as the
+// creation time of artifacts, following https://reproducible-builds.org/specs/source-date-epoch/.
+// Without it, artifacts record no creation time, so identical content always yields identical
+// digests.
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// sourceDateEpoch returns the time in SourceDateEpochEnv, or zero when it is unset.
+func sourceDateEpoch() (time.Time, error) {
+	raw := strings.TrimSpace(os.Getenv(SourceDateEpochEnv))
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s=%q", ErrSourceDateEpochInvalid, SourceDateEpochEnv, raw)
+	}
+
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// archiveTime returns the modification time of archive entries for an artifact created at
+// created, the Unix epoch when it has no creation time.
+func archiveTime(created time.Time) time.Time {
+	if created.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+
+	return created
+}
+
+// normalizeChartArchive rewrites the timestamps in the gzipped chart archive helm package
+// creates, which records the time of packaging, to modTime.
+func normalizeChartArchive(archive []byte, modTime time.Time) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("read chart package: %w", err)
+	}
+
+	var normalized bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&normalized)
+	gzipWriter.Extra = gzipReader.Extra
+	gzipWriter.Comment = gzipReader.Comment
+
+	tarReader := tar.NewReader(gzipReader)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read chart package: %w", err)
+		}
+
+		header.ModTime = modTime
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return nil, fmt.Errorf("write tar header for %s: %w", header.Name, err)
+		}
+
+		//nolint:gosec // chart packages are created from the local source directory
+		_, err = io.Copy(tarWriter, tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("copy %s to tar: %w", header.Name, err)
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close tar writer: %w", err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close gzip writer: %w", err)
+	}
+
+	return normalized.Bytes(), nil
+}
+
+// checkPushedDigest fails with ErrDigestMismatch unless ref points to digest in the registry.
+func checkPushedDigest(ctx context.Context, ref name.Reference, digest v1.Hash) error {
+	desc, err := remote.Head(ref, remote.WithContext(ctx))
+	if isNotFound(err) {
+		return fmt.Errorf("%w: %s is not pushed, build is %s", ErrDigestMismatch, ref, digest)
+	}
+
+	if err != nil {
+		return fmt.Errorf("resolve pushed digest: %w", err)
+	}
+
+	if desc.Digest != digest {
+		return fmt.Errorf("%w: %s is %s, build is %s", ErrDigestMismatch, ref, desc.Digest, digest)
+	}
+
+	return nil
+}
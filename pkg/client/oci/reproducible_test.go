@@ -0,0 +1,146 @@
+package oci_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+func TestCheckPassesForUnchangedContent(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+	sourceDir := writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n")
+
+	pushed := buildSourceDir(t, endpoint, oci.BuildOptions{SourcePath: sourceDir})
+
+	// Touching files must not change the digest.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(sourceDir, "app.yaml"), later, later))
+
+	checked := buildSourceDir(t, endpoint, oci.BuildOptions{SourcePath: sourceDir, Check: true})
+
+	assert.Equal(t, pushed.Digest, checked.Digest)
+}
+
+func TestCheckFailsForChangedContent(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+	sourceDir := writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n")
+
+	buildSourceDir(t, endpoint, oci.BuildOptions{SourcePath: sourceDir})
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(sourceDir, "app.yaml"),
+		[]byte("kind: ConfigMap\nmetadata:\n  name: changed\n"),
+		0o600,
+	))
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       sourceDir,
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "latest",
+		Check:            true,
+	})
+
+	require.ErrorIs(t, err, oci.ErrDigestMismatch)
+}
+
+func TestCheckFailsForUnpushedTag(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n"),
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "latest",
+		Check:            true,
+	})
+
+	require.ErrorIs(t, err, oci.ErrDigestMismatch)
+
+	repositories, err := oci.ListRepositories(t.Context(), endpoint)
+	require.NoError(t, err)
+	assert.Empty(t, repositories, "check must not push")
+}
+
+func TestHelmChartBuildsAreReproducible(t *testing.T) {
+	t.Parallel()
+
+	chartDir, err := chartutil.Create("podinfo", t.TempDir())
+	require.NoError(t, err)
+
+	endpoint := startRegistry(t)
+
+	pushed := buildSourceDir(t, endpoint, oci.BuildOptions{SourcePath: chartDir, Version: "0.1.0"})
+
+	// Helm records the time of packaging, which must not leak into the digest.
+	time.Sleep(time.Second)
+
+	checked := buildSourceDir(t, endpoint, oci.BuildOptions{
+		SourcePath: chartDir,
+		Version:    "0.1.0",
+		Check:      true,
+	})
+
+	assert.Equal(t, pushed.Digest, checked.Digest)
+}
+
+//nolint:paralleltest // sets SOURCE_DATE_EPOCH
+func TestBuildRecordsSourceDateEpoch(t *testing.T) {
+	t.Setenv(oci.SourceDateEpochEnv, "1700000000")
+
+	endpoint := startRegistry(t)
+
+	buildSourceDir(t, endpoint, oci.BuildOptions{
+		SourcePath: writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n"),
+	})
+
+	artifacts, err := oci.ListArtifacts(t.Context(), endpoint, "app")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), artifacts[0].Created)
+	assert.Equal(t, "2023-11-14T22:13:20Z", artifacts[0].Annotations["org.opencontainers.image.created"])
+}
+
+//nolint:paralleltest // sets SOURCE_DATE_EPOCH
+func TestBuildRejectsInvalidSourceDateEpoch(t *testing.T) {
+	t.Setenv(oci.SourceDateEpochEnv, "yesterday")
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n"),
+		RegistryEndpoint: "localhost:5000",
+		Version:          "latest",
+	})
+
+	require.ErrorIs(t, err, oci.ErrSourceDateEpochInvalid)
+}
+
+// buildSourceDir builds opts into the app repository of endpoint, tagged latest unless opts
+// sets a version.
+func buildSourceDir(t *testing.T, endpoint string, opts oci.BuildOptions) oci.BuildResult {
+	t.Helper()
+
+	opts.RegistryEndpoint = endpoint
+	opts.Repository = "app"
+
+	if opts.Version == "" {
+		opts.Version = "latest"
+	}
+
+	result, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), opts)
+	require.NoError(t, err)
+
+	return result
+}
@@ -0,0 +1,253 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// Cosign signature format constants, so signatures are verifiable with cosign verify and by
+// the Flux source-controller.
+const (
+	signatureTagSuffix     = ".sig"
+	simpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	signatureAnnotation    = "dev.cosignproject.cosign/signature"
+	cosignSignatureType    = "cosign container image signature"
+)
+
+// simpleSigningPayload is the payload cosign signs, binding the signature to a manifest digest.
+type simpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]any `json:"optional"`
+}
+
+// SignatureTag returns the tag cosign stores the signature of the artifact with digest under,
+// in the repository of the artifact.
+func SignatureTag(digest v1.Hash) string {
+	return digest.Algorithm + "-" + digest.Hex + signatureTagSuffix
+}
+
+// VerifyArtifact checks that the artifact at reference, e.g. localhost:5000/app:latest, has a
+// cosign signature made by the private key of publicKey for its current digest.
+func VerifyArtifact(ctx context.Context, reference string, publicKey crypto.PublicKey) error {
+	ref, err := name.ParseReference(reference, name.WeakValidation, name.Insecure)
+	if err != nil {
+		return fmt.Errorf("parse reference: %w", err)
+	}
+
+	desc, err := remote.Head(ref, remote.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("resolve artifact digest: %w", err)
+	}
+
+	sigRef := ref.Context().Tag(SignatureTag(desc.Digest))
+
+	sigImage, err := remote.Image(sigRef, remote.WithContext(ctx))
+	if isNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrSignatureNotFound, reference)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fetch signature: %w", err)
+	}
+
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return fmt.Errorf("read signature manifest: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != simpleSigningMediaType {
+			continue
+		}
+
+		valid, err := verifySignatureLayer(sigImage, layer, desc.Digest, publicKey)
+		if err != nil {
+			return err
+		}
+
+		if valid {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSignatureInvalid, reference)
+}
+
+// --- internals ---
+
+// signArtifact pushes a cosign signature of the artifact with digest in the repository of
+// ref. An existing signature of the same digest is replaced.
+func signArtifact(
+	ctx context.Context,
+	pusher imagePusher,
+	ref name.Reference,
+	digest v1.Hash,
+	key crypto.Signer,
+) (string, error) {
+	var payload simpleSigningPayload
+
+	payload.Critical.Identity.DockerReference = ref.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = digest.String()
+	payload.Critical.Type = cosignSignatureType
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal signature payload: %w", err)
+	}
+
+	signature, err := signPayload(key, content)
+	if err != nil {
+		return "", err
+	}
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+
+	img, err = mutate.Append(img, mutate.Addendum{
+		Layer: static.NewLayer(content, simpleSigningMediaType),
+		Annotations: map[string]string{
+			signatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("build signature image: %w", err)
+	}
+
+	sigRef := ref.Context().Tag(SignatureTag(digest))
+
+	err = pusher.Push(ctx, sigRef, img)
+	if err != nil {
+		return "", fmt.Errorf("push signature: %w", err)
+	}
+
+	return sigRef.String(), nil
+}
+
+// signPayload signs payload the way cosign does: Ed25519 keys sign the payload itself, other
+// keys its SHA-256 digest.
+func signPayload(key crypto.Signer, payload []byte) ([]byte, error) {
+	if _, isEd25519 := key.Public().(ed25519.PublicKey); isEd25519 {
+		signature, err := key.Sign(rand.Reader, payload, crypto.Hash(0))
+		if err != nil {
+			return nil, fmt.Errorf("sign payload: %w", err)
+		}
+
+		return signature, nil
+	}
+
+	sum := sha256.Sum256(payload)
+
+	signature, err := key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("sign payload: %w", err)
+	}
+
+	return signature, nil
+}
+
+// verifySignatureLayer reports whether layer holds a payload for digest with a valid
+// signature by publicKey.
+func verifySignatureLayer(
+	sigImage v1.Image,
+	layer v1.Descriptor,
+	digest v1.Hash,
+	publicKey crypto.PublicKey,
+) (bool, error) {
+	signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+	if err != nil {
+		return false, nil //nolint:nilerr // a malformed signature is not a valid one
+	}
+
+	blob, err := sigImage.LayerByDigest(layer.Digest)
+	if err != nil {
+		return false, fmt.Errorf("read signature layer: %w", err)
+	}
+
+	reader, err := blob.Compressed()
+	if err != nil {
+		return false, fmt.Errorf("read signature layer: %w", err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return false, fmt.Errorf("read signature layer: %w", err)
+	}
+
+	if !verifyPayload(publicKey, content, signature) {
+		return false, nil
+	}
+
+	var payload simpleSigningPayload
+
+	err = json.Unmarshal(content, &payload)
+	if err != nil {
+		return false, nil //nolint:nilerr // a signed but malformed payload does not match
+	}
+
+	return payload.Critical.Image.DockerManifestDigest == digest.String(), nil
+}
+
+func verifyPayload(publicKey crypto.PublicKey, payload, signature []byte) bool {
+	sum := sha256.Sum256(payload)
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, sum[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	default:
+		return false
+	}
+}
+
+func isNotFound(err error) bool {
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+
+	if transportErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	for _, diagnostic := range transportErr.Errors {
+		if strings.EqualFold(string(diagnostic.Code), string(transport.ManifestUnknownErrorCode)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,145 @@
+package oci_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/oci"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSignsArtifact(t *testing.T) {
+	t.Parallel()
+
+	for name, key := range map[string]crypto.Signer{
+		"ecdsa":   newECDSAKey(t),
+		"ed25519": newEd25519Key(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := startRegistry(t)
+
+			result := buildSigned(t, endpoint, key)
+
+			digest, err := v1.NewHash(result.Digest)
+			require.NoError(t, err)
+			assert.Equal(t, endpoint+"/app:"+oci.SignatureTag(digest), result.Signature)
+
+			err = oci.VerifyArtifact(t.Context(), endpoint+"/app:latest", key.Public())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifyArtifactRejectsOtherKey(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+
+	buildSigned(t, endpoint, newECDSAKey(t))
+
+	err := oci.VerifyArtifact(t.Context(), endpoint+"/app:latest", newECDSAKey(t).Public())
+
+	require.ErrorIs(t, err, oci.ErrSignatureInvalid)
+}
+
+func TestVerifyArtifactRequiresSignature(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+
+	buildSigned(t, endpoint, nil)
+
+	err := oci.VerifyArtifact(t.Context(), endpoint+"/app:latest", newECDSAKey(t).Public())
+
+	require.ErrorIs(t, err, oci.ErrSignatureNotFound)
+}
+
+func TestVerifyArtifactRejectsSignatureOfPreviousDigest(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startRegistry(t)
+	key := newECDSAKey(t)
+
+	buildSigned(t, endpoint, key)
+
+	// Re-pushing changed manifests unsigned moves the tag to a digest without a signature.
+	sourceDir := writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: changed\n")
+
+	_, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       sourceDir,
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "latest",
+	})
+	require.NoError(t, err)
+
+	err = oci.VerifyArtifact(t.Context(), endpoint+"/app:latest", key.Public())
+
+	require.ErrorIs(t, err, oci.ErrSignatureNotFound)
+}
+
+func startRegistry(t *testing.T) string {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func buildSigned(t *testing.T, endpoint string, key crypto.Signer) oci.BuildResult {
+	t.Helper()
+
+	sourceDir := writeManifest(t, "kind: ConfigMap\nmetadata:\n  name: app\n")
+
+	result, err := oci.NewWorkloadArtifactBuilder().Build(t.Context(), oci.BuildOptions{
+		SourcePath:       sourceDir,
+		RegistryEndpoint: endpoint,
+		Repository:       "app",
+		Version:          "latest",
+		SigningKey:       key,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+
+	sourceDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "app.yaml"), []byte(content), 0o600))
+
+	return sourceDir
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
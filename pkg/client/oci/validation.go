@@ -13,10 +13,13 @@ import (
 // Validate normalizes and verifies the build options before artifact construction.
 //
 // This method performs the following validation steps:
-//  1. Validates and resolves the source path to an absolute directory
+//  1. Validates and resolves the source path to an absolute directory, containing a
+//     kustomization when Kustomize is set
 //  2. Normalizes and validates the registry endpoint
-//  3. Validates and normalizes the version (semantic versioning or "latest")
-//  4. Normalizes repository and artifact names using source path defaults
+//  3. Validates and normalizes the version (semantic versioning or "latest"), or resolves
+//     it from Chart.yaml for Helm charts
+//  4. Reads the creation time to record from SOURCE_DATE_EPOCH
+//  5. Normalizes repository and artifact names using source path or chart name defaults
 //
 // Returns ValidatedBuildOptions ready for use by the builder, or an error if validation fails.
 func (o BuildOptions) Validate() (ValidatedBuildOptions, error) {
@@ -43,17 +46,47 @@ func (o BuildOptions) Validate() (ValidatedBuildOptions, error) {
 		return ValidatedBuildOptions{}, ErrSourcePathNotDirectory
 	}
 
+	if o.Kustomize && !hasKustomization(absSource) {
+		return ValidatedBuildOptions{}, ErrKustomizationNotFound
+	}
+
 	endpoint, err := normalizeRegistryEndpoint(o.RegistryEndpoint)
 	if err != nil {
 		return ValidatedBuildOptions{}, err
 	}
 
-	version, err := normalizeVersion(o.Version)
+	helmChart := !o.Kustomize && isHelmChart(absSource)
+	repositoryCandidate := o.Repository
+
+	var version string
+
+	if helmChart {
+		metadata, err := loadChartMetadata(absSource)
+		if err != nil {
+			return ValidatedBuildOptions{}, err
+		}
+
+		version, err = resolveChartVersion(strings.TrimSpace(o.Version), metadata)
+		if err != nil {
+			return ValidatedBuildOptions{}, err
+		}
+
+		if strings.TrimSpace(repositoryCandidate) == "" {
+			repositoryCandidate = metadata.Name
+		}
+	} else {
+		version, err = normalizeVersion(o.Version)
+		if err != nil {
+			return ValidatedBuildOptions{}, err
+		}
+	}
+
+	created, err := sourceDateEpoch()
 	if err != nil {
 		return ValidatedBuildOptions{}, err
 	}
 
-	repository := normalizeRepositoryName(o.Repository, absSource)
+	repository := normalizeRepositoryName(repositoryCandidate, absSource)
 	name := normalizeArtifactName(o.Name, repository)
 
 	return ValidatedBuildOptions{
@@ -62,6 +95,9 @@ func (o BuildOptions) Validate() (ValidatedBuildOptions, error) {
 		RegistryEndpoint: endpoint,
 		Repository:       repository,
 		Version:          version,
+		Kustomize:        o.Kustomize,
+		HelmChart:        helmChart,
+		Created:          created,
 	}, nil
 }
 
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// AuditActionAnnotation marks a command as mutating; its value is the action the command's
+// runs are recorded as in the audit log.
+const AuditActionAnnotation = "ksail.io/audit-action"
+
+//nolint:gochecknoglobals // audited runs span PersistentPreRunE and Execute, which share only the root command
+var activeAudits sync.Map
+
+// MarkAudited records every run of cmd as action in the audit log.
+func MarkAudited(cmd *cobra.Command, action string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+
+	cmd.Annotations[AuditActionAnnotation] = action
+}
+
+// StartAudit starts recording the run of cmd when it is marked with MarkAudited and auditing
+// is enabled. The recorder is carried by the command's context, so the steps of the run can
+// be recorded with audit.Track.
+func StartAudit(cmd *cobra.Command) {
+	if cmd == nil {
+		return
+	}
+
+	action := cmd.Annotations[AuditActionAnnotation]
+	if action == "" {
+		return
+	}
+
+	log := audit.DefaultLog()
+	if log == nil {
+		return
+	}
+
+	recorder := audit.NewRecorder(log, action, auditedCommandLine(cmd))
+
+	activeAudits.Store(cmd.Root(), recorder)
+	cmd.SetContext(audit.WithRecorder(cmd.Context(), recorder))
+}
+
+// FinishAudit records the outcome of the run started by StartAudit for the command tree of
+// cmd. It does nothing when the run is not audited.
+func FinishAudit(cmd *cobra.Command, err error) {
+	if cmd == nil {
+		return
+	}
+
+	value, ok := activeAudits.LoadAndDelete(cmd.Root())
+	if !ok {
+		return
+	}
+
+	recorder, _ := value.(*audit.Recorder)
+	recorder.Finish(err)
+}
+
+// auditedCommandLine describes the run as its command path and the flags that were set.
+func auditedCommandLine(cmd *cobra.Command) string {
+	parts := []string{cmd.CommandPath()}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		parts = append(parts, "--"+flag.Name+"="+flag.Value.String())
+	})
+
+	return strings.Join(parts, " ")
+}
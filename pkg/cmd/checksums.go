@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/spf13/cobra"
+)
+
+// RefreshChecksumsFlagName is the flag that replaces pinned digests that no longer match.
+const RefreshChecksumsFlagName = "refresh-checksums"
+
+// AddRefreshChecksumsFlag registers the --refresh-checksums flag on cmd.
+func AddRefreshChecksumsFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		RefreshChecksumsFlagName,
+		false,
+		"Accept and pin new digests for downloaded charts that no longer match "+checksum.DefaultPath,
+	)
+}
+
+// HelmChecksumOption returns a Helm client option that verifies downloaded charts against
+// the digests pinned in the project's checksum file, refreshing them when cmd asks to.
+func HelmChecksumOption(cmd *cobra.Command) helm.ClientOption {
+	return helm.WithChecksums(ProjectChecksums(cmd), checksum.DigestFile)
+}
+
+// ProjectChecksums returns the checksum store next to the project's ksail.yaml, or the store
+// shared by SharePins.
+func ProjectChecksums(cmd *cobra.Command) *checksum.Store {
+	if pins := pinsFromCommand(cmd); pins != nil {
+		return pins.checksums
+	}
+
+	refresh, _, _ := getBoolFlag(cmd.Flags(), RefreshChecksumsFlagName)
+
+	return checksum.NewStore(ProjectPath(cmd, checksum.DefaultPath), refresh)
+}
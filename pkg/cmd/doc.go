@@ -8,6 +8,7 @@
 //   - Docker client lifecycle management with automatic cleanup
 //   - Lifecycle command helpers for cluster operations (start, stop, delete, etc.)
 //   - Command runner utilities for executing commands with output capture
+//   - Hidden --pprof and --trace flags that profile a command run for diagnosis
 //
 // The utilities in this package follow dependency injection patterns and integrate
 // with the KSail runtime container for testability and flexibility.
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/docker/docker/client"
 	"github.com/spf13/cobra"
@@ -11,14 +13,23 @@ import (
 // WithDockerClient creates a Docker client, executes the given operation function, and ensures cleanup.
 // The Docker client is automatically closed after the operation completes, regardless of success or failure.
 //
+// When the command runs with a DI session, the session's shared client is used instead and stays
+// open for the rest of the invocation, so chained stages do not each dial the daemon.
+//
 // This function is suitable for production use. For testing with mock clients, use WithDockerClientInstance instead.
 //
 // Returns an error if client creation fails or if the operation function returns an error.
 func WithDockerClient(cmd *cobra.Command, operation func(client.APIClient) error) error {
-	dockerClient, err := client.NewClientWithOpts(
-		client.FromEnv,
-		client.WithAPIVersionNegotiation(),
-	)
+	if session := runtime.SessionFromContext(cmd.Context()); session != nil {
+		dockerClient, err := session.DockerClient()
+		if err != nil {
+			return fmt.Errorf("failed to create docker client: %w", err)
+		}
+
+		return operation(dockerClient)
+	}
+
+	dockerClient, err := dockerclient.GetDockerClient()
 	if err != nil {
 		return fmt.Errorf("failed to create docker client: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -75,3 +76,46 @@ func MaybeTimer(cmd *cobra.Command, tmr timer.Timer) timer.Timer {
 
 	return tmr
 }
+
+// CIFlagName is the global/root persistent flag that selects CI-specific output rendering.
+const CIFlagName = "ci"
+
+// ConfigureCIOutput applies the output format selected by the --ci flag to the root command.
+//
+// For the github format the root command's stdout and stderr are wrapped so notifications are
+// rendered as GitHub Actions annotations and log groups. Subcommands inherit the wrapped writers
+// through OutOrStdout and ErrOrStderr.
+func ConfigureCIOutput(cmd *cobra.Command) error {
+	if cmd == nil {
+		return errNilCommand
+	}
+
+	flag := cmd.Flags().Lookup(CIFlagName)
+	if flag == nil {
+		flag = cmd.InheritedFlags().Lookup(CIFlagName)
+	}
+
+	if flag == nil {
+		return nil
+	}
+
+	format, err := notify.ParseCIFormat(flag.Value.String())
+	if err != nil {
+		return fmt.Errorf("parse --%s flag: %w", CIFlagName, err)
+	}
+
+	if format != notify.CIFormatGitHub {
+		return nil
+	}
+
+	root := cmd.Root()
+	if _, ok := root.OutOrStdout().(*notify.GitHubActionsWriter); ok {
+		return nil
+	}
+
+	stdout := notify.NewGitHubActionsWriter(root.OutOrStdout())
+	root.SetOut(stdout)
+	root.SetErr(stdout.Wrap(root.ErrOrStderr()))
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	iopath "github.com/devantler-tech/ksail-go/pkg/io"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/io/wsl"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 )
 
@@ -24,7 +25,8 @@ func GetDefaultKubeconfigPath() string {
 // If the config doesn't specify a kubeconfig path, it returns the default path from GetDefaultKubeconfigPath.
 //
 // The function always expands tilde (~) characters in the path to the user's home directory,
-// regardless of whether the path came from the config or is the default.
+// regardless of whether the path came from the config or is the default. Under WSL2, Windows
+// paths such as C:\Users\me\.kube\config are translated to their location in the distribution.
 //
 // Returns an error if path expansion fails.
 func GetKubeconfigPathFromConfig(cfg *v1alpha1.Cluster) (string, error) {
@@ -33,6 +35,10 @@ func GetKubeconfigPathFromConfig(cfg *v1alpha1.Cluster) (string, error) {
 		kubeconfigPath = GetDefaultKubeconfigPath()
 	}
 
+	if wsl.Detect() {
+		kubeconfigPath = wsl.ToLinuxPath(kubeconfigPath)
+	}
+
 	// Always expand tilde in kubeconfig path, regardless of source
 	expandedPath, err := iopath.ExpandHomePath(kubeconfigPath)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	runtime "github.com/devantler-tech/ksail-go/pkg/di"
 	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
 	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
 	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
@@ -87,6 +88,8 @@ func WrapLifecycleHandler(
 
 				deps := LifecycleDeps{Timer: tmr, Factory: factory}
 
+				BindProjectConfig(cmd, cfgManager)
+
 				return handler(cmd, cfgManager, deps)
 			},
 		),
@@ -154,7 +157,7 @@ func RunLifecycleWithConfig(
 	config LifecycleConfig,
 	clusterCfg *v1alpha1.Cluster,
 ) error {
-	provisioner, distributionConfig, err := deps.Factory.Create(cmd.Context(), clusterCfg)
+	provisioner, distributionConfig, err := CreateClusterProvisioner(cmd, deps.Factory, clusterCfg)
 	if err != nil {
 		return fmt.Errorf("failed to resolve cluster provisioner: %w", err)
 	}
@@ -168,6 +171,8 @@ func RunLifecycleWithConfig(
 		return fmt.Errorf("failed to get cluster name from config: %w", err)
 	}
 
+	audit.SetCluster(cmd.Context(), clusterName)
+
 	return runLifecycleWithProvisioner(cmd, deps, config, provisioner, clusterName)
 }
 
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/spf13/cobra"
+)
+
+// FrozenLockfileFlagName is the flag that fails runs which would change the project's lock.
+const FrozenLockfileFlagName = "frozen-lockfile"
+
+// AddFrozenLockfileFlag registers the --frozen-lockfile flag on cmd.
+func AddFrozenLockfileFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(
+		FrozenLockfileFlagName,
+		false,
+		"Fail instead of updating "+lockfile.DefaultPath+" when a chart, image or node image differs from its pin",
+	)
+}
+
+// ProjectLock returns the project's lock, frozen when cmd asks for it, or the lock shared
+// by SharePins.
+func ProjectLock(cmd *cobra.Command) *lockfile.Lock {
+	if pins := pinsFromCommand(cmd); pins != nil {
+		return pins.lock
+	}
+
+	mode := lockfile.ModeRecord
+
+	frozen, _, _ := getBoolFlag(cmd.Flags(), FrozenLockfileFlagName)
+	if frozen {
+		mode = lockfile.ModeFrozen
+	}
+
+	return lockfile.New(ProjectPath(cmd, lockfile.DefaultPath), mode)
+}
+
+// HelmLockOption returns a Helm client option that installs the chart versions pinned in the
+// project's lock and pins the charts it downloads.
+func HelmLockOption(cmd *cobra.Command) helm.ClientOption {
+	return helm.WithChartLock(ProjectLock(cmd), checksum.DigestFile)
+}
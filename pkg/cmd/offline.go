@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/spf13/cobra"
+)
+
+// OfflineFlagName is the global/root persistent flag that forbids network egress.
+const OfflineFlagName = "offline"
+
+// ConfigureOffline marks the context of cmd as offline when the --offline flag is set, so
+// every step of the run that would reach the network fails instead.
+func ConfigureOffline(cmd *cobra.Command) error {
+	if cmd == nil {
+		return errNilCommand
+	}
+
+	enabled, found, err := getBoolFlag(cmd.Flags(), OfflineFlagName)
+	if !found && err == nil {
+		enabled, _, err = getBoolFlag(cmd.InheritedFlags(), OfflineFlagName)
+	}
+
+	if err != nil || !enabled {
+		return err
+	}
+
+	cmd.SetContext(offline.WithOffline(cmd.Context()))
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/spf13/cobra"
+)
+
+type sharedPinsKey struct{}
+
+// sharedPins are the project lock and checksum store that every command of a run hands out.
+type sharedPins struct {
+	lock      *lockfile.Lock
+	checksums *checksum.Store
+}
+
+// SharePins makes cmd, and every command that later shares its context, hand out one project
+// lock and one checksum store. Helm clients that install concurrently need this: separate
+// instances of the same file each write back their own pins and drop the others'.
+func SharePins(cmd *cobra.Command) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pins := &sharedPins{lock: ProjectLock(cmd), checksums: ProjectChecksums(cmd)}
+
+	cmd.SetContext(context.WithValue(ctx, sharedPinsKey{}, pins))
+}
+
+func pinsFromCommand(cmd *cobra.Command) *sharedPins {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return nil
+	}
+
+	pins, _ := ctx.Value(sharedPinsKey{}).(*sharedPins)
+
+	return pins
+}
@@ -0,0 +1,29 @@
+package cmd_test
+
+import (
+	"testing"
+
+	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectLockIsNewPerCallWithoutSharedPins(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{Use: "create"}
+
+	assert.NotSame(t, pkgcmd.ProjectLock(cmd), pkgcmd.ProjectLock(cmd))
+}
+
+func TestSharePinsSharesProjectLockWithCommandsOfTheSameContext(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{Use: "create"}
+	pkgcmd.SharePins(cmd)
+
+	stageCmd := &cobra.Command{Use: "create"}
+	stageCmd.SetContext(cmd.Context())
+
+	assert.Same(t, pkgcmd.ProjectLock(cmd), pkgcmd.ProjectLock(stageCmd))
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// PprofFlagName is the hidden root persistent flag naming the directory that receives
+	// CPU and heap profiles of the command run.
+	PprofFlagName = "pprof"
+	// TraceFlagName is the hidden root persistent flag naming the file that receives a
+	// runtime execution trace of the command run.
+	TraceFlagName = "trace"
+
+	// CPUProfileFileName is the CPU profile written to the --pprof directory.
+	CPUProfileFileName = "cpu.pprof"
+	// HeapProfileFileName is the heap profile written to the --pprof directory.
+	HeapProfileFileName = "heap.pprof"
+
+	profileDirPerm = 0o750
+)
+
+var errProfilingActive = errors.New("profiling is already active for this command")
+
+//nolint:gochecknoglobals // profiling spans PersistentPreRunE and Execute, which share only the root command
+var activeProfiles sync.Map
+
+// AddProfilingFlags registers the hidden --pprof and --trace flags on the root command.
+func AddProfilingFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		PprofFlagName,
+		"",
+		"Write CPU and heap profiles of the command run to this directory",
+	)
+	cmd.PersistentFlags().String(
+		TraceFlagName,
+		"",
+		"Write a runtime execution trace of the command run to this file",
+	)
+
+	_ = cmd.PersistentFlags().MarkHidden(PprofFlagName)
+	_ = cmd.PersistentFlags().MarkHidden(TraceFlagName)
+}
+
+// StartProfiling starts the profiles requested by the --pprof and --trace flags.
+//
+// Profiling runs until StopProfiling is called with any command of the same tree, which
+// lets it cover the whole run including commands that fail.
+func StartProfiling(cmd *cobra.Command) error {
+	if cmd == nil {
+		return errNilCommand
+	}
+
+	profileDir := lookupStringFlag(cmd, PprofFlagName)
+	tracePath := lookupStringFlag(cmd, TraceFlagName)
+
+	if profileDir == "" && tracePath == "" {
+		return nil
+	}
+
+	profiles := &runProfiles{profileDir: profileDir}
+
+	_, loaded := activeProfiles.LoadOrStore(cmd.Root(), profiles)
+	if loaded {
+		return errProfilingActive
+	}
+
+	err := profiles.start(tracePath)
+	if err != nil {
+		activeProfiles.Delete(cmd.Root())
+
+		return errors.Join(err, profiles.stop())
+	}
+
+	return nil
+}
+
+// StopProfiling stops the profiles started for the command tree of cmd and writes them out.
+// It does nothing when no profiling was requested.
+func StopProfiling(cmd *cobra.Command) error {
+	if cmd == nil {
+		return nil
+	}
+
+	value, ok := activeProfiles.LoadAndDelete(cmd.Root())
+	if !ok {
+		return nil
+	}
+
+	profiles, _ := value.(*runProfiles)
+
+	return profiles.stop()
+}
+
+// runProfiles holds the profile files of one command run.
+type runProfiles struct {
+	profileDir string
+	cpuFile    *os.File
+	traceFile  *os.File
+}
+
+func (p *runProfiles) start(tracePath string) error {
+	if p.profileDir != "" {
+		err := os.MkdirAll(p.profileDir, profileDirPerm)
+		if err != nil {
+			return fmt.Errorf("create profile directory: %w", err)
+		}
+
+		p.cpuFile, err = os.Create(filepath.Join(p.profileDir, CPUProfileFileName))
+		if err != nil {
+			return fmt.Errorf("create CPU profile: %w", err)
+		}
+
+		err = pprof.StartCPUProfile(p.cpuFile)
+		if err != nil {
+			return fmt.Errorf("start CPU profile: %w", err)
+		}
+	}
+
+	if tracePath != "" {
+		var err error
+
+		p.traceFile, err = os.Create(filepath.Clean(tracePath))
+		if err != nil {
+			return fmt.Errorf("create trace file: %w", err)
+		}
+
+		err = trace.Start(p.traceFile)
+		if err != nil {
+			return fmt.Errorf("start trace: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *runProfiles) stop() error {
+	var errs []error
+
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+
+		errs = append(errs, closeProfile(p.cpuFile, "CPU profile"))
+		errs = append(errs, p.writeHeapProfile())
+	}
+
+	if p.traceFile != nil {
+		trace.Stop()
+
+		errs = append(errs, closeProfile(p.traceFile, "trace"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *runProfiles) writeHeapProfile() error {
+	file, err := os.Create(filepath.Join(p.profileDir, HeapProfileFileName))
+	if err != nil {
+		return fmt.Errorf("create heap profile: %w", err)
+	}
+
+	// Collect garbage first so the profile reflects live memory, not pending garbage.
+	runtime.GC()
+
+	err = pprof.WriteHeapProfile(file)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("write heap profile: %w", err)
+	}
+
+	return closeProfile(file, "heap profile")
+}
+
+func closeProfile(file *os.File, name string) error {
+	err := file.Close()
+	if err != nil {
+		return fmt.Errorf("close %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func lookupStringFlag(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.InheritedFlags().Lookup(name)
+	}
+
+	if flag == nil {
+		return ""
+	}
+
+	return flag.Value.String()
+}
@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProfiledRoot(run func()) *cobra.Command {
+	root := &cobra.Command{
+		Use: "root",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return pkgcmd.StartProfiling(cmd)
+		},
+	}
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	pkgcmd.AddProfilingFlags(root)
+
+	root.AddCommand(&cobra.Command{
+		Use: "child",
+		Run: func(*cobra.Command, []string) { run() },
+	})
+
+	return root
+}
+
+//nolint:paralleltest // The CPU profiler and tracer are process-wide.
+func TestProfilingWritesRequestedProfiles(t *testing.T) {
+	dir := t.TempDir()
+	profileDir := filepath.Join(dir, "profiles")
+	tracePath := filepath.Join(dir, "run.trace")
+
+	root := newProfiledRoot(func() {
+		buffer := make([]byte, 0, 1024)
+		for i := range 1024 {
+			buffer = append(buffer, byte(i))
+		}
+	})
+	root.SetArgs([]string{"child", "--pprof", profileDir, "--trace", tracePath})
+
+	require.NoError(t, root.Execute())
+	require.NoError(t, pkgcmd.StopProfiling(root))
+
+	for _, path := range []string{
+		filepath.Join(profileDir, pkgcmd.CPUProfileFileName),
+		filepath.Join(profileDir, pkgcmd.HeapProfileFileName),
+		tracePath,
+	} {
+		assert.FileExists(t, path)
+	}
+
+	// A second stop has nothing left to write.
+	require.NoError(t, pkgcmd.StopProfiling(root))
+}
+
+func TestProfilingIsOptIn(t *testing.T) {
+	t.Parallel()
+
+	ran := false
+	root := newProfiledRoot(func() { ran = true })
+	root.SetArgs([]string{"child"})
+
+	require.NoError(t, root.Execute())
+	require.NoError(t, pkgcmd.StopProfiling(root))
+	assert.True(t, ran)
+}
+
+func TestProfilingFlagsAreHidden(t *testing.T) {
+	t.Parallel()
+
+	root := newProfiledRoot(func() {})
+
+	for _, name := range []string{pkgcmd.PprofFlagName, pkgcmd.TraceFlagName} {
+		flag := root.PersistentFlags().Lookup(name)
+		require.NotNil(t, flag)
+		assert.True(t, flag.Hidden)
+	}
+}
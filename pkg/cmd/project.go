@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/spf13/cobra"
+)
+
+type projectConfigKey struct{}
+
+// BindProjectConfig makes ProjectPath resolve the project files of cmd, and of every command
+// that later shares its context, next to the ksail.yaml cfgManager loads.
+func BindProjectConfig(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cmd.SetContext(context.WithValue(ctx, projectConfigKey{}, cfgManager))
+}
+
+// ProjectPath resolves name, a path relative to the project directory, against the directory of
+// the ksail.yaml loaded for cmd. Commands without a bound configuration locate ksail.yaml from
+// the working directory upwards, the way config discovery does.
+func ProjectPath(cmd *cobra.Command, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
+	if ctx := cmd.Context(); ctx != nil {
+		if cfgManager, ok := ctx.Value(projectConfigKey{}).(*ksailconfigmanager.ConfigManager); ok {
+			return filepath.Join(cfgManager.ProjectDir(), name)
+		}
+	}
+
+	return filepath.Join(ksailconfigmanager.ProjectDir(""), name)
+}
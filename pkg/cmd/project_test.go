@@ -0,0 +1,74 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+const pinnedDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000001"
+
+// newNestedProject writes a project with ksail.yaml and files at its root, and changes into the
+// directory two levels below it, which it returns.
+func newNestedProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	files["ksail.yaml"] = "apiVersion: ksail.dev/v1alpha1\nkind: Cluster\n"
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0o600))
+	}
+
+	nestedDir := filepath.Join(projectDir, "k8s", "apps")
+	require.NoError(t, os.MkdirAll(nestedDir, 0o750))
+	t.Chdir(nestedDir)
+
+	return nestedDir
+}
+
+//nolint:paralleltest // Uses t.Chdir to run from a directory below the project root.
+func TestProjectChecksumsUsesTheSumFileNextToKsailYAMLFromANestedDirectory(t *testing.T) {
+	nestedDir := newNestedProject(t, map[string]string{
+		checksum.DefaultPath: "chart.tgz " + pinnedDigest + "\n",
+	})
+
+	store := pkgcmd.ProjectChecksums(&cobra.Command{Use: "create"})
+
+	digest, ok, err := store.Pinned("chart.tgz")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, pinnedDigest, digest)
+
+	err = store.Verify("chart.tgz", "sha256:ffff")
+	require.ErrorIs(t, err, checksum.ErrChecksumMismatch)
+	require.NoFileExists(t, filepath.Join(nestedDir, checksum.DefaultPath))
+}
+
+//nolint:paralleltest // Uses t.Chdir to run from a directory below the project root.
+func TestProjectLockUsesTheLockNextToKsailYAMLFromANestedDirectory(t *testing.T) {
+	nestedDir := newNestedProject(t, map[string]string{
+		lockfile.DefaultPath: "images:\n  nginx:1.27: " + pinnedDigest + "\n",
+	})
+
+	cmd := &cobra.Command{Use: "create"}
+	pkgcmd.AddFrozenLockfileFlag(cmd)
+	require.NoError(t, cmd.Flags().Set(pkgcmd.FrozenLockfileFlagName, "true"))
+
+	lock := pkgcmd.ProjectLock(cmd)
+
+	digest, ok, err := lock.Image("nginx:1.27")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, pinnedDigest, digest)
+
+	err = lock.RecordImage("nginx:1.27", "sha256:ffff")
+	require.ErrorIs(t, err, lockfile.ErrOutOfDate)
+	require.NoFileExists(t, filepath.Join(nestedDir, lockfile.DefaultPath))
+}
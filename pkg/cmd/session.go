@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+)
+
+// CreateClusterProvisioner resolves the provisioner and distribution config for clusterCfg.
+// Within a DI session the result is shared by every layer of the invocation; otherwise the
+// factory is called directly.
+//
+//nolint:ireturn // provisioners are exposed through their interface.
+func CreateClusterProvisioner(
+	cmd *cobra.Command,
+	factory clusterprovisioner.Factory,
+	clusterCfg *v1alpha1.Cluster,
+) (clusterprovisioner.ClusterProvisioner, any, error) {
+	if session := runtime.SessionFromContext(cmd.Context()); session != nil {
+		//nolint:wrapcheck // callers wrap factory errors
+		return session.ClusterProvisioner(cmd.Context(), factory, clusterCfg)
+	}
+
+	//nolint:wrapcheck // callers wrap factory errors
+	return factory.Create(cmd.Context(), clusterCfg)
+}
+
+// BuildRESTConfig builds the REST config for kubeconfig and context, reusing the one the DI
+// session already built for the same kubeconfig file.
+func BuildRESTConfig(cmd *cobra.Command, kubeconfig, context string) (*rest.Config, error) {
+	if session := runtime.SessionFromContext(cmd.Context()); session != nil {
+		restConfig, err := session.RESTConfig(kubeconfig, context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build rest config: %w", err)
+		}
+
+		return restConfig, nil
+	}
+
+	restConfig, err := k8s.BuildRESTConfig(kubeconfig, context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rest config: %w", err)
+	}
+
+	return restConfig, nil
+}
@@ -0,0 +1,151 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	pkgcmd "github.com/devantler-tech/ksail-go/pkg/cmd"
+	runtime "github.com/devantler-tech/ksail-go/pkg/di"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func sessionKubeconfig(server string) string {
+	return `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + server + `
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`
+}
+
+func newSessionCommand(withSession bool) *cobra.Command {
+	cmd := &cobra.Command{}
+
+	ctx := context.Background()
+	if withSession {
+		ctx = runtime.ContextWithSession(ctx, runtime.NewSession())
+	}
+
+	cmd.SetContext(ctx)
+
+	return cmd
+}
+
+func TestCreateClusterProvisionerSharesResultWithinSession(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	factory := clusterprovisioner.NewMockFactory(t)
+	factory.EXPECT().
+		Create(mock.Anything, clusterCfg).
+		Return(provisioner, "kind-config", nil).
+		Once()
+
+	cmd := newSessionCommand(true)
+
+	for range 2 {
+		got, distributionConfig, err := pkgcmd.CreateClusterProvisioner(cmd, factory, clusterCfg)
+		require.NoError(t, err)
+		assert.Same(t, provisioner, got)
+		assert.Equal(t, "kind-config", distributionConfig)
+	}
+}
+
+func TestCreateClusterProvisionerSharesResultAcrossLoadedConfigs(t *testing.T) {
+	t.Parallel()
+
+	first := v1alpha1.NewCluster()
+	first.Spec.Distribution = v1alpha1.DistributionKind
+	first.Spec.DistributionConfig = "kind.yaml"
+
+	reloaded := first.DeepCopy()
+
+	other := first.DeepCopy()
+	other.Spec.Distribution = v1alpha1.DistributionK3d
+	other.Spec.DistributionConfig = "k3d.yaml"
+
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	factory := clusterprovisioner.NewMockFactory(t)
+	factory.EXPECT().Create(mock.Anything, first).Return(provisioner, "kind-config", nil).Once()
+	factory.EXPECT().Create(mock.Anything, other).Return(provisioner, "k3d-config", nil).Once()
+
+	cmd := newSessionCommand(true)
+
+	_, distributionConfig, err := pkgcmd.CreateClusterProvisioner(cmd, factory, first)
+	require.NoError(t, err)
+	assert.Equal(t, "kind-config", distributionConfig)
+
+	_, distributionConfig, err = pkgcmd.CreateClusterProvisioner(cmd, factory, reloaded)
+	require.NoError(t, err)
+	assert.Equal(t, "kind-config", distributionConfig)
+
+	_, distributionConfig, err = pkgcmd.CreateClusterProvisioner(cmd, factory, other)
+	require.NoError(t, err)
+	assert.Equal(t, "k3d-config", distributionConfig)
+}
+
+func TestCreateClusterProvisionerWithoutSessionCallsFactory(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	factory := clusterprovisioner.NewMockFactory(t)
+	factory.EXPECT().
+		Create(mock.Anything, clusterCfg).
+		Return(provisioner, nil, nil).
+		Twice()
+
+	cmd := newSessionCommand(false)
+
+	for range 2 {
+		_, _, err := pkgcmd.CreateClusterProvisioner(cmd, factory, clusterCfg)
+		require.NoError(t, err)
+	}
+}
+
+func TestBuildRESTConfigRebuildsAfterKubeconfigChanges(t *testing.T) {
+	t.Parallel()
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(sessionKubeconfig("https://127.0.0.1:1")), 0o600))
+
+	cmd := newSessionCommand(true)
+
+	first, err := pkgcmd.BuildRESTConfig(cmd, kubeconfig, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:1", first.Host)
+
+	// Callers get copies, so changing one does not leak into the shared config.
+	first.Host = "changed"
+
+	second, err := pkgcmd.BuildRESTConfig(cmd, kubeconfig, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:1", second.Host)
+
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(sessionKubeconfig("https://127.0.0.1:22")), 0o600))
+
+	rebuilt, err := pkgcmd.BuildRESTConfig(cmd, kubeconfig, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:22", rebuilt.Host)
+}
+
+func TestBuildRESTConfigReportsMissingKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := pkgcmd.BuildRESTConfig(newSessionCommand(true), "", "")
+	require.Error(t, err)
+}
@@ -9,6 +9,8 @@
 // Runtime: A container that manages dependency registration and lifecycle.
 // Module: A function that registers one or more dependencies with the injector.
 // Injector: The underlying DI container (aliased from samber/do).
+// Session: A per-invocation cache of resolved state (provisioners, Docker clients, REST configs)
+// that RunEWithRuntime places in the command's context so nested layers reuse it.
 //
 // # Usage
 //
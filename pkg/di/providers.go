@@ -1,6 +1,7 @@
 package di
 
 import (
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 	"github.com/samber/do/v2"
@@ -9,11 +10,14 @@ import (
 // Dependency providers.
 
 // NewRuntime constructs the shared runtime container used by root command and tests.
-// It registers default implementations for timer and cluster provisioner factory.
+// It registers default implementations for timer, cluster provisioner factory, cipher backend
+// and the invocation's session.
 func NewRuntime() *Runtime {
 	return New(
 		provideTimer,
 		provideClusterProvisionerFactory,
+		provideCipherBackend,
+		provideSession,
 	)
 }
 
@@ -34,3 +38,21 @@ func provideClusterProvisionerFactory(i Injector) error {
 
 	return nil
 }
+
+// provideCipherBackend registers the SOPS cipher backend dependency.
+func provideCipherBackend(i Injector) error {
+	do.Provide(i, func(Injector) (ciphersvc.Backend, error) {
+		return ciphersvc.NewDefaultBackend(), nil
+	})
+
+	return nil
+}
+
+// provideSession registers the per-invocation session that caches resolved state.
+func provideSession(i Injector) error {
+	do.Provide(i, func(Injector) (*Session, error) {
+		return NewSession(), nil
+	})
+
+	return nil
+}
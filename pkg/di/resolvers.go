@@ -3,6 +3,7 @@ package di
 import (
 	"fmt"
 
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 	"github.com/samber/do/v2"
@@ -38,6 +39,17 @@ func ResolveClusterProvisionerFactory(
 	return factory, nil
 }
 
+// ResolveCipherBackend retrieves the SOPS cipher backend dependency from the injector
+// with consistent error handling.
+func ResolveCipherBackend(injector Injector) (ciphersvc.Backend, error) {
+	backend, err := do.Invoke[ciphersvc.Backend](injector)
+	if err != nil {
+		return ciphersvc.Backend{}, fmt.Errorf("resolve cipher backend dependency: %w", err)
+	}
+
+	return backend, nil
+}
+
 // Handler decorators.
 
 // WithTimer decorates a handler to automatically resolve the timer dependency.
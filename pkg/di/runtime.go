@@ -1,6 +1,8 @@
 package di
 
 import (
+	"context"
+
 	"github.com/samber/do/v2"
 	"github.com/spf13/cobra"
 )
@@ -72,7 +74,34 @@ func RunEWithRuntime(
 ) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
 		return runtimeContainer.Invoke(func(injector Injector) error {
+			restore := shareSession(cmd, injector)
+			defer restore()
+
 			return handler(cmd, injector)
 		})
 	}
 }
+
+// shareSession makes the injector's session reachable through the command's context, so the
+// layers the handler calls into reuse what it resolves. Commands run from within another
+// command keep the outer command's session. The returned function restores the context.
+func shareSession(cmd *cobra.Command, injector Injector) func() {
+	previous := cmd.Context()
+	if SessionFromContext(previous) != nil {
+		return func() {}
+	}
+
+	session, err := ResolveSession(injector)
+	if err != nil {
+		return func() {}
+	}
+
+	ctx := previous
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cmd.SetContext(ContextWithSession(ctx, session))
+
+	return func() { cmd.SetContext(previous) }
+}
@@ -0,0 +1,210 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/docker/docker/client"
+	"github.com/samber/do/v2"
+	"k8s.io/client-go/rest"
+)
+
+// Session caches state resolved during one command invocation.
+//
+// Layers of a command that each need the cluster provisioner, a Docker client or a REST config
+// resolve them through the session, so the configuration is loaded and the daemon dialed once
+// per invocation instead of once per layer. The session lives in the invocation's injector and
+// is shut down with it, which closes the Docker clients it opened.
+type Session struct {
+	mutex         sync.Mutex
+	dockerClients map[string]client.APIClient
+	restConfigs   map[restConfigKey]*rest.Config
+	provisioners  map[provisionerKey]resolvedProvisioner
+}
+
+type resolvedProvisioner struct {
+	provisioner        clusterprovisioner.ClusterProvisioner
+	distributionConfig any
+}
+
+// provisionerKey identifies the cluster a provisioner is created for by the settings the
+// factory reads, so configurations loaded separately for the same cluster share it.
+type provisionerKey struct {
+	distribution       v1alpha1.Distribution
+	distributionConfig string
+	clusterName        string
+	kubeconfig         string
+	context            string
+}
+
+func newProvisionerKey(clusterCfg *v1alpha1.Cluster) provisionerKey {
+	return provisionerKey{
+		distribution:       clusterCfg.Spec.Distribution,
+		distributionConfig: clusterCfg.Spec.DistributionConfig,
+		clusterName:        clusterCfg.Spec.ClusterName,
+		kubeconfig:         clusterCfg.Spec.Connection.Kubeconfig,
+		context:            clusterCfg.Spec.Connection.Context,
+	}
+}
+
+// restConfigKey identifies a kubeconfig as it was on disk, so configs are rebuilt after the
+// file is rewritten, for example once a cluster has been created.
+type restConfigKey struct {
+	path    string
+	context string
+	modTime time.Time
+	size    int64
+}
+
+type sessionContextKey struct{}
+
+// NewSession creates an empty session.
+func NewSession() *Session {
+	return &Session{
+		dockerClients: map[string]client.APIClient{},
+		restConfigs:   map[restConfigKey]*rest.Config{},
+		provisioners:  map[provisionerKey]resolvedProvisioner{},
+	}
+}
+
+// ContextWithSession returns a copy of ctx carrying session.
+func ContextWithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the session carried by ctx, or nil when there is none.
+func SessionFromContext(ctx context.Context) *Session {
+	if ctx == nil {
+		return nil
+	}
+
+	session, _ := ctx.Value(sessionContextKey{}).(*Session)
+
+	return session
+}
+
+// ResolveSession retrieves the invocation's session from the injector with consistent error handling.
+func ResolveSession(injector Injector) (*Session, error) {
+	session, err := do.Invoke[*Session](injector)
+	if err != nil {
+		return nil, fmt.Errorf("resolve session dependency: %w", err)
+	}
+
+	return session, nil
+}
+
+// DockerClient returns the session's Docker client for the current DOCKER_HOST, dialing it on
+// first use. The client is owned by the session; callers must not close it.
+//
+//nolint:ireturn // Docker exposes its client as an interface.
+func (s *Session) DockerClient() (client.APIClient, error) {
+	host := os.Getenv(client.EnvOverrideHost)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if dockerClient, ok := s.dockerClients[host]; ok {
+		return dockerClient, nil
+	}
+
+	dockerClient, err := dockerclient.NewClientForHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("create docker client: %w", err)
+	}
+
+	s.dockerClients[host] = dockerClient
+
+	return dockerClient, nil
+}
+
+// RESTConfig returns a copy of the REST config for kubeconfig and context, building it once
+// for each version of the kubeconfig file.
+func (s *Session) RESTConfig(kubeconfig, context string) (*rest.Config, error) {
+	key := restConfigKey{path: kubeconfig, context: context}
+
+	info, err := os.Stat(kubeconfig)
+	if err == nil {
+		key.modTime = info.ModTime()
+		key.size = info.Size()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if restConfig, ok := s.restConfigs[key]; ok {
+		return rest.CopyConfig(restConfig), nil
+	}
+
+	restConfig, err := k8s.BuildRESTConfig(kubeconfig, context)
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	s.restConfigs[key] = restConfig
+
+	return rest.CopyConfig(restConfig), nil
+}
+
+// ClusterProvisioner returns the provisioner and distribution config factory creates for
+// clusterCfg, creating them once per distribution, distribution config and cluster name.
+// Failures and nil configurations are not cached.
+//
+//nolint:ireturn // provisioners are exposed through their interface.
+func (s *Session) ClusterProvisioner(
+	ctx context.Context,
+	factory clusterprovisioner.Factory,
+	clusterCfg *v1alpha1.Cluster,
+) (clusterprovisioner.ClusterProvisioner, any, error) {
+	if clusterCfg == nil {
+		return factory.Create(ctx, clusterCfg) //nolint:wrapcheck // callers wrap factory errors as before
+	}
+
+	key := newProvisionerKey(clusterCfg)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if resolved, ok := s.provisioners[key]; ok {
+		return resolved.provisioner, resolved.distributionConfig, nil
+	}
+
+	provisioner, distributionConfig, err := factory.Create(ctx, clusterCfg)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck // callers wrap factory errors as before
+	}
+
+	s.provisioners[key] = resolvedProvisioner{
+		provisioner:        provisioner,
+		distributionConfig: distributionConfig,
+	}
+
+	return provisioner, distributionConfig, nil
+}
+
+// Shutdown closes the Docker clients opened by the session. The injector calls it when the
+// invocation ends.
+func (s *Session) Shutdown() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var errs []error
+
+	for host, dockerClient := range s.dockerClients {
+		err := dockerClient.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close docker client: %w", err))
+		}
+
+		delete(s.dockerClients, host)
+	}
+
+	return errors.Join(errs...)
+}
@@ -3,21 +3,179 @@ package configmanager
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	externalprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/external"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
 var errUnsupportedConfigType = errors.New("unsupported config type")
 
-// GetClusterName extracts the cluster name from supported Kind or K3d config structures.
+// GetClusterName extracts the cluster name from supported Kind, K3d, Talos, k0s, kwok or
+// external cluster config structures.
 func GetClusterName(config any) (string, error) {
 	switch cfg := config.(type) {
 	case *v1alpha4.Cluster:
 		return cfg.Name, nil
 	case *v1alpha5.SimpleConfig:
 		return cfg.Name, nil
+	case *talosprovisioner.Config:
+		return cfg.Name, nil
+	case *k0sprovisioner.Config:
+		return cfg.Name, nil
+	case *kwokprovisioner.Config:
+		return cfg.Name, nil
+	case *externalprovisioner.Config:
+		return cfg.Name, nil
 	default:
 		return "", fmt.Errorf("%w: %T", errUnsupportedConfigType, cfg)
 	}
 }
+
+// ApplyClusterName overrides the cluster name of a supported Kind, K3d, Talos, k0s or kwok config
+// structure.
+// An empty name keeps the name from the config.
+func ApplyClusterName(config any, name string) {
+	if name == "" {
+		return
+	}
+
+	switch cfg := config.(type) {
+	case *v1alpha4.Cluster:
+		if cfg != nil {
+			cfg.Name = name
+		}
+	case *v1alpha5.SimpleConfig:
+		if cfg != nil {
+			cfg.Name = name
+		}
+	case *talosprovisioner.Config:
+		if cfg != nil {
+			cfg.Name = name
+		}
+	case *k0sprovisioner.Config:
+		if cfg != nil {
+			cfg.Name = name
+		}
+	case *kwokprovisioner.Config:
+		if cfg != nil {
+			cfg.Name = name
+		}
+	}
+}
+
+// ApplyNodeCounts overrides the number of control-plane and worker nodes of a supported Kind,
+// K3d, Talos, k0s or kwok config structure. A zero count keeps the count from the config; k0s
+// clusters always have a single controller and the workers of kwok clusters are fake nodes.
+func ApplyNodeCounts(config any, controlPlanes, workers int32) {
+	switch cfg := config.(type) {
+	case *v1alpha4.Cluster:
+		if cfg == nil {
+			return
+		}
+
+		if controlPlanes > 0 {
+			SetKindNodeCount(cfg, v1alpha4.ControlPlaneRole, int(controlPlanes))
+		}
+
+		if workers > 0 {
+			SetKindNodeCount(cfg, v1alpha4.WorkerRole, int(workers))
+		}
+	case *v1alpha5.SimpleConfig:
+		if cfg == nil {
+			return
+		}
+
+		if controlPlanes > 0 {
+			cfg.Servers = int(controlPlanes)
+		}
+
+		if workers > 0 {
+			cfg.Agents = int(workers)
+		}
+	case *talosprovisioner.Config:
+		if cfg == nil {
+			return
+		}
+
+		if controlPlanes > 0 {
+			cfg.ControlPlanes = int(controlPlanes)
+		}
+
+		if workers > 0 {
+			cfg.Workers = int(workers)
+		}
+	case *k0sprovisioner.Config:
+		if cfg != nil && workers > 0 {
+			cfg.Workers = int(workers)
+		}
+	case *kwokprovisioner.Config:
+		if cfg != nil && workers > 0 {
+			cfg.Nodes = int(workers)
+		}
+	}
+}
+
+// ApplyDockerNetwork overrides the Docker network name and subnet of a K3d config structure.
+// Kind reads the network from the environment, so the Kind provisioner is given the name itself,
+// and the other distributions manage their own networks, so their configs are left unchanged.
+// K3d takes a single subnet, so only the first CIDR of a DualStack subnet is applied. Empty
+// values keep the values from the config.
+func ApplyDockerNetwork(config any, name, subnet string) {
+	cfg, ok := config.(*v1alpha5.SimpleConfig)
+	if !ok || cfg == nil {
+		return
+	}
+
+	if name != "" {
+		cfg.Network = name
+	}
+
+	if first, _, _ := strings.Cut(subnet, ","); strings.TrimSpace(first) != "" {
+		cfg.Subnet = strings.TrimSpace(first)
+	}
+}
+
+// SetKindNodeCount makes the Kind config declare count nodes of the role. Surplus nodes are
+// dropped from the end; missing ones copy the last node of the role without its host port
+// mappings, which only one node can publish. A config without nodes stands for the single
+// control-plane node Kind creates by default.
+func SetKindNodeCount(cfg *v1alpha4.Cluster, role v1alpha4.NodeRole, count int) {
+	nodes := cfg.Nodes
+	if len(nodes) == 0 {
+		nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	var matching, others []v1alpha4.Node
+
+	for _, node := range nodes {
+		if node.Role == role {
+			matching = append(matching, node)
+		} else {
+			others = append(others, node)
+		}
+	}
+
+	template := v1alpha4.Node{Role: role}
+	if len(matching) > 0 {
+		template = matching[len(matching)-1]
+		template.ExtraPortMappings = nil
+	}
+
+	for len(matching) < count {
+		matching = append(matching, template)
+	}
+
+	matching = matching[:count]
+
+	// Kind lists control-plane nodes first.
+	if role == v1alpha4.ControlPlaneRole {
+		cfg.Nodes = append(matching, others...)
+	} else {
+		cfg.Nodes = append(others, matching...)
+	}
+}
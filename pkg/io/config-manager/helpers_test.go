@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	configtypes "github.com/k3d-io/k3d/v5/pkg/config/types"
 	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/stretchr/testify/require"
@@ -30,6 +32,14 @@ func TestGetClusterName(t *testing.T) {
 			},
 			wantName: "k3d-custom",
 		},
+		"talos config": {
+			config:   &talosprovisioner.Config{Name: "talos-custom"},
+			wantName: "talos-custom",
+		},
+		"k0s config": {
+			config:   k0sprovisioner.NewConfig("k0s-custom"),
+			wantName: "k0s-custom",
+		},
 		"unsupported type": {
 			config:  123,
 			wantErr: true,
@@ -52,3 +62,80 @@ func TestGetClusterName(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyNodeCounts(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &v1alpha4.Cluster{}
+	configmanager.ApplyNodeCounts(kindConfig, 3, 2)
+	require.Equal(t, []v1alpha4.NodeRole{
+		v1alpha4.ControlPlaneRole,
+		v1alpha4.ControlPlaneRole,
+		v1alpha4.ControlPlaneRole,
+		v1alpha4.WorkerRole,
+		v1alpha4.WorkerRole,
+	}, kindNodeRoles(kindConfig))
+
+	k3dConfig := &v1alpha5.SimpleConfig{Servers: 1, Agents: 4}
+	configmanager.ApplyNodeCounts(k3dConfig, 3, 0)
+	require.Equal(t, 3, k3dConfig.Servers)
+	require.Equal(t, 4, k3dConfig.Agents, "zero keeps the count of the config")
+
+	talosConfig := talosprovisioner.NewDefaultConfig()
+	configmanager.ApplyNodeCounts(talosConfig, 3, 2)
+	require.Equal(t, 3, talosConfig.ControlPlanes)
+	require.Equal(t, 2, talosConfig.Workers)
+
+	k0sConfig := k0sprovisioner.NewConfig("")
+	configmanager.ApplyNodeCounts(k0sConfig, 3, 2)
+	require.Equal(t, 2, k0sConfig.Workers)
+}
+
+func TestApplyDockerNetwork(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{Network: "from-config", Subnet: "172.28.0.0/16"}
+	configmanager.ApplyDockerNetwork(k3dConfig, "dev", "")
+	require.Equal(t, "dev", k3dConfig.Network)
+	require.Equal(t, "172.28.0.0/16", k3dConfig.Subnet, "empty keeps the subnet of the config")
+
+	configmanager.ApplyDockerNetwork(k3dConfig, "", "172.30.0.0/16, fd00:30::/64")
+	require.Equal(t, "172.30.0.0/16", k3dConfig.Subnet)
+
+	kindConfig := &v1alpha4.Cluster{}
+	configmanager.ApplyDockerNetwork(kindConfig, "dev", "172.30.0.0/16")
+	require.Equal(t, &v1alpha4.Cluster{}, kindConfig)
+}
+
+func TestSetKindNodeCountKeepsNodeSettings(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &v1alpha4.Cluster{
+		Nodes: []v1alpha4.Node{
+			{
+				Role:              v1alpha4.ControlPlaneRole,
+				ExtraPortMappings: []v1alpha4.PortMapping{{ContainerPort: 80, HostPort: 8080}},
+			},
+			{Role: v1alpha4.WorkerRole, Labels: map[string]string{"tier": "a"}},
+			{Role: v1alpha4.WorkerRole, Labels: map[string]string{"tier": "b"}},
+		},
+	}
+
+	configmanager.SetKindNodeCount(kindConfig, v1alpha4.ControlPlaneRole, 2)
+	configmanager.SetKindNodeCount(kindConfig, v1alpha4.WorkerRole, 1)
+
+	require.Len(t, kindConfig.Nodes, 3)
+	require.Len(t, kindConfig.Nodes[0].ExtraPortMappings, 1)
+	require.Empty(t, kindConfig.Nodes[1].ExtraPortMappings, "host ports are published by one node only")
+	require.Equal(t, v1alpha4.ControlPlaneRole, kindConfig.Nodes[1].Role)
+	require.Equal(t, "a", kindConfig.Nodes[2].Labels["tier"])
+}
+
+func kindNodeRoles(cfg *v1alpha4.Cluster) []v1alpha4.NodeRole {
+	roles := make([]v1alpha4.NodeRole, 0, len(cfg.Nodes))
+	for _, node := range cfg.Nodes {
+		roles = append(roles, node.Role)
+	}
+
+	return roles
+}
@@ -0,0 +1,5 @@
+// Package k0s provides configuration management for k0s clusters.
+//
+// This package contains the core Manager implementation for loading the k0s.yaml
+// distribution configuration from files.
+package k0s
@@ -0,0 +1,59 @@
+package k0s
+
+import (
+	"fmt"
+
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/helpers"
+	k0svalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/k0s"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+)
+
+// ConfigManager implements configuration management for k0s.yaml configurations.
+// It provides file-based configuration loading without Viper dependency.
+type ConfigManager struct {
+	configPath   string
+	config       *k0sprovisioner.Config
+	configLoaded bool
+}
+
+// Compile-time interface compliance verification.
+var _ configmanager.ConfigManager[k0sprovisioner.Config] = (*ConfigManager)(nil)
+
+// NewConfigManager creates a new configuration manager for k0s cluster configurations.
+// configPath specifies the path to the k0s configuration file to load.
+func NewConfigManager(configPath string) *ConfigManager {
+	return &ConfigManager{
+		configPath:   configPath,
+		config:       nil,
+		configLoaded: false,
+	}
+}
+
+// LoadConfig loads the k0s configuration from the specified file.
+// Returns the loaded config, either freshly loaded or previously cached.
+// If the file doesn't exist, returns a default k0s cluster configuration.
+// Validates the configuration after loading and returns an error if validation fails.
+// The timer parameter is accepted for interface compliance but not currently used.
+func (m *ConfigManager) LoadConfig(_ timer.Timer) (*k0sprovisioner.Config, error) {
+	if m.configLoaded {
+		return m.config, nil
+	}
+
+	config, err := helpers.LoadAndValidateConfig(
+		m.configPath,
+		func() *k0sprovisioner.Config {
+			return k0sprovisioner.NewConfig("")
+		},
+		k0svalidator.NewValidator(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load k0s config: %w", err)
+	}
+
+	m.config = config
+	m.configLoaded = true
+
+	return m.config, nil
+}
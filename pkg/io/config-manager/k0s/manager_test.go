@@ -0,0 +1,59 @@
+package k0s_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/k0s"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigDefaultsWhenFileIsMissing(t *testing.T) {
+	t.Parallel()
+
+	manager := k0s.NewConfigManager(filepath.Join(t.TempDir(), "k0s.yaml"))
+
+	config, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, k0sprovisioner.NewConfig(""), config)
+}
+
+func TestLoadConfigReadsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "k0s.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: k0s.ksail.dev/v1alpha1
+kind: Cluster
+name: dev
+workers: 2
+apiServerPort: 6443
+`), 0o600))
+
+	manager := k0s.NewConfigManager(path)
+
+	config, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", config.Name)
+	assert.Equal(t, 2, config.Workers)
+	assert.Equal(t, int32(6443), config.APIServerPort)
+
+	cached, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Same(t, config, cached)
+}
+
+func TestLoadConfigRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "k0s.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: k0s.ksail.dev/v1alpha1
+kind: Cluster
+workers: -1
+`), 0o600))
+
+	_, err := k0s.NewConfigManager(path).LoadConfig(nil)
+	require.Error(t, err)
+}
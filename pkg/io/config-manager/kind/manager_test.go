@@ -6,6 +6,7 @@ import (
 	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
 	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/kind"
 	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/testutils"
+	sharedtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
 	"github.com/stretchr/testify/assert"
 	v1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
@@ -163,3 +164,18 @@ name: cached
 		},
 	)
 }
+
+// FuzzLoadConfig checks that loading arbitrary kind.yaml files returns either a config or an
+// error and never panics.
+func FuzzLoadConfig(f *testing.F) {
+	sharedtestutils.AddYAMLFuzzCorpus(f, sharedtestutils.KindConfigSeeds()...)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := sharedtestutils.WriteFuzzInput(t, "kind.yaml", data)
+
+		config, err := kind.NewConfigManager(path).LoadConfig(nil)
+		if err == nil {
+			assert.NotNil(t, config)
+		}
+	})
+}
@@ -172,19 +172,27 @@ func (m *ConfigManager) handleDurationFlag(
 // We initialize this map once and reuse it for better performance.
 func (m *ConfigManager) getFieldMappings() map[any]string {
 	return map[any]string{
-		&m.Config.Spec.Distribution:                   "distribution",
-		&m.Config.Spec.DistributionConfig:             "distribution-config",
-		&m.Config.Spec.SourceDirectory:                "source-directory",
-		&m.Config.Spec.Connection.Context:             "context",
-		&m.Config.Spec.Connection.Kubeconfig:          "kubeconfig",
-		&m.Config.Spec.Connection.Timeout:             "timeout",
-		&m.Config.Spec.GitOpsEngine:                   "gitops-engine",
-		&m.Config.Spec.CNI:                            "cni",
-		&m.Config.Spec.CSI:                            "csi",
-		&m.Config.Spec.MetricsServer:                  "metrics-server",
-		&m.Config.Spec.LocalRegistry:                  "local-registry",
-		&m.Config.Spec.Options.LocalRegistry.HostPort: "local-registry-port",
-		&m.Config.Spec.Options.Flux.Interval:          "flux-interval",
+		&m.Config.Spec.Distribution:                     "distribution",
+		&m.Config.Spec.DistributionConfig:               "distribution-config",
+		&m.Config.Spec.SourceDirectory:                  "source-directory",
+		&m.Config.Spec.Connection.Context:               "context",
+		&m.Config.Spec.Connection.Kubeconfig:            "kubeconfig",
+		&m.Config.Spec.Connection.Timeout:               "timeout",
+		&m.Config.Spec.GitOpsEngine:                     "gitops-engine",
+		&m.Config.Spec.CNI:                              "cni",
+		&m.Config.Spec.CSI:                              "csi",
+		&m.Config.Spec.MetricsServer:                    "metrics-server",
+		&m.Config.Spec.ControlPlaneNodes:                "control-plane-nodes",
+		&m.Config.Spec.WorkerNodes:                      "worker-nodes",
+		&m.Config.Spec.Networking.IPFamily:              "ip-family",
+		&m.Config.Spec.Networking.PodSubnet:             "pod-subnet",
+		&m.Config.Spec.Networking.ServiceSubnet:         "service-subnet",
+		&m.Config.Spec.Networking.DockerNetwork.Name:    "docker-network",
+		&m.Config.Spec.Networking.DockerNetwork.Subnet:  "docker-subnet",
+		&m.Config.Spec.Networking.DockerNetwork.Gateway: "docker-gateway",
+		&m.Config.Spec.LocalRegistry:                    "local-registry",
+		&m.Config.Spec.Options.LocalRegistry.HostPort:   "local-registry-port",
+		&m.Config.Spec.Options.Flux.Interval:            "flux-interval",
 	}
 }
 
@@ -263,6 +271,8 @@ func (m *ConfigManager) setPflagValueDefault(pflagValue interface {
 		_ = pflagValue.Set(string(val))
 	case v1alpha1.LocalRegistry:
 		_ = pflagValue.Set(string(val))
+	case v1alpha1.IPFamily:
+		_ = pflagValue.Set(string(val))
 	default:
 		if str, ok := val.(string); ok {
 			_ = pflagValue.Set(str)
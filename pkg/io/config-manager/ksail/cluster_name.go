@@ -0,0 +1,230 @@
+package configmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// AutoClusterName is the spec.clusterName value that selects a collision-safe name derived
+	// from the project, the Git branch and, in CI, the job that runs KSail.
+	AutoClusterName = "auto"
+	// AutoClusterNameTemplate is the template AutoClusterName expands to.
+	AutoClusterNameTemplate = "{{ .Project }}-{{ .GitBranch }}-{{ .Unique }}"
+	// MaxClusterNameLength is the longest cluster name KSail produces. It matches k3d's limit,
+	// the stricter of the supported distributions.
+	MaxClusterNameLength = 32
+
+	uniqueSuffixLength = 8
+	gitBranchTimeout   = 5 * time.Second
+)
+
+// ErrClusterNameEmpty is returned when spec.clusterName renders to an empty name.
+var ErrClusterNameEmpty = errors.New("cluster name template rendered an empty name")
+
+//nolint:gochecknoglobals // environment variables CI systems use to expose the current branch, in priority order
+var branchEnvVars = []string{
+	"GITHUB_HEAD_REF",
+	"GITHUB_REF_NAME",
+	"CI_COMMIT_REF_NAME",
+	"BUILDKITE_BRANCH",
+}
+
+//nolint:gochecknoglobals // environment variables that identify a CI job, mixed into .Unique
+var ciJobEnvVars = []string{
+	"GITHUB_RUN_ID",
+	"GITHUB_RUN_ATTEMPT",
+	"GITHUB_JOB",
+	"CI_JOB_ID",
+	"BUILDKITE_JOB_ID",
+}
+
+//nolint:gochecknoglobals // compiled once; matches runs of characters not allowed in cluster names
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ClusterNameData is the data spec.clusterName templates are rendered with.
+type ClusterNameData struct {
+	// Project is the name of the directory holding ksail.yaml.
+	Project string
+	// GitBranch is the current Git branch, taken from the CI environment when available.
+	GitBranch string
+	// Distribution is the lowercased distribution, such as kind or k3d.
+	Distribution string
+	// Unique is a short hash that differs between checkouts, branches and CI jobs.
+	Unique string
+}
+
+// ResolveClusterName renders a spec.clusterName template into a cluster name.
+//
+// The rendered name is reduced to lowercase letters, digits and hyphens. Names longer than
+// MaxClusterNameLength are shortened and suffixed with a hash of the full name, so distinct
+// long names stay distinct. An empty template resolves to an empty name, which keeps the name
+// from the distribution config.
+func ResolveClusterName(nameTemplate string, data ClusterNameData) (string, error) {
+	nameTemplate = strings.TrimSpace(nameTemplate)
+	if nameTemplate == "" {
+		return "", nil
+	}
+
+	if strings.EqualFold(nameTemplate, AutoClusterName) {
+		nameTemplate = AutoClusterNameTemplate
+	}
+
+	tmpl, err := template.New("clusterName").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse cluster name template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+
+	err = tmpl.Execute(&rendered, data)
+	if err != nil {
+		return "", fmt.Errorf("render cluster name template: %w", err)
+	}
+
+	name := sanitizeClusterName(rendered.String())
+	if name == "" {
+		return "", fmt.Errorf("%w: %q", ErrClusterNameEmpty, nameTemplate)
+	}
+
+	return shortenClusterName(name), nil
+}
+
+// NewClusterNameData collects the template data for a project whose ksail.yaml lives in
+// projectDir. An empty projectDir means the current working directory.
+func NewClusterNameData(projectDir string, distribution v1alpha1.Distribution) ClusterNameData {
+	if projectDir == "" {
+		projectDir = "."
+	}
+
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		absDir = projectDir
+	}
+
+	branch := currentGitBranch(absDir)
+
+	uniqueParts := []string{absDir, branch}
+	for _, envVar := range ciJobEnvVars {
+		uniqueParts = append(uniqueParts, os.Getenv(envVar))
+	}
+
+	return ClusterNameData{
+		Project:      sanitizeClusterName(filepath.Base(absDir)),
+		GitBranch:    sanitizeClusterName(branch),
+		Distribution: strings.ToLower(string(distribution)),
+		Unique:       shortHash(strings.Join(uniqueParts, "\x00")),
+	}
+}
+
+// ContextNameForCluster returns the kube context a distribution creates for a cluster name,
+// or an empty string for distributions KSail does not name contexts for.
+func ContextNameForCluster(distribution v1alpha1.Distribution, clusterName string) string {
+	switch distribution {
+	case v1alpha1.DistributionKind:
+		return "kind-" + clusterName
+	case v1alpha1.DistributionK3d:
+		return "k3d-" + clusterName
+	case v1alpha1.DistributionTalos:
+		return "admin@" + clusterName
+	case v1alpha1.DistributionK0s:
+		return "k0s-" + clusterName
+	case v1alpha1.DistributionKwok:
+		return "kwok-" + clusterName
+	default:
+		return ""
+	}
+}
+
+// applyClusterName resolves spec.clusterName and points a defaulted context at the resolved
+// cluster, so every later step sees the same name.
+func (m *ConfigManager) applyClusterName() error {
+	if m.Config == nil || strings.TrimSpace(m.Config.Spec.ClusterName) == "" {
+		return nil
+	}
+
+	projectDir := ""
+	if configFile := m.Viper.ConfigFileUsed(); configFile != "" {
+		projectDir = filepath.Dir(configFile)
+	}
+
+	name, err := ResolveClusterName(
+		m.Config.Spec.ClusterName,
+		NewClusterNameData(projectDir, m.Config.Spec.Distribution),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve spec.clusterName: %w", err)
+	}
+
+	m.Config.Spec.ClusterName = name
+
+	connection := &m.Config.Spec.Connection
+	if connection.Context == "" ||
+		connection.Context == v1alpha1.ExpectedContextName(m.Config.Spec.Distribution) {
+		connection.Context = ContextNameForCluster(m.Config.Spec.Distribution, name)
+	}
+
+	return nil
+}
+
+func currentGitBranch(dir string) string {
+	for _, envVar := range branchEnvVars {
+		if branch := strings.TrimSpace(os.Getenv(envVar)); branch != "" {
+			return branch
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitBranchTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	branch := strings.TrimSpace(string(output))
+	if branch == "HEAD" {
+		// Detached HEAD has no branch name.
+		return ""
+	}
+
+	return branch
+}
+
+func sanitizeClusterName(name string) string {
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+
+	return strings.Trim(name, "-")
+}
+
+func shortenClusterName(name string) string {
+	if len(name) <= MaxClusterNameLength {
+		return name
+	}
+
+	prefix := strings.TrimRight(name[:MaxClusterNameLength-uniqueSuffixLength-1], "-")
+
+	return prefix + "-" + shortHash(name)
+}
+
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])[:uniqueSuffixLength]
+}
@@ -0,0 +1,130 @@
+package configmanager_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveClusterName(t *testing.T) {
+	t.Parallel()
+
+	data := configmanager.ClusterNameData{
+		Project:      "shop",
+		GitBranch:    "feature-login",
+		Distribution: "kind",
+		Unique:       "1a2b3c4d",
+	}
+
+	tests := map[string]struct {
+		template string
+		want     string
+		wantErr  error
+	}{
+		"empty template keeps distribution name": {template: "  ", want: ""},
+		"literal name":                           {template: "dev", want: "dev"},
+		"template": {
+			template: "{{ .Project }}-{{ .GitBranch }}",
+			want:     "shop-feature-login",
+		},
+		"auto": {template: "auto", want: "shop-feature-login-1a2b3c4d"},
+		"sanitized": {
+			template: "My_Project/{{ .Distribution }}--",
+			want:     "my-project-kind",
+		},
+		"empty render": {template: "{{ \"\" }}", wantErr: configmanager.ErrClusterNameEmpty},
+	}
+
+	for name, testCase := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, err := configmanager.ResolveClusterName(testCase.template, data)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, resolved)
+		})
+	}
+}
+
+func TestResolveClusterNameShortensLongNames(t *testing.T) {
+	t.Parallel()
+
+	data := configmanager.ClusterNameData{GitBranch: strings.Repeat("b", 40)}
+
+	first, err := configmanager.ResolveClusterName("a-{{ .GitBranch }}-one", data)
+	require.NoError(t, err)
+
+	second, err := configmanager.ResolveClusterName("a-{{ .GitBranch }}-two", data)
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, len(first), configmanager.MaxClusterNameLength)
+	assert.LessOrEqual(t, len(second), configmanager.MaxClusterNameLength)
+	assert.NotEqual(t, first, second)
+}
+
+func TestResolveClusterNameRejectsInvalidTemplates(t *testing.T) {
+	t.Parallel()
+
+	_, err := configmanager.ResolveClusterName("{{ .Owner }}", configmanager.ClusterNameData{})
+	require.Error(t, err)
+
+	_, err = configmanager.ResolveClusterName("{{ .Project", configmanager.ClusterNameData{})
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv to control the project and branch.
+func TestLoadConfigResolvesClusterName(t *testing.T) {
+	tempDir := filepath.Join(t.TempDir(), "Web_App")
+	require.NoError(t, os.Mkdir(tempDir, 0o750))
+	t.Chdir(tempDir)
+	t.Setenv("GITHUB_HEAD_REF", "feature/login")
+
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "  clusterName: \"{{ .Project }}-{{ .GitBranch }}\"")
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	cluster, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "web-app-feature-login", cluster.Spec.ClusterName)
+	assert.Equal(t, "kind-web-app-feature-login", cluster.Spec.Connection.Context)
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv to control the project and CI job.
+func TestLoadConfigAutoClusterNameDiffersPerCIJob(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GITHUB_HEAD_REF", "main")
+
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "  clusterName: auto")
+
+	load := func(runID string) string {
+		t.Setenv("GITHUB_RUN_ID", runID)
+
+		manager := configmanager.NewConfigManager(io.Discard)
+		manager.Viper.SetConfigFile("ksail.yaml")
+
+		cluster, err := manager.LoadConfig(nil)
+		require.NoError(t, err)
+
+		return cluster.Spec.ClusterName
+	}
+
+	first := load("1")
+
+	assert.Equal(t, first, load("1"))
+	assert.NotEqual(t, first, load("2"))
+}
@@ -3,4 +3,6 @@
 // Located at pkg/io/config-manager/ksail, this package contains the core Manager implementation for
 // handling cluster configurations, field selector binding functionality for automatic CLI flag
 // creation, and various field selection utilities for working with KSail cluster configurations.
+// SOPS-encrypted values in ksail.yaml, and in an optional ksail.secrets.yaml overlay, are
+// decrypted transparently while loading.
 package configmanager
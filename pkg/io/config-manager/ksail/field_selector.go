@@ -142,6 +142,74 @@ func DefaultMetricsServerFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	}
 }
 
+// DefaultControlPlaneNodesFieldSelector creates a standard field selector for the number of
+// control-plane nodes.
+func DefaultControlPlaneNodesFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector: func(c *v1alpha1.Cluster) any { return &c.Spec.ControlPlaneNodes },
+		Description: "Number of control-plane nodes; more than one creates an HA control plane " +
+			"(0 keeps the distribution config)",
+		DefaultValue: int32(0),
+	}
+}
+
+// DefaultWorkerNodesFieldSelector creates a standard field selector for the number of worker nodes.
+func DefaultWorkerNodesFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.WorkerNodes },
+		Description:  "Number of worker nodes (0 keeps the distribution config)",
+		DefaultValue: int32(0),
+	}
+}
+
+// DefaultIPFamilyFieldSelector creates a standard field selector for the IP family of the cluster.
+func DefaultIPFamilyFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.IPFamily },
+		Description: "IP family of the cluster network (IPv4, IPv6 or DualStack; defaults to IPv4)",
+	}
+}
+
+// DefaultPodSubnetFieldSelector creates a standard field selector for the pod CIDR.
+func DefaultPodSubnetFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.PodSubnet },
+		Description: "Pod CIDR; DualStack takes an IPv4 and an IPv6 CIDR separated by a comma",
+	}
+}
+
+// DefaultServiceSubnetFieldSelector creates a standard field selector for the service CIDR.
+func DefaultServiceSubnetFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.ServiceSubnet },
+		Description: "Service CIDR; DualStack takes an IPv4 and an IPv6 CIDR separated by a comma",
+	}
+}
+
+// DefaultDockerNetworkFieldSelector creates a standard field selector for the Docker network name.
+func DefaultDockerNetworkFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.DockerNetwork.Name },
+		Description: "Docker network the nodes and registries join (defaults to kind or k3d-<cluster name>)",
+	}
+}
+
+// DefaultDockerSubnetFieldSelector creates a standard field selector for the Docker network subnet.
+func DefaultDockerSubnetFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.DockerNetwork.Subnet },
+		Description: "Docker network CIDR, e.g. 172.30.0.0/16; DualStack takes an IPv4 and an IPv6 CIDR",
+	}
+}
+
+// DefaultDockerGatewayFieldSelector creates a standard field selector for the Docker network gateway.
+func DefaultDockerGatewayFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:    func(c *v1alpha1.Cluster) any { return &c.Spec.Networking.DockerNetwork.Gateway },
+		Description: "Docker network gateway address within the Docker network subnet",
+	}
+}
+
 // DefaultKubeconfigFieldSelector creates a standard field selector for kubeconfig.
 func DefaultKubeconfigFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	return FieldSelector[v1alpha1.Cluster]{
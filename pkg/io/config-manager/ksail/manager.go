@@ -16,6 +16,7 @@ import (
 	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
 	kindconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kind"
 	ksailvalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/ksail"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 	mapstructure "github.com/go-viper/mapstructure/v2"
@@ -37,8 +38,11 @@ type ConfigManager struct {
 	configLoaded                  bool              // Track if config has been actually loaded
 	Writer                        io.Writer         // Writer for output notifications
 	command                       *cobra.Command    // Associated Cobra command for flag introspection
+	provided                      *v1alpha1.Cluster // Configuration supplied in place of config files
 	localRegistryExplicit         bool              // Tracks if config explicitly set the local registry behavior
 	localRegistryHostPortExplicit bool              // Tracks if config explicitly set the registry host port
+	cipherBackend                 ciphersvc.Backend // Decrypts SOPS-encrypted config and secrets files
+	configEncrypted               bool              // Tracks if the config file read was SOPS-encrypted
 }
 
 // Compile-time interface compliance verification.
@@ -60,6 +64,7 @@ func NewConfigManager(
 		Config:         config,
 		configLoaded:   false,
 		Writer:         writer,
+		cipherBackend:  ciphersvc.NewDefaultBackend(),
 	}
 
 	return manager
@@ -79,6 +84,13 @@ func NewCommandConfigManager(
 	return manager
 }
 
+// UseConfig makes the manager load cluster in place of the on-disk config file. Environment
+// variables and configuration files are not read; defaults, flags and validation still apply.
+func (m *ConfigManager) UseConfig(cluster *v1alpha1.Cluster) {
+	m.provided = cluster
+	m.configLoaded = false
+}
+
 // LoadConfig loads the configuration from files and environment variables.
 // Returns the loaded config (either freshly loaded or previously cached) and an error if loading failed.
 // Returns nil config on error.
@@ -113,6 +125,11 @@ func (m *ConfigManager) loadConfigWithOptions(
 	silent bool,
 	ignoreConfigFile bool,
 ) (*v1alpha1.Cluster, error) {
+	if m.command != nil {
+		// Follow the command's writer, which may be set after the manager was created.
+		m.Writer = m.command.OutOrStdout()
+	}
+
 	if !silent {
 		m.notifyLoadingStart()
 	}
@@ -129,7 +146,7 @@ func (m *ConfigManager) loadConfigWithOptions(
 		m.notifyLoadingConfig()
 	}
 
-	if !ignoreConfigFile {
+	if !ignoreConfigFile && m.provided == nil {
 		// Use native Viper API to read configuration
 		err := m.readConfig(silent)
 		if err != nil {
@@ -140,7 +157,7 @@ func (m *ConfigManager) loadConfigWithOptions(
 	// Unmarshal and apply defaults
 	flagOverrides := m.captureChangedFlagValues()
 
-	err := m.unmarshalAndApplyDefaults()
+	err := m.unmarshalAndApplyDefaults(m.provided)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +170,11 @@ func (m *ConfigManager) loadConfigWithOptions(
 	m.applyGitOpsAwareDefaults(flagOverrides)
 	m.applyDistributionConfigDefaults()
 
+	err = m.applyClusterName()
+	if err != nil {
+		return nil, err
+	}
+
 	err = m.validateConfig()
 	if err != nil {
 		return nil, err
@@ -178,14 +200,18 @@ func (m *ConfigManager) readConfig(silent bool) error {
 		if !silent {
 			m.notifyUsingDefaults()
 		}
-	} else if !silent {
+
+		return nil
+	}
+
+	if !silent {
 		m.notifyConfigFound()
 	}
 
-	return nil
+	return m.decryptConfig()
 }
 
-func (m *ConfigManager) unmarshalAndApplyDefaults() error {
+func (m *ConfigManager) unmarshalAndApplyDefaults(provided *v1alpha1.Cluster) error {
 	decoderConfig := func(dc *mapstructure.DecoderConfig) {
 		dc.DecodeHook = mapstructure.ComposeDecodeHookFunc(
 			metav1DurationDecodeHook(),
@@ -195,9 +221,14 @@ func (m *ConfigManager) unmarshalAndApplyDefaults() error {
 	// Reset derived defaults so we can detect whether users explicitly configured these values.
 	m.Config.Spec.LocalRegistry = ""
 
-	err := m.Viper.Unmarshal(m.Config, decoderConfig)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal configuration: %w", err)
+	if provided != nil {
+		// Deep copy so defaults applied below do not leak into the caller's configuration.
+		*m.Config = *provided.DeepCopy()
+	} else {
+		err := m.Viper.Unmarshal(m.Config, decoderConfig)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal configuration: %w", err)
+		}
 	}
 
 	m.localRegistryExplicit = m.Config.Spec.LocalRegistry != ""
@@ -298,6 +329,13 @@ func (m *ConfigManager) wasLocalRegistryHostPortExplicit(flagOverrides map[strin
 }
 
 func (m *ConfigManager) defaultLocalRegistryBehavior() v1alpha1.LocalRegistry {
+	// External clusters cannot reach a registry KSail runs in the local Docker engine, and
+	// simulated clusters never pull images.
+	if m.Config != nil && (m.Config.Spec.Distribution == v1alpha1.DistributionExternal ||
+		m.Config.Spec.Distribution.IsSimulated()) {
+		return v1alpha1.LocalRegistryDisabled
+	}
+
 	if m.gitOpsEngineSelected() {
 		return v1alpha1.LocalRegistryEnabled
 	}
@@ -527,20 +565,25 @@ func expectedDistributionConfigName(distribution v1alpha1.Distribution) string {
 		return "kind.yaml"
 	case v1alpha1.DistributionK3d:
 		return "k3d.yaml"
+	case v1alpha1.DistributionTalos:
+		return "talos.yaml"
+	case v1alpha1.DistributionK0s:
+		return "k0s.yaml"
+	case v1alpha1.DistributionKwok:
+		return "kwok.yaml"
 	default:
 		return ""
 	}
 }
 
 func distributionConfigIsOppositeDefault(current string, distribution v1alpha1.Distribution) bool {
-	switch distribution {
-	case v1alpha1.DistributionKind:
-		return current == expectedDistributionConfigName(v1alpha1.DistributionK3d)
-	case v1alpha1.DistributionK3d:
-		return current == expectedDistributionConfigName(v1alpha1.DistributionKind)
-	default:
-		return false
+	for _, other := range v1alpha1.ValidDistributions() {
+		if other != distribution && current == expectedDistributionConfigName(other) {
+			return true
+		}
 	}
+
+	return false
 }
 
 // isFieldEmpty checks if a field pointer points to an empty/zero value.
@@ -611,6 +654,8 @@ func (m *ConfigManager) loadKindConfig() *kindv1alpha4.Cluster {
 		return nil
 	}
 
+	configmanagerinterface.ApplyClusterName(config, m.Config.Spec.ClusterName)
+
 	return config
 }
 
@@ -636,5 +681,7 @@ func (m *ConfigManager) loadK3dConfig() *k3dv1alpha5.SimpleConfig {
 		return nil
 	}
 
+	configmanagerinterface.ApplyClusterName(config, m.Config.Spec.ClusterName)
+
 	return config
 }
@@ -1364,3 +1364,84 @@ func TestIsFieldEmptyForTesting_NonEmptyString(t *testing.T) {
 	result := configmanager.IsFieldEmptyForTesting(&value)
 	assert.False(t, result)
 }
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestLoadConfigAcceptsEveryGeneratedVariant(t *testing.T) {
+	for _, variant := range testutils.KSailConfigMatrix() {
+		t.Run(variant.Name(), func(t *testing.T) {
+			dir := t.TempDir()
+			variant.WriteProject(t, dir)
+			t.Chdir(dir)
+
+			cfg, err := newManagerWithDefaultSelectors().LoadConfigSilent()
+			require.NoError(t, err)
+
+			// The default CNI is pruned from ksail.yaml, so it loads as the empty value.
+			loadedCNI := cfg.Spec.CNI
+			if loadedCNI == "" {
+				loadedCNI = v1alpha1.CNIDefault
+			}
+
+			assert.Equal(t, variant.Distribution, cfg.Spec.Distribution)
+			assert.Equal(t, variant.CNI, loadedCNI)
+			assert.Equal(t, variant.GitOpsEngine, cfg.Spec.GitOpsEngine)
+		})
+	}
+}
+
+func TestLoadConfigFromParallelTempProjects(t *testing.T) {
+	t.Parallel()
+
+	for _, variant := range testutils.KSailConfigMatrix() {
+		t.Run(variant.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			project := testutils.NewTempProject(t, testutils.WithTempProjectVariant(variant))
+
+			manager := newManagerWithDefaultSelectors()
+			manager.Viper.SetConfigFile(project.ConfigPath)
+
+			cfg, err := manager.LoadConfigSilent()
+			require.NoError(t, err)
+
+			assert.Equal(t, project.Context, cfg.Spec.Connection.Context)
+			assert.Equal(t, project.KubeconfigPath, cfg.Spec.Connection.Kubeconfig)
+			assert.Equal(t, project.DistributionConfigPath, cfg.Spec.DistributionConfig)
+			assert.Equal(t, project.SourceDir, cfg.Spec.SourceDirectory)
+			assert.DirExists(t, project.SourceDir)
+			assert.FileExists(t, project.KubeconfigPath)
+		})
+	}
+}
+
+func TestNewTempProjectUsesUniqueNames(t *testing.T) {
+	t.Parallel()
+
+	first := testutils.NewTempProject(t)
+	second := testutils.NewTempProject(t)
+
+	assert.NotEqual(t, first.Dir, second.Dir)
+	assert.NotEqual(t, first.ClusterName, second.ClusterName)
+	assert.NotEqual(t, first.RegistryName, second.RegistryName)
+	assert.LessOrEqual(t, len(first.ClusterName), 32)
+	assert.Regexp(t, `^ksail-[a-z0-9-]+-[0-9a-f]{8}$`, first.ClusterName)
+	assert.Equal(t, "kind-"+first.ClusterName, first.Context)
+}
+
+// FuzzLoadConfig checks that loading arbitrary ksail.yaml files returns either a config or an
+// error and never panics.
+func FuzzLoadConfig(f *testing.F) {
+	testutils.AddYAMLFuzzCorpus(f, testutils.KSailConfigSeeds()...)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := testutils.WriteFuzzInput(t, "ksail.yaml", data)
+
+		manager := configmanager.NewConfigManager(io.Discard, configmanager.DefaultClusterFieldSelectors()...)
+		manager.Viper.SetConfigFile(path)
+
+		config, err := manager.LoadConfigSilent()
+		if err == nil {
+			assert.NotNil(t, config)
+		}
+	})
+}
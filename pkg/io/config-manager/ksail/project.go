@@ -0,0 +1,24 @@
+package configmanager
+
+import (
+	"path/filepath"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+)
+
+// ProjectDir returns the project directory of configFile, the ksail.yaml a configuration was
+// loaded from. Without one, ksail.yaml is located from the working directory upwards, as config
+// discovery does, and the working directory is returned when there is none.
+func ProjectDir(configFile string) string {
+	if configFile == "" {
+		configFile, _ = ksailio.FindFile(DefaultConfigFileName + ".yaml")
+	}
+
+	return filepath.Dir(configFile)
+}
+
+// ProjectDir returns the directory of the ksail.yaml the manager loaded, the project files such
+// as ksail.lock and ksail.sum live in.
+func (m *ConfigManager) ProjectDir() string {
+	return ProjectDir(m.Viper.ConfigFileUsed())
+}
@@ -0,0 +1,88 @@
+package configmanager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+)
+
+// SecretsFileName is the optional overlay next to the config file that holds credentials,
+// typically encrypted with SOPS so it can be committed. Its values override the config file.
+const SecretsFileName = DefaultConfigFileName + ".secrets.yaml"
+
+// ErrEncryptedConfig is returned when a command would rewrite a SOPS-encrypted config file.
+var ErrEncryptedConfig = errors.New("config file is SOPS-encrypted")
+
+// SetCipherBackend sets the SOPS backend encrypted config files are decrypted with. Managers
+// use the default backend, backed by the local key service, unless one is set.
+func (m *ConfigManager) SetCipherBackend(backend ciphersvc.Backend) {
+	m.cipherBackend = backend
+}
+
+// decryptConfig replaces the values read from a SOPS-encrypted config file with their
+// plaintext, and merges the secrets overlay next to it, decrypting it as needed.
+func (m *ConfigManager) decryptConfig() error {
+	configFile := m.Viper.ConfigFileUsed()
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // config file is discovered by Viper
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	m.configEncrypted = ciphersvc.IsEncryptedYAML(content)
+
+	if m.configEncrypted {
+		plaintext, err := ciphersvc.DecryptYAML(m.cipherBackend, content)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt config file %s: %w", configFile, err)
+		}
+
+		err = m.Viper.ReadConfig(bytes.NewReader(plaintext))
+		if err != nil {
+			return fmt.Errorf("failed to read decrypted config file: %w", err)
+		}
+	}
+
+	secretsFile := filepath.Join(filepath.Dir(configFile), SecretsFileName)
+
+	content, err = os.ReadFile(secretsFile) //nolint:gosec // overlay sits next to the config file
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	plaintext, err := ciphersvc.DecryptYAML(m.cipherBackend, content)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secrets file %s: %w", secretsFile, err)
+	}
+
+	err = m.Viper.MergeConfig(bytes.NewReader(plaintext))
+	if err != nil {
+		return fmt.Errorf("failed to merge secrets file: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureWritable returns ErrEncryptedConfig when the loaded config file is SOPS-encrypted.
+// Writing the file back would drop its SOPS metadata and leave encrypted values in place, so
+// commands that record settings in the config file call it before they change anything.
+func (m *ConfigManager) EnsureWritable() error {
+	if !m.configEncrypted {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s; change it with 'ksail cipher edit %s' instead",
+		ErrEncryptedConfig,
+		m.Viper.ConfigFileUsed(),
+		m.Viper.ConfigFileUsed(),
+	)
+}
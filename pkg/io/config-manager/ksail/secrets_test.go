@@ -0,0 +1,148 @@
+package configmanager_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/integration/stubs"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/config"
+	yamlstore "github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vaultTokenSpec = "  options:\n" +
+	"    vault:\n" +
+	"      enabled: true\n" +
+	"      token: s3cr3t\n"
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestLoadConfigDecryptsEncryptedConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeKindConfigFile(t)
+
+	backend := stubs.NewCipherBackend()
+	encrypted := encryptYAML(t, backend, ksailClusterBaseYAML+vaultTokenSpec)
+	assert.NotContains(t, string(encrypted), "s3cr3t")
+	require.NoError(t, os.WriteFile("ksail.yaml", encrypted, 0o600))
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.SetCipherBackend(backend)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	cluster, err := manager.LoadConfigSilent()
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", cluster.Spec.Options.Vault.Token)
+	assert.True(t, cluster.Spec.Options.Vault.Enabled)
+	assert.Equal(t, "kind.yaml", cluster.Spec.DistributionConfig)
+}
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestLoadConfigMergesEncryptedSecretsFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "  options:\n    vault:\n      enabled: true\n")
+
+	backend := stubs.NewCipherBackend()
+	secrets := encryptYAML(t, backend, "spec:\n"+vaultTokenSpec)
+	require.NoError(t, os.WriteFile(configmanager.SecretsFileName, secrets, 0o600))
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.SetCipherBackend(backend)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	cluster, err := manager.LoadConfigSilent()
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", cluster.Spec.Options.Vault.Token)
+	assert.True(t, cluster.Spec.Options.Vault.Enabled)
+}
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestLoadConfigMergesPlaintextSecretsFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t)
+
+	require.NoError(t, os.WriteFile(configmanager.SecretsFileName, []byte("spec:\n"+vaultTokenSpec), 0o600))
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	cluster, err := manager.LoadConfigSilent()
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t", cluster.Spec.Options.Vault.Token)
+}
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestEnsureWritableRejectsEncryptedConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeKindConfigFile(t)
+
+	backend := stubs.NewCipherBackend()
+	require.NoError(t, os.WriteFile("ksail.yaml", encryptYAML(t, backend, ksailClusterBaseYAML+vaultTokenSpec), 0o600))
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.SetCipherBackend(backend)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	_, err := manager.LoadConfigSilent()
+	require.NoError(t, err)
+
+	err = manager.EnsureWritable()
+	require.ErrorIs(t, err, configmanager.ErrEncryptedConfig)
+	assert.ErrorContains(t, err, "ksail cipher edit ksail.yaml")
+}
+
+//nolint:paralleltest // Uses t.Chdir to isolate file system state for config loading.
+func TestEnsureWritableAcceptsPlaintextConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t)
+
+	manager := configmanager.NewConfigManager(io.Discard)
+	manager.Viper.SetConfigFile("ksail.yaml")
+
+	_, err := manager.LoadConfigSilent()
+	require.NoError(t, err)
+
+	assert.NoError(t, manager.EnsureWritable())
+}
+
+// encryptYAML encrypts the token values of plaintext with backend, like a .sops.yaml
+// creation rule with an encrypted_regex would.
+func encryptYAML(t *testing.T, backend ciphersvc.Backend, plaintext string) []byte {
+	t.Helper()
+
+	store := yamlstore.NewStore(&config.YAMLStoreConfig{})
+
+	branches, err := store.LoadPlainFile([]byte(plaintext))
+	require.NoError(t, err)
+
+	tree := &sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups:      backend.KeyGroups,
+			EncryptedRegex: "^token$",
+			Version:        "3.11.0",
+		},
+	}
+
+	dataKey, errs := tree.GenerateDataKeyWithKeyServices(backend.KeyServices)
+	require.Empty(t, errs)
+
+	err = common.EncryptTree(common.EncryptTreeOpts{DataKey: dataKey, Tree: tree, Cipher: backend.Cipher})
+	require.NoError(t, err)
+
+	encrypted, err := store.EmitEncryptedFile(*tree)
+	require.NoError(t, err)
+
+	return encrypted
+}
@@ -66,6 +66,7 @@ func configureViperEnvironment(viperInstance *viper.Viper) {
 	// Explicitly bind nested environment variables for better compatibility
 	_ = viperInstance.BindEnv("metadata.name", "KSAIL_METADATA_NAME")
 	_ = viperInstance.BindEnv("spec.distribution", "KSAIL_SPEC_DISTRIBUTION")
+	_ = viperInstance.BindEnv("spec.clustername", "KSAIL_SPEC_CLUSTERNAME")
 	_ = viperInstance.BindEnv("spec.sourcedirectory", "KSAIL_SPEC_SOURCEDIRECTORY")
 	_ = viperInstance.BindEnv("spec.connection.context", "KSAIL_SPEC_CONNECTION_CONTEXT")
 	_ = viperInstance.BindEnv("spec.connection.kubeconfig", "KSAIL_SPEC_CONNECTION_KUBECONFIG")
@@ -0,0 +1,5 @@
+// Package kwok provides configuration management for kwok clusters.
+//
+// This package contains the core Manager implementation for loading the kwok.yaml
+// distribution configuration from files.
+package kwok
@@ -0,0 +1,59 @@
+package kwok
+
+import (
+	"fmt"
+
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/helpers"
+	kwokvalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/kwok"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+)
+
+// ConfigManager implements configuration management for kwok.yaml configurations.
+// It provides file-based configuration loading without Viper dependency.
+type ConfigManager struct {
+	configPath   string
+	config       *kwokprovisioner.Config
+	configLoaded bool
+}
+
+// Compile-time interface compliance verification.
+var _ configmanager.ConfigManager[kwokprovisioner.Config] = (*ConfigManager)(nil)
+
+// NewConfigManager creates a new configuration manager for kwok cluster configurations.
+// configPath specifies the path to the kwok configuration file to load.
+func NewConfigManager(configPath string) *ConfigManager {
+	return &ConfigManager{
+		configPath:   configPath,
+		config:       nil,
+		configLoaded: false,
+	}
+}
+
+// LoadConfig loads the kwok configuration from the specified file.
+// Returns the loaded config, either freshly loaded or previously cached.
+// If the file doesn't exist, returns a default kwok cluster configuration.
+// Validates the configuration after loading and returns an error if validation fails.
+// The timer parameter is accepted for interface compliance but not currently used.
+func (m *ConfigManager) LoadConfig(_ timer.Timer) (*kwokprovisioner.Config, error) {
+	if m.configLoaded {
+		return m.config, nil
+	}
+
+	config, err := helpers.LoadAndValidateConfig(
+		m.configPath,
+		func() *kwokprovisioner.Config {
+			return kwokprovisioner.NewConfig("")
+		},
+		kwokvalidator.NewValidator(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kwok config: %w", err)
+	}
+
+	m.config = config
+	m.configLoaded = true
+
+	return m.config, nil
+}
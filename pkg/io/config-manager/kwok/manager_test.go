@@ -0,0 +1,57 @@
+package kwok_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/kwok"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigDefaultsWhenFileIsMissing(t *testing.T) {
+	t.Parallel()
+
+	manager := kwok.NewConfigManager(filepath.Join(t.TempDir(), "kwok.yaml"))
+
+	config, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, kwokprovisioner.NewConfig(""), config)
+}
+
+func TestLoadConfigReadsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "kwok.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: kwok.ksail.dev/v1alpha1
+kind: Cluster
+name: scale
+nodes: 500
+`), 0o600))
+
+	manager := kwok.NewConfigManager(path)
+
+	config, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "scale", config.Name)
+	assert.Equal(t, 500, config.Nodes)
+
+	cached, err := manager.LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Same(t, config, cached)
+}
+
+func TestLoadConfigRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "kwok.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`apiVersion: kwok.ksail.dev/v1alpha1
+kind: Cluster
+nodes: -1
+`), 0o600))
+
+	_, err := kwok.NewConfigManager(path).LoadConfig(nil)
+	require.Error(t, err)
+}
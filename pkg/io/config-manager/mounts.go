@@ -0,0 +1,98 @@
+package configmanager
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// ApplyMounts mounts the host directories of the KSail configuration into every node of a Kind
+// or K3d config structure. Relative host paths are resolved against the working directory, and
+// a mount replaces any mount of the config at the same container path, so applying the same
+// mounts again leaves the config unchanged. Other distributions are left unchanged.
+func ApplyMounts(config any, mounts []v1alpha1.Mount) {
+	if len(mounts) == 0 {
+		return
+	}
+
+	switch cfg := config.(type) {
+	case *v1alpha4.Cluster:
+		if cfg != nil {
+			applyKindMounts(cfg, mounts)
+		}
+	case *v1alpha5.SimpleConfig:
+		if cfg != nil {
+			applyK3dMounts(cfg, mounts)
+		}
+	}
+}
+
+func applyKindMounts(cfg *v1alpha4.Cluster, mounts []v1alpha1.Mount) {
+	if len(cfg.Nodes) == 0 {
+		cfg.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	for i := range cfg.Nodes {
+		// Nodes copied by SetKindNodeCount share their mounts, so they are rebuilt per node.
+		extraMounts := slices.Clone(cfg.Nodes[i].ExtraMounts)
+
+		for _, mount := range mounts {
+			extraMounts = slices.DeleteFunc(extraMounts, func(existing v1alpha4.Mount) bool {
+				return existing.ContainerPath == mount.ContainerPath
+			})
+			extraMounts = append(extraMounts, v1alpha4.Mount{
+				HostPath:      absoluteHostPath(mount.HostPath),
+				ContainerPath: mount.ContainerPath,
+				Readonly:      mount.ReadOnly,
+			})
+		}
+
+		cfg.Nodes[i].ExtraMounts = extraMounts
+	}
+}
+
+func applyK3dMounts(cfg *v1alpha5.SimpleConfig, mounts []v1alpha1.Mount) {
+	for _, mount := range mounts {
+		cfg.Volumes = slices.DeleteFunc(cfg.Volumes, func(existing v1alpha5.VolumeWithNodeFilters) bool {
+			return k3dVolumeTarget(existing.Volume) == mount.ContainerPath
+		})
+
+		volume := absoluteHostPath(mount.HostPath) + ":" + mount.ContainerPath
+		if mount.ReadOnly {
+			volume += ":ro"
+		}
+
+		cfg.Volumes = append(cfg.Volumes, v1alpha5.VolumeWithNodeFilters{
+			Volume:      volume,
+			NodeFilters: []string{"server:*", "agent:*"},
+		})
+	}
+}
+
+// k3dVolumeTarget returns the container path of a k3d volume in the source:target[:mode]
+// format of docker run --volume.
+func k3dVolumeTarget(volume string) string {
+	for _, mode := range []string{":ro", ":rw"} {
+		volume = strings.TrimSuffix(volume, mode)
+	}
+
+	index := strings.LastIndex(volume, ":")
+	if index < 0 {
+		return volume
+	}
+
+	return volume[index+1:]
+}
+
+func absoluteHostPath(path string) string {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	return absolute
+}
@@ -0,0 +1,50 @@
+package configmanager_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/require"
+	v1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestApplyMountsKind(t *testing.T) {
+	t.Parallel()
+
+	hostPath := t.TempDir()
+	kindConfig := &v1alpha4.Cluster{Nodes: []v1alpha4.Node{
+		{Role: v1alpha4.ControlPlaneRole, ExtraMounts: []v1alpha4.Mount{{HostPath: "/old", ContainerPath: "/data"}}},
+		{Role: v1alpha4.WorkerRole},
+	}}
+	mounts := []v1alpha1.Mount{{HostPath: hostPath, ContainerPath: "/data", ReadOnly: true}}
+
+	configmanager.ApplyMounts(kindConfig, mounts)
+	configmanager.ApplyMounts(kindConfig, mounts)
+
+	expected := []v1alpha4.Mount{{HostPath: hostPath, ContainerPath: "/data", Readonly: true}}
+	require.Equal(t, expected, kindConfig.Nodes[0].ExtraMounts, "the mount replaces the one at the same path")
+	require.Equal(t, expected, kindConfig.Nodes[1].ExtraMounts)
+}
+
+func TestApplyMountsK3d(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{Volumes: []v1alpha5.VolumeWithNodeFilters{
+		{Volume: "/old:/data:ro", NodeFilters: []string{"server:0"}},
+		{Volume: "/cache:/cache", NodeFilters: []string{"agent:*"}},
+	}}
+	mounts := []v1alpha1.Mount{{HostPath: "manifests", ContainerPath: "/data"}}
+
+	configmanager.ApplyMounts(k3dConfig, mounts)
+	configmanager.ApplyMounts(k3dConfig, mounts)
+
+	absolute, err := filepath.Abs("manifests")
+	require.NoError(t, err)
+	require.Equal(t, []v1alpha5.VolumeWithNodeFilters{
+		{Volume: "/cache:/cache", NodeFilters: []string{"agent:*"}},
+		{Volume: absolute + ":/data", NodeFilters: []string{"server:*", "agent:*"}},
+	}, k3dConfig.Volumes)
+}
@@ -0,0 +1,199 @@
+package configmanager
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// ApplyNodes applies the labels and taints of the KSail node customizations to a Kind or K3d
+// config structure. Entries are matched to the nodes of their role by position, and the node
+// counts are raised when a role has more entries than nodes. Applying the same nodes again
+// leaves the config unchanged. Other distributions are left unchanged.
+func ApplyNodes(config any, nodes []v1alpha1.Node) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	switch cfg := config.(type) {
+	case *v1alpha4.Cluster:
+		if cfg != nil {
+			applyKindNodes(cfg, nodes)
+		}
+	case *v1alpha5.SimpleConfig:
+		if cfg != nil {
+			applyK3dNodes(cfg, nodes)
+		}
+	}
+}
+
+// nodesByRole splits node customizations into control-plane and worker entries.
+func nodesByRole(nodes []v1alpha1.Node) ([]v1alpha1.Node, []v1alpha1.Node) {
+	var controlPlanes, workers []v1alpha1.Node
+
+	for _, node := range nodes {
+		if node.Role == v1alpha1.NodeRoleControlPlane {
+			controlPlanes = append(controlPlanes, node)
+		} else {
+			workers = append(workers, node)
+		}
+	}
+
+	return controlPlanes, workers
+}
+
+func applyKindNodes(cfg *v1alpha4.Cluster, nodes []v1alpha1.Node) {
+	controlPlanes, workers := nodesByRole(nodes)
+
+	if len(cfg.Nodes) == 0 {
+		cfg.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	if len(controlPlanes) > countKindNodes(cfg, v1alpha4.ControlPlaneRole) {
+		SetKindNodeCount(cfg, v1alpha4.ControlPlaneRole, len(controlPlanes))
+	}
+
+	if len(workers) > countKindNodes(cfg, v1alpha4.WorkerRole) {
+		SetKindNodeCount(cfg, v1alpha4.WorkerRole, len(workers))
+	}
+
+	var controlPlaneIndex, workerIndex int
+
+	for i := range cfg.Nodes {
+		node := &cfg.Nodes[i]
+
+		switch node.Role {
+		case v1alpha4.ControlPlaneRole:
+			if controlPlaneIndex < len(controlPlanes) {
+				// kubeadm initializes the first control-plane node; the others join.
+				customizeKindNode(node, controlPlanes[controlPlaneIndex], controlPlaneIndex == 0)
+			}
+
+			controlPlaneIndex++
+		case v1alpha4.WorkerRole:
+			if workerIndex < len(workers) {
+				customizeKindNode(node, workers[workerIndex], false)
+			}
+
+			workerIndex++
+		}
+	}
+}
+
+func countKindNodes(cfg *v1alpha4.Cluster, role v1alpha4.NodeRole) int {
+	count := 0
+
+	for _, node := range cfg.Nodes {
+		if node.Role == role {
+			count++
+		}
+	}
+
+	return count
+}
+
+// customizeKindNode adds the labels of spec to the node and registers its taints through a
+// kubeadm patch, as Kind has no taint field. Nodes copied by SetKindNodeCount share maps and
+// slices, so both are cloned before they change.
+func customizeKindNode(node *v1alpha4.Node, spec v1alpha1.Node, initializes bool) {
+	if len(spec.Labels) > 0 {
+		labels := maps.Clone(node.Labels)
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		maps.Copy(labels, spec.Labels)
+		node.Labels = labels
+	}
+
+	if len(spec.Taints) == 0 {
+		return
+	}
+
+	kind := "JoinConfiguration"
+	if initializes {
+		kind = "InitConfiguration"
+	}
+
+	patch := kindTaintPatch(kind, spec.Taints)
+	if !slices.Contains(node.KubeadmConfigPatches, patch) {
+		node.KubeadmConfigPatches = append(slices.Clone(node.KubeadmConfigPatches), patch)
+	}
+}
+
+func kindTaintPatch(kind string, taints []v1alpha1.Taint) string {
+	var patch strings.Builder
+
+	patch.WriteString("kind: " + kind + "\nnodeRegistration:\n  taints:\n")
+
+	for _, taint := range taints {
+		patch.WriteString("  - key: " + strconv.Quote(taint.Key) + "\n")
+
+		if taint.Value != "" {
+			patch.WriteString("    value: " + strconv.Quote(taint.Value) + "\n")
+		}
+
+		patch.WriteString("    effect: " + string(taint.Effect) + "\n")
+	}
+
+	return patch.String()
+}
+
+func applyK3dNodes(cfg *v1alpha5.SimpleConfig, nodes []v1alpha1.Node) {
+	controlPlanes, workers := nodesByRole(nodes)
+
+	// k3d creates a single server when the config declares none.
+	if len(controlPlanes) > max(cfg.Servers, 1) {
+		cfg.Servers = len(controlPlanes)
+	}
+
+	if len(workers) > cfg.Agents {
+		cfg.Agents = len(workers)
+	}
+
+	for i, node := range controlPlanes {
+		customizeK3dNode(cfg, node, fmt.Sprintf("server:%d", i))
+	}
+
+	for i, node := range workers {
+		customizeK3dNode(cfg, node, fmt.Sprintf("agent:%d", i))
+	}
+}
+
+// customizeK3dNode adds the labels and taints of spec to the K3s options of the node that
+// nodeFilter selects.
+func customizeK3dNode(cfg *v1alpha5.SimpleConfig, spec v1alpha1.Node, nodeFilter string) {
+	k3sOptions := &cfg.Options.K3sOptions
+
+	for _, key := range slices.Sorted(maps.Keys(spec.Labels)) {
+		label := v1alpha5.LabelWithNodeFilters{
+			Label:       key + "=" + spec.Labels[key],
+			NodeFilters: []string{nodeFilter},
+		}
+
+		if !slices.ContainsFunc(k3sOptions.NodeLabels, func(existing v1alpha5.LabelWithNodeFilters) bool {
+			return existing.Label == label.Label && slices.Equal(existing.NodeFilters, label.NodeFilters)
+		}) {
+			k3sOptions.NodeLabels = append(k3sOptions.NodeLabels, label)
+		}
+	}
+
+	for _, taint := range spec.Taints {
+		arg := v1alpha5.K3sArgWithNodeFilters{
+			Arg:         "--node-taint=" + taint.String(),
+			NodeFilters: []string{nodeFilter},
+		}
+
+		if !slices.ContainsFunc(k3sOptions.ExtraArgs, func(existing v1alpha5.K3sArgWithNodeFilters) bool {
+			return existing.Arg == arg.Arg && slices.Equal(existing.NodeFilters, arg.NodeFilters)
+		}) {
+			k3sOptions.ExtraArgs = append(k3sOptions.ExtraArgs, arg)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package configmanager_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/require"
+	v1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+//nolint:gochecknoglobals // Shared node customizations of the ApplyNodes tests.
+var testNodes = []v1alpha1.Node{
+	{Role: v1alpha1.NodeRoleControlPlane, Labels: map[string]string{"ingress-ready": "true"}},
+	{Labels: map[string]string{"tier": "a"}},
+	{
+		Role:   v1alpha1.NodeRoleWorker,
+		Labels: map[string]string{"tier": "b"},
+		Taints: []v1alpha1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1alpha1.TaintEffectNoSchedule}},
+	},
+}
+
+func TestApplyNodesKind(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &v1alpha4.Cluster{Nodes: []v1alpha4.Node{
+		{Role: v1alpha4.ControlPlaneRole},
+		{Role: v1alpha4.WorkerRole, Labels: map[string]string{"zone": "z1"}},
+	}}
+
+	configmanager.ApplyNodes(kindConfig, testNodes)
+
+	require.Len(t, kindConfig.Nodes, 3, "the worker count is raised to the customized workers")
+	require.Equal(t, map[string]string{"ingress-ready": "true"}, kindConfig.Nodes[0].Labels)
+	require.Equal(t, map[string]string{"zone": "z1", "tier": "a"}, kindConfig.Nodes[1].Labels)
+	require.Equal(t, map[string]string{"zone": "z1", "tier": "b"}, kindConfig.Nodes[2].Labels)
+	require.Empty(t, kindConfig.Nodes[1].KubeadmConfigPatches)
+	require.Equal(t, []string{
+		"kind: JoinConfiguration\nnodeRegistration:\n  taints:\n" +
+			"  - key: \"dedicated\"\n    value: \"gpu\"\n    effect: NoSchedule\n",
+	}, kindConfig.Nodes[2].KubeadmConfigPatches)
+
+	// Applying again leaves the config unchanged.
+	configmanager.ApplyNodes(kindConfig, testNodes)
+	require.Len(t, kindConfig.Nodes[2].KubeadmConfigPatches, 1)
+}
+
+func TestApplyNodesKindTaintsInitializingControlPlane(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &v1alpha4.Cluster{}
+
+	configmanager.ApplyNodes(kindConfig, []v1alpha1.Node{{
+		Role:   v1alpha1.NodeRoleControlPlane,
+		Taints: []v1alpha1.Taint{{Key: "critical", Effect: v1alpha1.TaintEffectNoExecute}},
+	}})
+
+	require.Len(t, kindConfig.Nodes, 1)
+	require.Equal(t, []string{
+		"kind: InitConfiguration\nnodeRegistration:\n  taints:\n  - key: \"critical\"\n    effect: NoExecute\n",
+	}, kindConfig.Nodes[0].KubeadmConfigPatches)
+}
+
+func TestApplyNodesK3d(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{Agents: 1}
+
+	configmanager.ApplyNodes(k3dConfig, testNodes)
+	configmanager.ApplyNodes(k3dConfig, testNodes)
+
+	require.Zero(t, k3dConfig.Servers, "k3d creates the single customized server by default")
+	require.Equal(t, 2, k3dConfig.Agents)
+	require.Equal(t, []v1alpha5.LabelWithNodeFilters{
+		{Label: "ingress-ready=true", NodeFilters: []string{"server:0"}},
+		{Label: "tier=a", NodeFilters: []string{"agent:0"}},
+		{Label: "tier=b", NodeFilters: []string{"agent:1"}},
+	}, k3dConfig.Options.K3sOptions.NodeLabels)
+	require.Equal(t, []v1alpha5.K3sArgWithNodeFilters{
+		{Arg: "--node-taint=dedicated=gpu:NoSchedule", NodeFilters: []string{"agent:1"}},
+	}, k3dConfig.Options.K3sOptions.ExtraArgs)
+}
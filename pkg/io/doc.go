@@ -16,5 +16,7 @@
 //   - generator: Template and configuration generation
 //   - marshaller: Serialization and deserialization
 //   - scaffolder: Project scaffolding and file generation
+//   - shellenv: Shell export statements for bash, zsh, fish and direnv
 //   - validator: Configuration validation
+//   - wsl: WSL2 detection and Windows ↔ Linux path translation
 package io
@@ -0,0 +1,6 @@
+// Package argocdgenerator generates ArgoCD Application and AppProject manifests.
+//
+// kubectl create cannot produce ArgoCD custom resources, so these generators build them from
+// flag-like options modelled on the argocd CLI, letting a GitOps source directory declare its
+// Applications and AppProjects without it.
+package argocdgenerator
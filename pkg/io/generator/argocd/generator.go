@@ -0,0 +1,343 @@
+package argocdgenerator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// APIVersion is the API version of ArgoCD resources.
+	APIVersion = "argoproj.io/v1alpha1"
+	// ApplicationKind is the kind of ArgoCD Applications.
+	ApplicationKind = "Application"
+	// AppProjectKind is the kind of ArgoCD AppProjects.
+	AppProjectKind = "AppProject"
+
+	// DefaultNamespace is the namespace ArgoCD watches for Applications and AppProjects.
+	DefaultNamespace = "argocd"
+	// DefaultProject is the project of Applications when none is given.
+	DefaultProject = "default"
+	// DefaultTargetRevision is the revision an Application syncs when none is given.
+	DefaultTargetRevision = "HEAD"
+	// DefaultServer is the API server of the cluster ArgoCD runs in.
+	DefaultServer = "https://kubernetes.default.svc"
+	// SyncPolicyAutomated syncs an Application whenever its source changes.
+	SyncPolicyAutomated = "automated"
+	// SyncPolicyNone leaves syncing an Application to the user.
+	SyncPolicyNone = "none"
+
+	groupKindParts = 2
+)
+
+var (
+	// ErrInvalidName is returned when a resource name is not a valid DNS subdomain.
+	ErrInvalidName = errors.New("invalid name")
+	// ErrRepoURLRequired is returned when an Application has no repository URL.
+	ErrRepoURLRequired = errors.New("repository url is required")
+	// ErrConflictingSource is returned when an Application has both a path and a chart.
+	ErrConflictingSource = errors.New("cannot set both path and chart")
+	// ErrConflictingDestination is returned when an Application has both a server and a
+	// cluster name as destination.
+	ErrConflictingDestination = errors.New("cannot set both destination server and name")
+	// ErrInvalidSyncPolicy is returned for sync policies other than automated and none, or
+	// for automated sync options without the automated policy.
+	ErrInvalidSyncPolicy = errors.New("invalid sync policy")
+	// ErrInvalidDestination is returned when a project destination is not server,namespace.
+	ErrInvalidDestination = errors.New("invalid destination")
+	// ErrInvalidClusterResource is returned when a cluster resource is not group/kind.
+	ErrInvalidClusterResource = errors.New("invalid cluster resource")
+)
+
+// Application is the subset of the ArgoCD Application resource KSail generates.
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ApplicationSpec `json:"spec"`
+}
+
+// ApplicationSpec is the desired state of an ArgoCD Application.
+type ApplicationSpec struct {
+	Project     string                 `json:"project"`
+	Source      ApplicationSource      `json:"source"`
+	Destination ApplicationDestination `json:"destination"`
+	SyncPolicy  *SyncPolicy            `json:"syncPolicy,omitempty"`
+}
+
+// ApplicationSource is the repository, and the path or Helm chart in it, an Application syncs.
+type ApplicationSource struct {
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path,omitempty"`
+	Chart          string `json:"chart,omitempty"`
+	TargetRevision string `json:"targetRevision"`
+}
+
+// ApplicationDestination is the cluster, by server or name, and namespace an Application
+// deploys to.
+type ApplicationDestination struct {
+	Server    string `json:"server,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// SyncPolicy controls when and how an Application is synced.
+type SyncPolicy struct {
+	Automated   *AutomatedSync `json:"automated,omitempty"`
+	SyncOptions []string       `json:"syncOptions,omitempty"`
+}
+
+// AutomatedSync configures automated syncs.
+type AutomatedSync struct {
+	Prune    bool `json:"prune,omitempty"`
+	SelfHeal bool `json:"selfHeal,omitempty"`
+}
+
+// AppProject is the subset of the ArgoCD AppProject resource KSail generates.
+type AppProject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec AppProjectSpec `json:"spec"`
+}
+
+// AppProjectSpec restricts the sources, destinations and cluster resources of the
+// Applications in a project.
+type AppProjectSpec struct {
+	Description              string                   `json:"description,omitempty"`
+	SourceRepos              []string                 `json:"sourceRepos"`
+	Destinations             []ApplicationDestination `json:"destinations"`
+	ClusterResourceWhitelist []metav1.GroupKind       `json:"clusterResourceWhitelist,omitempty"`
+}
+
+// ApplicationOptions describe the Application to generate.
+type ApplicationOptions struct {
+	Name string
+	// Namespace defaults to DefaultNamespace.
+	Namespace string
+	// Project defaults to DefaultProject.
+	Project string
+	// RepoURL is the Git repository, Helm repository or OCI registry to sync from.
+	RepoURL string
+	// Path is the directory of the manifests in a Git repository.
+	Path string
+	// Chart is the name of the chart in a Helm repository, instead of Path.
+	Chart string
+	// TargetRevision defaults to DefaultTargetRevision.
+	TargetRevision string
+	// DestServer defaults to DefaultServer unless DestName is set.
+	DestServer string
+	// DestName is the name of the destination cluster, instead of DestServer.
+	DestName      string
+	DestNamespace string
+	// SyncPolicy is SyncPolicyAutomated or SyncPolicyNone, the default.
+	SyncPolicy string
+	// AutoPrune and SelfHeal require SyncPolicyAutomated.
+	AutoPrune   bool
+	SelfHeal    bool
+	SyncOptions []string
+}
+
+// AppProjectOptions describe the AppProject to generate.
+type AppProjectOptions struct {
+	Name string
+	// Namespace defaults to DefaultNamespace.
+	Namespace   string
+	Description string
+	// SourceRepos defaults to every repository, "*".
+	SourceRepos []string
+	// Destinations are server,namespace pairs; they default to every namespace of DefaultServer.
+	Destinations []string
+	// ClusterResources are the group/kind of cluster-scoped resources Applications may
+	// deploy, e.g. "/Namespace" for the core group.
+	ClusterResources []string
+}
+
+// NewApplication builds an Application from opts, applying defaults and validating the result.
+func NewApplication(opts ApplicationOptions) (Application, error) {
+	objectMeta, err := newObjectMeta(opts.Name, opts.Namespace)
+	if err != nil {
+		return Application{}, err
+	}
+
+	source, err := newApplicationSource(opts)
+	if err != nil {
+		return Application{}, err
+	}
+
+	destination := ApplicationDestination{
+		Server:    strings.TrimSpace(opts.DestServer),
+		Name:      strings.TrimSpace(opts.DestName),
+		Namespace: strings.TrimSpace(opts.DestNamespace),
+	}
+
+	switch {
+	case destination.Server != "" && destination.Name != "":
+		return Application{}, ErrConflictingDestination
+	case destination.Server == "" && destination.Name == "":
+		destination.Server = DefaultServer
+	}
+
+	syncPolicy, err := newSyncPolicy(opts)
+	if err != nil {
+		return Application{}, err
+	}
+
+	return Application{
+		TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: ApplicationKind},
+		ObjectMeta: objectMeta,
+		Spec: ApplicationSpec{
+			Project:     valueOr(opts.Project, DefaultProject),
+			Source:      source,
+			Destination: destination,
+			SyncPolicy:  syncPolicy,
+		},
+	}, nil
+}
+
+// NewAppProject builds an AppProject from opts, applying defaults and validating the result.
+func NewAppProject(opts AppProjectOptions) (AppProject, error) {
+	objectMeta, err := newObjectMeta(opts.Name, opts.Namespace)
+	if err != nil {
+		return AppProject{}, err
+	}
+
+	sourceRepos := trimAll(opts.SourceRepos)
+	if len(sourceRepos) == 0 {
+		sourceRepos = []string{"*"}
+	}
+
+	destinations := []ApplicationDestination{{Server: DefaultServer, Namespace: "*"}}
+
+	if len(opts.Destinations) > 0 {
+		destinations = make([]ApplicationDestination, 0, len(opts.Destinations))
+
+		for _, destination := range opts.Destinations {
+			server, namespace, found := strings.Cut(destination, ",")
+			if !found || strings.TrimSpace(server) == "" || strings.TrimSpace(namespace) == "" {
+				return AppProject{}, fmt.Errorf("%w %q: expected server,namespace", ErrInvalidDestination, destination)
+			}
+
+			destinations = append(destinations, ApplicationDestination{
+				Server:    strings.TrimSpace(server),
+				Namespace: strings.TrimSpace(namespace),
+			})
+		}
+	}
+
+	clusterResources := make([]metav1.GroupKind, 0, len(opts.ClusterResources))
+
+	for _, resource := range opts.ClusterResources {
+		parts := strings.Split(strings.TrimSpace(resource), "/")
+		if len(parts) != groupKindParts || parts[1] == "" {
+			return AppProject{}, fmt.Errorf("%w %q: expected group/kind", ErrInvalidClusterResource, resource)
+		}
+
+		clusterResources = append(clusterResources, metav1.GroupKind{Group: parts[0], Kind: parts[1]})
+	}
+
+	if len(clusterResources) == 0 {
+		clusterResources = nil
+	}
+
+	return AppProject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: APIVersion, Kind: AppProjectKind},
+		ObjectMeta: objectMeta,
+		Spec: AppProjectSpec{
+			Description:              strings.TrimSpace(opts.Description),
+			SourceRepos:              sourceRepos,
+			Destinations:             destinations,
+			ClusterResourceWhitelist: clusterResources,
+		},
+	}, nil
+}
+
+// Generate renders resource as YAML, writing it to opts.Output when set.
+func Generate[T Application | AppProject](resource T, opts yamlgenerator.Options) (string, error) {
+	out, err := yamlgenerator.NewYAMLGenerator[T]().Generate(resource, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ArgoCD resource: %w", err)
+	}
+
+	return out, nil
+}
+
+// --- internals ---
+
+func newApplicationSource(opts ApplicationOptions) (ApplicationSource, error) {
+	source := ApplicationSource{
+		RepoURL:        strings.TrimSpace(opts.RepoURL),
+		Path:           strings.TrimSpace(opts.Path),
+		Chart:          strings.TrimSpace(opts.Chart),
+		TargetRevision: valueOr(opts.TargetRevision, DefaultTargetRevision),
+	}
+
+	if source.RepoURL == "" {
+		return ApplicationSource{}, ErrRepoURLRequired
+	}
+
+	if source.Path != "" && source.Chart != "" {
+		return ApplicationSource{}, ErrConflictingSource
+	}
+
+	return source, nil
+}
+
+func newSyncPolicy(opts ApplicationOptions) (*SyncPolicy, error) {
+	syncOptions := trimAll(opts.SyncOptions)
+
+	switch strings.ToLower(strings.TrimSpace(opts.SyncPolicy)) {
+	case SyncPolicyAutomated:
+		return &SyncPolicy{
+			Automated:   &AutomatedSync{Prune: opts.AutoPrune, SelfHeal: opts.SelfHeal},
+			SyncOptions: syncOptions,
+		}, nil
+	case "", SyncPolicyNone:
+		if opts.AutoPrune || opts.SelfHeal {
+			return nil, fmt.Errorf("%w: auto-prune and self-heal require the %s policy",
+				ErrInvalidSyncPolicy, SyncPolicyAutomated)
+		}
+
+		if len(syncOptions) == 0 {
+			return nil, nil
+		}
+
+		return &SyncPolicy{SyncOptions: syncOptions}, nil
+	default:
+		return nil, fmt.Errorf("%w %q: must be %s or %s",
+			ErrInvalidSyncPolicy, opts.SyncPolicy, SyncPolicyAutomated, SyncPolicyNone)
+	}
+}
+
+func newObjectMeta(name, namespace string) (metav1.ObjectMeta, error) {
+	name = strings.TrimSpace(name)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return metav1.ObjectMeta{}, fmt.Errorf("%w %q: %s", ErrInvalidName, name, strings.Join(errs, "; "))
+	}
+
+	return metav1.ObjectMeta{Name: name, Namespace: valueOr(namespace, DefaultNamespace)}, nil
+}
+
+func trimAll(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+
+	return trimmed
+}
+
+func valueOr(value, fallback string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+
+	return fallback
+}
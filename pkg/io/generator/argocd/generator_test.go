@@ -0,0 +1,123 @@
+package argocdgenerator_test
+
+import (
+	"testing"
+
+	argocdgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/argocd"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) { testutils.RunTestMainWithSnapshotCleanup(m) }
+
+func TestGenerateApplicationDefaults(t *testing.T) {
+	t.Parallel()
+
+	application, err := argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name:    "podinfo",
+		RepoURL: "https://github.com/stefanprodan/podinfo",
+		Path:    "kustomize",
+	})
+	require.NoError(t, err)
+
+	out, err := argocdgenerator.Generate(application, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestGenerateApplicationAutomated(t *testing.T) {
+	t.Parallel()
+
+	application, err := argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name:           "podinfo",
+		Project:        "apps",
+		RepoURL:        "https://stefanprodan.github.io/podinfo",
+		Chart:          "podinfo",
+		TargetRevision: "6.9.0",
+		DestNamespace:  "podinfo",
+		SyncPolicy:     "Automated",
+		AutoPrune:      true,
+		SelfHeal:       true,
+		SyncOptions:    []string{"CreateNamespace=true"},
+	})
+	require.NoError(t, err)
+
+	out, err := argocdgenerator.Generate(application, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestGenerateAppProject(t *testing.T) {
+	t.Parallel()
+
+	project, err := argocdgenerator.NewAppProject(argocdgenerator.AppProjectOptions{
+		Name:             "apps",
+		Description:      "Team applications",
+		SourceRepos:      []string{"https://github.com/example/*"},
+		Destinations:     []string{"https://kubernetes.default.svc,apps-*"},
+		ClusterResources: []string{"/Namespace"},
+	})
+	require.NoError(t, err)
+
+	out, err := argocdgenerator.Generate(project, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestAppProjectDefaultsAllowEverything(t *testing.T) {
+	t.Parallel()
+
+	project, err := argocdgenerator.NewAppProject(argocdgenerator.AppProjectOptions{Name: "sandbox"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"*"}, project.Spec.SourceRepos)
+	require.Len(t, project.Spec.Destinations, 1)
+	assert.Equal(t, argocdgenerator.DefaultServer, project.Spec.Destinations[0].Server)
+	assert.Equal(t, "*", project.Spec.Destinations[0].Namespace)
+}
+
+func TestGeneratorValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{Name: "Pod_Info", RepoURL: "x"})
+	require.ErrorIs(t, err, argocdgenerator.ErrInvalidName)
+
+	_, err = argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{Name: "podinfo"})
+	require.ErrorIs(t, err, argocdgenerator.ErrRepoURLRequired)
+
+	_, err = argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name: "podinfo", RepoURL: "x", Path: "k8s", Chart: "podinfo",
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrConflictingSource)
+
+	_, err = argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name: "podinfo", RepoURL: "x", DestServer: "https://a", DestName: "b",
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrConflictingDestination)
+
+	_, err = argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name: "podinfo", RepoURL: "x", AutoPrune: true,
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrInvalidSyncPolicy)
+
+	_, err = argocdgenerator.NewApplication(argocdgenerator.ApplicationOptions{
+		Name: "podinfo", RepoURL: "x", SyncPolicy: "sometimes",
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrInvalidSyncPolicy)
+
+	_, err = argocdgenerator.NewAppProject(argocdgenerator.AppProjectOptions{
+		Name: "apps", Destinations: []string{"https://kubernetes.default.svc"},
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrInvalidDestination)
+
+	_, err = argocdgenerator.NewAppProject(argocdgenerator.AppProjectOptions{
+		Name: "apps", ClusterResources: []string{"Namespace"},
+	})
+	require.ErrorIs(t, err, argocdgenerator.ErrInvalidClusterResource)
+}
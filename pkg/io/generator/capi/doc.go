@@ -0,0 +1,6 @@
+// Package capigenerator provides utilities for generating Cluster API manifests.
+//
+// This package implements the Generator interface for ClusterSpec structures,
+// translating a KSail cluster into Cluster API manifests for the Docker infrastructure
+// provider (CAPD) so local clusters can be recreated in CAPI-managed environments.
+package capigenerator
@@ -0,0 +1,203 @@
+package capigenerator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/io"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/io/marshaller"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+)
+
+// API versions of the emitted Cluster API resources.
+const (
+	ClusterAPIVersion        = "cluster.x-k8s.io/v1beta1"
+	InfrastructureAPIVersion = "infrastructure.cluster.x-k8s.io/v1beta1"
+	ControlPlaneAPIVersion   = "controlplane.cluster.x-k8s.io/v1beta1"
+	BootstrapAPIVersion      = "bootstrap.cluster.x-k8s.io/v1beta1"
+)
+
+// DefaultNamespace is the namespace used when ClusterSpec.Namespace is empty.
+const DefaultNamespace = "default"
+
+const (
+	controlPlaneSuffix = "-control-plane"
+	workerSuffix       = "-md-0"
+	documentSeparator  = "---\n"
+)
+
+// ClusterSpec describes the cluster to translate into Cluster API manifests.
+type ClusterSpec struct {
+	// Name is the Cluster API cluster name.
+	Name string
+	// Namespace is the namespace of every emitted resource.
+	Namespace string
+	// KubernetesVersion is the version used for control plane and worker machines, e.g. v1.34.0.
+	KubernetesVersion string
+	// ControlPlaneReplicas is the number of control plane machines.
+	ControlPlaneReplicas int32
+	// WorkerReplicas is the number of worker machines.
+	WorkerReplicas int32
+	// PodCIDRs are the pod network ranges.
+	PodCIDRs []string
+	// ServiceCIDRs are the service network ranges.
+	ServiceCIDRs []string
+}
+
+// CAPIGenerator generates Cluster API manifests for the Docker infrastructure provider.
+type CAPIGenerator struct {
+	Marshaller marshaller.Marshaller[map[string]any]
+}
+
+// NewCAPIGenerator creates and returns a new CAPIGenerator instance.
+func NewCAPIGenerator() *CAPIGenerator {
+	return &CAPIGenerator{
+		Marshaller: yamlmarshaller.NewMarshaller[map[string]any](),
+	}
+}
+
+// Generate renders the Cluster API manifests for spec as a multi-document YAML stream
+// and writes it to the specified output.
+//
+// The stream contains a Cluster, DockerCluster, KubeadmControlPlane with its
+// DockerMachineTemplate and, when workers are requested, a MachineDeployment with its
+// DockerMachineTemplate and KubeadmConfigTemplate.
+func (g *CAPIGenerator) Generate(spec *ClusterSpec, opts yamlgenerator.Options) (string, error) {
+	resources := buildResources(spec)
+	documents := make([]string, 0, len(resources))
+
+	for _, resource := range resources {
+		out, err := g.Marshaller.Marshal(resource)
+		if err != nil {
+			return "", fmt.Errorf("marshal capi manifest: %w", err)
+		}
+
+		documents = append(documents, out)
+	}
+
+	out := strings.Join(documents, documentSeparator)
+
+	// write to file if output path is specified
+	if opts.Output != "" {
+		result, err := io.TryWriteFile(out, opts.Output, opts.Force)
+		if err != nil {
+			return "", fmt.Errorf("write capi manifests: %w", err)
+		}
+
+		return result, nil
+	}
+
+	return out, nil
+}
+
+func buildResources(spec *ClusterSpec) []map[string]any {
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	controlPlaneName := spec.Name + controlPlaneSuffix
+
+	resources := []map[string]any{
+		newResource(ClusterAPIVersion, "Cluster", spec.Name, namespace, map[string]any{
+			"clusterNetwork": map[string]any{
+				"pods":     map[string]any{"cidrBlocks": spec.PodCIDRs},
+				"services": map[string]any{"cidrBlocks": spec.ServiceCIDRs},
+			},
+			"controlPlaneRef":   objectRef(ControlPlaneAPIVersion, "KubeadmControlPlane", controlPlaneName),
+			"infrastructureRef": objectRef(InfrastructureAPIVersion, "DockerCluster", spec.Name),
+		}),
+		newResource(InfrastructureAPIVersion, "DockerCluster", spec.Name, namespace, map[string]any{}),
+		newResource(ControlPlaneAPIVersion, "KubeadmControlPlane", controlPlaneName, namespace, map[string]any{
+			"replicas": spec.ControlPlaneReplicas,
+			"version":  spec.KubernetesVersion,
+			"machineTemplate": map[string]any{
+				"infrastructureRef": objectRef(InfrastructureAPIVersion, "DockerMachineTemplate", controlPlaneName),
+			},
+			"kubeadmConfigSpec": map[string]any{
+				"clusterConfiguration": map[string]any{
+					"apiServer": map[string]any{
+						"certSANs": []string{"localhost", "127.0.0.1", "0.0.0.0", "host.docker.internal"},
+					},
+				},
+				"initConfiguration": map[string]any{
+					"nodeRegistration": map[string]any{},
+				},
+				"joinConfiguration": map[string]any{
+					"nodeRegistration": map[string]any{},
+				},
+			},
+		}),
+		newDockerMachineTemplate(controlPlaneName, namespace),
+	}
+
+	if spec.WorkerReplicas == 0 {
+		return resources
+	}
+
+	workerName := spec.Name + workerSuffix
+
+	return append(resources,
+		newResource(ClusterAPIVersion, "MachineDeployment", workerName, namespace, map[string]any{
+			"clusterName": spec.Name,
+			"replicas":    spec.WorkerReplicas,
+			"selector":    map[string]any{"matchLabels": map[string]any{}},
+			"template": map[string]any{
+				"spec": map[string]any{
+					"clusterName": spec.Name,
+					"version":     spec.KubernetesVersion,
+					"bootstrap": map[string]any{
+						"configRef": objectRef(BootstrapAPIVersion, "KubeadmConfigTemplate", workerName),
+					},
+					"infrastructureRef": objectRef(InfrastructureAPIVersion, "DockerMachineTemplate", workerName),
+				},
+			},
+		}),
+		newDockerMachineTemplate(workerName, namespace),
+		newResource(BootstrapAPIVersion, "KubeadmConfigTemplate", workerName, namespace, map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"joinConfiguration": map[string]any{
+						"nodeRegistration": map[string]any{},
+					},
+				},
+			},
+		}),
+	)
+}
+
+func newDockerMachineTemplate(name, namespace string) map[string]any {
+	return newResource(InfrastructureAPIVersion, "DockerMachineTemplate", name, namespace, map[string]any{
+		"template": map[string]any{
+			"spec": map[string]any{
+				"extraMounts": []map[string]any{
+					{
+						"containerPath": "/var/run/docker.sock",
+						"hostPath":      "/var/run/docker.sock",
+					},
+				},
+			},
+		},
+	})
+}
+
+func newResource(apiVersion, kind, name, namespace string, spec map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": spec,
+	}
+}
+
+func objectRef(apiVersion, kind, name string) map[string]any {
+	return map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"name":       name,
+	}
+}
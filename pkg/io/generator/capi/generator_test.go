@@ -0,0 +1,113 @@
+package capigenerator_test
+
+import (
+	"testing"
+
+	generator "github.com/devantler-tech/ksail-go/pkg/io/generator/capi"
+	generatortestutils "github.com/devantler-tech/ksail-go/pkg/io/generator/testutils"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/gkampitakis/go-snaps/snaps"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestMain(m *testing.M) { testutils.RunTestMainWithSnapshotCleanup(m) }
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	gen := generator.NewCAPIGenerator()
+
+	createSpec := func(name string) *generator.ClusterSpec {
+		spec := generator.SpecFromKind(nil)
+		spec.Name = name
+
+		return spec
+	}
+
+	assertContent := func(t *testing.T, result, _ string) {
+		t.Helper()
+		snaps.MatchSnapshot(t, result)
+	}
+
+	generatortestutils.RunStandardGeneratorTests(t, gen, createSpec, "cluster.yaml", assertContent)
+}
+
+func TestGenerateFromKindConfig(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{
+		Name: "dev",
+		Nodes: []kindv1alpha4.Node{
+			{Role: kindv1alpha4.ControlPlaneRole, Image: "kindest/node:v1.31.2@sha256:abc"},
+			{Role: kindv1alpha4.WorkerRole},
+			{Role: kindv1alpha4.WorkerRole},
+		},
+		Networking: kindv1alpha4.Networking{
+			PodSubnet:     "10.10.0.0/16",
+			ServiceSubnet: "10.20.0.0/16",
+		},
+	}
+
+	result, err := generator.NewCAPIGenerator().Generate(
+		generator.SpecFromKind(kindConfig),
+		yamlgenerator.Options{},
+	)
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, result)
+}
+
+func TestGenerateFromK3dConfig(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &k3dv1alpha5.SimpleConfig{
+		Servers: 3,
+		Agents:  1,
+		Image:   "rancher/k3s:v1.29.4-k3s1",
+	}
+	k3dConfig.Name = "edge"
+	k3dConfig.Options.K3sOptions.ExtraArgs = []k3dv1alpha5.K3sArgWithNodeFilters{
+		{Arg: "--cluster-cidr=10.50.0.0/16,fd00:50::/56"},
+	}
+
+	spec := generator.SpecFromK3d(k3dConfig)
+
+	assert.Equal(t, "edge", spec.Name)
+	assert.Equal(t, "v1.29.4", spec.KubernetesVersion)
+	assert.Equal(t, int32(3), spec.ControlPlaneReplicas)
+	assert.Equal(t, int32(1), spec.WorkerReplicas)
+	assert.Equal(t, []string{"10.50.0.0/16", "fd00:50::/56"}, spec.PodCIDRs)
+	assert.Equal(t, []string{"10.43.0.0/16"}, spec.ServiceCIDRs)
+
+	result, err := generator.NewCAPIGenerator().Generate(spec, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, result)
+}
+
+func TestSpecFromK3dDefaults(t *testing.T) {
+	t.Parallel()
+
+	spec := generator.SpecFromK3d(&k3dv1alpha5.SimpleConfig{})
+
+	assert.Equal(t, "k3s-default", spec.Name)
+	assert.Equal(t, "v1.29.4", spec.KubernetesVersion, "the version of the scaffolded K3s image")
+	assert.Equal(t, []string{"10.42.0.0/16"}, spec.PodCIDRs)
+}
+
+func TestSpecFromKindDefaults(t *testing.T) {
+	t.Parallel()
+
+	spec := generator.SpecFromKind(&kindv1alpha4.Cluster{})
+
+	assert.Equal(t, "kind", spec.Name)
+	assert.Regexp(t, `^v\d+\.\d+\.\d+$`, spec.KubernetesVersion)
+	assert.Equal(t, int32(1), spec.ControlPlaneReplicas)
+	assert.Equal(t, int32(0), spec.WorkerReplicas)
+	assert.Equal(t, []string{"10.244.0.0/16"}, spec.PodCIDRs)
+	assert.Equal(t, []string{"10.96.0.0/12"}, spec.ServiceCIDRs)
+}
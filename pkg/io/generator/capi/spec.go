@@ -0,0 +1,156 @@
+package capigenerator
+
+import (
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	k3dtypes "github.com/k3d-io/k3d/v5/pkg/types"
+	kinddefaults "sigs.k8s.io/kind/pkg/apis/config/defaults"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// Network defaults of the source distributions, used when their configs leave them unset.
+const (
+	kindDefaultPodCIDR     = "10.244.0.0/16"
+	kindDefaultServiceCIDR = "10.96.0.0/12"
+	k3sDefaultPodCIDR      = "10.42.0.0/16"
+	k3sDefaultServiceCIDR  = "10.43.0.0/16"
+
+	kindDefaultName = "kind"
+
+	k3sClusterCIDRArg = "--cluster-cidr="
+	k3sServiceCIDRArg = "--service-cidr="
+)
+
+// SpecFromKind translates a Kind cluster configuration into a ClusterSpec.
+//
+// Control plane and worker replicas follow the node roles, the Kubernetes version is taken
+// from the first node image tag (falling back to Kind's default node image), and the pod
+// and service subnets fall back to Kind's defaults.
+func SpecFromKind(cfg *v1alpha4.Cluster) *ClusterSpec {
+	spec := &ClusterSpec{
+		Name:                 kindDefaultName,
+		KubernetesVersion:    versionFromImage(kinddefaults.Image),
+		ControlPlaneReplicas: 1,
+		PodCIDRs:             []string{kindDefaultPodCIDR},
+		ServiceCIDRs:         []string{kindDefaultServiceCIDR},
+	}
+
+	if cfg == nil {
+		return spec
+	}
+
+	if name := strings.TrimSpace(cfg.Name); name != "" {
+		spec.Name = name
+	}
+
+	if cfg.Networking.PodSubnet != "" {
+		spec.PodCIDRs = splitCIDRs(cfg.Networking.PodSubnet)
+	}
+
+	if cfg.Networking.ServiceSubnet != "" {
+		spec.ServiceCIDRs = splitCIDRs(cfg.Networking.ServiceSubnet)
+	}
+
+	if len(cfg.Nodes) == 0 {
+		return spec
+	}
+
+	spec.ControlPlaneReplicas = 0
+	versionFromNode := false
+
+	for _, node := range cfg.Nodes {
+		if node.Role == v1alpha4.WorkerRole {
+			spec.WorkerReplicas++
+		} else {
+			spec.ControlPlaneReplicas++
+		}
+
+		if version := versionFromImage(node.Image); version != "" && !versionFromNode {
+			spec.KubernetesVersion = version
+			versionFromNode = true
+		}
+	}
+
+	return spec
+}
+
+// SpecFromK3d translates a K3d simple configuration into a ClusterSpec.
+//
+// Servers become control plane machines and agents become workers. The Kubernetes version
+// is derived from the K3s image tag (falling back to the K3s image KSail scaffolds), the name
+// falls back to k3d's default cluster name, and --cluster-cidr and --service-cidr K3s arguments
+// override the K3s network defaults.
+func SpecFromK3d(cfg *k3dv1alpha5.SimpleConfig) *ClusterSpec {
+	spec := &ClusterSpec{
+		Name:                 k3dtypes.DefaultClusterName,
+		KubernetesVersion:    versionFromImage(v1alpha1.DefaultK3sImage),
+		ControlPlaneReplicas: 1,
+		PodCIDRs:             []string{k3sDefaultPodCIDR},
+		ServiceCIDRs:         []string{k3sDefaultServiceCIDR},
+	}
+
+	if cfg == nil {
+		return spec
+	}
+
+	if name := strings.TrimSpace(cfg.Name); name != "" {
+		spec.Name = name
+	}
+
+	if cfg.Servers > 0 {
+		spec.ControlPlaneReplicas = int32(cfg.Servers) //nolint:gosec // node counts are small
+	}
+
+	spec.WorkerReplicas = int32(cfg.Agents) //nolint:gosec // node counts are small
+
+	if version := versionFromImage(cfg.Image); version != "" {
+		spec.KubernetesVersion = version
+	}
+
+	for _, extraArg := range cfg.Options.K3sOptions.ExtraArgs {
+		switch {
+		case strings.HasPrefix(extraArg.Arg, k3sClusterCIDRArg):
+			spec.PodCIDRs = splitCIDRs(strings.TrimPrefix(extraArg.Arg, k3sClusterCIDRArg))
+		case strings.HasPrefix(extraArg.Arg, k3sServiceCIDRArg):
+			spec.ServiceCIDRs = splitCIDRs(strings.TrimPrefix(extraArg.Arg, k3sServiceCIDRArg))
+		}
+	}
+
+	return spec
+}
+
+// versionFromImage extracts a Kubernetes version such as v1.34.0 from a node image
+// reference like kindest/node:v1.34.0@sha256:... or rancher/k3s:v1.29.4-k3s1.
+// It returns an empty string when the tag is not a version.
+func versionFromImage(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+
+	index := strings.LastIndex(image, ":")
+	if index < 0 || strings.Contains(image[index:], "/") {
+		return ""
+	}
+
+	tag := image[index+1:]
+	if !strings.HasPrefix(tag, "v") {
+		return ""
+	}
+
+	version, _, _ := strings.Cut(tag, "-")
+
+	return version
+}
+
+func splitCIDRs(value string) []string {
+	parts := strings.Split(value, ",")
+	cidrs := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		if cidr := strings.TrimSpace(part); cidr != "" {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	return cidrs
+}
@@ -0,0 +1,6 @@
+// Package cataloggenerator provides utilities for generating developer portal catalog metadata.
+//
+// This package implements the Generator interface for ProjectSpec structures, describing a
+// KSail-managed environment (the cluster, its components and workload artifacts) as Backstage
+// catalog entities or as generic JSON, so internal developer portals can register it automatically.
+package cataloggenerator
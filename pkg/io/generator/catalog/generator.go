@@ -0,0 +1,223 @@
+package cataloggenerator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/io"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/io/marshaller"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+)
+
+// Format selects how catalog metadata is rendered.
+type Format string
+
+const (
+	// FormatBackstage renders Backstage catalog entities as a multi-document YAML stream.
+	FormatBackstage Format = "backstage"
+	// FormatJSON renders the ProjectSpec as indented JSON.
+	FormatJSON Format = "json"
+)
+
+// BackstageAPIVersion is the API version of the emitted Backstage entities.
+const BackstageAPIVersion = "backstage.io/v1alpha1"
+
+// DefaultOwner is the owner of emitted entities when ProjectSpec.Owner is empty.
+const DefaultOwner = "unknown"
+
+// Annotations and labels set on emitted Backstage entities.
+const (
+	KubernetesIDAnnotation     = "backstage.io/kubernetes-id"
+	SourceLocationAnnotation   = "backstage.io/source-location"
+	DistributionLabel          = "ksail.dev/distribution"
+	KubernetesVersionLabel     = "ksail.dev/kubernetes-version"
+	clusterResourceType        = "kubernetes-cluster"
+	workloadComponentType      = "workload"
+	workloadComponentLifecycle = "experimental"
+	documentSeparator          = "---\n"
+	jsonIndent                 = "  "
+)
+
+// ErrUnsupportedFormat is returned when an unknown catalog format is requested.
+var ErrUnsupportedFormat = errors.New("unsupported catalog format")
+
+// CatalogGenerator generates developer portal catalog metadata.
+type CatalogGenerator struct {
+	Format     Format
+	Marshaller marshaller.Marshaller[map[string]any]
+}
+
+// NewCatalogGenerator creates and returns a new CatalogGenerator rendering format.
+func NewCatalogGenerator(format Format) *CatalogGenerator {
+	return &CatalogGenerator{
+		Format:     format,
+		Marshaller: yamlmarshaller.NewMarshaller[map[string]any](),
+	}
+}
+
+// Generate renders the catalog metadata for spec and writes it to the specified output.
+//
+// The Backstage format emits a System for the environment, a Resource for the cluster and
+// for each component, and a Component for each workload artifact, all related to the cluster
+// so portals can draw the dependency graph.
+func (g *CatalogGenerator) Generate(spec *ProjectSpec, opts yamlgenerator.Options) (string, error) {
+	var (
+		out string
+		err error
+	)
+
+	switch g.Format {
+	case FormatBackstage:
+		out, err = g.renderBackstage(spec)
+	case FormatJSON:
+		out, err = renderJSON(spec)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, g.Format)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// write to file if output path is specified
+	if opts.Output != "" {
+		result, err := io.TryWriteFile(out, opts.Output, opts.Force)
+		if err != nil {
+			return "", fmt.Errorf("write catalog metadata: %w", err)
+		}
+
+		return result, nil
+	}
+
+	return out, nil
+}
+
+func renderJSON(spec *ProjectSpec) (string, error) {
+	data, err := json.MarshalIndent(spec, "", jsonIndent)
+	if err != nil {
+		return "", fmt.Errorf("marshal catalog json: %w", err)
+	}
+
+	return string(data) + "\n", nil
+}
+
+func (g *CatalogGenerator) renderBackstage(spec *ProjectSpec) (string, error) {
+	entities := buildEntities(spec)
+	documents := make([]string, 0, len(entities))
+
+	for _, entity := range entities {
+		out, err := g.Marshaller.Marshal(entity)
+		if err != nil {
+			return "", fmt.Errorf("marshal backstage entity: %w", err)
+		}
+
+		documents = append(documents, out)
+	}
+
+	return strings.Join(documents, documentSeparator), nil
+}
+
+func buildEntities(spec *ProjectSpec) []map[string]any {
+	owner := spec.Owner
+	if owner == "" {
+		owner = DefaultOwner
+	}
+
+	clusterRef := "resource:" + spec.Name
+
+	clusterMetadata := map[string]any{
+		"name":        spec.Name,
+		"description": fmt.Sprintf("%s cluster managed by KSail", spec.Distribution),
+		"labels":      clusterLabels(spec),
+		"annotations": map[string]any{KubernetesIDAnnotation: spec.Name},
+	}
+
+	entities := []map[string]any{
+		newEntity("System", map[string]any{
+			"name":        spec.Name,
+			"description": "Environment managed by KSail",
+		}, map[string]any{"owner": owner}),
+		newEntity("Resource", clusterMetadata, map[string]any{
+			"type":   clusterResourceType,
+			"owner":  owner,
+			"system": spec.Name,
+		}),
+	}
+
+	for _, component := range spec.Components {
+		metadata := map[string]any{
+			"name":  spec.Name + "-" + entityName(component.Name),
+			"title": component.Name,
+		}
+		if component.Endpoint != "" {
+			metadata["links"] = []map[string]any{{"url": "http://" + component.Endpoint, "title": component.Name}}
+		}
+
+		entities = append(entities, newEntity("Resource", metadata, map[string]any{
+			"type":         component.Type,
+			"owner":        owner,
+			"system":       spec.Name,
+			"dependencyOf": []string{clusterRef},
+		}))
+	}
+
+	for _, artifact := range spec.Artifacts {
+		entities = append(entities, newEntity("Component", map[string]any{
+			"name": artifact.Name,
+			"annotations": map[string]any{
+				KubernetesIDAnnotation:   spec.Name,
+				SourceLocationAnnotation: "dir:" + artifact.Location,
+			},
+			"tags": []string{artifact.Type},
+		}, map[string]any{
+			"type":      workloadComponentType,
+			"lifecycle": workloadComponentLifecycle,
+			"owner":     owner,
+			"system":    spec.Name,
+			"dependsOn": []string{clusterRef},
+		}))
+	}
+
+	return entities
+}
+
+func clusterLabels(spec *ProjectSpec) map[string]any {
+	labels := map[string]any{DistributionLabel: entityName(spec.Distribution)}
+	if spec.KubernetesVersion != "" {
+		labels[KubernetesVersionLabel] = spec.KubernetesVersion
+	}
+
+	return labels
+}
+
+func newEntity(kind string, metadata, spec map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": BackstageAPIVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+		"spec":       spec,
+	}
+}
+
+// entityName converts value into a lowercase Backstage entity name, e.g. LocalPathStorage
+// becomes local-path-storage.
+func entityName(value string) string {
+	var builder strings.Builder
+
+	for i, r := range value {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				builder.WriteRune('-')
+			}
+
+			r += 'a' - 'A'
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
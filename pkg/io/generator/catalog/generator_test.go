@@ -0,0 +1,108 @@
+package cataloggenerator_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	generator "github.com/devantler-tech/ksail-go/pkg/io/generator/catalog"
+	generatortestutils "github.com/devantler-tech/ksail-go/pkg/io/generator/testutils"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) { testutils.RunTestMainWithSnapshotCleanup(m) }
+
+func newCluster() *v1alpha1.Cluster {
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Distribution = v1alpha1.DistributionKind
+	cluster.Spec.CNI = v1alpha1.CNICilium
+	cluster.Spec.CSI = v1alpha1.CSILocalPathStorage
+	cluster.Spec.MetricsServer = v1alpha1.MetricsServerEnabled
+	cluster.Spec.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+	cluster.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+	cluster.Spec.SourceDirectory = "k8s"
+	cluster.Spec.Connection.Context = "kind-dev"
+
+	return cluster
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	gen := generator.NewCatalogGenerator(generator.FormatBackstage)
+
+	createSpec := func(name string) *generator.ProjectSpec {
+		return generator.SpecFromCluster(newCluster(), name)
+	}
+
+	assertContent := func(t *testing.T, result, _ string) {
+		t.Helper()
+		snaps.MatchSnapshot(t, result)
+	}
+
+	generatortestutils.RunStandardGeneratorTests(t, gen, createSpec, "catalog-info.yaml", assertContent)
+}
+
+func TestGenerateBackstageWithOwnerAndVersion(t *testing.T) {
+	t.Parallel()
+
+	spec := generator.SpecFromCluster(newCluster(), "dev")
+	spec.Owner = "team-a"
+	spec.KubernetesVersion = "v1.34.0"
+
+	result, err := generator.NewCatalogGenerator(generator.FormatBackstage).Generate(spec, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, result)
+}
+
+func TestGenerateJSON(t *testing.T) {
+	t.Parallel()
+
+	spec := generator.SpecFromCluster(newCluster(), "dev")
+
+	result, err := generator.NewCatalogGenerator(generator.FormatJSON).Generate(spec, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	var decoded generator.ProjectSpec
+
+	require.NoError(t, json.Unmarshal([]byte(result), &decoded))
+	assert.Equal(t, *spec, decoded)
+}
+
+func TestGenerateRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	spec := generator.SpecFromCluster(newCluster(), "dev")
+
+	_, err := generator.NewCatalogGenerator("xml").Generate(spec, yamlgenerator.Options{})
+
+	require.ErrorIs(t, err, generator.ErrUnsupportedFormat)
+}
+
+func TestSpecFromClusterOmitsDistributionDefaults(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Distribution = v1alpha1.DistributionK3d
+	cluster.Spec.CNI = v1alpha1.CNIDefault
+	cluster.Spec.CSI = v1alpha1.CSIDefault
+	cluster.Spec.MetricsServer = v1alpha1.MetricsServerDisabled
+	cluster.Spec.GitOpsEngine = v1alpha1.GitOpsEngineNone
+	cluster.Spec.LocalRegistry = v1alpha1.LocalRegistryDisabled
+	cluster.Spec.SourceDirectory = ""
+
+	spec := generator.SpecFromCluster(cluster, "edge")
+
+	assert.Equal(t, "K3d", spec.Distribution)
+	assert.Empty(t, spec.Components)
+	assert.Equal(t, []generator.Artifact{{
+		Name:     "edge-workloads",
+		Type:     generator.ArtifactTypeKubernetesManifests,
+		Location: v1alpha1.DefaultSourceDirectory,
+	}}, spec.Artifacts)
+}
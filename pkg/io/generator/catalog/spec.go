@@ -0,0 +1,126 @@
+package cataloggenerator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+)
+
+// Component types used for the cluster components of a ProjectSpec.
+const (
+	ComponentTypeCNI           = "cni"
+	ComponentTypeCSI           = "csi"
+	ComponentTypeMetricsServer = "metrics-server"
+	ComponentTypeGitOps        = "gitops"
+	ComponentTypeOCIRegistry   = "oci-registry"
+)
+
+// ArtifactTypeKubernetesManifests is the artifact type of the workload source directory.
+const ArtifactTypeKubernetesManifests = "kubernetes-manifests"
+
+const (
+	localRegistryHost = "localhost"
+	workloadsSuffix   = "-workloads"
+)
+
+// ProjectSpec describes a KSail-managed environment for a developer portal catalog.
+type ProjectSpec struct {
+	// Name is the cluster name.
+	Name string `json:"name"`
+	// Owner is the team or user owning the environment.
+	Owner string `json:"owner,omitempty"`
+	// Distribution is the Kubernetes distribution, e.g. Kind or K3d.
+	Distribution string `json:"distribution"`
+	// KubernetesVersion is the Kubernetes version of the cluster nodes, if known.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Context is the kube context used to reach the cluster.
+	Context string `json:"context,omitempty"`
+	// Components are the cluster components installed by KSail.
+	Components []Component `json:"components"`
+	// Artifacts are the workload artifacts deployed to the cluster.
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Component is a cluster component installed by KSail.
+type Component struct {
+	// Name is the component implementation, e.g. Cilium.
+	Name string `json:"name"`
+	// Type is the component category, e.g. cni.
+	Type string `json:"type"`
+	// Endpoint is where the component is reachable from the host, if anywhere.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Artifact is a workload artifact deployed to the cluster.
+type Artifact struct {
+	// Name identifies the artifact.
+	Name string `json:"name"`
+	// Type is the artifact kind, e.g. kubernetes-manifests.
+	Type string `json:"type"`
+	// Location is the path or reference the artifact is built from.
+	Location string `json:"location"`
+}
+
+// SpecFromCluster describes cluster named name as a ProjectSpec.
+//
+// Components are derived from the CNI, CSI, metrics server, GitOps engine and local registry
+// settings; defaults provided by the distribution itself are omitted. The workload source
+// directory is recorded as the only artifact.
+func SpecFromCluster(cluster *v1alpha1.Cluster, name string) *ProjectSpec {
+	spec := &ProjectSpec{
+		Name:         name,
+		Distribution: string(cluster.Spec.Distribution),
+		Context:      cluster.Spec.Connection.Context,
+		Components:   []Component{},
+		Artifacts:    []Artifact{},
+	}
+
+	if cluster.Spec.CNI != "" && cluster.Spec.CNI != v1alpha1.CNIDefault {
+		spec.Components = append(spec.Components, Component{Name: string(cluster.Spec.CNI), Type: ComponentTypeCNI})
+	}
+
+	if cluster.Spec.CSI != "" && cluster.Spec.CSI != v1alpha1.CSIDefault {
+		spec.Components = append(spec.Components, Component{Name: string(cluster.Spec.CSI), Type: ComponentTypeCSI})
+	}
+
+	if cluster.Spec.MetricsServer == v1alpha1.MetricsServerEnabled {
+		spec.Components = append(spec.Components, Component{
+			Name: "MetricsServer",
+			Type: ComponentTypeMetricsServer,
+		})
+	}
+
+	if cluster.Spec.GitOpsEngine != "" && cluster.Spec.GitOpsEngine != v1alpha1.GitOpsEngineNone {
+		spec.Components = append(spec.Components, Component{
+			Name: string(cluster.Spec.GitOpsEngine),
+			Type: ComponentTypeGitOps,
+		})
+	}
+
+	if cluster.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+		port := cluster.Spec.Options.LocalRegistry.HostPort
+		if port == 0 {
+			port = v1alpha1.DefaultLocalRegistryPort
+		}
+
+		spec.Components = append(spec.Components, Component{
+			Name:     "LocalRegistry",
+			Type:     ComponentTypeOCIRegistry,
+			Endpoint: localRegistryHost + ":" + strconv.Itoa(int(port)),
+		})
+	}
+
+	sourceDirectory := strings.TrimSpace(cluster.Spec.SourceDirectory)
+	if sourceDirectory == "" {
+		sourceDirectory = v1alpha1.DefaultSourceDirectory
+	}
+
+	spec.Artifacts = append(spec.Artifacts, Artifact{
+		Name:     name + workloadsSuffix,
+		Type:     ArtifactTypeKubernetesManifests,
+		Location: sourceDirectory,
+	})
+
+	return spec
+}
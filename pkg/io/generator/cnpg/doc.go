@@ -0,0 +1,5 @@
+// Package cnpggenerator generates CloudNativePG Cluster manifests.
+//
+// The generated Cluster declares a PostgreSQL instance that the CloudNativePG operator
+// provisions, so a database can be added to a GitOps source directory with one command.
+package cnpggenerator
@@ -0,0 +1,164 @@
+package cnpggenerator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/io/marshaller"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// APIVersion is the API version of CloudNativePG clusters.
+	APIVersion = "postgresql.cnpg.io/v1"
+	// Kind is the kind of CloudNativePG clusters.
+	Kind = "Cluster"
+
+	// DefaultInstances is the number of PostgreSQL instances when none is given.
+	DefaultInstances = 1
+	// DefaultStorageSize is the volume size of each instance when none is given.
+	DefaultStorageSize = "1Gi"
+	// DefaultDatabase is the application database created on bootstrap.
+	DefaultDatabase = "app"
+)
+
+var (
+	// ErrInvalidName is returned when a cluster name is not a valid DNS label.
+	ErrInvalidName = errors.New("invalid cluster name")
+	// ErrInvalidInstances is returned when fewer than one instance is requested.
+	ErrInvalidInstances = errors.New("instances must be at least 1")
+	// ErrInvalidStorageSize is returned when the storage size is not a Kubernetes quantity.
+	ErrInvalidStorageSize = errors.New("invalid storage size")
+)
+
+// Cluster is the subset of the CloudNativePG Cluster resource KSail generates.
+type Cluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec ClusterSpec `json:"spec"`
+}
+
+// ClusterSpec is the desired state of a CloudNativePG Cluster.
+type ClusterSpec struct {
+	Instances int       `json:"instances"`
+	ImageName string    `json:"imageName,omitempty"`
+	Storage   Storage   `json:"storage"`
+	Bootstrap Bootstrap `json:"bootstrap"`
+}
+
+// Storage configures the volume of each instance.
+type Storage struct {
+	Size string `json:"size"`
+}
+
+// Bootstrap configures how the cluster is initialized.
+type Bootstrap struct {
+	InitDB InitDB `json:"initdb"`
+}
+
+// InitDB creates an empty application database owned by Owner.
+type InitDB struct {
+	Database string `json:"database"`
+	Owner    string `json:"owner"`
+}
+
+// Options describe the PostgreSQL cluster to generate.
+type Options struct {
+	Name      string
+	Namespace string
+	// Instances defaults to DefaultInstances.
+	Instances int
+	// StorageSize defaults to DefaultStorageSize.
+	StorageSize string
+	// ImageName selects the PostgreSQL image; empty uses the operator default.
+	ImageName string
+	// Database defaults to DefaultDatabase.
+	Database string
+	// Owner defaults to Database.
+	Owner string
+}
+
+// NewCluster builds a Cluster from opts, applying defaults and validating the result.
+func NewCluster(opts Options) (Cluster, error) {
+	name := strings.TrimSpace(opts.Name)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return Cluster{}, fmt.Errorf("%w %q: %s", ErrInvalidName, name, strings.Join(errs, "; "))
+	}
+
+	instances := opts.Instances
+	if instances == 0 {
+		instances = DefaultInstances
+	}
+
+	if instances < 1 {
+		return Cluster{}, fmt.Errorf("%w: %d", ErrInvalidInstances, instances)
+	}
+
+	storageSize := valueOr(opts.StorageSize, DefaultStorageSize)
+
+	_, err := resource.ParseQuantity(storageSize)
+	if err != nil {
+		return Cluster{}, fmt.Errorf("%w %q: %w", ErrInvalidStorageSize, storageSize, err)
+	}
+
+	database := valueOr(opts.Database, DefaultDatabase)
+
+	return Cluster{
+		TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: strings.TrimSpace(opts.Namespace),
+		},
+		Spec: ClusterSpec{
+			Instances: instances,
+			ImageName: strings.TrimSpace(opts.ImageName),
+			Storage:   Storage{Size: storageSize},
+			Bootstrap: Bootstrap{InitDB: InitDB{
+				Database: database,
+				Owner:    valueOr(opts.Owner, database),
+			}},
+		},
+	}, nil
+}
+
+// CNPGGenerator generates CloudNativePG Cluster manifests.
+type CNPGGenerator struct {
+	Marshaller marshaller.Marshaller[Cluster]
+}
+
+// NewCNPGGenerator creates and returns a new CNPGGenerator instance.
+func NewCNPGGenerator() *CNPGGenerator {
+	return &CNPGGenerator{Marshaller: yamlmarshaller.NewMarshaller[Cluster]()}
+}
+
+// Generate builds the Cluster described by cluster options and renders it as YAML,
+// writing it to opts.Output when set.
+func (g *CNPGGenerator) Generate(clusterOpts Options, opts yamlgenerator.Options) (string, error) {
+	cluster, err := NewCluster(clusterOpts)
+	if err != nil {
+		return "", err
+	}
+
+	generator := yamlgenerator.YAMLGenerator[Cluster]{Marshaller: g.Marshaller}
+
+	out, err := generator.Generate(cluster, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate CloudNativePG cluster: %w", err)
+	}
+
+	return out, nil
+}
+
+func valueOr(value, fallback string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+
+	return fallback
+}
@@ -0,0 +1,67 @@
+package cnpggenerator_test
+
+import (
+	"testing"
+
+	cnpggenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/cnpg"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) { testutils.RunTestMainWithSnapshotCleanup(m) }
+
+func TestGenerateDefaults(t *testing.T) {
+	t.Parallel()
+
+	out, err := cnpggenerator.NewCNPGGenerator().Generate(
+		cnpggenerator.Options{Name: "orders-db", Namespace: "orders"},
+		yamlgenerator.Options{},
+	)
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestGenerateCustomized(t *testing.T) {
+	t.Parallel()
+
+	out, err := cnpggenerator.NewCNPGGenerator().Generate(
+		cnpggenerator.Options{
+			Name:        "orders-db",
+			Instances:   3,
+			StorageSize: "10Gi",
+			ImageName:   "ghcr.io/cloudnative-pg/postgresql:17",
+			Database:    "orders",
+			Owner:       "orders-svc",
+		},
+		yamlgenerator.Options{},
+	)
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestNewClusterValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := cnpggenerator.NewCluster(cnpggenerator.Options{Name: "Orders_DB"})
+	require.ErrorIs(t, err, cnpggenerator.ErrInvalidName)
+
+	_, err = cnpggenerator.NewCluster(cnpggenerator.Options{Name: "db", Instances: -1})
+	require.ErrorIs(t, err, cnpggenerator.ErrInvalidInstances)
+
+	_, err = cnpggenerator.NewCluster(cnpggenerator.Options{Name: "db", StorageSize: "lots"})
+	require.ErrorIs(t, err, cnpggenerator.ErrInvalidStorageSize)
+}
+
+func TestNewClusterOwnerDefaultsToDatabase(t *testing.T) {
+	t.Parallel()
+
+	cluster, err := cnpggenerator.NewCluster(cnpggenerator.Options{Name: "db", Database: "orders"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", cluster.Spec.Bootstrap.InitDB.Owner)
+}
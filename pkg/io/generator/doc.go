@@ -9,6 +9,9 @@
 //   - Generate: Transform model into string representation
 //
 // Subpackages:
+//   - capi: Cluster API manifest generator for the Docker infrastructure provider
+//   - catalog: Backstage and JSON catalog metadata generator for developer portals
+//   - cnpg: CloudNativePG PostgreSQL cluster manifest generator
 //   - k3d: K3d YAML configuration generator
 //   - kind: Kind YAML configuration generator
 //   - kustomization: Kustomization YAML generator
@@ -0,0 +1,6 @@
+// Package fluxgenerator generates Flux GitRepository, OCIRepository and Kustomization manifests.
+//
+// kubectl create cannot produce Flux custom resources, so these generators build them from
+// flag-like options, letting a GitOps source directory declare its Flux sources and syncs
+// without the flux CLI.
+package fluxgenerator
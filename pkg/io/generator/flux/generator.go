@@ -0,0 +1,318 @@
+package fluxgenerator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultNamespace is the namespace of generated resources when none is given.
+	DefaultNamespace = "flux-system"
+	// DefaultInterval is the reconciliation interval when none is given.
+	DefaultInterval = time.Minute
+	// DefaultBranch is the branch a GitRepository tracks when no reference is given.
+	DefaultBranch = "main"
+	// DefaultTag is the tag an OCIRepository tracks when no reference is given.
+	DefaultTag = "latest"
+	// DefaultPath is the path a Kustomization applies when none is given.
+	DefaultPath = "./"
+
+	referenceParts  = 2
+	dependencyParts = 2
+)
+
+var (
+	// ErrInvalidName is returned when a resource name is not a valid DNS subdomain.
+	ErrInvalidName = errors.New("invalid name")
+	// ErrInvalidURL is returned when a source URL is missing or has an unsupported scheme.
+	ErrInvalidURL = errors.New("invalid url")
+	// ErrInvalidSourceRef is returned when a Kustomization source is not Kind/name[.namespace]
+	// of a supported kind.
+	ErrInvalidSourceRef = errors.New("invalid source reference")
+	// ErrInvalidDependency is returned when a dependency is not name or namespace/name.
+	ErrInvalidDependency = errors.New("invalid dependency")
+)
+
+// GitRepositoryOptions describe the GitRepository to generate.
+type GitRepositoryOptions struct {
+	Name string
+	// Namespace defaults to DefaultNamespace.
+	Namespace string
+	// URL is the http(s):// or ssh:// address of the repository.
+	URL string
+	// Branch defaults to DefaultBranch when Tag, SemVer and Commit are empty too.
+	Branch string
+	Tag    string
+	SemVer string
+	Commit string
+	// SecretRef names a Secret with the credentials of the repository.
+	SecretRef string
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// OCIRepositoryOptions describe the OCIRepository to generate.
+type OCIRepositoryOptions struct {
+	Name string
+	// Namespace defaults to DefaultNamespace.
+	Namespace string
+	// URL is the oci:// address of the artifact repository.
+	URL string
+	// Tag defaults to DefaultTag when SemVer and Digest are empty too.
+	Tag    string
+	SemVer string
+	Digest string
+	// Provider is the OIDC provider to authenticate with: generic, aws, azure or gcp.
+	Provider string
+	// SecretRef names a docker-registry Secret with the credentials of the registry.
+	SecretRef string
+	// Insecure allows plain HTTP registries, such as the local registry.
+	Insecure bool
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// KustomizationOptions describe the Kustomization to generate.
+type KustomizationOptions struct {
+	Name string
+	// Namespace defaults to DefaultNamespace.
+	Namespace string
+	// Source is the source to apply, as Kind/name or Kind/name.namespace, e.g.
+	// GitRepository/podinfo or OCIRepository/podinfo.flux-system.
+	Source string
+	// Path defaults to DefaultPath.
+	Path string
+	// TargetNamespace overrides the namespace of the applied resources.
+	TargetNamespace string
+	// DependsOn lists Kustomizations, as name or namespace/name, to apply first.
+	DependsOn []string
+	Prune     bool
+	Wait      bool
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration
+	// Timeout bounds apply and health checks; zero leaves it to Flux.
+	Timeout time.Duration
+}
+
+// NewGitRepository builds a GitRepository from opts, applying defaults and validating the result.
+func NewGitRepository(opts GitRepositoryOptions) (*sourcev1.GitRepository, error) {
+	objectMeta, err := newObjectMeta(opts.Name, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSpace(opts.URL)
+	if !hasScheme(url, "http://", "https://", "ssh://") {
+		return nil, fmt.Errorf("%w %q: must start with http://, https:// or ssh://", ErrInvalidURL, url)
+	}
+
+	reference := &sourcev1.GitRepositoryRef{
+		Branch: strings.TrimSpace(opts.Branch),
+		Tag:    strings.TrimSpace(opts.Tag),
+		SemVer: strings.TrimSpace(opts.SemVer),
+		Commit: strings.TrimSpace(opts.Commit),
+	}
+	if *reference == (sourcev1.GitRepositoryRef{}) {
+		reference.Branch = DefaultBranch
+	}
+
+	return &sourcev1.GitRepository{
+		TypeMeta:   metav1.TypeMeta{APIVersion: sourcev1.GroupVersion.String(), Kind: sourcev1.GitRepositoryKind},
+		ObjectMeta: objectMeta,
+		Spec: sourcev1.GitRepositorySpec{
+			URL:       url,
+			Reference: reference,
+			SecretRef: localObjectReference(opts.SecretRef),
+			Interval:  interval(opts.Interval),
+		},
+	}, nil
+}
+
+// NewOCIRepository builds an OCIRepository from opts, applying defaults and validating the result.
+func NewOCIRepository(opts OCIRepositoryOptions) (*sourcev1.OCIRepository, error) {
+	objectMeta, err := newObjectMeta(opts.Name, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSpace(opts.URL)
+	if !hasScheme(url, "oci://") {
+		return nil, fmt.Errorf("%w %q: must start with oci://", ErrInvalidURL, url)
+	}
+
+	reference := &sourcev1.OCIRepositoryRef{
+		Tag:    strings.TrimSpace(opts.Tag),
+		SemVer: strings.TrimSpace(opts.SemVer),
+		Digest: strings.TrimSpace(opts.Digest),
+	}
+	if *reference == (sourcev1.OCIRepositoryRef{}) {
+		reference.Tag = DefaultTag
+	}
+
+	return &sourcev1.OCIRepository{
+		TypeMeta:   metav1.TypeMeta{APIVersion: sourcev1.GroupVersion.String(), Kind: sourcev1.OCIRepositoryKind},
+		ObjectMeta: objectMeta,
+		Spec: sourcev1.OCIRepositorySpec{
+			URL:       url,
+			Reference: reference,
+			Provider:  strings.TrimSpace(opts.Provider),
+			SecretRef: localObjectReference(opts.SecretRef),
+			Insecure:  opts.Insecure,
+			Interval:  interval(opts.Interval),
+		},
+	}, nil
+}
+
+// NewKustomization builds a Kustomization from opts, applying defaults and validating the result.
+func NewKustomization(opts KustomizationOptions) (*kustomizev1.Kustomization, error) {
+	objectMeta, err := newObjectMeta(opts.Name, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceRef, err := parseSourceRef(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	dependsOn, err := parseDependencies(opts.DependsOn)
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.TrimSpace(opts.Path)
+	if path == "" {
+		path = DefaultPath
+	}
+
+	kustomization := &kustomizev1.Kustomization{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kustomizev1.GroupVersion.String(), Kind: kustomizev1.KustomizationKind},
+		ObjectMeta: objectMeta,
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef:       sourceRef,
+			Path:            path,
+			TargetNamespace: strings.TrimSpace(opts.TargetNamespace),
+			DependsOn:       dependsOn,
+			Prune:           opts.Prune,
+			Wait:            opts.Wait,
+			Interval:        interval(opts.Interval),
+		},
+	}
+
+	if opts.Timeout > 0 {
+		kustomization.Spec.Timeout = &metav1.Duration{Duration: opts.Timeout}
+	}
+
+	return kustomization, nil
+}
+
+// Generate renders resource as YAML, writing it to opts.Output when set.
+func Generate[T any](resource T, opts yamlgenerator.Options) (string, error) {
+	out, err := yamlgenerator.NewYAMLGenerator[T]().Generate(resource, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate Flux resource: %w", err)
+	}
+
+	return out, nil
+}
+
+// --- internals ---
+
+func newObjectMeta(name, namespace string) (metav1.ObjectMeta, error) {
+	name = strings.TrimSpace(name)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return metav1.ObjectMeta{}, fmt.Errorf("%w %q: %s", ErrInvalidName, name, strings.Join(errs, "; "))
+	}
+
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	return metav1.ObjectMeta{Name: name, Namespace: namespace}, nil
+}
+
+func hasScheme(url string, schemes ...string) bool {
+	return slices.ContainsFunc(schemes, func(scheme string) bool {
+		return strings.HasPrefix(url, scheme) && len(url) > len(scheme)
+	})
+}
+
+func localObjectReference(name string) *meta.LocalObjectReference {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	return &meta.LocalObjectReference{Name: name}
+}
+
+func interval(value time.Duration) metav1.Duration {
+	if value <= 0 {
+		value = DefaultInterval
+	}
+
+	return metav1.Duration{Duration: value}
+}
+
+// parseSourceRef parses Kind/name or Kind/name.namespace into a source reference. Kinds are
+// matched case-insensitively and returned in their canonical form.
+func parseSourceRef(source string) (kustomizev1.CrossNamespaceSourceReference, error) {
+	kind, name, found := strings.Cut(strings.TrimSpace(source), "/")
+	if !found || name == "" {
+		return kustomizev1.CrossNamespaceSourceReference{}, fmt.Errorf(
+			"%w %q: expected Kind/name or Kind/name.namespace", ErrInvalidSourceRef, source)
+	}
+
+	validKinds := []string{sourcev1.GitRepositoryKind, sourcev1.OCIRepositoryKind, sourcev1.BucketKind}
+
+	index := slices.IndexFunc(validKinds, func(valid string) bool { return strings.EqualFold(kind, valid) })
+	if index < 0 {
+		return kustomizev1.CrossNamespaceSourceReference{}, fmt.Errorf(
+			"%w %q: kind must be one of %s", ErrInvalidSourceRef, source, strings.Join(validKinds, ", "))
+	}
+
+	reference := kustomizev1.CrossNamespaceSourceReference{Kind: validKinds[index], Name: name}
+
+	parts := strings.SplitN(name, ".", referenceParts)
+	if len(parts) == referenceParts {
+		reference.Name = parts[0]
+		reference.Namespace = parts[1]
+	}
+
+	return reference, nil
+}
+
+// parseDependencies parses dependencies given as name or namespace/name.
+func parseDependencies(dependsOn []string) ([]kustomizev1.DependencyReference, error) {
+	references := make([]kustomizev1.DependencyReference, 0, len(dependsOn))
+
+	for _, dependency := range dependsOn {
+		parts := strings.Split(strings.TrimSpace(dependency), "/")
+
+		switch {
+		case len(parts) == 1 && parts[0] != "":
+			references = append(references, kustomizev1.DependencyReference{Name: parts[0]})
+		case len(parts) == dependencyParts && parts[0] != "" && parts[1] != "":
+			references = append(references, kustomizev1.DependencyReference{Namespace: parts[0], Name: parts[1]})
+		default:
+			return nil, fmt.Errorf("%w %q: expected name or namespace/name", ErrInvalidDependency, dependency)
+		}
+	}
+
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	return references, nil
+}
@@ -0,0 +1,118 @@
+package fluxgenerator_test
+
+import (
+	"testing"
+	"time"
+
+	fluxgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/flux"
+	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) { testutils.RunTestMainWithSnapshotCleanup(m) }
+
+func TestGenerateGitRepository(t *testing.T) {
+	t.Parallel()
+
+	repository, err := fluxgenerator.NewGitRepository(fluxgenerator.GitRepositoryOptions{
+		Name:      "podinfo",
+		URL:       "https://github.com/stefanprodan/podinfo",
+		Tag:       "6.9.0",
+		SecretRef: "git-credentials",
+		Interval:  10 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	out, err := fluxgenerator.Generate(*repository, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestGenerateOCIRepository(t *testing.T) {
+	t.Parallel()
+
+	repository, err := fluxgenerator.NewOCIRepository(fluxgenerator.OCIRepositoryOptions{
+		Name:      "podinfo",
+		Namespace: "apps",
+		URL:       "oci://local-registry:5000/podinfo",
+		Insecure:  true,
+	})
+	require.NoError(t, err)
+
+	out, err := fluxgenerator.Generate(*repository, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestGenerateKustomization(t *testing.T) {
+	t.Parallel()
+
+	kustomization, err := fluxgenerator.NewKustomization(fluxgenerator.KustomizationOptions{
+		Name:            "podinfo",
+		Source:          "ocirepository/podinfo.apps",
+		Path:            "./kustomize",
+		TargetNamespace: "podinfo",
+		DependsOn:       []string{"infrastructure", "apps/databases"},
+		Prune:           true,
+		Wait:            true,
+		Timeout:         3 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	out, err := fluxgenerator.Generate(*kustomization, yamlgenerator.Options{})
+	require.NoError(t, err)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+func TestSourceReferenceDefaults(t *testing.T) {
+	t.Parallel()
+
+	git, err := fluxgenerator.NewGitRepository(fluxgenerator.GitRepositoryOptions{
+		Name: "podinfo",
+		URL:  "ssh://git@github.com/stefanprodan/podinfo",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fluxgenerator.DefaultBranch, git.Spec.Reference.Branch)
+	assert.Equal(t, fluxgenerator.DefaultNamespace, git.Namespace)
+
+	oci, err := fluxgenerator.NewOCIRepository(fluxgenerator.OCIRepositoryOptions{
+		Name:   "podinfo",
+		URL:    "oci://ghcr.io/stefanprodan/manifests/podinfo",
+		SemVer: ">=6.0.0",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, oci.Spec.Reference.Tag)
+	assert.Equal(t, ">=6.0.0", oci.Spec.Reference.SemVer)
+}
+
+func TestGeneratorValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := fluxgenerator.NewGitRepository(fluxgenerator.GitRepositoryOptions{Name: "Pod_Info", URL: "https://x"})
+	require.ErrorIs(t, err, fluxgenerator.ErrInvalidName)
+
+	_, err = fluxgenerator.NewGitRepository(fluxgenerator.GitRepositoryOptions{Name: "podinfo", URL: "oci://x"})
+	require.ErrorIs(t, err, fluxgenerator.ErrInvalidURL)
+
+	_, err = fluxgenerator.NewOCIRepository(fluxgenerator.OCIRepositoryOptions{Name: "podinfo"})
+	require.ErrorIs(t, err, fluxgenerator.ErrInvalidURL)
+
+	_, err = fluxgenerator.NewKustomization(fluxgenerator.KustomizationOptions{
+		Name:   "podinfo",
+		Source: "HelmRepository/podinfo",
+	})
+	require.ErrorIs(t, err, fluxgenerator.ErrInvalidSourceRef)
+
+	_, err = fluxgenerator.NewKustomization(fluxgenerator.KustomizationOptions{
+		Name:      "podinfo",
+		Source:    "GitRepository/podinfo",
+		DependsOn: []string{"a/b/c"},
+	})
+	require.ErrorIs(t, err, fluxgenerator.ErrInvalidDependency)
+}
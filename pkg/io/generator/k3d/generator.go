@@ -17,7 +17,7 @@ type K3dGenerator struct {
 
 // NewK3dGenerator creates and returns a new K3dGenerator instance.
 func NewK3dGenerator() *K3dGenerator {
-	m := yamlmarshaller.NewMarshaller[*v1alpha5.SimpleConfig]()
+	m := yamlmarshaller.NewTypedMarshaller[*v1alpha5.SimpleConfig]()
 
 	return &K3dGenerator{
 		Marshaller: m,
@@ -17,7 +17,7 @@ type KindGenerator struct {
 
 // NewKindGenerator creates and returns a new KindGenerator instance.
 func NewKindGenerator() *KindGenerator {
-	m := yamlmarshaller.NewMarshaller[*v1alpha4.Cluster]()
+	m := yamlmarshaller.NewTypedMarshaller[*v1alpha4.Cluster]()
 
 	return &KindGenerator{
 		Marshaller: m,
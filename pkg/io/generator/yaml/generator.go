@@ -28,6 +28,14 @@ func NewYAMLGenerator[T any]() *YAMLGenerator[T] {
 	}
 }
 
+// NewTypedYAMLGenerator creates a YAMLGenerator that marshals through a cached per-type plan.
+// Use it for the known configuration types; NewYAMLGenerator remains the choice for custom types.
+func NewTypedYAMLGenerator[T any]() *YAMLGenerator[T] {
+	return &YAMLGenerator[T]{
+		Marshaller: yamlmarshaller.NewTypedMarshaller[T](),
+	}
+}
+
 // Generate converts a model to YAML string format and optionally writes to file.
 func (g *YAMLGenerator[T]) Generate(model T, opts Options) (string, error) {
 	// marshal model
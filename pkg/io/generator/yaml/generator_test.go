@@ -65,17 +65,7 @@ func TestGenerateWithComplexModel(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if result == "" {
-		t.Fatal("expected non-empty result")
-	}
-
-	// Verify YAML structure is valid by checking it contains expected keys
-	expectedKeys := []string{"metadata:", "spec:", "name:", "replicas:"}
-	for _, key := range expectedKeys {
-		if !strings.Contains(result, key) {
-			t.Errorf("expected result to contain %q, but it didn't. Result: %s", key, result)
-		}
-	}
+	testutils.AssertGolden(t, t.Name(), result)
 }
 
 func TestGenerateWithEmptyModel(t *testing.T) {
@@ -2,4 +2,7 @@
 //
 // This package implements the Marshaller interface for YAML format, providing
 // serialization and deserialization of Go structs to and from YAML.
+//
+// NewMarshaller encodes any model through JSON. NewTypedMarshaller produces the same output for
+// the known configuration types from a cached per-type plan, avoiding the JSON round trip.
 package yamlmarshaller
@@ -3,11 +3,13 @@ package yamlmarshaller_test
 import (
 	"testing"
 
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail-go/pkg/io/marshaller"
 	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
 	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
 // sample model used for tests.
@@ -163,3 +165,143 @@ func assertBadUnmarshalError(
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "failed to unmarshal YAML")
 }
+
+func TestClusterComposeFileRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.ComposeFile = "compose.yaml"
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "composeFile: compose.yaml")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.Equal(t, "compose.yaml", decoded.Spec.ComposeFile)
+}
+
+func TestClusterDockerHostRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.DockerHost = "ssh://dev@build-box"
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "dockerHost: ssh://dev@build-box")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.Equal(t, "ssh://dev@build-box", decoded.Spec.DockerHost)
+}
+
+func TestClusterColimaOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Options.Colima = v1alpha1.OptionsColima{
+		Enabled: true,
+		Profile: "ksail",
+		CPUs:    4,
+		Memory:  8,
+		Disk:    60,
+	}
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "colima:")
+	assert.Contains(t, out, "profile: ksail")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.Equal(t, cluster.Spec.Options.Colima, decoded.Spec.Options.Colima)
+}
+
+func TestClusterGiteaOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Options.Gitea.Enabled = true
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "gitea:")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.True(t, decoded.Spec.Options.Gitea.Enabled)
+}
+
+func TestClusterLocalStackOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Options.LocalStack = v1alpha1.OptionsLocalStack{
+		Enabled:  true,
+		Endpoint: "http://localstack:4566",
+	}
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "endpoint: http://localstack:4566")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.Equal(t, cluster.Spec.Options.LocalStack, decoded.Spec.Options.LocalStack)
+}
+
+func TestClusterCloudNativePGOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	mar := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Options.CloudNativePG.Enabled = true
+
+	out, err := mar.Marshal(*cluster)
+	require.NoError(t, err)
+	assert.Contains(t, out, "cloudNativePG:")
+
+	var decoded v1alpha1.Cluster
+
+	require.NoError(t, mar.UnmarshalString(out, &decoded))
+	assert.True(t, decoded.Spec.Options.CloudNativePG.Enabled)
+}
+
+// FuzzUnmarshalClusterConfigs checks that malformed ksail.yaml and kind.yaml inputs never
+// panic and that anything that unmarshals successfully can be marshalled back.
+func FuzzUnmarshalClusterConfigs(f *testing.F) {
+	testutils.AddYAMLFuzzCorpus(f, testutils.KSailConfigSeeds()...)
+	testutils.AddYAMLFuzzCorpus(f, testutils.KindConfigSeeds()...)
+
+	ksailMarshaller := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	kindMarshaller := yamlmarshaller.NewMarshaller[kindv1alpha4.Cluster]()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var ksailConfig v1alpha1.Cluster
+
+		err := ksailMarshaller.Unmarshal(data, &ksailConfig)
+		if err == nil {
+			_, err = ksailMarshaller.Marshal(ksailConfig)
+			require.NoError(t, err)
+		}
+
+		var kindConfig kindv1alpha4.Cluster
+
+		err = kindMarshaller.UnmarshalString(string(data), &kindConfig)
+		if err == nil {
+			_, err = kindMarshaller.Marshal(kindConfig)
+			require.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,649 @@
+package yamlmarshaller
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/marshaller"
+	yamlv2 "go.yaml.in/yaml/v2"
+)
+
+// TypedMarshaller marshals known configuration types through a cached encoding plan.
+//
+// The default marshaller encodes a model to JSON and parses that JSON back before emitting
+// YAML. For the Kind, K3d and KSail configuration types the generators use, TypedMarshaller
+// builds the YAML tree directly from a per-type plan of JSON field names and omitempty rules,
+// computed once and cached. The output is identical to YAMLMarshaller. Values the plan cannot
+// represent, such as types with custom JSON marshalling, are encoded through JSON for just that
+// value; types the plan does not support at all fall back to YAMLMarshaller.
+type TypedMarshaller[T any] struct {
+	YAMLMarshaller[T]
+}
+
+// NewTypedMarshaller creates a new TypedMarshaller instance implementing Marshaller.
+func NewTypedMarshaller[T any]() marshaller.Marshaller[T] {
+	return &TypedMarshaller[T]{}
+}
+
+// Marshal serializes the model into a string representation.
+func (m *TypedMarshaller[T]) Marshal(model T) (string, error) {
+	tree, ok := encodePlanned(reflect.ValueOf(any(model)))
+	if !ok {
+		return m.YAMLMarshaller.Marshal(model)
+	}
+
+	data, err := yamlv2.Marshal(tree)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// JSONProjector is implemented by types whose MarshalJSON encodes another value, such as a
+// projection with defaults pruned. TypedMarshaller plans that value instead of calling
+// MarshalJSON, so MarshalJSON must be equivalent to json.Marshal of JSONProjection's result.
+type JSONProjector interface {
+	JSONProjection() any
+}
+
+// encoderFunc converts a value into the generic tree sigs.k8s.io/yaml would build from its
+// JSON encoding. It reports false when the value must be marshalled the reflective way.
+type encoderFunc func(reflect.Value) (any, bool)
+
+// planField is a JSON-visible struct field, possibly promoted from an embedded struct.
+type planField struct {
+	name      string
+	index     []int
+	depth     int
+	tagged    bool
+	omitEmpty bool
+	isZero    func(reflect.Value) bool
+	encode    encoderFunc
+}
+
+// isZeroer is the interface encoding/json consults for the omitzero tag option.
+type isZeroer interface {
+	IsZero() bool
+}
+
+//nolint:gochecknoglobals // plans are computed once per type and shared by all marshallers
+var encoderCache sync.Map
+
+//nolint:gochecknoglobals // reflected interface types used to detect custom marshalling
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	projectorType     = reflect.TypeFor[JSONProjector]()
+	isZeroerType      = reflect.TypeFor[isZeroer]()
+)
+
+func encodePlanned(value reflect.Value) (any, bool) {
+	if !value.IsValid() {
+		return nil, true
+	}
+
+	return encoderFor(value.Type())(value)
+}
+
+// encoderFor returns the cached encoder for typ, building it on first use. Recursive types
+// resolve to a placeholder that waits for the encoder being built.
+func encoderFor(typ reflect.Type) encoderFunc {
+	if cached, ok := encoderCache.Load(typ); ok {
+		fn, _ := cached.(encoderFunc)
+
+		return fn
+	}
+
+	var (
+		ready sync.WaitGroup
+		built encoderFunc
+	)
+
+	ready.Add(1)
+
+	placeholder := encoderFunc(func(value reflect.Value) (any, bool) {
+		ready.Wait()
+
+		return built(value)
+	})
+
+	if cached, loaded := encoderCache.LoadOrStore(typ, placeholder); loaded {
+		fn, _ := cached.(encoderFunc)
+
+		return fn
+	}
+
+	built = newEncoder(typ)
+
+	ready.Done()
+	encoderCache.Store(typ, built)
+
+	return built
+}
+
+func newEncoder(typ reflect.Type) encoderFunc {
+	if typ.Implements(projectorType) {
+		return encodeProjection
+	}
+
+	if typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+		return encodeViaJSON
+	}
+
+	plain := newPlainEncoder(typ)
+
+	pointer := reflect.PointerTo(typ)
+	if typ.Kind() != reflect.Pointer &&
+		(pointer.Implements(jsonMarshalerType) || pointer.Implements(textMarshalerType)) {
+		// encoding/json only uses pointer-receiver marshallers for addressable values.
+		return func(value reflect.Value) (any, bool) {
+			if value.CanAddr() {
+				return encodeViaJSON(value.Addr())
+			}
+
+			return plain(value)
+		}
+	}
+
+	return plain
+}
+
+//nolint:cyclop,exhaustive // one case per kind encoding/json supports; the rest are unsupported
+func newPlainEncoder(typ reflect.Type) encoderFunc {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return func(value reflect.Value) (any, bool) { return value.Bool(), true }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(value reflect.Value) (any, bool) { return intNode(value.Int()), true }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encodeUint
+	case reflect.Float32:
+		return floatEncoder(32)
+	case reflect.Float64:
+		return floatEncoder(64)
+	case reflect.String:
+		return encodeString
+	case reflect.Interface:
+		return encodeInterface
+	case reflect.Pointer:
+		return pointerEncoder(typ)
+	case reflect.Map:
+		return mapEncoder(typ)
+	case reflect.Slice:
+		return sliceEncoder(typ)
+	case reflect.Array:
+		return listEncoder(typ)
+	case reflect.Struct:
+		return structEncoder(typ)
+	default:
+		return unsupported
+	}
+}
+
+func unsupported(reflect.Value) (any, bool) {
+	return nil, false
+}
+
+func encodeProjection(value reflect.Value) (any, bool) {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, true
+	}
+
+	if !value.CanInterface() {
+		return nil, false
+	}
+
+	projector, _ := value.Interface().(JSONProjector)
+
+	return encodePlanned(reflect.ValueOf(projector.JSONProjection()))
+}
+
+// encodeViaJSON encodes a value with custom marshalling the way sigs.k8s.io/yaml does.
+func encodeViaJSON(value reflect.Value) (any, bool) {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, true
+	}
+
+	if !value.CanInterface() {
+		return nil, false
+	}
+
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, false
+	}
+
+	var node any
+
+	err = yamlv2.Unmarshal(data, &node)
+	if err != nil {
+		return nil, false
+	}
+
+	return node, true
+}
+
+// intNode returns an integer typed the way go-yaml resolves it when parsing JSON.
+func intNode(number int64) any {
+	if int64(int(number)) == number {
+		return int(number)
+	}
+
+	return number
+}
+
+func encodeUint(value reflect.Value) (any, bool) {
+	number := value.Uint()
+	if number <= math.MaxInt64 {
+		return intNode(int64(number)), true
+	}
+
+	return number, true
+}
+
+// floatEncoder mirrors encoding/json, which writes integral floats without a fraction so
+// go-yaml reads them back as integers.
+func floatEncoder(bits int) encoderFunc {
+	return func(value reflect.Value) (any, bool) {
+		number := value.Float()
+		if math.IsInf(number, 0) || math.IsNaN(number) {
+			return nil, false
+		}
+
+		if number == math.Trunc(number) {
+			if math.Abs(number) >= math.MaxInt64 {
+				return nil, false
+			}
+
+			return intNode(int64(number)), true
+		}
+
+		parsed, err := strconv.ParseFloat(strconv.FormatFloat(number, 'g', -1, bits), 64)
+		if err != nil {
+			return nil, false
+		}
+
+		return parsed, true
+	}
+}
+
+func encodeString(value reflect.Value) (any, bool) {
+	text := value.String()
+	if !utf8.ValidString(text) {
+		return encodeViaJSON(value)
+	}
+
+	return text, true
+}
+
+func encodeInterface(value reflect.Value) (any, bool) {
+	if value.IsNil() {
+		return nil, true
+	}
+
+	return encodePlanned(value.Elem())
+}
+
+func pointerEncoder(typ reflect.Type) encoderFunc {
+	elem := encoderFor(typ.Elem())
+
+	return func(value reflect.Value) (any, bool) {
+		if value.IsNil() {
+			return nil, true
+		}
+
+		return elem(value.Elem())
+	}
+}
+
+func mapEncoder(typ reflect.Type) encoderFunc {
+	keyType := typ.Key()
+
+	var key func(reflect.Value) string
+
+	switch {
+	case keyType.Kind() == reflect.String:
+		key = reflect.Value.String
+	case keyType.Implements(textMarshalerType):
+		return unsupported
+	case reflect.Zero(keyType).CanInt():
+		key = func(value reflect.Value) string { return strconv.FormatInt(value.Int(), 10) }
+	case reflect.Zero(keyType).CanUint():
+		key = func(value reflect.Value) string { return strconv.FormatUint(value.Uint(), 10) }
+	default:
+		return unsupported
+	}
+
+	elem := encoderFor(typ.Elem())
+
+	return func(value reflect.Value) (any, bool) {
+		if value.IsNil() {
+			return nil, true
+		}
+
+		node := make(map[any]any, value.Len())
+
+		iter := value.MapRange()
+		for iter.Next() {
+			encoded, ok := elem(iter.Value())
+			if !ok {
+				return nil, false
+			}
+
+			node[key(iter.Key())] = encoded
+		}
+
+		return node, true
+	}
+}
+
+func sliceEncoder(typ reflect.Type) encoderFunc {
+	elemType := typ.Elem()
+	pointer := reflect.PointerTo(elemType)
+
+	isBytes := elemType.Kind() == reflect.Uint8 &&
+		!elemType.Implements(jsonMarshalerType) && !elemType.Implements(textMarshalerType) &&
+		!pointer.Implements(jsonMarshalerType) && !pointer.Implements(textMarshalerType)
+	if isBytes {
+		return func(value reflect.Value) (any, bool) {
+			if value.IsNil() {
+				return nil, true
+			}
+
+			return base64.StdEncoding.EncodeToString(value.Bytes()), true
+		}
+	}
+
+	list := listEncoder(typ)
+
+	return func(value reflect.Value) (any, bool) {
+		if value.IsNil() {
+			return nil, true
+		}
+
+		return list(value)
+	}
+}
+
+func listEncoder(typ reflect.Type) encoderFunc {
+	elem := encoderFor(typ.Elem())
+
+	return func(value reflect.Value) (any, bool) {
+		node := make([]any, value.Len())
+
+		for index := range node {
+			encoded, ok := elem(value.Index(index))
+			if !ok {
+				return nil, false
+			}
+
+			node[index] = encoded
+		}
+
+		return node, true
+	}
+}
+
+func structEncoder(typ reflect.Type) encoderFunc {
+	fields, ok := collectFields(typ)
+	if !ok {
+		return unsupported
+	}
+
+	return func(value reflect.Value) (any, bool) {
+		node := make(map[any]any, len(fields))
+
+		for _, field := range fields {
+			fieldValue, present := fieldByIndex(value, field.index)
+			if !present || (field.omitEmpty && isEmptyValue(fieldValue)) ||
+				(field.isZero != nil && field.isZero(fieldValue)) {
+				continue
+			}
+
+			encoded, ok := field.encode(fieldValue)
+			if !ok {
+				return nil, false
+			}
+
+			node[field.name] = encoded
+		}
+
+		return node, true
+	}
+}
+
+// collectFields lists the fields encoding/json would emit for typ, applying its rules for
+// promoted fields. It reports false for the string tag option, which the plan does not implement.
+func collectFields(typ reflect.Type) ([]planField, bool) {
+	var candidates []planField
+
+	ok := appendFields(typ, nil, map[reflect.Type]bool{typ: true}, &candidates)
+	if !ok {
+		return nil, false
+	}
+
+	byName := map[string][]planField{}
+	order := []string{}
+
+	for _, field := range candidates {
+		if _, seen := byName[field.name]; !seen {
+			order = append(order, field.name)
+		}
+
+		byName[field.name] = append(byName[field.name], field)
+	}
+
+	fields := make([]planField, 0, len(order))
+
+	for _, name := range order {
+		field, dominant := dominantField(byName[name])
+		if dominant {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, true
+}
+
+func appendFields(
+	typ reflect.Type,
+	index []int,
+	visited map[reflect.Type]bool,
+	fields *[]planField,
+) bool {
+	for fieldIndex := range typ.NumField() {
+		structField := typ.Field(fieldIndex)
+
+		tag := structField.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if hasTagOption(options, "string") {
+			return false
+		}
+
+		fieldType := structField.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if structField.Anonymous {
+			if !structField.IsExported() && fieldType.Kind() != reflect.Struct {
+				continue
+			}
+		} else if !structField.IsExported() {
+			continue
+		}
+
+		fieldPath := append(append([]int{}, index...), fieldIndex)
+
+		if structField.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if visited[fieldType] {
+				return false
+			}
+
+			visited[fieldType] = true
+
+			if !appendFields(fieldType, fieldPath, visited, fields) {
+				return false
+			}
+
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = structField.Name
+		}
+
+		*fields = append(*fields, planField{
+			name:      name,
+			index:     fieldPath,
+			depth:     len(fieldPath),
+			tagged:    tagged,
+			omitEmpty: hasTagOption(options, "omitempty"),
+			isZero:    zeroCheck(structField.Type, hasTagOption(options, "omitzero")),
+			encode:    encoderFor(structField.Type),
+		})
+	}
+
+	return true
+}
+
+// dominantField picks the field encoding/json emits among fields sharing a name: the
+// shallowest one, or the only tagged one among the shallowest. Ambiguous names are dropped.
+func dominantField(fields []planField) (planField, bool) {
+	shallowest := fields[0].depth
+	for _, field := range fields[1:] {
+		shallowest = min(shallowest, field.depth)
+	}
+
+	var (
+		candidates []planField
+		tagged     []planField
+	)
+
+	for _, field := range fields {
+		if field.depth != shallowest {
+			continue
+		}
+
+		candidates = append(candidates, field)
+		if field.tagged {
+			tagged = append(tagged, field)
+		}
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	default:
+		return planField{}, false
+	}
+}
+
+func hasTagOption(options, option string) bool {
+	for candidate := range strings.SplitSeq(options, ",") {
+		if candidate == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldByIndex walks to a possibly promoted field, reporting false when an embedded pointer
+// on the way is nil.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for _, fieldIndex := range index {
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.Field(fieldIndex)
+	}
+
+	return value, true
+}
+
+// zeroCheck mirrors encoding/json's omitzero rules: a type's own IsZero method when it has
+// one, and the reflective zero value otherwise. It returns nil when omitzero is not set.
+func zeroCheck(typ reflect.Type, omitZero bool) func(reflect.Value) bool {
+	if !omitZero {
+		return nil
+	}
+
+	switch {
+	case typ.Kind() == reflect.Interface && typ.Implements(isZeroerType):
+		return func(value reflect.Value) bool {
+			return value.IsNil() ||
+				(value.Elem().Kind() == reflect.Pointer && value.Elem().IsNil()) ||
+				callIsZero(value)
+		}
+	case typ.Kind() == reflect.Pointer && typ.Implements(isZeroerType):
+		return func(value reflect.Value) bool {
+			return value.IsNil() || callIsZero(value)
+		}
+	case typ.Implements(isZeroerType):
+		return callIsZero
+	case reflect.PointerTo(typ).Implements(isZeroerType):
+		return func(value reflect.Value) bool {
+			if !value.CanAddr() {
+				boxed := reflect.New(value.Type()).Elem()
+				boxed.Set(value)
+				value = boxed
+			}
+
+			return callIsZero(value.Addr())
+		}
+	default:
+		return reflect.Value.IsZero
+	}
+}
+
+func callIsZero(value reflect.Value) bool {
+	if !value.CanInterface() {
+		return value.IsZero()
+	}
+
+	zeroer, ok := value.Interface().(isZeroer)
+
+	return ok && zeroer.IsZero()
+}
+
+//nolint:exhaustive // mirrors encoding/json's omitempty rules; other kinds are never empty
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
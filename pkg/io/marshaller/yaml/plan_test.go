@@ -0,0 +1,190 @@
+package yamlmarshaller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// assertSameAsReflective checks that the typed marshaller emits exactly what the reflective
+// marshaller emits for model.
+func assertSameAsReflective[T any](t *testing.T, model T) {
+	t.Helper()
+
+	want, wantErr := yamlmarshaller.NewMarshaller[T]().Marshal(model)
+	got, gotErr := yamlmarshaller.NewTypedMarshaller[T]().Marshal(model)
+
+	if wantErr != nil {
+		require.Error(t, gotErr)
+
+		return
+	}
+
+	require.NoError(t, gotErr)
+	assert.Equal(t, want, got)
+}
+
+func TestTypedMarshalMatchesKindConfig(t *testing.T) {
+	t.Parallel()
+
+	assertSameAsReflective(t, &kindv1alpha4.Cluster{})
+
+	cluster := &kindv1alpha4.Cluster{
+		TypeMeta: kindv1alpha4.TypeMeta{APIVersion: "kind.x-k8s.io/v1alpha4", Kind: "Cluster"},
+		Name:     "ksail",
+		Nodes: []kindv1alpha4.Node{
+			{
+				Role:  kindv1alpha4.ControlPlaneRole,
+				Image: "kindest/node:v1.34.0",
+				ExtraPortMappings: []kindv1alpha4.PortMapping{
+					{ContainerPort: 80, HostPort: 8080, Protocol: kindv1alpha4.PortMappingProtocolTCP},
+				},
+				ExtraMounts: []kindv1alpha4.Mount{{HostPath: "/tmp", ContainerPath: "/data", Readonly: true}},
+				Labels:      map[string]string{"ingress-ready": "true", "zone": "yes"},
+			},
+			{Role: kindv1alpha4.WorkerRole},
+		},
+		Networking: kindv1alpha4.Networking{
+			DisableDefaultCNI: true,
+			KubeProxyMode:     kindv1alpha4.IPVSProxyMode,
+			APIServerPort:     6443,
+		},
+		FeatureGates:            map[string]bool{"InPlacePodVerticalScaling": true},
+		ContainerdConfigPatches: []string{"[plugins]\n  enabled = true\n"},
+	}
+
+	assertSameAsReflective(t, cluster)
+}
+
+func TestTypedMarshalMatchesK3dConfig(t *testing.T) {
+	t.Parallel()
+
+	assertSameAsReflective(t, &k3dv1alpha5.SimpleConfig{})
+
+	config := &k3dv1alpha5.SimpleConfig{
+		Servers: 1,
+		Agents:  2,
+		Image:   "rancher/k3s:v1.34.1-k3s1",
+		Ports: []k3dv1alpha5.PortWithNodeFilters{
+			{Port: "8080:80", NodeFilters: []string{"loadbalancer"}},
+		},
+		Options: k3dv1alpha5.SimpleConfigOptions{
+			K3dOptions: k3dv1alpha5.SimpleConfigOptionsK3d{Wait: true, Timeout: time.Minute},
+			K3sOptions: k3dv1alpha5.SimpleConfigOptionsK3s{
+				ExtraArgs: []k3dv1alpha5.K3sArgWithNodeFilters{
+					{Arg: "--disable=metrics-server", NodeFilters: []string{"server:*"}},
+				},
+			},
+			Runtime: k3dv1alpha5.SimpleConfigOptionsRuntime{
+				Ulimits: []k3dv1alpha5.Ulimit{{Name: "nofile", Soft: 1024, Hard: 4096}},
+			},
+		},
+		Registries: k3dv1alpha5.SimpleConfigRegistries{
+			Use:    []string{"k3d-registry:5000"},
+			Create: &k3dv1alpha5.SimpleConfigRegistryCreateConfig{Name: "registry"},
+		},
+	}
+	config.APIVersion = "k3d.io/v1alpha5"
+	config.Kind = "Simple"
+
+	assertSameAsReflective(t, config)
+}
+
+func TestTypedMarshalMatchesKSailConfig(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Options.LocalStack.Enabled = true
+	cluster.Spec.Connection.Timeout = metav1.Duration{Duration: 90 * time.Second}
+
+	assertSameAsReflective(t, *cluster)
+	assertSameAsReflective(t, cluster)
+}
+
+type embeddedBase struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type otherBase struct {
+	Name string `json:"name"`
+}
+
+type textValue struct{ raw string }
+
+func (v *textValue) MarshalText() ([]byte, error) { return []byte("text:" + v.raw), nil }
+
+type customTypes struct {
+	*embeddedBase
+	otherBase
+
+	Ratio     float64            `json:"ratio"`
+	Whole     float64            `json:"whole"`
+	Small     float32            `json:"small"`
+	Big       uint64             `json:"big"`
+	Data      []byte             `json:"data"`
+	Ports     map[int]string     `json:"ports"`
+	Values    map[string]any     `json:"values,omitempty"`
+	Empty     []string           `json:"empty"`
+	Omitted   []string           `json:"omitted,omitempty"`
+	Text      textValue          `json:"text"`
+	TextPtr   *textValue         `json:"textPtr"`
+	Untagged  string             //nolint:tagliatelle // exercises untagged field names
+	Skipped   string             `json:"-"`
+	Nested    map[string][]int   `json:"nested"`
+	Duration  time.Duration      `json:"duration"`
+	Timestamp metav1.Time        `json:"timestamp"`
+	Labels    map[string]*string `json:"labels"`
+	hidden    string
+}
+
+func TestTypedMarshalMatchesCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	label := "yes"
+	model := customTypes{
+		embeddedBase: &embeddedBase{ID: 7, Name: "embedded"},
+		otherBase:    otherBase{Name: "other"},
+		Ratio:        0.25,
+		Whole:        3,
+		Small:        0.1,
+		Big:          1 << 63,
+		Data:         []byte("payload"),
+		Ports:        map[int]string{80: "http", 443: "https"},
+		Values:       map[string]any{"count": 2, "nested": map[string]any{"on": true}, "none": nil},
+		Empty:        []string{},
+		Text:         textValue{raw: "value"},
+		TextPtr:      &textValue{raw: "pointer"},
+		Untagged:     "untagged",
+		Skipped:      "skipped",
+		Nested:       map[string][]int{"a": {1, 2}},
+		Duration:     time.Second,
+		Timestamp:    metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Labels:       map[string]*string{"set": &label, "unset": nil},
+		hidden:       "hidden",
+	}
+
+	assertSameAsReflective(t, model)
+	assertSameAsReflective(t, &model)
+	assertSameAsReflective(t, customTypes{})
+	assertSameAsReflective[any](t, nil)
+	assertSameAsReflective(t, map[string]string{"invalid": "\xff"})
+}
+
+func TestTypedMarshalFallsBackForUnsupportedTypes(t *testing.T) {
+	t.Parallel()
+
+	_, err := yamlmarshaller.NewTypedMarshaller[bad]().Marshal(bad{F: func() {}})
+	require.Error(t, err)
+
+	assertSameAsReflective(t, struct {
+		Count int `json:"count,string"`
+	}{Count: 3})
+}
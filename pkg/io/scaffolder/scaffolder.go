@@ -1,6 +1,7 @@
 package scaffolder
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
@@ -10,11 +11,15 @@ import (
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
 	"github.com/devantler-tech/ksail-go/pkg/io/generator"
 	k3dgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/k3d"
 	kindgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/kind"
 	kustomizationgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/kustomization"
 	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
 	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/k3d-io/k3d/v5/pkg/config/types"
@@ -31,13 +36,24 @@ const (
 
 	// K3dConfigFile is the default filename for K3d distribution configuration.
 	K3dConfigFile = "k3d.yaml"
+
+	// TalosConfigFile is the default filename for Talos distribution configuration.
+	TalosConfigFile = "talos.yaml"
+
+	// K0sConfigFile is the default filename for k0s distribution configuration.
+	K0sConfigFile = "k0s.yaml"
+
+	// KwokConfigFile is the default filename for kwok distribution configuration.
+	KwokConfigFile = "kwok.yaml"
 )
 
 const (
-	// Default images.
+	// K3s subnets for IPv6 and DualStack clusters, which K3s does not default.
 
-	// defaultK3sImage pins K3d clusters to a Flux-compatible Kubernetes version.
-	defaultK3sImage = "rancher/k3s:v1.29.4-k3s1"
+	k3sIPv4PodSubnet     = "10.42.0.0/16"
+	k3sIPv4ServiceSubnet = "10.43.0.0/16"
+	k3sIPv6PodSubnet     = "2001:cafe:42::/56"
+	k3sIPv6ServiceSubnet = "2001:cafe:43::/112"
 )
 
 var (
@@ -55,6 +71,15 @@ var (
 	// ErrK3dConfigGeneration wraps failures when creating K3d configuration.
 	ErrK3dConfigGeneration = errors.New("failed to generate k3d configuration")
 
+	// ErrTalosConfigGeneration wraps failures when creating Talos configuration.
+	ErrTalosConfigGeneration = errors.New("failed to generate talos configuration")
+
+	// ErrK0sConfigGeneration wraps failures when creating k0s configuration.
+	ErrK0sConfigGeneration = errors.New("failed to generate k0s configuration")
+
+	// ErrKwokConfigGeneration wraps failures when creating kwok configuration.
+	ErrKwokConfigGeneration = errors.New("failed to generate kwok configuration")
+
 	// ErrKustomizationGeneration wraps failures when creating kustomization.yaml.
 	ErrKustomizationGeneration = errors.New("failed to generate kustomization configuration")
 )
@@ -65,6 +90,9 @@ type Scaffolder struct {
 	KSailYAMLGenerator     generator.Generator[v1alpha1.Cluster, yamlgenerator.Options]
 	KindGenerator          generator.Generator[*v1alpha4.Cluster, yamlgenerator.Options]
 	K3dGenerator           generator.Generator[*k3dv1alpha5.SimpleConfig, yamlgenerator.Options]
+	TalosGenerator         generator.Generator[*talosprovisioner.Config, yamlgenerator.Options]
+	K0sGenerator           generator.Generator[*k0sprovisioner.Config, yamlgenerator.Options]
+	KwokGenerator          generator.Generator[*kwokprovisioner.Config, yamlgenerator.Options]
 	KustomizationGenerator generator.Generator[*ktypes.Kustomization, yamlgenerator.Options]
 	Writer                 io.Writer
 	MirrorRegistries       []string // Format: "name=upstream" (e.g., "docker.io=https://registry-1.docker.io")
@@ -72,7 +100,7 @@ type Scaffolder struct {
 
 // NewScaffolder creates a new Scaffolder instance with the provided KSail cluster configuration.
 func NewScaffolder(cfg v1alpha1.Cluster, writer io.Writer) *Scaffolder {
-	ksailGenerator := yamlgenerator.NewYAMLGenerator[v1alpha1.Cluster]()
+	ksailGenerator := yamlgenerator.NewTypedYAMLGenerator[v1alpha1.Cluster]()
 	kindGenerator := kindgenerator.NewKindGenerator()
 	k3dGenerator := k3dgenerator.NewK3dGenerator()
 	kustomizationGenerator := kustomizationgenerator.NewKustomizationGenerator()
@@ -82,6 +110,9 @@ func NewScaffolder(cfg v1alpha1.Cluster, writer io.Writer) *Scaffolder {
 		KSailYAMLGenerator:     ksailGenerator,
 		KindGenerator:          kindGenerator,
 		K3dGenerator:           k3dGenerator,
+		TalosGenerator:         yamlgenerator.NewTypedYAMLGenerator[*talosprovisioner.Config](),
+		K0sGenerator:           yamlgenerator.NewTypedYAMLGenerator[*k0sprovisioner.Config](),
+		KwokGenerator:          yamlgenerator.NewTypedYAMLGenerator[*kwokprovisioner.Config](),
 		KustomizationGenerator: kustomizationGenerator,
 		Writer:                 writer,
 	}
@@ -93,7 +124,7 @@ func NewScaffolder(cfg v1alpha1.Cluster, writer io.Writer) *Scaffolder {
 //
 // This method orchestrates the generation of:
 //   - ksail.yaml configuration
-//   - Distribution-specific configuration (kind.yaml or k3d.yaml)
+//   - Distribution-specific configuration (kind.yaml, k3d.yaml or talos.yaml)
 //   - kustomization.yaml in the source directory
 //
 // Parameters:
@@ -127,11 +158,21 @@ func (s *Scaffolder) Scaffold(output string, force bool) error {
 
 // Registry configuration helpers.
 
+// mirrorSpecs returns the mirrors of the KSail configuration, overridden per host by the
+// MirrorRegistries specs.
+func (s *Scaffolder) mirrorSpecs() []registry.MirrorSpec {
+	return registry.MergeMirrorSpecs(
+		registry.MirrorSpecsFromConfig(s.KSailConfig.Spec.Mirrors),
+		registry.ParseMirrorSpecs(s.MirrorRegistries),
+	)
+}
+
 // GenerateContainerdPatches generates containerd config patches for Kind mirror registry.
-// Input format: "name=upstream" (e.g., "docker.io=https://registry-1.docker.io")
+// Mirrors come from spec.mirrors of the KSail configuration and MirrorRegistries, whose
+// format is "name=upstream" (e.g., "docker.io=https://registry-1.docker.io").
 // Container names match the registry host after sanitization to align with runtime provisioning.
 func (s *Scaffolder) GenerateContainerdPatches() []string {
-	specs := registry.ParseMirrorSpecs(s.MirrorRegistries)
+	specs := s.mirrorSpecs()
 	if len(specs) == 0 {
 		return nil
 	}
@@ -147,7 +188,8 @@ func (s *Scaffolder) GenerateContainerdPatches() []string {
 }
 
 // GenerateK3dRegistryConfig generates K3d registry configuration for mirror registry.
-// Input format: "name=upstream" (e.g., "docker.io=https://registry-1.docker.io")
+// Mirrors come from spec.mirrors of the KSail configuration and MirrorRegistries, whose
+// format is "name=upstream" (e.g., "docker.io=https://registry-1.docker.io").
 // K3d requires one registry per proxy, so we generate multiple create configs.
 func (s *Scaffolder) GenerateK3dRegistryConfig() k3dv1alpha5.SimpleConfigRegistries {
 	registryConfig := k3dv1alpha5.SimpleConfigRegistries{}
@@ -156,7 +198,7 @@ func (s *Scaffolder) GenerateK3dRegistryConfig() k3dv1alpha5.SimpleConfigRegistr
 		return registryConfig
 	}
 
-	specs := registry.ParseMirrorSpecs(s.MirrorRegistries)
+	specs := s.mirrorSpecs()
 
 	hostEndpoints, updated := registry.BuildHostEndpointMap(specs, "", nil)
 	if len(hostEndpoints) == 0 || !updated {
@@ -177,7 +219,7 @@ func (s *Scaffolder) CreateK3dConfig() k3dv1alpha5.SimpleConfig {
 			APIVersion: "k3d.io/v1alpha5",
 			Kind:       "Simple",
 		},
-		Image: defaultK3sImage,
+		Image: v1alpha1.DefaultK3sImage,
 		// Additional configuration will be handled by the provisioner with sensible defaults
 		// Users can override any settings in this generated config file
 	}
@@ -209,21 +251,95 @@ func (s *Scaffolder) CreateK3dConfig() k3dv1alpha5.SimpleConfig {
 		)
 	}
 
+	extraArgs = append(extraArgs, s.k3dNetworkingArgs()...)
+
 	// Set ExtraArgs if we have any
 	if len(extraArgs) > 0 {
 		config.Options.K3sOptions.ExtraArgs = extraArgs
 	}
 
 	// Add registry configuration for mirror registries
-	if len(s.MirrorRegistries) > 0 {
+	if len(s.mirrorSpecs()) > 0 {
 		config.Registries = s.GenerateK3dRegistryConfig()
 	}
 
+	// k3d puts the servers behind its load balancer, so more than one yields an HA control plane
+	s.applyNodeCounts(&config)
+	configmanager.ApplyNodes(&config, s.KSailConfig.Spec.Nodes)
+	configmanager.ApplyMounts(&config, s.KSailConfig.Spec.Mounts)
+
+	dockerNetwork := s.KSailConfig.Spec.Networking.DockerNetwork
+	configmanager.ApplyDockerNetwork(&config, dockerNetwork.Name, dockerNetwork.Subnet)
+
 	return config
 }
 
 // Configuration defaults and helpers.
 
+// applyNodeCounts writes the node counts of the KSail configuration into a distribution config.
+func (s *Scaffolder) applyNodeCounts(distributionConfig any) {
+	configmanager.ApplyNodeCounts(
+		distributionConfig,
+		s.KSailConfig.Spec.ControlPlaneNodes,
+		s.KSailConfig.Spec.WorkerNodes,
+	)
+}
+
+// applyKindNetworking writes the IP family and subnets of the KSail configuration into a Kind config.
+func (s *Scaffolder) applyKindNetworking(kindConfig *v1alpha4.Cluster) {
+	networking := s.KSailConfig.Spec.Networking
+
+	switch networking.IPFamily {
+	case v1alpha1.IPFamilyIPv4:
+		kindConfig.Networking.IPFamily = v1alpha4.IPv4Family
+	case v1alpha1.IPFamilyIPv6:
+		kindConfig.Networking.IPFamily = v1alpha4.IPv6Family
+	case v1alpha1.IPFamilyDualStack:
+		kindConfig.Networking.IPFamily = v1alpha4.DualStackFamily
+	}
+
+	kindConfig.Networking.PodSubnet = networking.PodSubnet
+	kindConfig.Networking.ServiceSubnet = networking.ServiceSubnet
+}
+
+// k3dNetworkingArgs returns the K3s server arguments for the IP family and subnets of the KSail
+// configuration. K3s has no IPv6 defaults, so IPv6 and DualStack clusters without subnets get
+// the IPv6 ranges the K3s documentation uses.
+func (s *Scaffolder) k3dNetworkingArgs() []k3dv1alpha5.K3sArgWithNodeFilters {
+	networking := s.KSailConfig.Spec.Networking
+	podSubnet := networking.PodSubnet
+	serviceSubnet := networking.ServiceSubnet
+
+	switch networking.IPFamily {
+	case v1alpha1.IPFamilyIPv6:
+		podSubnet = cmp.Or(podSubnet, k3sIPv6PodSubnet)
+		serviceSubnet = cmp.Or(serviceSubnet, k3sIPv6ServiceSubnet)
+	case v1alpha1.IPFamilyDualStack:
+		podSubnet = cmp.Or(podSubnet, k3sIPv4PodSubnet+","+k3sIPv6PodSubnet)
+		serviceSubnet = cmp.Or(serviceSubnet, k3sIPv4ServiceSubnet+","+k3sIPv6ServiceSubnet)
+	case v1alpha1.IPFamilyIPv4:
+		// K3s defaults to IPv4 subnets
+	}
+
+	var args []k3dv1alpha5.K3sArgWithNodeFilters
+
+	if podSubnet != "" {
+		args = append(args, k3dv1alpha5.K3sArgWithNodeFilters{
+			Arg:         "--cluster-cidr=" + podSubnet,
+			NodeFilters: []string{"server:*"},
+		})
+	}
+
+	if serviceSubnet != "" {
+		args = append(args, k3dv1alpha5.K3sArgWithNodeFilters{
+			Arg:         "--service-cidr=" + serviceSubnet,
+			NodeFilters: []string{"server:*"},
+		})
+	}
+
+	return args
+}
+
 // applyKSailConfigDefaults applies distribution-specific defaults to the KSail configuration.
 // This ensures the generated ksail.yaml has consistent context and distributionConfig values
 // that match the distribution-specific configuration files being generated.
@@ -408,6 +524,15 @@ func (s *Scaffolder) generateDistributionConfig(output string, force bool) error
 		return s.generateKindConfig(output, force)
 	case v1alpha1.DistributionK3d:
 		return s.generateK3dConfig(output, force)
+	case v1alpha1.DistributionTalos:
+		return s.generateTalosConfig(output, force)
+	case v1alpha1.DistributionK0s:
+		return s.generateK0sConfig(output, force)
+	case v1alpha1.DistributionKwok:
+		return s.generateKwokConfig(output, force)
+	case v1alpha1.DistributionExternal:
+		// External clusters are not provisioned, so they have no distribution configuration
+		return nil
 	default:
 		return ErrUnknownDistribution
 	}
@@ -475,10 +600,17 @@ func (s *Scaffolder) generateKindConfig(output string, force bool) error {
 	}
 
 	// Add containerd config patches for mirror registries
-	if len(s.MirrorRegistries) > 0 {
+	if len(s.mirrorSpecs()) > 0 {
 		kindConfig.ContainerdConfigPatches = s.GenerateContainerdPatches()
 	}
 
+	s.applyKindNetworking(kindConfig)
+
+	// Kind puts multiple control-plane nodes behind an external load balancer
+	s.applyNodeCounts(kindConfig)
+	configmanager.ApplyNodes(kindConfig, s.KSailConfig.Spec.Nodes)
+	configmanager.ApplyMounts(kindConfig, s.KSailConfig.Spec.Mounts)
+
 	opts := yamlgenerator.Options{
 		Output: filepath.Join(output, KindConfigFile),
 		Force:  force,
@@ -523,6 +655,98 @@ func (s *Scaffolder) generateK3dConfig(output string, force bool) error {
 	)
 }
 
+func (s *Scaffolder) generateTalosConfig(output string, force bool) error {
+	talosConfig := talosprovisioner.NewDefaultConfig()
+
+	// Skip the default CNI (Flannel) if another CNI is requested
+	if s.KSailConfig.Spec.CNI == v1alpha1.CNICilium || s.KSailConfig.Spec.CNI == v1alpha1.CNICalico {
+		talosConfig.DisableDefaultCNI = true
+	}
+
+	s.applyNodeCounts(talosConfig)
+
+	opts := yamlgenerator.Options{
+		Output: filepath.Join(output, TalosConfigFile),
+		Force:  force,
+	}
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[*talosprovisioner.Config]{
+			Gen:         s.TalosGenerator,
+			Model:       talosConfig,
+			Opts:        opts,
+			DisplayName: TalosConfigFile,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrTalosConfigGeneration, err)
+			},
+		},
+	)
+}
+
+// generateK0sConfig generates the k0s.yaml configuration file.
+func (s *Scaffolder) generateK0sConfig(output string, force bool) error {
+	k0sConfig := k0sprovisioner.NewConfig("")
+
+	// Skip the default CNI (kube-router) if another CNI is requested
+	if s.KSailConfig.Spec.CNI == v1alpha1.CNICilium || s.KSailConfig.Spec.CNI == v1alpha1.CNICalico {
+		k0sConfig.DisableDefaultCNI = true
+	}
+
+	// k0s ships metrics-server; leave it out when it is disabled
+	if s.KSailConfig.Spec.MetricsServer == v1alpha1.MetricsServerDisabled {
+		k0sConfig.ControllerArgs = append(k0sConfig.ControllerArgs, "--disable-components=metrics-server")
+	}
+
+	s.applyNodeCounts(k0sConfig)
+
+	opts := yamlgenerator.Options{
+		Output: filepath.Join(output, K0sConfigFile),
+		Force:  force,
+	}
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[*k0sprovisioner.Config]{
+			Gen:         s.K0sGenerator,
+			Model:       k0sConfig,
+			Opts:        opts,
+			DisplayName: K0sConfigFile,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrK0sConfigGeneration, err)
+			},
+		},
+	)
+}
+
+// generateKwokConfig generates the kwok.yaml configuration file.
+func (s *Scaffolder) generateKwokConfig(output string, force bool) error {
+	kwokConfig := kwokprovisioner.NewConfig("")
+
+	s.applyNodeCounts(kwokConfig)
+
+	opts := yamlgenerator.Options{
+		Output: filepath.Join(output, KwokConfigFile),
+		Force:  force,
+	}
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[*kwokprovisioner.Config]{
+			Gen:         s.KwokGenerator,
+			Model:       kwokConfig,
+			Opts:        opts,
+			DisplayName: KwokConfigFile,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrKwokConfigGeneration, err)
+			},
+		},
+	)
+}
+
 // generateKustomizationConfig generates the kustomization.yaml file.
 func (s *Scaffolder) generateKustomizationConfig(output string, force bool) error {
 	kustomization := ktypes.Kustomization{}
@@ -12,9 +12,14 @@ import (
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	k0sconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k0s"
+	kwokconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kwok"
 	"github.com/devantler-tech/ksail-go/pkg/io/generator"
 	yamlgenerator "github.com/devantler-tech/ksail-go/pkg/io/generator/yaml"
 	"github.com/devantler-tech/ksail-go/pkg/io/scaffolder"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	"github.com/gkampitakis/go-snaps/snaps"
 	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
@@ -56,6 +61,9 @@ func TestScaffoldAppliesDistributionDefaults(t *testing.T) {
 			expected:     scaffolder.KindConfigFile,
 		},
 		{name: "K3d", distribution: v1alpha1.DistributionK3d, expected: scaffolder.K3dConfigFile},
+		{name: "Talos", distribution: v1alpha1.DistributionTalos, expected: scaffolder.TalosConfigFile},
+		{name: "K0s", distribution: v1alpha1.DistributionK0s, expected: scaffolder.K0sConfigFile},
+		{name: "Kwok", distribution: v1alpha1.DistributionKwok, expected: scaffolder.KwokConfigFile},
 		{name: "Unknown", distribution: "unknown", expected: scaffolder.KindConfigFile},
 	}
 
@@ -136,6 +144,68 @@ func TestScaffoldContentValidation(t *testing.T) {
 	}
 }
 
+func TestScaffoldProjectGolden(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		setupFunc func(string) v1alpha1.Cluster
+	}{
+		{name: "kind", setupFunc: createKindCluster},
+		{name: "k3d", setupFunc: createK3dCluster},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			output := &bytes.Buffer{}
+			scaffolderInstance := scaffolder.NewScaffolder(testCase.setupFunc("golden"), output)
+
+			err := scaffolderInstance.Scaffold(tempDir, false)
+			require.NoError(t, err)
+
+			testutils.AssertGolden(
+				t,
+				t.Name(),
+				output.String()+renderProjectTree(t, tempDir),
+				testutils.NormalizePath(tempDir, "<PROJECT>"),
+			)
+		})
+	}
+}
+
+// renderProjectTree renders every file below root with its relative path and content.
+func renderProjectTree(t *testing.T, root string) string {
+	t.Helper()
+
+	var builder strings.Builder
+
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil || entry.IsDir() {
+			return walkErr
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("relative path for %s: %w", path, err)
+		}
+
+		content, err := os.ReadFile(path) //nolint:gosec // path comes from walking a test temp dir
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+
+		_, _ = fmt.Fprintf(&builder, "--- %s ---\n%s", filepath.ToSlash(relPath), content)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return builder.String()
+}
+
 func TestScaffoldErrorHandling(t *testing.T) {
 	t.Parallel()
 
@@ -522,6 +592,147 @@ func TestGenerateKindConfigHandlesCNI(t *testing.T) {
 	}
 }
 
+func TestScaffoldGeneratesTalosConfig(t *testing.T) {
+	t.Parallel()
+
+	cluster := createTestCluster("talos")
+	cluster.Spec.Distribution = v1alpha1.DistributionTalos
+	cluster.Spec.DistributionConfig = ""
+	cluster.Spec.CNI = v1alpha1.CNICilium
+
+	tempDir := t.TempDir()
+
+	require.NoError(t, scaffolder.NewScaffolder(cluster, io.Discard).Scaffold(tempDir, false))
+
+	talosConfig, err := talosprovisioner.LoadConfig(filepath.Join(tempDir, scaffolder.TalosConfigFile))
+	require.NoError(t, err)
+	assert.Equal(t, talosprovisioner.DefaultClusterName, talosConfig.Name)
+	assert.True(t, talosConfig.DisableDefaultCNI)
+
+	ksailConfig, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml")) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailConfig), "distribution: Talos")
+	assert.NotContains(t, string(ksailConfig), "distributionConfig", "talos.yaml is the default")
+	assert.NotContains(t, string(ksailConfig), "context", "admin@talos-default is the default")
+}
+
+func TestScaffoldGeneratesK0sConfig(t *testing.T) {
+	t.Parallel()
+
+	cluster := createTestCluster("k0s")
+	cluster.Spec.Distribution = v1alpha1.DistributionK0s
+	cluster.Spec.DistributionConfig = ""
+	cluster.Spec.CNI = v1alpha1.CNICalico
+	cluster.Spec.MetricsServer = v1alpha1.MetricsServerDisabled
+
+	tempDir := t.TempDir()
+
+	require.NoError(t, scaffolder.NewScaffolder(cluster, io.Discard).Scaffold(tempDir, false))
+
+	k0sConfig, err := k0sconfigmanager.NewConfigManager(filepath.Join(tempDir, scaffolder.K0sConfigFile)).
+		LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, k0sprovisioner.DefaultClusterName, k0sConfig.Name)
+	assert.Equal(t, k0sprovisioner.DefaultImage, k0sConfig.Image)
+	assert.True(t, k0sConfig.DisableDefaultCNI)
+	assert.Equal(t, []string{"--disable-components=metrics-server"}, k0sConfig.ControllerArgs)
+
+	ksailConfig, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml")) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailConfig), "distribution: K0s")
+	assert.NotContains(t, string(ksailConfig), "distributionConfig", "k0s.yaml is the default")
+}
+
+func TestScaffoldGeneratesKwokConfig(t *testing.T) {
+	t.Parallel()
+
+	cluster := createTestCluster("kwok")
+	cluster.Spec.Distribution = v1alpha1.DistributionKwok
+	cluster.Spec.DistributionConfig = ""
+	cluster.Spec.WorkerNodes = 500
+
+	tempDir := t.TempDir()
+
+	require.NoError(t, scaffolder.NewScaffolder(cluster, io.Discard).Scaffold(tempDir, false))
+
+	kwokConfig, err := kwokconfigmanager.NewConfigManager(filepath.Join(tempDir, scaffolder.KwokConfigFile)).
+		LoadConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, kwokprovisioner.DefaultClusterName, kwokConfig.Name)
+	assert.Equal(t, kwokprovisioner.DefaultImage, kwokConfig.Image)
+	assert.Equal(t, 500, kwokConfig.Nodes)
+
+	ksailConfig, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml")) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailConfig), "distribution: Kwok")
+	assert.NotContains(t, string(ksailConfig), "distributionConfig", "kwok.yaml is the default")
+}
+
+func TestScaffoldGeneratesHATopology(t *testing.T) {
+	t.Parallel()
+
+	cluster := createTestCluster("ha")
+	cluster.Spec.DistributionConfig = ""
+	cluster.Spec.ControlPlaneNodes = 3
+	cluster.Spec.WorkerNodes = 2
+
+	tempDir := t.TempDir()
+
+	require.NoError(t, scaffolder.NewScaffolder(cluster, io.Discard).Scaffold(tempDir, false))
+
+	kindConfig, err := os.ReadFile(filepath.Join(tempDir, scaffolder.KindConfigFile)) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(kindConfig), "role: control-plane"))
+	assert.Equal(t, 2, strings.Count(string(kindConfig), "role: worker"))
+
+	ksailConfig, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml")) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailConfig), "controlPlaneNodes: 3")
+	assert.Contains(t, string(ksailConfig), "workerNodes: 2")
+
+	cluster.Spec.Distribution = v1alpha1.DistributionK3d
+	k3dConfig := scaffolder.NewScaffolder(cluster, io.Discard).CreateK3dConfig()
+	assert.Equal(t, 3, k3dConfig.Servers)
+	assert.Equal(t, 2, k3dConfig.Agents)
+}
+
+func TestScaffoldGeneratesDualStackNetworking(t *testing.T) {
+	t.Parallel()
+
+	cluster := createTestCluster("dual-stack")
+	cluster.Spec.DistributionConfig = ""
+	cluster.Spec.Networking = v1alpha1.Networking{
+		IPFamily:  v1alpha1.IPFamilyDualStack,
+		PodSubnet: "10.244.0.0/16,fd00:10:244::/56",
+	}
+
+	tempDir := t.TempDir()
+
+	require.NoError(t, scaffolder.NewScaffolder(cluster, io.Discard).Scaffold(tempDir, false))
+
+	kindConfig, err := os.ReadFile(filepath.Join(tempDir, scaffolder.KindConfigFile)) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(kindConfig), "ipFamily: dual")
+	assert.Contains(t, string(kindConfig), "podSubnet: 10.244.0.0/16,fd00:10:244::/56")
+
+	ksailConfig, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml")) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailConfig), "ipFamily: DualStack")
+
+	cluster.Spec.Distribution = v1alpha1.DistributionK3d
+	k3dConfig := scaffolder.NewScaffolder(cluster, io.Discard).CreateK3dConfig()
+
+	args := make([]string, 0, len(k3dConfig.Options.K3sOptions.ExtraArgs))
+	for _, arg := range k3dConfig.Options.K3sOptions.ExtraArgs {
+		args = append(args, arg.Arg)
+	}
+
+	assert.Equal(t, []string{
+		"--cluster-cidr=10.244.0.0/16,fd00:10:244::/56",
+		"--service-cidr=10.43.0.0/16,2001:cafe:43::/112",
+	}, args)
+}
+
 func TestGenerateK3dConfigHandlesCNI(t *testing.T) {
 	t.Parallel()
 
@@ -1009,6 +1220,40 @@ func TestGenerateContainerdPatches_InvalidSpecs(t *testing.T) {
 	assert.Empty(t, patches)
 }
 
+func TestGenerateK3dRegistryConfig_WithConfiguredMirrors(t *testing.T) {
+	t.Parallel()
+
+	scaffolderInstance := newK3dScaffolder(t, []string{"ghcr.io=https://ghcr.example.com"})
+	scaffolderInstance.KSailConfig.Spec.Mirrors = []v1alpha1.Mirror{
+		{Host: "ghcr.io"},
+		{Host: "quay.io"},
+		{Host: "registry.k8s.io", Upstream: "https://registry.k8s.io"},
+	}
+
+	config := scaffolderInstance.GenerateK3dRegistryConfig()
+
+	for _, host := range []string{"ghcr.io", "quay.io", "registry.k8s.io"} {
+		assert.Contains(t, config.Config, "\""+host+"\":")
+	}
+
+	assert.Contains(t, config.Config, "https://ghcr.example.com", "the flag overrides the configured upstream")
+	assert.NotContains(t, config.Config, "https://ghcr.io")
+	assert.Contains(t, config.Config, "https://quay.io")
+}
+
+func TestGenerateContainerdPatches_WithConfiguredMirrors(t *testing.T) {
+	t.Parallel()
+
+	cluster := *v1alpha1.NewCluster()
+	cluster.Spec.Mirrors = []v1alpha1.Mirror{{Host: "docker.io"}, {Host: "gcr.io"}}
+
+	patches := scaffolder.NewScaffolder(cluster, &bytes.Buffer{}).GenerateContainerdPatches()
+
+	require.Len(t, patches, 2)
+	assert.Contains(t, patches[0], `mirrors."docker.io"`)
+	assert.Contains(t, patches[1], `mirrors."gcr.io"`)
+}
+
 // Tests for createK3dConfig with MetricsServer configuration.
 func TestCreateK3dConfig_MetricsServerDisabled(t *testing.T) {
 	t.Parallel()
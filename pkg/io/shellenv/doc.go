@@ -0,0 +1,5 @@
+// Package shellenv renders environment variables as shell statements.
+//
+// It supports bash, zsh, fish and direnv (.envrc) syntax so that the output of
+// `ksail env` can be evaluated directly by the user's shell.
+package shellenv
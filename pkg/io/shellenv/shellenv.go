@@ -0,0 +1,116 @@
+package shellenv
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Shell identifies the syntax used to render environment variables.
+type Shell string
+
+const (
+	// ShellBash renders POSIX export statements for bash.
+	ShellBash Shell = "bash"
+	// ShellZsh renders POSIX export statements for zsh.
+	ShellZsh Shell = "zsh"
+	// ShellFish renders set -gx statements for fish.
+	ShellFish Shell = "fish"
+	// ShellDirenv renders export statements suitable for an .envrc file.
+	ShellDirenv Shell = "direnv"
+)
+
+// ErrUnsupportedShell is returned when an unknown shell is requested.
+var ErrUnsupportedShell = errors.New("unsupported shell")
+
+// Variable is a single environment variable to export.
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// ValidShells returns the shells accepted by ParseShell.
+func ValidShells() []Shell {
+	return []Shell{ShellBash, ShellZsh, ShellFish, ShellDirenv}
+}
+
+// ParseShell converts a flag value into a Shell.
+func ParseShell(value string) (Shell, error) {
+	shell := Shell(strings.ToLower(strings.TrimSpace(value)))
+
+	switch shell {
+	case ShellBash, ShellZsh, ShellFish, ShellDirenv:
+		return shell, nil
+	default:
+		return "", fmt.Errorf("%w: %q (valid options: %s)", ErrUnsupportedShell, value, joinShells())
+	}
+}
+
+// DetectShell derives the shell from a $SHELL path, falling back to bash for unknown shells.
+func DetectShell(shellPath string) Shell {
+	shell, err := ParseShell(filepath.Base(shellPath))
+	if err != nil || shell == ShellDirenv {
+		return ShellBash
+	}
+
+	return shell
+}
+
+// Render returns the statements that export vars in the syntax of shell.
+//
+// Interactive shells get a trailing usage hint as a comment; direnv output has none so it can
+// be written to .envrc as is.
+func Render(shell Shell, vars []Variable, evalCommand string) string {
+	var builder strings.Builder
+
+	for _, variable := range vars {
+		switch shell {
+		case ShellFish:
+			fmt.Fprintf(&builder, "set -gx %s %s;\n", variable.Name, quoteFish(variable.Value))
+		case ShellBash, ShellZsh, ShellDirenv:
+			fmt.Fprintf(&builder, "export %s=%s\n", variable.Name, quotePOSIX(variable.Value))
+		default:
+			fmt.Fprintf(&builder, "export %s=%s\n", variable.Name, quotePOSIX(variable.Value))
+		}
+	}
+
+	if evalCommand == "" {
+		return builder.String()
+	}
+
+	switch shell {
+	case ShellFish:
+		builder.WriteString("# Run this command to configure your shell:\n")
+		fmt.Fprintf(&builder, "# %s | source\n", evalCommand)
+	case ShellBash, ShellZsh:
+		builder.WriteString("# Run this command to configure your shell:\n")
+		fmt.Fprintf(&builder, "# eval \"$(%s)\"\n", evalCommand)
+	case ShellDirenv:
+	}
+
+	return builder.String()
+}
+
+// quotePOSIX wraps value in single quotes, closing and reopening them around embedded quotes.
+func quotePOSIX(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// quoteFish wraps value in single quotes, escaping backslashes and quotes as fish requires.
+func quoteFish(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	return "'" + replacer.Replace(value) + "'"
+}
+
+func joinShells() string {
+	shells := ValidShells()
+	names := make([]string, 0, len(shells))
+
+	for _, shell := range shells {
+		names = append(names, string(shell))
+	}
+
+	return strings.Join(names, ", ")
+}
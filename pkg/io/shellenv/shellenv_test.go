@@ -0,0 +1,88 @@
+package shellenv_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/shellenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShell(t *testing.T) {
+	t.Parallel()
+
+	for _, shell := range shellenv.ValidShells() {
+		parsed, err := shellenv.ParseShell(" " + string(shell) + " ")
+		require.NoError(t, err)
+		assert.Equal(t, shell, parsed)
+	}
+
+	_, err := shellenv.ParseShell("powershell")
+	require.ErrorIs(t, err, shellenv.ErrUnsupportedShell)
+	assert.Contains(t, err.Error(), "bash, zsh, fish, direnv")
+}
+
+func TestDetectShell(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]shellenv.Shell{
+		"/bin/bash":              shellenv.ShellBash,
+		"/usr/bin/zsh":           shellenv.ShellZsh,
+		"/opt/homebrew/bin/fish": shellenv.ShellFish,
+		"/bin/tcsh":              shellenv.ShellBash,
+		"":                       shellenv.ShellBash,
+	}
+
+	for path, want := range tests {
+		assert.Equal(t, want, shellenv.DetectShell(path), path)
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	vars := []shellenv.Variable{
+		{Name: "KUBECONFIG", Value: "/home/dev/.kube/config"},
+		{Name: "KSAIL_CLUSTER_NAME", Value: `it's\here`},
+	}
+
+	tests := []struct {
+		shell shellenv.Shell
+		want  string
+	}{
+		{
+			shell: shellenv.ShellBash,
+			want: "export KUBECONFIG='/home/dev/.kube/config'\n" +
+				"export KSAIL_CLUSTER_NAME='it'\\''s\\here'\n" +
+				"# Run this command to configure your shell:\n" +
+				"# eval \"$(ksail env)\"\n",
+		},
+		{
+			shell: shellenv.ShellZsh,
+			want: "export KUBECONFIG='/home/dev/.kube/config'\n" +
+				"export KSAIL_CLUSTER_NAME='it'\\''s\\here'\n" +
+				"# Run this command to configure your shell:\n" +
+				"# eval \"$(ksail env)\"\n",
+		},
+		{
+			shell: shellenv.ShellFish,
+			want: "set -gx KUBECONFIG '/home/dev/.kube/config';\n" +
+				"set -gx KSAIL_CLUSTER_NAME 'it\\'s\\\\here';\n" +
+				"# Run this command to configure your shell:\n" +
+				"# ksail env | source\n",
+		},
+		{
+			shell: shellenv.ShellDirenv,
+			want: "export KUBECONFIG='/home/dev/.kube/config'\n" +
+				"export KSAIL_CLUSTER_NAME='it'\\''s\\here'\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(string(testCase.shell), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, shellenv.Render(testCase.shell, vars, "ksail env"))
+		})
+	}
+}
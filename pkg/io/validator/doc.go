@@ -15,4 +15,5 @@
 //   - kind: Kind configuration validator
 //   - ksail: KSail configuration validator
 //   - metadata: Configuration metadata validation
+//   - network: Pod and service subnet validation
 package validator
@@ -0,0 +1,6 @@
+// Package k0s provides k0s configuration validation functionality.
+//
+// This package implements the Validator interface for the k0s.yaml distribution
+// configuration, validating cluster configurations for semantic correctness and
+// constraint compliance.
+package k0s
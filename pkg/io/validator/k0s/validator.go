@@ -0,0 +1,73 @@
+package k0s
+
+import (
+	"math"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/validator"
+	"github.com/devantler-tech/ksail-go/pkg/io/validator/metadata"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Validator validates k0s cluster configurations.
+type Validator struct{}
+
+// NewValidator creates a new k0s configuration validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validate performs validation on a loaded k0s cluster configuration.
+func (v *Validator) Validate(config *k0sprovisioner.Config) *validator.ValidationResult {
+	result := validator.NewValidationResult("k0s.yaml")
+
+	if config == nil {
+		result.AddError(validator.ValidationError{
+			Field:         "config",
+			Message:       "configuration is nil",
+			FixSuggestion: "Provide a valid k0s cluster configuration",
+		})
+
+		return result
+	}
+
+	metadata.ValidateMetadata(
+		config.Kind,
+		config.APIVersion,
+		k0sprovisioner.Kind,
+		k0sprovisioner.APIVersion,
+		result,
+	)
+
+	// The name is used for container, network and DNS names.
+	if config.Name != "" && len(validation.IsDNS1123Label(config.Name)) > 0 {
+		result.AddError(validator.ValidationError{
+			Field:         "name",
+			Message:       "name must be a valid DNS label",
+			CurrentValue:  config.Name,
+			FixSuggestion: "Use lowercase letters, digits and '-', starting and ending with a letter or digit",
+		})
+	}
+
+	if config.Workers < 0 {
+		result.AddError(validator.ValidationError{
+			Field:         "workers",
+			Message:       "workers must not be negative",
+			CurrentValue:  config.Workers,
+			ExpectedValue: ">= 0",
+			FixSuggestion: "Set workers to 0 for a single node cluster",
+		})
+	}
+
+	if config.APIServerPort < 0 || config.APIServerPort > math.MaxUint16 {
+		result.AddError(validator.ValidationError{
+			Field:         "apiServerPort",
+			Message:       "apiServerPort must be a valid port",
+			CurrentValue:  config.APIServerPort,
+			ExpectedValue: "0-65535",
+			FixSuggestion: "Set apiServerPort to 0 to pick a free port",
+		})
+	}
+
+	return result
+}
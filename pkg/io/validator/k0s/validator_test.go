@@ -0,0 +1,69 @@
+package k0s_test
+
+import (
+	"testing"
+
+	k0svalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/k0s"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mutate      func(config *k0sprovisioner.Config)
+		errorFields []string
+	}{
+		{name: "default", mutate: func(*k0sprovisioner.Config) {}},
+		{
+			name:        "invalid_name",
+			mutate:      func(config *k0sprovisioner.Config) { config.Name = "My_Cluster" },
+			errorFields: []string{"name"},
+		},
+		{
+			name:        "negative_workers",
+			mutate:      func(config *k0sprovisioner.Config) { config.Workers = -1 },
+			errorFields: []string{"workers"},
+		},
+		{
+			name:        "port_out_of_range",
+			mutate:      func(config *k0sprovisioner.Config) { config.APIServerPort = 70000 },
+			errorFields: []string{"apiServerPort"},
+		},
+		{
+			name:        "missing_kind",
+			mutate:      func(config *k0sprovisioner.Config) { config.Kind = "" },
+			errorFields: []string{"kind"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := k0sprovisioner.NewConfig("")
+			testCase.mutate(config)
+
+			result := k0svalidator.NewValidator().Validate(config)
+
+			var fields []string
+			for _, validationError := range result.Errors {
+				fields = append(fields, validationError.Field)
+			}
+
+			assert.Equal(t, testCase.errorFields, fields)
+			assert.Equal(t, len(testCase.errorFields) == 0, result.Valid)
+		})
+	}
+}
+
+func TestValidateNilConfig(t *testing.T) {
+	t.Parallel()
+
+	result := k0svalidator.NewValidator().Validate(nil)
+
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 1)
+}
@@ -3,6 +3,7 @@ package kind
 import (
 	"github.com/devantler-tech/ksail-go/pkg/io/validator"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator/metadata"
+	"github.com/devantler-tech/ksail-go/pkg/io/validator/network"
 	kindapi "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
@@ -38,5 +39,34 @@ func (v *Validator) Validate(config *kindapi.Cluster) *validator.ValidationResul
 		result,
 	)
 
+	v.validateNetworking(config, result)
+
 	return result
 }
+
+// validateNetworking ensures the IP family is one Kind supports and the subnets match it.
+func (v *Validator) validateNetworking(config *kindapi.Cluster, result *validator.ValidationResult) {
+	var ipv4, ipv6 bool
+
+	switch config.Networking.IPFamily {
+	case "", kindapi.IPv4Family:
+		ipv4 = true
+	case kindapi.IPv6Family:
+		ipv6 = true
+	case kindapi.DualStackFamily:
+		ipv4, ipv6 = true, true
+	default:
+		result.AddError(validator.ValidationError{
+			Field:         "networking.ipFamily",
+			Message:       "invalid IP family",
+			CurrentValue:  config.Networking.IPFamily,
+			ExpectedValue: []kindapi.ClusterIPFamily{kindapi.IPv4Family, kindapi.IPv6Family, kindapi.DualStackFamily},
+			FixSuggestion: "Set networking.ipFamily to ipv4, ipv6 or dual",
+		})
+
+		return
+	}
+
+	network.ValidateSubnet("networking.podSubnet", config.Networking.PodSubnet, ipv4, ipv6, result)
+	network.ValidateSubnet("networking.serviceSubnet", config.Networking.ServiceSubnet, ipv4, ipv6, result)
+}
@@ -3,9 +3,11 @@ package kind_test
 import (
 	"testing"
 
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator"
 	kindvalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/kind"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator/testutils"
+	sharedtestutils "github.com/devantler-tech/ksail-go/pkg/testutils"
 	kindapi "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
@@ -73,6 +75,61 @@ func createKindTestCases() []testutils.ValidatorTestCase[*kindapi.Cluster] {
 			ExpectedValid:  true,
 			ExpectedErrors: []validator.ValidationError{},
 		},
+		{
+			Name: "valid_kind_config_dual_stack",
+			Config: &kindapi.Cluster{
+				TypeMeta: kindapi.TypeMeta{
+					APIVersion: "kind.x-k8s.io/v1alpha4",
+					Kind:       "Cluster",
+				},
+				Networking: kindapi.Networking{
+					IPFamily:      kindapi.DualStackFamily,
+					PodSubnet:     "10.244.0.0/16,fd00:10:244::/56",
+					ServiceSubnet: "10.96.0.0/16,fd00:10:96::/112",
+				},
+			},
+			ExpectedValid:  true,
+			ExpectedErrors: []validator.ValidationError{},
+		},
+		{
+			Name: "invalid_kind_config_subnet_family",
+			Config: &kindapi.Cluster{
+				TypeMeta: kindapi.TypeMeta{
+					APIVersion: "kind.x-k8s.io/v1alpha4",
+					Kind:       "Cluster",
+				},
+				Networking: kindapi.Networking{
+					IPFamily:  kindapi.IPv6Family,
+					PodSubnet: "10.244.0.0/16",
+				},
+			},
+			ExpectedValid: false,
+			ExpectedErrors: []validator.ValidationError{
+				{Field: "networking.podSubnet", Message: "subnets do not match the IP family"},
+			},
+		},
 		testutils.CreateNilConfigTestCase[*kindapi.Cluster](),
 	}
 }
+
+// FuzzValidate checks that validating arbitrary kind.yaml documents never panics.
+func FuzzValidate(f *testing.F) {
+	sharedtestutils.AddYAMLFuzzCorpus(f, sharedtestutils.KindConfigSeeds()...)
+
+	kindValidator := kindvalidator.NewValidator()
+	marshaller := yamlmarshaller.NewMarshaller[kindapi.Cluster]()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var config kindapi.Cluster
+
+		err := marshaller.Unmarshal(data, &config)
+		if err != nil {
+			t.Skip()
+		}
+
+		result := kindValidator.Validate(&config)
+		if result == nil {
+			t.Fatal("Validate must return a non-nil result")
+		}
+	})
+}
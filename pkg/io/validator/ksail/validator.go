@@ -1,18 +1,47 @@
 package ksail
 
 import (
+	"bytes"
 	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path"
+	"slices"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator/metadata"
+	"github.com/devantler-tech/ksail-go/pkg/io/validator/network"
 	k3dapi "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
 )
 
 const requiredCiliumArgs = 2
 
+// IPv4 pod and service subnets the distributions use when spec.networking sets none.
+//
+//nolint:gochecknoglobals // Distribution defaults checked against the Docker network subnet.
+var (
+	defaultPodSubnets = map[v1alpha1.Distribution]string{
+		v1alpha1.DistributionKind: "10.244.0.0/16",
+		v1alpha1.DistributionK3d:  "10.42.0.0/16",
+	}
+	defaultServiceSubnets = map[v1alpha1.Distribution]string{
+		v1alpha1.DistributionKind: "10.96.0.0/12",
+		v1alpha1.DistributionK3d:  "10.43.0.0/16",
+	}
+)
+
+// Vault mounts a KV mount cannot take: built-in paths and the transit engine KSail enables.
+//
+//nolint:gochecknoglobals // Fixed list of reserved Vault paths.
+var reservedVaultMounts = []string{"sys", "cubbyhole", "identity", "transit"}
+
 // Validator validates KSail cluster configurations for semantic correctness and cross-configuration consistency.
 type Validator struct {
 	kindConfig *kindv1alpha4.Cluster
@@ -76,6 +105,22 @@ func (v *Validator) Validate(config *v1alpha1.Cluster) *validator.ValidationResu
 	v.validateCNIAlignment(config, result)
 	v.validateRegistry(config, result)
 	v.validateFlux(config, result)
+	v.validateArgoCD(config, result)
+	v.validateNodeCounts(config, result)
+	v.validateNetworking(config, result)
+	v.validateNodes(config, result)
+	v.validateMounts(config, result)
+	v.validateMirrors(config, result)
+	v.validateHelmValues(config, result)
+	v.validateChartVersions(config, result)
+	v.validateChartMirror(config, result)
+	v.validateChartRepositories(config, result)
+	v.validateMetalLB(config, result)
+	v.validateKyverno(config, result)
+	v.validateGatewayAPI(config, result)
+	v.validateVault(config, result)
+	v.validatePlugins(config, result)
+	v.validateInstall(config, result)
 
 	return result
 }
@@ -140,8 +185,8 @@ func (v *Validator) validateDistribution(
 		})
 	}
 
-	// Validate distributionConfig field
-	if config.Spec.DistributionConfig == "" {
+	// Validate distributionConfig field; External clusters are not provisioned and have none
+	if config.Spec.DistributionConfig == "" && distribution != v1alpha1.DistributionExternal {
 		result.AddError(validator.ValidationError{
 			Field:         "spec.distributionConfig",
 			Message:       "distributionConfig is required",
@@ -151,8 +196,9 @@ func (v *Validator) validateDistribution(
 }
 
 // getExpectedContextName returns the expected context name for the given configuration.
-// Context name follows the pattern: {distribution}-{cluster_name}, where cluster_name is extracted
-// from the distribution config. Returns empty string if no distribution config is available.
+// Context name follows the pattern: {distribution}-{cluster_name}, where cluster_name is the
+// resolved spec.clusterName or, without one, extracted from the distribution config. Returns
+// empty string if no distribution config is available.
 func (v *Validator) getExpectedContextName(config *v1alpha1.Cluster) string {
 	distributionName := v.getDistributionConfigName(config.Spec.Distribution)
 	if distributionName == "" {
@@ -160,6 +206,10 @@ func (v *Validator) getExpectedContextName(config *v1alpha1.Cluster) string {
 		return ""
 	}
 
+	if config.Spec.ClusterName != "" {
+		distributionName = config.Spec.ClusterName
+	}
+
 	switch config.Spec.Distribution {
 	case v1alpha1.DistributionKind:
 		return "kind-" + distributionName
@@ -391,6 +441,31 @@ func (v *Validator) validateRegistry(
 
 	enabled := config.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled
 
+	if enabled && config.Spec.Distribution == v1alpha1.DistributionExternal {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.localRegistry",
+			Message:       "the local registry is not supported for External clusters",
+			CurrentValue:  config.Spec.LocalRegistry,
+			ExpectedValue: v1alpha1.LocalRegistryDisabled,
+			FixSuggestion: "Disable spec.localRegistry or push images to a registry the cluster can reach",
+		})
+
+		return
+	}
+
+	if enabled && config.Spec.Distribution.IsSimulated() {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.localRegistry",
+			Message:       "the local registry is not supported for simulated clusters",
+			CurrentValue:  config.Spec.LocalRegistry,
+			ExpectedValue: v1alpha1.LocalRegistryDisabled,
+			FixSuggestion: "Disable spec.localRegistry; the fake nodes of " + string(config.Spec.Distribution) +
+				" clusters never pull images",
+		})
+
+		return
+	}
+
 	if enabled {
 		if port <= 0 || port > 65535 {
 			result.AddError(validator.ValidationError{
@@ -406,6 +481,808 @@ func (v *Validator) validateRegistry(
 	}
 }
 
+// validateNodeCounts ensures the node counts are ones the distribution can create.
+func (v *Validator) validateNodeCounts(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	counts := []struct {
+		field string
+		value int32
+	}{
+		{"spec.controlPlaneNodes", config.Spec.ControlPlaneNodes},
+		{"spec.workerNodes", config.Spec.WorkerNodes},
+	}
+
+	for _, count := range counts {
+		switch {
+		case count.value < 0:
+			result.AddError(validator.ValidationError{
+				Field:         count.field,
+				Message:       "node count must not be negative",
+				CurrentValue:  count.value,
+				ExpectedValue: ">= 0",
+				FixSuggestion: "Set " + count.field + " to 0 to keep the count of the distribution config",
+			})
+		case count.value > 0 && config.Spec.Distribution == v1alpha1.DistributionExternal:
+			result.AddError(validator.ValidationError{
+				Field:         count.field,
+				Message:       "node counts cannot be set for External clusters",
+				CurrentValue:  count.value,
+				ExpectedValue: 0,
+				FixSuggestion: "Remove " + count.field + "; KSail does not manage the nodes of External clusters",
+			})
+		}
+	}
+
+	if config.Spec.Distribution == v1alpha1.DistributionK0s && config.Spec.ControlPlaneNodes > 1 {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.controlPlaneNodes",
+			Message:       "k0s clusters run a single controller",
+			CurrentValue:  config.Spec.ControlPlaneNodes,
+			ExpectedValue: 1,
+			FixSuggestion: "Use the Kind, K3d or Talos distribution for highly available control planes",
+		})
+	}
+
+	if config.Spec.Distribution == v1alpha1.DistributionKwok && config.Spec.ControlPlaneNodes > 1 {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.controlPlaneNodes",
+			Message:       "kwok clusters run a single control plane",
+			CurrentValue:  config.Spec.ControlPlaneNodes,
+			ExpectedValue: 1,
+			FixSuggestion: "Set spec.workerNodes to the number of fake nodes instead",
+		})
+	}
+}
+
+// validateNetworking ensures the IP family is supported by the distribution and the subnets
+// match it.
+func (v *Validator) validateNetworking(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	networking := config.Spec.Networking
+	if networking == (v1alpha1.Networking{}) {
+		return
+	}
+
+	if config.Spec.Distribution != v1alpha1.DistributionKind &&
+		config.Spec.Distribution != v1alpha1.DistributionK3d {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.networking",
+			Message:       "networking can only be set for Kind and K3d clusters",
+			CurrentValue:  config.Spec.Distribution,
+			ExpectedValue: []v1alpha1.Distribution{v1alpha1.DistributionKind, v1alpha1.DistributionK3d},
+			FixSuggestion: "Remove spec.networking and configure the network in the distribution config",
+		})
+
+		return
+	}
+
+	var ipv4, ipv6 bool
+
+	switch networking.IPFamily {
+	case "", v1alpha1.IPFamilyIPv4:
+		ipv4 = true
+	case v1alpha1.IPFamilyIPv6:
+		ipv6 = true
+	case v1alpha1.IPFamilyDualStack:
+		ipv4, ipv6 = true, true
+	default:
+		result.AddError(validator.ValidationError{
+			Field:         "spec.networking.ipFamily",
+			Message:       "invalid IP family",
+			CurrentValue:  networking.IPFamily,
+			ExpectedValue: v1alpha1.ValidIPFamilies(),
+			FixSuggestion: "Set spec.networking.ipFamily to IPv4, IPv6 or DualStack",
+		})
+
+		return
+	}
+
+	network.ValidateSubnet("spec.networking.podSubnet", networking.PodSubnet, ipv4, ipv6, result)
+	network.ValidateSubnet("spec.networking.serviceSubnet", networking.ServiceSubnet, ipv4, ipv6, result)
+
+	validateDockerNetwork(config.Spec.Distribution, networking, ipv4, ipv6, result)
+}
+
+// validateDockerNetwork ensures the Docker network subnet matches the IP family, contains the
+// gateway, and does not overlap the pod and service subnets, or the distribution's defaults when
+// they are not set.
+func validateDockerNetwork(
+	distribution v1alpha1.Distribution,
+	networking v1alpha1.Networking,
+	ipv4, ipv6 bool,
+	result *validator.ValidationResult,
+) {
+	const (
+		subnetField  = "spec.networking.dockerNetwork.subnet"
+		gatewayField = "spec.networking.dockerNetwork.gateway"
+	)
+
+	dockerNetwork := networking.DockerNetwork
+
+	network.ValidateSubnet(subnetField, dockerNetwork.Subnet, ipv4, ipv6, result)
+	network.ValidateGateway(gatewayField, dockerNetwork.Gateway, subnetField, dockerNetwork.Subnet, result)
+
+	podSubnet, serviceSubnet := networking.PodSubnet, networking.ServiceSubnet
+
+	if podSubnet == "" {
+		podSubnet = defaultPodSubnets[distribution]
+	}
+
+	if serviceSubnet == "" {
+		serviceSubnet = defaultServiceSubnets[distribution]
+	}
+
+	network.ValidateNoOverlap(subnetField, dockerNetwork.Subnet, "spec.networking.podSubnet", podSubnet, result)
+	network.ValidateNoOverlap(
+		subnetField,
+		dockerNetwork.Subnet,
+		"spec.networking.serviceSubnet",
+		serviceSubnet,
+		result,
+	)
+}
+
+// validateNodes ensures node customizations are only set for Kind and K3d clusters, use valid
+// roles, labels and taints, and fit the configured node counts.
+func (v *Validator) validateNodes(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if len(config.Spec.Nodes) == 0 {
+		return
+	}
+
+	if config.Spec.Distribution != v1alpha1.DistributionKind &&
+		config.Spec.Distribution != v1alpha1.DistributionK3d {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.nodes",
+			Message:       "nodes can only be set for Kind and K3d clusters",
+			CurrentValue:  config.Spec.Distribution,
+			ExpectedValue: []v1alpha1.Distribution{v1alpha1.DistributionKind, v1alpha1.DistributionK3d},
+			FixSuggestion: "Remove spec.nodes and configure the nodes in the distribution config",
+		})
+
+		return
+	}
+
+	roleCounts := map[v1alpha1.NodeRole]int32{}
+
+	for i, node := range config.Spec.Nodes {
+		field := fmt.Sprintf("spec.nodes[%d]", i)
+
+		role := node.Role
+		if role == "" {
+			role = v1alpha1.NodeRoleWorker
+		}
+
+		if !slices.Contains(v1alpha1.ValidNodeRoles(), role) {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".role",
+				Message:       "invalid node role",
+				CurrentValue:  node.Role,
+				ExpectedValue: v1alpha1.ValidNodeRoles(),
+				FixSuggestion: "Set " + field + ".role to ControlPlane or Worker",
+			})
+		}
+
+		roleCounts[role]++
+
+		validateNodeLabels(field, node.Labels, result)
+		validateNodeTaints(field, node.Taints, result)
+	}
+
+	counts := []struct {
+		field string
+		role  v1alpha1.NodeRole
+		value int32
+	}{
+		{"spec.controlPlaneNodes", v1alpha1.NodeRoleControlPlane, config.Spec.ControlPlaneNodes},
+		{"spec.workerNodes", v1alpha1.NodeRoleWorker, config.Spec.WorkerNodes},
+	}
+
+	for _, count := range counts {
+		if count.value > 0 && roleCounts[count.role] > count.value {
+			result.AddError(validator.ValidationError{
+				Field: "spec.nodes",
+				Message: fmt.Sprintf("%d %s nodes are customized, but %s is %d",
+					roleCounts[count.role], count.role, count.field, count.value),
+				CurrentValue:  roleCounts[count.role],
+				ExpectedValue: fmt.Sprintf("<= %d", count.value),
+				FixSuggestion: "Raise " + count.field + " or remove " + string(count.role) + " entries from spec.nodes",
+			})
+		}
+	}
+}
+
+func validateNodeLabels(field string, labels map[string]string, result *validator.ValidationResult) {
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		problems := append(k8svalidation.IsQualifiedName(key), k8svalidation.IsValidLabelValue(labels[key])...)
+		if len(problems) == 0 {
+			continue
+		}
+
+		result.AddError(validator.ValidationError{
+			Field:         field + ".labels",
+			Message:       fmt.Sprintf("invalid label %s=%s: %s", key, labels[key], strings.Join(problems, "; ")),
+			CurrentValue:  labels[key],
+			FixSuggestion: "Use a Kubernetes label key such as example.com/tier and a value of at most 63 characters",
+		})
+	}
+}
+
+func validateNodeTaints(field string, taints []v1alpha1.Taint, result *validator.ValidationResult) {
+	for i, taint := range taints {
+		taintField := fmt.Sprintf("%s.taints[%d]", field, i)
+
+		problems := k8svalidation.IsQualifiedName(taint.Key)
+		if taint.Value != "" {
+			problems = append(problems, k8svalidation.IsValidLabelValue(taint.Value)...)
+		}
+
+		if len(problems) > 0 {
+			result.AddError(validator.ValidationError{
+				Field:         taintField,
+				Message:       fmt.Sprintf("invalid taint %s: %s", taint, strings.Join(problems, "; ")),
+				CurrentValue:  taint.String(),
+				FixSuggestion: "Use a Kubernetes label key such as example.com/dedicated and a value of at most 63 characters",
+			})
+		}
+
+		if !slices.Contains(v1alpha1.ValidTaintEffects(), taint.Effect) {
+			result.AddError(validator.ValidationError{
+				Field:         taintField + ".effect",
+				Message:       "invalid taint effect",
+				CurrentValue:  taint.Effect,
+				ExpectedValue: v1alpha1.ValidTaintEffects(),
+				FixSuggestion: "Set " + taintField + ".effect to NoSchedule, PreferNoSchedule or NoExecute",
+			})
+		}
+	}
+}
+
+// validateMounts ensures host mounts are only set for Kind and K3d clusters, mount existing
+// host directories, and use distinct absolute container paths. Host paths of clusters on a
+// remote Docker host are not checked, as they live on that host.
+func (v *Validator) validateMounts(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if len(config.Spec.Mounts) == 0 {
+		return
+	}
+
+	if config.Spec.Distribution != v1alpha1.DistributionKind &&
+		config.Spec.Distribution != v1alpha1.DistributionK3d {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.mounts",
+			Message:       "mounts can only be set for Kind and K3d clusters",
+			CurrentValue:  config.Spec.Distribution,
+			ExpectedValue: []v1alpha1.Distribution{v1alpha1.DistributionKind, v1alpha1.DistributionK3d},
+			FixSuggestion: "Remove spec.mounts and configure the mounts in the distribution config",
+		})
+
+		return
+	}
+
+	localHost := strings.TrimSpace(config.Spec.DockerHost) == ""
+	containerPaths := map[string]bool{}
+
+	for i, mount := range config.Spec.Mounts {
+		field := fmt.Sprintf("spec.mounts[%d]", i)
+
+		validateMountHostPath(field+".hostPath", mount.HostPath, localHost, result)
+
+		if !path.IsAbs(mount.ContainerPath) {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".containerPath",
+				Message:       "container path must be absolute",
+				CurrentValue:  mount.ContainerPath,
+				FixSuggestion: "Set " + field + ".containerPath to an absolute path such as /data",
+			})
+
+			continue
+		}
+
+		if containerPaths[mount.ContainerPath] {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".containerPath",
+				Message:       "container path is mounted more than once",
+				CurrentValue:  mount.ContainerPath,
+				FixSuggestion: "Mount each container path once",
+			})
+		}
+
+		containerPaths[mount.ContainerPath] = true
+	}
+}
+
+func validateMountHostPath(field, hostPath string, checkExists bool, result *validator.ValidationResult) {
+	if strings.TrimSpace(hostPath) == "" {
+		result.AddError(validator.ValidationError{
+			Field:         field,
+			Message:       "host path is required",
+			FixSuggestion: "Set " + field + " to the host directory to mount",
+		})
+
+		return
+	}
+
+	if !checkExists {
+		return
+	}
+
+	_, err := os.Stat(hostPath)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         field,
+			Message:       fmt.Sprintf("host path cannot be mounted: %v", err),
+			CurrentValue:  hostPath,
+			FixSuggestion: "Create the directory, or fix the path relative to the working directory",
+		})
+	}
+}
+
+// validateMirrors ensures registry mirrors are only set for Kind and K3d clusters, and mirror
+// distinct registry hosts from http or https upstreams.
+func (v *Validator) validateMirrors(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if len(config.Spec.Mirrors) == 0 {
+		return
+	}
+
+	if config.Spec.Distribution != v1alpha1.DistributionKind &&
+		config.Spec.Distribution != v1alpha1.DistributionK3d {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.mirrors",
+			Message:       "mirrors can only be set for Kind and K3d clusters",
+			CurrentValue:  config.Spec.Distribution,
+			ExpectedValue: []v1alpha1.Distribution{v1alpha1.DistributionKind, v1alpha1.DistributionK3d},
+			FixSuggestion: "Remove spec.mirrors and configure the registry mirrors in the distribution config",
+		})
+
+		return
+	}
+
+	hosts := map[string]bool{}
+
+	for i, mirror := range config.Spec.Mirrors {
+		field := fmt.Sprintf("spec.mirrors[%d]", i)
+		host := strings.TrimSpace(mirror.Host)
+
+		if host == "" || strings.Contains(host, "/") {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".host",
+				Message:       "host must be a registry host without scheme or path",
+				CurrentValue:  mirror.Host,
+				FixSuggestion: "Set " + field + ".host to a registry host such as ghcr.io",
+			})
+
+			continue
+		}
+
+		if hosts[host] {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".host",
+				Message:       "registry host is mirrored more than once",
+				CurrentValue:  host,
+				FixSuggestion: "Mirror each registry host once",
+			})
+		}
+
+		hosts[host] = true
+
+		upstream := strings.TrimSpace(mirror.Upstream)
+		if upstream != "" && !strings.HasPrefix(upstream, "http://") && !strings.HasPrefix(upstream, "https://") {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".upstream",
+				Message:       "upstream must be an http or https URL",
+				CurrentValue:  mirror.Upstream,
+				FixSuggestion: "Set " + field + ".upstream to a URL such as https://" + host + ", or remove it",
+			})
+		}
+	}
+}
+
+// validateHelmValues ensures the user-supplied Helm values of the charts KSail installs can be
+// read: values files must exist and inline values must be a YAML mapping.
+func (v *Validator) validateHelmValues(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	charts := []struct {
+		field  string
+		values v1alpha1.HelmValues
+	}{
+		{"spec.options.cilium", config.Spec.Options.Cilium.HelmValues()},
+		{"spec.options.calico", config.Spec.Options.Calico.HelmValues()},
+		{"spec.options.metricsServer", config.Spec.Options.MetricsServer.HelmValues()},
+	}
+
+	for _, chart := range charts {
+		if chart.values.ValuesFile != "" {
+			_, err := os.Stat(chart.values.ValuesFile)
+			if err != nil {
+				result.AddError(validator.ValidationError{
+					Field:         chart.field + ".valuesFile",
+					Message:       fmt.Sprintf("values file cannot be read: %v", err),
+					CurrentValue:  chart.values.ValuesFile,
+					FixSuggestion: "Create the file, or fix the path relative to the working directory",
+				})
+			}
+		}
+
+		var values map[string]any
+
+		err := yaml.Unmarshal([]byte(chart.values.Values), &values)
+		if err != nil {
+			result.AddError(validator.ValidationError{
+				Field:         chart.field + ".values",
+				Message:       fmt.Sprintf("values must be a YAML mapping: %v", err),
+				FixSuggestion: "Set " + chart.field + ".values to chart values such as 'replicas: 2'",
+			})
+		}
+	}
+}
+
+// validateChartVersions ensures the pinned chart versions of the components KSail installs are
+// semantic versions or version constraints Helm can resolve.
+func (v *Validator) validateChartVersions(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options
+	versions := []struct {
+		field   string
+		version string
+	}{
+		{"spec.options.cilium.version", options.Cilium.Version},
+		{"spec.options.calico.version", options.Calico.Version},
+		{"spec.options.metricsServer.version", options.MetricsServer.Version},
+		{"spec.options.metalLB.version", options.MetalLB.Version},
+		{"spec.options.flux.version", options.Flux.Version},
+		{"spec.options.argocd.version", options.ArgoCD.Version},
+		{"spec.options.argoRollouts.version", options.ArgoRollouts.Version},
+		{"spec.options.gitea.version", options.Gitea.Version},
+		{"spec.options.headlamp.version", options.Headlamp.Version},
+		{"spec.options.localStack.version", options.LocalStack.Version},
+		{"spec.options.vault.version", options.Vault.Version},
+		{"spec.options.cloudNativePG.version", options.CloudNativePG.Version},
+		{"spec.options.kyverno.version", options.Kyverno.Version},
+	}
+
+	for _, pinned := range versions {
+		if pinned.version == "" {
+			continue
+		}
+
+		_, err := semver.NewConstraint(pinned.version)
+		if err != nil {
+			result.AddError(validator.ValidationError{
+				Field:         pinned.field,
+				Message:       fmt.Sprintf("invalid chart version: %v", err),
+				CurrentValue:  pinned.version,
+				FixSuggestion: "Use a chart version such as '1.18.2', or a constraint such as '~1.18'",
+			})
+		}
+	}
+}
+
+// validateChartMirror ensures the chart mirror is an oci:// registry path or an existing
+// directory of chart archives.
+func (v *Validator) validateChartMirror(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	mirror := config.Spec.Options.Helm.ChartMirror
+	if mirror == "" || strings.HasPrefix(mirror, "oci://") {
+		return
+	}
+
+	message := "chart mirror is not a directory"
+
+	info, err := os.Stat(mirror)
+	if err != nil {
+		message = fmt.Sprintf("chart mirror cannot be read: %v", err)
+	} else if info.IsDir() {
+		return
+	}
+
+	result.AddError(validator.ValidationError{
+		Field:         "spec.options.helm.chartMirror",
+		Message:       message,
+		CurrentValue:  mirror,
+		FixSuggestion: "Use an oci:// registry path, or a directory of chart archives written by 'helm pull'",
+	})
+}
+
+// validateChartRepositories ensures the chart repositories that override those of the CNI
+// charts are oci:// registry paths or HTTP(S) Helm repositories, and that a password comes
+// with a username.
+func (v *Validator) validateChartRepositories(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	repositories := []struct {
+		field      string
+		repository v1alpha1.ChartRepository
+	}{
+		{"spec.options.cilium.repository", config.Spec.Options.Cilium.Repository},
+		{"spec.options.calico.repository", config.Spec.Options.Calico.Repository},
+	}
+
+	for _, entry := range repositories {
+		repository := entry.repository
+		if repository == (v1alpha1.ChartRepository{}) {
+			continue
+		}
+
+		if !strings.HasPrefix(repository.URL, "oci://") && !strings.HasPrefix(repository.URL, "https://") &&
+			!strings.HasPrefix(repository.URL, "http://") {
+			result.AddError(validator.ValidationError{
+				Field:         entry.field + ".url",
+				Message:       "chart repository must be an oci:// registry path or an HTTP(S) Helm repository",
+				CurrentValue:  repository.URL,
+				FixSuggestion: "Use a URL such as 'oci://registry.example.com/charts'",
+			})
+		}
+
+		if repository.Password != "" && repository.Username == "" {
+			result.AddError(validator.ValidationError{
+				Field:         entry.field + ".username",
+				Message:       "chart repository password is set without a username",
+				FixSuggestion: "Set " + entry.field + ".username, or remove the password to use 'helm registry login'",
+			})
+		}
+	}
+}
+
+// validateMetalLB ensures MetalLB is only enabled on Kind, the distribution without a built-in
+// LoadBalancer implementation, and that its addresses are a CIDR or an IPv4 range.
+func (v *Validator) validateMetalLB(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options.MetalLB
+	if !options.Enabled {
+		return
+	}
+
+	if config.Spec.Distribution != v1alpha1.DistributionKind {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.metalLB.enabled",
+			Message:       "MetalLB is only supported for Kind clusters",
+			CurrentValue:  config.Spec.Distribution,
+			ExpectedValue: v1alpha1.DistributionKind,
+			FixSuggestion: "Disable spec.options.metalLB; " + string(config.Spec.Distribution) +
+				" clusters assign LoadBalancer addresses themselves or have no host-reachable network",
+		})
+
+		return
+	}
+
+	if options.Addresses != "" && !validAddressPool(options.Addresses) {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.metalLB.addresses",
+			Message:       "addresses must be a CIDR or a range of two IPv4 addresses",
+			CurrentValue:  options.Addresses,
+			FixSuggestion: "Use a range of the cluster's Docker network, e.g. 172.18.255.200-172.18.255.250",
+		})
+	}
+}
+
+// validAddressPool reports whether addresses is a CIDR or a "<first>-<last>" IPv4 range.
+func validAddressPool(addresses string) bool {
+	_, _, err := net.ParseCIDR(addresses)
+	if err == nil {
+		return true
+	}
+
+	first, last, found := strings.Cut(addresses, "-")
+	firstIP := net.ParseIP(strings.TrimSpace(first)).To4()
+	lastIP := net.ParseIP(strings.TrimSpace(last)).To4()
+
+	return found && firstIP != nil && lastIP != nil && bytes.Compare(firstIP, lastIP) <= 0
+}
+
+// validateKyverno ensures the Pod Security Standards level of the Kyverno policies is known,
+// and only set when Kyverno is enabled.
+func (v *Validator) validateKyverno(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options.Kyverno
+	if options.PodSecurityStandard == "" {
+		return
+	}
+
+	if !slices.Contains(v1alpha1.ValidPodSecurityStandards(), options.PodSecurityStandard) {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.kyverno.podSecurityStandard",
+			Message:       "unknown Pod Security Standards level",
+			CurrentValue:  options.PodSecurityStandard,
+			ExpectedValue: v1alpha1.ValidPodSecurityStandards(),
+			FixSuggestion: "Use 'baseline' or 'restricted'",
+		})
+	}
+
+	if !options.Enabled {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.kyverno.podSecurityStandard",
+			Message:       "Kyverno policies require Kyverno",
+			CurrentValue:  options.PodSecurityStandard,
+			FixSuggestion: "Set spec.options.kyverno.enabled to true, or remove the podSecurityStandard",
+		})
+	}
+}
+
+// validateGatewayAPI ensures the Gateway API channel is known and the version is a release
+// such as v1.3.0.
+func (v *Validator) validateGatewayAPI(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options.GatewayAPI
+
+	if options.Channel != "" && !slices.Contains(v1alpha1.ValidGatewayAPIChannels(), options.Channel) {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.gatewayAPI.channel",
+			Message:       "unknown Gateway API release channel",
+			CurrentValue:  options.Channel,
+			ExpectedValue: v1alpha1.ValidGatewayAPIChannels(),
+			FixSuggestion: "Use 'Standard' or 'Experimental'",
+		})
+	}
+
+	if options.Version == "" {
+		return
+	}
+
+	_, err := semver.StrictNewVersion(strings.TrimPrefix(options.Version, "v"))
+	if err != nil || !strings.HasPrefix(options.Version, "v") {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.gatewayAPI.version",
+			Message:       "version must be a Gateway API release",
+			CurrentValue:  options.Version,
+			FixSuggestion: "Use a release tag such as 'v1.3.0'",
+		})
+	}
+}
+
+// validateVault ensures the KV mount and transit key of the Vault bootstrap are usable names.
+func (v *Validator) validateVault(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options.Vault
+
+	mount := strings.Trim(options.KVMount, "/")
+	if options.KVMount != "" && (mount == "" || slices.Contains(reservedVaultMounts, mount) ||
+		strings.ContainsAny(mount, " \t")) {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.vault.kvMount",
+			Message:       "KV mount must be a path that is not reserved by Vault",
+			CurrentValue:  options.KVMount,
+			FixSuggestion: "Use a path such as 'apps'; 'sys', 'cubbyhole', 'identity' and 'transit' are reserved",
+		})
+	}
+
+	if strings.ContainsAny(options.TransitKey, "/ \t") {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.options.vault.transitKey",
+			Message:       "transit key must be a name without slashes or spaces",
+			CurrentValue:  options.TransitKey,
+			FixSuggestion: "Use a name such as 'sops'",
+		})
+	}
+}
+
+// validatePlugins ensures plugins have distinct DNS label names and a command, and do not
+// depend on themselves. Whether DependsOn names exist is checked when create plans the installs.
+func (v *Validator) validatePlugins(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	names := map[string]bool{}
+
+	for i, plugin := range config.Spec.Plugins {
+		field := fmt.Sprintf("spec.plugins[%d]", i)
+
+		if len(k8svalidation.IsDNS1123Label(plugin.Name)) > 0 {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".name",
+				Message:       "plugin name must be a lowercase DNS label",
+				CurrentValue:  plugin.Name,
+				FixSuggestion: "Use a name such as 'platform-charts'",
+			})
+		} else if names[plugin.Name] {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".name",
+				Message:       "plugin name is used more than once",
+				CurrentValue:  plugin.Name,
+				FixSuggestion: "Give each plugin a distinct name",
+			})
+		}
+
+		names[plugin.Name] = true
+
+		if strings.TrimSpace(plugin.Command) == "" {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".command",
+				Message:       "plugin command is required",
+				FixSuggestion: "Set " + field + ".command to an executable on PATH or a path such as ./plugins/install",
+			})
+		}
+
+		if slices.Contains(plugin.DependsOn, plugin.Name) {
+			result.AddError(validator.ValidationError{
+				Field:         field + ".dependsOn",
+				Message:       "plugin cannot depend on itself",
+				CurrentValue:  plugin.DependsOn,
+				FixSuggestion: "Remove " + plugin.Name + " from " + field + ".dependsOn",
+			})
+		}
+	}
+}
+
+// validateInstall ensures the install retry policy and its per-component overrides are not
+// negative.
+func (v *Validator) validateInstall(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	options := config.Spec.Options.Install
+
+	v.validateInstallPolicy("spec.options.install", v1alpha1.InstallPolicy{
+		Attempts: options.Attempts,
+		Interval: options.Interval,
+		Timeout:  options.Timeout,
+	}, result)
+
+	components := slices.Sorted(maps.Keys(options.Components))
+	for _, component := range components {
+		v.validateInstallPolicy("spec.options.install.components."+component, options.Components[component], result)
+	}
+}
+
+func (v *Validator) validateInstallPolicy(
+	field string,
+	policy v1alpha1.InstallPolicy,
+	result *validator.ValidationResult,
+) {
+	if policy.Attempts < 0 {
+		result.AddError(validator.ValidationError{
+			Field:         field + ".attempts",
+			Message:       "attempts must not be negative",
+			CurrentValue:  policy.Attempts,
+			FixSuggestion: "Use 1 to disable retries, or omit the field for the default of 3",
+		})
+	}
+
+	if policy.Interval.Duration < 0 {
+		result.AddError(validator.ValidationError{
+			Field:         field + ".interval",
+			Message:       "interval must not be negative",
+			CurrentValue:  policy.Interval.Duration.String(),
+			FixSuggestion: "Use a duration such as '5s'",
+		})
+	}
+
+	if policy.Timeout.Duration < 0 {
+		result.AddError(validator.ValidationError{
+			Field:         field + ".timeout",
+			Message:       "timeout must not be negative",
+			CurrentValue:  policy.Timeout.Duration.String(),
+			FixSuggestion: "Use a duration such as '10m'",
+		})
+	}
+}
+
 // validateFlux ensures Flux-specific settings are valid when Flux is enabled.
 func (v *Validator) validateFlux(
 	config *v1alpha1.Cluster,
@@ -425,3 +1302,21 @@ func (v *Validator) validateFlux(
 		})
 	}
 }
+
+// validateArgoCD ensures ArgoCD and Flux are not both set up to sync the source directory.
+func (v *Validator) validateArgoCD(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if !config.Spec.Options.ArgoCD.Enabled || config.Spec.GitOpsEngine != v1alpha1.GitOpsEngineFlux {
+		return
+	}
+
+	result.AddError(validator.ValidationError{
+		Field:         "spec.options.argocd.enabled",
+		Message:       "ArgoCD cannot be enabled together with Flux, as both would sync the source directory",
+		CurrentValue:  config.Spec.GitOpsEngine,
+		ExpectedValue: v1alpha1.GitOpsEngineNone,
+		FixSuggestion: "Set spec.gitOpsEngine to None, or disable spec.options.argocd",
+	})
+}
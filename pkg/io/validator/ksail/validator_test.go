@@ -1,12 +1,17 @@
 package ksail_test
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
 	"github.com/devantler-tech/ksail-go/pkg/io/validator"
 	ksailvalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	k3dtypes "github.com/k3d-io/k3d/v5/pkg/config/types"
 	k3dapi "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/stretchr/testify/assert"
@@ -340,6 +345,8 @@ func fluxRegistryValidationCases() []fluxRegistryValidationCase {
 		{name: "registry_port_required_when_enabled", run: validateRegistryPortRequiredCase},
 		{name: "registry_port_range", run: validateRegistryPortRangeCase},
 		{name: "registry_port_warning_when_disabled", run: validateRegistryPortWarningCase},
+		{name: "registry_unsupported_for_external", run: validateRegistryExternalCase},
+		{name: "registry_unsupported_for_kwok", run: validateRegistryKwokCase},
 		{name: "flux_interval_must_be_positive", run: validateFluxIntervalCase},
 	}
 }
@@ -395,6 +402,32 @@ func validateRegistryPortWarningCase(t *testing.T) {
 	assert.Empty(t, result.Warnings)
 }
 
+func validateRegistryExternalCase(t *testing.T) {
+	t.Helper()
+
+	validator := ksailvalidator.NewValidator()
+	config := createValidKSailConfig(v1alpha1.DistributionExternal)
+	config.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+	config.Spec.Options.LocalRegistry.HostPort = 5111
+
+	result := validator.Validate(config)
+	assert.False(t, result.Valid)
+	validateExpectedErrors(t, []string{"spec.localRegistry"}, result.Errors)
+}
+
+func validateRegistryKwokCase(t *testing.T) {
+	t.Helper()
+
+	validator := ksailvalidator.NewValidator()
+	config := createValidKSailConfig(v1alpha1.DistributionKwok)
+	config.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+	config.Spec.Options.LocalRegistry.HostPort = 5111
+
+	result := validator.Validate(config)
+	assert.False(t, result.Valid)
+	validateExpectedErrors(t, []string{"spec.localRegistry"}, result.Errors)
+}
+
 func validateFluxIntervalCase(t *testing.T) {
 	t.Helper()
 
@@ -408,6 +441,628 @@ func validateFluxIntervalCase(t *testing.T) {
 	validateExpectedErrors(t, []string{"spec.options.flux.interval"}, result.Errors)
 }
 
+func TestKSailValidatorNodeCounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		distribution   v1alpha1.Distribution
+		controlPlanes  int32
+		workers        int32
+		expectedFields []string
+	}{
+		{name: "kind_ha", distribution: v1alpha1.DistributionKind, controlPlanes: 3, workers: 2},
+		{name: "k3d_ha", distribution: v1alpha1.DistributionK3d, controlPlanes: 3, workers: 2},
+		{
+			name:           "negative_counts",
+			distribution:   v1alpha1.DistributionKind,
+			controlPlanes:  -1,
+			workers:        -2,
+			expectedFields: []string{"spec.controlPlaneNodes", "spec.workerNodes"},
+		},
+		{
+			name:           "k0s_single_controller",
+			distribution:   v1alpha1.DistributionK0s,
+			controlPlanes:  3,
+			expectedFields: []string{"spec.controlPlaneNodes"},
+		},
+		{name: "kwok_fake_nodes", distribution: v1alpha1.DistributionKwok, workers: 500},
+		{
+			name:           "kwok_single_control_plane",
+			distribution:   v1alpha1.DistributionKwok,
+			controlPlanes:  3,
+			expectedFields: []string{"spec.controlPlaneNodes"},
+		},
+		{
+			name:           "external_unmanaged_nodes",
+			distribution:   v1alpha1.DistributionExternal,
+			workers:        1,
+			expectedFields: []string{"spec.workerNodes"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := createValidKSailConfig(testCase.distribution)
+			config.Spec.ControlPlaneNodes = testCase.controlPlanes
+			config.Spec.WorkerNodes = testCase.workers
+
+			result := ksailvalidator.NewValidator().Validate(config)
+
+			if len(testCase.expectedFields) == 0 {
+				assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+				return
+			}
+
+			assert.False(t, result.Valid)
+			validateExpectedErrors(t, testCase.expectedFields, result.Errors)
+		})
+	}
+}
+
+func TestKSailValidatorNetworking(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		distribution   v1alpha1.Distribution
+		networking     v1alpha1.Networking
+		expectedFields []string
+	}{
+		{
+			name:         "kind_dual_stack",
+			distribution: v1alpha1.DistributionKind,
+			networking: v1alpha1.Networking{
+				IPFamily:      v1alpha1.IPFamilyDualStack,
+				PodSubnet:     "10.244.0.0/16,fd00:10:244::/56",
+				ServiceSubnet: "10.96.0.0/16, fd00:10:96::/112",
+			},
+		},
+		{
+			name:         "k3d_ipv4_subnets",
+			distribution: v1alpha1.DistributionK3d,
+			networking:   v1alpha1.Networking{PodSubnet: "10.42.0.0/16", ServiceSubnet: "10.43.0.0/16"},
+		},
+		{
+			name:           "invalid_ip_family",
+			distribution:   v1alpha1.DistributionKind,
+			networking:     v1alpha1.Networking{IPFamily: "IPv5"},
+			expectedFields: []string{"spec.networking.ipFamily"},
+		},
+		{
+			name:         "invalid_cidr",
+			distribution: v1alpha1.DistributionKind,
+			networking: v1alpha1.Networking{
+				IPFamily:  v1alpha1.IPFamilyIPv6,
+				PodSubnet: "fd00:10:244::",
+			},
+			expectedFields: []string{"spec.networking.podSubnet"},
+		},
+		{
+			name:         "subnets_mismatch_ip_family",
+			distribution: v1alpha1.DistributionKind,
+			networking: v1alpha1.Networking{
+				IPFamily:      v1alpha1.IPFamilyDualStack,
+				PodSubnet:     "10.244.0.0/16",
+				ServiceSubnet: "fd00:10:96::/112",
+			},
+			expectedFields: []string{"spec.networking.podSubnet", "spec.networking.serviceSubnet"},
+		},
+		{
+			name:         "kind_docker_network",
+			distribution: v1alpha1.DistributionKind,
+			networking: v1alpha1.Networking{
+				DockerNetwork: v1alpha1.DockerNetwork{Name: "team-a", Subnet: "172.30.0.0/16", Gateway: "172.30.0.1"},
+			},
+		},
+		{
+			name:         "docker_gateway_outside_subnet",
+			distribution: v1alpha1.DistributionK3d,
+			networking: v1alpha1.Networking{
+				DockerNetwork: v1alpha1.DockerNetwork{Subnet: "172.30.0.0/16", Gateway: "172.31.0.1"},
+			},
+			expectedFields: []string{"spec.networking.dockerNetwork.gateway"},
+		},
+		{
+			name:         "docker_subnet_overlaps_default_pod_subnet",
+			distribution: v1alpha1.DistributionK3d,
+			networking: v1alpha1.Networking{
+				DockerNetwork: v1alpha1.DockerNetwork{Subnet: "10.42.128.0/24"},
+			},
+			expectedFields: []string{"spec.networking.dockerNetwork.subnet"},
+		},
+		{
+			name:         "docker_subnet_overlaps_service_subnet",
+			distribution: v1alpha1.DistributionKind,
+			networking: v1alpha1.Networking{
+				ServiceSubnet: "172.30.0.0/24",
+				DockerNetwork: v1alpha1.DockerNetwork{Subnet: "172.30.0.0/16"},
+			},
+			expectedFields: []string{"spec.networking.dockerNetwork.subnet"},
+		},
+		{
+			name:           "unsupported_distribution",
+			distribution:   v1alpha1.DistributionTalos,
+			networking:     v1alpha1.Networking{IPFamily: v1alpha1.IPFamilyIPv6},
+			expectedFields: []string{"spec.networking"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := createValidKSailConfig(testCase.distribution)
+			config.Spec.Networking = testCase.networking
+
+			result := ksailvalidator.NewValidator().Validate(config)
+
+			if len(testCase.expectedFields) == 0 {
+				assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+				return
+			}
+
+			assert.False(t, result.Valid)
+			validateExpectedErrors(t, testCase.expectedFields, result.Errors)
+		})
+	}
+}
+
+func TestKSailValidatorNodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		distribution   v1alpha1.Distribution
+		workerNodes    int32
+		nodes          []v1alpha1.Node
+		expectedFields []string
+	}{
+		{
+			name:         "kind_labels_and_taints",
+			distribution: v1alpha1.DistributionKind,
+			nodes: []v1alpha1.Node{
+				{Role: v1alpha1.NodeRoleControlPlane, Labels: map[string]string{"ingress-ready": "true"}},
+				{
+					Labels: map[string]string{"example.com/tier": "gpu"},
+					Taints: []v1alpha1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1alpha1.TaintEffectNoSchedule}},
+				},
+			},
+		},
+		{
+			name:         "invalid_role_label_and_taint",
+			distribution: v1alpha1.DistributionK3d,
+			nodes: []v1alpha1.Node{{
+				Role:   "Master",
+				Labels: map[string]string{"bad key": "x"},
+				Taints: []v1alpha1.Taint{{Key: "dedicated", Effect: "Never"}},
+			}},
+			expectedFields: []string{"spec.nodes[0].role", "spec.nodes[0].labels", "spec.nodes[0].taints[0].effect"},
+		},
+		{
+			name:           "more_workers_than_worker_nodes",
+			distribution:   v1alpha1.DistributionKind,
+			workerNodes:    1,
+			nodes:          []v1alpha1.Node{{}, {}},
+			expectedFields: []string{"spec.nodes"},
+		},
+		{
+			name:           "unsupported_distribution",
+			distribution:   v1alpha1.DistributionTalos,
+			nodes:          []v1alpha1.Node{{Labels: map[string]string{"tier": "a"}}},
+			expectedFields: []string{"spec.nodes"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := createValidKSailConfig(testCase.distribution)
+			config.Spec.WorkerNodes = testCase.workerNodes
+			config.Spec.Nodes = testCase.nodes
+
+			result := ksailvalidator.NewValidator().Validate(config)
+
+			if len(testCase.expectedFields) == 0 {
+				assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+				return
+			}
+
+			assert.False(t, result.Valid)
+			validateExpectedErrors(t, testCase.expectedFields, result.Errors)
+		})
+	}
+}
+
+func TestKSailValidatorMounts(t *testing.T) {
+	t.Parallel()
+
+	hostPath := t.TempDir()
+
+	tests := []struct {
+		name           string
+		distribution   v1alpha1.Distribution
+		dockerHost     string
+		mounts         []v1alpha1.Mount
+		expectedFields []string
+	}{
+		{
+			name:         "k3d_existing_directory",
+			distribution: v1alpha1.DistributionK3d,
+			mounts:       []v1alpha1.Mount{{HostPath: hostPath, ContainerPath: "/data", ReadOnly: true}},
+		},
+		{
+			name:         "missing_directory_relative_and_duplicate_paths",
+			distribution: v1alpha1.DistributionKind,
+			mounts: []v1alpha1.Mount{
+				{HostPath: filepath.Join(hostPath, "missing"), ContainerPath: "/data"},
+				{HostPath: hostPath, ContainerPath: "data"},
+				{HostPath: hostPath, ContainerPath: "/data"},
+			},
+			expectedFields: []string{
+				"spec.mounts[0].hostPath",
+				"spec.mounts[1].containerPath",
+				"spec.mounts[2].containerPath",
+			},
+		},
+		{
+			name:         "remote_docker_host_skips_existence",
+			distribution: v1alpha1.DistributionKind,
+			dockerHost:   "ssh://builder@example.com",
+			mounts:       []v1alpha1.Mount{{HostPath: "/srv/data", ContainerPath: "/data"}},
+		},
+		{
+			name:           "unsupported_distribution",
+			distribution:   v1alpha1.DistributionK0s,
+			mounts:         []v1alpha1.Mount{{HostPath: hostPath, ContainerPath: "/data"}},
+			expectedFields: []string{"spec.mounts"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := createValidKSailConfig(testCase.distribution)
+			config.Spec.DockerHost = testCase.dockerHost
+			config.Spec.Mounts = testCase.mounts
+
+			result := ksailvalidator.NewValidator().Validate(config)
+
+			if len(testCase.expectedFields) == 0 {
+				assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+				return
+			}
+
+			assert.False(t, result.Valid)
+			validateExpectedErrors(t, testCase.expectedFields, result.Errors)
+		})
+	}
+}
+
+func TestKSailValidatorMirrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		distribution   v1alpha1.Distribution
+		mirrors        []v1alpha1.Mirror
+		expectedFields []string
+	}{
+		{
+			name:         "kind_multiple_upstreams",
+			distribution: v1alpha1.DistributionKind,
+			mirrors: []v1alpha1.Mirror{
+				{Host: "docker.io"},
+				{Host: "ghcr.io", Upstream: "https://ghcr.io"},
+				{Host: "registry.k8s.io"},
+			},
+		},
+		{
+			name:         "invalid_host_duplicate_host_and_upstream",
+			distribution: v1alpha1.DistributionK3d,
+			mirrors: []v1alpha1.Mirror{
+				{Host: "https://quay.io"},
+				{Host: "quay.io", Upstream: "quay.io"},
+				{Host: "quay.io"},
+			},
+			expectedFields: []string{
+				"spec.mirrors[0].host",
+				"spec.mirrors[1].upstream",
+				"spec.mirrors[2].host",
+			},
+		},
+		{
+			name:           "unsupported_distribution",
+			distribution:   v1alpha1.DistributionTalos,
+			mirrors:        []v1alpha1.Mirror{{Host: "gcr.io"}},
+			expectedFields: []string{"spec.mirrors"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := createValidKSailConfig(testCase.distribution)
+			config.Spec.Mirrors = testCase.mirrors
+
+			result := ksailvalidator.NewValidator().Validate(config)
+
+			if len(testCase.expectedFields) == 0 {
+				assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+				return
+			}
+
+			assert.False(t, result.Valid)
+			validateExpectedErrors(t, testCase.expectedFields, result.Errors)
+		})
+	}
+}
+
+func TestKSailValidatorHelmValues(t *testing.T) {
+	t.Parallel()
+
+	valuesFile := filepath.Join(t.TempDir(), "cilium-values.yaml")
+	require.NoError(t, os.WriteFile(valuesFile, []byte("hubble:\n  enabled: true\n"), 0o600))
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Cilium = v1alpha1.OptionsCilium{ValuesFile: valuesFile, Values: "operator:\n  replicas: 2\n"}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.Calico = v1alpha1.OptionsCalico{ValuesFile: filepath.Join(t.TempDir(), "missing.yaml")}
+	config.Spec.Options.MetricsServer = v1alpha1.OptionsMetricsServer{Values: "- not\n- a mapping\n"}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	assert.False(t, result.Valid)
+	validateExpectedErrors(t, []string{
+		"spec.options.calico.valuesFile",
+		"spec.options.metricsServer.values",
+	}, result.Errors)
+}
+
+func TestKSailValidatorChartVersions(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Cilium.Version = "1.18.2"
+	config.Spec.Options.Flux.Version = "~2.16"
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.MetricsServer.Version = "latest"
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	assert.False(t, result.Valid)
+	validateExpectedErrors(t, []string{"spec.options.metricsServer.version"}, result.Errors)
+}
+
+func TestKSailValidatorChartMirror(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+
+	for _, mirror := range []string{"oci://localhost:5000/charts", t.TempDir()} {
+		config.Spec.Options.Helm.ChartMirror = mirror
+
+		result := ksailvalidator.NewValidator().Validate(config)
+		assert.True(t, result.Valid, "unexpected errors for %s: %v", mirror, result.Errors)
+	}
+
+	config.Spec.Options.Helm.ChartMirror = filepath.Join(t.TempDir(), "missing")
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.False(t, result.Valid)
+	validateExpectedErrors(t, []string{"spec.options.helm.chartMirror"}, result.Errors)
+}
+
+func TestKSailValidatorChartRepositories(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Cilium.Repository = v1alpha1.ChartRepository{
+		URL:      "oci://registry.example.com/charts",
+		Username: "ksail",
+		Password: "secret",
+	}
+	config.Spec.Options.Calico.Repository = v1alpha1.ChartRepository{URL: "https://charts.example.com/calico"}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.Cilium.Repository = v1alpha1.ChartRepository{Password: "secret"}
+	config.Spec.Options.Calico.Repository = v1alpha1.ChartRepository{URL: "registry.example.com/charts"}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{
+		"spec.options.cilium.repository.url",
+		"spec.options.cilium.repository.username",
+		"spec.options.calico.repository.url",
+	}, result.Errors)
+}
+
+func TestKSailValidatorMetalLB(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.MetalLB = v1alpha1.OptionsMetalLB{Enabled: true}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	for _, addresses := range []string{"172.18.255.200-172.18.255.250", "172.18.255.192/27"} {
+		config.Spec.Options.MetalLB.Addresses = addresses
+
+		result = ksailvalidator.NewValidator().Validate(config)
+		assert.True(t, result.Valid, "unexpected errors for %s: %v", addresses, result.Errors)
+	}
+
+	config.Spec.Options.MetalLB.Addresses = "172.18.255.250-172.18.255.200"
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{"spec.options.metalLB.addresses"}, result.Errors)
+
+	k3dConfig := createValidKSailConfig(v1alpha1.DistributionK3d)
+	k3dConfig.Spec.Options.MetalLB = v1alpha1.OptionsMetalLB{Enabled: true}
+
+	result = ksailvalidator.NewValidator().Validate(k3dConfig)
+	validateExpectedErrors(t, []string{"spec.options.metalLB.enabled"}, result.Errors)
+}
+
+func TestKSailValidatorGatewayAPI(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.GatewayAPI = v1alpha1.OptionsGatewayAPI{
+		Enabled: true,
+		Channel: v1alpha1.GatewayAPIChannelExperimental,
+		Version: "v1.3.0",
+	}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.GatewayAPI.Channel = "Beta"
+	config.Spec.Options.GatewayAPI.Version = "1.3"
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{
+		"spec.options.gatewayAPI.channel",
+		"spec.options.gatewayAPI.version",
+	}, result.Errors)
+}
+
+func TestKSailValidatorKyverno(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Kyverno = v1alpha1.OptionsKyverno{
+		Enabled:             true,
+		PodSecurityStandard: v1alpha1.PodSecurityStandardRestricted,
+	}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.Kyverno.PodSecurityStandard = "privileged"
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{"spec.options.kyverno.podSecurityStandard"}, result.Errors)
+
+	config.Spec.Options.Kyverno = v1alpha1.OptionsKyverno{PodSecurityStandard: v1alpha1.PodSecurityStandardBaseline}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{"spec.options.kyverno.podSecurityStandard"}, result.Errors)
+}
+
+func TestKSailValidatorArgoCD(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.ArgoCD = v1alpha1.OptionsArgoCD{Enabled: true}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+	config.Spec.Options.Flux.Interval = metav1.Duration{Duration: time.Minute}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{"spec.options.argocd.enabled"}, result.Errors)
+}
+
+func TestKSailValidatorVault(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Vault = v1alpha1.OptionsVault{
+		Enabled:    true,
+		KVMount:    "apps",
+		TransitKey: "sops",
+	}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.Vault.KVMount = "sys"
+	config.Spec.Options.Vault.TransitKey = "keys/sops"
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{
+		"spec.options.vault.kvMount",
+		"spec.options.vault.transitKey",
+	}, result.Errors)
+}
+
+func TestKSailValidatorPlugins(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Plugins = []v1alpha1.Plugin{
+		{Name: "platform-charts", Command: "./plugins/platform-charts", DependsOn: []string{"flux"}},
+		{Name: "policies", Command: "ksail-policies", DependsOn: []string{"platform-charts"}},
+	}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Plugins = []v1alpha1.Plugin{
+		{Name: "Platform Charts", Command: "ksail-platform"},
+		{Name: "policies", Command: "ksail-policies"},
+		{Name: "policies", DependsOn: []string{"policies"}},
+	}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{
+		"spec.plugins[0].name",
+		"spec.plugins[2].name",
+		"spec.plugins[2].command",
+		"spec.plugins[2].dependsOn",
+	}, result.Errors)
+}
+
+func TestKSailValidatorInstall(t *testing.T) {
+	t.Parallel()
+
+	config := createValidKSailConfig(v1alpha1.DistributionKind)
+	config.Spec.Options.Install = v1alpha1.OptionsInstall{
+		Attempts: 5,
+		Interval: metav1.Duration{Duration: 5 * time.Second},
+		Components: map[string]v1alpha1.InstallPolicy{
+			"flux": {Timeout: metav1.Duration{Duration: 15 * time.Minute}},
+		},
+	}
+
+	result := ksailvalidator.NewValidator().Validate(config)
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+
+	config.Spec.Options.Install.Attempts = -1
+	config.Spec.Options.Install.Components = map[string]v1alpha1.InstallPolicy{
+		"flux": {
+			Interval: metav1.Duration{Duration: -time.Second},
+			Timeout:  metav1.Duration{Duration: -time.Minute},
+		},
+	}
+
+	result = ksailvalidator.NewValidator().Validate(config)
+	validateExpectedErrors(t, []string{
+		"spec.options.install.attempts",
+		"spec.options.install.components.flux.interval",
+		"spec.options.install.components.flux.timeout",
+	}, result.Errors)
+}
+
 func testKindValidContext(t *testing.T) {
 	t.Helper()
 
@@ -1870,3 +2525,39 @@ func TestKSailValidatorK3dDefaultCNIAlignment(t *testing.T) {
 		})
 	}
 }
+
+func TestKSailValidator_AcceptsEveryGeneratedVariant(t *testing.T) {
+	t.Parallel()
+
+	for _, variant := range testutils.KSailConfigMatrix() {
+		t.Run(variant.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			result := ksailvalidator.NewValidator().Validate(variant.Cluster())
+			require.NotNil(t, result)
+			assert.True(t, result.Valid, "expected %s to be valid, got errors: %v", variant.Name(), result.Errors)
+		})
+	}
+}
+
+// FuzzValidate checks that validating arbitrary ksail.yaml documents, alone or alongside a
+// kind.yaml distribution config, never panics.
+func FuzzValidate(f *testing.F) {
+	testutils.AddYAMLFuzzCorpus(f, testutils.KSailConfigSeeds()...)
+
+	marshaller := yamlmarshaller.NewMarshaller[v1alpha1.Cluster]()
+	kindConfig := &kindv1alpha4.Cluster{}
+	kindv1alpha4.SetDefaultsCluster(kindConfig)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var config v1alpha1.Cluster
+
+		err := marshaller.Unmarshal(data, &config)
+		if err != nil {
+			t.Skip()
+		}
+
+		require.NotNil(t, ksailvalidator.NewValidator().Validate(&config))
+		require.NotNil(t, ksailvalidator.NewValidatorForKind(kindConfig).Validate(&config))
+	})
+}
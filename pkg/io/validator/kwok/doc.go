@@ -0,0 +1,6 @@
+// Package kwok provides kwok configuration validation functionality.
+//
+// This package implements the Validator interface for the kwok.yaml distribution
+// configuration, validating cluster configurations for semantic correctness and
+// constraint compliance.
+package kwok
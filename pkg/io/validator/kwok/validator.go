@@ -0,0 +1,73 @@
+package kwok
+
+import (
+	"math"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/validator"
+	"github.com/devantler-tech/ksail-go/pkg/io/validator/metadata"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Validator validates kwok cluster configurations.
+type Validator struct{}
+
+// NewValidator creates a new kwok configuration validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validate performs validation on a loaded kwok cluster configuration.
+func (v *Validator) Validate(config *kwokprovisioner.Config) *validator.ValidationResult {
+	result := validator.NewValidationResult("kwok.yaml")
+
+	if config == nil {
+		result.AddError(validator.ValidationError{
+			Field:         "config",
+			Message:       "configuration is nil",
+			FixSuggestion: "Provide a valid kwok cluster configuration",
+		})
+
+		return result
+	}
+
+	metadata.ValidateMetadata(
+		config.Kind,
+		config.APIVersion,
+		kwokprovisioner.Kind,
+		kwokprovisioner.APIVersion,
+		result,
+	)
+
+	// The name is used for the container name and the kube context.
+	if config.Name != "" && len(validation.IsDNS1123Label(config.Name)) > 0 {
+		result.AddError(validator.ValidationError{
+			Field:         "name",
+			Message:       "name must be a valid DNS label",
+			CurrentValue:  config.Name,
+			FixSuggestion: "Use lowercase letters, digits and '-', starting and ending with a letter or digit",
+		})
+	}
+
+	if config.Nodes < 0 {
+		result.AddError(validator.ValidationError{
+			Field:         "nodes",
+			Message:       "nodes must not be negative",
+			CurrentValue:  config.Nodes,
+			ExpectedValue: ">= 0",
+			FixSuggestion: "Set nodes to the number of fake nodes the cluster should have",
+		})
+	}
+
+	if config.APIServerPort < 0 || config.APIServerPort > math.MaxUint16 {
+		result.AddError(validator.ValidationError{
+			Field:         "apiServerPort",
+			Message:       "apiServerPort must be a valid port",
+			CurrentValue:  config.APIServerPort,
+			ExpectedValue: "0-65535",
+			FixSuggestion: "Set apiServerPort to 0 to pick a free port",
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,61 @@
+package kwok_test
+
+import (
+	"testing"
+
+	kwokvalidator "github.com/devantler-tech/ksail-go/pkg/io/validator/kwok"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mutate      func(config *kwokprovisioner.Config)
+		errorFields []string
+	}{
+		{name: "default", mutate: func(*kwokprovisioner.Config) {}},
+		{name: "many_nodes", mutate: func(config *kwokprovisioner.Config) { config.Nodes = 500 }},
+		{
+			name:        "invalid_name",
+			mutate:      func(config *kwokprovisioner.Config) { config.Name = "My_Cluster" },
+			errorFields: []string{"name"},
+		},
+		{
+			name:        "negative_nodes",
+			mutate:      func(config *kwokprovisioner.Config) { config.Nodes = -1 },
+			errorFields: []string{"nodes"},
+		},
+		{
+			name:        "port_out_of_range",
+			mutate:      func(config *kwokprovisioner.Config) { config.APIServerPort = 70000 },
+			errorFields: []string{"apiServerPort"},
+		},
+		{
+			name:        "missing_api_version",
+			mutate:      func(config *kwokprovisioner.Config) { config.APIVersion = "" },
+			errorFields: []string{"apiVersion"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := kwokprovisioner.NewConfig("")
+			testCase.mutate(config)
+
+			result := kwokvalidator.NewValidator().Validate(config)
+
+			var fields []string
+			for _, validationError := range result.Errors {
+				fields = append(fields, validationError.Field)
+			}
+
+			assert.Equal(t, testCase.errorFields, fields)
+			assert.Equal(t, len(testCase.errorFields) == 0, result.Valid)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package network provides shared cluster network validation utilities used across multiple
+// validators.
+//
+// This package contains common validation functions for pod, service and Docker network
+// subnets, used by different validator implementations to check that subnets are valid CIDRs
+// of the IP families the cluster runs, do not overlap, and contain their gateways.
+package network
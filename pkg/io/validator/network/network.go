@@ -0,0 +1,156 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/validator"
+)
+
+// ValidateSubnet validates that a comma-separated list of CIDRs holds exactly one subnet of
+// every IP family the cluster runs. Empty subnets are valid and keep the distribution default.
+func ValidateSubnet(
+	field, subnet string,
+	ipv4, ipv6 bool,
+	result *validator.ValidationResult,
+) {
+	if subnet == "" {
+		return
+	}
+
+	var ipv4Subnets, ipv6Subnets int
+
+	for cidr := range strings.SplitSeq(subnet, ",") {
+		ip, _, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			result.AddError(validator.ValidationError{
+				Field:         field,
+				Message:       fmt.Sprintf("%q is not a valid CIDR", strings.TrimSpace(cidr)),
+				CurrentValue:  subnet,
+				ExpectedValue: expectedSubnets(ipv4, ipv6),
+				FixSuggestion: "Set " + field + " to CIDRs such as 10.244.0.0/16 or fd00:10:244::/56",
+			})
+
+			return
+		}
+
+		if ip.To4() != nil {
+			ipv4Subnets++
+		} else {
+			ipv6Subnets++
+		}
+	}
+
+	if ipv4Subnets != count(ipv4) || ipv6Subnets != count(ipv6) {
+		result.AddError(validator.ValidationError{
+			Field:         field,
+			Message:       "subnets do not match the IP family of the cluster",
+			CurrentValue:  subnet,
+			ExpectedValue: expectedSubnets(ipv4, ipv6),
+			FixSuggestion: "Set " + field + " to " + expectedSubnets(ipv4, ipv6) +
+				", or change the IP family of the cluster",
+		})
+	}
+}
+
+func expectedSubnets(ipv4, ipv6 bool) string {
+	switch {
+	case ipv4 && ipv6:
+		return "one IPv4 and one IPv6 CIDR separated by a comma"
+	case ipv6:
+		return "one IPv6 CIDR"
+	default:
+		return "one IPv4 CIDR"
+	}
+}
+
+func count(family bool) int {
+	if family {
+		return 1
+	}
+
+	return 0
+}
+
+// ValidateNoOverlap validates that no CIDR of subnet overlaps a CIDR of other, e.g. that the
+// Docker network of the nodes does not shadow the pod or service range of the cluster.
+func ValidateNoOverlap(
+	field, subnet, otherField, other string,
+	result *validator.ValidationResult,
+) {
+	for _, cidr := range parseCIDRs(subnet) {
+		for _, otherCIDR := range parseCIDRs(other) {
+			if !cidr.Contains(otherCIDR.IP) && !otherCIDR.Contains(cidr.IP) {
+				continue
+			}
+
+			result.AddError(validator.ValidationError{
+				Field:         field,
+				Message:       fmt.Sprintf("%s overlaps %s %s", cidr, otherField, otherCIDR),
+				CurrentValue:  subnet,
+				ExpectedValue: "a CIDR that does not overlap " + otherCIDR.String(),
+				FixSuggestion: "Set " + field + " to a range outside of " + otherField,
+			})
+
+			return
+		}
+	}
+}
+
+// ValidateGateway validates that gateway is an IP address inside one of the CIDRs of subnet.
+// Empty gateways are valid and let the container engine pick the first address.
+func ValidateGateway(
+	field, gateway, subnetField, subnet string,
+	result *validator.ValidationResult,
+) {
+	if gateway == "" {
+		return
+	}
+
+	if subnet == "" {
+		result.AddError(validator.ValidationError{
+			Field:         field,
+			Message:       "a gateway requires a subnet",
+			CurrentValue:  gateway,
+			FixSuggestion: "Set " + subnetField + " to the CIDR the gateway belongs to, or remove " + field,
+		})
+
+		return
+	}
+
+	ip := net.ParseIP(gateway)
+
+	for _, cidr := range parseCIDRs(subnet) {
+		if ip != nil && cidr.Contains(ip) {
+			return
+		}
+	}
+
+	result.AddError(validator.ValidationError{
+		Field:         field,
+		Message:       fmt.Sprintf("%q is not an IP address inside %s", gateway, subnetField),
+		CurrentValue:  gateway,
+		ExpectedValue: "an IP address inside " + subnet,
+		FixSuggestion: "Set " + field + " to an address of " + subnetField + ", e.g. its first address",
+	})
+}
+
+// parseCIDRs returns the valid CIDRs of a comma-separated list; ValidateSubnet reports the
+// invalid ones.
+func parseCIDRs(subnet string) []*net.IPNet {
+	if subnet == "" {
+		return nil
+	}
+
+	var cidrs []*net.IPNet
+
+	for cidr := range strings.SplitSeq(subnet, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err == nil {
+			cidrs = append(cidrs, ipNet)
+		}
+	}
+
+	return cidrs
+}
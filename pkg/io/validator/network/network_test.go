@@ -0,0 +1,113 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/validator"
+	"github.com/devantler-tech/ksail-go/pkg/io/validator/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSubnet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		subnet  string
+		ipv4    bool
+		ipv6    bool
+		message string
+	}{
+		{name: "empty", subnet: "", ipv4: true},
+		{name: "ipv4", subnet: "10.244.0.0/16", ipv4: true},
+		{name: "ipv6", subnet: "fd00:10:244::/56", ipv6: true},
+		{name: "dual_stack", subnet: "fd00:10:244::/56, 10.244.0.0/16", ipv4: true, ipv6: true},
+		{name: "invalid_cidr", subnet: "10.244.0.0", ipv4: true, message: "is not a valid CIDR"},
+		{name: "wrong_family", subnet: "10.244.0.0/16", ipv6: true, message: "do not match"},
+		{
+			name:    "missing_family",
+			subnet:  "10.244.0.0/16",
+			ipv4:    true,
+			ipv6:    true,
+			message: "do not match",
+		},
+		{
+			name:    "duplicate_family",
+			subnet:  "10.244.0.0/16,10.245.0.0/16",
+			ipv4:    true,
+			message: "do not match",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.NewValidationResult("test.yaml")
+
+			network.ValidateSubnet("podSubnet", testCase.subnet, testCase.ipv4, testCase.ipv6, result)
+
+			if testCase.message == "" {
+				assert.Empty(t, result.Errors)
+
+				return
+			}
+
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, "podSubnet", result.Errors[0].Field)
+			assert.Contains(t, result.Errors[0].Message, testCase.message)
+		})
+	}
+}
+
+func TestValidateNoOverlap(t *testing.T) {
+	t.Parallel()
+
+	result := validator.NewValidationResult("test.yaml")
+	network.ValidateNoOverlap("dockerSubnet", "172.30.0.0/16", "podSubnet", "10.244.0.0/16", result)
+	network.ValidateNoOverlap("dockerSubnet", "", "podSubnet", "10.244.0.0/16", result)
+	assert.Empty(t, result.Errors)
+
+	network.ValidateNoOverlap("dockerSubnet", "10.0.0.0/8, fd00::/64", "podSubnet", "10.244.0.0/16", result)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "dockerSubnet", result.Errors[0].Field)
+	assert.Equal(t, "10.0.0.0/8 overlaps podSubnet 10.244.0.0/16", result.Errors[0].Message)
+}
+
+func TestValidateGateway(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		gateway string
+		subnet  string
+		message string
+	}{
+		{name: "empty", gateway: "", subnet: "172.30.0.0/16"},
+		{name: "inside", gateway: "172.30.0.1", subnet: "172.30.0.0/16"},
+		{name: "inside_dual_stack", gateway: "fd00:30::1", subnet: "172.30.0.0/16,fd00:30::/64"},
+		{name: "without_subnet", gateway: "172.30.0.1", message: "requires a subnet"},
+		{name: "outside", gateway: "10.0.0.1", subnet: "172.30.0.0/16", message: "is not an IP address inside"},
+		{name: "invalid", gateway: "gateway", subnet: "172.30.0.0/16", message: "is not an IP address inside"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.NewValidationResult("test.yaml")
+
+			network.ValidateGateway("gateway", testCase.gateway, "subnet", testCase.subnet, result)
+
+			if testCase.message == "" {
+				assert.Empty(t, result.Errors)
+
+				return
+			}
+
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Message, testCase.message)
+		})
+	}
+}
@@ -33,6 +33,7 @@ func TryWrite(content string, writer io.Writer) (string, error) {
 
 // WriteFileSafe writes content to a file path only if it is within the specified base directory.
 // It prevents path traversal attacks by validating the path is within basePath.
+// The file is replaced atomically, see WriteFileAtomic.
 //
 // Parameters:
 //   - content: The content to write to the file
@@ -78,8 +79,7 @@ func WriteFileSafe(content, basePath, filePath string, force bool) error {
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
-	// Write the file using os.WriteFile which doesn't trigger G304 like os.OpenFile does
-	err = os.WriteFile(filePath, []byte(content), filePermUserRW)
+	err = WriteFileAtomic(filePath, []byte(content), filePermUserRW)
 	if err != nil {
 		return fmt.Errorf("failed to write file %s: %w", filePath, err)
 	}
@@ -87,10 +87,136 @@ func WriteFileSafe(content, basePath, filePath string, force bool) error {
 	return nil
 }
 
+// WriteFileAtomic replaces the file at path with data so readers only ever see the old or the
+// new content, never a truncated file.
+//
+// The data is written to a temporary file in the same directory, flushed to disk and renamed
+// over path. An existing file keeps its permissions and must be writable; new files get perm.
+// When path is a symlink, the file it points to is replaced and the link is kept.
+//
+// Parameters:
+//   - path: The file path to write to (its directory must exist)
+//   - data: The content to write
+//   - perm: The permissions for a newly created file
+//
+// Returns:
+//   - error: Error if the file cannot be written or replaced
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	path, err := resolveSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	mode, err := existingFileMode(path, perm)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file in %s: %w", dir, err)
+	}
+
+	tmpPath := tmp.Name()
+
+	err = writeAndSync(tmp, data, mode)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf("replace %s: %w", path, err)
+	}
+
+	syncDir(dir)
+
+	return nil
+}
+
+// resolveSymlinks returns the file an existing path points to, so renaming over it replaces
+// the target of a symlink rather than the link. Paths that do not exist are returned as is.
+func resolveSymlinks(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("resolve symlinks of %s: %w", path, err)
+	}
+
+	return resolved, nil
+}
+
+// existingFileMode returns the permissions of the file at path, or perm if it does not exist.
+// Existing files must be writable, matching the behavior of writing to them in place.
+func existingFileMode(path string, perm os.FileMode) (os.FileMode, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return perm, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	// #nosec G304 -- opened without truncation only to confirm the caller may write the file
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, fmt.Errorf("open %s for writing: %w", path, err)
+	}
+
+	_ = file.Close()
+
+	return info.Mode().Perm(), nil
+}
+
+func writeAndSync(file *os.File, data []byte, mode os.FileMode) error {
+	_, err := file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("write temporary file %s: %w", file.Name(), err)
+	}
+
+	return nil
+}
+
+// syncDir flushes a rename to disk where the platform supports syncing directories.
+func syncDir(dir string) {
+	// #nosec G304 -- dir is the parent of a path the caller chose to write
+	handle, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+
+	_ = handle.Sync()
+	_ = handle.Close()
+}
+
 // File writing operations.
 
 // TryWriteFile writes content to a file path, handling force/overwrite logic.
 // It validates that the output path doesn't contain path traversal attempts.
+// The file is replaced atomically, see WriteFileAtomic.
 //
 // Parameters:
 //   - content: The content to write to the file
@@ -130,8 +256,7 @@ func TryWriteFile(content string, output string, force bool) (string, error) {
 		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
-	// Write the file using os.WriteFile
-	err = os.WriteFile(output, []byte(content), filePermUserRW)
+	err = WriteFileAtomic(output, []byte(content), filePermUserRW)
 	if err != nil {
 		return "", fmt.Errorf("failed to write file %s: %w", output, err)
 	}
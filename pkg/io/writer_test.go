@@ -630,3 +630,81 @@ func runErrorTestsWithThreeParams(
 		})
 	}
 }
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates new file", func(t *testing.T) {
+		t.Parallel()
+
+		tempDir := t.TempDir()
+		outputPath := filepath.Join(tempDir, "ksail.yaml")
+
+		err := ioutils.WriteFileAtomic(outputPath, []byte(testContent), 0o600)
+		require.NoError(t, err, "WriteFileAtomic()")
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 -- test temp file
+		require.NoError(t, err, "ReadFile()")
+		assert.Equal(t, testContent, string(content))
+
+		entries, err := os.ReadDir(tempDir)
+		require.NoError(t, err, "ReadDir()")
+		assert.Len(t, entries, 1, "expected no temporary files to be left behind")
+	})
+
+	t.Run("replaces existing file and keeps its permissions", func(t *testing.T) {
+		t.Parallel()
+
+		outputPath := filepath.Join(t.TempDir(), "kubeconfig")
+		require.NoError(t, os.WriteFile(outputPath, []byte(originalContent), 0o640))
+		require.NoError(t, os.Chmod(outputPath, 0o640))
+
+		err := ioutils.WriteFileAtomic(outputPath, []byte(testContent), 0o600)
+		require.NoError(t, err, "WriteFileAtomic()")
+
+		info, err := os.Stat(outputPath)
+		require.NoError(t, err, "Stat()")
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+		content, err := os.ReadFile(outputPath) // #nosec G304 -- test temp file
+		require.NoError(t, err, "ReadFile()")
+		assert.Equal(t, testContent, string(content))
+	})
+
+	t.Run("replaces the target of a symlink and keeps the link", func(t *testing.T) {
+		t.Parallel()
+
+		targetDir := t.TempDir()
+		targetPath := filepath.Join(targetDir, "config")
+		require.NoError(t, os.WriteFile(targetPath, []byte(originalContent), 0o600))
+
+		linkDir := t.TempDir()
+		linkPath := filepath.Join(linkDir, "kubeconfig")
+		require.NoError(t, os.Symlink(targetPath, linkPath))
+
+		err := ioutils.WriteFileAtomic(linkPath, []byte(testContent), 0o600)
+		require.NoError(t, err, "WriteFileAtomic()")
+
+		info, err := os.Lstat(linkPath)
+		require.NoError(t, err, "Lstat()")
+		assert.Equal(t, os.ModeSymlink, info.Mode().Type(), "expected the symlink to be kept")
+
+		content, err := os.ReadFile(targetPath) // #nosec G304 -- test temp file
+		require.NoError(t, err, "ReadFile()")
+		assert.Equal(t, testContent, string(content))
+
+		entries, err := os.ReadDir(linkDir)
+		require.NoError(t, err, "ReadDir()")
+		assert.Len(t, entries, 1, "expected the temporary file next to the target, not the link")
+	})
+
+	t.Run("fails when directory is missing", func(t *testing.T) {
+		t.Parallel()
+
+		outputPath := filepath.Join(t.TempDir(), "missing", "ksail.yaml")
+
+		err := ioutils.WriteFileAtomic(outputPath, []byte(testContent), 0o600)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "create temporary file")
+	})
+}
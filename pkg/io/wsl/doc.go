@@ -0,0 +1,8 @@
+// Package wsl detects Windows Subsystem for Linux 2 environments and translates paths
+// between Windows and the Linux distribution.
+//
+// Key functionality:
+//   - Detect / IsWSL: Report whether KSail runs inside a WSL2 distribution
+//   - ToLinuxPath: Convert C:\dir and \\wsl.localhost\distro\dir paths to their Linux form
+//   - ToWindowsPath: Convert Linux paths to the form Windows tools use to reach them
+package wsl
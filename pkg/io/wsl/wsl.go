@@ -0,0 +1,98 @@
+package wsl
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// Environment variables set by WSL in every distribution process.
+const (
+	DistroNameEnvVar = "WSL_DISTRO_NAME"
+	interopEnvVar    = "WSL_INTEROP"
+)
+
+const (
+	kernelVersionPath = "/proc/version"
+	kernelMarker      = "microsoft"
+	driveMountRoot    = "/mnt/"
+	windowsSeparator  = `\`
+	uncHostLocalhost  = `\\wsl.localhost\`
+	uncHostLegacy     = `\\wsl$\`
+)
+
+//nolint:gochecknoglobals // compiled once for path translation
+var (
+	windowsDrivePath = regexp.MustCompile(`^([A-Za-z]):(?:[\\/](.*))?$`)
+	mountedDrivePath = regexp.MustCompile(`^/mnt/([a-z])(?:/(.*))?$`)
+)
+
+// Detect reports whether the current process runs inside a WSL2 distribution.
+func Detect() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	kernelVersion, _ := os.ReadFile(kernelVersionPath)
+
+	return IsWSL(string(kernelVersion), os.Getenv)
+}
+
+// IsWSL reports whether kernelVersion (the content of /proc/version) and the environment
+// looked up through getenv identify a WSL distribution.
+func IsWSL(kernelVersion string, getenv func(string) string) bool {
+	if getenv(DistroNameEnvVar) != "" || getenv(interopEnvVar) != "" {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(kernelVersion), kernelMarker)
+}
+
+// ToLinuxPath converts a Windows path into the path of the same file inside the distribution.
+//
+// Drive paths such as C:\Users\me map to /mnt/c/Users/me and UNC paths into a distribution,
+// such as \\wsl.localhost\Ubuntu\home\me, map to /home/me. Other paths are returned unchanged.
+func ToLinuxPath(windowsPath string) string {
+	if match := windowsDrivePath.FindStringSubmatch(windowsPath); match != nil {
+		drive := strings.ToLower(match[1])
+		rest := strings.ReplaceAll(match[2], windowsSeparator, "/")
+
+		return strings.TrimSuffix(driveMountRoot+drive+"/"+rest, "/")
+	}
+
+	for _, prefix := range []string{uncHostLocalhost, uncHostLegacy} {
+		if len(windowsPath) < len(prefix) || !strings.EqualFold(windowsPath[:len(prefix)], prefix) {
+			continue
+		}
+
+		// Drop the distribution name that follows the host.
+		_, rest, _ := strings.Cut(windowsPath[len(prefix):], windowsSeparator)
+
+		return "/" + strings.ReplaceAll(rest, windowsSeparator, "/")
+	}
+
+	return windowsPath
+}
+
+// ToWindowsPath converts an absolute Linux path into the path Windows tools use to reach it.
+//
+// Paths below /mnt/<drive> map back to the drive, e.g. /mnt/c/src becomes C:\src; all other
+// absolute paths map into the distribution through \\wsl.localhost\<distro>. Relative paths
+// are returned unchanged.
+func ToWindowsPath(linuxPath, distro string) string {
+	if !path.IsAbs(linuxPath) {
+		return linuxPath
+	}
+
+	cleaned := path.Clean(linuxPath)
+
+	if match := mountedDrivePath.FindStringSubmatch(cleaned); match != nil {
+		drive := strings.ToUpper(match[1])
+
+		return drive + `:\` + strings.ReplaceAll(match[2], "/", windowsSeparator)
+	}
+
+	return uncHostLocalhost + distro + strings.ReplaceAll(cleaned, "/", windowsSeparator)
+}
@@ -0,0 +1,75 @@
+package wsl_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/io/wsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestIsWSL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		kernelVersion string
+		env           map[string]string
+		want          bool
+	}{
+		{
+			name:          "wsl2 kernel",
+			kernelVersion: "Linux version 5.15.153.1-microsoft-standard-WSL2 (root@1c602f52c2e4)",
+			want:          true,
+		},
+		{
+			name: "distro environment",
+			env:  map[string]string{wsl.DistroNameEnvVar: "Ubuntu"},
+			want: true,
+		},
+		{
+			name:          "native linux",
+			kernelVersion: "Linux version 6.8.0-45-generic (buildd@lcy02-amd64-115)",
+			want:          false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, wsl.IsWSL(testCase.kernelVersion, env(testCase.env)))
+		})
+	}
+}
+
+func TestToLinuxPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		`C:\Users\me\.kube\config`:           "/mnt/c/Users/me/.kube/config",
+		`d:/src/app`:                         "/mnt/d/src/app",
+		`E:\`:                                "/mnt/e",
+		`\\wsl.localhost\Ubuntu\home\me\k8s`: "/home/me/k8s",
+		`\\wsl$\Debian\srv\data`:             "/srv/data",
+		"/home/me/.kube/config":              "/home/me/.kube/config",
+		"k8s/overlays/dev":                   "k8s/overlays/dev",
+		"~/.kube/config":                     "~/.kube/config",
+	}
+
+	for input, want := range tests {
+		assert.Equal(t, want, wsl.ToLinuxPath(input), input)
+	}
+}
+
+func TestToWindowsPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `C:\Users\me\src`, wsl.ToWindowsPath("/mnt/c/Users/me/src", "Ubuntu"))
+	assert.Equal(t, `\\wsl.localhost\Ubuntu\home\me\.kube\config`,
+		wsl.ToWindowsPath("/home/me/.kube/config", "Ubuntu"))
+	assert.Equal(t, "k8s", wsl.ToWindowsPath("k8s", "Ubuntu"))
+}
@@ -18,8 +18,8 @@ import (
 //   - No pods are unavailable
 //   - All pods have been updated to the current specification
 //
-// The function tolerates NotFound errors and continues polling. Other API errors
-// are returned immediately.
+// The function tolerates NotFound and transient errors (see IsTransientError) and continues
+// polling. Other API errors are returned immediately.
 //
 // Returns an error if the DaemonSet is not ready within the deadline or if an API error occurs.
 func WaitForDaemonSetReady(
@@ -33,20 +33,13 @@ func WaitForDaemonSetReady(
 			DaemonSets(namespace).
 			Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) || IsTransientError(err) {
 				return false, nil
 			}
 
 			return false, fmt.Errorf("failed to get daemonset %s/%s: %w", namespace, name, err)
 		}
 
-		if daemonSet.Status.DesiredNumberScheduled == 0 {
-			return false, nil
-		}
-
-		ready := daemonSet.Status.NumberUnavailable == 0 &&
-			daemonSet.Status.UpdatedNumberScheduled == daemonSet.Status.DesiredNumberScheduled
-
-		return ready, nil
+		return daemonSetReady(daemonSet), nil
 	})
 }
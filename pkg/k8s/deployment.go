@@ -18,8 +18,8 @@ import (
 //   - All replicas have been updated
 //   - All replicas are available
 //
-// The function tolerates NotFound errors and continues polling. Other API errors
-// are returned immediately.
+// The function tolerates NotFound and transient errors (see IsTransientError) and continues
+// polling. Other API errors are returned immediately.
 //
 // Returns an error if the Deployment is not ready within the deadline or if an API error occurs.
 func WaitForDeploymentReady(
@@ -33,25 +33,13 @@ func WaitForDeploymentReady(
 			Deployments(namespace).
 			Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) || IsTransientError(err) {
 				return false, nil
 			}
 
 			return false, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
 		}
 
-		if deployment.Status.Replicas == 0 {
-			return false, nil
-		}
-
-		if deployment.Status.UpdatedReplicas < deployment.Status.Replicas {
-			return false, nil
-		}
-
-		if deployment.Status.AvailableReplicas < deployment.Status.Replicas {
-			return false, nil
-		}
-
-		return true, nil
+		return deploymentReady(deployment), nil
 	})
 }
@@ -9,6 +9,10 @@
 //   - REST config building from kubeconfig files (BuildRESTConfig)
 //   - Deployment readiness polling (WaitForDeploymentReady)
 //   - DaemonSet readiness polling (WaitForDaemonSetReady)
-//   - Multi-resource coordination (WaitForMultipleResources)
-//   - Flexible polling mechanism (PollForReadiness)
+//   - One-off ready, degraded or missing checks of either (CheckReadiness)
+//   - Multi-resource coordination with optional concurrency and fail-fast control
+//     (WaitForMultipleResources)
+//   - Polling with capped exponential backoff and jitter (PollForReadiness)
+//   - Retrying operations that fail with transient errors (Retry, IsTransientError)
+//   - Port-forwarding a local port to a service (ForwardService)
 package k8s
@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestWaitForMultipleResources_AgainstAPIServer(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+	ctx := context.Background()
+
+	const (
+		namespace = "default"
+		name      = "envtest-app"
+	)
+
+	createDeployment(ctx, t, server.Clientset, namespace, name)
+
+	checks := []k8s.ReadinessCheck{{Type: "deployment", Namespace: namespace, Name: name}}
+
+	// No controllers run under envtest, so the deployment stays unready until its status is patched.
+	err := k8s.WaitForMultipleResources(ctx, server.Clientset, checks, 3*time.Second)
+	require.Error(t, err)
+
+	markDeploymentReady(ctx, t, server.Clientset, namespace, name)
+
+	err = k8s.WaitForMultipleResources(ctx, server.Clientset, checks, 10*time.Second)
+	require.NoError(t, err)
+}
+
+func TestBuildRESTConfig_AgainstAPIServer(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+
+	config, err := k8s.BuildRESTConfig(server.KubeconfigPath, testutils.EnvtestContext)
+	require.NoError(t, err)
+
+	clientset, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	_, err = clientset.Discovery().ServerVersion()
+	require.NoError(t, err)
+}
+
+func createDeployment(
+	ctx context.Context,
+	t *testing.T,
+	clientset kubernetes.Interface,
+	namespace, name string,
+) {
+	t.Helper()
+
+	replicas := int32(1)
+	labels := map[string]string{"app": name}
+
+	_, err := clientset.AppsV1().Deployments(namespace).Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "busybox"}},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func markDeploymentReady(
+	ctx context.Context,
+	t *testing.T,
+	clientset kubernetes.Interface,
+	namespace, name string,
+) {
+	t.Helper()
+
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	deployment.Status = appsv1.DeploymentStatus{
+		Replicas:          1,
+		UpdatedReplicas:   1,
+		ReadyReplicas:     1,
+		AvailableReplicas: 1,
+	}
+
+	_, err = clientset.AppsV1().Deployments(namespace).UpdateStatus(ctx, deployment, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
@@ -2,11 +2,13 @@ package k8s_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/k8s"
 	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -219,6 +221,50 @@ func TestPollForReadiness(t *testing.T) {
 			"pollForReadiness error wrap",
 		)
 	})
+
+	t.Run("BacksOffBetweenChecks", func(t *testing.T) {
+		t.Parallel()
+
+		calls := countPollsUntilTimeout(
+			t,
+			400*time.Millisecond,
+			k8s.WithPollInterval(50*time.Millisecond),
+			k8s.WithPollMaxInterval(time.Second),
+		)
+
+		// Delays of roughly 50, 100 and 200ms fit in the deadline, unlike eight fixed 50ms ones.
+		assert.LessOrEqual(t, calls, int32(5))
+		assert.GreaterOrEqual(t, calls, int32(2))
+	})
+
+	t.Run("CapsDelay", func(t *testing.T) {
+		t.Parallel()
+
+		calls := countPollsUntilTimeout(
+			t,
+			400*time.Millisecond,
+			k8s.WithPollInterval(20*time.Millisecond),
+			k8s.WithPollMaxInterval(20*time.Millisecond),
+		)
+
+		assert.GreaterOrEqual(t, calls, int32(8))
+	})
+}
+
+func countPollsUntilTimeout(t *testing.T, deadline time.Duration, opts ...k8s.PollOption) int32 {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	err := k8s.PollForReadiness(context.Background(), deadline, func(context.Context) (bool, error) {
+		calls.Add(1)
+
+		return false, nil
+	}, opts...)
+
+	testutils.ExpectErrorContains(t, err, "failed to poll for readiness", "pollForReadiness timeout")
+
+	return calls.Load()
 }
 
 func pollForReadinessWithDefaultTimeout(
@@ -2,7 +2,9 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
@@ -17,67 +19,193 @@ type ReadinessCheck struct {
 	Namespace string
 	// Name is the name of the resource to check for readiness.
 	Name string
+	// Timeout optionally caps how long this resource may take to become ready.
+	// Zero means the resource may use whatever remains of the shared timeout.
+	Timeout time.Duration
+}
+
+// WaitOption configures WaitForMultipleResources.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	maxConcurrency int
+	failFast       bool
+}
+
+// WithMaxConcurrency sets how many readiness checks may run at the same time.
+// Values below one are treated as one, which checks resources in sequence.
+func WithMaxConcurrency(maxConcurrency int) WaitOption {
+	return func(o *waitOptions) {
+		o.maxConcurrency = max(maxConcurrency, 1)
+	}
+}
+
+// WithFailFast controls whether the first failing resource aborts the remaining checks.
+// When disabled, all resources are checked and every failure is reported together.
+func WithFailFast(failFast bool) WaitOption {
+	return func(o *waitOptions) {
+		o.failFast = failFast
+	}
 }
 
 // WaitForMultipleResources waits for multiple Kubernetes resources to be ready.
 //
-// This function checks each resource in sequence, allocating remaining timeout
-// proportionally. If any resource fails to become ready within the allocated time,
-// the function returns an error.
+// By default resources are checked in sequence and the first failure is returned. Use
+// WithMaxConcurrency to check several resources at once and WithFailFast(false) to report
+// every failing resource instead of only the first one.
 //
-// The timeout parameter is shared across all resources, so each subsequent resource
-// gets less time to become ready. Resources are checked in the order they appear
-// in the checks slice.
+// The timeout parameter is shared across all resources, so resources checked later get less
+// time to become ready. A ReadinessCheck with a Timeout is additionally capped by it.
 //
-// Returns ErrTimeoutExceeded if the timeout is reached before a resource is checked.
+// Returns ErrTimeoutExceeded if the timeout is reached before a resource is checked, or the
+// error of ctx if it is canceled first.
 // Returns an error if any resource fails to become ready.
 func WaitForMultipleResources(
 	ctx context.Context,
 	clientset kubernetes.Interface,
 	checks []ReadinessCheck,
 	timeout time.Duration,
+	opts ...WaitOption,
 ) error {
-	start := time.Now()
+	options := waitOptions{maxConcurrency: 1, failFast: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
 
 	for _, check := range checks {
-		elapsed := time.Since(start)
-
-		remainingTimeout := timeout - elapsed
-		if remainingTimeout <= 0 {
-			return errTimeoutExceeded(check.Type, check.Namespace, check.Name)
+		if check.Type != "deployment" && check.Type != "daemonset" {
+			return fmt.Errorf("%w: %s", errUnknownResourceType, check.Type)
 		}
+	}
 
-		resourceCtx, cancel := context.WithTimeout(ctx, remainingTimeout)
+	deadline := time.Now().Add(timeout)
 
-		var err error
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		switch check.Type {
-		case "deployment":
-			err = WaitForDeploymentReady(
-				resourceCtx, clientset, check.Namespace, check.Name, remainingTimeout,
-			)
-		case "daemonset":
-			err = WaitForDaemonSetReady(
-				resourceCtx, clientset, check.Namespace, check.Name, remainingTimeout,
-			)
-		default:
+	var (
+		waitGroup sync.WaitGroup
+		mutex     sync.Mutex
+		firstErr  error
+	)
+
+	errs := make([]error, len(checks))
+	slots := make(chan struct{}, options.maxConcurrency)
+
+	record := func(index int, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		errs[index] = err
+		if firstErr == nil {
+			firstErr = err
+		}
+
+		if options.failFast {
 			cancel()
+		}
+	}
 
-			return fmt.Errorf("%w: %s", errUnknownResourceType, check.Type)
+	failed := func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return firstErr != nil
+	}
+
+	for index, check := range checks {
+		acquired := acquireSlot(runCtx, slots)
+
+		if options.failFast && failed() {
+			if acquired {
+				<-slots
+			}
+
+			break
 		}
 
-		cancel()
+		remaining := time.Until(deadline)
+		if !acquired || remaining <= 0 {
+			if acquired {
+				<-slots
+			}
+
+			record(index, errNotChecked(ctx, check))
 
-		if err != nil {
-			return fmt.Errorf("%s %s not ready: %w", check.Name, check.Type, err)
+			if options.failFast {
+				break
+			}
+
+			continue
 		}
+
+		if check.Timeout > 0 && check.Timeout < remaining {
+			remaining = check.Timeout
+		}
+
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+			defer func() { <-slots }()
+
+			err := waitForCheck(runCtx, clientset, check, remaining)
+			if err != nil {
+				record(index, fmt.Errorf("%s %s not ready: %w", check.Name, check.Type, err))
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	if options.failFast {
+		return firstErr
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // Helper functions.
 
+func waitForCheck(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	check ReadinessCheck,
+	deadline time.Duration,
+) error {
+	resourceCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	if check.Type == "deployment" {
+		return WaitForDeploymentReady(resourceCtx, clientset, check.Namespace, check.Name, deadline)
+	}
+
+	return WaitForDaemonSetReady(resourceCtx, clientset, check.Namespace, check.Name, deadline)
+}
+
+// acquireSlot blocks until a concurrency slot is free, and reports false if ctx ends first.
+func acquireSlot(ctx context.Context, slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// errNotChecked reports why check was not started: the caller canceled ctx, or the shared
+// timeout ran out.
+func errNotChecked(ctx context.Context, check ReadinessCheck) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf(
+			"canceled before checking %s %s/%s: %w",
+			check.Type, check.Namespace, check.Name, ctx.Err(),
+		)
+	}
+
+	return errTimeoutExceeded(check.Type, check.Namespace, check.Name)
+}
+
 func errTimeoutExceeded(resourceType, namespace, name string) error {
 	return fmt.Errorf(
 		"%w before checking %s %s/%s",
@@ -0,0 +1,178 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWaitForMultipleResources(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SucceedsWhenAllReady", testWaitForMultipleResourcesReady)
+	t.Run("RejectsUnknownType", testWaitForMultipleResourcesUnknownType)
+	t.Run("FailFastReportsFirstFailure", testWaitForMultipleResourcesFailFast)
+	t.Run("CollectAllReportsEveryFailure", testWaitForMultipleResourcesCollectAll)
+	t.Run("PerResourceTimeout", testWaitForMultipleResourcesPerResourceTimeout)
+	t.Run("ConcurrentChecksShareTimeout", testWaitForMultipleResourcesConcurrent)
+	t.Run("ReportsCancellation", testWaitForMultipleResourcesCanceled)
+}
+
+func testWaitForMultipleResourcesCanceled(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "api"},
+		{Type: "daemonset", Namespace: "default", Name: "agent"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := k8s.WaitForMultipleResources(ctx, client, checks, time.Minute, k8s.WithFailFast(false))
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotErrorIs(t, err, k8s.ErrTimeoutExceeded)
+}
+
+func testWaitForMultipleResourcesReady(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(readyDeployment("api"), readyDaemonSet("agent"))
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "api"},
+		{Type: "daemonset", Namespace: "default", Name: "agent"},
+	}
+
+	err := k8s.WaitForMultipleResources(context.Background(), client, checks, time.Second)
+
+	require.NoError(t, err)
+}
+
+func testWaitForMultipleResourcesUnknownType(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	checks := []k8s.ReadinessCheck{{Type: "statefulset", Namespace: "default", Name: "db"}}
+
+	err := k8s.WaitForMultipleResources(context.Background(), client, checks, time.Second)
+
+	require.ErrorContains(t, err, "unknown resource type: statefulset")
+}
+
+func testWaitForMultipleResourcesFailFast(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(readyDeployment("api"))
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "missing"},
+		{Type: "deployment", Namespace: "default", Name: "also-missing"},
+	}
+
+	err := k8s.WaitForMultipleResources(context.Background(), client, checks, 100*time.Millisecond)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing deployment not ready")
+	assert.NotContains(t, err.Error(), "also-missing")
+}
+
+func testWaitForMultipleResourcesCollectAll(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(readyDeployment("api"))
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "missing"},
+		{Type: "deployment", Namespace: "default", Name: "api"},
+		{Type: "daemonset", Namespace: "default", Name: "also-missing"},
+	}
+
+	err := k8s.WaitForMultipleResources(
+		context.Background(),
+		client,
+		checks,
+		100*time.Millisecond,
+		k8s.WithMaxConcurrency(len(checks)),
+		k8s.WithFailFast(false),
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing deployment not ready")
+	assert.Contains(t, err.Error(), "also-missing daemonset not ready")
+	assert.NotContains(t, err.Error(), "api deployment")
+}
+
+func testWaitForMultipleResourcesPerResourceTimeout(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "missing", Timeout: 50 * time.Millisecond},
+	}
+
+	start := time.Now()
+	err := k8s.WaitForMultipleResources(context.Background(), client, checks, 5*time.Second)
+
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func testWaitForMultipleResourcesConcurrent(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+
+	client := fake.NewSimpleClientset()
+	checks := []k8s.ReadinessCheck{
+		{Type: "deployment", Namespace: "default", Name: "first"},
+		{Type: "deployment", Namespace: "default", Name: "second"},
+		{Type: "deployment", Namespace: "default", Name: "third"},
+	}
+
+	start := time.Now()
+	err := k8s.WaitForMultipleResources(
+		context.Background(),
+		client,
+		checks,
+		200*time.Millisecond,
+		k8s.WithMaxConcurrency(len(checks)),
+		k8s.WithFailFast(false),
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "third deployment not ready")
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func readyDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			AvailableReplicas: 1,
+		},
+	}
+}
+
+func readyDaemonSet(name string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 1,
+			UpdatedNumberScheduled: 1,
+		},
+	}
+}
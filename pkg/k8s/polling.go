@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -11,14 +12,40 @@ import (
 const (
 	// Polling configuration.
 
-	// readinessPollInterval is the interval between readiness checks.
-	readinessPollInterval = 2 * time.Second
+	// DefaultPollInterval is the delay before the second readiness check.
+	DefaultPollInterval = 500 * time.Millisecond
+	// DefaultPollMaxInterval caps the delay between readiness checks during long waits.
+	DefaultPollMaxInterval = 10 * time.Second
+
+	// readinessPollFactor multiplies the delay after every check that is not ready.
+	readinessPollFactor = 2.0
+	// readinessPollJitter spreads checks by up to this fraction of the delay, so concurrent
+	// waits do not hit the API server in lockstep.
+	readinessPollJitter = 0.2
 )
 
+// PollOption configures PollForReadiness.
+type PollOption func(*wait.Backoff)
+
+// WithPollInterval sets the delay before the second readiness check.
+func WithPollInterval(interval time.Duration) PollOption {
+	return func(backoff *wait.Backoff) {
+		backoff.Duration = interval
+	}
+}
+
+// WithPollMaxInterval caps the delay between readiness checks.
+func WithPollMaxInterval(maxInterval time.Duration) PollOption {
+	return func(backoff *wait.Backoff) {
+		backoff.Cap = maxInterval
+	}
+}
+
 // PollForReadiness polls a check function until ready or timeout.
 //
-// This function repeatedly calls the provided poll function at regular intervals
-// until either:
+// This function calls the provided poll function immediately and then with an
+// exponentially growing, jittered delay capped at DefaultPollMaxInterval (see
+// WithPollInterval and WithPollMaxInterval), until either:
 //   - The poll function returns (true, nil) indicating readiness
 //   - The deadline is exceeded
 //   - The poll function returns an error
@@ -31,14 +58,23 @@ func PollForReadiness(
 	ctx context.Context,
 	deadline time.Duration,
 	poll func(context.Context) (bool, error),
+	opts ...PollOption,
 ) error {
-	pollErr := wait.PollUntilContextTimeout(
-		ctx,
-		readinessPollInterval,
-		deadline,
-		true,
-		poll,
-	)
+	backoff := wait.Backoff{
+		Duration: DefaultPollInterval,
+		Factor:   readinessPollFactor,
+		Jitter:   readinessPollJitter,
+		Steps:    math.MaxInt32,
+		Cap:      DefaultPollMaxInterval,
+	}
+	for _, opt := range opts {
+		opt(&backoff)
+	}
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	pollErr := backoff.DelayFunc().Until(deadlineCtx, true, false, poll)
 	if pollErr != nil {
 		return fmt.Errorf("failed to poll for readiness: %w", pollErr)
 	}
@@ -0,0 +1,186 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const loopbackAddress = "127.0.0.1"
+
+var (
+	// ErrNoReadyPod is returned when no running pod backs a forwarded service.
+	ErrNoReadyPod = errors.New("no running pod found")
+	// ErrServicePortNotFound is returned when a forwarded service does not expose the port.
+	ErrServicePortNotFound = errors.New("service port not found")
+)
+
+// PortForward forwards a local port to a service until Close is called.
+type PortForward struct {
+	// LocalURL is the base URL of the service on the host, e.g. http://127.0.0.1:51234.
+	LocalURL string
+
+	stopCh chan struct{}
+}
+
+// Close stops forwarding.
+func (p *PortForward) Close() {
+	close(p.stopCh)
+}
+
+// ForwardService forwards a free local port to a running pod behind port of a service, like
+// `kubectl port-forward svc/<name>` does.
+func ForwardService(
+	ctx context.Context,
+	restConfig *rest.Config,
+	namespace, name string,
+	port int,
+) (*PortForward, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	pod, targetPort, err := resolveServiceBackend(ctx, clientset, namespace, name, port)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create port-forward transport: %w", err)
+	}
+
+	url := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+
+	forwarder, err := portforward.NewOnAddresses(
+		dialer,
+		[]string{loopbackAddress},
+		[]string{"0:" + strconv.Itoa(targetPort)},
+		stopCh,
+		readyCh,
+		io.Discard,
+		io.Discard,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create port-forward: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err = <-errCh:
+		return nil, fmt.Errorf("forward port of service %s/%s: %w", namespace, name, err)
+	case <-ctx.Done():
+		close(stopCh)
+
+		return nil, fmt.Errorf("forward port of service %s/%s: %w", namespace, name, ctx.Err())
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stopCh)
+
+		return nil, fmt.Errorf("resolve forwarded port of service %s/%s: %w", namespace, name, err)
+	}
+
+	return &PortForward{
+		LocalURL: fmt.Sprintf("http://%s:%d", loopbackAddress, ports[0].Local),
+		stopCh:   stopCh,
+	}, nil
+}
+
+// resolveServiceBackend returns a running pod selected by the service and the container port
+// its port targets.
+func resolveServiceBackend(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace, name string,
+	port int,
+) (string, int, error) {
+	service, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("get service %s/%s: %w", namespace, name, err)
+	}
+
+	var target *intstr.IntOrString
+
+	for i := range service.Spec.Ports {
+		if int(service.Spec.Ports[i].Port) == port {
+			target = &service.Spec.Ports[i].TargetPort
+		}
+	}
+
+	if target == nil {
+		return "", 0, fmt.Errorf("%w: %s/%s:%d", ErrServicePortNotFound, namespace, name, port)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("list pods of service %s/%s: %w", namespace, name, err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		containerPort, ok := resolveContainerPort(pod, *target, port)
+		if ok {
+			return pod.Name, containerPort, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: %s/%s", ErrNoReadyPod, namespace, name)
+}
+
+// resolveContainerPort resolves a service target port against the ports declared by pod. An
+// unset target port targets the service port itself.
+func resolveContainerPort(pod *corev1.Pod, target intstr.IntOrString, servicePort int) (int, bool) {
+	if target.Type == intstr.Int {
+		if target.IntValue() == 0 {
+			return servicePort, true
+		}
+
+		return target.IntValue(), true
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == target.StrVal {
+				return int(port.ContainerPort), true
+			}
+		}
+	}
+
+	return 0, false
+}
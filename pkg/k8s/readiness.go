@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Readiness is the state of a resource at the time it was checked.
+type Readiness string
+
+const (
+	// ReadinessReady means the resource exists and is ready.
+	ReadinessReady Readiness = "ready"
+	// ReadinessDegraded means the resource exists but is not ready.
+	ReadinessDegraded Readiness = "degraded"
+	// ReadinessMissing means the resource does not exist.
+	ReadinessMissing Readiness = "missing"
+)
+
+// CheckReadiness reports the readiness of the resource described by check without waiting,
+// using the criteria of WaitForDeploymentReady and WaitForDaemonSetReady.
+//
+// Returns an error for unknown resource types and API errors other than NotFound.
+func CheckReadiness(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	check ReadinessCheck,
+) (Readiness, error) {
+	var (
+		ready bool
+		err   error
+	)
+
+	switch check.Type {
+	case "deployment":
+		var deployment *appsv1.Deployment
+
+		deployment, err = clientset.AppsV1().Deployments(check.Namespace).Get(ctx, check.Name, metav1.GetOptions{})
+		if err == nil {
+			ready = deploymentReady(deployment)
+		}
+	case "daemonset":
+		var daemonSet *appsv1.DaemonSet
+
+		daemonSet, err = clientset.AppsV1().DaemonSets(check.Namespace).Get(ctx, check.Name, metav1.GetOptions{})
+		if err == nil {
+			ready = daemonSetReady(daemonSet)
+		}
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownResourceType, check.Type)
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return ReadinessMissing, nil
+	case err != nil:
+		return "", fmt.Errorf("failed to get %s %s/%s: %w", check.Type, check.Namespace, check.Name, err)
+	case ready:
+		return ReadinessReady, nil
+	default:
+		return ReadinessDegraded, nil
+	}
+}
+
+func deploymentReady(deployment *appsv1.Deployment) bool {
+	return deployment.Status.Replicas > 0 &&
+		deployment.Status.UpdatedReplicas >= deployment.Status.Replicas &&
+		deployment.Status.AvailableReplicas >= deployment.Status.Replicas
+}
+
+func daemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.Status.DesiredNumberScheduled > 0 &&
+		daemonSet.Status.NumberUnavailable == 0 &&
+		daemonSet.Status.UpdatedNumberScheduled == daemonSet.Status.DesiredNumberScheduled
+}
@@ -0,0 +1,75 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckReadiness(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium-operator", Namespace: "kube-system"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium", Namespace: "kube-system"},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 2,
+				NumberUnavailable:      1,
+				UpdatedNumberScheduled: 2,
+			},
+		},
+	)
+
+	tests := []struct {
+		name     string
+		check    k8s.ReadinessCheck
+		expected k8s.Readiness
+	}{
+		{
+			name:     "ready deployment",
+			check:    k8s.ReadinessCheck{Type: "deployment", Namespace: "kube-system", Name: "cilium-operator"},
+			expected: k8s.ReadinessReady,
+		},
+		{
+			name:     "degraded daemonset",
+			check:    k8s.ReadinessCheck{Type: "daemonset", Namespace: "kube-system", Name: "cilium"},
+			expected: k8s.ReadinessDegraded,
+		},
+		{
+			name:     "missing deployment",
+			check:    k8s.ReadinessCheck{Type: "deployment", Namespace: "kube-system", Name: "metrics-server"},
+			expected: k8s.ReadinessMissing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			readiness, err := k8s.CheckReadiness(context.Background(), clientset, test.check)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, readiness)
+		})
+	}
+}
+
+func TestCheckReadinessRejectsUnknownType(t *testing.T) {
+	t.Parallel()
+
+	_, err := k8s.CheckReadiness(
+		context.Background(),
+		fake.NewClientset(),
+		k8s.ReadinessCheck{Type: "statefulset", Namespace: "vault", Name: "vault"},
+	)
+	require.ErrorContains(t, err, "unknown resource type: statefulset")
+}
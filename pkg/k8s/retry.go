@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultRetryAttempts is how many times an operation failing with a transient error is
+	// tried when no policy is configured.
+	DefaultRetryAttempts = 3
+	// DefaultRetryInterval is the delay before the first retry when no policy is configured.
+	DefaultRetryInterval = 2 * time.Second
+	// DefaultRetryMaxInterval caps the delay between retries.
+	DefaultRetryMaxInterval = time.Minute
+
+	retryFactor = 2.0
+	retryJitter = 0.2
+)
+
+// transientMessages are fragments of errors that lost their type on the way up, e.g. through
+// Helm or registry clients, but describe a failure that may not repeat.
+//
+//nolint:gochecknoglobals // Fixed list of error fragments.
+var transientMessages = []string{
+	"connection refused",
+	"connection reset by peer",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"429 too many requests",
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+// RetryPolicy sets how Retry retries an operation.
+type RetryPolicy struct {
+	// Attempts is how many times the operation is tried. Values below one are treated as one.
+	Attempts int
+	// Interval is the delay before the first retry. It doubles for every further retry, up to
+	// DefaultRetryMaxInterval, and is spread by jitter.
+	Interval time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Attempts: DefaultRetryAttempts, Interval: DefaultRetryInterval}
+}
+
+// Retry runs operation until it succeeds, fails with an error that IsTransientError does not
+// recognize, the attempts of policy are used up, or ctx ends.
+//
+// Returns the error of the last attempt, noting the number of attempts when there were several.
+func Retry(ctx context.Context, policy RetryPolicy, operation func(context.Context) error) error {
+	attempts := max(policy.Attempts, 1)
+	backoff := wait.Backoff{
+		Duration: policy.Interval,
+		Factor:   retryFactor,
+		Jitter:   retryJitter,
+		Steps:    attempts,
+		Cap:      DefaultRetryMaxInterval,
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := operation(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= attempts || !IsTransientError(err) {
+			if attempt > 1 {
+				return fmt.Errorf("after %d attempts: %w", attempt, err)
+			}
+
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("after %d attempts: %w", attempt, err)
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// IsTransientError reports whether err is a failure that may not repeat: an overloaded or
+// restarting API server, a dropped or timed out connection, or a registry that rate limits or
+// answers with a gateway error. Cancelled and expired contexts are not transient.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range transientMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
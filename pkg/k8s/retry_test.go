@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var errChartNotFound = errors.New("chart not found")
+
+func TestRetryRetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	err := k8s.Retry(context.Background(), k8s.RetryPolicy{Attempts: 3, Interval: time.Millisecond},
+		func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("fetch index: %w", syscall.ECONNRESET)
+			}
+
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryGivesUpAfterAttempts(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	unavailable := apierrors.NewServiceUnavailable("apiserver is restarting")
+
+	err := k8s.Retry(context.Background(), k8s.RetryPolicy{Attempts: 2, Interval: time.Millisecond},
+		func(context.Context) error {
+			calls++
+
+			return unavailable
+		})
+
+	require.ErrorIs(t, err, unavailable)
+	assert.ErrorContains(t, err, "after 2 attempts")
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetryReturnsPermanentErrorsImmediately(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	err := k8s.Retry(context.Background(), k8s.DefaultRetryPolicy(), func(context.Context) error {
+		calls++
+
+		return errChartNotFound
+	})
+
+	require.Equal(t, errChartNotFound, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{name: "nil", err: nil, transient: false},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), transient: true},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), transient: true},
+		{
+			name:      "registry gateway error",
+			err:       errors.New("failed to fetch https://helm.cilium.io/index.yaml : 503 Service Unavailable"),
+			transient: true,
+		},
+		{
+			name:      "not found",
+			err:       apierrors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "cilium-operator"),
+			transient: false,
+		},
+		{name: "deadline", err: fmt.Errorf("install: %w", context.DeadlineExceeded), transient: false},
+		{name: "permanent", err: errChartNotFound, transient: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.transient, k8s.IsTransientError(test.err))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ksail
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	coreinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/core"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+)
+
+// installComponents installs the CNI, metrics-server and Flux of clusterCfg in that order, as
+// the components need networking and Flux runs on top of them. The steps are the ones
+// `ksail cluster create` runs after it provisions the cluster.
+func installComponents(ctx context.Context, clusterCfg *v1alpha1.Cluster, o *options) error {
+	kubeconfig, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("resolve kubeconfig: %w", err)
+	}
+
+	_, err = os.Stat(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("access kubeconfig: %w", err)
+	}
+
+	activity := func(content string) {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: content,
+			Writer:  o.output,
+		})
+	}
+
+	cniName, err := coreinstaller.CNIName(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("install cni: %w", err)
+	}
+
+	if cniName != "" {
+		err = installComponent(
+			clusterCfg, kubeconfig, coreinstaller.ComponentCNI,
+			func(client helm.Interface) error {
+				return coreinstaller.InstallCNI(ctx, client, kubeconfig, clusterCfg, activity)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("install cni: %w", err)
+		}
+	}
+
+	if coreinstaller.NeedsMetricsServer(clusterCfg) {
+		err = installComponent(
+			clusterCfg, kubeconfig, coreinstaller.ComponentMetricsServer,
+			func(client helm.Interface) error {
+				return coreinstaller.InstallMetricsServer(ctx, client, kubeconfig, clusterCfg, activity)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("install metrics-server: %w", err)
+		}
+	}
+
+	if clusterCfg.Spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
+		err = installComponent(
+			clusterCfg, kubeconfig, coreinstaller.ComponentFlux,
+			func(client helm.Interface) error {
+				fluxInstaller := coreinstaller.NewFluxInstaller(client, clusterCfg)
+
+				return coreinstaller.InstallFlux(ctx, fluxInstaller, kubeconfig, clusterCfg, activity)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("install flux: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// installComponent runs install with a Helm client that retries as spec.options.install sets
+// for component.
+func installComponent(
+	clusterCfg *v1alpha1.Cluster,
+	kubeconfig, component string,
+	install func(client helm.Interface) error,
+) error {
+	helmClient, err := coreinstaller.NewHelmClient(clusterCfg, kubeconfig, component)
+	if err != nil {
+		return err //nolint:wrapcheck // installComponents wraps the error with the component.
+	}
+
+	return install(helmClient)
+}
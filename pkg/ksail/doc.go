@@ -0,0 +1,19 @@
+// Package ksail is the embeddable Go API of KSail.
+//
+// It lets Go tools and test harnesses drive KSail in-process instead of shelling out to the
+// CLI. Each function takes a KSail cluster configuration, the same v1alpha1.Cluster that
+// ksail.yaml holds, and a set of options, and calls the cluster provisioners and component
+// installers directly:
+//
+//   - Create provisions the cluster and installs the CNI, metrics-server and Flux it enables
+//   - Delete, Start and Stop delete, start and stop the cluster
+//   - Apply applies the manifests in the source directory, like `ksail workload apply`
+//   - Status reports whether the cluster exists and how to reach it
+//
+// Registries, Docker networks and the optional components of `ksail cluster create` are left
+// to the CLI.
+//
+// The configuration is used in place of ksail.yaml; defaults and validation apply as they do
+// for the CLI. Relative paths in it, such as the distribution config and the source directory,
+// resolve against the process's working directory.
+package ksail
@@ -0,0 +1,276 @@
+package ksail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/kubectl"
+	cmdhelpers "github.com/devantler-tech/ksail-go/pkg/cmd"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	"github.com/devantler-tech/ksail-go/pkg/svc/applycache"
+	coreinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/core"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var (
+	// ErrNilConfig is returned when an SDK function is called without a cluster configuration.
+	ErrNilConfig = errors.New("cluster configuration is required")
+	// ErrNilProvisioner is returned when the provisioner factory resolves no provisioner.
+	ErrNilProvisioner = errors.New("provisioner factory returned no provisioner")
+	// ErrUnsupportedCNI is returned when Create is asked to install a CNI it does not know.
+	ErrUnsupportedCNI = coreinstaller.ErrUnsupportedCNI
+)
+
+// ClusterStatus describes a cluster as Status found it.
+type ClusterStatus struct {
+	// Name is the cluster name, resolved from spec.clusterName or the distribution config.
+	Name string
+	// Distribution is the distribution the cluster runs.
+	Distribution v1alpha1.Distribution
+	// Kubeconfig is the kubeconfig file the cluster's context is written to.
+	Kubeconfig string
+	// Context is the kubeconfig context that targets the cluster.
+	Context string
+	// Exists reports whether the cluster has been created.
+	Exists bool
+}
+
+// Create provisions the cluster described by cfg and installs the CNI, metrics-server and
+// Flux the configuration enables.
+func Create(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) error {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return err
+	}
+
+	err = runProvisioner(ctx, clusterCfg, o, clusterprovisioner.ClusterProvisioner.Create)
+	if err != nil {
+		return fmt.Errorf("create cluster: %w", err)
+	}
+
+	if !o.components || clusterCfg.Spec.Distribution.IsSimulated() {
+		return nil
+	}
+
+	return installComponents(ctx, clusterCfg, o)
+}
+
+// Delete deletes the cluster described by cfg.
+func Delete(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) error {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return err
+	}
+
+	err = runProvisioner(ctx, clusterCfg, o, clusterprovisioner.ClusterProvisioner.Delete)
+	if err != nil {
+		return fmt.Errorf("delete cluster: %w", err)
+	}
+
+	return nil
+}
+
+// Start starts the stopped cluster described by cfg.
+func Start(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) error {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return err
+	}
+
+	err = runProvisioner(ctx, clusterCfg, o, clusterprovisioner.ClusterProvisioner.Start)
+	if err != nil {
+		return fmt.Errorf("start cluster: %w", err)
+	}
+
+	return nil
+}
+
+// Stop stops the running cluster described by cfg.
+func Stop(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) error {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return err
+	}
+
+	err = runProvisioner(ctx, clusterCfg, o, clusterprovisioner.ClusterProvisioner.Stop)
+	if err != nil {
+		return fmt.Errorf("stop cluster: %w", err)
+	}
+
+	return nil
+}
+
+// Apply applies the manifests in the configuration's source directory to the cluster. A
+// directory with a kustomization is built with Kustomize; any other directory is applied
+// recursively. With WithSkipUnchanged, resources whose manifest and live object are unchanged
+// since they were last applied are skipped.
+func Apply(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) error {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigPath, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("resolve kubeconfig: %w", err)
+	}
+
+	client := kubectl.NewClient(
+		genericiooptions.IOStreams{In: nil, Out: o.output, ErrOut: o.output},
+		kubectl.WithPluginMode(false),
+		kubectl.WithContext(clusterCfg.Spec.Connection.Context),
+		kubectl.WithApplyCache(
+			applycache.NewStore(filepath.Join(ksailconfigmanager.ProjectDir(""), applycache.DefaultPath)),
+			applycache.Hash,
+		),
+	)
+
+	applyCmd := client.CreateApplyCommand(kubeconfigPath)
+	applyCmd.SetArgs(applyArgs(clusterCfg.Spec.SourceDirectory, o.skipUnchanged))
+	applyCmd.SetOut(o.output)
+	applyCmd.SetErr(o.output)
+	applyCmd.SilenceUsage = true
+	applyCmd.SilenceErrors = true
+
+	err = applyCmd.ExecuteContext(ctx)
+	if err != nil {
+		return fmt.Errorf("apply manifests: %w", err)
+	}
+
+	return nil
+}
+
+// Status reports whether the cluster described by cfg exists, along with the kubeconfig and
+// context that reach it.
+func Status(ctx context.Context, cfg *v1alpha1.Cluster, opts ...Option) (*ClusterStatus, error) {
+	o := newOptions(opts)
+
+	clusterCfg, err := loadConfig(cfg, o)
+	if err != nil {
+		return nil, err
+	}
+
+	provisioner, name, err := resolveProvisioner(ctx, clusterCfg, o)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigPath, err := cmdhelpers.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return nil, fmt.Errorf("resolve kubeconfig: %w", err)
+	}
+
+	exists, err := provisioner.Exists(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("check cluster existence: %w", err)
+	}
+
+	return &ClusterStatus{
+		Name:         name,
+		Distribution: clusterCfg.Spec.Distribution,
+		Kubeconfig:   kubeconfigPath,
+		Context:      clusterCfg.Spec.Connection.Context,
+		Exists:       exists,
+	}, nil
+}
+
+// runProvisioner resolves the provisioner of clusterCfg and runs action against the cluster.
+func runProvisioner(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	o *options,
+	action func(clusterprovisioner.ClusterProvisioner, context.Context, string) error,
+) error {
+	provisioner, name, err := resolveProvisioner(ctx, clusterCfg, o)
+	if err != nil {
+		return err
+	}
+
+	//nolint:wrapcheck // Callers wrap the error with the operation that failed.
+	return action(provisioner, ctx, name)
+}
+
+// resolveProvisioner returns the provisioner of clusterCfg and the name of its cluster.
+//
+//nolint:ireturn // Provisioners are resolved per distribution behind their interface.
+func resolveProvisioner(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	o *options,
+) (clusterprovisioner.ClusterProvisioner, string, error) {
+	provisioner, distributionConfig, err := o.factory.Create(ctx, clusterCfg)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve provisioner: %w", err)
+	}
+
+	if provisioner == nil {
+		return nil, "", ErrNilProvisioner
+	}
+
+	name, err := configmanager.GetClusterName(distributionConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve cluster name: %w", err)
+	}
+
+	return provisioner, name, nil
+}
+
+// loadConfig applies the CLI's defaults and validation to cfg. Validation problems are written
+// to the output of o.
+func loadConfig(cfg *v1alpha1.Cluster, o *options) (*v1alpha1.Cluster, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	manager := ksailconfigmanager.NewConfigManager(
+		o.output,
+		ksailconfigmanager.DefaultClusterFieldSelectors()...,
+	)
+	manager.UseConfig(cfg)
+
+	clusterCfg, err := manager.LoadConfigSilent()
+	if err != nil {
+		return nil, fmt.Errorf("load cluster configuration: %w", err)
+	}
+
+	return clusterCfg, nil
+}
+
+func applyArgs(sourceDir string, skipUnchanged bool) []string {
+	if sourceDir == "" {
+		sourceDir = v1alpha1.DefaultSourceDirectory
+	}
+
+	args := []string{"-f", sourceDir, "--recursive"}
+
+	for _, name := range []string{"kustomization.yaml", "kustomization.yml", "Kustomization"} {
+		_, err := os.Stat(filepath.Join(sourceDir, name))
+		if err == nil {
+			args = []string{"-k", sourceDir}
+
+			break
+		}
+	}
+
+	if skipUnchanged {
+		args = append(args, "--skip-unchanged")
+	}
+
+	return args
+}
@@ -0,0 +1,132 @@
+package ksail_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/io/config-manager/helpers"
+	"github.com/devantler-tech/ksail-go/pkg/ksail"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestFunctionsRejectNilConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	require.ErrorIs(t, ksail.Create(ctx, nil), ksail.ErrNilConfig)
+	require.ErrorIs(t, ksail.Delete(ctx, nil), ksail.ErrNilConfig)
+	require.ErrorIs(t, ksail.Start(ctx, nil), ksail.ErrNilConfig)
+	require.ErrorIs(t, ksail.Stop(ctx, nil), ksail.ErrNilConfig)
+	require.ErrorIs(t, ksail.Apply(ctx, nil), ksail.ErrNilConfig)
+
+	_, err := ksail.Status(ctx, nil)
+	require.ErrorIs(t, err, ksail.ErrNilConfig)
+}
+
+func TestCreateRejectsInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := v1alpha1.NewCluster()
+	cfg.Spec.Distribution = "Unknown"
+
+	var out bytes.Buffer
+
+	err := ksail.Create(context.Background(), cfg, ksail.WithOutput(&out))
+
+	var validationErr *helpers.ValidationSummaryError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, out.String(), "invalid distribution value")
+	assert.Equal(t, v1alpha1.Distribution("Unknown"), cfg.Spec.Distribution)
+}
+
+//nolint:paralleltest // Uses t.Chdir so the cluster name resolves against a known directory.
+func TestCreateProvisionsCluster(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	provisioner.EXPECT().Create(mock.Anything, "dev").Return(nil)
+
+	err := ksail.Create(
+		context.Background(),
+		kindCluster(),
+		ksail.WithProvisionerFactory(factoryFor(t, provisioner)),
+		ksail.WithComponents(false),
+	)
+	require.NoError(t, err)
+}
+
+//nolint:paralleltest // Uses t.Chdir so the cluster name resolves against a known directory.
+func TestLifecycleFunctionsCallProvisioner(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	errProvisioner := errors.New("provisioner failed")
+
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	provisioner.EXPECT().Start(mock.Anything, "dev").Return(nil)
+	provisioner.EXPECT().Stop(mock.Anything, "dev").Return(nil)
+	provisioner.EXPECT().Delete(mock.Anything, "dev").Return(errProvisioner)
+
+	factory := ksail.WithProvisionerFactory(factoryFor(t, provisioner))
+	ctx := context.Background()
+
+	require.NoError(t, ksail.Start(ctx, kindCluster(), factory))
+	require.NoError(t, ksail.Stop(ctx, kindCluster(), factory))
+	require.ErrorIs(t, ksail.Delete(ctx, kindCluster(), factory), errProvisioner)
+}
+
+//nolint:paralleltest // Uses t.Chdir so the cluster name resolves against a known directory.
+func TestStatusReportsCluster(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cfg := kindCluster()
+	cfg.Spec.Connection.Kubeconfig = "/tmp/kubeconfig"
+
+	provisioner := clusterprovisioner.NewMockClusterProvisioner(t)
+	provisioner.EXPECT().Exists(mock.Anything, "dev").Return(true, nil)
+
+	status, err := ksail.Status(
+		context.Background(),
+		cfg,
+		ksail.WithProvisionerFactory(factoryFor(t, provisioner)),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, &ksail.ClusterStatus{
+		Name:         "dev",
+		Distribution: v1alpha1.DistributionKind,
+		Kubeconfig:   "/tmp/kubeconfig",
+		Context:      "kind-dev",
+		Exists:       true,
+	}, status)
+	assert.Empty(t, cfg.Spec.Connection.Context, "the caller's config is not modified")
+}
+
+func kindCluster() *v1alpha1.Cluster {
+	cfg := v1alpha1.NewCluster()
+	cfg.Spec.Distribution = v1alpha1.DistributionKind
+	cfg.Spec.ClusterName = "dev"
+
+	return cfg
+}
+
+func factoryFor(
+	t *testing.T,
+	provisioner clusterprovisioner.ClusterProvisioner,
+) *clusterprovisioner.MockFactory {
+	t.Helper()
+
+	factory := clusterprovisioner.NewMockFactory(t)
+	factory.EXPECT().
+		Create(mock.Anything, mock.Anything).
+		Return(provisioner, &v1alpha4.Cluster{Name: "dev"}, nil)
+
+	return factory
+}
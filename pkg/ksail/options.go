@@ -0,0 +1,68 @@
+package ksail
+
+import (
+	"io"
+
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+)
+
+// Option customizes a single SDK call.
+type Option func(*options)
+
+type options struct {
+	output        io.Writer
+	components    bool
+	skipUnchanged bool
+	factory       clusterprovisioner.Factory
+}
+
+func newOptions(opts []Option) *options {
+	resolved := &options{
+		output:     io.Discard,
+		components: true,
+		factory:    clusterprovisioner.DefaultFactory{},
+	}
+
+	for _, opt := range opts {
+		opt(resolved)
+	}
+
+	return resolved
+}
+
+// WithOutput writes progress messages, such as the component being installed, and validation
+// problems of the configuration to writer. Output is discarded by default.
+func WithOutput(writer io.Writer) Option {
+	return func(o *options) {
+		if writer != nil {
+			o.output = writer
+		}
+	}
+}
+
+// WithComponents controls whether Create installs the CNI, metrics-server and Flux after it
+// provisions the cluster. Components are installed by default.
+func WithComponents(enabled bool) Option {
+	return func(o *options) {
+		o.components = enabled
+	}
+}
+
+// WithSkipUnchanged controls whether Apply skips resources whose manifest and live object are
+// unchanged since they were last applied, as `ksail workload apply --skip-unchanged` does. Every
+// resource is applied by default.
+func WithSkipUnchanged(enabled bool) Option {
+	return func(o *options) {
+		o.skipUnchanged = enabled
+	}
+}
+
+// WithProvisionerFactory resolves cluster provisioners through factory instead of the
+// provisioners of the configured distribution.
+func WithProvisionerFactory(factory clusterprovisioner.Factory) Option {
+	return func(o *options) {
+		if factory != nil {
+			o.factory = factory
+		}
+	}
+}
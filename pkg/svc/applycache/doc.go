@@ -0,0 +1,7 @@
+// Package applycache remembers what `ksail workload apply` last applied to each cluster.
+//
+// A Store keeps a content hash per resource, grouped by cluster, in a JSON file under the
+// project's .ksail directory. With --skip-unchanged, apply compares the hash of every rendered
+// manifest and the revision of its live object with the stored ones and only sends the
+// resources that changed, turning reapplies of unchanged projects into no-ops.
+package applycache
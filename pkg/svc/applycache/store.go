@@ -0,0 +1,160 @@
+package applycache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+)
+
+// DefaultPath is where the apply cache is kept, relative to the project directory.
+const DefaultPath = ".ksail/apply-cache.json"
+
+// storeVersion is bumped when the file layout or hash input changes, which discards
+// older caches instead of trusting them.
+const storeVersion = 2
+
+const (
+	stateDirPerm  = 0o750
+	stateFilePerm = 0o600
+)
+
+type storeFile struct {
+	Version  int                          `json:"version"`
+	Clusters map[string]map[string]string `json:"clusters"`
+}
+
+// Store is a file-backed cache of applied manifest hashes. It loads lazily on first use and
+// is safe for concurrent use.
+type Store struct {
+	path     string
+	mutex    sync.Mutex
+	loaded   bool
+	clusters map[string]map[string]string
+}
+
+// NewStore creates a Store backed by the file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Hash returns the content hash stored for a manifest.
+func Hash(manifest []byte) string {
+	sum := sha256.Sum256(manifest)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Unchanged reports whether key was last applied to cluster with the given hash.
+func (s *Store) Unchanged(cluster, key, hash string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return false, err
+	}
+
+	stored, ok := s.clusters[cluster][key]
+
+	return ok && stored == hash, nil
+}
+
+// Record stores hash as the last applied content of key in cluster. Call Save to persist it.
+func (s *Store) Record(cluster, key, hash string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if s.clusters[cluster] == nil {
+		s.clusters[cluster] = map[string]string{}
+	}
+
+	s.clusters[cluster][key] = hash
+
+	return nil
+}
+
+// Forget drops every hash recorded for cluster, so its next apply sends all resources.
+func (s *Store) Forget(cluster string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	delete(s.clusters, cluster)
+
+	return nil
+}
+
+// Save writes the cache to disk, creating its directory when needed.
+func (s *Store) Save() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(storeFile{Version: storeVersion, Clusters: s.clusters}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode apply cache: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.path), stateDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create apply cache directory: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(s.path, append(data, '\n'), stateFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write apply cache: %w", err)
+	}
+
+	return nil
+}
+
+// load reads the cache file once. A missing, unreadable-as-JSON or outdated file starts an
+// empty cache, since the worst outcome is reapplying everything.
+func (s *Store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	s.clusters = map[string]map[string]string{}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.loaded = true
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to read apply cache: %w", err)
+	}
+
+	var file storeFile
+
+	if json.Unmarshal(data, &file) == nil && file.Version == storeVersion && file.Clusters != nil {
+		s.clusters = file.Clusters
+	}
+
+	s.loaded = true
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package applycache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/applycache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".ksail", "apply-cache.json")
+	hash := applycache.Hash([]byte(`{"kind":"ConfigMap"}`))
+
+	store := applycache.NewStore(path)
+
+	unchanged, err := store.Unchanged("cluster-a", "/ConfigMap/default/app", hash)
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+
+	require.NoError(t, store.Record("cluster-a", "/ConfigMap/default/app", hash))
+	require.NoError(t, store.Save())
+
+	reloaded := applycache.NewStore(path)
+
+	unchanged, err = reloaded.Unchanged("cluster-a", "/ConfigMap/default/app", hash)
+	require.NoError(t, err)
+	assert.True(t, unchanged)
+
+	unchanged, err = reloaded.Unchanged("cluster-b", "/ConfigMap/default/app", hash)
+	require.NoError(t, err)
+	assert.False(t, unchanged, "hashes are scoped to the cluster they were applied to")
+
+	unchanged, err = reloaded.Unchanged("cluster-a", "/ConfigMap/default/app", applycache.Hash([]byte("{}")))
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+
+	require.NoError(t, reloaded.Forget("cluster-a"))
+
+	unchanged, err = reloaded.Unchanged("cluster-a", "/ConfigMap/default/app", hash)
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+}
+
+func TestStoreIgnoresCorruptFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "apply-cache.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	unchanged, err := applycache.NewStore(path).Unchanged("cluster", "key", "hash")
+
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+}
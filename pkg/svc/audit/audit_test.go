@@ -0,0 +1,115 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStep = errors.New("step failed")
+
+func TestRecorderAppendsStepsAndRun(t *testing.T) {
+	t.Parallel()
+
+	log := audit.NewLog(filepath.Join(t.TempDir(), "ksail", "audit.log"))
+	recorder := audit.NewRecorder(log, "cluster.create", "ksail cluster create")
+	ctx := audit.WithRecorder(context.Background(), recorder)
+
+	audit.SetCluster(ctx, "dev")
+
+	require.NoError(t, audit.Track(ctx, audit.ActionRegistry, "creating mirror registries", func() error {
+		return nil
+	}))
+	require.ErrorIs(t, audit.Track(ctx, audit.ActionComponentInstall, "flux", func() error {
+		return errStep
+	}), errStep)
+
+	audit.AddResources(ctx, []string{"apps/Deployment/default/web"})
+	recorder.Finish(errStep)
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, audit.ActionRegistry, entries[0].Action)
+	assert.Equal(t, "creating mirror registries", entries[0].Target)
+	assert.Equal(t, audit.OutcomeSuccess, entries[0].Outcome)
+
+	assert.Equal(t, audit.ActionComponentInstall, entries[1].Action)
+	assert.Equal(t, audit.OutcomeFailure, entries[1].Outcome)
+	assert.Equal(t, errStep.Error(), entries[1].Error)
+
+	run := entries[2]
+	assert.Equal(t, "cluster.create", run.Action)
+	assert.Equal(t, "ksail cluster create", run.Command)
+	assert.Equal(t, []string{"apps/Deployment/default/web"}, run.Resources)
+	assert.Equal(t, audit.OutcomeFailure, run.Outcome)
+
+	for _, entry := range entries {
+		assert.Equal(t, "dev", entry.Cluster)
+		assert.Equal(t, run.RunID, entry.RunID)
+		assert.NotEmpty(t, entry.RunID)
+	}
+}
+
+func TestTrackWithoutRecorderRunsStep(t *testing.T) {
+	t.Parallel()
+
+	called := false
+
+	err := audit.Track(context.Background(), audit.ActionRegistry, "registry", func() error {
+		called = true
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestLogSkipsCorruptLinesAndKeepsAppending(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("{\"action\":\"cluster.delete\"}\n{\"act"), 0o600))
+
+	log := audit.NewLog(path)
+	require.NoError(t, log.Append(audit.Entry{Action: "cluster.create"}))
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "cluster.delete", entries[0].Action)
+
+	missing, err := audit.NewLog(filepath.Join(t.TempDir(), "missing.log")).Entries()
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestQueryFilter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	entries := []audit.Entry{
+		{Time: now.Add(-2 * time.Hour), Action: "cluster.create", Cluster: "dev", Outcome: audit.OutcomeSuccess},
+		{Time: now.Add(-time.Hour), Action: "clusters.other", Cluster: "dev", Outcome: audit.OutcomeSuccess},
+		{Time: now.Add(-time.Minute), Action: "cluster.delete", Cluster: "dev", Outcome: audit.OutcomeFailure},
+		{Time: now, Action: "cluster.delete", Cluster: "prod", Outcome: audit.OutcomeSuccess},
+	}
+
+	assert.Len(t, audit.Query{Action: "cluster"}.Filter(entries), 3)
+	assert.Len(t, audit.Query{Action: "cluster", Cluster: "dev"}.Filter(entries), 2)
+	assert.Len(t, audit.Query{Outcome: audit.OutcomeFailure}.Filter(entries), 1)
+	assert.Len(t, audit.Query{Since: now.Add(-90 * time.Minute)}.Filter(entries), 3)
+
+	latest := audit.Query{Limit: 2}.Filter(entries)
+	require.Len(t, latest, 2)
+	assert.Equal(t, "prod", latest[1].Cluster)
+}
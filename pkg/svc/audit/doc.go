@@ -0,0 +1,8 @@
+// Package audit records the mutating actions KSail performs in an append-only local log.
+//
+// Every audited command run appends one entry describing the command, the cluster it
+// targeted, the resources it applied and its outcome, preceded by one entry per step it took
+// along the way, such as a registry being created or a component being installed. Entries of
+// one run share a run ID. The log is a JSON Lines file in the user's configuration directory,
+// read back by `ksail history`.
+package audit
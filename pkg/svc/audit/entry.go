@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// Actions recorded for the steps of a command run. Command runs themselves are recorded with
+// the action their command is marked with.
+const (
+	ActionRegistry           = "registry"
+	ActionComponentInstall   = "component.install"
+	ActionComponentUninstall = "component.uninstall"
+)
+
+// Outcome is the result of an audited action.
+type Outcome string
+
+const (
+	// OutcomeSuccess marks an action that completed.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure marks an action that returned an error.
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	RunID      string    `json:"runId,omitempty"`
+	Action     string    `json:"action"`
+	Target     string    `json:"target,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	Cluster    string    `json:"cluster,omitempty"`
+	Resources  []string  `json:"resources,omitempty"`
+	Outcome    Outcome   `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"durationMs"`
+}
+
+// Query selects entries from the audit log. Zero fields match every entry.
+type Query struct {
+	// Action matches entries whose action equals it or starts with it followed by a dot, so
+	// "cluster" matches "cluster.create".
+	Action  string
+	Cluster string
+	Outcome Outcome
+	Since   time.Time
+	// Limit keeps only the most recent matching entries.
+	Limit int
+}
+
+// Matches reports whether entry is selected by the query, ignoring Limit.
+func (q Query) Matches(entry Entry) bool {
+	if q.Action != "" && entry.Action != q.Action && !strings.HasPrefix(entry.Action, q.Action+".") {
+		return false
+	}
+
+	if q.Cluster != "" && entry.Cluster != q.Cluster {
+		return false
+	}
+
+	if q.Outcome != "" && entry.Outcome != q.Outcome {
+		return false
+	}
+
+	return q.Since.IsZero() || !entry.Time.Before(q.Since)
+}
+
+// Filter returns the entries selected by the query, oldest first.
+func (q Query) Filter(entries []Entry) []Entry {
+	selected := make([]Entry, 0, len(entries))
+
+	for _, entry := range entries {
+		if q.Matches(entry) {
+			selected = append(selected, entry)
+		}
+	}
+
+	if q.Limit > 0 && len(selected) > q.Limit {
+		selected = selected[len(selected)-q.Limit:]
+	}
+
+	return selected
+}
+
+func newEntry(action string, started time.Time, err error) Entry {
+	entry := Entry{
+		Time:       started.UTC(),
+		Action:     action,
+		Outcome:    OutcomeSuccess,
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+
+	if err != nil {
+		entry.Outcome = OutcomeFailure
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// PathEnvVar overrides where the audit log is kept. Set it to Disabled to turn auditing off.
+	PathEnvVar = "KSAIL_AUDIT_LOG"
+	// Disabled is the PathEnvVar value that turns auditing off.
+	Disabled = "off"
+
+	logDirPerm  = 0o700
+	logFilePerm = 0o600
+
+	// maxLineSize bounds a single entry; applies of large manifest sets list many resources.
+	maxLineSize = 16 << 20
+)
+
+// Log is an append-only audit log in the JSON Lines format. It is safe for concurrent use;
+// entries appended by concurrent processes do not interleave, as each is a single write to a
+// file opened for appending.
+type Log struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewLog creates a Log backed by the file at path.
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// DefaultPath returns the audit log location: PathEnvVar when set, otherwise audit.log in the
+// ksail directory of the user's configuration directory. It returns an empty path when
+// auditing is disabled or no configuration directory is known.
+func DefaultPath() string {
+	if path, ok := os.LookupEnv(PathEnvVar); ok && path != "" {
+		if path == Disabled {
+			return ""
+		}
+
+		return path
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "ksail", "audit.log")
+}
+
+// DefaultLog returns the Log at DefaultPath, or nil when auditing is disabled. A nil Log
+// discards appended entries.
+func DefaultLog() *Log {
+	path := DefaultPath()
+	if path == "" {
+		return nil
+	}
+
+	return NewLog(path)
+}
+
+// Path returns the file backing the log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds entries to the end of the log, creating it when needed.
+func (l *Log) Append(entries ...Entry) error {
+	if l == nil || len(entries) == 0 {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for _, entry := range entries {
+		err := encoder.Encode(entry)
+		if err != nil {
+			return fmt.Errorf("encode audit entry: %w", err)
+		}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(l.path), logDirPerm)
+	if err != nil {
+		return fmt.Errorf("create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, logFilePerm)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+
+	_, err = file.Write(buffer.Bytes())
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("write audit log: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("close audit log: %w", err)
+	}
+
+	return nil
+}
+
+// Entries reads every entry in the log, oldest first. A missing log has no entries. Lines
+// that cannot be decoded, such as one cut short by a crash, are skipped.
+func (l *Log) Entries() ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	file, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+
+	for scanner.Scan() {
+		var entry Entry
+
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+const runIDBytes = 6
+
+type recorderKey struct{}
+
+// Recorder collects the audit entries of one command run. Steps are appended to the log as
+// they finish; Finish appends the entry of the run itself. A nil Recorder records nothing, so
+// code reached outside an audited run needs no checks.
+type Recorder struct {
+	log     *Log
+	runID   string
+	action  string
+	command string
+	started time.Time
+
+	mutex     sync.Mutex
+	cluster   string
+	resources []string
+}
+
+// NewRecorder starts recording a run of command, logged as action, to log.
+func NewRecorder(log *Log, action, command string) *Recorder {
+	return &Recorder{
+		log:     log,
+		runID:   newRunID(),
+		action:  action,
+		command: command,
+		started: time.Now(),
+	}
+}
+
+// WithRecorder returns a context carrying recorder, which Track, SetCluster and AddResources
+// record to.
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// FromContext returns the recorder carried by ctx, or nil.
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+
+	return recorder
+}
+
+// SetCluster sets the cluster the run targets on the recorder carried by ctx.
+func SetCluster(ctx context.Context, cluster string) {
+	FromContext(ctx).SetCluster(cluster)
+}
+
+// AddResources adds resources the run applied to the recorder carried by ctx.
+func AddResources(ctx context.Context, resources []string) {
+	FromContext(ctx).AddResources(resources...)
+}
+
+// Track runs step and records it as action on target with the recorder carried by ctx,
+// returning the step's error.
+func Track(ctx context.Context, action, target string, step func() error) error {
+	started := time.Now()
+
+	err := step()
+
+	entry := newEntry(action, started, err)
+	entry.Target = target
+
+	FromContext(ctx).Record(entry)
+
+	return err
+}
+
+// SetCluster sets the cluster the run targets. Entries recorded afterwards carry it.
+func (r *Recorder) SetCluster(cluster string) {
+	if r == nil || cluster == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cluster = cluster
+}
+
+// AddResources adds resources the run applied; they are listed in the run's entry.
+func (r *Recorder) AddResources(resources ...string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.resources = append(r.resources, resources...)
+}
+
+// Record appends entry to the log as a step of the run. Auditing never fails a run, so
+// errors writing the log are dropped.
+func (r *Recorder) Record(entry Entry) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+
+	entry.RunID = r.runID
+	if entry.Cluster == "" {
+		entry.Cluster = r.cluster
+	}
+
+	r.mutex.Unlock()
+
+	_ = r.log.Append(entry)
+}
+
+// Finish appends the entry of the run, with err as its outcome.
+func (r *Recorder) Finish(err error) {
+	if r == nil {
+		return
+	}
+
+	entry := newEntry(r.action, r.started, err)
+	entry.Command = r.command
+
+	r.mutex.Lock()
+	entry.Resources = append([]string(nil), r.resources...)
+	r.mutex.Unlock()
+
+	r.Record(entry)
+}
+
+func newRunID() string {
+	id := make([]byte, runIDBytes)
+
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
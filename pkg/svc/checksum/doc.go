@@ -0,0 +1,7 @@
+// Package checksum pins the digests of assets KSail downloads, such as Helm chart archives.
+//
+// A Store keeps one digest per asset in a ksail.sum file next to ksail.yaml, meant to be
+// committed with the project. The first download of an asset records its digest; later
+// downloads must match it, so a republished chart or a tampered mirror fails loudly instead
+// of silently changing the environment. Refreshing replaces mismatching pins on purpose.
+package checksum
@@ -0,0 +1,198 @@
+package checksum
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+)
+
+// DefaultPath is where pinned digests are kept, relative to the project directory.
+const DefaultPath = "ksail.sum"
+
+const (
+	sumFilePerm = 0o644
+	// digestPrefix names the algorithm of every digest written by this package.
+	digestPrefix = "sha256:"
+	lineFields   = 2
+)
+
+// ErrChecksumMismatch is returned when a downloaded asset does not match its pinned digest.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrMalformedSumFile is returned when a line of the sum file cannot be parsed.
+var ErrMalformedSumFile = errors.New("malformed checksum file")
+
+// Store is a file-backed set of pinned asset digests. It loads lazily on first use and is
+// safe for concurrent use.
+type Store struct {
+	path    string
+	refresh bool
+	mutex   sync.Mutex
+	loaded  bool
+	pins    map[string]string
+}
+
+// NewStore creates a Store backed by the file at path. With refresh, mismatching digests
+// replace the pinned ones instead of failing verification.
+func NewStore(path string, refresh bool) *Store {
+	return &Store{path: path, refresh: refresh}
+}
+
+// Digest returns the sha256 digest of the content read from reader, in "sha256:<hex>" form.
+func Digest(reader io.Reader) (string, error) {
+	hash := sha256.New()
+
+	_, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash content: %w", err)
+	}
+
+	return digestPrefix + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// DigestFile returns the sha256 digest of the file at path.
+func DigestFile(path string) (string, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is a downloaded asset chosen by the caller
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return Digest(file)
+}
+
+// Verify checks digest against the pin for asset. Unpinned assets are pinned and saved.
+// A mismatch returns ErrChecksumMismatch unless the store refreshes pins.
+func (s *Store) Verify(asset, digest string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	pinned, ok := s.pins[asset]
+	if ok && pinned == digest {
+		return nil
+	}
+
+	if ok && !s.refresh {
+		return fmt.Errorf(
+			"%w for %s: pinned %s, downloaded %s (rerun with --refresh-checksums to accept it)",
+			ErrChecksumMismatch,
+			asset,
+			pinned,
+			digest,
+		)
+	}
+
+	s.pins[asset] = digest
+
+	return s.save()
+}
+
+// Pinned returns the digest pinned for asset, if any.
+func (s *Store) Pinned(asset string) (string, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return "", false, err
+	}
+
+	digest, ok := s.pins[asset]
+
+	return digest, ok, nil
+}
+
+// save writes the pins sorted by asset, one "<asset> <digest>" line each.
+func (s *Store) save() error {
+	assets := make([]string, 0, len(s.pins))
+	for asset := range s.pins {
+		assets = append(assets, asset)
+	}
+
+	sort.Strings(assets)
+
+	var buf bytes.Buffer
+	for _, asset := range assets {
+		buf.WriteString(asset + " " + s.pins[asset] + "\n")
+	}
+
+	dir := filepath.Dir(s.path)
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create checksum directory: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(s.path, buf.Bytes(), sumFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write checksum file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	s.pins = map[string]string{}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.loaded = true
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to read checksum file: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != lineFields || !strings.HasPrefix(fields[1], digestPrefix) {
+			return fmt.Errorf("%w: %s line %d", ErrMalformedSumFile, s.path, lineNumber)
+		}
+
+		s.pins[fields[0]] = fields[1]
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf("failed to read checksum file: %w", err)
+	}
+
+	s.loaded = true
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package checksum_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/checksum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ciliumChart = "https://helm.cilium.io/cilium@1.16.0"
+	digestOne   = "sha256:1111"
+	digestTwo   = "sha256:2222"
+)
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	digest, err := checksum.Digest(strings.NewReader("chart"))
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:cc57fc1903e444cf6a726490b43b27ee9f87facc037f86872201847c565b45fb", digest)
+}
+
+func TestStoreVerifyPinsUnknownAssets(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.sum")
+
+	require.NoError(t, checksum.NewStore(path, false).Verify(ciliumChart, digestOne))
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Equal(t, ciliumChart+" "+digestOne+"\n", string(data))
+
+	digest, ok, err := checksum.NewStore(path, false).Pinned(ciliumChart)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, digestOne, digest)
+}
+
+func TestStoreVerifyRejectsMismatch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.sum")
+	require.NoError(t, checksum.NewStore(path, false).Verify(ciliumChart, digestOne))
+
+	store := checksum.NewStore(path, false)
+	require.NoError(t, store.Verify(ciliumChart, digestOne))
+
+	err := store.Verify(ciliumChart, digestTwo)
+	require.ErrorIs(t, err, checksum.ErrChecksumMismatch)
+	assert.Contains(t, err.Error(), "--refresh-checksums")
+}
+
+func TestStoreVerifyRefreshReplacesPins(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.sum")
+	require.NoError(t, checksum.NewStore(path, false).Verify(ciliumChart, digestOne))
+
+	require.NoError(t, checksum.NewStore(path, true).Verify(ciliumChart, digestTwo))
+
+	digest, _, err := checksum.NewStore(path, false).Pinned(ciliumChart)
+	require.NoError(t, err)
+	assert.Equal(t, digestTwo, digest)
+}
+
+func TestStoreRejectsMalformedFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.sum")
+	require.NoError(t, os.WriteFile(path, []byte("# pinned charts\nnot-a-pin\n"), 0o600))
+
+	err := checksum.NewStore(path, false).Verify(ciliumChart, digestOne)
+	require.ErrorIs(t, err, checksum.ErrMalformedSumFile)
+}
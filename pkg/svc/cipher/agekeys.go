@@ -0,0 +1,59 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getsops/sops/v3/age"
+)
+
+// ErrUserConfigDir is returned when the user config directory holding the default age keys
+// file cannot be determined.
+var ErrUserConfigDir = errors.New("failed to determine user config directory")
+
+// AgeKeysFile returns the age keys file SOPS reads: $SOPS_AGE_KEY_FILE, or sops/age/keys.txt
+// in the user config directory.
+func AgeKeysFile() (string, error) {
+	if keysFile := os.Getenv(age.SopsAgeKeyFileEnv); keysFile != "" {
+		return keysFile, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUserConfigDir, err)
+	}
+
+	return filepath.Join(configDir, age.SopsAgeKeyUserConfigPath), nil
+}
+
+// LoadAgeKeys returns the age identities SOPS decrypts with locally: $SOPS_AGE_KEY, or else
+// the contents of AgeKeysFile. Without either, it returns an empty string and no error.
+func LoadAgeKeys() (string, error) {
+	if keys := strings.TrimSpace(os.Getenv(age.SopsAgeKeyEnv)); keys != "" {
+		return keys + "\n", nil
+	}
+
+	keysFile, err := AgeKeysFile()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(keysFile) //nolint:gosec // keys file path comes from SOPS conventions
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read age keys file: %w", err)
+	}
+
+	keys := strings.TrimSpace(string(content))
+	if keys == "" {
+		return "", nil
+	}
+
+	return keys + "\n", nil
+}
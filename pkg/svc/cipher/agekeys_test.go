@@ -0,0 +1,44 @@
+package cipher_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAgeKeysPrefersEnvironment(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", "  AGE-SECRET-KEY-1ENV  ")
+	t.Setenv("SOPS_AGE_KEY_FILE", filepath.Join(t.TempDir(), "keys.txt"))
+
+	keys, err := ciphersvc.LoadAgeKeys()
+
+	require.NoError(t, err)
+	assert.Equal(t, "AGE-SECRET-KEY-1ENV\n", keys)
+}
+
+func TestLoadAgeKeysReadsKeysFile(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(keysFile, []byte("# public key: age1test\nAGE-SECRET-KEY-1FILE\n\n"), 0o600))
+
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", keysFile)
+
+	keys, err := ciphersvc.LoadAgeKeys()
+
+	require.NoError(t, err)
+	assert.Equal(t, "# public key: age1test\nAGE-SECRET-KEY-1FILE\n", keys)
+}
+
+func TestLoadAgeKeysWithoutKeys(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY", "")
+	t.Setenv("SOPS_AGE_KEY_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+
+	keys, err := ciphersvc.LoadAgeKeys()
+
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
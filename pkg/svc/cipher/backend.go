@@ -0,0 +1,26 @@
+package cipher
+
+import (
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/keyservice"
+)
+
+// Backend bundles the SOPS cipher and key management used to encrypt and decrypt files.
+type Backend struct {
+	// Cipher encrypts and decrypts individual values with the data key.
+	Cipher sops.Cipher
+	// KeyServices encrypt and decrypt the data key for each master key.
+	KeyServices []keyservice.KeyServiceClient
+	// KeyGroups are the master keys newly encrypted files are encrypted for.
+	KeyGroups []sops.KeyGroup
+}
+
+// NewDefaultBackend returns the production backend: AES-GCM values and the local key service.
+func NewDefaultBackend() Backend {
+	return Backend{
+		Cipher:      aes.NewCipher(),
+		KeyServices: []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+		KeyGroups:   []sops.KeyGroup{},
+	}
+}
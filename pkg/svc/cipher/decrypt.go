@@ -0,0 +1,50 @@
+package cipher
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/config"
+	yamlstore "github.com/getsops/sops/v3/stores/yaml"
+)
+
+// IsEncryptedYAML reports whether data is a YAML document with SOPS metadata.
+func IsEncryptedYAML(data []byte) bool {
+	_, err := yamlstore.NewStore(&config.YAMLStoreConfig{}).LoadEncryptedFile(data)
+
+	return err == nil
+}
+
+// DecryptYAML decrypts a SOPS-encrypted YAML document with backend and returns the plaintext
+// document. Documents without SOPS metadata are returned unchanged, so callers can pass
+// plaintext and encrypted files alike.
+func DecryptYAML(backend Backend, data []byte) ([]byte, error) {
+	store := yamlstore.NewStore(&config.YAMLStoreConfig{})
+
+	tree, err := store.LoadEncryptedFile(data)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return data, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encrypted document: %w", err)
+	}
+
+	_, err = common.DecryptTree(common.DecryptTreeOpts{
+		Cipher:      backend.Cipher,
+		Tree:        &tree,
+		KeyServices: backend.KeyServices,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt document: %w", err)
+	}
+
+	plaintext, err := store.EmitPlainFile(tree.Branches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to emit decrypted document: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,6 @@
+// Package cipher provides the SOPS backend used by the cipher commands.
+//
+// A Backend bundles the value cipher, the key services that wrap the data key, and the
+// master key groups new files are encrypted for, so commands can be run against real
+// key management in production and against stubs in tests.
+package cipher
@@ -0,0 +1,185 @@
+package clusterinfo
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// Info describes a cluster and the components KSail installed into it.
+type Info struct {
+	Name          string                 `json:"name"`
+	Distribution  v1alpha1.Distribution  `json:"distribution"`
+	Context       string                 `json:"context"`
+	Kubeconfig    string                 `json:"kubeconfig"`
+	CNI           v1alpha1.CNI           `json:"cni"`
+	CSI           v1alpha1.CSI           `json:"csi"`
+	MetricsServer v1alpha1.MetricsServer `json:"metricsServer"`
+	GitOpsEngine  v1alpha1.GitOpsEngine  `json:"gitOpsEngine"`
+	LocalRegistry string                 `json:"localRegistry,omitempty"`
+	NodeImage     string                 `json:"nodeImage,omitempty"`
+	Mirrors       []Mirror               `json:"mirrors"`
+	// Reachable reports whether Collect could read the running cluster. Nodes and
+	// Components are empty when it could not.
+	Reachable  bool        `json:"reachable"`
+	Nodes      []Node      `json:"nodes"`
+	Components []Component `json:"components"`
+	// Health is the readiness of the CNI, CSI, ingress, metrics server and GitOps engine,
+	// reported when requested.
+	Health []ComponentHealth `json:"health,omitempty"`
+}
+
+// Mirror is a registry mirror the cluster's container runtime pulls through.
+type Mirror struct {
+	Host      string   `json:"host"`
+	Endpoints []string `json:"endpoints"`
+}
+
+// Node is a node of the running cluster.
+type Node struct {
+	Name             string   `json:"name"`
+	Roles            []string `json:"roles"`
+	KubeletVersion   string   `json:"kubeletVersion"`
+	OSImage          string   `json:"osImage"`
+	ContainerRuntime string   `json:"containerRuntime"`
+}
+
+// Component is the latest revision of a Helm release installed into the cluster.
+type Component struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Chart      string `json:"chart"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion,omitempty"`
+	Revision   int    `json:"revision"`
+	Status     string `json:"status"`
+}
+
+// Mirrors converts a map of registry host to mirror endpoints into mirrors sorted by host.
+func Mirrors(hostEndpoints map[string][]string) []Mirror {
+	mirrors := make([]Mirror, 0, len(hostEndpoints))
+
+	for _, host := range slices.Sorted(maps.Keys(hostEndpoints)) {
+		mirrors = append(mirrors, Mirror{Host: host, Endpoints: hostEndpoints[host]})
+	}
+
+	return mirrors
+}
+
+// Collect reads the nodes and the Helm releases of the cluster behind clientset into info and
+// marks it reachable.
+func Collect(ctx context.Context, clientset kubernetes.Interface, info *Info) error {
+	nodes, err := listNodes(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	components, err := listComponents(clientset)
+	if err != nil {
+		return err
+	}
+
+	info.Nodes = nodes
+	info.Components = components
+	info.Reachable = true
+
+	return nil
+}
+
+func listNodes(ctx context.Context, clientset kubernetes.Interface) ([]Node, error) {
+	list, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	nodes := make([]Node, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		nodes = append(nodes, Node{
+			Name:             item.Name,
+			Roles:            nodeRoles(item),
+			KubeletVersion:   item.Status.NodeInfo.KubeletVersion,
+			OSImage:          item.Status.NodeInfo.OSImage,
+			ContainerRuntime: item.Status.NodeInfo.ContainerRuntimeVersion,
+		})
+	}
+
+	slices.SortFunc(nodes, func(a, b Node) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return nodes, nil
+}
+
+func nodeRoles(node corev1.Node) []string {
+	roles := []string{}
+
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	slices.Sort(roles)
+
+	return roles
+}
+
+// listComponents reads the Helm releases in all namespaces from Helm's secret storage and
+// keeps the latest revision of each.
+func listComponents(clientset kubernetes.Interface) ([]Component, error) {
+	releases, err := driver.NewSecrets(clientset.CoreV1().Secrets(metav1.NamespaceAll)).List(
+		func(*release.Release) bool { return true },
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list helm releases: %w", err)
+	}
+
+	latest := map[string]*release.Release{}
+
+	for _, rel := range releases {
+		key := rel.Namespace + "/" + rel.Name
+		if current, ok := latest[key]; !ok || rel.Version > current.Version {
+			latest[key] = rel
+		}
+	}
+
+	components := make([]Component, 0, len(latest))
+
+	for _, key := range slices.Sorted(maps.Keys(latest)) {
+		components = append(components, newComponent(latest[key]))
+	}
+
+	return components, nil
+}
+
+func newComponent(rel *release.Release) Component {
+	component := Component{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+	}
+
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		component.Chart = rel.Chart.Metadata.Name
+		component.Version = rel.Chart.Metadata.Version
+		component.AppVersion = rel.Chart.Metadata.AppVersion
+	}
+
+	if rel.Info != nil {
+		component.Status = rel.Info.Status.String()
+	}
+
+	return component
+}
@@ -0,0 +1,125 @@
+package clusterinfo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/clusterinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollectReadsNodesAndLatestReleases(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		newNode("local-worker", "", "v1.34.0"),
+		newNode("local-control-plane", "control-plane", "v1.34.0"),
+	)
+
+	storeRelease(t, clientset, "cilium", "kube-system", 1, release.StatusSuperseded, "1.18.1")
+	storeRelease(t, clientset, "cilium", "kube-system", 2, release.StatusDeployed, "1.18.2")
+	storeRelease(t, clientset, "metrics-server", "kube-system", 1, release.StatusFailed, "3.13.0")
+
+	info := clusterinfo.Info{}
+
+	require.NoError(t, clusterinfo.Collect(context.Background(), clientset, &info))
+
+	assert.True(t, info.Reachable)
+	assert.Equal(t, []clusterinfo.Node{
+		{
+			Name:             "local-control-plane",
+			Roles:            []string{"control-plane"},
+			KubeletVersion:   "v1.34.0",
+			OSImage:          "Debian GNU/Linux 12",
+			ContainerRuntime: "containerd://2.1.4",
+		},
+		{
+			Name:             "local-worker",
+			Roles:            []string{},
+			KubeletVersion:   "v1.34.0",
+			OSImage:          "Debian GNU/Linux 12",
+			ContainerRuntime: "containerd://2.1.4",
+		},
+	}, info.Nodes)
+	assert.Equal(t, []clusterinfo.Component{
+		{
+			Name:      "cilium",
+			Namespace: "kube-system",
+			Chart:     "cilium",
+			Version:   "1.18.2",
+			Revision:  2,
+			Status:    "deployed",
+		},
+		{
+			Name:      "metrics-server",
+			Namespace: "kube-system",
+			Chart:     "metrics-server",
+			Version:   "3.13.0",
+			Revision:  1,
+			Status:    "failed",
+		},
+	}, info.Components)
+}
+
+func TestMirrorsSortsByHost(t *testing.T) {
+	t.Parallel()
+
+	mirrors := clusterinfo.Mirrors(map[string][]string{
+		"ghcr.io":   {"http://ghcr.io:5000"},
+		"docker.io": {"http://docker.io:5000", "https://registry-1.docker.io"},
+	})
+
+	assert.Equal(t, []clusterinfo.Mirror{
+		{Host: "docker.io", Endpoints: []string{"http://docker.io:5000", "https://registry-1.docker.io"}},
+		{Host: "ghcr.io", Endpoints: []string{"http://ghcr.io:5000"}},
+	}, mirrors)
+}
+
+func newNode(name, role, version string) *corev1.Node {
+	labels := map[string]string{"kubernetes.io/hostname": name}
+	if role != "" {
+		labels["node-role.kubernetes.io/"+role] = ""
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion:          version,
+				OSImage:                 "Debian GNU/Linux 12",
+				ContainerRuntimeVersion: "containerd://2.1.4",
+			},
+		},
+	}
+}
+
+func storeRelease(
+	t *testing.T,
+	clientset *fake.Clientset,
+	name, namespace string,
+	revision int,
+	status release.Status,
+	version string,
+) {
+	t.Helper()
+
+	rel := &release.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   revision,
+		Info:      &release.Info{Status: status},
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: name, Version: version}},
+	}
+
+	secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
+
+	require.NoError(t, secrets.Create(fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision), rel))
+}
@@ -0,0 +1,8 @@
+// Package clusterinfo describes what KSail created for a cluster.
+//
+// An Info combines what the KSail and distribution configs declare, such as the distribution,
+// the kubeconfig, the CNI, the node image and the registry mirrors, with what Collect reads
+// from the running cluster: its nodes and the Helm releases of the installed components.
+// CheckHealth additionally reports whether the workloads of each component are ready,
+// degraded or missing.
+package clusterinfo
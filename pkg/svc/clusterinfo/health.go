@@ -0,0 +1,91 @@
+package clusterinfo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"k8s.io/client-go/kubernetes"
+)
+
+// HealthCheck names the workloads that make up a component.
+type HealthCheck struct {
+	// Name is the role of the component, e.g. cni or gitops-engine.
+	Name string
+	// Provider is what fills the role, e.g. Cilium or Flux.
+	Provider string
+	Checks   []k8s.ReadinessCheck
+}
+
+// ComponentHealth is the readiness of a component and of each of its workloads.
+type ComponentHealth struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	// Readiness is ready when every workload is ready, missing when none exists and degraded
+	// otherwise.
+	Readiness k8s.Readiness    `json:"readiness"`
+	Workloads []WorkloadHealth `json:"workloads"`
+}
+
+// WorkloadHealth is the readiness of a Deployment or DaemonSet of a component.
+type WorkloadHealth struct {
+	Type      string        `json:"type"`
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Readiness k8s.Readiness `json:"readiness"`
+}
+
+// CheckHealth reports the readiness of the components described by checks, in their order.
+func CheckHealth(ctx context.Context, clientset kubernetes.Interface, checks []HealthCheck) ([]ComponentHealth, error) {
+	health := make([]ComponentHealth, 0, len(checks))
+
+	for _, check := range checks {
+		component := ComponentHealth{
+			Name:      check.Name,
+			Provider:  check.Provider,
+			Workloads: make([]WorkloadHealth, 0, len(check.Checks)),
+		}
+
+		for _, workload := range check.Checks {
+			readiness, err := k8s.CheckReadiness(ctx, clientset, workload)
+			if err != nil {
+				return nil, fmt.Errorf("check %s health: %w", check.Name, err)
+			}
+
+			component.Workloads = append(component.Workloads, WorkloadHealth{
+				Type:      workload.Type,
+				Namespace: workload.Namespace,
+				Name:      workload.Name,
+				Readiness: readiness,
+			})
+		}
+
+		component.Readiness = aggregateReadiness(component.Workloads)
+		health = append(health, component)
+	}
+
+	return health, nil
+}
+
+func aggregateReadiness(workloads []WorkloadHealth) k8s.Readiness {
+	ready, missing := 0, 0
+
+	for _, workload := range workloads {
+		switch workload.Readiness {
+		case k8s.ReadinessReady:
+			ready++
+		case k8s.ReadinessMissing:
+			missing++
+		case k8s.ReadinessDegraded:
+		}
+	}
+
+	switch {
+	case ready == len(workloads):
+		return k8s.ReadinessReady
+	case missing == len(workloads):
+		return k8s.ReadinessMissing
+	default:
+		return k8s.ReadinessDegraded
+	}
+}
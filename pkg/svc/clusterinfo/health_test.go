@@ -0,0 +1,69 @@
+package clusterinfo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"github.com/devantler-tech/ksail-go/pkg/svc/clusterinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckHealthReportsReadyDegradedAndMissingComponents(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium", Namespace: "kube-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cilium-operator", Namespace: "kube-system"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "flux-operator", Namespace: "flux-system"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+	)
+
+	health, err := clusterinfo.CheckHealth(context.Background(), clientset, []clusterinfo.HealthCheck{
+		{
+			Name:     "cni",
+			Provider: "Cilium",
+			Checks: []k8s.ReadinessCheck{
+				{Type: "daemonset", Namespace: "kube-system", Name: "cilium"},
+				{Type: "deployment", Namespace: "kube-system", Name: "cilium-operator"},
+			},
+		},
+		{
+			Name:     "gitops-engine",
+			Provider: "Flux",
+			Checks: []k8s.ReadinessCheck{
+				{Type: "deployment", Namespace: "flux-system", Name: "flux-operator"},
+				{Type: "deployment", Namespace: "flux-system", Name: "source-controller"},
+			},
+		},
+		{
+			Name:     "metrics-server",
+			Provider: "metrics-server",
+			Checks:   []k8s.ReadinessCheck{{Type: "deployment", Namespace: "kube-system", Name: "metrics-server"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, health, 3)
+
+	assert.Equal(t, k8s.ReadinessReady, health[0].Readiness)
+	assert.Equal(t, k8s.ReadinessDegraded, health[1].Readiness)
+	assert.Equal(t, clusterinfo.WorkloadHealth{
+		Type:      "deployment",
+		Namespace: "flux-system",
+		Name:      "source-controller",
+		Readiness: k8s.ReadinessMissing,
+	}, health[1].Workloads[1])
+	assert.Equal(t, k8s.ReadinessMissing, health[2].Readiness)
+}
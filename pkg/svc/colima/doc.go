@@ -0,0 +1,7 @@
+// Package colima manages the Colima VM that provides a Docker runtime on macOS and Linux
+// hosts without Docker Desktop.
+//
+// A Service starts a Colima profile with the requested CPU, memory and disk before a cluster
+// is provisioned and stops it again after the cluster is deleted. SocketPath returns the
+// Docker socket of a profile so Docker clients can be pointed at the VM.
+package colima
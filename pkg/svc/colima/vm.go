@@ -0,0 +1,170 @@
+package colima
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+)
+
+const (
+	defaultBinary = "colima"
+	// DefaultProfile is the Colima profile used when none is configured.
+	DefaultProfile = "default"
+	// HomeEnvVar overrides the directory Colima keeps its profiles in.
+	HomeEnvVar       = "COLIMA_HOME"
+	defaultHomeDir   = ".colima"
+	dockerSocket     = "docker.sock"
+	unixSocketScheme = "unix://"
+)
+
+// VM describes a Colima profile and the resources it is started with.
+// Zero resource values leave Colima's defaults in place.
+type VM struct {
+	// Profile is the Colima profile name; empty selects DefaultProfile.
+	Profile string
+	// CPUs is the number of virtual CPUs.
+	CPUs int32
+	// Memory is the memory size in GiB.
+	Memory int32
+	// Disk is the disk size in GiB.
+	Disk int32
+}
+
+// Service starts and stops Colima VMs with the colima CLI.
+type Service struct {
+	binary string
+	runner execrunner.Runner
+}
+
+// Option customizes a Service.
+type Option func(*Service)
+
+// WithRunner replaces the function used to execute colima.
+func WithRunner(runner execrunner.Runner) Option {
+	return func(s *Service) {
+		s.runner = runner
+	}
+}
+
+// WithBinary sets the colima binary.
+func WithBinary(binary string) Option {
+	return func(s *Service) {
+		s.binary = binary
+	}
+}
+
+// NewService creates a Service that runs `colima`.
+func NewService(opts ...Option) *Service {
+	service := &Service{binary: defaultBinary, runner: execrunner.Exec}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// ProfileName returns profile, or DefaultProfile when profile is empty.
+func ProfileName(profile string) string {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		return DefaultProfile
+	}
+
+	return profile
+}
+
+// SocketPath returns the Docker socket of profile inside $COLIMA_HOME, which defaults to
+// ~/.colima.
+func SocketPath(profile string) (string, error) {
+	home := os.Getenv(HomeEnvVar)
+	if home == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolve home directory: %w", err)
+		}
+
+		home = filepath.Join(userHome, defaultHomeDir)
+	}
+
+	return filepath.Join(home, ProfileName(profile), dockerSocket), nil
+}
+
+// DockerHost returns the unix:// Docker host URL of profile.
+func DockerHost(profile string) (string, error) {
+	socket, err := SocketPath(profile)
+	if err != nil {
+		return "", err
+	}
+
+	return unixSocketScheme + socket, nil
+}
+
+// IsRunning reports whether the VM of profile is running.
+func (s *Service) IsRunning(ctx context.Context, profile string) bool {
+	_, err := s.runner(ctx, s.binary, "status", "--profile", ProfileName(profile))
+
+	return err == nil
+}
+
+// Start starts the VM unless it is already running. It returns whether the VM was started.
+//
+// Resources of a running VM are left untouched, since Colima only applies them on start.
+func (s *Service) Start(ctx context.Context, vm VM) (bool, error) {
+	profile := ProfileName(vm.Profile)
+
+	if s.IsRunning(ctx, profile) {
+		return false, nil
+	}
+
+	args := []string{"start", "--profile", profile}
+	args = appendResource(args, "--cpu", vm.CPUs)
+	args = appendResource(args, "--memory", vm.Memory)
+	args = appendResource(args, "--disk", vm.Disk)
+
+	output, err := s.runner(ctx, s.binary, args...)
+	if err != nil {
+		return false, fmt.Errorf(
+			"start colima profile %s: %w: %s",
+			profile,
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return true, nil
+}
+
+// Stop stops the VM of profile. It is a no-op when the VM is not running.
+func (s *Service) Stop(ctx context.Context, profile string) error {
+	profile = ProfileName(profile)
+
+	if !s.IsRunning(ctx, profile) {
+		return nil
+	}
+
+	output, err := s.runner(ctx, s.binary, "stop", "--profile", profile)
+	if err != nil {
+		return fmt.Errorf(
+			"stop colima profile %s: %w: %s",
+			profile,
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
+
+func appendResource(args []string, flag string, value int32) []string {
+	if value <= 0 {
+		return args
+	}
+
+	return append(args, flag, strconv.Itoa(int(value)))
+}
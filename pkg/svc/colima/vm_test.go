@@ -0,0 +1,137 @@
+package colima_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/colima"
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errColimaFailed = errors.New("exit status 1")
+
+type fakeColima struct {
+	testutils.RecordingRunner
+
+	running  bool
+	startErr error
+}
+
+// runner answers colima commands from the state of the fake VM.
+func (f *fakeColima) runner() execrunner.Runner {
+	f.Respond = f.respond
+
+	return f.Run
+}
+
+func (f *fakeColima) respond(_ string, args []string) ([]byte, error) {
+	switch args[0] {
+	case "status":
+		if !f.running {
+			return nil, errColimaFailed
+		}
+	case "start":
+		if f.startErr != nil {
+			return []byte("FATA[0000] not enough memory\n"), f.startErr
+		}
+
+		f.running = true
+	case "stop":
+		f.running = false
+	}
+
+	return nil, nil
+}
+
+func TestStartStartsStoppedVMWithResources(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{}
+	service := colima.NewService(colima.WithRunner(fake.runner()))
+
+	started, err := service.Start(context.Background(), colima.VM{
+		Profile: "ksail",
+		CPUs:    4,
+		Memory:  8,
+		Disk:    60,
+	})
+	require.NoError(t, err)
+	assert.True(t, started)
+
+	require.Len(t, fake.Calls(), 2)
+	assert.Equal(t, []string{"colima", "status", "--profile", "ksail"}, fake.Calls()[0])
+	assert.Equal(t, []string{
+		"colima", "start", "--profile", "ksail",
+		"--cpu", "4", "--memory", "8", "--disk", "60",
+	}, fake.Calls()[1])
+}
+
+func TestStartOmitsUnsetResourcesAndUsesDefaultProfile(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{}
+
+	_, err := colima.NewService(colima.WithRunner(fake.runner())).Start(context.Background(), colima.VM{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"colima", "start", "--profile", colima.DefaultProfile}, fake.Calls()[1])
+}
+
+func TestStartSkipsRunningVM(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{running: true}
+
+	started, err := colima.NewService(colima.WithRunner(fake.runner())).
+		Start(context.Background(), colima.VM{CPUs: 2})
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Len(t, fake.Calls(), 1)
+}
+
+func TestStartIncludesColimaOutputInError(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{startErr: errColimaFailed}
+
+	_, err := colima.NewService(colima.WithRunner(fake.runner())).Start(context.Background(), colima.VM{})
+	require.ErrorIs(t, err, errColimaFailed)
+	assert.Contains(t, err.Error(), "not enough memory")
+}
+
+func TestStopStopsRunningVM(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{running: true}
+
+	err := colima.NewService(colima.WithRunner(fake.runner())).Stop(context.Background(), "ksail")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"colima", "stop", "--profile", "ksail"}, fake.Calls()[1])
+	assert.False(t, fake.running)
+}
+
+func TestStopIsNoOpWhenVMIsStopped(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeColima{}
+
+	err := colima.NewService(colima.WithRunner(fake.runner())).Stop(context.Background(), "")
+	require.NoError(t, err)
+	assert.Len(t, fake.Calls(), 1)
+}
+
+//nolint:paralleltest // t.Setenv is incompatible with t.Parallel
+func TestDockerHostUsesColimaHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(colima.HomeEnvVar, home)
+
+	host, err := colima.DockerHost("")
+	require.NoError(t, err)
+	assert.Equal(t, "unix://"+filepath.Join(home, colima.DefaultProfile, "docker.sock"), host)
+}
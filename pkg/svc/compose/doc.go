@@ -0,0 +1,7 @@
+// Package compose manages Docker Compose sidecar services that run next to a KSail cluster.
+//
+// Services declared in the compose file referenced by ksail.yaml are started with the
+// docker compose CLI on the cluster's Docker network, so workloads can reach databases and
+// message brokers by service name. The services share the cluster lifecycle: they are started
+// when the cluster is created and removed when it is deleted.
+package compose
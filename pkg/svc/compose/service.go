@@ -0,0 +1,148 @@
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+)
+
+const (
+	defaultBinary      = "docker"
+	projectNameSuffix  = "-sidecars"
+	overrideFilePrefix = "ksail-compose-network-"
+	overrideFilePerm   = 0o600
+)
+
+var (
+	// ErrComposeFileNotFound is returned when the configured compose file does not exist.
+	ErrComposeFileNotFound = errors.New("compose file not found")
+	// ErrNetworkRequired is returned when a project has no cluster network to attach to.
+	ErrNetworkRequired = errors.New("cluster network is required")
+)
+
+// Project describes the compose services attached to one cluster.
+type Project struct {
+	// Name is the compose project name; see ProjectName.
+	Name string
+	// File is the path to the compose file.
+	File string
+	// Network is the Docker network of the cluster the services join.
+	Network string
+}
+
+// Service starts and stops compose projects with the docker compose CLI.
+type Service struct {
+	binary string
+	runner execrunner.Runner
+}
+
+// Option customizes a Service.
+type Option func(*Service)
+
+// WithRunner replaces the function used to execute docker compose.
+func WithRunner(runner execrunner.Runner) Option {
+	return func(s *Service) {
+		s.runner = runner
+	}
+}
+
+// WithBinary sets the docker CLI binary used to run compose.
+func WithBinary(binary string) Option {
+	return func(s *Service) {
+		s.binary = binary
+	}
+}
+
+// NewService creates a Service that runs `docker compose`.
+func NewService(opts ...Option) *Service {
+	service := &Service{binary: defaultBinary, runner: execrunner.Exec}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// ProjectName returns the compose project name used for the sidecars of clusterName.
+func ProjectName(clusterName string) string {
+	return strings.ToLower(clusterName) + projectNameSuffix
+}
+
+// Up starts the project's services in the background and waits until they are running
+// (or healthy, for services that declare a health check).
+func (s *Service) Up(ctx context.Context, project Project) error {
+	return s.run(ctx, project, "up", "--detach", "--wait")
+}
+
+// Down stops and removes the project's containers. Volumes are kept.
+func (s *Service) Down(ctx context.Context, project Project) error {
+	return s.run(ctx, project, "down", "--remove-orphans")
+}
+
+func (s *Service) run(ctx context.Context, project Project, command ...string) error {
+	if project.Network == "" {
+		return ErrNetworkRequired
+	}
+
+	_, err := os.Stat(project.File)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrComposeFileNotFound, project.File)
+	}
+
+	override, err := writeNetworkOverride(project.Network)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(override) }()
+
+	args := []string{
+		"compose",
+		"--project-name", project.Name,
+		"--project-directory", filepath.Dir(project.File),
+		"--file", project.File,
+		"--file", override,
+	}
+	args = append(args, command...)
+
+	output, err := s.runner(ctx, s.binary, args...)
+	if err != nil {
+		return fmt.Errorf(
+			"docker compose %s failed: %w: %s",
+			command[0],
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
+
+// writeNetworkOverride writes a compose file that replaces the project's default network
+// with the existing cluster network.
+func writeNetworkOverride(networkName string) (string, error) {
+	file, err := os.CreateTemp("", overrideFilePrefix+"*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("create compose network override: %w", err)
+	}
+
+	content := fmt.Sprintf("networks:\n  default:\n    name: %q\n    external: true\n", networkName)
+
+	_, err = file.WriteString(content)
+	closeErr := file.Close()
+
+	err = errors.Join(err, closeErr, os.Chmod(file.Name(), overrideFilePerm))
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		return "", fmt.Errorf("write compose network override: %w", err)
+	}
+
+	return file.Name(), nil
+}
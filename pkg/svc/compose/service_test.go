@@ -0,0 +1,109 @@
+package compose_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/compose"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errComposeFailed = errors.New("exit status 1")
+
+// composeRunner answers every command with output and err, and records the network override
+// each command was given before the service removes it.
+func composeRunner(overrides *[]string, output string, err error) *testutils.RecordingRunner {
+	return &testutils.RecordingRunner{Respond: func(_ string, args []string) ([]byte, error) {
+		// The network override is the second --file argument.
+		content, _ := os.ReadFile(args[8]) //nolint:gosec // path is created by the service under test
+		*overrides = append(*overrides, string(content))
+
+		return []byte(output), err
+	}}
+}
+
+func writeComposeFile(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "compose.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("services:\n  db:\n    image: postgres:17\n"), 0o600))
+
+	return path
+}
+
+func TestUpAndDownRunComposeOnClusterNetwork(t *testing.T) {
+	t.Parallel()
+
+	file := writeComposeFile(t)
+	project := compose.Project{Name: compose.ProjectName("Dev"), File: file, Network: "k3d-dev"}
+
+	var overrides []string
+
+	runner := composeRunner(&overrides, "", nil)
+	service := compose.NewService(compose.WithRunner(runner.Run))
+
+	require.NoError(t, service.Up(context.Background(), project))
+	require.NoError(t, service.Down(context.Background(), project))
+
+	calls := runner.Calls()
+	require.Len(t, calls, 2)
+
+	for i, command := range [][]string{{"up", "--detach", "--wait"}, {"down", "--remove-orphans"}} {
+		name, args := calls[i][0], calls[i][1:]
+		assert.Equal(t, "docker", name)
+		assert.Equal(t, []string{
+			"compose",
+			"--project-name", "dev-sidecars",
+			"--project-directory", filepath.Dir(file),
+			"--file", file,
+			"--file", args[8],
+		}, args[:9])
+		assert.Equal(t, command, args[9:])
+		assert.Equal(t, "networks:\n  default:\n    name: \"k3d-dev\"\n    external: true\n", overrides[i])
+		assert.NoFileExists(t, args[8])
+	}
+}
+
+func TestUpReportsComposeOutputOnFailure(t *testing.T) {
+	t.Parallel()
+
+	var overrides []string
+
+	runner := composeRunner(&overrides, "network kind not found\n", errComposeFailed)
+	service := compose.NewService(compose.WithBinary("podman"), compose.WithRunner(runner.Run))
+
+	err := service.Up(context.Background(), compose.Project{
+		Name:    "dev-sidecars",
+		File:    writeComposeFile(t),
+		Network: "kind",
+	})
+
+	require.ErrorIs(t, err, errComposeFailed)
+	assert.Contains(t, err.Error(), "docker compose up failed")
+	assert.Contains(t, err.Error(), "network kind not found")
+	require.Len(t, runner.Calls(), 1)
+	assert.Equal(t, "podman", runner.Calls()[0][0])
+}
+
+func TestUpValidatesProject(t *testing.T) {
+	t.Parallel()
+
+	runner := &testutils.RecordingRunner{}
+	service := compose.NewService(compose.WithRunner(runner.Run))
+
+	err := service.Up(context.Background(), compose.Project{
+		Name:    "dev-sidecars",
+		File:    filepath.Join(t.TempDir(), "missing.yaml"),
+		Network: "kind",
+	})
+	require.ErrorIs(t, err, compose.ErrComposeFileNotFound)
+
+	err = service.Up(context.Background(), compose.Project{Name: "dev-sidecars", File: writeComposeFile(t)})
+	require.ErrorIs(t, err, compose.ErrNetworkRequired)
+	assert.Empty(t, runner.Calls(), "invalid projects must not run docker compose")
+}
@@ -0,0 +1,180 @@
+package devcontainer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/svc/devcontainer"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var errNoSuchFile = errors.New("no such file")
+
+func newDetector(env map[string]string, dockerEnv bool) devcontainer.Detector {
+	return devcontainer.Detector{
+		Getenv: func(key string) string { return env[key] },
+		Stat: func(string) (os.FileInfo, error) {
+			if dockerEnv {
+				return nil, nil
+			}
+
+			return nil, errNoSuchFile
+		},
+		Hostname: func() (string, error) { return "devcontainer-1", nil },
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		env        map[string]string
+		dockerEnv  bool
+		daemonName string
+		want       devcontainer.DockerMode
+	}{
+		{name: "host", want: devcontainer.ModeHost},
+		{
+			name:       "codespaces with host daemon",
+			env:        map[string]string{"CODESPACES": "true"},
+			daemonName: "codespaces-host",
+			want:       devcontainer.ModeDockerOutsideOfDocker,
+		},
+		{
+			name:       "container with own daemon",
+			dockerEnv:  true,
+			daemonName: "devcontainer-1",
+			want:       devcontainer.ModeDockerInDocker,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			docker := dockerclient.NewMockAPIClient(t)
+			if testCase.want != devcontainer.ModeHost {
+				docker.EXPECT().Info(mock.Anything).Return(system.Info{Name: testCase.daemonName}, nil).Once()
+			}
+
+			env, err := newDetector(testCase.env, testCase.dockerEnv).Detect(context.Background(), docker)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.want, env.Mode)
+			assert.Equal(t, testCase.want != devcontainer.ModeHost, env.InContainer)
+		})
+	}
+}
+
+func TestInternalServerURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"https://dev-control-plane:6443",
+		devcontainer.InternalServerURL(v1alpha1.DistributionKind, "dev"),
+	)
+	assert.Equal(t,
+		"https://k3d-dev-server-0:6443",
+		devcontainer.InternalServerURL(v1alpha1.DistributionK3d, "dev"),
+	)
+}
+
+func TestConnectContainer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connects when detached", func(t *testing.T) {
+		t.Parallel()
+
+		docker := dockerclient.NewMockAPIClient(t)
+		docker.EXPECT().ContainerInspect(mock.Anything, "devcontainer-1").
+			Return(container.InspectResponse{NetworkSettings: &container.NetworkSettings{
+				Networks: map[string]*network.EndpointSettings{},
+			}}, nil).
+			Once()
+		docker.EXPECT().NetworkConnect(mock.Anything, "kind", "devcontainer-1", mock.Anything).
+			Return(nil).
+			Once()
+
+		err := devcontainer.ConnectContainer(context.Background(), docker, "kind", "devcontainer-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("skips when attached", func(t *testing.T) {
+		t.Parallel()
+
+		docker := dockerclient.NewMockAPIClient(t)
+		docker.EXPECT().ContainerInspect(mock.Anything, "devcontainer-1").
+			Return(container.InspectResponse{NetworkSettings: &container.NetworkSettings{
+				Networks: map[string]*network.EndpointSettings{"kind": {}},
+			}}, nil).
+			Once()
+
+		err := devcontainer.ConnectContainer(context.Background(), docker, "kind", "devcontainer-1")
+		require.NoError(t, err)
+	})
+}
+
+const sourceKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: kind-dev
+  cluster:
+    server: https://127.0.0.1:6443
+- name: other
+  cluster:
+    server: https://other.example.com
+contexts:
+- name: kind-dev
+  context:
+    cluster: kind-dev
+    user: kind-dev
+- name: other
+  context:
+    cluster: other
+    user: other
+current-context: other
+users:
+- name: kind-dev
+  user:
+    token: dev-token
+- name: other
+  user:
+    token: other-token
+`
+
+func TestExportKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(source, []byte(sourceKubeconfig), 0o600))
+
+	dest := filepath.Join(dir, ".ksail", "kubeconfig")
+
+	err := devcontainer.ExportKubeconfig(source, "kind-dev", dest, "https://dev-control-plane:6443")
+	require.NoError(t, err)
+
+	exported, err := clientcmd.LoadFromFile(dest)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kind-dev", exported.CurrentContext)
+	assert.Len(t, exported.Contexts, 1)
+	assert.Len(t, exported.Clusters, 1)
+	assert.Equal(t, "https://dev-control-plane:6443", exported.Clusters["kind-dev"].Server)
+	assert.Equal(t, "dev-token", exported.AuthInfos["kind-dev"].Token)
+
+	err = devcontainer.ExportKubeconfig(source, "missing", dest, "")
+	require.ErrorIs(t, err, devcontainer.ErrContextNotFound)
+}
@@ -0,0 +1,8 @@
+// Package devcontainer integrates KSail clusters with development containers and Codespaces.
+//
+// It detects whether KSail runs inside a devcontainer and how the container reaches Docker:
+// through a daemon running inside the container (docker-in-docker) or through the host's
+// socket (docker-outside-of-docker). In the latter case the cluster's API server is not
+// reachable on localhost, so the devcontainer is attached to the cluster network and the
+// exported kubeconfig points at the control plane container instead.
+package devcontainer
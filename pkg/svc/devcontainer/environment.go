@@ -0,0 +1,105 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/client"
+)
+
+// DockerMode describes how the current process reaches the Docker daemon.
+type DockerMode int
+
+const (
+	// ModeHost means KSail runs directly on the Docker host.
+	ModeHost DockerMode = iota
+	// ModeDockerInDocker means KSail runs in a container that hosts its own Docker daemon.
+	ModeDockerInDocker
+	// ModeDockerOutsideOfDocker means KSail runs in a container that uses the host's Docker daemon.
+	ModeDockerOutsideOfDocker
+)
+
+// dockerEnvFile is created by Docker in the root of every container.
+const dockerEnvFile = "/.dockerenv"
+
+// String returns a readable name for the mode.
+func (m DockerMode) String() string {
+	switch m {
+	case ModeHost:
+		return "host"
+	case ModeDockerInDocker:
+		return "docker-in-docker"
+	case ModeDockerOutsideOfDocker:
+		return "docker-outside-of-docker"
+	default:
+		return "unknown"
+	}
+}
+
+// Environment describes the devcontainer KSail runs in.
+type Environment struct {
+	// InContainer reports whether KSail runs inside a container.
+	InContainer bool
+	// ContainerID identifies the current container for Docker API calls (its hostname).
+	ContainerID string
+	// Mode describes how the Docker daemon is reached.
+	Mode DockerMode
+}
+
+// Detector inspects the process environment. Its functions are injectable for tests.
+type Detector struct {
+	Getenv   func(string) string
+	Stat     func(string) (os.FileInfo, error)
+	Hostname func() (string, error)
+}
+
+// NewDetector creates a Detector backed by the operating system.
+func NewDetector() Detector {
+	return Detector{
+		Getenv:   os.Getenv,
+		Stat:     os.Stat,
+		Hostname: os.Hostname,
+	}
+}
+
+// Detect determines whether KSail runs in a container and which Docker mode applies.
+//
+// A container is recognized by /.dockerenv or by the REMOTE_CONTAINERS and CODESPACES
+// variables set by VS Code and GitHub Codespaces. Inside a container, a Docker daemon
+// reporting the container's own hostname is treated as docker-in-docker; any other daemon
+// is the host's.
+func (d Detector) Detect(ctx context.Context, dockerClient client.APIClient) (Environment, error) {
+	if !d.inContainer() {
+		return Environment{Mode: ModeHost}, nil
+	}
+
+	hostname, err := d.Hostname()
+	if err != nil {
+		return Environment{}, fmt.Errorf("resolve container hostname: %w", err)
+	}
+
+	info, err := dockerClient.Info(ctx)
+	if err != nil {
+		return Environment{}, fmt.Errorf("inspect docker daemon: %w", err)
+	}
+
+	env := Environment{InContainer: true, ContainerID: hostname, Mode: ModeDockerOutsideOfDocker}
+	if strings.EqualFold(info.Name, hostname) {
+		env.Mode = ModeDockerInDocker
+	}
+
+	return env, nil
+}
+
+func (d Detector) inContainer() bool {
+	if strings.EqualFold(d.Getenv("REMOTE_CONTAINERS"), "true") ||
+		strings.EqualFold(d.Getenv("CODESPACES"), "true") {
+		return true
+	}
+
+	_, err := d.Stat(dockerEnvFile)
+
+	return err == nil
+}
@@ -0,0 +1,95 @@
+package devcontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	kubeconfigDirPerm  = 0o750
+	kubeconfigFilePerm = 0o600
+)
+
+// ErrContextNotFound is returned when the requested context is missing from the kubeconfig.
+var ErrContextNotFound = errors.New("context not found in kubeconfig")
+
+// ExportKubeconfig writes a standalone kubeconfig containing only contextName to dest.
+// When server is non-empty it replaces the API server address of the context's cluster.
+func ExportKubeconfig(src, contextName, dest, server string) error {
+	config, err := clientcmd.LoadFromFile(src)
+	if err != nil {
+		return fmt.Errorf("load kubeconfig %s: %w", src, err)
+	}
+
+	if _, exists := config.Contexts[contextName]; !exists {
+		return fmt.Errorf("%w: %s", ErrContextNotFound, contextName)
+	}
+
+	config.CurrentContext = contextName
+
+	err = clientcmdapi.MinifyConfig(config)
+	if err != nil {
+		return fmt.Errorf("minify kubeconfig: %w", err)
+	}
+
+	err = clientcmdapi.FlattenConfig(config)
+	if err != nil {
+		return fmt.Errorf("flatten kubeconfig: %w", err)
+	}
+
+	if server != "" {
+		clusterName := config.Contexts[contextName].Cluster
+		config.Clusters[clusterName].Server = server
+	}
+
+	err = os.MkdirAll(filepath.Dir(dest), kubeconfigDirPerm)
+	if err != nil {
+		return fmt.Errorf("create kubeconfig directory: %w", err)
+	}
+
+	content, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("serialize kubeconfig: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(dest, content, kubeconfigFilePerm)
+	if err != nil {
+		return fmt.Errorf("write kubeconfig %s: %w", dest, err)
+	}
+
+	return nil
+}
+
+// WaitForAPIServer polls the API server of contextName until it answers or timeout elapses.
+func WaitForAPIServer(ctx context.Context, kubeconfig, contextName string, timeout time.Duration) error {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfig, contextName)
+	if err != nil {
+		return fmt.Errorf("build rest config: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create discovery client: %w", err)
+	}
+
+	err = k8s.PollForReadiness(ctx, timeout, func(context.Context) (bool, error) {
+		_, versionErr := discoveryClient.ServerVersion()
+
+		return versionErr == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for api server: %w", err)
+	}
+
+	return nil
+}
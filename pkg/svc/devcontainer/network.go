@@ -0,0 +1,56 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+const (
+	k3dNetworkPrefix  = "k3d-"
+	apiServerPortPath = ":6443"
+)
+
+// InternalServerURL returns the API server address reachable from the cluster network.
+func InternalServerURL(distribution v1alpha1.Distribution, clusterName string) string {
+	switch distribution {
+	case v1alpha1.DistributionKind:
+		return "https://" + clusterName + "-control-plane" + apiServerPortPath
+	case v1alpha1.DistributionK3d:
+		return "https://" + k3dNetworkPrefix + clusterName + "-server-0" + apiServerPortPath
+	case v1alpha1.DistributionTalos:
+		return "https://" + clusterName + "-controlplane-1" + apiServerPortPath
+	case v1alpha1.DistributionK0s:
+		return "https://" + clusterName + "-controller" + apiServerPortPath
+	default:
+		return ""
+	}
+}
+
+// ConnectContainer attaches containerID to networkName unless it is already attached.
+func ConnectContainer(
+	ctx context.Context,
+	dockerClient client.APIClient,
+	networkName, containerID string,
+) error {
+	inspect, err := dockerClient.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("inspect devcontainer: %w", err)
+	}
+
+	if inspect.NetworkSettings != nil {
+		if _, exists := inspect.NetworkSettings.Networks[networkName]; exists {
+			return nil
+		}
+	}
+
+	err = dockerClient.NetworkConnect(ctx, networkName, containerID, &network.EndpointSettings{})
+	if err != nil {
+		return fmt.Errorf("connect devcontainer to network %s: %w", networkName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	gib = 1 << 30
+
+	// MinInotifyWatches is the inotify watch limit kind recommends for multi-node clusters.
+	MinInotifyWatches = 524288
+	// MinInotifyInstances is the inotify instance limit kind recommends for multi-node clusters.
+	MinInotifyInstances = 512
+	// WarnFreeDisk is the free disk space below which node images and volumes may not fit.
+	WarnFreeDisk = 10 * gib
+	// MinFreeDisk is the free disk space below which clusters cannot be created.
+	MinFreeDisk = 2 * gib
+)
+
+//nolint:gochecknoglobals // Kernel modules each CNI depends on, checked before creating clusters.
+var cniKernelModules = map[v1alpha1.CNI][]string{
+	v1alpha1.CNICilium: {"cls_bpf", "sch_ingress", "vxlan"},
+	v1alpha1.CNICalico: {"ip_set", "ip_tables", "xt_set", "xt_mark", "xt_conntrack", "ipip", "vxlan"},
+}
+
+// ContainerEngineCheck verifies that Docker or Podman answers on the configured socket.
+func ContainerEngineCheck() Check {
+	return Check{
+		Name: "container engine",
+		Run: func(ctx context.Context, env Environment) Result {
+			if env.Ping == nil {
+				return Result{Status: StatusFailed, Message: "no container engine client configured"}
+			}
+
+			engine, err := env.Ping(ctx)
+			if err != nil {
+				return Result{
+					Status:  StatusFailed,
+					Message: fmt.Sprintf("container engine is not reachable: %v", err),
+					Fix:     "Start Docker or Podman, or point DOCKER_HOST at the socket of a running engine",
+				}
+			}
+
+			return Result{Status: StatusOK, Message: engine + " is reachable"}
+		},
+	}
+}
+
+// CgroupV2Check verifies that the host mounts the unified cgroup v2 hierarchy. Node images
+// still boot on cgroup v1, but support for it is deprecated.
+func CgroupV2Check() Check {
+	return Check{
+		Name: "cgroup v2",
+		Run: func(_ context.Context, env Environment) Result {
+			if env.OS != "linux" {
+				return skipped("cgroups are managed by the container engine's VM on " + env.OS)
+			}
+
+			_, err := fs.Stat(env.FS, "sys/fs/cgroup/cgroup.controllers")
+			if err != nil {
+				return Result{
+					Status:  StatusWarning,
+					Message: "the host does not use the unified cgroup v2 hierarchy",
+					Fix:     "Boot with systemd.unified_cgroup_hierarchy=1 on the kernel command line",
+				}
+			}
+
+			return Result{Status: StatusOK, Message: "unified cgroup v2 hierarchy mounted"}
+		},
+	}
+}
+
+// InotifyCheck verifies that the inotify limits allow several nodes to watch files.
+func InotifyCheck() Check {
+	return Check{
+		Name: "inotify limits",
+		Run: func(_ context.Context, env Environment) Result {
+			if env.OS != "linux" {
+				return skipped("inotify limits are managed by the container engine's VM on " + env.OS)
+			}
+
+			limits := []struct {
+				key     string
+				minimum int
+			}{
+				{key: "fs.inotify.max_user_watches", minimum: MinInotifyWatches},
+				{key: "fs.inotify.max_user_instances", minimum: MinInotifyInstances},
+			}
+
+			var low, fixes []string
+
+			for _, limit := range limits {
+				value, err := readInt(env.FS, "proc/sys/"+strings.ReplaceAll(limit.key, ".", "/"))
+				if err != nil {
+					return Result{Status: StatusWarning, Message: fmt.Sprintf("cannot read %s: %v", limit.key, err)}
+				}
+
+				if value < limit.minimum {
+					low = append(low, fmt.Sprintf("%s is %d", limit.key, value))
+					fixes = append(fixes, fmt.Sprintf("sudo sysctl %s=%d", limit.key, limit.minimum))
+				}
+			}
+
+			if len(low) > 0 {
+				return Result{
+					Status:  StatusWarning,
+					Message: strings.Join(low, ", ") + "; multi-node clusters may run out of file watches",
+					Fix:     "Run " + strings.Join(fixes, " and ") + ", and persist them in /etc/sysctl.d",
+				}
+			}
+
+			return Result{Status: StatusOK, Message: "inotify limits are high enough for multi-node clusters"}
+		},
+	}
+}
+
+// DiskSpaceCheck verifies that the volume of dir has room for node images and volumes.
+func DiskSpaceCheck(dir string) Check {
+	return Check{
+		Name: "free disk space",
+		Run: func(_ context.Context, env Environment) Result {
+			if env.FreeDiskSpace == nil {
+				return skipped("free disk space cannot be measured on " + env.OS)
+			}
+
+			free, err := env.FreeDiskSpace(dir)
+			if errors.Is(err, errors.ErrUnsupported) {
+				return skipped("free disk space cannot be measured on " + env.OS)
+			}
+
+			if err != nil {
+				return Result{Status: StatusWarning, Message: fmt.Sprintf("cannot measure free disk space: %v", err)}
+			}
+
+			message := fmt.Sprintf("%.1f GiB free on the volume of %s", float64(free)/gib, dir)
+			fix := "Free disk space, e.g. with docker system prune, or grow the container engine's disk"
+
+			switch {
+			case free < MinFreeDisk:
+				return Result{Status: StatusFailed, Message: message, Fix: fix}
+			case free < WarnFreeDisk:
+				return Result{Status: StatusWarning, Message: message, Fix: fix}
+			default:
+				return Result{Status: StatusOK, Message: message}
+			}
+		},
+	}
+}
+
+// PortsCheck verifies that the host ports a cluster publishes are free. ports maps each
+// port to what publishes it, e.g. "local registry".
+func PortsCheck(ports map[int]string) Check {
+	return Check{
+		Name: "port conflicts",
+		Run: func(_ context.Context, env Environment) Result {
+			if len(ports) == 0 {
+				return skipped("the cluster publishes no fixed host ports")
+			}
+
+			numbers := make([]int, 0, len(ports))
+			for port := range ports {
+				numbers = append(numbers, port)
+			}
+
+			slices.Sort(numbers)
+
+			var busy []string
+
+			for _, port := range numbers {
+				listener, err := env.Listen("tcp", ":"+strconv.Itoa(port))
+				if err != nil {
+					busy = append(busy, fmt.Sprintf("%d (%s)", port, ports[port]))
+
+					continue
+				}
+
+				_ = listener.Close()
+			}
+
+			if len(busy) > 0 {
+				return Result{
+					Status:  StatusFailed,
+					Message: "ports in use: " + strings.Join(busy, ", "),
+					Fix:     "Stop the process using the port, or configure another port in ksail.yaml or the distribution config",
+				}
+			}
+
+			return Result{Status: StatusOK, Message: fmt.Sprintf("%d host ports are free", len(numbers))}
+		},
+	}
+}
+
+// KernelModulesCheck verifies that the kernel modules cni depends on are loaded or built in.
+func KernelModulesCheck(cni v1alpha1.CNI) Check {
+	return Check{
+		Name: "kernel modules",
+		Run: func(_ context.Context, env Environment) Result {
+			modules, ok := cniKernelModules[cni]
+			if !ok {
+				return skipped("the default CNI needs no extra kernel modules")
+			}
+
+			if env.OS != "linux" {
+				return skipped("kernel modules are managed by the container engine's VM on " + env.OS)
+			}
+
+			builtin := builtinModules(env.FS)
+
+			var missing []string
+
+			for _, module := range modules {
+				_, err := fs.Stat(env.FS, path.Join("sys/module", module))
+				if err != nil && !builtin[module] {
+					missing = append(missing, module)
+				}
+			}
+
+			if len(missing) > 0 {
+				return Result{
+					Status:  StatusWarning,
+					Message: fmt.Sprintf("%s needs kernel modules that are not loaded: %s", cni, strings.Join(missing, ", ")),
+					Fix:     "Run sudo modprobe -a " + strings.Join(missing, " "),
+				}
+			}
+
+			return Result{Status: StatusOK, Message: fmt.Sprintf("kernel modules for %s are available", cni)}
+		},
+	}
+}
+
+// builtinModules returns the modules compiled into the running kernel.
+func builtinModules(fsys fs.FS) map[string]bool {
+	release, err := fs.ReadFile(fsys, "proc/sys/kernel/osrelease")
+	if err != nil {
+		return nil
+	}
+
+	data, err := fs.ReadFile(fsys, path.Join("lib/modules", strings.TrimSpace(string(release)), "modules.builtin"))
+	if err != nil {
+		return nil
+	}
+
+	modules := map[string]bool{}
+
+	for line := range strings.Lines(string(data)) {
+		name := strings.TrimSuffix(path.Base(strings.TrimSpace(line)), ".ko")
+		if name != "" {
+			modules[name] = true
+		}
+	}
+
+	return modules
+}
+
+func readInt(fsys fs.FS, name string) (int, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+func skipped(message string) Result {
+	return Result{Status: StatusSkipped, Message: message}
+}
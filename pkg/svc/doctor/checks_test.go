@@ -0,0 +1,149 @@
+package doctor_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"testing"
+	"testing/fstest"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/svc/doctor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRefused = errors.New("connection refused")
+
+func healthyHost() fstest.MapFS {
+	return fstest.MapFS{
+		"sys/fs/cgroup/cgroup.controllers":       {Data: []byte("cpu memory pids\n")},
+		"proc/sys/fs/inotify/max_user_watches":   {Data: []byte("1048576\n")},
+		"proc/sys/fs/inotify/max_user_instances": {Data: []byte("8192\n")},
+		"proc/sys/kernel/osrelease":              {Data: []byte("6.8.0-test\n")},
+		"lib/modules/6.8.0-test/modules.builtin": {Data: []byte("kernel/net/sched/cls_bpf.ko\n")},
+		"sys/module/sch_ingress":                 {Mode: fs.ModeDir | 0o555},
+		"sys/module/vxlan":                       {Mode: fs.ModeDir | 0o555},
+	}
+}
+
+func newEnvironment(fsys fstest.MapFS) doctor.Environment {
+	return doctor.Environment{
+		OS: "linux",
+		FS: fsys,
+		FreeDiskSpace: func(string) (uint64, error) {
+			return 50 << 30, nil
+		},
+		Listen: func(string, string) (net.Listener, error) {
+			return fakeListener{}, nil
+		},
+		Ping: func(context.Context) (string, error) {
+			return "Docker Engine - Community 28.5.1", nil
+		},
+	}
+}
+
+func TestRunPassesOnHealthyHost(t *testing.T) {
+	t.Parallel()
+
+	results := doctor.Run(context.Background(), newEnvironment(healthyHost()), []doctor.Check{
+		doctor.ContainerEngineCheck(),
+		doctor.CgroupV2Check(),
+		doctor.InotifyCheck(),
+		doctor.DiskSpaceCheck("/"),
+		doctor.PortsCheck(map[int]string{5111: "local registry"}),
+		doctor.KernelModulesCheck(v1alpha1.CNICilium),
+	})
+
+	require.Len(t, results, 6)
+
+	for _, result := range results {
+		assert.Equal(t, doctor.StatusOK, result.Status, "%s: %s", result.Name, result.Message)
+	}
+
+	assert.Equal(t, "container engine", results[0].Name)
+	assert.Equal(t, "Docker Engine - Community 28.5.1 is reachable", results[0].Message)
+	assert.False(t, doctor.Failed(results))
+}
+
+func TestRunReportsProblemsWithFixes(t *testing.T) {
+	t.Parallel()
+
+	host := healthyHost()
+	delete(host, "sys/fs/cgroup/cgroup.controllers")
+	host["proc/sys/fs/inotify/max_user_watches"] = &fstest.MapFile{Data: []byte("8192\n")}
+
+	env := newEnvironment(host)
+	env.Ping = func(context.Context) (string, error) {
+		return "", errRefused
+	}
+	env.FreeDiskSpace = func(string) (uint64, error) {
+		return 5 << 30, nil
+	}
+	env.Listen = func(_, address string) (net.Listener, error) {
+		if address == ":5111" {
+			return nil, errRefused
+		}
+
+		return fakeListener{}, nil
+	}
+
+	results := doctor.Run(context.Background(), env, []doctor.Check{
+		doctor.ContainerEngineCheck(),
+		doctor.CgroupV2Check(),
+		doctor.InotifyCheck(),
+		doctor.DiskSpaceCheck("/"),
+		doctor.PortsCheck(map[int]string{5111: "local registry", 6443: "API server"}),
+		doctor.KernelModulesCheck(v1alpha1.CNICalico),
+	})
+
+	statuses := make([]doctor.Status, 0, len(results))
+	for _, result := range results {
+		statuses = append(statuses, result.Status)
+
+		assert.NotEmpty(t, result.Fix, result.Name)
+	}
+
+	assert.Equal(t, []doctor.Status{
+		doctor.StatusFailed,
+		doctor.StatusWarning,
+		doctor.StatusWarning,
+		doctor.StatusWarning,
+		doctor.StatusFailed,
+		doctor.StatusWarning,
+	}, statuses)
+	assert.True(t, doctor.Failed(results))
+
+	assert.Contains(t, results[2].Fix, "sudo sysctl fs.inotify.max_user_watches=524288")
+	assert.NotContains(t, results[2].Fix, "max_user_instances")
+	assert.Equal(t, "ports in use: 5111 (local registry)", results[4].Message)
+	assert.Contains(t, results[5].Fix, "sudo modprobe -a ip_set ip_tables xt_set xt_mark xt_conntrack ipip")
+}
+
+func TestRunSkipsChecksThatDoNotApply(t *testing.T) {
+	t.Parallel()
+
+	env := newEnvironment(fstest.MapFS{})
+	env.OS = "darwin"
+	env.FreeDiskSpace = func(string) (uint64, error) {
+		return 0, errors.ErrUnsupported
+	}
+
+	results := doctor.Run(context.Background(), env, []doctor.Check{
+		doctor.CgroupV2Check(),
+		doctor.InotifyCheck(),
+		doctor.DiskSpaceCheck("/"),
+		doctor.PortsCheck(nil),
+		doctor.KernelModulesCheck(v1alpha1.CNIDefault),
+		doctor.KernelModulesCheck(v1alpha1.CNICilium),
+	})
+
+	for _, result := range results {
+		assert.Equal(t, doctor.StatusSkipped, result.Status, "%s: %s", result.Name, result.Message)
+	}
+}
+
+type fakeListener struct{ net.Listener }
+
+func (fakeListener) Close() error { return nil }
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package doctor
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func freeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", path, err)
+	}
+
+	//nolint:gosec // Block sizes are positive.
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
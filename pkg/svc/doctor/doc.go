@@ -0,0 +1,7 @@
+// Package doctor runs preflight checks of the host environment a cluster is created on.
+//
+// Each Check inspects one precondition, such as a reachable container engine, cgroup v2,
+// inotify limits, free disk space, free ports or the kernel modules a CNI depends on, and
+// reports a Result with a suggested fix when the precondition does not hold. Checks read the
+// host through an Environment so they can be tested against fake file systems.
+package doctor
@@ -0,0 +1,95 @@
+package doctor
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	goruntime "runtime"
+)
+
+// Status is the outcome of a check.
+type Status int
+
+const (
+	// StatusOK means the precondition holds.
+	StatusOK Status = iota
+	// StatusWarning means clusters may work, but some features or larger clusters may not.
+	StatusWarning
+	// StatusFailed means clusters cannot be created until the problem is fixed.
+	StatusFailed
+	// StatusSkipped means the check does not apply to this host or configuration.
+	StatusSkipped
+)
+
+// Result reports the outcome of one check.
+type Result struct {
+	// Name identifies the check, e.g. "cgroup v2".
+	Name string
+	// Status is the outcome of the check.
+	Status Status
+	// Message describes what the check found.
+	Message string
+	// Fix suggests how to resolve a warning or failure.
+	Fix string
+}
+
+// Check inspects one precondition of the host.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context, env Environment) Result
+}
+
+// Pinger reports whether the container engine answers, and what it is.
+type Pinger func(ctx context.Context) (string, error)
+
+// Environment is the view of the host the checks read.
+type Environment struct {
+	// OS is the operating system, as in runtime.GOOS. Kernel checks only run on linux.
+	OS string
+	// FS is the host file system rooted at "/", used to read /proc and /sys.
+	FS fs.FS
+	// FreeDiskSpace returns the bytes available to unprivileged users on the volume of path.
+	FreeDiskSpace func(path string) (uint64, error)
+	// Listen opens a TCP listener on address, used to probe whether ports are free.
+	Listen func(network, address string) (net.Listener, error)
+	// Ping queries the container engine.
+	Ping Pinger
+}
+
+// HostEnvironment returns the Environment of the running host. ping queries its
+// container engine.
+func HostEnvironment(ping Pinger) Environment {
+	return Environment{
+		OS:            goruntime.GOOS,
+		FS:            os.DirFS("/"),
+		FreeDiskSpace: freeDiskSpace,
+		Listen:        net.Listen,
+		Ping:          ping,
+	}
+}
+
+// Run runs every check in order and returns their results.
+func Run(ctx context.Context, env Environment, checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+
+	for _, check := range checks {
+		result := check.Run(ctx, env)
+		result.Name = check.Name
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// Failed reports whether any result failed.
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
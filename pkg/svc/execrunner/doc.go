@@ -0,0 +1,3 @@
+// Package execrunner runs the external binaries KSail drives, such as docker compose, ssh,
+// colima and git, behind a Runner function services accept so tests can replace it.
+package execrunner
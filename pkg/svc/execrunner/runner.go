@@ -0,0 +1,58 @@
+package execrunner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+const outputFilePrefix = "ksail-exec-"
+
+// Runner executes name with args and returns its combined output.
+type Runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// Exec runs name with args and returns its combined stdout and stderr.
+func Exec(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- callers run fixed binaries with generated arguments
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	if err != nil {
+		return output.Bytes(), fmt.Errorf("run %s: %w", name, err)
+	}
+
+	return output.Bytes(), nil
+}
+
+// ExecToFile runs name with args like Exec, capturing the output in a temporary file rather
+// than a pipe, for commands that leave a background process holding their output open.
+func ExecToFile(ctx context.Context, name string, args ...string) ([]byte, error) {
+	outputFile, err := os.CreateTemp("", outputFilePrefix+"*.log")
+	if err != nil {
+		return nil, fmt.Errorf("create %s output file: %w", name, err)
+	}
+
+	defer func() {
+		_ = outputFile.Close()
+		_ = os.Remove(outputFile.Name())
+	}()
+
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- callers run fixed binaries with generated arguments
+	cmd.Stdout = outputFile
+	cmd.Stderr = outputFile
+
+	runErr := cmd.Run()
+
+	output, _ := os.ReadFile(outputFile.Name())
+
+	if runErr != nil {
+		return output, fmt.Errorf("run %s: %w", name, runErr)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,40 @@
+package execrunner_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnersCombineOutputAndReportFailures(t *testing.T) {
+	t.Parallel()
+
+	_, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	for name, runner := range map[string]execrunner.Runner{
+		"Exec":       execrunner.Exec,
+		"ExecToFile": execrunner.ExecToFile,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := runner(context.Background(), "sh", "-c", "echo out; echo err >&2")
+			require.NoError(t, err)
+			assert.Equal(t, "out\nerr\n", string(output))
+
+			output, err = runner(context.Background(), "sh", "-c", "echo failed; exit 3")
+
+			var exitErr *exec.ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, 3, exitErr.ExitCode())
+			assert.Equal(t, "failed\n", string(output))
+		})
+	}
+}
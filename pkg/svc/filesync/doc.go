@@ -0,0 +1,7 @@
+// Package filesync syncs local files into running pods for hot-reload development loops.
+//
+// A Syncer copies changed files into every target container by streaming a tar archive to
+// `tar -x` over the Kubernetes exec API, removes files that were deleted locally, and can
+// send a signal to the container's main process so interpreted runtimes reload the new code.
+// Watch reports batches of changed files below the synced directories.
+package filesync
@@ -0,0 +1,72 @@
+package filesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodExecutor runs commands in pods through the Kubernetes exec API.
+type PodExecutor struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+// NewPodExecutor creates a PodExecutor for the cluster behind config.
+func NewPodExecutor(config *rest.Config) (*PodExecutor, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	return &PodExecutor{config: config, clientset: clientset}, nil
+}
+
+// Exec runs command in the target container. The command's stderr is included in the error
+// when it fails.
+func (e *PodExecutor) Exec(ctx context.Context, target Target, command []string, stdin io.Reader) error {
+	request := e.clientset.CoreV1().RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: target.Container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", request.URL())
+	if err != nil {
+		return fmt.Errorf("create exec stream: %w", err)
+	}
+
+	var stderr bytes.Buffer
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: io.Discard,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message != "" {
+			return fmt.Errorf("%s: %w: %s", command[0], err, message)
+		}
+
+		return fmt.Errorf("%s: %w", command[0], err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package filesync_test
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/filesync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type execCall struct {
+	target  filesync.Target
+	command []string
+	files   map[string]string
+}
+
+type recordingExecutor struct {
+	calls []execCall
+}
+
+func (r *recordingExecutor) Exec(
+	_ context.Context,
+	target filesync.Target,
+	command []string,
+	stdin io.Reader,
+) error {
+	call := execCall{target: target, command: command}
+
+	if stdin != nil {
+		call.files = map[string]string{}
+		reader := tar.NewReader(stdin)
+
+		for {
+			header, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return err
+			}
+
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+
+			call.files[header.Name] = string(content)
+		}
+	}
+
+	r.calls = append(r.calls, call)
+
+	return nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestParseMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping, err := filesync.ParseMapping("src:/app/src/")
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, filesync.Mapping{Local: filepath.Join(wd, "src"), Remote: "/app/src"}, mapping)
+
+	for _, spec := range []string{"src", ":/app", "src:", "src:app"} {
+		_, err = filesync.ParseMapping(spec)
+		require.ErrorIs(t, err, filesync.ErrInvalidMapping, spec)
+	}
+}
+
+func TestSyncCopiesAndRemovesFiles(t *testing.T) {
+	t.Parallel()
+
+	local := t.TempDir()
+	writeFile(t, filepath.Join(local, "app.py"), "print('hi')")
+	writeFile(t, filepath.Join(local, "pkg", "util.py"), "x = 1")
+
+	executor := &recordingExecutor{}
+	syncer := filesync.NewSyncer(executor, []filesync.Mapping{{Local: local, Remote: "/app"}}, "HUP")
+	targets := []filesync.Target{
+		{Namespace: "default", Pod: "web-1", Container: "web"},
+		{Namespace: "default", Pod: "web-2", Container: "web"},
+	}
+
+	result, err := syncer.Sync(context.Background(), targets, []string{
+		filepath.Join(local, "app.py"),
+		filepath.Join(local, "pkg", "util.py"),
+		filepath.Join(local, "deleted.py"),
+		filepath.Join(t.TempDir(), "outside.py"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, filesync.Result{Copied: 2, Removed: 1}, result)
+	require.Len(t, executor.calls, 6)
+
+	for i, target := range targets {
+		copyCall := executor.calls[i*2]
+		assert.Equal(t, target, copyCall.target)
+		assert.Equal(t, []string{"tar", "-xmf", "-", "-C", "/app"}, copyCall.command)
+		assert.Equal(t, map[string]string{"app.py": "print('hi')", "pkg/util.py": "x = 1"}, copyCall.files)
+
+		removeCall := executor.calls[i*2+1]
+		assert.Equal(t, []string{"rm", "-rf", "--", "/app/deleted.py"}, removeCall.command)
+
+		signalCall := executor.calls[4+i]
+		assert.Equal(t, target, signalCall.target)
+		assert.Equal(t, []string{"kill", "-HUP", "1"}, signalCall.command)
+	}
+}
+
+func TestSyncAllCopiesEveryFileWithoutSignal(t *testing.T) {
+	t.Parallel()
+
+	local := t.TempDir()
+	writeFile(t, filepath.Join(local, "a.txt"), "a")
+	writeFile(t, filepath.Join(local, "nested", "b.txt"), "b")
+
+	executor := &recordingExecutor{}
+	syncer := filesync.NewSyncer(executor, []filesync.Mapping{{Local: local, Remote: "/srv"}}, "")
+
+	result, err := syncer.SyncAll(context.Background(), []filesync.Target{{Namespace: "dev", Pod: "api"}})
+	require.NoError(t, err)
+	assert.Equal(t, filesync.Result{Copied: 2}, result)
+	require.Len(t, executor.calls, 1)
+	assert.Equal(t, map[string]string{"a.txt": "a", "nested/b.txt": "b"}, executor.calls[0].files)
+}
+
+func TestResolveTargets(t *testing.T) {
+	t.Parallel()
+
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev", Labels: map[string]string{"app": "web"}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	clientset := fake.NewClientset(pod("web-1", corev1.PodRunning), pod("web-2", corev1.PodPending))
+
+	targets, err := filesync.ResolveTargets(context.Background(), clientset, "dev", "app=web", "web")
+	require.NoError(t, err)
+	assert.Equal(t, []filesync.Target{{Namespace: "dev", Pod: "web-1", Container: "web"}}, targets)
+	assert.Equal(t, "dev/web-1:web", targets[0].String())
+
+	_, err = filesync.ResolveTargets(context.Background(), clientset, "dev", "app=api", "")
+	require.ErrorIs(t, err, filesync.ErrNoTargets)
+}
+
+func TestWatchReportsDebouncedChanges(t *testing.T) {
+	t.Parallel()
+
+	local := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		batches [][]string
+	)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- filesync.Watch(ctx, []filesync.Mapping{{Local: local, Remote: "/app"}}, 50*time.Millisecond,
+			func(paths []string) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				batches = append(batches, paths)
+
+				if strings.HasSuffix(paths[len(paths)-1], "main.go") {
+					cancel()
+				}
+
+				return nil
+			})
+	}()
+
+	// Give the watcher time to register before writing.
+	time.Sleep(200 * time.Millisecond)
+	writeFile(t, filepath.Join(local, "main.go"), "package main")
+
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.NotEmpty(t, batches)
+	assert.Contains(t, batches[len(batches)-1], filepath.Join(local, "main.go"))
+}
@@ -0,0 +1,66 @@
+package filesync
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidMapping is returned when a sync mapping cannot be parsed.
+var ErrInvalidMapping = errors.New("invalid sync mapping")
+
+// Mapping pairs a local directory with the directory it is synced to inside the container.
+type Mapping struct {
+	// Local is the absolute local directory.
+	Local string
+	// Remote is the absolute directory inside the container.
+	Remote string
+}
+
+// ParseMapping parses a "local:remote" specification. The local directory is made absolute.
+func ParseMapping(spec string) (Mapping, error) {
+	separator := strings.LastIndex(spec, ":")
+	if separator <= 0 || separator == len(spec)-1 {
+		return Mapping{}, fmt.Errorf("%w: %q (expected local:remote)", ErrInvalidMapping, spec)
+	}
+
+	remote := strings.TrimSpace(spec[separator+1:])
+	if !path.IsAbs(remote) {
+		return Mapping{}, fmt.Errorf("%w: %q (remote path must be absolute)", ErrInvalidMapping, spec)
+	}
+
+	local, err := filepath.Abs(strings.TrimSpace(spec[:separator]))
+	if err != nil {
+		return Mapping{}, fmt.Errorf("resolve local path of %q: %w", spec, err)
+	}
+
+	return Mapping{Local: local, Remote: path.Clean(remote)}, nil
+}
+
+// ParseMappings parses every specification with ParseMapping.
+func ParseMappings(specs []string) ([]Mapping, error) {
+	mappings := make([]Mapping, 0, len(specs))
+
+	for _, spec := range specs {
+		mapping, err := ParseMapping(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+// relative returns the slash-separated path of localPath below the mapping's local directory.
+func (m Mapping) relative(localPath string) (string, bool) {
+	rel, err := filepath.Rel(m.Local, localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
@@ -0,0 +1,231 @@
+package filesync
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Target identifies a container that receives synced files.
+type Target struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// String returns the target as namespace/pod[:container].
+func (t Target) String() string {
+	if t.Container == "" {
+		return t.Namespace + "/" + t.Pod
+	}
+
+	return t.Namespace + "/" + t.Pod + ":" + t.Container
+}
+
+// Executor runs a command inside a target container, streaming stdin to it.
+type Executor interface {
+	Exec(ctx context.Context, target Target, command []string, stdin io.Reader) error
+}
+
+// Result summarizes one sync operation.
+type Result struct {
+	Copied  int
+	Removed int
+}
+
+// Syncer copies local changes into target containers.
+type Syncer struct {
+	executor Executor
+	mappings []Mapping
+	signal   string
+}
+
+// NewSyncer creates a Syncer. When signal is non-empty (for example "HUP"), it is sent to
+// process 1 of each target after files were synced.
+func NewSyncer(executor Executor, mappings []Mapping, signal string) *Syncer {
+	return &Syncer{executor: executor, mappings: mappings, signal: strings.TrimSpace(signal)}
+}
+
+// SyncAll copies every file below the mapped local directories into the targets.
+func (s *Syncer) SyncAll(ctx context.Context, targets []Target) (Result, error) {
+	var paths []string
+
+	for _, mapping := range s.mappings {
+		err := filepath.WalkDir(mapping.Local, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.Type().IsRegular() {
+				paths = append(paths, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("walk %s: %w", mapping.Local, err)
+		}
+	}
+
+	return s.Sync(ctx, targets, paths)
+}
+
+// Sync copies the given local files into the targets. Paths that no longer exist locally are
+// removed from the containers; paths outside every mapping are ignored.
+func (s *Syncer) Sync(ctx context.Context, targets []Target, paths []string) (Result, error) {
+	var result Result
+
+	for _, mapping := range s.mappings {
+		copied, removed := s.partition(mapping, paths)
+		if len(copied) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		for _, target := range targets {
+			err := s.apply(ctx, target, mapping, copied, removed)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		result.Copied += len(copied)
+		result.Removed += len(removed)
+	}
+
+	if result.Copied+result.Removed > 0 && s.signal != "" {
+		for _, target := range targets {
+			err := s.executor.Exec(ctx, target, []string{"kill", "-" + s.signal, "1"}, nil)
+			if err != nil {
+				return result, fmt.Errorf("signal %s: %w", target, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// partition splits paths below mapping into files to copy and files to remove, relative to
+// the mapping's local directory.
+func (s *Syncer) partition(mapping Mapping, paths []string) ([]string, []string) {
+	copied := map[string]struct{}{}
+	removed := map[string]struct{}{}
+
+	for _, path := range paths {
+		rel, ok := mapping.relative(path)
+		if !ok || rel == "." {
+			continue
+		}
+
+		info, err := os.Stat(path)
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			removed[rel] = struct{}{}
+		case err == nil && info.Mode().IsRegular():
+			copied[rel] = struct{}{}
+		}
+	}
+
+	return sortedKeys(copied), sortedKeys(removed)
+}
+
+func (s *Syncer) apply(ctx context.Context, target Target, mapping Mapping, copied, removed []string) error {
+	if len(copied) > 0 {
+		archive, err := buildArchive(mapping.Local, copied)
+		if err != nil {
+			return err
+		}
+
+		err = s.executor.Exec(ctx, target, []string{"tar", "-xmf", "-", "-C", mapping.Remote}, archive)
+		if err != nil {
+			return fmt.Errorf("copy files to %s: %w", target, err)
+		}
+	}
+
+	if len(removed) > 0 {
+		command := []string{"rm", "-rf", "--"}
+		for _, rel := range removed {
+			command = append(command, mapping.Remote+"/"+rel)
+		}
+
+		err := s.executor.Exec(ctx, target, command, nil)
+		if err != nil {
+			return fmt.Errorf("remove files from %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
+
+// buildArchive returns a tar archive with the files at rels below root.
+func buildArchive(root string, rels []string) (*bytes.Buffer, error) {
+	var buffer bytes.Buffer
+
+	writer := tar.NewWriter(&buffer)
+
+	for _, rel := range rels {
+		err := addArchiveFile(writer, filepath.Join(root, filepath.FromSlash(rel)), rel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return &buffer, nil
+}
+
+func addArchiveFile(writer *tar.Writer, path, name string) error {
+	file, err := os.Open(path) //nolint:gosec // path comes from a user-selected sync directory
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("archive header for %s: %w", path, err)
+	}
+
+	header.Name = name
+
+	err = writer.WriteHeader(header)
+	if err != nil {
+		return fmt.Errorf("archive %s: %w", path, err)
+	}
+
+	_, err = io.Copy(writer, file)
+	if err != nil {
+		return fmt.Errorf("archive %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,43 @@
+package filesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrNoTargets is returned when no running pod matches the selector.
+var ErrNoTargets = errors.New("no running pods match the selector")
+
+// ResolveTargets returns the running pods in namespace that match selector.
+// An empty container selects each pod's default container.
+func ResolveTargets(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace, selector, container string,
+) ([]Target, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	targets := make([]Target, 0, len(pods.Items))
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		targets = append(targets, Target{Namespace: pod.Namespace, Pod: pod.Name, Container: container})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: %q in namespace %q", ErrNoTargets, selector, namespace)
+	}
+
+	return targets, nil
+}
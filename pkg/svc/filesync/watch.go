@@ -0,0 +1,102 @@
+package filesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch reports changed files below the mapped local directories until ctx is cancelled.
+//
+// Events are collected until no new event arrives for debounce, then onChange receives the
+// batch of changed paths. Directories created while watching are watched as well.
+// An error returned by onChange stops the watch.
+func Watch(ctx context.Context, mappings []Mapping, debounce time.Duration, onChange func([]string) error) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create file watcher: %w", err)
+	}
+
+	defer func() { _ = watcher.Close() }()
+
+	for _, mapping := range mappings {
+		err = addRecursive(watcher, mapping.Local)
+		if err != nil {
+			return err
+		}
+	}
+
+	pending := map[string]struct{}{}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Has(fsnotify.Create) {
+				info, statErr := os.Stat(event.Name)
+				if statErr == nil && info.IsDir() {
+					_ = addRecursive(watcher, event.Name)
+				}
+			}
+
+			// Attribute-only changes do not alter file contents.
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			pending[event.Name] = struct{}{}
+
+			timer.Reset(debounce)
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return fmt.Errorf("watch files: %w", watchErr)
+		case <-timer.C:
+			paths := sortedKeys(pending)
+			pending = map[string]struct{}{}
+
+			err = onChange(paths)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func addRecursive(watcher *fsnotify.Watcher, root string) error {
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		return watcher.Add(path)
+	})
+	if err != nil {
+		return fmt.Errorf("watch %s: %w", root, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const createRepositoryPath = "/api/v1/user/repos"
+
+// ErrUnexpectedStatus is returned when the Gitea API answers with an unexpected status code.
+var ErrUnexpectedStatus = errors.New("unexpected gitea API status")
+
+// APIClient talks to the Gitea REST API as the administrator account.
+type APIClient struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewAPIClient creates an APIClient for the Gitea server at baseURL.
+// A nil httpClient selects http.DefaultClient.
+func NewAPIClient(baseURL, username, password string, httpClient *http.Client) *APIClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &APIClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		username:   username,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+type createRepositoryRequest struct {
+	Name          string `json:"name"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// EnsureRepository creates a public repository named name owned by the client's user.
+// It succeeds when the repository already exists.
+func (c *APIClient) EnsureRepository(ctx context.Context, name string) error {
+	body, err := json.Marshal(createRepositoryRequest{
+		Name:          name,
+		Private:       false,
+		DefaultBranch: DefaultBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("encode repository request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.baseURL+createRepositoryPath,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("build repository request: %w", err)
+	}
+
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("create gitea repository %s: %w", name, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusConflict:
+		return nil
+	default:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024)) //nolint:mnd // enough for an error message
+
+		return fmt.Errorf(
+			"%w: create repository %s returned %d: %s",
+			ErrUnexpectedStatus,
+			name,
+			resp.StatusCode,
+			strings.TrimSpace(string(message)),
+		)
+	}
+}
@@ -0,0 +1,11 @@
+// Package gitea publishes a KSail project to the in-cluster Gitea server.
+//
+// Gitea is installed by the gitea installer as a local Git server for testing the full
+// GitOps loop without any external Git hosting. This package holds the well-known
+// coordinates of that installation, an API client that creates repositories, a pusher that
+// commits a source directory and force-pushes it, and a port-forward that makes the server
+// reachable from the host. Sync combines them to publish a project in one call.
+//
+// Gitea runs inside the cluster rather than as a container next to it, so Flux and ArgoCD
+// clone from it through cluster DNS on every distribution, without joining a Docker network.
+package gitea
@@ -0,0 +1,67 @@
+package gitea
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Namespace is the namespace Gitea is installed into.
+	Namespace = "gitea"
+	// ReleaseName is the Helm release name of the Gitea installation.
+	ReleaseName = "gitea"
+	// ServiceName is the Gitea HTTP service.
+	ServiceName = "gitea-http"
+	// HTTPPort is the port of the Gitea HTTP service.
+	HTTPPort = 3000
+	// AdminUsername is the administrator account that owns the project repositories.
+	AdminUsername = "ksail"
+	// AdminPassword is the password of AdminUsername. The server is local to the cluster
+	// and only reachable through a port-forward, so a fixed password is sufficient.
+	AdminPassword = "ksail-gitea"
+	// AdminEmail is the email of AdminUsername.
+	AdminEmail = "ksail@localhost"
+	// DefaultBranch is the branch projects are pushed to.
+	DefaultBranch = "main"
+	// DefaultRepositoryName is used when no repository name can be derived from the source directory.
+	DefaultRepositoryName = "ksail-workloads"
+)
+
+// InClusterURL returns the base URL of Gitea for clients running inside the cluster.
+func InClusterURL() string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", ServiceName, Namespace, HTTPPort)
+}
+
+// InClusterRepositoryURL returns the clone URL of repository for clients running inside
+// the cluster, such as Flux source-controller or the ArgoCD repo server.
+func InClusterRepositoryURL(repository string) string {
+	return fmt.Sprintf("%s/%s/%s.git", InClusterURL(), AdminUsername, repository)
+}
+
+// RepositoryName derives a repository name from the project source directory, so the same
+// directory always maps to the same repository.
+func RepositoryName(sourceDir string) string {
+	var builder strings.Builder
+
+	previousHyphen := false
+
+	for _, char := range strings.ToLower(strings.TrimSpace(sourceDir)) {
+		switch {
+		case char >= 'a' && char <= 'z', char >= '0' && char <= '9':
+			builder.WriteRune(char)
+
+			previousHyphen = false
+		case !previousHyphen:
+			builder.WriteRune('-')
+
+			previousHyphen = true
+		}
+	}
+
+	name := strings.Trim(builder.String(), "-")
+	if name == "" {
+		return DefaultRepositoryName
+	}
+
+	return name
+}
@@ -0,0 +1,19 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"k8s.io/client-go/rest"
+)
+
+// ForwardService forwards a free local port to a running pod behind the Gitea HTTP service.
+func ForwardService(ctx context.Context, restConfig *rest.Config) (*k8s.PortForward, error) {
+	forward, err := k8s.ForwardService(ctx, restConfig, Namespace, ServiceName, HTTPPort)
+	if err != nil {
+		return nil, fmt.Errorf("forward gitea port: %w", err)
+	}
+
+	return forward, nil
+}
@@ -0,0 +1,110 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+)
+
+const (
+	defaultGitBinary = "git"
+	gitDirPrefix     = "ksail-gitea-"
+	commitMessage    = "Publish project from ksail"
+)
+
+// Pusher commits a directory and force-pushes it to a Git remote with the git CLI.
+type Pusher struct {
+	binary string
+	runner execrunner.Runner
+}
+
+// PusherOption customizes a Pusher.
+type PusherOption func(*Pusher)
+
+// WithRunner replaces the function used to execute git.
+func WithRunner(runner execrunner.Runner) PusherOption {
+	return func(p *Pusher) {
+		p.runner = runner
+	}
+}
+
+// WithBinary sets the git binary.
+func WithBinary(binary string) PusherOption {
+	return func(p *Pusher) {
+		p.binary = binary
+	}
+}
+
+// NewPusher creates a Pusher that runs `git`.
+func NewPusher(opts ...PusherOption) *Pusher {
+	pusher := &Pusher{binary: defaultGitBinary, runner: execrunner.Exec}
+
+	for _, opt := range opts {
+		opt(pusher)
+	}
+
+	return pusher
+}
+
+// Push commits the contents of dir as a single commit and force-pushes it to branch of
+// remoteURL.
+//
+// The commit is built in a temporary Git directory with dir as its work tree, so dir is
+// never modified and may itself be part of another repository.
+func (p *Pusher) Push(ctx context.Context, dir, remoteURL, branch string) error {
+	gitDir, err := os.MkdirTemp("", gitDirPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("create temporary git directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(gitDir) }()
+
+	base := []string{"--git-dir=" + gitDir, "--work-tree=" + dir}
+	identity := []string{"-c", "user.name=" + AdminUsername, "-c", "user.email=" + AdminEmail}
+
+	steps := [][]string{
+		append(append([]string{}, base...), "init", "--quiet"),
+		append(append([]string{}, base...), "add", "--all"),
+		append(append(append([]string{}, identity...), base...),
+			"commit", "--quiet", "--allow-empty", "--message", commitMessage),
+		append(append([]string{}, base...),
+			"push", "--quiet", "--force", remoteURL, "HEAD:refs/heads/"+branch),
+	}
+
+	for _, args := range steps {
+		output, err := p.runner(ctx, p.binary, args...)
+		if err != nil {
+			return fmt.Errorf(
+				"git %s: %w: %s",
+				gitSubcommand(args),
+				err,
+				redact(strings.TrimSpace(string(output)), remoteURL),
+			)
+		}
+	}
+
+	return nil
+}
+
+// gitSubcommand returns the first argument of args that is not a global option.
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "-c":
+			i++
+		case strings.HasPrefix(args[i], "-"):
+		default:
+			return args[i]
+		}
+	}
+
+	return ""
+}
+
+// redact removes remoteURL, which carries credentials, from git output.
+func redact(output, remoteURL string) string {
+	return strings.ReplaceAll(output, remoteURL, "<remote>")
+}
@@ -0,0 +1,75 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+)
+
+// SyncOptions selects the cluster and the project directory to publish.
+type SyncOptions struct {
+	// Kubeconfig is the path to the cluster kubeconfig.
+	Kubeconfig string
+	// Context is the kubeconfig context; empty selects the current context.
+	Context string
+	// SourceDir is the directory whose contents are pushed.
+	SourceDir string
+	// Repository is the repository name; empty derives it from SourceDir via RepositoryName.
+	Repository string
+}
+
+// Sync publishes opts.SourceDir to the in-cluster Gitea server and returns the repository
+// name. It creates the repository when needed and replaces the default branch with a single
+// commit holding the current contents of the directory.
+func Sync(ctx context.Context, opts SyncOptions) (string, error) {
+	repository := opts.Repository
+	if repository == "" {
+		repository = RepositoryName(opts.SourceDir)
+	}
+
+	restConfig, err := k8s.BuildRESTConfig(opts.Kubeconfig, opts.Context)
+	if err != nil {
+		return "", fmt.Errorf("build rest config: %w", err)
+	}
+
+	forward, err := ForwardService(ctx, restConfig)
+	if err != nil {
+		return "", err
+	}
+
+	defer forward.Close()
+
+	err = NewAPIClient(forward.LocalURL, AdminUsername, AdminPassword, nil).
+		EnsureRepository(ctx, repository)
+	if err != nil {
+		return "", err
+	}
+
+	remoteURL, err := authenticatedRepositoryURL(forward.LocalURL, repository)
+	if err != nil {
+		return "", err
+	}
+
+	err = NewPusher().Push(ctx, opts.SourceDir, remoteURL, DefaultBranch)
+	if err != nil {
+		return "", fmt.Errorf("push to gitea repository %s: %w", repository, err)
+	}
+
+	return repository, nil
+}
+
+// authenticatedRepositoryURL returns the clone URL of repository on baseURL with the
+// administrator credentials embedded.
+func authenticatedRepositoryURL(baseURL, repository string) (string, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse gitea URL: %w", err)
+	}
+
+	parsed.User = url.UserPassword(AdminUsername, AdminPassword)
+	parsed.Path = fmt.Sprintf("/%s/%s.git", AdminUsername, repository)
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,167 @@
+package hostdns
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+)
+
+const (
+	managedHeaderPrefix = "# Managed by ksail for cluster "
+	filePrefix          = "ksail-"
+	fileSuffix          = ".conf"
+	dirPerm             = 0o755
+	filePerm            = 0o644
+	// localNameserver is where dnsmasq answers queries routed by the other resolvers.
+	localNameserver = "127.0.0.1"
+)
+
+// Backend configures one resolver on the host.
+type Backend struct {
+	// Name identifies the resolver in messages, e.g. "dnsmasq".
+	Name string
+	// Dir is the directory the resolver reads its configuration files from.
+	Dir string
+	// FileName returns the name of the file written for a record.
+	FileName func(record Record) string
+	// Render returns the resolver configuration for a record.
+	Render func(record Record) string
+	// Reload is the command that makes the resolver pick up changed files; empty for none.
+	Reload []string
+}
+
+// NewDnsmasqBackend answers every name below a domain with the record's IP.
+func NewDnsmasqBackend(dir string, reload ...string) Backend {
+	return Backend{
+		Name:     "dnsmasq",
+		Dir:      dir,
+		FileName: confFileName,
+		Render: func(record Record) string {
+			return fmt.Sprintf("address=/%s/%s\n", record.Domain, record.IP)
+		},
+		Reload: reload,
+	}
+}
+
+// NewResolvedBackend routes queries for a domain from systemd-resolved to the local dnsmasq.
+func NewResolvedBackend(dir string) Backend {
+	return Backend{
+		Name:     "systemd-resolved",
+		Dir:      dir,
+		FileName: confFileName,
+		Render: func(record Record) string {
+			return fmt.Sprintf("[Resolve]\nDNS=%s\nDomains=~%s\n", localNameserver, record.Domain)
+		},
+		Reload: []string{"systemctl", "restart", "systemd-resolved"},
+	}
+}
+
+// NewMacOSResolverBackend routes queries for a domain from the macOS resolver to the local
+// dnsmasq. macOS requires the file to be named after the domain.
+func NewMacOSResolverBackend(dir string) Backend {
+	return Backend{
+		Name: "macOS resolver",
+		Dir:  dir,
+		FileName: func(record Record) string {
+			return record.Domain
+		},
+		Render: func(_ Record) string {
+			return fmt.Sprintf("nameserver %s\n", localNameserver)
+		},
+	}
+}
+
+func confFileName(record Record) string {
+	return filePrefix + record.Domain + fileSuffix
+}
+
+func managedHeader(cluster string) string {
+	return managedHeaderPrefix + cluster + "\n"
+}
+
+// install writes the file for record and returns whether its content changed.
+func (b Backend) install(record Record) (bool, error) {
+	path := filepath.Join(b.Dir, b.FileName(record))
+	content := managedHeader(record.Cluster) + b.Render(record)
+
+	existing, err := os.ReadFile(path) //nolint:gosec // path is built from a validated domain
+	if err == nil {
+		if string(existing) == content {
+			return false, nil
+		}
+
+		owner, managed := managedBy(existing)
+		if !managed || owner != record.Cluster {
+			return false, fmt.Errorf("%w: %s", ErrFileNotManaged, path)
+		}
+	}
+
+	err = os.MkdirAll(b.Dir, dirPerm)
+	if err != nil {
+		return false, fmt.Errorf("create %s: %w", b.Dir, err)
+	}
+
+	err = ksailio.WriteFileAtomic(path, []byte(content), filePerm)
+	if err != nil {
+		return false, fmt.Errorf("write %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// uninstall removes every file in Dir written for cluster and returns whether any was removed.
+func (b Backend) uninstall(cluster string) (bool, error) {
+	entries, err := os.ReadDir(b.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", b.Dir, err)
+	}
+
+	removed := false
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(b.Dir, entry.Name())
+
+		data, readErr := os.ReadFile(path) //nolint:gosec // path is inside the resolver directory
+		if readErr != nil {
+			continue
+		}
+
+		owner, managed := managedBy(data)
+		if !managed || owner != cluster {
+			continue
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			return removed, fmt.Errorf("remove %s: %w", path, err)
+		}
+
+		removed = true
+	}
+
+	return removed, nil
+}
+
+// managedBy returns the cluster named in the managed header of a file.
+func managedBy(data []byte) (string, bool) {
+	line, _, err := bufio.NewReader(bytes.NewReader(data)).ReadLine()
+	if err != nil {
+		return "", false
+	}
+
+	return strings.CutPrefix(string(line), managedHeaderPrefix)
+}
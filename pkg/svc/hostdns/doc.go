@@ -0,0 +1,8 @@
+// Package hostdns routes a DNS domain on the host to a KSail cluster.
+//
+// A Manager writes configuration files for the resolvers found on the host: a dnsmasq
+// address rule that answers every name below the domain with the cluster's ingress IP,
+// plus a systemd-resolved drop-in on Linux or a /etc/resolver file on macOS that sends
+// queries for the domain to that dnsmasq. Every file is tagged with the cluster it belongs
+// to, so Uninstall removes exactly the files written for one cluster.
+package hostdns
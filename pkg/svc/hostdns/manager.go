@@ -0,0 +1,210 @@
+package hostdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultDomain is the domain routed to the cluster when none is given.
+	DefaultDomain = "ksail.test"
+	// DefaultIP is the address ingress controllers of local clusters are published on.
+	DefaultIP = "127.0.0.1"
+
+	goosLinux  = "linux"
+	goosDarwin = "darwin"
+
+	linuxDnsmasqDir        = "/etc/dnsmasq.d"
+	linuxResolvedDir       = "/etc/systemd/resolved.conf.d"
+	linuxResolvedRunDir    = "/run/systemd/resolve"
+	macOSResolverDir       = "/etc/resolver"
+	macOSHomebrewPrefix    = "/opt/homebrew"
+	macOSHomebrewLegacyDir = "/usr/local"
+	homebrewDnsmasqDir     = "etc/dnsmasq.d"
+)
+
+var (
+	// ErrInvalidDomain is returned when a domain is not a valid DNS subdomain.
+	ErrInvalidDomain = errors.New("invalid domain")
+	// ErrInvalidIP is returned when an IP address cannot be parsed.
+	ErrInvalidIP = errors.New("invalid IP address")
+	// ErrUnsupportedPlatform is returned on hosts without a supported resolver.
+	ErrUnsupportedPlatform = errors.New("host DNS configuration is not supported on this platform")
+	// ErrFileNotManaged is returned instead of overwriting a file KSail did not write for the cluster.
+	ErrFileNotManaged = errors.New("refusing to overwrite file not managed by ksail for this cluster")
+)
+
+// Record routes Domain and all names below it to IP for Cluster.
+type Record struct {
+	Cluster string
+	Domain  string
+	IP      string
+}
+
+// Validate normalizes the domain and checks the domain and IP.
+func (r *Record) Validate() error {
+	r.Domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(r.Domain)), ".")
+
+	if errs := validation.IsDNS1123Subdomain(r.Domain); len(errs) > 0 {
+		return fmt.Errorf("%w %q: %s", ErrInvalidDomain, r.Domain, strings.Join(errs, "; "))
+	}
+
+	if net.ParseIP(strings.TrimSpace(r.IP)) == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidIP, r.IP)
+	}
+
+	r.IP = strings.TrimSpace(r.IP)
+
+	return nil
+}
+
+// Manager installs records into a set of resolver backends.
+type Manager struct {
+	backends []Backend
+	runner   execrunner.Runner
+}
+
+// Option customizes a Manager.
+type Option func(*Manager)
+
+// WithRunner replaces the function used to run reload commands.
+func WithRunner(runner execrunner.Runner) Option {
+	return func(m *Manager) {
+		m.runner = runner
+	}
+}
+
+// NewManager creates a Manager for backends.
+func NewManager(backends []Backend, opts ...Option) *Manager {
+	manager := &Manager{backends: backends, runner: execrunner.Exec}
+
+	for _, opt := range opts {
+		opt(manager)
+	}
+
+	return manager
+}
+
+// Backends returns the resolver backends of the manager.
+func (m *Manager) Backends() []Backend {
+	return m.backends
+}
+
+// Install writes record to every backend and reloads the resolvers whose files changed.
+func (m *Manager) Install(ctx context.Context, record Record) error {
+	err := record.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, backend := range m.backends {
+		changed, err := backend.install(record)
+		if err != nil {
+			return fmt.Errorf("configure %s: %w", backend.Name, err)
+		}
+
+		if changed {
+			err = m.reload(ctx, backend)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Uninstall removes every record written for cluster and returns whether anything was removed.
+func (m *Manager) Uninstall(ctx context.Context, cluster string) (bool, error) {
+	removedAny := false
+
+	for _, backend := range m.backends {
+		removed, err := backend.uninstall(cluster)
+		if err != nil {
+			return removedAny, fmt.Errorf("unconfigure %s: %w", backend.Name, err)
+		}
+
+		if removed {
+			removedAny = true
+
+			err = m.reload(ctx, backend)
+			if err != nil {
+				return removedAny, err
+			}
+		}
+	}
+
+	return removedAny, nil
+}
+
+func (m *Manager) reload(ctx context.Context, backend Backend) error {
+	if len(backend.Reload) == 0 {
+		return nil
+	}
+
+	output, err := m.runner(ctx, backend.Reload[0], backend.Reload[1:]...)
+	if err != nil {
+		return fmt.Errorf(
+			"reload %s: %w: %s",
+			backend.Name,
+			err,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
+
+// NewHostManager returns a Manager for the resolvers of the current host.
+func NewHostManager(opts ...Option) (*Manager, error) {
+	backends, err := DetectBackends(runtime.GOOS, PathExists)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewManager(backends, opts...), nil
+}
+
+// DetectBackends returns the resolver backends for the host operating system goos.
+// exists reports whether a path exists and is used to detect systemd-resolved and Homebrew.
+func DetectBackends(goos string, exists func(path string) bool) ([]Backend, error) {
+	switch goos {
+	case goosLinux:
+		backends := []Backend{
+			NewDnsmasqBackend(linuxDnsmasqDir, "systemctl", "restart", "dnsmasq"),
+		}
+
+		if exists(linuxResolvedRunDir) {
+			backends = append(backends, NewResolvedBackend(linuxResolvedDir))
+		}
+
+		return backends, nil
+	case goosDarwin:
+		prefix := macOSHomebrewLegacyDir
+		if exists(macOSHomebrewPrefix) {
+			prefix = macOSHomebrewPrefix
+		}
+
+		return []Backend{
+			NewDnsmasqBackend(prefix+"/"+homebrewDnsmasqDir, "brew", "services", "restart", "dnsmasq"),
+			NewMacOSResolverBackend(macOSResolverDir),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPlatform, goos)
+	}
+}
+
+// PathExists reports whether path exists. It is the default probe for DetectBackends.
+func PathExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
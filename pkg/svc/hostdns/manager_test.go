@@ -0,0 +1,162 @@
+package hostdns_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/hostdns"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newManager(t *testing.T) (*hostdns.Manager, string, string, *testutils.RecordingRunner) {
+	t.Helper()
+
+	dnsmasqDir := filepath.Join(t.TempDir(), "dnsmasq.d")
+	resolvedDir := filepath.Join(t.TempDir(), "resolved.conf.d")
+	reloads := &testutils.RecordingRunner{}
+
+	manager := hostdns.NewManager([]hostdns.Backend{
+		hostdns.NewDnsmasqBackend(dnsmasqDir, "systemctl", "restart", "dnsmasq"),
+		hostdns.NewResolvedBackend(resolvedDir),
+	}, hostdns.WithRunner(reloads.Run))
+
+	return manager, dnsmasqDir, resolvedDir, reloads
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path) //nolint:gosec // test temp dir
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestInstallWritesResolverFilesAndReloads(t *testing.T) {
+	t.Parallel()
+
+	manager, dnsmasqDir, resolvedDir, reloads := newManager(t)
+
+	err := manager.Install(context.Background(), hostdns.Record{
+		Cluster: "dev",
+		Domain:  "Apps.KSail.test.",
+		IP:      "127.0.0.1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"# Managed by ksail for cluster dev\naddress=/apps.ksail.test/127.0.0.1\n",
+		readFile(t, filepath.Join(dnsmasqDir, "ksail-apps.ksail.test.conf")),
+	)
+	assert.Equal(t,
+		"# Managed by ksail for cluster dev\n[Resolve]\nDNS=127.0.0.1\nDomains=~apps.ksail.test\n",
+		readFile(t, filepath.Join(resolvedDir, "ksail-apps.ksail.test.conf")),
+	)
+	assert.Equal(t, [][]string{
+		{"systemctl", "restart", "dnsmasq"},
+		{"systemctl", "restart", "systemd-resolved"},
+	}, reloads.Calls())
+}
+
+func TestInstallSkipsReloadWhenUnchanged(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, reloads := newManager(t)
+	record := hostdns.Record{Cluster: "dev", Domain: "ksail.test", IP: "127.0.0.1"}
+
+	require.NoError(t, manager.Install(context.Background(), record))
+	require.NoError(t, manager.Install(context.Background(), record))
+
+	assert.Len(t, reloads.Calls(), 2)
+}
+
+func TestInstallRefusesToOverwriteForeignFile(t *testing.T) {
+	t.Parallel()
+
+	manager, dnsmasqDir, _, _ := newManager(t)
+
+	require.NoError(t, os.MkdirAll(dnsmasqDir, 0o750))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dnsmasqDir, "ksail-ksail.test.conf"),
+		[]byte("address=/ksail.test/10.0.0.1\n"),
+		0o600,
+	))
+
+	err := manager.Install(context.Background(), hostdns.Record{
+		Cluster: "dev",
+		Domain:  "ksail.test",
+		IP:      "127.0.0.1",
+	})
+	require.ErrorIs(t, err, hostdns.ErrFileNotManaged)
+}
+
+func TestInstallRejectsInvalidRecords(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, _ := newManager(t)
+
+	err := manager.Install(context.Background(), hostdns.Record{Cluster: "dev", Domain: "not a domain", IP: "127.0.0.1"})
+	require.ErrorIs(t, err, hostdns.ErrInvalidDomain)
+
+	err = manager.Install(context.Background(), hostdns.Record{Cluster: "dev", Domain: "ksail.test", IP: "localhost"})
+	require.ErrorIs(t, err, hostdns.ErrInvalidIP)
+}
+
+func TestUninstallRemovesOnlyFilesOfCluster(t *testing.T) {
+	t.Parallel()
+
+	manager, dnsmasqDir, resolvedDir, reloads := newManager(t)
+
+	require.NoError(t, manager.Install(context.Background(), hostdns.Record{
+		Cluster: "dev", Domain: "dev.test", IP: "127.0.0.1",
+	}))
+	require.NoError(t, manager.Install(context.Background(), hostdns.Record{
+		Cluster: "other", Domain: "other.test", IP: "127.0.0.1",
+	}))
+
+	reloads.Reset()
+
+	removed, err := manager.Uninstall(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	assert.NoFileExists(t, filepath.Join(dnsmasqDir, "ksail-dev.test.conf"))
+	assert.NoFileExists(t, filepath.Join(resolvedDir, "ksail-dev.test.conf"))
+	assert.FileExists(t, filepath.Join(dnsmasqDir, "ksail-other.test.conf"))
+	assert.Len(t, reloads.Calls(), 2)
+
+	removed, err = manager.Uninstall(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestDetectBackends(t *testing.T) {
+	t.Parallel()
+
+	none := func(string) bool { return false }
+	all := func(string) bool { return true }
+
+	linux, err := hostdns.DetectBackends("linux", none)
+	require.NoError(t, err)
+	require.Len(t, linux, 1)
+	assert.Equal(t, "dnsmasq", linux[0].Name)
+
+	linuxResolved, err := hostdns.DetectBackends("linux", all)
+	require.NoError(t, err)
+	require.Len(t, linuxResolved, 2)
+	assert.Equal(t, "systemd-resolved", linuxResolved[1].Name)
+
+	darwin, err := hostdns.DetectBackends("darwin", all)
+	require.NoError(t, err)
+	require.Len(t, darwin, 2)
+	assert.Equal(t, "/opt/homebrew/etc/dnsmasq.d", darwin[0].Dir)
+	assert.Equal(t, "/etc/resolver", darwin[1].Dir)
+	assert.Equal(t, "ksail.test", darwin[1].FileName(hostdns.Record{Domain: "ksail.test"}))
+
+	_, err = hostdns.DetectBackends("windows", all)
+	require.ErrorIs(t, err, hostdns.ErrUnsupportedPlatform)
+}
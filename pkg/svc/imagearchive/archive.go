@@ -0,0 +1,193 @@
+package imagearchive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// Labels Kind and K3d set on the node containers of a cluster.
+const (
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	k3dClusterLabel  = "k3d.cluster"
+	k3dRoleLabel     = "k3d.role"
+)
+
+// ErrImportFailed is returned when a node fails to import an archive.
+var ErrImportFailed = errors.New("image import failed")
+
+// Export pulls images for the platform of the host into an archive at path, in the format
+// of `docker save`.
+func Export(ctx context.Context, path string, images []string) error {
+	refToImage := make(map[name.Reference]v1.Image, len(images))
+
+	for _, image := range images {
+		err := offline.Guard(ctx, "pull image %s", image)
+		if err != nil {
+			return err
+		}
+
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			return fmt.Errorf("invalid image reference %q: %w", image, err)
+		}
+
+		img, err := remote.Image(
+			ref,
+			remote.WithContext(ctx),
+			remote.WithPlatform(v1.Platform{OS: "linux", Architecture: goruntime.GOARCH}),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to pull %s: %w", image, err)
+		}
+
+		refToImage[archiveReference(ref, image)] = img
+	}
+
+	err := tarball.MultiRefWriteToFile(path, refToImage)
+	if err != nil {
+		return fmt.Errorf("failed to write image archive %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// archiveReference returns the reference image is stored under in the archive. Images pinned
+// by digest keep their tag, since loaded images are looked up by tag.
+func archiveReference(ref name.Reference, image string) name.Reference {
+	if _, isDigest := ref.(name.Digest); !isDigest {
+		return ref
+	}
+
+	tagged, _, _ := strings.Cut(image, "@")
+
+	tag, err := name.NewTag(tagged, name.StrictValidation)
+	if err != nil {
+		return ref
+	}
+
+	return tag
+}
+
+// LoadIntoDocker loads the images of the archive at path into the Docker daemon, where the
+// distributions find node images without pulling them.
+func LoadIntoDocker(ctx context.Context, dockerClient client.APIClient, path string) error {
+	archive, err := os.Open(path) // #nosec G304 -- path is the archive the user imports
+	if err != nil {
+		return fmt.Errorf("failed to open image archive: %w", err)
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	response, err := dockerClient.ImageLoad(ctx, archive, client.ImageLoadWithQuiet(true))
+	if err != nil {
+		return fmt.Errorf("failed to load image archive %s: %w", path, err)
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	_, err = io.Copy(io.Discard, response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to load image archive %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// NodeContainers returns the names of the node containers of the Kind or K3d cluster named
+// clusterName. Load balancers and other helper containers are not nodes.
+func NodeContainers(ctx context.Context, dockerClient client.APIClient, clusterName string) ([]string, error) {
+	var nodes []string
+
+	for _, label := range []string{kindClusterLabel, k3dClusterLabel} {
+		containers, err := dockerClient.ContainerList(ctx, container.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("label", label+"="+clusterName)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes of cluster %s: %w", clusterName, err)
+		}
+
+		for _, node := range containers {
+			role, isK3d := node.Labels[k3dRoleLabel]
+			if isK3d && role != "server" && role != "agent" {
+				continue
+			}
+
+			if len(node.Names) > 0 {
+				nodes = append(nodes, strings.TrimPrefix(node.Names[0], "/"))
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// LoadIntoNode imports the images of the archive at path into the containerd of a running
+// node container, where the kubelet finds them without pulling.
+func LoadIntoNode(ctx context.Context, dockerClient client.APIClient, containerID, path string) error {
+	archive, err := os.Open(path) // #nosec G304 -- path is the archive the user imports
+	if err != nil {
+		return fmt.Errorf("failed to open image archive: %w", err)
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	created, err := dockerClient.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          []string{"ctr", "--namespace=k8s.io", "images", "import", "--all-platforms", "-"},
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	attached, err := dockerClient.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to attach exec: %w", err)
+	}
+	defer attached.Close()
+
+	_, err = io.Copy(attached.Conn, archive)
+	if err != nil {
+		return fmt.Errorf("failed to stream image archive: %w", err)
+	}
+
+	err = attached.CloseWrite()
+	if err != nil {
+		return fmt.Errorf("failed to stream image archive: %w", err)
+	}
+
+	var stderr bytes.Buffer
+
+	_, err = stdcopy.StdCopy(io.Discard, &stderr, attached.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspect, err := dockerClient.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec: %w", err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("%w with exit code %d: %s", ErrImportFailed, inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
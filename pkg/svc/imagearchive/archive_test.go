@@ -0,0 +1,111 @@
+package imagearchive_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/svc/imagearchive"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWritesLoadableArchive(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	tags := []string{host + "/cilium/cilium:v1.18.4", host + "/kindest/node:v1.34.0"}
+	images := make([]string, 0, len(tags))
+
+	for _, ref := range tags {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+
+		parsed, err := name.ParseReference(ref)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(parsed, img))
+
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		image := ref
+		if strings.Contains(ref, "kindest") {
+			// Node images are pinned by digest, but loaded images are looked up by tag.
+			image += "@" + digest.String()
+		}
+
+		images = append(images, image)
+	}
+
+	path := filepath.Join(t.TempDir(), "images.tar")
+	require.NoError(t, imagearchive.Export(context.Background(), path, images))
+
+	for _, ref := range tags {
+		tag, err := name.NewTag(ref)
+		require.NoError(t, err)
+
+		_, err = tarball.ImageFromPath(path, &tag)
+		require.NoError(t, err, ref)
+	}
+}
+
+func TestExportFailsOffline(t *testing.T) {
+	t.Parallel()
+
+	ctx := offline.WithOffline(context.Background())
+	path := filepath.Join(t.TempDir(), "images.tar")
+
+	err := imagearchive.Export(ctx, path, []string{"quay.io/cilium/cilium:v1.18.4"})
+	require.ErrorIs(t, err, offline.ErrNetworkDisabled)
+}
+
+func TestLoadIntoDocker(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "images.tar")
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, tarball.WriteToFile(path, name.MustParseReference("kindest/node:v1.34.0"), img))
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ImageLoad(mock.Anything, mock.Anything, mock.Anything).
+		Return(image.LoadResponse{Body: io.NopCloser(strings.NewReader("{}"))}, nil)
+
+	require.NoError(t, imagearchive.LoadIntoDocker(context.Background(), client, path))
+}
+
+func TestNodeContainersSkipsHelperContainers(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{
+			{Names: []string{"/dev-control-plane"}, Labels: map[string]string{"io.x-k8s.kind.cluster": "dev"}},
+		}, nil).Once()
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{
+			{Names: []string{"/k3d-dev-server-0"}, Labels: map[string]string{"k3d.cluster": "dev", "k3d.role": "server"}},
+			{Names: []string{"/k3d-dev-agent-0"}, Labels: map[string]string{"k3d.cluster": "dev", "k3d.role": "agent"}},
+			{Names: []string{"/k3d-dev-serverlb"}, Labels: map[string]string{"k3d.cluster": "dev", "k3d.role": "loadbalancer"}},
+		}, nil).Once()
+
+	nodes, err := imagearchive.NodeContainers(context.Background(), client, "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev-control-plane", "k3d-dev-server-0", "k3d-dev-agent-0"}, nodes)
+}
@@ -0,0 +1,7 @@
+// Package imagearchive moves the images of a cluster to machines without internet access.
+//
+// Export pulls the node image and the component images of a cluster from their registries
+// into a single archive in the `docker save` format. On the air-gapped machine, LoadIntoDocker
+// makes the node image available to the distributions, and LoadIntoNode imports the archive
+// into the containerd of a running node, so the components start without pulling anything.
+package imagearchive
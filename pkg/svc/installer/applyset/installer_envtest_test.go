@@ -0,0 +1,51 @@
+package applysetinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	applysetinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/applyset"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+func TestApplySetInstaller_InstallAndUninstallAgainstAPIServer(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.StartEnvtest(t)
+	ctx := context.Background()
+
+	apiExtClient, err := apiextensionsclientset.NewForConfig(server.Config)
+	require.NoError(t, err)
+
+	dynamicClient, err := dynamic.NewForConfig(server.Config)
+	require.NoError(t, err)
+
+	crdClient := apiExtClient.ApiextensionsV1().CustomResourceDefinitions()
+	applySetClient := dynamicClient.Resource(schema.GroupVersionResource{
+		Group:    "k8s.devantler.tech",
+		Version:  "v1",
+		Resource: "applysets",
+	})
+
+	installer := applysetinstaller.NewApplySetInstaller(30*time.Second, crdClient, applySetClient)
+
+	require.NoError(t, installer.Install(ctx))
+	// A second install must be a no-op against existing resources.
+	require.NoError(t, installer.Install(ctx))
+
+	applySet, err := applySetClient.Get(ctx, "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "ApplySet", applySet.GetKind())
+
+	require.NoError(t, installer.Uninstall(ctx))
+
+	_, err = applySetClient.Get(ctx, "ksail", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err), "expected ApplySet to be deleted, got %v", err)
+}
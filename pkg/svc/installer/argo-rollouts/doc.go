@@ -0,0 +1,7 @@
+// Package argorolloutsinstaller provides an installer for installing Argo Rollouts on a
+// Kubernetes cluster.
+//
+// The Argo Rollouts controller and its CRDs let Rollout, AnalysisTemplate and Experiment
+// resources for progressive delivery be applied and promoted, e.g. with the
+// `kubectl argo rollouts` plugin, on local clusters.
+package argorolloutsinstaller
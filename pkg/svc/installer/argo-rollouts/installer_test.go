@@ -0,0 +1,94 @@
+package argorolloutsinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	argorolloutsinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argo-rollouts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgoRolloutsInstallerInstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoRolloutsInstallerWithDefaults(t)
+	installer.SetVersion("2.40.5")
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "argo" && entry.URL == "https://argoproj.github.io/argo-helm"
+		})).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			assert.Equal(t, "argo-rollouts", spec.ReleaseName)
+			assert.Equal(t, "argo/argo-rollouts", spec.ChartName)
+			assert.Equal(t, "argo-rollouts", spec.Namespace)
+			assert.Equal(t, "2.40.5", spec.Version)
+			assert.True(t, spec.CreateNamespace)
+			assert.True(t, spec.UpgradeCRDs)
+			assert.True(t, spec.Wait)
+
+			return true
+		})).
+		Return(&helm.ReleaseInfo{}, nil)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestArgoRolloutsInstallerInstallRepositoryError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoRolloutsInstallerWithDefaults(t)
+
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to add argo repository")
+}
+
+func TestArgoRolloutsInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoRolloutsInstallerWithDefaults(t)
+
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().InstallOrUpgradeChart(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install argo-rollouts chart")
+}
+
+func TestArgoRolloutsInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoRolloutsInstallerWithDefaults(t)
+
+	client.EXPECT().UninstallRelease(mock.Anything, "argo-rollouts", "argo-rollouts").Return(assert.AnError)
+
+	err := installer.Uninstall(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to uninstall argo-rollouts release")
+}
+
+func newArgoRolloutsInstallerWithDefaults(
+	t *testing.T,
+) (*argorolloutsinstaller.ArgoRolloutsInstaller, *helm.MockInterface) {
+	t.Helper()
+	client := helm.NewMockInterface(t)
+	installer := argorolloutsinstaller.NewArgoRolloutsInstaller(client, 5*time.Second)
+
+	return installer, client
+}
@@ -0,0 +1,239 @@
+package argocdinstaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// Namespace is the namespace ArgoCD is installed into, and its Applications are created in.
+	Namespace = "argocd"
+	// DefaultTargetRevision is the revision an Application syncs when none is configured.
+	DefaultTargetRevision = "HEAD"
+
+	inClusterServer = "https://kubernetes.default.svc"
+
+	applicationHealthy = "Healthy"
+	applicationSynced  = "Synced"
+	operationRunning   = "Running"
+	operationFailed    = "Failed"
+	operationError     = "Error"
+)
+
+// ErrApplicationSyncFailed is returned when ArgoCD reports that the sync of an Application failed.
+var ErrApplicationSyncFailed = errors.New("application sync failed")
+
+//nolint:gochecknoglobals // GroupVersionResource of the ArgoCD Application custom resource.
+var applications = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "applications",
+}
+
+// Application describes the ArgoCD Application Install creates for the project, so ArgoCD
+// syncs it without setting it up in the web UI.
+type Application struct {
+	// Name is the name of the Application, e.g. the name of the project.
+	Name string
+	// RepoURL is the Git repository, or the oci:// artifact in the local registry, to sync from.
+	RepoURL string
+	// Path is the directory in RepoURL with the manifests, e.g. "k8s". Artifacts of the local
+	// registry hold the manifests at their root, "./".
+	Path string
+	// TargetRevision is the branch, tag or commit to sync. It defaults to DefaultTargetRevision.
+	TargetRevision string
+	// DestinationNamespace is the namespace of manifests that do not set one.
+	DestinationNamespace string
+}
+
+// Manifest returns the Application resource of a, synced automatically with pruning and
+// self-healing.
+func (a Application) Manifest() *unstructured.Unstructured {
+	targetRevision := a.TargetRevision
+	if targetRevision == "" {
+		targetRevision = DefaultTargetRevision
+	}
+
+	destination := map[string]any{"server": inClusterServer}
+	if a.DestinationNamespace != "" {
+		destination["namespace"] = a.DestinationNamespace
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": applications.GroupVersion().String(),
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": a.Name, "namespace": Namespace},
+		"spec": map[string]any{
+			"project": "default",
+			"source": map[string]any{
+				"repoURL":        a.RepoURL,
+				"path":           a.Path,
+				"targetRevision": targetRevision,
+			},
+			"destination": destination,
+			"syncPolicy": map[string]any{
+				"automated":   map[string]any{"prune": true, "selfHeal": true},
+				"syncOptions": []any{"CreateNamespace=true"},
+			},
+		},
+	}}
+}
+
+// ApplicationReady reports whether ArgoCD has synced every sync wave of application and its
+// resources are healthy. ArgoCD applies the waves of a sync one after another, and the
+// Application can look Synced and Healthy between two waves, so it is only ready once the sync
+// operation is no longer running. A failed sync is returned as an error.
+func ApplicationReady(application *unstructured.Unstructured) (bool, error) {
+	phase, _, _ := unstructured.NestedString(application.Object, "status", "operationState", "phase")
+
+	switch phase {
+	case operationRunning:
+		return false, nil
+	case operationFailed, operationError:
+		message, _, _ := unstructured.NestedString(application.Object, "status", "operationState", "message")
+
+		return false, fmt.Errorf("%w: %s: %s", ErrApplicationSyncFailed, application.GetName(), message)
+	}
+
+	syncStatus, _, _ := unstructured.NestedString(application.Object, "status", "sync", "status")
+	healthStatus, _, _ := unstructured.NestedString(application.Object, "status", "health", "status")
+
+	return syncStatus == applicationSynced && healthStatus == applicationHealthy, nil
+}
+
+// --- internals ---
+
+// applyApplication creates or updates the Application set with SetApplication and waits until
+// it is ready.
+func (a *ArgoCDInstaller) applyApplication(ctx context.Context) error {
+	return applyApplication(ctx, a.dynamicClient, a.application.Manifest(), a.timeout)
+}
+
+// applyApplication creates or updates desired and waits until it is ready. The Application CRD
+// is registered by the chart, so creating it is retried until the timeout.
+func applyApplication(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	desired *unstructured.Unstructured,
+	timeout time.Duration,
+) error {
+	client := dynamicClient.Resource(applications).Namespace(Namespace)
+
+	var lastErr error
+
+	err := k8s.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		lastErr = upsertApplication(ctx, client, desired)
+
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w (last error: %w)", err, lastErr)
+	}
+
+	var status string
+
+	err = k8s.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		current, getErr := client.Get(ctx, desired.GetName(), metav1.GetOptions{})
+		if getErr != nil {
+			return false, fmt.Errorf("get application %s: %w", desired.GetName(), getErr)
+		}
+
+		status = applicationStatus(current)
+
+		return ApplicationReady(current)
+	})
+	if err != nil {
+		return fmt.Errorf("wait for application %s (%s): %w", desired.GetName(), status, err)
+	}
+
+	return nil
+}
+
+func upsertApplication(
+	ctx context.Context,
+	client dynamic.ResourceInterface,
+	desired *unstructured.Unstructured,
+) error {
+	_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create application %s: %w", desired.GetName(), err)
+	}
+
+	existing, err := client.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get application %s: %w", desired.GetName(), err)
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+
+	if annotations := desired.GetAnnotations(); len(annotations) > 0 {
+		merged := existing.GetAnnotations()
+		if merged == nil {
+			merged = map[string]string{}
+		}
+
+		maps.Copy(merged, annotations)
+		existing.SetAnnotations(merged)
+	}
+
+	_, err = client.Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update application %s: %w", desired.GetName(), err)
+	}
+
+	return nil
+}
+
+// deleteApplication removes the Application, leaving the resources it synced in place. A
+// cluster without the Application, or without its CRD, has nothing to delete.
+func (a *ArgoCDInstaller) deleteApplication(ctx context.Context) error {
+	err := a.dynamicClient.Resource(applications).Namespace(Namespace).
+		Delete(ctx, a.application.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("delete application %s: %w", a.application.Name, err)
+	}
+
+	return nil
+}
+
+// applicationStatus summarizes the sync, health and operation status of an Application for
+// timeout errors, e.g. "sync: Synced, health: Progressing, operation: Running".
+func applicationStatus(application *unstructured.Unstructured) string {
+	parts := make([]string, 0, 3) //nolint:mnd // sync, health and operation
+
+	for _, field := range []struct {
+		label string
+		path  []string
+	}{
+		{label: "sync", path: []string{"status", "sync", "status"}},
+		{label: "health", path: []string{"status", "health", "status"}},
+		{label: "operation", path: []string{"status", "operationState", "phase"}},
+	} {
+		value, _, _ := unstructured.NestedString(application.Object, field.path...)
+		if value != "" {
+			parts = append(parts, field.label+": "+value)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "no status"
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,137 @@
+package argocdinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argocdinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+//nolint:gochecknoglobals // Resource of the Application checked by the tests.
+var applications = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+func TestApplicationManifestDefaultsTargetRevision(t *testing.T) {
+	t.Parallel()
+
+	manifest := argocdinstaller.Application{
+		Name:    "ksail",
+		RepoURL: "http://gitea-http.gitea.svc:3000/ksail/k8s.git",
+		Path:    "k8s",
+	}.Manifest()
+
+	assert.Equal(t, "argocd", manifest.GetNamespace())
+
+	revision, _, err := unstructured.NestedString(manifest.Object, "spec", "source", "targetRevision")
+	require.NoError(t, err)
+	assert.Equal(t, argocdinstaller.DefaultTargetRevision, revision)
+
+	prune, _, err := unstructured.NestedBool(manifest.Object, "spec", "syncPolicy", "automated", "prune")
+	require.NoError(t, err)
+	assert.True(t, prune)
+}
+
+func TestApplicationReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sync    string
+		health  string
+		phase   string
+		ready   bool
+		wantErr bool
+	}{
+		{name: "synced and healthy", sync: "Synced", health: "Healthy", phase: "Succeeded", ready: true},
+		{name: "between sync waves", sync: "Synced", health: "Healthy", phase: "Running"},
+		{name: "progressing", sync: "Synced", health: "Progressing", phase: "Succeeded"},
+		{name: "out of sync", sync: "OutOfSync", health: "Healthy"},
+		{name: "failed sync", sync: "OutOfSync", health: "Missing", phase: "Failed", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ready, err := argocdinstaller.ApplicationReady(newApplication(test.sync, test.health, test.phase))
+			if test.wantErr {
+				require.ErrorIs(t, err, argocdinstaller.ErrApplicationSyncFailed)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.ready, ready)
+		})
+	}
+}
+
+func TestArgoCDInstallerInstallBootstrapsApplication(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoCDInstallerWithDefaults(t)
+	expectArgoCDInstall(t, client, nil)
+
+	// An Application left by a previous install is updated, and is ready once ArgoCD synced it.
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(
+		runtime.NewScheme(),
+		newApplication("Synced", "Healthy", "Succeeded"),
+	)
+	installer.SetApplication(dynamicClient, argocdinstaller.Application{
+		Name:    "ksail",
+		RepoURL: "oci://local-registry:5000/k8s",
+		Path:    ".",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, installer.Install(ctx))
+
+	application, err := dynamicClient.Resource(applications).Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	repoURL, _, err := unstructured.NestedString(application.Object, "spec", "source", "repoURL")
+	require.NoError(t, err)
+	assert.Equal(t, "oci://local-registry:5000/k8s", repoURL)
+}
+
+func TestArgoCDInstallerUninstallRemovesApplication(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoCDInstallerWithDefaults(t)
+	expectArgoCDUninstall(t, client, nil)
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(
+		runtime.NewScheme(),
+		newApplication("Synced", "Healthy", "Succeeded"),
+	)
+	installer.SetApplication(dynamicClient, argocdinstaller.Application{Name: "ksail"})
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+
+	_, err := dynamicClient.Resource(applications).Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func newApplication(sync, health, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": "ksail", "namespace": "argocd"},
+		"spec":       map[string]any{},
+		"status": map[string]any{
+			"sync":           map[string]any{"status": sync},
+			"health":         map[string]any{"status": health},
+			"operationState": map[string]any{"phase": phase, "message": "one or more objects failed to apply"},
+		},
+	}}
+}
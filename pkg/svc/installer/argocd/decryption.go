@@ -0,0 +1,78 @@
+package argocdinstaller
+
+const (
+	// DecryptionSecretName is the Secret with the age keys the repo server decrypts
+	// SOPS-encrypted manifests with.
+	DecryptionSecretName = "sops-age"
+
+	ksopsImage         = "viaductoss/ksops:v4.3.3"
+	customToolsVolume  = "custom-tools"
+	ageKeysDirectory   = "/.config/sops/age"
+	ageKeysFileName    = "keys.txt"
+	kustomizeBuildOpts = "--enable-alpha-plugins --enable-exec"
+)
+
+// SetDecryption enables SOPS decryption with the age identities in ageKeys. The keys are
+// stored in the DecryptionSecretName Secret, and the repo server runs kustomize with the KSOPS
+// plugin, so Applications decrypt manifests listed by a ksops generator in their
+// kustomization.yaml. Empty ageKeys leave decryption disabled.
+func (a *ArgoCDInstaller) SetDecryption(ageKeys string) {
+	a.ageKeys = ageKeys
+}
+
+// --- internals ---
+
+// decryptionValues returns the chart values that install KSOPS into the repo server and
+// create the Secret holding ageKeys.
+func decryptionValues(ageKeys string) map[string]any {
+	return map[string]any{
+		"configs": map[string]any{
+			"cm": map[string]any{"kustomize.buildOptions": kustomizeBuildOpts},
+		},
+		"repoServer": map[string]any{
+			"env": []any{
+				map[string]any{"name": "SOPS_AGE_KEY_FILE", "value": ageKeysDirectory + "/" + ageKeysFileName},
+			},
+			"volumes": []any{
+				map[string]any{"name": customToolsVolume, "emptyDir": map[string]any{}},
+				map[string]any{
+					"name":   DecryptionSecretName,
+					"secret": map[string]any{"secretName": DecryptionSecretName},
+				},
+			},
+			"initContainers": []any{
+				map[string]any{
+					"name":    "install-ksops",
+					"image":   ksopsImage,
+					"command": []any{"/bin/sh", "-c"},
+					"args":    []any{"mv ksops kustomize /" + customToolsVolume + "/"},
+					"volumeMounts": []any{
+						map[string]any{"name": customToolsVolume, "mountPath": "/" + customToolsVolume},
+					},
+				},
+			},
+			"volumeMounts": []any{
+				map[string]any{
+					"name":      customToolsVolume,
+					"mountPath": "/usr/local/bin/kustomize",
+					"subPath":   "kustomize",
+				},
+				map[string]any{
+					"name":      customToolsVolume,
+					"mountPath": "/usr/local/bin/ksops",
+					"subPath":   "ksops",
+				},
+				map[string]any{"name": DecryptionSecretName, "mountPath": ageKeysDirectory},
+			},
+		},
+		"extraObjects": []any{
+			map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]any{"name": DecryptionSecretName, "namespace": Namespace},
+				"type":       "Opaque",
+				"stringData": map[string]any{ageKeysFileName: ageKeys},
+			},
+		},
+	}
+}
@@ -1,5 +1,8 @@
 // Package argocdinstaller provides an installer for installing ArgoCD on a Kubernetes cluster.
 //
 // This package contains the ArgoCD installer implementation and client interfaces
-// for managing ArgoCD installations on Kubernetes clusters.
+// for managing ArgoCD installations on Kubernetes clusters. The installer can bootstrap an
+// Application for the project, pointing at its Git repository or at its artifact in the local
+// registry, and waits until ArgoCD has synced every sync wave of it. With age keys set, the
+// repo server decrypts SOPS-encrypted manifests through KSOPS.
 package argocdinstaller
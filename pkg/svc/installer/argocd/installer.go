@@ -6,12 +6,19 @@ import (
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
 )
 
 // ArgoCDInstaller implements the installer.Installer interface for ArgoCD.
 type ArgoCDInstaller struct {
-	timeout time.Duration
-	client  helm.Interface
+	timeout       time.Duration
+	client        helm.Interface
+	dynamicClient dynamic.Interface
+	application   Application
+	ageKeys       string
+	version       string
+	registryRepo  string
 }
 
 // NewArgoCDInstaller creates a new ArgoCD installer instance.
@@ -25,19 +32,49 @@ func NewArgoCDInstaller(
 	}
 }
 
-// Install installs or upgrades ArgoCD via its Helm chart.
+// SetVersion pins the argo-cd chart Install deploys. Without a version, the chart locked for the
+// project is installed, falling back to the latest argo-cd release.
+func (a *ArgoCDInstaller) SetVersion(version string) {
+	a.version = version
+}
+
+// SetApplication sets the Application Install creates through dynamicClient once ArgoCD is
+// installed, and waits until every sync wave of it is synced and healthy.
+func (a *ArgoCDInstaller) SetApplication(dynamicClient dynamic.Interface, application Application) {
+	a.dynamicClient = dynamicClient
+	a.application = application
+}
+
+// Install installs or upgrades ArgoCD via its Helm chart, and bootstraps the Application set
+// with SetApplication.
 func (a *ArgoCDInstaller) Install(ctx context.Context) error {
 	err := a.helmInstallOrUpgradeArgoCD(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to install ArgoCD: %w", err)
 	}
 
+	if a.application.Name == "" {
+		return nil
+	}
+
+	err = a.applyApplication(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap ArgoCD application: %w", err)
+	}
+
 	return nil
 }
 
-// Uninstall removes the Helm release for ArgoCD.
+// Uninstall removes the Application set with SetApplication, and the Helm release for ArgoCD.
 func (a *ArgoCDInstaller) Uninstall(ctx context.Context) error {
-	err := a.client.UninstallRelease(ctx, "argocd", "argocd")
+	if a.application.Name != "" {
+		err := a.deleteApplication(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove ArgoCD application: %w", err)
+		}
+	}
+
+	err := a.client.UninstallRelease(ctx, "argocd", Namespace)
 	if err != nil {
 		return fmt.Errorf("failed to uninstall argocd release: %w", err)
 	}
@@ -58,24 +95,62 @@ func (a *ArgoCDInstaller) helmInstallOrUpgradeArgoCD(ctx context.Context) error
 		return fmt.Errorf("failed to add argo repository: %w", addRepoErr)
 	}
 
+	values, err := a.chartValues()
+	if err != nil {
+		return err
+	}
+
 	spec := &helm.ChartSpec{
 		ReleaseName:     "argocd",
 		ChartName:       "argo/argo-cd",
-		Namespace:       "argocd",
+		Namespace:       Namespace,
 		RepoURL:         "https://argoproj.github.io/argo-helm",
 		CreateNamespace: true,
 		Atomic:          true,
 		UpgradeCRDs:     true,
+		Version:         a.version,
 		Timeout:         a.timeout,
+		ValuesYaml:      values,
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, a.timeout)
 	defer cancel()
 
-	_, err := a.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	_, err = a.client.InstallOrUpgradeChart(timeoutCtx, spec)
 	if err != nil {
 		return fmt.Errorf("failed to install argocd chart: %w", err)
 	}
 
 	return nil
 }
+
+// chartValues returns the chart values for decryption and the local registry repository, or
+// no values when neither is set.
+func (a *ArgoCDInstaller) chartValues() (string, error) {
+	values := map[string]any{}
+
+	if a.ageKeys != "" {
+		values = decryptionValues(a.ageKeys)
+	}
+
+	if a.registryRepo != "" {
+		configs, _ := values["configs"].(map[string]any)
+		if configs == nil {
+			configs = map[string]any{}
+			values["configs"] = configs
+		}
+
+		configs["repositories"] = registryRepositoryValues(a.registryRepo)
+	}
+
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal argocd values: %w", err)
+	}
+
+	return string(out), nil
+}
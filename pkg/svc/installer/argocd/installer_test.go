@@ -58,6 +58,32 @@ func TestArgoCDInstallerInstallAddRepositoryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to add argo repository")
 }
 
+func TestArgoCDInstallerInstallWithDecryption(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoCDInstallerWithDefaults(t)
+	installer.SetDecryption("AGE-SECRET-KEY-1TEST\n")
+
+	expectArgoCDAddRepository(t, client, nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Contains(t, spec.ValuesYaml, "kustomize.buildOptions: --enable-alpha-plugins --enable-exec")
+				assert.Contains(t, spec.ValuesYaml, "image: viaductoss/ksops")
+				assert.Contains(t, spec.ValuesYaml, "secretName: sops-age")
+				assert.Contains(t, spec.ValuesYaml, "AGE-SECRET-KEY-1TEST")
+
+				return true
+			}),
+		).
+		Return(nil, nil)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
 func TestArgoCDInstallerUninstallSuccess(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +149,7 @@ func expectArgoCDInstall(t *testing.T, client *helm.MockInterface, installErr er
 				assert.True(t, spec.CreateNamespace)
 				assert.True(t, spec.Atomic)
 				assert.True(t, spec.UpgradeCRDs)
+				assert.Empty(t, spec.ValuesYaml)
 
 				return true
 			}),
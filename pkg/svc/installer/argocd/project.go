@@ -0,0 +1,101 @@
+package argocdinstaller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// ArtifactTag is the tag of the artifact in the local registry the project Application syncs.
+	ArtifactTag = "latest"
+
+	refreshAnnotation = "argocd.argoproj.io/refresh"
+	refreshHard       = "hard"
+	defaultNamespace  = "default"
+)
+
+// ProjectApplication returns the Application that syncs the source directory of clusterCfg.
+// With the in-cluster Gitea server enabled, it syncs the branch the project is pushed to;
+// otherwise it syncs the artifact `ksail workload reconcile` pushes to the local registry.
+func ProjectApplication(clusterCfg *v1alpha1.Cluster) Application {
+	sourceDir := strings.TrimSpace(clusterCfg.Spec.SourceDirectory)
+	if sourceDir == "" {
+		sourceDir = v1alpha1.DefaultSourceDirectory
+	}
+
+	if clusterCfg.Spec.Options.Gitea.Enabled {
+		repository := gitea.RepositoryName(sourceDir)
+
+		return Application{
+			Name:                 repository,
+			RepoURL:              gitea.InClusterRepositoryURL(repository),
+			Path:                 ".",
+			TargetRevision:       gitea.DefaultBranch,
+			DestinationNamespace: defaultNamespace,
+		}
+	}
+
+	repository := fluxinstaller.WorkloadRepositoryName(sourceDir)
+
+	return Application{
+		Name:                 repository,
+		RepoURL:              LocalRegistryRepositoryURL(repository),
+		Path:                 ".",
+		TargetRevision:       ArtifactTag,
+		DestinationNamespace: defaultNamespace,
+	}
+}
+
+// LocalRegistryRepositoryURL returns the oci:// URL clusters pull repository from the local
+// registry with.
+func LocalRegistryRepositoryURL(repository string) string {
+	host := net.JoinHostPort(registry.LocalRegistryClusterHost, strconv.Itoa(registry.DefaultRegistryPort))
+
+	return fmt.Sprintf("oci://%s/%s", host, repository)
+}
+
+// SetLocalRegistryRepository registers repoURL, an oci:// URL of LocalRegistryRepositoryURL,
+// as a repository ArgoCD pulls over plain HTTP, as the local registry serves no TLS.
+func (a *ArgoCDInstaller) SetLocalRegistryRepository(repoURL string) {
+	a.registryRepo = repoURL
+}
+
+// ReconcileApplication creates or updates application and requests a hard refresh, so ArgoCD
+// syncs a freshly pushed revision without waiting for its next poll. It waits until every sync
+// wave of the Application is synced and healthy.
+func ReconcileApplication(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	application Application,
+	timeout time.Duration,
+) error {
+	desired := application.Manifest()
+	desired.SetAnnotations(map[string]string{refreshAnnotation: refreshHard})
+
+	return applyApplication(ctx, dynamicClient, desired, timeout)
+}
+
+// --- internals ---
+
+// registryRepositoryValues returns the configs.repositories chart values that register repoURL
+// as an insecure OCI repository.
+func registryRepositoryValues(repoURL string) map[string]any {
+	return map[string]any{
+		"ksail-local-registry": map[string]any{
+			"url":                  repoURL,
+			"type":                 "oci",
+			"name":                 "ksail-local-registry",
+			"insecureOCIForceHttp": "true",
+		},
+	}
+}
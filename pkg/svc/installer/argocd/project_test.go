@@ -0,0 +1,84 @@
+package argocdinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	argocdinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestProjectApplication(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.SourceDirectory = "k8s"
+
+	application := argocdinstaller.ProjectApplication(clusterCfg)
+	assert.Equal(t, "oci://local-registry:5000/k8s", application.RepoURL)
+	assert.Equal(t, argocdinstaller.ArtifactTag, application.TargetRevision)
+	assert.Equal(t, argocdinstaller.LocalRegistryRepositoryURL("k8s"), application.RepoURL)
+
+	clusterCfg.Spec.Options.Gitea.Enabled = true
+
+	application = argocdinstaller.ProjectApplication(clusterCfg)
+	assert.Equal(t, "http://gitea-http.gitea.svc.cluster.local:3000/ksail/k8s.git", application.RepoURL)
+	assert.Equal(t, "main", application.TargetRevision)
+	assert.Equal(t, ".", application.Path)
+}
+
+func TestArgoCDInstallerInstallWithLocalRegistryRepository(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newArgoCDInstallerWithDefaults(t)
+	installer.SetVersion("8.0.0")
+	installer.SetLocalRegistryRepository(argocdinstaller.LocalRegistryRepositoryURL("k8s"))
+
+	expectArgoCDAddRepository(t, client, nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, "8.0.0", spec.Version)
+				assert.Contains(t, spec.ValuesYaml, "url: oci://local-registry:5000/k8s")
+				assert.Contains(t, spec.ValuesYaml, `insecureOCIForceHttp: "true"`)
+
+				return true
+			}),
+		).
+		Return(nil, nil)
+
+	require.NoError(t, installer.Install(context.Background()))
+}
+
+func TestReconcileApplicationRequestsRefresh(t *testing.T) {
+	t.Parallel()
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(
+		runtime.NewScheme(),
+		newApplication("Synced", "Healthy", "Succeeded"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := argocdinstaller.ReconcileApplication(ctx, dynamicClient, argocdinstaller.Application{
+		Name:    "ksail",
+		RepoURL: argocdinstaller.LocalRegistryRepositoryURL("k8s"),
+		Path:    ".",
+	}, 5*time.Second)
+	require.NoError(t, err)
+
+	application, err := dynamicClient.Resource(applications).Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "hard", application.GetAnnotations()["argocd.argoproj.io/refresh"])
+}
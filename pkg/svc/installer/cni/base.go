@@ -37,6 +37,9 @@ type InstallerBase struct {
 	timeout    time.Duration
 	client     helm.Interface
 	waitFn     func(context.Context) error
+	version    string
+	values     []string
+	repository helm.RepoConfig
 }
 
 // NewInstallerBase creates a new base installer instance with the provided configuration.
@@ -121,6 +124,69 @@ func (b *InstallerBase) GetContext() string {
 	return b.context
 }
 
+// SetVersion pins the chart of the CNI the embedding installer deploys. An empty version resolves
+// to the chart locked for the project, or the newest one.
+func (b *InstallerBase) SetVersion(version string) {
+	b.version = version
+}
+
+// GetVersion returns the pinned chart version.
+func (b *InstallerBase) GetVersion() string {
+	return b.version
+}
+
+// SetRepository overrides the repository the chart is installed from, e.g. with an oci://
+// registry that mirrors it. Its URL, credentials and TLS settings replace those of the
+// installer's default repository. A repository without a URL keeps the default.
+func (b *InstallerBase) SetRepository(repository helm.RepoConfig) {
+	b.repository = repository
+}
+
+// InstallOrUpgradeChart installs or upgrades the chart of chartConfig from defaultRepo, or from
+// the repository set with SetRepository, with the installer's Helm client and timeout.
+func (b *InstallerBase) InstallOrUpgradeChart(
+	ctx context.Context,
+	defaultRepo helm.RepoConfig,
+	chartConfig helm.ChartConfig,
+) error {
+	client, err := b.GetClient()
+	if err != nil {
+		return fmt.Errorf("get helm client: %w", err)
+	}
+
+	repoConfig := defaultRepo
+	if b.repository.URL != "" {
+		repoConfig = b.repository
+		repoConfig.Name = defaultRepo.Name
+		repoConfig.RepoName = defaultRepo.RepoName
+		chartConfig.RepoURL = b.repository.URL
+	}
+
+	err = helm.InstallOrUpgradeChart(ctx, client, repoConfig, chartConfig, b.timeout)
+	if err != nil {
+		return fmt.Errorf("install or upgrade %s: %w", chartConfig.ReleaseName, err)
+	}
+
+	return nil
+}
+
+// SetValues sets user-supplied chart values as YAML documents, which are merged over the
+// installer's defaults in order.
+func (b *InstallerBase) SetValues(documents ...string) {
+	b.values = documents
+}
+
+// MergeValues merges the user-supplied chart values over the installer's default values and
+// returns them as a single YAML document.
+func (b *InstallerBase) MergeValues(defaults string) (string, error) {
+	values, err := helm.MergeValuesYAML(append([]string{defaults}, b.values...)...)
+	if err != nil {
+		return "", fmt.Errorf("merge chart values: %w", err)
+	}
+
+	return values, nil
+}
+
 // GetWaitFn returns the wait function for testing purposes.
 // This method is primarily used in tests to verify wait function behavior.
 func (b *InstallerBase) GetWaitFn() func(context.Context) error {
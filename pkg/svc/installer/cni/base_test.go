@@ -1,12 +1,14 @@
 package cni_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/client/helm"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer/cni"
 	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestInstallerBaseBuildRESTConfig(t *testing.T) {
@@ -85,3 +87,59 @@ func testBuildRESTConfigMissingContext(t *testing.T) {
 		"buildRESTConfig missing context",
 	)
 }
+
+func TestInstallerBaseInstallOrUpgradeChartFromRepositoryOverride(t *testing.T) {
+	t.Parallel()
+
+	defaultRepo := helm.RepoConfig{Name: "cilium", URL: "https://helm.cilium.io", RepoName: "cilium"}
+	chartConfig := helm.ChartConfig{
+		ReleaseName: "cilium",
+		ChartName:   "cilium/cilium",
+		Namespace:   "kube-system",
+		RepoURL:     defaultRepo.URL,
+	}
+
+	t.Run("DefaultRepository", func(t *testing.T) {
+		t.Parallel()
+
+		client := helm.NewMockInterface(t)
+		client.EXPECT().
+			AddRepository(mock.Anything, &helm.RepositoryEntry{Name: "cilium", URL: "https://helm.cilium.io"}).
+			Return(nil)
+		client.EXPECT().
+			InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				return spec.RepoURL == "https://helm.cilium.io" && spec.Username == ""
+			})).
+			Return(&helm.ReleaseInfo{}, nil)
+
+		base := cni.NewInstallerBase(client, "", "", time.Second, nil)
+
+		err := base.InstallOrUpgradeChart(context.Background(), defaultRepo, chartConfig)
+		testutils.ExpectNoError(t, err, "install from default repository")
+	})
+
+	t.Run("AuthenticatedOCIRegistry", func(t *testing.T) {
+		t.Parallel()
+
+		// OCI registries have no index to add, so AddRepository must not be called
+		client := helm.NewMockInterface(t)
+		client.EXPECT().
+			InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				return spec.RepoURL == "oci://registry.example.com/charts" &&
+					spec.ChartName == "cilium/cilium" &&
+					spec.Username == "ksail" && spec.Password == "secret" && spec.PlainHTTP
+			})).
+			Return(&helm.ReleaseInfo{}, nil)
+
+		base := cni.NewInstallerBase(client, "", "", time.Second, nil)
+		base.SetRepository(helm.RepoConfig{
+			URL:       "oci://registry.example.com/charts",
+			Username:  "ksail",
+			Password:  "secret",
+			PlainHTTP: true,
+		})
+
+		err := base.InstallOrUpgradeChart(context.Background(), defaultRepo, chartConfig)
+		testutils.ExpectNoError(t, err, "install from OCI registry")
+	})
+}
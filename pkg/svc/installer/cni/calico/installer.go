@@ -9,6 +9,11 @@ import (
 	"github.com/devantler-tech/ksail-go/pkg/k8s"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer/cni"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 // CalicoInstaller implements the installer.Installer interface for Calico.
@@ -49,13 +54,20 @@ func (c *CalicoInstaller) SetWaitForReadinessFunc(waitFunc func(context.Context)
 	c.InstallerBase.SetWaitForReadinessFunc(waitFunc, c.waitForReadiness)
 }
 
-// Uninstall removes the Helm release for Calico.
+// Uninstall removes the Calico installation and then the Helm release for the Tigera operator.
+// The operator only removes the Calico components while it still runs, so the installation is
+// deleted first and the Calico nodes are awaited before the operator goes.
 func (c *CalicoInstaller) Uninstall(ctx context.Context) error {
 	client, err := c.GetClient()
 	if err != nil {
 		return fmt.Errorf("get helm client: %w", err)
 	}
 
+	err = c.deleteInstallation(ctx)
+	if err != nil {
+		return err
+	}
+
 	err = client.UninstallRelease(ctx, "calico", "tigera-operator")
 	if err != nil {
 		return fmt.Errorf("failed to uninstall calico release: %w", err)
@@ -67,9 +79,9 @@ func (c *CalicoInstaller) Uninstall(ctx context.Context) error {
 // --- internals ---
 
 func (c *CalicoInstaller) helmInstallOrUpgradeCalico(ctx context.Context) error {
-	client, err := c.GetClient()
+	values, err := c.MergeValues("")
 	if err != nil {
-		return fmt.Errorf("get helm client: %w", err)
+		return fmt.Errorf("calico values: %w", err)
 	}
 
 	repoConfig := helm.RepoConfig{
@@ -83,29 +95,70 @@ func (c *CalicoInstaller) helmInstallOrUpgradeCalico(ctx context.Context) error
 		ChartName:       "projectcalico/tigera-operator",
 		Namespace:       "tigera-operator",
 		RepoURL:         "https://docs.tigera.io/calico/charts",
+		Version:         c.GetVersion(),
 		CreateNamespace: true,
+		ValuesYaml:      values,
+	}
+
+	//nolint:wrapcheck // The installer base wraps the error with the release name.
+	return c.InstallOrUpgradeChart(ctx, repoConfig, chartConfig)
+}
+
+// operatorResources are the cluster-scoped Tigera operator resources that describe the Calico
+// installation, named "default".
+//
+//nolint:gochecknoglobals // Resources of the Tigera operator API.
+var operatorResources = []schema.GroupVersionResource{
+	{Group: "operator.tigera.io", Version: "v1", Resource: "apiservers"},
+	{Group: "operator.tigera.io", Version: "v1", Resource: "installations"},
+}
+
+func (c *CalicoInstaller) deleteInstallation(ctx context.Context) error {
+	restConfig, err := c.BuildRESTConfig()
+	if err != nil {
+		return fmt.Errorf("build kubernetes client config: %w", err)
 	}
 
-	err = helm.InstallOrUpgradeChart(ctx, client, repoConfig, chartConfig, c.GetTimeout())
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
-		return fmt.Errorf("install or upgrade calico: %w", err)
+		return fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	for _, resource := range operatorResources {
+		err = dynamicClient.Resource(resource).Delete(ctx, "default", metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete calico %s: %w", resource.Resource, err)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	err = cni.WaitForPodsDeleted(ctx, clientset, "calico-system", "k8s-app=calico-node", c.GetTimeout())
+	if err != nil {
+		return fmt.Errorf("wait for calico nodes to stop: %w", err)
 	}
 
 	return nil
 }
 
-func (c *CalicoInstaller) waitForReadiness(ctx context.Context) error {
-	checks := []k8s.ReadinessCheck{
+// ReadinessChecks returns the workloads that are ready once Calico is.
+func ReadinessChecks() []k8s.ReadinessCheck {
+	return []k8s.ReadinessCheck{
 		{Type: "deployment", Namespace: "tigera-operator", Name: "tigera-operator"},
 		{Type: "daemonset", Namespace: "calico-system", Name: "calico-node"},
 		{Type: "deployment", Namespace: "calico-system", Name: "calico-kube-controllers"},
 	}
+}
 
+func (c *CalicoInstaller) waitForReadiness(ctx context.Context) error {
 	err := installer.WaitForResourceReadiness(
 		ctx,
 		c.GetKubeconfig(),
 		c.GetContext(),
-		checks,
+		ReadinessChecks(),
 		c.GetTimeout(),
 		"calico",
 	)
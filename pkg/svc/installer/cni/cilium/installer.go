@@ -9,6 +9,7 @@ import (
 	"github.com/devantler-tech/ksail-go/pkg/k8s"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer/cni"
+	"k8s.io/client-go/kubernetes"
 )
 
 // CiliumInstaller implements the installer.Installer interface for Cilium.
@@ -49,7 +50,8 @@ func (c *CiliumInstaller) SetWaitForReadinessFunc(waitFunc func(context.Context)
 	c.InstallerBase.SetWaitForReadinessFunc(waitFunc, c.waitForReadiness)
 }
 
-// Uninstall removes the Helm release for Cilium.
+// Uninstall removes the Helm release for Cilium and waits for the Cilium agents to stop, as
+// they remove their CNI configuration from the nodes on shutdown.
 func (c *CiliumInstaller) Uninstall(ctx context.Context) error {
 	client, err := c.GetClient()
 	if err != nil {
@@ -61,15 +63,30 @@ func (c *CiliumInstaller) Uninstall(ctx context.Context) error {
 		return fmt.Errorf("failed to uninstall cilium release: %w", err)
 	}
 
+	restConfig, err := c.BuildRESTConfig()
+	if err != nil {
+		return fmt.Errorf("build kubernetes client config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	err = cni.WaitForPodsDeleted(ctx, clientset, "kube-system", "k8s-app=cilium", c.GetTimeout())
+	if err != nil {
+		return fmt.Errorf("wait for cilium agents to stop: %w", err)
+	}
+
 	return nil
 }
 
 // --- internals ---
 
 func (c *CiliumInstaller) helmInstallOrUpgradeCilium(ctx context.Context) error {
-	client, err := c.GetClient()
+	values, err := c.MergeValues(ciliumValues)
 	if err != nil {
-		return fmt.Errorf("get helm client: %w", err)
+		return fmt.Errorf("cilium values: %w", err)
 	}
 
 	repoConfig := helm.RepoConfig{
@@ -83,35 +100,37 @@ func (c *CiliumInstaller) helmInstallOrUpgradeCilium(ctx context.Context) error
 		ChartName:       "cilium/cilium",
 		Namespace:       "kube-system",
 		RepoURL:         "https://helm.cilium.io",
+		Version:         c.GetVersion(),
 		CreateNamespace: false,
-		SetJSONVals:     defaultCiliumValues(),
+		ValuesYaml:      values,
 	}
 
-	err = helm.InstallOrUpgradeChart(ctx, client, repoConfig, chartConfig, c.GetTimeout())
-	if err != nil {
-		return fmt.Errorf("install or upgrade cilium: %w", err)
-	}
-
-	return nil
-}
-
-func defaultCiliumValues() map[string]string {
-	return map[string]string{
-		"operator.replicas": "1",
-	}
+	//nolint:wrapcheck // The installer base wraps the error with the release name.
+	return c.InstallOrUpgradeChart(ctx, repoConfig, chartConfig)
 }
 
-func (c *CiliumInstaller) waitForReadiness(ctx context.Context) error {
-	checks := []k8s.ReadinessCheck{
+// ciliumValues are the default chart values. The agents remove their CNI configuration when
+// they stop, so another CNI can take over.
+const ciliumValues = `operator:
+  replicas: 1
+cni:
+  uninstall: true
+`
+
+// ReadinessChecks returns the workloads that are ready once Cilium is.
+func ReadinessChecks() []k8s.ReadinessCheck {
+	return []k8s.ReadinessCheck{
 		{Type: "daemonset", Namespace: "kube-system", Name: "cilium"},
 		{Type: "deployment", Namespace: "kube-system", Name: "cilium-operator"},
 	}
+}
 
+func (c *CiliumInstaller) waitForReadiness(ctx context.Context) error {
 	err := installer.WaitForResourceReadiness(
 		ctx,
 		c.GetKubeconfig(),
 		c.GetContext(),
-		checks,
+		ReadinessChecks(),
 		c.GetTimeout(),
 		"cilium",
 	)
@@ -31,7 +31,8 @@
 //     }
 //
 //  4. Use the shared helper functions for Helm operations:
-//     - helm.InstallOrUpgradeChart() (from pkg/client/helm) for chart installation
+//     - InstallerBase.InstallOrUpgradeChart() for chart installation, which honours the
+//     repository set with SetRepository
 //     - installer.WaitForResourceReadiness() (from pkg/svc/installer) for readiness checks
 //
 //  5. Add comprehensive unit tests following the patterns in existing CNI implementations
@@ -69,6 +70,7 @@
 // All CNI installers benefit from shared utilities in InstallerBase:
 //
 //   - Helm client management for chart operations
+//   - Repository overrides, including authenticated oci:// registries that mirror the charts
 //   - Kubeconfig and context handling for cluster access
 //   - Timeout management for long-running operations
 //   - Standardized readiness checking patterns
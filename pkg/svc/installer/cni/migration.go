@@ -0,0 +1,114 @@
+package cni
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kindnetNamespace = "kube-system"
+	kindnetName      = "kindnet"
+	kindnetSelector  = "app=kindnet"
+)
+
+// RemoveKindnet removes kindnet, the CNI Kind installs when its default CNI is enabled, and
+// waits for its pods to be gone. A cluster without kindnet is left unchanged.
+func RemoveKindnet(ctx context.Context, clientset kubernetes.Interface, timeout time.Duration) error {
+	err := clientset.AppsV1().DaemonSets(kindnetNamespace).Delete(ctx, kindnetName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete kindnet daemonset: %w", err)
+	}
+
+	return WaitForPodsDeleted(ctx, clientset, kindnetNamespace, kindnetSelector, timeout)
+}
+
+// WaitForPodsDeleted waits until no pod in namespace matches the label selector.
+func WaitForPodsDeleted(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace, selector string,
+	timeout time.Duration,
+) error {
+	err := k8s.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, fmt.Errorf("list pods %q in %s: %w", selector, namespace, err)
+		}
+
+		return len(pods.Items) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for pods %q in %s to be deleted: %w", selector, namespace, err)
+	}
+
+	return nil
+}
+
+// WaitForNodesReady waits until every node of the cluster reports the Ready condition, which
+// kubelets only do once a CNI is installed.
+func WaitForNodesReady(ctx context.Context, clientset kubernetes.Interface, timeout time.Duration) error {
+	err := k8s.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, fmt.Errorf("list nodes: %w", err)
+		}
+
+		for _, node := range nodes.Items {
+			if !nodeReady(node) {
+				return false, nil
+			}
+		}
+
+		return len(nodes.Items) > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for nodes to be ready: %w", err)
+	}
+
+	return nil
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// RestartPodNetworking deletes the running pods that got their network from the previous CNI,
+// so their controllers recreate them on the new one. Pods on the host network and pods
+// without a controller are left alone. It returns the number of deleted pods.
+func RestartPodNetworking(ctx context.Context, clientset kubernetes.Interface) (int, error) {
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list pods: %w", err)
+	}
+
+	restarted := 0
+
+	for _, pod := range pods.Items {
+		if pod.Spec.HostNetwork || len(pod.OwnerReferences) == 0 ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		err = clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return restarted, fmt.Errorf("delete pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+
+		restarted++
+	}
+
+	return restarted, nil
+}
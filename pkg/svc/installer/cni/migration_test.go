@@ -0,0 +1,96 @@
+package cni_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer/cni"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRemoveKindnet(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kindnet", Namespace: "kube-system"}},
+	)
+
+	err := cni.RemoveKindnet(context.Background(), clientset, time.Second)
+	require.NoError(t, err)
+
+	daemonSets, err := clientset.AppsV1().DaemonSets("kube-system").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, daemonSets.Items)
+
+	// A cluster without kindnet is left unchanged.
+	require.NoError(t, cni.RemoveKindnet(context.Background(), clientset, time.Second))
+}
+
+func TestWaitForPodsDeletedTimesOut(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "cilium-abcde",
+		Namespace: "kube-system",
+		Labels:    map[string]string{"k8s-app": "cilium"},
+	}})
+
+	err := cni.WaitForPodsDeleted(context.Background(), clientset, "kube-system", "k8s-app=cilium", 50*time.Millisecond)
+	require.ErrorContains(t, err, "to be deleted")
+}
+
+func TestWaitForNodesReady(t *testing.T) {
+	t.Parallel()
+
+	readyNode := func(name string, status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status},
+			}},
+		}
+	}
+
+	ready := fake.NewClientset(readyNode("control-plane", corev1.ConditionTrue))
+	require.NoError(t, cni.WaitForNodesReady(context.Background(), ready, time.Second))
+
+	notReady := fake.NewClientset(
+		readyNode("control-plane", corev1.ConditionTrue),
+		readyNode("worker", corev1.ConditionFalse),
+	)
+	require.Error(t, cni.WaitForNodesReady(context.Background(), notReady, 50*time.Millisecond))
+}
+
+func TestRestartPodNetworking(t *testing.T) {
+	t.Parallel()
+
+	owner := []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "coredns-5d78c9869d"}}
+	clientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system", OwnerReferences: owner}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system", OwnerReferences: owner},
+			Spec:       corev1.PodSpec{HostNetwork: true},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default", OwnerReferences: owner},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	)
+
+	restarted, err := cni.RestartPodNetworking(context.Background(), clientset)
+	require.NoError(t, err)
+	require.Equal(t, 1, restarted)
+
+	_, err = clientset.CoreV1().Pods("kube-system").Get(context.Background(), "coredns", metav1.GetOptions{})
+	require.Error(t, err, "the pod networked by the previous CNI is deleted")
+
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 3)
+}
@@ -0,0 +1,6 @@
+// Package cnpginstaller provides an installer for installing the CloudNativePG operator
+// on a Kubernetes cluster.
+//
+// The operator reconciles postgresql.cnpg.io Cluster resources, such as those generated
+// by `ksail workload gen pgcluster`, into running PostgreSQL instances.
+package cnpginstaller
@@ -0,0 +1,152 @@
+package cnpginstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	cnpginstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cnpg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCNPGInstaller(t *testing.T) {
+	t.Parallel()
+
+	timeout := 5 * time.Minute
+
+	client := helm.NewMockInterface(t)
+	installer := cnpginstaller.NewCNPGInstaller(client, timeout)
+
+	assert.NotNil(t, installer)
+}
+
+func TestCNPGInstallerInstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newCNPGInstallerWithDefaults(t)
+	expectCNPGInstall(t, client, nil)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestCNPGInstallerInstallRepositoryError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newCNPGInstallerWithDefaults(t)
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "cnpg" && entry.URL == "https://cloudnative-pg.github.io/charts"
+		})).
+		Return(assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to add cnpg repository")
+}
+
+func TestCNPGInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newCNPGInstallerWithDefaults(t)
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.Anything).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.Anything).
+		Return(nil, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to install cnpg chart")
+}
+
+func TestCNPGInstallerUninstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newCNPGInstallerWithDefaults(t)
+	expectCNPGUninstall(t, client, nil)
+
+	err := installer.Uninstall(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestCNPGInstallerUninstallError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newCNPGInstallerWithDefaults(t)
+	expectCNPGUninstall(t, client, assert.AnError)
+
+	err := installer.Uninstall(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to uninstall cnpg release")
+}
+
+func newCNPGInstallerWithDefaults(
+	t *testing.T,
+) (*cnpginstaller.CNPGInstaller, *helm.MockInterface) {
+	t.Helper()
+	client := helm.NewMockInterface(t)
+	installer := cnpginstaller.NewCNPGInstaller(
+		client,
+		5*time.Second,
+	)
+
+	return installer, client
+}
+
+func expectCNPGInstall(t *testing.T, client *helm.MockInterface, installErr error) {
+	t.Helper()
+
+	client.EXPECT().
+		AddRepository(
+			mock.Anything,
+			mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+				assert.Equal(t, "cnpg", entry.Name)
+				assert.Equal(t, "https://cloudnative-pg.github.io/charts", entry.URL)
+
+				return true
+			}),
+		).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, "cnpg", spec.ReleaseName)
+				assert.Equal(t, "cnpg/cloudnative-pg", spec.ChartName)
+				assert.Equal(t, "cnpg-system", spec.Namespace)
+				assert.True(t, spec.CreateNamespace)
+				assert.True(t, spec.Atomic)
+				assert.True(t, spec.Wait)
+				assert.True(t, spec.WaitForJobs)
+
+				return true
+			}),
+		).
+		Return(&helm.ReleaseInfo{}, installErr)
+}
+
+func expectCNPGUninstall(
+	t *testing.T,
+	client *helm.MockInterface,
+	uninstallErr error,
+) {
+	t.Helper()
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, "cnpg", "cnpg-system").
+		Return(uninstallErr)
+}
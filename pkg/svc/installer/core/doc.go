@@ -0,0 +1,7 @@
+// Package coreinstaller installs the core components of a new cluster: its custom CNI,
+// metrics-server and Flux.
+//
+// `ksail cluster create` and the embeddable ksail package both install them through this
+// package, so a cluster comes up the same either way. Callers provide the Helm client, which
+// carries their chart pinning, and an Activity to report progress with.
+package coreinstaller
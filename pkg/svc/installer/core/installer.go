@@ -0,0 +1,285 @@
+package coreinstaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/audit"
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	calicoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/calico"
+	ciliuminstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/cni/cilium"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	metricsserverinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metrics-server"
+)
+
+// Component names, as spec.options.install keys their timeouts and retry policies.
+const (
+	ComponentCNI           = "cni"
+	ComponentMetricsServer = "metrics-server"
+	ComponentFlux          = "flux"
+)
+
+const ciliumRepositoryURL = "https://helm.cilium.io/"
+
+// ErrUnsupportedCNI is returned for a CNI that has no installer.
+var ErrUnsupportedCNI = errors.New("unsupported CNI type")
+
+// Activity reports a step of an installation, such as "installing cilium".
+type Activity func(content string)
+
+// CNIInstaller is implemented by the Cilium and Calico installers.
+type CNIInstaller interface {
+	installer.Installer
+	WaitForReadiness(ctx context.Context) error
+}
+
+// NewHelmClient returns a Helm client for the cluster at kubeconfig that pulls charts through
+// the chart mirror of clusterCfg and retries transient failures as spec.options.install sets
+// for component. opts add to those, such as chart pinning.
+func NewHelmClient(
+	clusterCfg *v1alpha1.Cluster,
+	kubeconfig string,
+	component string,
+	opts ...helm.ClientOption,
+) (*helm.Client, error) {
+	opts = append(opts,
+		helm.WithChartMirror(clusterCfg.Spec.Options.Helm.ChartMirror),
+		helm.WithRetry(installer.GetRetryPolicy(clusterCfg, component)),
+	)
+
+	helmClient, err := helm.NewClient(kubeconfig, clusterCfg.Spec.Connection.Context, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Helm client: %w", err)
+	}
+
+	return helmClient, nil
+}
+
+// CNIName returns the name of the custom CNI of clusterCfg, or an empty name for the CNI the
+// distribution comes with.
+func CNIName(clusterCfg *v1alpha1.Cluster) (string, error) {
+	switch clusterCfg.Spec.CNI {
+	case v1alpha1.CNICilium:
+		return "cilium", nil
+	case v1alpha1.CNICalico:
+		return "calico", nil
+	case v1alpha1.CNIDefault, "":
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCNI, clusterCfg.Spec.CNI)
+	}
+}
+
+// InstallCNI installs the custom CNI of clusterCfg and waits for it to be ready. The CNI the
+// distribution comes with needs no installation.
+func InstallCNI(
+	ctx context.Context,
+	helmClient helm.Interface,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+	activity Activity,
+) error {
+	name, err := CNIName(clusterCfg)
+	if err != nil || name == "" {
+		return err
+	}
+
+	cni, err := NewCNIInstaller(ctx, helmClient, kubeconfig, clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	activity("installing " + name)
+
+	err = audit.Track(ctx, audit.ActionComponentInstall, name, func() error {
+		return cni.Install(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("%s installation failed: %w", name, err)
+	}
+
+	activity("awaiting " + name + " to be ready")
+
+	err = cni.WaitForReadiness(ctx)
+	if err != nil {
+		return fmt.Errorf("%s readiness check failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// NewCNIInstaller returns the installer of the custom CNI of clusterCfg, configured with the
+// version, values and chart repository it sets. The Cilium repository is added to helmClient.
+//
+//nolint:ireturn // Cilium and Calico share the CNIInstaller abstraction.
+func NewCNIInstaller(
+	ctx context.Context,
+	helmClient helm.Interface,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+) (CNIInstaller, error) {
+	name, err := CNIName(clusterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCNI, v1alpha1.CNIDefault)
+	}
+
+	options := clusterCfg.Spec.Options
+	timeout := installer.GetComponentTimeout(clusterCfg, ComponentCNI)
+	kubeContext := clusterCfg.Spec.Connection.Context
+
+	if clusterCfg.Spec.CNI == v1alpha1.CNICalico {
+		values, err := installer.ReadHelmValues(options.Calico.HelmValues())
+		if err != nil {
+			return nil, err //nolint:wrapcheck // ReadHelmValues names the values file.
+		}
+
+		calico := calicoinstaller.NewCalicoInstaller(helmClient, kubeconfig, kubeContext, timeout)
+		calico.SetRepository(installer.ChartRepository(options.Calico.Repository))
+		calico.SetVersion(options.Calico.Version)
+		calico.SetValues(values...)
+
+		return calico, nil
+	}
+
+	err = helmClient.AddRepository(ctx, ciliumRepository(options.Cilium))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add Cilium Helm repository: %w", err)
+	}
+
+	values, err := installer.ReadHelmValues(options.Cilium.HelmValues())
+	if err != nil {
+		return nil, err //nolint:wrapcheck // ReadHelmValues names the values file.
+	}
+
+	cilium := ciliuminstaller.NewCiliumInstaller(helmClient, kubeconfig, kubeContext, timeout)
+	cilium.SetRepository(installer.ChartRepository(options.Cilium.Repository))
+	cilium.SetVersion(options.Cilium.Version)
+	cilium.SetValues(values...)
+
+	return cilium, nil
+}
+
+// ciliumRepository returns the Helm repository of the Cilium chart, or the repository that
+// options overrides it with.
+func ciliumRepository(options v1alpha1.OptionsCilium) *helm.RepositoryEntry {
+	if options.Repository.URL == "" {
+		return &helm.RepositoryEntry{Name: "cilium", URL: ciliumRepositoryURL}
+	}
+
+	repository := installer.ChartRepository(options.Repository)
+
+	return &helm.RepositoryEntry{
+		Name:                  "cilium",
+		URL:                   repository.URL,
+		Username:              repository.Username,
+		Password:              repository.Password,
+		InsecureSkipTLSverify: repository.InsecureSkipTLSverify,
+		PlainHTTP:             repository.PlainHTTP,
+	}
+}
+
+// NeedsMetricsServer reports whether clusterCfg enables metrics-server on a distribution that
+// does not ship it. Distributions that ship it have it disabled in their configuration before
+// the cluster is created, so a disabled metrics-server needs nothing afterwards.
+func NeedsMetricsServer(clusterCfg *v1alpha1.Cluster) bool {
+	return clusterCfg.Spec.MetricsServer == v1alpha1.MetricsServerEnabled &&
+		!clusterCfg.Spec.Distribution.ProvidesMetricsServerByDefault()
+}
+
+// NewMetricsServerInstaller returns the installer of metrics-server, configured with the
+// version and values clusterCfg sets.
+func NewMetricsServerInstaller(
+	helmClient helm.Interface,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+) (*metricsserverinstaller.MetricsServerInstaller, error) {
+	values, err := installer.ReadHelmValues(clusterCfg.Spec.Options.MetricsServer.HelmValues())
+	if err != nil {
+		return nil, err //nolint:wrapcheck // ReadHelmValues names the values file.
+	}
+
+	msInstaller := metricsserverinstaller.NewMetricsServerInstaller(
+		helmClient,
+		kubeconfig,
+		clusterCfg.Spec.Connection.Context,
+		installer.GetComponentTimeout(clusterCfg, ComponentMetricsServer),
+	)
+	msInstaller.SetVersion(clusterCfg.Spec.Options.MetricsServer.Version)
+	msInstaller.SetValues(values...)
+
+	return msInstaller, nil
+}
+
+// InstallMetricsServer installs metrics-server with the version and values of clusterCfg.
+func InstallMetricsServer(
+	ctx context.Context,
+	helmClient helm.Interface,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+	activity Activity,
+) error {
+	msInstaller, err := NewMetricsServerInstaller(helmClient, kubeconfig, clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	activity("installing metrics-server")
+
+	err = audit.Track(ctx, audit.ActionComponentInstall, ComponentMetricsServer, func() error {
+		return msInstaller.Install(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("metrics-server installation failed: %w", err)
+	}
+
+	return nil
+}
+
+// NewFluxInstaller returns the installer of the Flux operator, with the version and timeout
+// clusterCfg sets.
+//
+//nolint:ireturn // Returns the installer abstraction callers substitute in tests.
+func NewFluxInstaller(helmClient helm.Interface, clusterCfg *v1alpha1.Cluster) installer.Installer {
+	fluxInstaller := fluxinstaller.NewFluxInstaller(
+		helmClient,
+		installer.GetComponentTimeout(clusterCfg, ComponentFlux),
+	)
+	fluxInstaller.SetVersion(clusterCfg.Spec.Options.Flux.Version)
+
+	return fluxInstaller
+}
+
+// InstallFlux installs the Flux operator with fluxInstaller, then the default Flux resources
+// that sync the cluster from its OCI source.
+func InstallFlux(
+	ctx context.Context,
+	fluxInstaller installer.Installer,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+	activity Activity,
+) error {
+	activity("installing controllers")
+
+	err := audit.Track(ctx, audit.ActionComponentInstall, ComponentFlux, func() error {
+		return fluxInstaller.Install(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install flux controllers: %w", err)
+	}
+
+	activity("applying custom resources")
+
+	err = fluxinstaller.EnsureDefaultResources(ctx, kubeconfig, clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to configure Flux resources: %w", err)
+	}
+
+	return nil
+}
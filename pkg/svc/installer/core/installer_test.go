@@ -0,0 +1,127 @@
+package coreinstaller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	coreinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errRepository = errors.New("repository unreachable")
+
+func TestCNIName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cni  v1alpha1.CNI
+		want string
+	}{
+		{cni: v1alpha1.CNICilium, want: "cilium"},
+		{cni: v1alpha1.CNICalico, want: "calico"},
+		{cni: v1alpha1.CNIDefault, want: ""},
+		{cni: "", want: ""},
+	}
+
+	for _, test := range tests {
+		clusterCfg := v1alpha1.NewCluster()
+		clusterCfg.Spec.CNI = test.cni
+
+		name, err := coreinstaller.CNIName(clusterCfg)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, name)
+	}
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.CNI = "Flannel"
+
+	_, err := coreinstaller.CNIName(clusterCfg)
+	require.ErrorIs(t, err, coreinstaller.ErrUnsupportedCNI)
+}
+
+func TestNeedsMetricsServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		distribution  v1alpha1.Distribution
+		metricsServer v1alpha1.MetricsServer
+		want          bool
+	}{
+		{distribution: v1alpha1.DistributionKind, metricsServer: v1alpha1.MetricsServerEnabled, want: true},
+		{distribution: v1alpha1.DistributionKind, metricsServer: v1alpha1.MetricsServerDisabled, want: false},
+		{distribution: v1alpha1.DistributionK3d, metricsServer: v1alpha1.MetricsServerEnabled, want: false},
+		{distribution: v1alpha1.DistributionK3d, metricsServer: v1alpha1.MetricsServerDisabled, want: false},
+	}
+
+	for _, test := range tests {
+		clusterCfg := v1alpha1.NewCluster()
+		clusterCfg.Spec.Distribution = test.distribution
+		clusterCfg.Spec.MetricsServer = test.metricsServer
+
+		assert.Equal(t, test.want, coreinstaller.NeedsMetricsServer(clusterCfg),
+			"%s with metrics-server %s", test.distribution, test.metricsServer)
+	}
+}
+
+func TestInstallCNISkipsTheDefaultCNI(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.CNI = v1alpha1.CNIDefault
+
+	err := coreinstaller.InstallCNI(
+		context.Background(),
+		helm.NewMockInterface(t),
+		"",
+		clusterCfg,
+		func(string) { t.Fatal("expected no activity for the default CNI") },
+	)
+	require.NoError(t, err)
+}
+
+func TestNewCNIInstallerAddsTheCiliumRepository(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.CNI = v1alpha1.CNICilium
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "cilium" && entry.URL == "https://helm.cilium.io/"
+		})).
+		Return(nil)
+
+	cni, err := coreinstaller.NewCNIInstaller(context.Background(), client, "", clusterCfg)
+	require.NoError(t, err)
+	assert.NotNil(t, cni)
+}
+
+func TestNewCNIInstallerFailsWhenTheCiliumRepositoryCannotBeAdded(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.CNI = v1alpha1.CNICilium
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(errRepository)
+
+	_, err := coreinstaller.NewCNIInstaller(context.Background(), client, "", clusterCfg)
+	require.ErrorIs(t, err, errRepository)
+}
+
+func TestNewCNIInstallerDoesNotAddARepositoryForCalico(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.CNI = v1alpha1.CNICalico
+
+	cni, err := coreinstaller.NewCNIInstaller(context.Background(), helm.NewMockInterface(t), "", clusterCfg)
+	require.NoError(t, err)
+	assert.NotNil(t, cni)
+}
@@ -1,6 +1,9 @@
 // Package installer provides functionality for installing and uninstalling components.
 //
 // This package defines the Installer interface and provides implementations
-// for installing various Kubernetes components (ArgoCD, Flux, Istio, Cilium,
-// Traefik, metrics-server, ApplySet) on Kubernetes clusters.
+// for installing various Kubernetes components (ArgoCD, Argo Rollouts, Flux, Istio,
+// Cilium, Traefik, Gitea, Headlamp, LocalStack, Vault, CloudNativePG, Kyverno,
+// metrics-server, Gateway API, ApplySet) on Kubernetes clusters, an installer that runs
+// third-party plugins, and a Pipeline that runs installations in the order of their
+// dependencies.
 package installer
@@ -0,0 +1,126 @@
+package fluxinstaller
+
+import (
+	"context"
+	"fmt"
+
+	fluxclient "github.com/devantler-tech/ksail-go/pkg/client/flux"
+	ciphersvc "github.com/devantler-tech/ksail-go/pkg/svc/cipher"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DecryptionSecretName is the Secret with the age keys kustomize-controller decrypts
+	// SOPS-encrypted workloads with.
+	DecryptionSecretName = "sops-age"
+	// DecryptionSecretKey is the key of the age identities in the decryption Secret. Flux
+	// reads every key ending in .agekey.
+	DecryptionSecretKey = "age.agekey"
+
+	decryptionProvider = "sops"
+)
+
+//nolint:gochecknoglobals // Allows mocking the local age keys for tests
+var loadAgeKeys = ciphersvc.LoadAgeKeys
+
+// NewDecryptionSecret returns the Secret that hands ageKeys to kustomize-controller.
+func NewDecryptionSecret(ageKeys string) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DecryptionSecretName,
+			Namespace: fluxclient.DefaultNamespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{DecryptionSecretKey: ageKeys},
+	}
+}
+
+// WorkloadDecryption returns the SOPS decryption settings of the Kustomization that syncs the
+// workloads.
+func WorkloadDecryption() *kustomizev1.Decryption {
+	return &kustomizev1.Decryption{
+		Provider:  decryptionProvider,
+		SecretRef: &meta.LocalObjectReference{Name: DecryptionSecretName},
+	}
+}
+
+// --- internals ---
+
+// decryptionKustomize patches the Kustomization the FluxInstance generates for its sync with
+// WorkloadDecryption, as the sync settings of the operator have no field for it.
+func decryptionKustomize() *Kustomize {
+	patch := fmt.Sprintf(`- op: add
+  path: /spec/decryption
+  value:
+    provider: %s
+    secretRef:
+      name: %s
+`, decryptionProvider, DecryptionSecretName)
+
+	return &Kustomize{
+		Patches: []KustomizePatch{{
+			Patch: patch,
+			Target: &PatchTarget{
+				Kind: kustomizev1.KustomizationKind,
+				Name: defaultOCIRepositoryName,
+			},
+		}},
+	}
+}
+
+// ensureDecryption creates or updates the decryption Secret from the local age keys. It
+// reports whether keys were found, so callers only configure decryption when Flux can use it.
+func ensureDecryption(ctx context.Context, fluxClient client.Client) (bool, error) {
+	ageKeys, err := loadAgeKeys()
+	if err != nil {
+		return false, fmt.Errorf("failed to load age keys: %w", err)
+	}
+
+	if ageKeys == "" {
+		return false, nil
+	}
+
+	err = applySecret(ctx, fluxClient, NewDecryptionSecret(ageKeys))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// applySecret creates desired, or replaces the data of the existing Secret with its data.
+func applySecret(ctx context.Context, fluxClient client.Client, desired *corev1.Secret) error {
+	key := client.ObjectKeyFromObject(desired)
+
+	existing := &corev1.Secret{}
+
+	err := fluxClient.Get(ctx, key, existing)
+	if apierrors.IsNotFound(err) {
+		err = fluxClient.Create(ctx, desired)
+		if err != nil {
+			return fmt.Errorf("create Secret %s/%s: %w", key.Namespace, key.Name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get Secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	existing.Data = nil
+	existing.StringData = desired.StringData
+
+	err = fluxClient.Update(ctx, existing)
+	if err != nil {
+		return fmt.Errorf("failed to update Secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	return nil
+}
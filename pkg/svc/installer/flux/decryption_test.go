@@ -0,0 +1,29 @@
+package fluxinstaller_test
+
+import (
+	"testing"
+
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDecryptionSecretHoldsAgeKeys(t *testing.T) {
+	t.Parallel()
+
+	secret := fluxinstaller.NewDecryptionSecret("AGE-SECRET-KEY-1TEST\n")
+
+	assert.Equal(t, fluxinstaller.DecryptionSecretName, secret.Name)
+	assert.Equal(t, "flux-system", secret.Namespace)
+	assert.Equal(t, "AGE-SECRET-KEY-1TEST\n", secret.StringData[fluxinstaller.DecryptionSecretKey])
+}
+
+func TestWorkloadDecryptionReferencesSecret(t *testing.T) {
+	t.Parallel()
+
+	decryption := fluxinstaller.WorkloadDecryption()
+
+	assert.Equal(t, "sops", decryption.Provider)
+	require.NotNil(t, decryption.SecretRef)
+	assert.Equal(t, fluxinstaller.DecryptionSecretName, decryption.SecretRef.Name)
+}
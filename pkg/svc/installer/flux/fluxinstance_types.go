@@ -9,6 +9,7 @@ import (
 const (
 	fluxInstanceKind        = "FluxInstance"
 	fluxOCIRepositoryKind   = "OCIRepository"
+	fluxGitRepositoryKind   = "GitRepository"
 	fluxInstanceDefaultName = "flux"
 	fluxInstanceGroup       = "fluxcd.controlplane.io"
 	fluxInstanceVersion     = "v1"
@@ -110,6 +111,7 @@ func (in *FluxInstanceList) DeepCopyObject() runtime.Object {
 type FluxInstanceSpec struct {
 	Distribution Distribution `json:"distribution"`
 	Sync         *Sync        `json:"sync,omitempty"`
+	Kustomize    *Kustomize   `json:"kustomize,omitempty"`
 }
 
 // DeepCopyInto copies all properties from this FluxInstanceSpec into another.
@@ -119,6 +121,11 @@ func (in *FluxInstanceSpec) DeepCopyInto(out *FluxInstanceSpec) {
 		out.Sync = new(Sync)
 		in.Sync.DeepCopyInto(out.Sync)
 	}
+
+	if in.Kustomize != nil {
+		out.Kustomize = new(Kustomize)
+		in.Kustomize.DeepCopyInto(out.Kustomize)
+	}
 }
 
 // Distribution references the Flux manifests and controller images KSail should install.
@@ -149,6 +156,44 @@ func (in *Sync) DeepCopyInto(out *Sync) {
 	}
 }
 
+// Kustomize holds the patches the operator applies to the resources it generates, including
+// the Kustomization of the sync.
+type Kustomize struct {
+	Patches []KustomizePatch `json:"patches,omitempty"`
+}
+
+// DeepCopyInto copies all properties into another Kustomize.
+func (in *Kustomize) DeepCopyInto(out *Kustomize) {
+	*out = *in
+	if in.Patches != nil {
+		out.Patches = make([]KustomizePatch, len(in.Patches))
+		for i := range in.Patches {
+			in.Patches[i].DeepCopyInto(&out.Patches[i])
+		}
+	}
+}
+
+// KustomizePatch is a JSON6902 or strategic merge patch for the resources matching Target.
+type KustomizePatch struct {
+	Patch  string       `json:"patch"`
+	Target *PatchTarget `json:"target,omitempty"`
+}
+
+// DeepCopyInto copies all properties into another KustomizePatch.
+func (in *KustomizePatch) DeepCopyInto(out *KustomizePatch) {
+	*out = *in
+	if in.Target != nil {
+		targetCopy := *in.Target
+		out.Target = &targetCopy
+	}
+}
+
+// PatchTarget selects the resources a KustomizePatch applies to.
+type PatchTarget struct {
+	Kind string `json:"kind,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
 // FluxInstanceStatus keeps parity with the real CRD so the scheme matches expectations.
 type FluxInstanceStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
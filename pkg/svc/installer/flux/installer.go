@@ -12,6 +12,7 @@ import (
 type FluxInstaller struct {
 	timeout time.Duration
 	client  helm.Interface
+	version string
 }
 
 // NewFluxInstaller creates a new Flux installer instance.
@@ -35,6 +36,12 @@ func (b *FluxInstaller) Install(ctx context.Context) error {
 	return nil
 }
 
+// SetVersion pins the flux-operator OCI chart. When empty, the chart locked for the project is
+// pulled, or the latest tag of the OCI repository.
+func (b *FluxInstaller) SetVersion(version string) {
+	b.version = version
+}
+
 // Uninstall removes the Helm release for the Flux Operator.
 func (b *FluxInstaller) Uninstall(ctx context.Context) error {
 	err := b.client.UninstallRelease(ctx, "flux-operator", "flux-system")
@@ -55,6 +62,7 @@ func (b *FluxInstaller) helmInstallOrUpgradeFluxOperator(ctx context.Context) er
 		CreateNamespace: true,
 		Atomic:          true,
 		UpgradeCRDs:     true,
+		Version:         b.version,
 		Timeout:         b.timeout,
 		// Silence Helm stderr because the Flux operator CRDs emit harmless
 		// "unrecognized format" warnings that confuse users if printed.
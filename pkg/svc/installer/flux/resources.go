@@ -11,13 +11,18 @@ import (
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 	fluxclient "github.com/devantler-tech/ksail-go/pkg/client/flux"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
 	registry "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -61,6 +66,14 @@ var (
 			return nil, fmt.Errorf("failed to add flux source scheme: %w", err)
 		}
 
+		if err := kustomizev1.AddToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("failed to add flux kustomize scheme: %w", err)
+		}
+
+		if err := corev1.AddToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("failed to add core scheme: %w", err)
+		}
+
 		fluxClient, err := client.New(restConfig, client.Options{Scheme: scheme})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create flux resource client: %w", err)
@@ -76,7 +89,9 @@ var (
 )
 
 // EnsureDefaultResources configures a default FluxInstance so the operator can
-// bootstrap controllers and sync from the local OCI registry.
+// bootstrap controllers and sync from the local OCI registry. When local age keys
+// exist, they are stored in the sops-age Secret and the sync decrypts SOPS-encrypted
+// manifests with them.
 //
 //nolint:contextcheck // context passed from caller and used in nested functions
 func EnsureDefaultResources(
@@ -112,6 +127,15 @@ func EnsureDefaultResources(
 		return err
 	}
 
+	decrypt, err := ensureDecryption(ctx, fluxClient)
+	if err != nil {
+		return err
+	}
+
+	if decrypt {
+		fluxInstance.Spec.Kustomize = decryptionKustomize()
+	}
+
 	err = upsertFluxResource(ctx, fluxClient, fluxInstance)
 	if err != nil {
 		return err
@@ -122,13 +146,79 @@ func EnsureDefaultResources(
 		return err
 	}
 
-	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled {
+	if clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled && !clusterCfg.Spec.Options.Gitea.Enabled {
 		return ensureLocalOCIRepositoryInsecure(ctx, fluxClient)
 	}
 
 	return nil
 }
 
+// RemoveDefaultResources deletes the FluxInstance EnsureDefaultResources configures and waits
+// until the operator has removed the Flux controllers it installed. A cluster without the
+// FluxInstance, or without its CRD, has nothing to remove.
+//
+// The operator must still run while the FluxInstance is deleted, so it is uninstalled afterwards.
+func RemoveDefaultResources(ctx context.Context, kubeconfig string, timeout time.Duration) error {
+	restConfig, err := loadRESTConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	fluxClient, err := newFluxResourcesClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	fluxInstance := &FluxInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fluxInstanceDefaultName,
+			Namespace: fluxclient.DefaultNamespace,
+		},
+	}
+	key := client.ObjectKeyFromObject(fluxInstance)
+
+	err = fluxClient.Delete(ctx, fluxInstance)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete FluxInstance %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, fluxAPIAvailabilityPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			// Other errors are retried until the timeout
+			getErr := fluxClient.Get(ctx, key, &FluxInstance{})
+
+			return apierrors.IsNotFound(getErr), nil
+		})
+	if err != nil {
+		return fmt.Errorf("timed out waiting for FluxInstance %s/%s to be removed: %w", key.Namespace, key.Name, err)
+	}
+
+	return nil
+}
+
+// DefaultResources returns the FluxInstance EnsureDefaultResources configures, for dry runs.
+func DefaultResources(clusterCfg *v1alpha1.Cluster) ([]runtime.Object, error) {
+	if clusterCfg == nil {
+		return nil, errInvalidClusterConfig
+	}
+
+	fluxInstance, err := buildFluxInstance(clusterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fluxInstance.TypeMeta = metav1.TypeMeta{
+		APIVersion: fluxInstanceGroupVersion.String(),
+		Kind:       fluxInstanceKind,
+	}
+
+	return []runtime.Object{fluxInstance}, nil
+}
+
 //nolint:unparam // error return kept for consistency with resource building patterns
 func buildFluxInstance(clusterCfg *v1alpha1.Cluster) (*FluxInstance, error) {
 	interval := clusterCfg.Spec.Options.Flux.Interval.Duration
@@ -163,6 +253,22 @@ func buildFluxInstance(clusterCfg *v1alpha1.Cluster) (*FluxInstance, error) {
 	normalizedPath := normalizeFluxPath()
 	intervalPtr := &metav1.Duration{Duration: interval}
 
+	sync := &Sync{
+		Kind:     fluxOCIRepositoryKind,
+		URL:      repoURL,
+		Ref:      defaultArtifactTag,
+		Path:     normalizedPath,
+		Provider: "generic",
+		Interval: intervalPtr,
+	}
+
+	// With the in-cluster Gitea server enabled, the project is pushed to Git instead
+	if clusterCfg.Spec.Options.Gitea.Enabled {
+		sync.Kind = fluxGitRepositoryKind
+		sync.URL = gitea.InClusterRepositoryURL(gitea.RepositoryName(sourceDir))
+		sync.Ref = "refs/heads/" + gitea.DefaultBranch
+	}
+
 	return &FluxInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fluxInstanceDefaultName,
@@ -174,14 +280,7 @@ func buildFluxInstance(clusterCfg *v1alpha1.Cluster) (*FluxInstance, error) {
 				Registry: fluxDistributionRegistry,
 				Artifact: fluxDistributionArtifact,
 			},
-			Sync: &Sync{
-				Kind:     fluxOCIRepositoryKind,
-				URL:      repoURL,
-				Ref:      defaultArtifactTag,
-				Path:     normalizedPath,
-				Provider: "generic",
-				Interval: intervalPtr,
-			},
+			Sync: sync,
 		},
 	}, nil
 }
@@ -0,0 +1,226 @@
+package fluxinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	fluxclient "github.com/devantler-tech/ksail-go/pkg/client/flux"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WorkloadSyncResources returns the OCIRepository and Kustomization through which Flux syncs
+// the workload artifact pushed to the local registry. They share their names and source URL
+// with the sync the default FluxInstance configures, so both describe the same objects.
+func WorkloadSyncResources(clusterCfg *v1alpha1.Cluster) ([]runtime.Object, error) {
+	if clusterCfg == nil {
+		return nil, errInvalidClusterConfig
+	}
+
+	repository, kustomization, err := buildWorkloadSync(clusterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return []runtime.Object{repository, kustomization}, nil
+}
+
+// WorkloadRepositoryName returns the registry repository the workload artifact of sourceDir is
+// pushed to, matching the URL Flux syncs from.
+func WorkloadRepositoryName(sourceDir string) string {
+	return sanitizeFluxName(sourceDir, defaultProjectName)
+}
+
+// ReconcileWorkloadArtifact creates or patches the resources from WorkloadSyncResources and
+// requests their immediate reconciliation, so Flux applies a freshly pushed artifact without
+// waiting for the next sync interval. Like EnsureDefaultResources, it enables SOPS decryption
+// of the Kustomization when local age keys exist. WithCosignVerification additionally makes
+// Flux verify the signature of the artifact before applying it.
+//
+//nolint:contextcheck // context passed from caller and used in nested functions
+func ReconcileWorkloadArtifact(
+	ctx context.Context,
+	kubeconfig string,
+	clusterCfg *v1alpha1.Cluster,
+	opts ...ReconcileOption,
+) error {
+	if clusterCfg == nil {
+		return errInvalidClusterConfig
+	}
+
+	options := reconcileOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	restConfig, err := loadRESTConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	repository, kustomization, err := buildWorkloadSync(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	fluxClient, err := newFluxResourcesClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	decrypt, err := ensureDecryption(ctx, fluxClient)
+	if err != nil {
+		return err
+	}
+
+	if decrypt {
+		kustomization.Spec.Decryption = WorkloadDecryption()
+	}
+
+	if len(options.verificationKey) > 0 {
+		err = applySecret(ctx, fluxClient, NewVerificationSecret(options.verificationKey))
+		if err != nil {
+			return err
+		}
+
+		repository.Spec.Verify = WorkloadVerification()
+	}
+
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+
+	err = upsertWorkloadSyncResource(ctx, fluxClient, repository, &sourcev1.OCIRepository{}, requestedAt)
+	if err != nil {
+		return err
+	}
+
+	return upsertWorkloadSyncResource(
+		ctx,
+		fluxClient,
+		kustomization,
+		&kustomizev1.Kustomization{},
+		requestedAt,
+	)
+}
+
+//nolint:unparam // error return kept for consistency with resource building patterns
+func buildWorkloadSync(
+	clusterCfg *v1alpha1.Cluster,
+) (*sourcev1.OCIRepository, *kustomizev1.Kustomization, error) {
+	fluxInstance, err := buildFluxInstance(clusterCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sync := fluxInstance.Spec.Sync
+	objectMeta := metav1.ObjectMeta{
+		Name:      defaultOCIRepositoryName,
+		Namespace: fluxclient.DefaultNamespace,
+	}
+
+	repository := &sourcev1.OCIRepository{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sourcev1.GroupVersion.String(),
+			Kind:       sourcev1.OCIRepositoryKind,
+		},
+		ObjectMeta: objectMeta,
+		Spec: sourcev1.OCIRepositorySpec{
+			URL:       sync.URL,
+			Reference: &sourcev1.OCIRepositoryRef{Tag: sync.Ref},
+			Provider:  sync.Provider,
+			Insecure:  clusterCfg.Spec.LocalRegistry == v1alpha1.LocalRegistryEnabled,
+			Interval:  *sync.Interval,
+		},
+	}
+
+	kustomization := &kustomizev1.Kustomization{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kustomizev1.GroupVersion.String(),
+			Kind:       kustomizev1.KustomizationKind,
+		},
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Spec: kustomizev1.KustomizationSpec{
+			Interval: *sync.Interval,
+			Path:     sync.Path,
+			Prune:    true,
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.OCIRepositoryKind,
+				Name: repository.Name,
+			},
+		},
+	}
+
+	return repository, kustomization, nil
+}
+
+// upsertWorkloadSyncResource creates desired, or copies its spec onto the existing object,
+// and stamps the reconcile request annotation Flux controllers react to.
+func upsertWorkloadSyncResource(
+	ctx context.Context,
+	fluxClient client.Client,
+	desired, existing client.Object,
+	requestedAt string,
+) error {
+	key := client.ObjectKeyFromObject(desired)
+	kind := desired.GetObjectKind().GroupVersionKind().Kind
+
+	err := fluxClient.Get(ctx, key, existing)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s %s/%s: %w", kind, key.Namespace, key.Name, err)
+		}
+
+		setReconcileRequest(desired, requestedAt)
+
+		err = fluxClient.Create(ctx, desired)
+		if err != nil {
+			return fmt.Errorf("create %s %s/%s: %w", kind, key.Namespace, key.Name, err)
+		}
+
+		return nil
+	}
+
+	//nolint:forcetypeassert // DeepCopyObject preserves the concrete Flux type
+	patch := client.MergeFrom(existing.DeepCopyObject().(client.Object))
+
+	switch current := existing.(type) {
+	case *sourcev1.OCIRepository:
+		//nolint:forcetypeassert // callers pass desired and existing of the same type
+		current.Spec = desired.(*sourcev1.OCIRepository).Spec
+	case *kustomizev1.Kustomization:
+		//nolint:forcetypeassert // callers pass desired and existing of the same type
+		current.Spec = desired.(*kustomizev1.Kustomization).Spec
+	default:
+		//nolint:err113 // type information is dynamic and necessary for debugging
+		return fmt.Errorf("unsupported Flux resource type %T", existing)
+	}
+
+	setReconcileRequest(existing, requestedAt)
+
+	err = fluxClient.Patch(ctx, existing, patch)
+	if err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", kind, key.Namespace, key.Name, err)
+	}
+
+	return nil
+}
+
+func setReconcileRequest(obj client.Object, requestedAt string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[meta.ReconcileRequestAnnotation] = requestedAt
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,47 @@
+package fluxinstaller_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSyncResourcesTargetPushedArtifact(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.SourceDirectory = "Deploy/K8s"
+	clusterCfg.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+
+	objects, err := fluxinstaller.WorkloadSyncResources(clusterCfg)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	repository, ok := objects[0].(*sourcev1.OCIRepository)
+	require.True(t, ok)
+	assert.Equal(t, "flux-system", repository.Name)
+	assert.Equal(t, "flux-system", repository.Namespace)
+	assert.Equal(t, "oci://local-registry:5000/deploy-k8s", repository.Spec.URL)
+	assert.Equal(t, "latest", repository.Spec.Reference.Tag)
+	assert.True(t, repository.Spec.Insecure)
+
+	kustomization, ok := objects[1].(*kustomizev1.Kustomization)
+	require.True(t, ok)
+	assert.Equal(t, repository.Name, kustomization.Name)
+	assert.Equal(t, sourcev1.OCIRepositoryKind, kustomization.Spec.SourceRef.Kind)
+	assert.Equal(t, repository.Name, kustomization.Spec.SourceRef.Name)
+	assert.True(t, kustomization.Spec.Prune)
+}
+
+func TestWorkloadRepositoryNameSanitizesSourceDirectory(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "k8s", fluxinstaller.WorkloadRepositoryName("k8s"))
+	assert.Equal(t, "deploy-k8s", fluxinstaller.WorkloadRepositoryName("./Deploy/K8s"))
+	assert.Equal(t, "ksail-workloads", fluxinstaller.WorkloadRepositoryName("  "))
+}
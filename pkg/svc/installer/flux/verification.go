@@ -0,0 +1,57 @@
+package fluxinstaller
+
+import (
+	fluxclient "github.com/devantler-tech/ksail-go/pkg/client/flux"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VerificationSecretName is the Secret with the cosign public key source-controller
+	// verifies the workload artifact with.
+	VerificationSecretName = "cosign-pub"
+	// VerificationSecretKey is the key of the public key in the verification Secret. Flux
+	// reads every key ending in .pub.
+	VerificationSecretKey = "cosign.pub"
+
+	verificationProvider = "cosign"
+)
+
+// ReconcileOption configures ReconcileWorkloadArtifact.
+type ReconcileOption func(*reconcileOptions)
+
+type reconcileOptions struct {
+	verificationKey []byte
+}
+
+// WithCosignVerification makes source-controller verify the cosign signature of the workload
+// artifact with the PEM encoded publicKey before Flux applies it.
+func WithCosignVerification(publicKey []byte) ReconcileOption {
+	return func(opts *reconcileOptions) {
+		opts.verificationKey = publicKey
+	}
+}
+
+// NewVerificationSecret returns the Secret that hands publicKey to source-controller.
+func NewVerificationSecret(publicKey []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      VerificationSecretName,
+			Namespace: fluxclient.DefaultNamespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{VerificationSecretKey: string(publicKey)},
+	}
+}
+
+// WorkloadVerification returns the signature verification settings of the OCIRepository that
+// syncs the workloads.
+func WorkloadVerification() *sourcev1.OCIRepositoryVerification {
+	return &sourcev1.OCIRepositoryVerification{
+		Provider:  verificationProvider,
+		SecretRef: &meta.LocalObjectReference{Name: VerificationSecretName},
+	}
+}
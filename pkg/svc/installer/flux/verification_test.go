@@ -0,0 +1,31 @@
+package fluxinstaller_test
+
+import (
+	"testing"
+
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVerificationSecretHoldsPublicKey(t *testing.T) {
+	t.Parallel()
+
+	publicKey := []byte("-----BEGIN PUBLIC KEY-----\nTEST\n-----END PUBLIC KEY-----\n")
+
+	secret := fluxinstaller.NewVerificationSecret(publicKey)
+
+	assert.Equal(t, fluxinstaller.VerificationSecretName, secret.Name)
+	assert.Equal(t, "flux-system", secret.Namespace)
+	assert.Equal(t, string(publicKey), secret.StringData[fluxinstaller.VerificationSecretKey])
+}
+
+func TestWorkloadVerificationReferencesSecret(t *testing.T) {
+	t.Parallel()
+
+	verification := fluxinstaller.WorkloadVerification()
+
+	assert.Equal(t, "cosign", verification.Provider)
+	require.NotNil(t, verification.SecretRef)
+	assert.Equal(t, fluxinstaller.VerificationSecretName, verification.SecretRef.Name)
+}
@@ -0,0 +1,270 @@
+package fluxinstaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	fluxclient "github.com/devantler-tech/ksail-go/pkg/client/flux"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrWorkloadSyncFailed is returned when Flux reports that it cannot sync the workloads.
+var ErrWorkloadSyncFailed = errors.New("workload sync failed")
+
+// RequestWorkloadReconcile asks Flux to reconcile the source and Kustomization that sync the
+// workloads now, e.g. after a push to Gitea, instead of at the next sync interval.
+//
+//nolint:contextcheck // context passed from caller and used in nested functions
+func RequestWorkloadReconcile(ctx context.Context, kubeconfig string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	restConfig, err := loadRESTConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	fluxClient, err := newFluxResourcesClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	kustomization, source, err := getWorkloadSync(ctx, fluxClient)
+	if err != nil {
+		return err
+	}
+
+	requestedAt := time.Now().Format(time.RFC3339Nano)
+
+	for _, obj := range []client.Object{source, kustomization} {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object)) //nolint:forcetypeassert // same type
+
+		setReconcileRequest(obj, requestedAt)
+
+		err = fluxClient.Patch(ctx, obj, patch)
+		if err != nil {
+			return fmt.Errorf("failed to request reconcile of %s: %w", objectName(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// WatchWorkloadSync waits until Flux has fetched the latest revision of the workloads and
+// applied it, calling report whenever the status of the source or Kustomization changes.
+// It fails as soon as Flux reports a failure that it does not retry on its own.
+//
+//nolint:contextcheck // context passed from caller and used in nested functions
+func WatchWorkloadSync(
+	ctx context.Context,
+	kubeconfig string,
+	timeout time.Duration,
+	report func(status string),
+) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	restConfig, err := loadRESTConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	fluxClient, err := newFluxResourcesClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	lastStatus := ""
+
+	err = k8s.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		kustomization, source, getErr := getWorkloadSync(ctx, fluxClient)
+		if apierrors.IsNotFound(getErr) {
+			return false, nil
+		}
+
+		if getErr != nil {
+			return false, getErr
+		}
+
+		converged, status, syncErr := WorkloadSyncConverged(source, kustomization)
+		if status != lastStatus && report != nil {
+			report(status)
+		}
+
+		lastStatus = status
+
+		return converged, syncErr
+	})
+	if err != nil {
+		return fmt.Errorf("wait for workloads to sync (%s): %w", lastStatus, err)
+	}
+
+	return nil
+}
+
+// WorkloadSyncConverged reports whether kustomization has applied the latest artifact of
+// source, an *sourcev1.OCIRepository or *sourcev1.GitRepository, and summarizes their status.
+// Pending reconcile requests must be handled by both first, so a status from before a push
+// does not count as converged.
+func WorkloadSyncConverged(
+	source client.Object,
+	kustomization *kustomizev1.Kustomization,
+) (bool, string, error) {
+	var (
+		conditions         []metav1.Condition
+		lastHandled        string
+		observedGeneration int64
+		artifact           *meta.Artifact
+	)
+
+	switch typed := source.(type) {
+	case *sourcev1.OCIRepository:
+		conditions = typed.Status.Conditions
+		lastHandled = typed.Status.GetLastHandledReconcileRequest()
+		observedGeneration = typed.Status.ObservedGeneration
+		artifact = typed.Status.Artifact
+	case *sourcev1.GitRepository:
+		conditions = typed.Status.Conditions
+		lastHandled = typed.Status.GetLastHandledReconcileRequest()
+		observedGeneration = typed.Status.ObservedGeneration
+		artifact = typed.Status.Artifact
+	default:
+		//nolint:err113 // type information is dynamic and necessary for debugging
+		return false, "", fmt.Errorf("unsupported Flux source type %T", source)
+	}
+
+	ready, status, err := objectReady(source, conditions, lastHandled, observedGeneration)
+	if !ready || err != nil {
+		return false, status, err
+	}
+
+	ready, status, err = objectReady(
+		kustomization,
+		kustomization.Status.Conditions,
+		kustomization.Status.GetLastHandledReconcileRequest(),
+		kustomization.Status.ObservedGeneration,
+	)
+	if !ready || err != nil {
+		return false, status, err
+	}
+
+	if artifact == nil || kustomization.Status.LastAppliedRevision != artifact.Revision {
+		return false, objectName(kustomization) + ": applying the latest revision", nil
+	}
+
+	return true, objectName(kustomization) + ": applied revision " + artifact.Revision, nil
+}
+
+// --- internals ---
+
+// getWorkloadSync returns the Kustomization the default FluxInstance creates and the source
+// it references.
+func getWorkloadSync(
+	ctx context.Context,
+	fluxClient client.Client,
+) (*kustomizev1.Kustomization, client.Object, error) {
+	kustomization := &kustomizev1.Kustomization{}
+	key := client.ObjectKey{Name: defaultOCIRepositoryName, Namespace: fluxclient.DefaultNamespace}
+
+	err := fluxClient.Get(ctx, key, kustomization)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get Kustomization %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	var source client.Object
+
+	switch kustomization.Spec.SourceRef.Kind {
+	case sourcev1.GitRepositoryKind:
+		source = &sourcev1.GitRepository{}
+	default:
+		source = &sourcev1.OCIRepository{}
+	}
+
+	sourceKey := client.ObjectKey{Name: kustomization.Spec.SourceRef.Name, Namespace: key.Namespace}
+	if kustomization.Spec.SourceRef.Namespace != "" {
+		sourceKey.Namespace = kustomization.Spec.SourceRef.Namespace
+	}
+
+	err = fluxClient.Get(ctx, sourceKey, source)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to get %s %s/%s: %w",
+			kustomization.Spec.SourceRef.Kind,
+			sourceKey.Namespace,
+			sourceKey.Name,
+			err,
+		)
+	}
+
+	return kustomization, source, nil
+}
+
+// objectReady applies the status conventions of Flux objects: a pending reconcile request or
+// generation, or the Reconciling condition, mean the object is still in progress, Stalled or
+// Ready=False without Reconciling mean it failed.
+func objectReady(
+	obj client.Object,
+	conditions []metav1.Condition,
+	lastHandled string,
+	observedGeneration int64,
+) (bool, string, error) {
+	name := objectName(obj)
+
+	requested := obj.GetAnnotations()[meta.ReconcileRequestAnnotation]
+	if requested != "" && requested != lastHandled {
+		return false, name + ": waiting for reconciliation", nil
+	}
+
+	if observedGeneration < obj.GetGeneration() {
+		return false, name + ": waiting for reconciliation", nil
+	}
+
+	readyCondition := apimeta.FindStatusCondition(conditions, meta.ReadyCondition)
+	if readyCondition == nil {
+		return false, name + ": waiting for status", nil
+	}
+
+	status := fmt.Sprintf("%s: %s: %s", name, readyCondition.Reason, readyCondition.Message)
+
+	if apimeta.IsStatusConditionTrue(conditions, meta.StalledCondition) {
+		return false, status, fmt.Errorf("%w: %s", ErrWorkloadSyncFailed, status)
+	}
+
+	switch {
+	case apimeta.IsStatusConditionTrue(conditions, meta.ReconcilingCondition):
+		return false, status, nil
+	case readyCondition.Status == metav1.ConditionTrue:
+		return true, status, nil
+	case readyCondition.Status == metav1.ConditionFalse:
+		return false, status, fmt.Errorf("%w: %s", ErrWorkloadSyncFailed, status)
+	default:
+		return false, status, nil
+	}
+}
+
+func objectName(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		switch obj.(type) {
+		case *sourcev1.OCIRepository:
+			kind = sourcev1.OCIRepositoryKind
+		case *sourcev1.GitRepository:
+			kind = sourcev1.GitRepositoryKind
+		case *kustomizev1.Kustomization:
+			kind = kustomizev1.KustomizationKind
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", kind, obj.GetName())
+}
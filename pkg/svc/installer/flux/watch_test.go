@@ -0,0 +1,109 @@
+package fluxinstaller_test
+
+import (
+	"testing"
+
+	fluxinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/flux"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testRevision    = "latest@sha256:abc"
+	testRequestedAt = "2026-10-17T10:00:00Z"
+)
+
+func TestWorkloadSyncConverged(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		source        *sourcev1.OCIRepository
+		kustomization *kustomizev1.Kustomization
+		converged     bool
+		wantErr       bool
+		status        string
+	}{
+		{
+			name:          "applied latest revision",
+			source:        newSource(metav1.ConditionTrue, ""),
+			kustomization: newKustomization(metav1.ConditionTrue, testRevision),
+			converged:     true,
+			status:        "Kustomization/flux-system: applied revision " + testRevision,
+		},
+		{
+			name:          "source has not handled the reconcile request",
+			source:        newSource(metav1.ConditionTrue, testRequestedAt),
+			kustomization: newKustomization(metav1.ConditionTrue, testRevision),
+			status:        "OCIRepository/flux-system: waiting for reconciliation",
+		},
+		{
+			name:          "previous revision still applied",
+			source:        newSource(metav1.ConditionTrue, ""),
+			kustomization: newKustomization(metav1.ConditionTrue, "latest@sha256:old"),
+			status:        "Kustomization/flux-system: applying the latest revision",
+		},
+		{
+			name:          "kustomization failed",
+			source:        newSource(metav1.ConditionTrue, ""),
+			kustomization: newKustomization(metav1.ConditionFalse, testRevision),
+			wantErr:       true,
+			status:        "Kustomization/flux-system: Failed: sync message",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			converged, status, err := fluxinstaller.WorkloadSyncConverged(test.source, test.kustomization)
+			if test.wantErr {
+				require.ErrorIs(t, err, fluxinstaller.ErrWorkloadSyncFailed)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.converged, converged)
+			assert.Equal(t, test.status, status)
+		})
+	}
+}
+
+func newSource(ready metav1.ConditionStatus, requestedAt string) *sourcev1.OCIRepository {
+	source := &sourcev1.OCIRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system", Generation: 1},
+	}
+	source.Status.ObservedGeneration = 1
+	source.Status.Artifact = &meta.Artifact{Revision: testRevision}
+	source.Status.Conditions = []metav1.Condition{newReadyCondition(ready)}
+
+	if requestedAt != "" {
+		source.Annotations = map[string]string{meta.ReconcileRequestAnnotation: requestedAt}
+	}
+
+	return source
+}
+
+func newKustomization(ready metav1.ConditionStatus, revision string) *kustomizev1.Kustomization {
+	kustomization := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "flux-system", Namespace: "flux-system", Generation: 1},
+	}
+	kustomization.Status.ObservedGeneration = 1
+	kustomization.Status.LastAppliedRevision = revision
+	kustomization.Status.Conditions = []metav1.Condition{newReadyCondition(ready)}
+
+	return kustomization
+}
+
+func newReadyCondition(status metav1.ConditionStatus) metav1.Condition {
+	reason := "Succeeded"
+	if status == metav1.ConditionFalse {
+		reason = "Failed"
+	}
+
+	return metav1.Condition{Type: meta.ReadyCondition, Status: status, Reason: reason, Message: "sync message"}
+}
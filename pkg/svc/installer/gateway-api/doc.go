@@ -0,0 +1,6 @@
+// Package gatewayapiinstaller provides an installer for the Gateway API CRDs.
+//
+// The installer applies the CRD bundle of a Gateway API release channel (standard or
+// experimental) and waits until the API server serves them, so CNIs and ingress controllers
+// that implement the Gateway API, such as Cilium and Traefik, find them when they start.
+package gatewayapiinstaller
@@ -0,0 +1,290 @@
+package gatewayapiinstaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// DefaultVersion is the Gateway API release installed when no version is pinned.
+	DefaultVersion = "v1.3.0"
+	// ChannelStandard is the release channel of the GA and beta resources.
+	ChannelStandard = "standard"
+	// ChannelExperimental is the release channel that adds the experimental resources and fields.
+	ChannelExperimental = "experimental"
+
+	crdKind      = "CustomResourceDefinition"
+	pollInterval = 500 * time.Millisecond
+	decodeBuffer = 4096
+)
+
+// Groups of the Gateway API CRDs, used to find them on uninstall.
+const (
+	group             = "gateway.networking.k8s.io"
+	experimentalGroup = "gateway.networking.x-k8s.io"
+)
+
+var (
+	// ErrManifestUnavailable is returned when the CRD bundle cannot be downloaded.
+	ErrManifestUnavailable = errors.New("gateway api manifest unavailable")
+	// ErrNoCRDs is returned when the bundle contains no CRDs.
+	ErrNoCRDs = errors.New("gateway api manifest contains no CRDs")
+)
+
+// GatewayAPIInstaller implements the installer.Installer interface for the Gateway API CRDs.
+type GatewayAPIInstaller struct {
+	timeout    time.Duration
+	client     apiextensionsv1client.CustomResourceDefinitionInterface
+	manifest   string
+	httpClient *http.Client
+}
+
+// NewGatewayAPIInstaller creates a new Gateway API installer instance that applies the CRDs of
+// manifest, a URL such as the one ManifestURL returns, or the path of a downloaded bundle.
+func NewGatewayAPIInstaller(
+	client apiextensionsv1client.CustomResourceDefinitionInterface,
+	manifest string,
+	timeout time.Duration,
+) *GatewayAPIInstaller {
+	return &GatewayAPIInstaller{
+		client:     client,
+		manifest:   manifest,
+		timeout:    timeout,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// ManifestURL returns the URL of the CRD bundle of a Gateway API release and channel. An empty
+// version selects DefaultVersion.
+func ManifestURL(version, channel string) string {
+	if version == "" {
+		version = DefaultVersion
+	}
+
+	return fmt.Sprintf(
+		"https://github.com/kubernetes-sigs/gateway-api/releases/download/%s/%s-install.yaml",
+		version,
+		channel,
+	)
+}
+
+// Install creates or updates the Gateway API CRDs and waits until they are established.
+// Other resources of the bundle, such as its admission policies, are left out.
+func (g *GatewayAPIInstaller) Install(ctx context.Context) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	manifest, err := g.readManifest(timeoutCtx)
+	if err != nil {
+		return fmt.Errorf("failed to read gateway api manifest: %w", err)
+	}
+
+	crds, err := decodeCRDs(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to decode gateway api manifest: %w", err)
+	}
+
+	for _, crd := range crds {
+		err = g.apply(timeoutCtx, crd)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, crd := range crds {
+		err = g.waitForEstablished(timeoutCtx, crd.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderManifests returns the Gateway API CRDs Install would apply.
+func (g *GatewayAPIInstaller) RenderManifests(ctx context.Context) ([]runtime.Object, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	manifest, err := g.readManifest(timeoutCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gateway api manifest: %w", err)
+	}
+
+	crds, err := decodeCRDs(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode gateway api manifest: %w", err)
+	}
+
+	objects := make([]runtime.Object, 0, len(crds))
+	for _, crd := range crds {
+		objects = append(objects, crd)
+	}
+
+	return objects, nil
+}
+
+// Uninstall deletes the CRDs of the Gateway API groups, and with them all Gateway API resources.
+func (g *GatewayAPIInstaller) Uninstall(ctx context.Context) error {
+	crds, err := g.client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list CRDs: %w", err)
+	}
+
+	for _, crd := range crds.Items {
+		if crd.Spec.Group != group && crd.Spec.Group != experimentalGroup {
+			continue
+		}
+
+		err = g.client.Delete(ctx, crd.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete CRD %s: %w", crd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// --- internals ---
+
+func (g *GatewayAPIInstaller) readManifest(ctx context.Context) ([]byte, error) {
+	if !offline.IsRemote(g.manifest) {
+		data, err := os.ReadFile(g.manifest)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", g.manifest, err)
+		}
+
+		return data, nil
+	}
+
+	err := offline.GuardURL(ctx, g.manifest, "download the Gateway API CRDs from %s", g.manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.manifest, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", g.manifest, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned %s", ErrManifestUnavailable, g.manifest, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", g.manifest, err)
+	}
+
+	return data, nil
+}
+
+func decodeCRDs(manifest []byte) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), decodeBuffer)
+
+	var crds []*apiextensionsv1.CustomResourceDefinition
+
+	for {
+		var crd apiextensionsv1.CustomResourceDefinition
+
+		err := decoder.Decode(&crd)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("decode document: %w", err)
+		}
+
+		if crd.Kind != crdKind || crd.Name == "" {
+			continue
+		}
+
+		crd.Status = apiextensionsv1.CustomResourceDefinitionStatus{}
+		crds = append(crds, &crd)
+	}
+
+	if len(crds) == 0 {
+		return nil, ErrNoCRDs
+	}
+
+	return crds, nil
+}
+
+func (g *GatewayAPIInstaller) apply(
+	ctx context.Context,
+	crd *apiextensionsv1.CustomResourceDefinition,
+) error {
+	_, err := g.client.Create(ctx, crd, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create CRD %s: %w", crd.Name, err)
+	}
+
+	existing, err := g.client.Get(ctx, crd.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get existing CRD %s: %w", crd.Name, err)
+	}
+
+	crd.ResourceVersion = existing.ResourceVersion
+
+	_, err = g.client.Update(ctx, crd, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update CRD %s: %w", crd.Name, err)
+	}
+
+	return nil
+}
+
+func (g *GatewayAPIInstaller) waitForEstablished(ctx context.Context, name string) error {
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, g.timeout, true,
+		func(ctx context.Context) (bool, error) {
+			crd, err := g.client.Get(ctx, name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+
+			if err != nil {
+				return false, fmt.Errorf("failed to get CRD: %w", err)
+			}
+
+			for _, cond := range crd.Status.Conditions {
+				if cond.Type == apiextensionsv1.Established &&
+					cond.Status == apiextensionsv1.ConditionTrue {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to wait for CRD %s to be established: %w", name, err)
+	}
+
+	return nil
+}
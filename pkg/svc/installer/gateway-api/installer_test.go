@@ -0,0 +1,146 @@
+package gatewayapiinstaller_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gatewayapiinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gateway-api"
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const bundle = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.gateway.networking.k8s.io
+spec:
+  group: gateway.networking.k8s.io
+  names:
+    kind: Gateway
+    plural: gateways
+  scope: Namespaced
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicy
+metadata:
+  name: safe-upgrades.gateway.networking.k8s.io
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: httproutes.gateway.networking.k8s.io
+spec:
+  group: gateway.networking.k8s.io
+  names:
+    kind: HTTPRoute
+    plural: httproutes
+  scope: Namespaced
+`
+
+func TestManifestURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"https://github.com/kubernetes-sigs/gateway-api/releases/download/v1.3.0/standard-install.yaml",
+		gatewayapiinstaller.ManifestURL("", gatewayapiinstaller.ChannelStandard),
+	)
+	assert.Equal(t,
+		"https://github.com/kubernetes-sigs/gateway-api/releases/download/v1.2.1/experimental-install.yaml",
+		gatewayapiinstaller.ManifestURL("v1.2.1", gatewayapiinstaller.ChannelExperimental),
+	)
+}
+
+func TestGatewayAPIInstallerInstallAppliesCRDs(t *testing.T) {
+	t.Parallel()
+
+	clientset := newEstablishingClientset()
+	crds := clientset.ApiextensionsV1().CustomResourceDefinitions()
+
+	installer := gatewayapiinstaller.NewGatewayAPIInstaller(crds, writeBundle(t), 5*time.Second)
+
+	require.NoError(t, installer.Install(context.Background()))
+
+	list, err := crds.List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(list.Items))
+	for _, crd := range list.Items {
+		names = append(names, crd.Name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"gateways.gateway.networking.k8s.io",
+		"httproutes.gateway.networking.k8s.io",
+	}, names)
+
+	// Installing again updates the existing CRDs.
+	require.NoError(t, installer.Install(context.Background()))
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+
+	list, err = crds.List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
+func TestGatewayAPIInstallerInstallOffline(t *testing.T) {
+	t.Parallel()
+
+	installer := gatewayapiinstaller.NewGatewayAPIInstaller(
+		apiextensionsfake.NewSimpleClientset().ApiextensionsV1().CustomResourceDefinitions(),
+		gatewayapiinstaller.ManifestURL("", gatewayapiinstaller.ChannelStandard),
+		time.Second,
+	)
+
+	err := installer.Install(offline.WithOffline(context.Background()))
+
+	require.ErrorIs(t, err, offline.ErrNetworkDisabled)
+}
+
+func writeBundle(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "standard-install.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(bundle), 0o600))
+
+	return path
+}
+
+// newEstablishingClientset returns a fake clientset that marks created CRDs established, like
+// the API server does once it serves them.
+func newEstablishingClientset() *apiextensionsfake.Clientset {
+	clientset := apiextensionsfake.NewSimpleClientset()
+	clientset.PrependReactor(
+		"*",
+		"customresourcedefinitions",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			var object runtime.Object
+
+			switch action := action.(type) {
+			case k8stesting.CreateAction:
+				object = action.GetObject()
+			case k8stesting.UpdateAction:
+				object = action.GetObject()
+			}
+
+			if crd, ok := object.(*apiextensionsv1.CustomResourceDefinition); ok {
+				crd.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{
+					{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+				}
+			}
+
+			return false, nil, nil
+		},
+	)
+
+	return clientset
+}
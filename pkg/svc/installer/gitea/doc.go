@@ -0,0 +1,5 @@
+// Package giteainstaller provides an installer for installing Gitea on a Kubernetes cluster.
+//
+// Gitea serves as a local Git server inside the cluster, so GitOps engines can sync from a
+// Git repository without any external Git hosting.
+package giteainstaller
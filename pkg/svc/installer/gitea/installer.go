@@ -0,0 +1,123 @@
+package giteainstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/svc/gitea"
+)
+
+// giteaValues runs a single Gitea instance backed by SQLite, without the PostgreSQL and
+// Valkey dependencies of the chart, and creates the administrator account KSail pushes as.
+const giteaValues = `gitea:
+  admin:
+    username: ` + gitea.AdminUsername + `
+    password: ` + gitea.AdminPassword + `
+    email: ` + gitea.AdminEmail + `
+  config:
+    database:
+      DB_TYPE: sqlite3
+    session:
+      PROVIDER: memory
+    cache:
+      ADAPTER: memory
+    queue:
+      TYPE: level
+    repository:
+      DEFAULT_BRANCH: ` + gitea.DefaultBranch + `
+postgresql:
+  enabled: false
+postgresql-ha:
+  enabled: false
+valkey:
+  enabled: false
+valkey-cluster:
+  enabled: false
+redis:
+  enabled: false
+redis-cluster:
+  enabled: false
+`
+
+// GiteaInstaller implements the installer.Installer interface for Gitea.
+type GiteaInstaller struct {
+	timeout time.Duration
+	client  helm.Interface
+	version string
+}
+
+// NewGiteaInstaller creates a new Gitea installer instance.
+func NewGiteaInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+) *GiteaInstaller {
+	return &GiteaInstaller{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Install installs or upgrades Gitea via its Helm chart.
+func (g *GiteaInstaller) Install(ctx context.Context) error {
+	err := g.helmInstallOrUpgradeGitea(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Gitea: %w", err)
+	}
+
+	return nil
+}
+
+// SetVersion pins the gitea chart that serves the in-cluster Git repository. Leave it empty for
+// the version locked for the project, or the newest chart.
+func (g *GiteaInstaller) SetVersion(version string) {
+	g.version = version
+}
+
+// Uninstall removes the Helm release for Gitea.
+func (g *GiteaInstaller) Uninstall(ctx context.Context) error {
+	err := g.client.UninstallRelease(ctx, gitea.ReleaseName, gitea.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall gitea release: %w", err)
+	}
+
+	return nil
+}
+
+// --- internals ---
+
+func (g *GiteaInstaller) helmInstallOrUpgradeGitea(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "gitea",
+		URL:  "https://dl.gitea.com/charts/",
+	}
+
+	addRepoErr := g.client.AddRepository(ctx, repoEntry)
+	if addRepoErr != nil {
+		return fmt.Errorf("failed to add gitea repository: %w", addRepoErr)
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName:     gitea.ReleaseName,
+		ChartName:       "gitea/gitea",
+		Namespace:       gitea.Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		WaitForJobs:     true,
+		Version:         g.version,
+		Timeout:         g.timeout,
+		ValuesYaml:      giteaValues,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	_, err := g.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install gitea chart: %w", err)
+	}
+
+	return nil
+}
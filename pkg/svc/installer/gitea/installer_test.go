@@ -0,0 +1,154 @@
+package giteainstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	giteainstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGiteaInstaller(t *testing.T) {
+	t.Parallel()
+
+	timeout := 5 * time.Minute
+
+	client := helm.NewMockInterface(t)
+	installer := giteainstaller.NewGiteaInstaller(client, timeout)
+
+	assert.NotNil(t, installer)
+}
+
+func TestGiteaInstallerInstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newGiteaInstallerWithDefaults(t)
+	expectGiteaInstall(t, client, nil)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestGiteaInstallerInstallRepositoryError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newGiteaInstallerWithDefaults(t)
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "gitea" && entry.URL == "https://dl.gitea.com/charts/"
+		})).
+		Return(assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to add gitea repository")
+}
+
+func TestGiteaInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newGiteaInstallerWithDefaults(t)
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.Anything).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.Anything).
+		Return(nil, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to install gitea chart")
+}
+
+func TestGiteaInstallerUninstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newGiteaInstallerWithDefaults(t)
+	expectGiteaUninstall(t, client, nil)
+
+	err := installer.Uninstall(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestGiteaInstallerUninstallError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newGiteaInstallerWithDefaults(t)
+	expectGiteaUninstall(t, client, assert.AnError)
+
+	err := installer.Uninstall(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to uninstall gitea release")
+}
+
+func newGiteaInstallerWithDefaults(
+	t *testing.T,
+) (*giteainstaller.GiteaInstaller, *helm.MockInterface) {
+	t.Helper()
+	client := helm.NewMockInterface(t)
+	installer := giteainstaller.NewGiteaInstaller(
+		client,
+		5*time.Second,
+	)
+
+	return installer, client
+}
+
+func expectGiteaInstall(t *testing.T, client *helm.MockInterface, installErr error) {
+	t.Helper()
+
+	client.EXPECT().
+		AddRepository(
+			mock.Anything,
+			mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+				assert.Equal(t, "gitea", entry.Name)
+				assert.Equal(t, "https://dl.gitea.com/charts/", entry.URL)
+
+				return true
+			}),
+		).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, "gitea", spec.ReleaseName)
+				assert.Equal(t, "gitea/gitea", spec.ChartName)
+				assert.Equal(t, "gitea", spec.Namespace)
+				assert.True(t, spec.CreateNamespace)
+				assert.True(t, spec.Atomic)
+				assert.True(t, spec.Wait)
+				assert.True(t, spec.WaitForJobs)
+				assert.Contains(t, spec.ValuesYaml, "DB_TYPE: sqlite3")
+				assert.Contains(t, spec.ValuesYaml, "username: ksail")
+
+				return true
+			}),
+		).
+		Return(&helm.ReleaseInfo{}, installErr)
+}
+
+func expectGiteaUninstall(
+	t *testing.T,
+	client *helm.MockInterface,
+	uninstallErr error,
+) {
+	t.Helper()
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, "gitea", "gitea").
+		Return(uninstallErr)
+}
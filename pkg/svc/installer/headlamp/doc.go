@@ -0,0 +1,7 @@
+// Package headlampinstaller provides an installer for installing the Headlamp web dashboard
+// on a Kubernetes cluster.
+//
+// Headlamp is served by the headlamp service and logs in with a service account token;
+// LoginToken issues a token of the service account the chart binds to cluster-admin, so
+// `ksail cluster open headlamp` can forward the service and hand out a token.
+package headlampinstaller
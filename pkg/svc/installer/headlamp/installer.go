@@ -0,0 +1,130 @@
+package headlampinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ReleaseName is the Helm release name of Headlamp.
+	ReleaseName = "headlamp"
+	// Namespace is the namespace Headlamp is installed into.
+	Namespace = "headlamp"
+	// ServiceName is the service that serves the Headlamp web UI.
+	ServiceName = "headlamp"
+	// ServicePort is the port of the Headlamp service.
+	ServicePort = 80
+	// ServiceAccountName is the service account the chart binds to cluster-admin.
+	ServiceAccountName = "headlamp"
+
+	tokenLifetime = time.Hour
+)
+
+// HeadlampInstaller implements the installer.Installer interface for Headlamp.
+type HeadlampInstaller struct {
+	timeout time.Duration
+	client  helm.Interface
+	version string
+}
+
+// NewHeadlampInstaller creates a new Headlamp installer instance.
+func NewHeadlampInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+) *HeadlampInstaller {
+	return &HeadlampInstaller{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// SetVersion pins the headlamp chart, and so the dashboard release `ksail cluster open headlamp`
+// serves. Empty means the locked chart, or the newest one.
+func (h *HeadlampInstaller) SetVersion(version string) {
+	h.version = version
+}
+
+// Install installs or upgrades Headlamp via its Helm chart.
+func (h *HeadlampInstaller) Install(ctx context.Context) error {
+	err := h.helmInstallOrUpgradeHeadlamp(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Headlamp: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the Helm release for Headlamp.
+func (h *HeadlampInstaller) Uninstall(ctx context.Context) error {
+	err := h.client.UninstallRelease(ctx, ReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall headlamp release: %w", err)
+	}
+
+	return nil
+}
+
+// LoginToken issues a short-lived token of the Headlamp service account to log in to the
+// dashboard with.
+func LoginToken(ctx context.Context, clientset kubernetes.Interface) (string, error) {
+	expiration := int64(tokenLifetime.Seconds())
+
+	request, err := clientset.CoreV1().ServiceAccounts(Namespace).CreateToken(
+		ctx,
+		ServiceAccountName,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expiration},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return "", fmt.Errorf("create token of service account %s/%s: %w", Namespace, ServiceAccountName, err)
+	}
+
+	return request.Status.Token, nil
+}
+
+// --- internals ---
+
+func (h *HeadlampInstaller) helmInstallOrUpgradeHeadlamp(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "headlamp",
+		URL:  "https://kubernetes-sigs.github.io/headlamp/",
+	}
+
+	addRepoErr := h.client.AddRepository(ctx, repoEntry)
+	if addRepoErr != nil {
+		return fmt.Errorf("failed to add headlamp repository: %w", addRepoErr)
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName:     ReleaseName,
+		ChartName:       "headlamp/headlamp",
+		Namespace:       Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		Version:         h.version,
+		Timeout:         h.timeout,
+		SetValues: map[string]string{
+			"clusterRoleBinding.create": "true",
+			"serviceAccount.name":       ServiceAccountName,
+		},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	_, err := h.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install headlamp chart: %w", err)
+	}
+
+	return nil
+}
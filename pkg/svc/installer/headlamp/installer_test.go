@@ -0,0 +1,85 @@
+package headlampinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	headlampinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/headlamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestHeadlampInstallerInstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "headlamp" && entry.URL == "https://kubernetes-sigs.github.io/headlamp/"
+		})).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			assert.Equal(t, "headlamp/headlamp", spec.ChartName)
+			assert.Equal(t, "headlamp", spec.Namespace)
+			assert.Equal(t, "0.36.0", spec.Version)
+			assert.Equal(t, "true", spec.SetValues["clusterRoleBinding.create"])
+
+			return true
+		})).
+		Return(&helm.ReleaseInfo{}, nil)
+
+	installer := headlampinstaller.NewHeadlampInstaller(client, 5*time.Second)
+	installer.SetVersion("0.36.0")
+
+	require.NoError(t, installer.Install(context.Background()))
+}
+
+func TestHeadlampInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().InstallOrUpgradeChart(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	installer := headlampinstaller.NewHeadlampInstaller(client, 5*time.Second)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install headlamp chart")
+}
+
+func TestLoginToken(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor(
+		"create",
+		"serviceaccounts",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			create, ok := action.(k8stesting.CreateAction)
+			if !ok || create.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+
+			assert.Equal(t, "headlamp", create.GetNamespace())
+
+			return true, &authenticationv1.TokenRequest{
+				Status: authenticationv1.TokenRequestStatus{Token: "token"},
+			}, nil
+		},
+	)
+
+	token, err := headlampinstaller.LoginToken(context.Background(), clientset)
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
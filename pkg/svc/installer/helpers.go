@@ -1,9 +1,13 @@
 package installer
 
 import (
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
 )
 
 const (
@@ -21,3 +25,69 @@ func GetInstallTimeout(clusterCfg *v1alpha1.Cluster) time.Duration {
 
 	return clusterCfg.Spec.Connection.Timeout.Duration
 }
+
+// GetComponentTimeout determines the timeout for installing component, a create step such as
+// cni or flux. A timeout set for the component under spec.options.install wins over one set
+// for all components, which wins over GetInstallTimeout.
+func GetComponentTimeout(clusterCfg *v1alpha1.Cluster, component string) time.Duration {
+	if clusterCfg != nil {
+		timeout := clusterCfg.Spec.Options.Install.Policy(component).Timeout.Duration
+		if timeout > 0 {
+			return timeout
+		}
+	}
+
+	return GetInstallTimeout(clusterCfg)
+}
+
+// GetRetryPolicy determines how operations of component that fail with a transient error are
+// retried, from spec.options.install with k8s.DefaultRetryPolicy filling unset fields.
+func GetRetryPolicy(clusterCfg *v1alpha1.Cluster, component string) k8s.RetryPolicy {
+	policy := k8s.DefaultRetryPolicy()
+	if clusterCfg == nil {
+		return policy
+	}
+
+	configured := clusterCfg.Spec.Options.Install.Policy(component)
+	if configured.Attempts > 0 {
+		policy.Attempts = int(configured.Attempts)
+	}
+
+	if configured.Interval.Duration > 0 {
+		policy.Interval = configured.Interval.Duration
+	}
+
+	return policy
+}
+
+// ReadHelmValues reads the user-supplied Helm values of a chart as YAML documents in the order
+// they are merged over the installer's defaults: the values file, then the inline values.
+func ReadHelmValues(values v1alpha1.HelmValues) ([]string, error) {
+	var documents []string
+
+	if values.ValuesFile != "" {
+		data, err := os.ReadFile(values.ValuesFile) // #nosec G304 -- path is the project's values file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+
+		documents = append(documents, string(data))
+	}
+
+	if values.Values != "" {
+		documents = append(documents, values.Values)
+	}
+
+	return documents, nil
+}
+
+// ChartRepository converts a chart repository of ksail.yaml for the installers.
+func ChartRepository(repository v1alpha1.ChartRepository) helm.RepoConfig {
+	return helm.RepoConfig{
+		URL:                   repository.URL,
+		Username:              repository.Username,
+		Password:              repository.Password,
+		InsecureSkipTLSverify: repository.InsecureSkipTLSVerify,
+		PlainHTTP:             repository.PlainHTTP,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
 	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,3 +62,34 @@ func TestGetInstallTimeout(t *testing.T) {
 		assertTimeoutEquals(t, 2*time.Hour, 2*time.Hour)
 	})
 }
+
+func TestGetComponentTimeoutAndRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Connection: v1alpha1.Connection{Timeout: metav1.Duration{Duration: 3 * time.Minute}},
+			Options: v1alpha1.Options{
+				Install: v1alpha1.OptionsInstall{
+					Attempts: 5,
+					Components: map[string]v1alpha1.InstallPolicy{
+						"cni": {
+							Interval: metav1.Duration{Duration: 10 * time.Second},
+							Timeout:  metav1.Duration{Duration: 15 * time.Minute},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 15*time.Minute, installer.GetComponentTimeout(cluster, "cni"))
+	assert.Equal(t, 3*time.Minute, installer.GetComponentTimeout(cluster, "flux"))
+	assert.Equal(t, installer.DefaultInstallTimeout, installer.GetComponentTimeout(nil, "flux"))
+
+	assert.Equal(t, k8s.RetryPolicy{Attempts: 5, Interval: 10 * time.Second},
+		installer.GetRetryPolicy(cluster, "cni"))
+	assert.Equal(t, k8s.RetryPolicy{Attempts: 5, Interval: k8s.DefaultRetryInterval},
+		installer.GetRetryPolicy(cluster, "flux"))
+	assert.Equal(t, k8s.DefaultRetryPolicy(), installer.GetRetryPolicy(nil, "flux"))
+}
@@ -1,6 +1,10 @@
 package installer
 
-import "context"
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 // Installer defines methods for installing and uninstalling components.
 type Installer interface {
@@ -10,3 +14,11 @@ type Installer interface {
 	// Uninstall uninstalls the component.
 	Uninstall(ctx context.Context) error
 }
+
+// ManifestRenderer is implemented by installers that apply resources besides their Helm
+// charts, for dry runs.
+type ManifestRenderer interface {
+	// RenderManifests installs the component's charts with its Helm client, which writes them
+	// out when it is a helm.Renderer, and returns the other resources instead of applying them.
+	RenderManifests(ctx context.Context) ([]runtime.Object, error)
+}
@@ -0,0 +1,7 @@
+// Package kyvernoinstaller provides an installer for installing the Kyverno policy engine
+// on a Kubernetes cluster.
+//
+// Once Kyverno serves its admission webhooks, the installer can add one of the Pod Security
+// Standards policy sets of the kyverno-policies chart, so workloads are admitted locally the
+// same way as on clusters enforcing those policies.
+package kyvernoinstaller
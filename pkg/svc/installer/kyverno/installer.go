@@ -0,0 +1,165 @@
+package kyvernoinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+)
+
+const (
+	// ReleaseName is the Helm release name of Kyverno.
+	ReleaseName = "kyverno"
+	// PoliciesReleaseName is the Helm release name of the Kyverno policy set.
+	PoliciesReleaseName = "kyverno-policies"
+	// Namespace is the namespace Kyverno and its policy set are installed into.
+	Namespace = "kyverno"
+)
+
+// KyvernoInstaller implements the installer.Installer interface for Kyverno.
+type KyvernoInstaller struct {
+	timeout time.Duration
+	client  helm.Interface
+	version string
+
+	podSecurityStandard string
+	enforce             bool
+}
+
+// NewKyvernoInstaller creates a new Kyverno installer instance.
+func NewKyvernoInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+) *KyvernoInstaller {
+	return &KyvernoInstaller{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// SetVersion pins the kyverno chart. kyverno-policies is released in lockstep with it, so the
+// policy set installs at the same version. An empty version uses the locked chart, or the newest.
+func (k *KyvernoInstaller) SetVersion(version string) {
+	k.version = version
+}
+
+// SetPolicies makes Install add the policies of a Pod Security Standards level, such as
+// "baseline" or "restricted". Violations are reported in policy reports, or rejected by the
+// admission webhook when enforce is set. An empty level installs no policies.
+func (k *KyvernoInstaller) SetPolicies(podSecurityStandard string, enforce bool) {
+	k.podSecurityStandard = podSecurityStandard
+	k.enforce = enforce
+}
+
+// Install installs or upgrades Kyverno via its Helm chart, and its policy set once Kyverno is ready.
+func (k *KyvernoInstaller) Install(ctx context.Context) error {
+	err := k.addRepository(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Kyverno: %w", err)
+	}
+
+	err = k.helmInstallOrUpgradeKyverno(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Kyverno: %w", err)
+	}
+
+	if k.podSecurityStandard == "" {
+		return nil
+	}
+
+	err = k.helmInstallOrUpgradePolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Kyverno policies: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the Helm releases of the policy set and Kyverno.
+func (k *KyvernoInstaller) Uninstall(ctx context.Context) error {
+	err := k.client.UninstallRelease(ctx, PoliciesReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall kyverno-policies release: %w", err)
+	}
+
+	err = k.client.UninstallRelease(ctx, ReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall kyverno release: %w", err)
+	}
+
+	return nil
+}
+
+// --- internals ---
+
+func (k *KyvernoInstaller) addRepository(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "kyverno",
+		URL:  "https://kyverno.github.io/kyverno/",
+	}
+
+	err := k.client.AddRepository(ctx, repoEntry)
+	if err != nil {
+		return fmt.Errorf("failed to add kyverno repository: %w", err)
+	}
+
+	return nil
+}
+
+// helmInstallOrUpgradeKyverno installs Kyverno and waits for its deployments, so its admission
+// webhooks serve before policies are created.
+func (k *KyvernoInstaller) helmInstallOrUpgradeKyverno(ctx context.Context) error {
+	spec := &helm.ChartSpec{
+		ReleaseName:     ReleaseName,
+		ChartName:       "kyverno/kyverno",
+		Namespace:       Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		WaitForJobs:     true,
+		Version:         k.version,
+		Timeout:         k.timeout,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	_, err := k.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install kyverno chart: %w", err)
+	}
+
+	return nil
+}
+
+func (k *KyvernoInstaller) helmInstallOrUpgradePolicies(ctx context.Context) error {
+	validationFailureAction := "Audit"
+	if k.enforce {
+		validationFailureAction = "Enforce"
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName: PoliciesReleaseName,
+		ChartName:   "kyverno/kyverno-policies",
+		Namespace:   Namespace,
+		Atomic:      true,
+		Wait:        true,
+		Version:     k.version,
+		Timeout:     k.timeout,
+		SetValues: map[string]string{
+			"podSecurityStandard":     k.podSecurityStandard,
+			"validationFailureAction": validationFailureAction,
+		},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, k.timeout)
+	defer cancel()
+
+	_, err := k.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install kyverno-policies chart: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package kyvernoinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	kyvernoinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/kyverno"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKyvernoInstallerInstallWithoutPolicies(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newKyvernoInstallerWithDefaults(t)
+	expectKyvernoInstall(t, client)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestKyvernoInstallerInstallWithPolicies(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newKyvernoInstallerWithDefaults(t)
+	installer.SetVersion("3.5.2")
+	installer.SetPolicies("restricted", true)
+
+	var installed []string
+
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, spec *helm.ChartSpec) (*helm.ReleaseInfo, error) {
+			installed = append(installed, spec.ReleaseName)
+
+			assert.Equal(t, "3.5.2", spec.Version)
+			assert.Equal(t, "kyverno", spec.Namespace)
+
+			if spec.ReleaseName == "kyverno-policies" {
+				assert.Equal(t, "kyverno/kyverno-policies", spec.ChartName)
+				assert.Equal(t, map[string]string{
+					"podSecurityStandard":     "restricted",
+					"validationFailureAction": "Enforce",
+				}, spec.SetValues)
+			}
+
+			return &helm.ReleaseInfo{}, nil
+		})
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kyverno", "kyverno-policies"}, installed)
+}
+
+func TestKyvernoInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newKyvernoInstallerWithDefaults(t)
+	installer.SetPolicies("baseline", false)
+
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.Anything).
+		Return(nil, assert.AnError).
+		Once()
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install kyverno chart")
+}
+
+func TestKyvernoInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newKyvernoInstallerWithDefaults(t)
+
+	client.EXPECT().UninstallRelease(mock.Anything, "kyverno-policies", "kyverno").Return(nil)
+	client.EXPECT().UninstallRelease(mock.Anything, "kyverno", "kyverno").Return(nil)
+
+	err := installer.Uninstall(context.Background())
+
+	require.NoError(t, err)
+}
+
+func newKyvernoInstallerWithDefaults(
+	t *testing.T,
+) (*kyvernoinstaller.KyvernoInstaller, *helm.MockInterface) {
+	t.Helper()
+	client := helm.NewMockInterface(t)
+	installer := kyvernoinstaller.NewKyvernoInstaller(client, 5*time.Second)
+
+	return installer, client
+}
+
+func expectKyvernoInstall(t *testing.T, client *helm.MockInterface) {
+	t.Helper()
+
+	client.EXPECT().
+		AddRepository(
+			mock.Anything,
+			mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+				return entry.Name == "kyverno" && entry.URL == "https://kyverno.github.io/kyverno/"
+			}),
+		).
+		Return(nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, "kyverno", spec.ReleaseName)
+				assert.Equal(t, "kyverno/kyverno", spec.ChartName)
+				assert.True(t, spec.CreateNamespace)
+				assert.True(t, spec.Wait)
+
+				return true
+			}),
+		).
+		Return(&helm.ReleaseInfo{}, nil).
+		Once()
+}
@@ -0,0 +1,7 @@
+// Package localstackinstaller provides an installer for the LocalStack AWS cloud emulator.
+//
+// The installer either deploys LocalStack into the cluster with its Helm chart or, when an
+// external endpoint is configured, connects a LocalStack instance that already runs on the
+// cluster's Docker network. In both cases it publishes the endpoint and dummy credentials in
+// a ConfigMap that workloads can load with envFrom.
+package localstackinstaller
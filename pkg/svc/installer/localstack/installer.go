@@ -0,0 +1,213 @@
+package localstackinstaller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ReleaseName is the Helm release name of the in-cluster LocalStack.
+	ReleaseName = "localstack"
+	// Namespace is the namespace the in-cluster LocalStack is installed into.
+	Namespace = "localstack"
+	// ConfigMapName is the ConfigMap holding the endpoint for workloads.
+	ConfigMapName = "localstack-endpoint"
+	// ConfigMapNamespace is the namespace of the endpoint ConfigMap.
+	ConfigMapNamespace = "default"
+	// InClusterEndpoint is the endpoint of the LocalStack deployed by the installer.
+	InClusterEndpoint = "http://localstack.localstack.svc.cluster.local:4566"
+
+	// LocalStack accepts any credentials; these are the values its documentation uses.
+	defaultRegion          = "us-east-1"
+	defaultAccessKeyID     = "test"
+	defaultSecretAccessKey = "test"
+)
+
+// LocalStackInstaller implements the installer.Installer interface for LocalStack.
+type LocalStackInstaller struct {
+	timeout   time.Duration
+	client    helm.Interface
+	clientset kubernetes.Interface
+	endpoint  string
+	version   string
+}
+
+// NewLocalStackInstaller creates a new LocalStack installer instance.
+//
+// An empty endpoint deploys LocalStack into the cluster; any other value is treated as the
+// URL of an external LocalStack reachable from the cluster, and no chart is installed.
+func NewLocalStackInstaller(
+	client helm.Interface,
+	clientset kubernetes.Interface,
+	endpoint string,
+	timeout time.Duration,
+) *LocalStackInstaller {
+	return &LocalStackInstaller{
+		client:    client,
+		clientset: clientset,
+		endpoint:  strings.TrimSpace(endpoint),
+		timeout:   timeout,
+	}
+}
+
+// Endpoint returns the LocalStack endpoint workloads are pointed at.
+func (l *LocalStackInstaller) Endpoint() string {
+	if l.endpoint != "" {
+		return l.endpoint
+	}
+
+	return InClusterEndpoint
+}
+
+// Install deploys LocalStack unless an external endpoint is configured, then publishes the
+// endpoint ConfigMap.
+func (l *LocalStackInstaller) Install(ctx context.Context) error {
+	if l.endpoint == "" {
+		err := l.helmInstallOrUpgradeLocalStack(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to install LocalStack: %w", err)
+		}
+	}
+
+	err := l.upsertEndpointConfigMap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to publish LocalStack endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// RenderManifests installs the chart with the installer's Helm client, unless an external
+// endpoint is configured, and returns the endpoint ConfigMap Install would publish.
+func (l *LocalStackInstaller) RenderManifests(ctx context.Context) ([]runtime.Object, error) {
+	if l.endpoint == "" {
+		err := l.helmInstallOrUpgradeLocalStack(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to install LocalStack: %w", err)
+		}
+	}
+
+	return []runtime.Object{l.endpointConfigMap()}, nil
+}
+
+// SetVersion pins the localstack chart used for an in-cluster LocalStack; an external endpoint
+// ignores it. Empty installs the locked chart, or the latest.
+func (l *LocalStackInstaller) SetVersion(version string) {
+	l.version = version
+}
+
+// Uninstall removes the endpoint ConfigMap and, for an in-cluster LocalStack, its Helm release.
+func (l *LocalStackInstaller) Uninstall(ctx context.Context) error {
+	err := l.clientset.CoreV1().ConfigMaps(ConfigMapNamespace).
+		Delete(ctx, ConfigMapName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete localstack endpoint configmap: %w", err)
+	}
+
+	if l.endpoint != "" {
+		return nil
+	}
+
+	err = l.client.UninstallRelease(ctx, ReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall localstack release: %w", err)
+	}
+
+	return nil
+}
+
+// --- internals ---
+
+func (l *LocalStackInstaller) helmInstallOrUpgradeLocalStack(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "localstack",
+		URL:  "https://localstack.github.io/helm-charts",
+	}
+
+	addRepoErr := l.client.AddRepository(ctx, repoEntry)
+	if addRepoErr != nil {
+		return fmt.Errorf("failed to add localstack repository: %w", addRepoErr)
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName:     ReleaseName,
+		ChartName:       "localstack/localstack",
+		Namespace:       Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		WaitForJobs:     true,
+		Version:         l.version,
+		Timeout:         l.timeout,
+		// ClusterIP keeps the service off the host; workloads use the in-cluster endpoint.
+		SetValues: map[string]string{"service.type": "ClusterIP"},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	_, err := l.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install localstack chart: %w", err)
+	}
+
+	return nil
+}
+
+// endpointConfigMap returns the ConfigMap that points workloads at the endpoint.
+func (l *LocalStackInstaller) endpointConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: ConfigMapNamespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "ksail"},
+		},
+		Data: map[string]string{
+			"AWS_ENDPOINT_URL":      l.Endpoint(),
+			"AWS_REGION":            defaultRegion,
+			"AWS_DEFAULT_REGION":    defaultRegion,
+			"AWS_ACCESS_KEY_ID":     defaultAccessKeyID,
+			"AWS_SECRET_ACCESS_KEY": defaultSecretAccessKey,
+		},
+	}
+}
+
+func (l *LocalStackInstaller) upsertEndpointConfigMap(ctx context.Context) error {
+	desired := l.endpointConfigMap()
+
+	configMaps := l.clientset.CoreV1().ConfigMaps(ConfigMapNamespace)
+
+	existing, err := configMaps.Get(ctx, ConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create configmap %s/%s: %w", ConfigMapNamespace, ConfigMapName, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get configmap %s/%s: %w", ConfigMapNamespace, ConfigMapName, err)
+	}
+
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+
+	_, err = configMaps.Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update configmap %s/%s: %w", ConfigMapNamespace, ConfigMapName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package localstackinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	localstackinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func getEndpointConfigMap(t *testing.T, clientset *fake.Clientset) *corev1.ConfigMap {
+	t.Helper()
+
+	configMap, err := clientset.CoreV1().
+		ConfigMaps(localstackinstaller.ConfigMapNamespace).
+		Get(context.Background(), localstackinstaller.ConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	return configMap
+}
+
+func TestLocalStackInstallerInstallDeploysChartAndPublishesEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	clientset := fake.NewClientset()
+
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "localstack" && entry.URL == "https://localstack.github.io/helm-charts"
+		})).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			return spec.ReleaseName == "localstack" &&
+				spec.ChartName == "localstack/localstack" &&
+				spec.Namespace == "localstack" &&
+				spec.CreateNamespace
+		})).
+		Return(&helm.ReleaseInfo{}, nil)
+
+	installer := localstackinstaller.NewLocalStackInstaller(client, clientset, "", 5*time.Second)
+
+	require.NoError(t, installer.Install(context.Background()))
+
+	configMap := getEndpointConfigMap(t, clientset)
+	assert.Equal(t, localstackinstaller.InClusterEndpoint, configMap.Data["AWS_ENDPOINT_URL"])
+	assert.Equal(t, "us-east-1", configMap.Data["AWS_REGION"])
+	assert.Equal(t, "test", configMap.Data["AWS_ACCESS_KEY_ID"])
+}
+
+func TestLocalStackInstallerInstallConnectsExternalEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	clientset := fake.NewClientset()
+
+	installer := localstackinstaller.NewLocalStackInstaller(
+		client,
+		clientset,
+		"http://localstack:4566",
+		5*time.Second,
+	)
+
+	require.NoError(t, installer.Install(context.Background()))
+	require.NoError(t, installer.Install(context.Background()), "install is idempotent")
+
+	configMap := getEndpointConfigMap(t, clientset)
+	assert.Equal(t, "http://localstack:4566", configMap.Data["AWS_ENDPOINT_URL"])
+}
+
+func TestLocalStackInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().InstallOrUpgradeChart(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	installer := localstackinstaller.NewLocalStackInstaller(client, fake.NewClientset(), "", 5*time.Second)
+
+	err := installer.Install(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install localstack chart")
+}
+
+func TestLocalStackInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	clientset := fake.NewClientset()
+
+	client.EXPECT().UninstallRelease(mock.Anything, "localstack", "localstack").Return(nil)
+
+	installer := localstackinstaller.NewLocalStackInstaller(client, clientset, "", 5*time.Second)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
+
+func TestLocalStackInstallerUninstallExternalKeepsRelease(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	clientset := fake.NewClientset()
+
+	installer := localstackinstaller.NewLocalStackInstaller(client, clientset, "http://localstack:4566", time.Second)
+
+	require.NoError(t, installer.Install(context.Background()))
+	require.NoError(t, installer.Uninstall(context.Background()))
+
+	_, err := clientset.CoreV1().
+		ConfigMaps(localstackinstaller.ConfigMapNamespace).
+		Get(context.Background(), localstackinstaller.ConfigMapName, metav1.GetOptions{})
+	require.Error(t, err)
+}
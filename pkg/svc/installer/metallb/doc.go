@@ -0,0 +1,7 @@
+// Package metallbinstaller provides an installer for MetalLB, which assigns external addresses
+// to Services of type LoadBalancer on clusters without a cloud load balancer, such as Kind.
+//
+// The installer deploys MetalLB with its Helm chart and configures an IPAddressPool and an
+// L2Advertisement for a range of the cluster's Docker network, so the addresses it hands out
+// are reachable from the host.
+package metallbinstaller
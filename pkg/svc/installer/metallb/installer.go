@@ -0,0 +1,253 @@
+package metallbinstaller
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// ReleaseName is the Helm release name of MetalLB.
+	ReleaseName = "metallb"
+	// Namespace is the namespace MetalLB is installed into.
+	Namespace = "metallb-system"
+	// PoolName is the name of the IPAddressPool and L2Advertisement the installer configures.
+	PoolName = "ksail"
+
+	// The address range is taken from the top of the subnet, like the Kind documentation does
+	// (172.18.255.200-172.18.255.250 for 172.18.0.0/16), where Docker rarely assigns addresses
+	// to containers.
+	rangeEndOffset    = 5
+	rangeStartOffset  = 55
+	maxRangePrefixLen = 24
+)
+
+var (
+	// ErrSubnetTooSmall is returned when a subnet is too small to spare addresses for MetalLB.
+	ErrSubnetTooSmall = errors.New("subnet is too small for a LoadBalancer address range")
+	// ErrNotIPv4Subnet is returned for subnets MetalLB cannot take an IPv4 address range from.
+	ErrNotIPv4Subnet = errors.New("not an IPv4 subnet")
+)
+
+//nolint:gochecknoglobals // GroupVersionResources of the MetalLB custom resources.
+var (
+	ipAddressPools   = schema.GroupVersionResource{Group: "metallb.io", Version: "v1beta1", Resource: "ipaddresspools"}
+	l2Advertisements = schema.GroupVersionResource{Group: "metallb.io", Version: "v1beta1", Resource: "l2advertisements"}
+)
+
+// MetalLBInstaller implements the installer.Installer interface for MetalLB.
+type MetalLBInstaller struct {
+	timeout       time.Duration
+	client        helm.Interface
+	dynamicClient dynamic.Interface
+	addresses     string
+	version       string
+}
+
+// NewMetalLBInstaller creates a new MetalLB installer instance that hands out the addresses
+// of the range or CIDR addresses, e.g. the one AddressRange returns for the Docker network.
+func NewMetalLBInstaller(
+	client helm.Interface,
+	dynamicClient dynamic.Interface,
+	addresses string,
+	timeout time.Duration,
+) *MetalLBInstaller {
+	return &MetalLBInstaller{
+		client:        client,
+		dynamicClient: dynamicClient,
+		addresses:     addresses,
+		timeout:       timeout,
+	}
+}
+
+// SetVersion pins the metallb chart release. The address pool Install configures does not depend
+// on it; an empty version uses the locked chart, or the newest one.
+func (m *MetalLBInstaller) SetVersion(version string) {
+	m.version = version
+}
+
+// Install deploys MetalLB and configures the address pool it assigns LoadBalancer addresses from.
+func (m *MetalLBInstaller) Install(ctx context.Context) error {
+	err := m.helmInstallOrUpgradeMetalLB(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install MetalLB: %w", err)
+	}
+
+	err = m.applyAddressPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to configure MetalLB address pool: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the Helm release for MetalLB.
+func (m *MetalLBInstaller) Uninstall(ctx context.Context) error {
+	err := m.client.UninstallRelease(ctx, ReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall metallb release: %w", err)
+	}
+
+	return nil
+}
+
+// RenderManifests installs the chart with the installer's Helm client and returns the address
+// pool Install would configure, if the installer has addresses to hand out.
+func (m *MetalLBInstaller) RenderManifests(ctx context.Context) ([]runtime.Object, error) {
+	err := m.helmInstallOrUpgradeMetalLB(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install MetalLB: %w", err)
+	}
+
+	if m.addresses == "" {
+		return nil, nil
+	}
+
+	pool, advertisement := m.addressPool()
+
+	return []runtime.Object{pool, advertisement}, nil
+}
+
+// AddressRange returns a range of 51 addresses at the top of subnet for MetalLB to assign to
+// LoadBalancer services, e.g. "172.18.255.200-172.18.255.250" for 172.18.0.0/16.
+func AddressRange(subnet *net.IPNet) (string, error) {
+	base := subnet.IP.To4()
+	ones, bits := subnet.Mask.Size()
+
+	if base == nil || bits != net.IPv4len*8 {
+		return "", fmt.Errorf("%w: %s", ErrNotIPv4Subnet, subnet)
+	}
+
+	if ones > maxRangePrefixLen {
+		return "", fmt.Errorf("%w: %s", ErrSubnetTooSmall, subnet)
+	}
+
+	last := binary.BigEndian.Uint32(base) | ^binary.BigEndian.Uint32(net.IP(subnet.Mask).To4())
+
+	return fmt.Sprintf("%s-%s", uint32ToIP(last-rangeStartOffset), uint32ToIP(last-rangeEndOffset)), nil
+}
+
+// --- internals ---
+
+func uint32ToIP(value uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, value)
+
+	return ip
+}
+
+func (m *MetalLBInstaller) helmInstallOrUpgradeMetalLB(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "metallb",
+		URL:  "https://metallb.github.io/metallb",
+	}
+
+	addRepoErr := m.client.AddRepository(ctx, repoEntry)
+	if addRepoErr != nil {
+		return fmt.Errorf("failed to add metallb repository: %w", addRepoErr)
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName:     ReleaseName,
+		ChartName:       "metallb/metallb",
+		Namespace:       Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		WaitForJobs:     true,
+		Version:         m.version,
+		Timeout:         m.timeout,
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	_, err := m.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install metallb chart: %w", err)
+	}
+
+	return nil
+}
+
+// applyAddressPool creates or updates the IPAddressPool and its L2Advertisement. MetalLB's
+// webhook rejects them until it serves, so they are retried until the timeout.
+func (m *MetalLBInstaller) applyAddressPool(ctx context.Context) error {
+	pool, advertisement := m.addressPool()
+
+	var lastErr error
+
+	err := k8s.PollForReadiness(ctx, m.timeout, func(ctx context.Context) (bool, error) {
+		lastErr = m.apply(ctx, ipAddressPools, pool)
+		if lastErr == nil {
+			lastErr = m.apply(ctx, l2Advertisements, advertisement)
+		}
+
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w (last error: %w)", err, lastErr)
+	}
+
+	return nil
+}
+
+// addressPool returns the IPAddressPool of the installer's addresses and its L2Advertisement.
+func (m *MetalLBInstaller) addressPool() (*unstructured.Unstructured, *unstructured.Unstructured) {
+	pool := newResource("IPAddressPool", map[string]any{"addresses": []any{m.addresses}})
+	advertisement := newResource("L2Advertisement", map[string]any{"ipAddressPools": []any{PoolName}})
+
+	return pool, advertisement
+}
+
+func (m *MetalLBInstaller) apply(
+	ctx context.Context,
+	resource schema.GroupVersionResource,
+	desired *unstructured.Unstructured,
+) error {
+	client := m.dynamicClient.Resource(resource).Namespace(Namespace)
+
+	_, err := client.Create(ctx, desired, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create %s: %w", desired.GetKind(), err)
+	}
+
+	existing, err := client.Get(ctx, PoolName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get %s: %w", desired.GetKind(), err)
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+
+	_, err = client.Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update %s: %w", desired.GetKind(), err)
+	}
+
+	return nil
+}
+
+func newResource(kind string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "metallb.io/v1beta1",
+		"kind":       kind,
+		"metadata":   map[string]any{"name": PoolName, "namespace": Namespace},
+		"spec":       spec,
+	}}
+}
@@ -0,0 +1,105 @@
+package metallbinstaller_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	metallbinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/metallb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+//nolint:gochecknoglobals // Resource of the address pool checked by the tests.
+var ipAddressPools = schema.GroupVersionResource{Group: "metallb.io", Version: "v1beta1", Resource: "ipaddresspools"}
+
+func TestAddressRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		subnet string
+		want   string
+	}{
+		{subnet: "172.18.0.0/16", want: "172.18.255.200-172.18.255.250"},
+		{subnet: "10.89.0.0/24", want: "10.89.0.200-10.89.0.250"},
+	}
+
+	for _, test := range tests {
+		_, subnet, err := net.ParseCIDR(test.subnet)
+		require.NoError(t, err)
+
+		addresses, err := metallbinstaller.AddressRange(subnet)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, addresses)
+	}
+
+	_, small, err := net.ParseCIDR("172.18.0.0/28")
+	require.NoError(t, err)
+
+	_, err = metallbinstaller.AddressRange(small)
+	require.ErrorIs(t, err, metallbinstaller.ErrSubnetTooSmall)
+}
+
+func TestMetalLBInstallerInstallConfiguresAddressPool(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "metallb" && entry.URL == "https://metallb.github.io/metallb"
+		})).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			return spec.ChartName == "metallb/metallb" && spec.Namespace == "metallb-system" && spec.Wait
+		})).
+		Return(&helm.ReleaseInfo{}, nil)
+
+	// A pool left by a previous install is updated to the new range.
+	existing := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "metallb.io/v1beta1",
+		"kind":       "IPAddressPool",
+		"metadata":   map[string]any{"name": "ksail", "namespace": "metallb-system"},
+		"spec":       map[string]any{"addresses": []any{"172.19.255.200-172.19.255.250"}},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), existing)
+
+	installer := metallbinstaller.NewMetalLBInstaller(
+		client,
+		dynamicClient,
+		"172.18.255.200-172.18.255.250",
+		5*time.Second,
+	)
+
+	require.NoError(t, installer.Install(context.Background()))
+
+	pool, err := dynamicClient.Resource(ipAddressPools).Namespace("metallb-system").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	addresses, _, err := unstructured.NestedStringSlice(pool.Object, "spec", "addresses")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"172.18.255.200-172.18.255.250"}, addresses)
+}
+
+func TestMetalLBInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().InstallOrUpgradeChart(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	installer := metallbinstaller.NewMetalLBInstaller(client, nil, "172.18.255.200-172.18.255.250", time.Second)
+
+	err := installer.Install(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install MetalLB")
+}
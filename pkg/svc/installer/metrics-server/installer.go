@@ -14,6 +14,8 @@ type MetricsServerInstaller struct {
 	context    string
 	timeout    time.Duration
 	client     helm.Interface
+	version    string
+	values     []string
 }
 
 // NewMetricsServerInstaller creates a new metrics-server installer instance.
@@ -40,6 +42,18 @@ func (m *MetricsServerInstaller) Install(ctx context.Context) error {
 	return nil
 }
 
+// SetVersion selects the metrics-server chart version. An empty version defers to the project's
+// lock, and then to the latest published chart.
+func (m *MetricsServerInstaller) SetVersion(version string) {
+	m.version = version
+}
+
+// SetValues sets user-supplied chart values as YAML documents, which are merged over the
+// installer's defaults in order.
+func (m *MetricsServerInstaller) SetValues(documents ...string) {
+	m.values = documents
+}
+
 // Uninstall removes the Helm release for metrics-server.
 func (m *MetricsServerInstaller) Uninstall(ctx context.Context) error {
 	err := m.client.UninstallRelease(ctx, "metrics-server", "kube-system")
@@ -52,6 +66,13 @@ func (m *MetricsServerInstaller) Uninstall(ctx context.Context) error {
 
 // --- internals ---
 
+// metricsServerValues are the default chart values for local development clusters (Kind, K3d)
+// with self-signed certificates: metrics-server needs to skip TLS verification and use
+// InternalIP for node communication.
+const metricsServerValues = `args:
+  - --kubelet-insecure-tls
+  - --kubelet-preferred-address-types=InternalIP`
+
 func (m *MetricsServerInstaller) helmInstallOrUpgradeMetricsServer(ctx context.Context) error {
 	repoEntry := &helm.RepositoryEntry{
 		Name: "metrics-server",
@@ -63,6 +84,11 @@ func (m *MetricsServerInstaller) helmInstallOrUpgradeMetricsServer(ctx context.C
 		return fmt.Errorf("failed to add metrics-server repository: %w", addRepoErr)
 	}
 
+	values, err := helm.MergeValuesYAML(append([]string{metricsServerValues}, m.values...)...)
+	if err != nil {
+		return fmt.Errorf("failed to merge metrics-server values: %w", err)
+	}
+
 	spec := &helm.ChartSpec{
 		ReleaseName: "metrics-server",
 		ChartName:   "metrics-server/metrics-server",
@@ -71,15 +97,12 @@ func (m *MetricsServerInstaller) helmInstallOrUpgradeMetricsServer(ctx context.C
 		Atomic:      true,
 		Wait:        true,
 		WaitForJobs: true,
+		Version:     m.version,
 		Timeout:     m.timeout,
-		// Values for local development clusters (Kind, K3d) with self-signed certificates:
-		// metrics-server needs to skip TLS verification and use InternalIP for node communication.
-		ValuesYaml: `args:
-  - --kubelet-insecure-tls
-  - --kubelet-preferred-address-types=InternalIP`,
+		ValuesYaml:  values,
 	}
 
-	_, err := m.client.InstallOrUpgradeChart(ctx, spec)
+	_, err = m.client.InstallOrUpgradeChart(ctx, spec)
 	if err != nil {
 		return fmt.Errorf("failed to install metrics-server chart: %w", err)
 	}
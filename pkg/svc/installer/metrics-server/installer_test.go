@@ -41,6 +41,29 @@ func TestMetricsServerInstallerInstallSuccess(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMetricsServerInstallerInstallMergesUserValues(t *testing.T) {
+	t.Parallel()
+
+	installer, client := newMetricsServerInstallerWithDefaults(t)
+	installer.SetValues("replicas: 2\n", "args:\n  - --kubelet-insecure-tls\n")
+
+	expectMetricsServerAddRepository(t, client, nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, "args:\n- --kubelet-insecure-tls\nreplicas: 2\n", spec.ValuesYaml)
+
+				return true
+			}),
+		).
+		Return(nil, nil)
+
+	err := installer.Install(context.Background())
+
+	require.NoError(t, err)
+}
+
 func TestMetricsServerInstallerInstallError(t *testing.T) {
 	t.Parallel()
 
@@ -145,6 +168,7 @@ func expectMetricsServerInstall(
 				assert.True(t, spec.Atomic)
 				assert.True(t, spec.Wait)
 				assert.True(t, spec.WaitForJobs)
+				assert.Contains(t, spec.ValuesYaml, "--kubelet-preferred-address-types=InternalIP")
 
 				return true
 			}),
@@ -0,0 +1,300 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrDuplicateStep is returned when two pipeline steps share a name.
+	ErrDuplicateStep = errors.New("duplicate pipeline step")
+	// ErrUnknownDependency is returned when a step depends on a step the pipeline does not have.
+	ErrUnknownDependency = errors.New("unknown pipeline dependency")
+	// ErrDependencyCycle is returned when steps depend on each other in a cycle.
+	ErrDependencyCycle = errors.New("pipeline dependency cycle")
+)
+
+// Step is a unit of work in a Pipeline, typically the installation of one component.
+type Step struct {
+	// Name identifies the step for DependsOn of other steps.
+	Name string
+	// DependsOn lists the steps that must succeed before this step runs.
+	DependsOn []string
+	// Run performs the step.
+	Run func(ctx context.Context) error
+}
+
+// PipelineOption configures a Pipeline.
+type PipelineOption func(*pipelineOptions)
+
+type pipelineOptions struct {
+	maxConcurrency int
+}
+
+// WithMaxConcurrency sets how many independent steps may run at the same time.
+// Values below one are treated as one, which runs the steps in sequence.
+func WithMaxConcurrency(maxConcurrency int) PipelineOption {
+	return func(o *pipelineOptions) {
+		o.maxConcurrency = max(maxConcurrency, 1)
+	}
+}
+
+// Pipeline runs steps in the topological order of their dependencies. Steps whose
+// dependencies have all succeeded run concurrently, up to the configured concurrency.
+type Pipeline struct {
+	steps      []Step
+	index      map[string]int
+	dependents map[string][]string
+	options    pipelineOptions
+}
+
+// NewPipeline creates a pipeline of the given steps. Ties between steps that are ready at the
+// same time are broken by their order in steps, so a sequential pipeline is deterministic.
+//
+// Returns ErrDuplicateStep, ErrUnknownDependency or ErrDependencyCycle if the steps do not
+// form a valid dependency graph.
+func NewPipeline(steps []Step, opts ...PipelineOption) (*Pipeline, error) {
+	options := pipelineOptions{maxConcurrency: max(len(steps), 1)}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	pipeline := &Pipeline{
+		steps:      steps,
+		index:      make(map[string]int, len(steps)),
+		dependents: make(map[string][]string, len(steps)),
+		options:    options,
+	}
+
+	for i, step := range steps {
+		if _, exists := pipeline.index[step.Name]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateStep, step.Name)
+		}
+
+		pipeline.index[step.Name] = i
+	}
+
+	for _, step := range steps {
+		for _, dependency := range step.DependsOn {
+			if _, exists := pipeline.index[dependency]; !exists {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, step.Name, dependency)
+			}
+
+			pipeline.dependents[dependency] = append(pipeline.dependents[dependency], step.Name)
+		}
+	}
+
+	order := pipeline.Order()
+	if len(order) < len(steps) {
+		blocked := make([]string, 0, len(steps)-len(order))
+
+		for _, step := range steps {
+			if !slices.Contains(order, step.Name) {
+				blocked = append(blocked, step.Name)
+			}
+		}
+
+		return nil, fmt.Errorf("%w between %s", ErrDependencyCycle, strings.Join(blocked, ", "))
+	}
+
+	return pipeline, nil
+}
+
+// SelectSteps returns the steps selected by name, in their original order. A selected step
+// depends on every selected step it depends on in steps, directly or through steps that are not
+// selected, so the selection runs in the order the full graph prescribes.
+func SelectSteps(steps []Step, selected func(name string) bool) []Step {
+	dependencies := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		dependencies[step.Name] = step.DependsOn
+	}
+
+	selection := make([]Step, 0, len(steps))
+
+	for _, step := range steps {
+		if !selected(step.Name) {
+			continue
+		}
+
+		step.DependsOn = selectedDependencies(dependencies, step.Name, selected)
+		selection = append(selection, step)
+	}
+
+	return selection
+}
+
+// selectedDependencies returns the selected steps name depends on, looking through the
+// dependencies of steps that are not selected.
+func selectedDependencies(
+	dependencies map[string][]string,
+	name string,
+	selected func(name string) bool,
+) []string {
+	var found []string
+
+	visited := map[string]bool{name: true}
+	pending := slices.Clone(dependencies[name])
+
+	for len(pending) > 0 {
+		dependency := pending[0]
+		pending = pending[1:]
+
+		if visited[dependency] {
+			continue
+		}
+
+		visited[dependency] = true
+
+		if selected(dependency) {
+			found = append(found, dependency)
+
+			continue
+		}
+
+		pending = append(pending, dependencies[dependency]...)
+	}
+
+	return found
+}
+
+// ReverseDependencies returns steps with their dependencies inverted: every step depends on the
+// steps that depended on it. A pipeline of the result undoes an installation, such as by
+// uninstalling components, in the reverse order of the original steps. Dependencies on steps
+// that are not in steps are dropped; reverse a subset chosen with SelectSteps to keep the order
+// it has through the steps left out.
+func ReverseDependencies(steps []Step) []Step {
+	names := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		names[step.Name] = true
+	}
+
+	dependents := make(map[string][]string, len(steps))
+
+	for _, step := range steps {
+		for _, dependency := range step.DependsOn {
+			if names[dependency] && !slices.Contains(dependents[dependency], step.Name) {
+				dependents[dependency] = append(dependents[dependency], step.Name)
+			}
+		}
+	}
+
+	reversed := make([]Step, 0, len(steps))
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		step.DependsOn = dependents[step.Name]
+		reversed = append(reversed, step)
+	}
+
+	return reversed
+}
+
+// Order returns the step names in the order a sequential run executes them.
+func (p *Pipeline) Order() []string {
+	remaining := p.dependencyCounts()
+	ready := p.initiallyReady(remaining)
+	order := make([]string, 0, len(p.steps))
+
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = p.release(ready[1:], name, remaining)
+		order = append(order, name)
+	}
+
+	return order
+}
+
+type stepResult struct {
+	name string
+	err  error
+}
+
+// Run executes the steps. Once a step fails no further steps are started, steps already
+// running are awaited, and the errors of all failed steps are returned together.
+func (p *Pipeline) Run(ctx context.Context) error {
+	remaining := p.dependencyCounts()
+	ready := p.initiallyReady(remaining)
+	results := make(chan stepResult)
+	running := 0
+
+	var errs []error
+
+	for {
+		for len(errs) == 0 && ctx.Err() == nil && running < p.options.maxConcurrency && len(ready) > 0 {
+			step := p.steps[p.index[ready[0]]]
+			ready = ready[1:]
+			running++
+
+			go func() {
+				results <- stepResult{name: step.Name, err: step.Run(ctx)}
+			}()
+		}
+
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+
+		if result.err != nil {
+			errs = append(errs, result.err)
+
+			continue
+		}
+
+		ready = p.release(ready, result.name, remaining)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("pipeline interrupted: %w", ctx.Err())
+	}
+
+	return nil
+}
+
+// dependencyCounts returns how many unfinished dependencies each step has.
+func (p *Pipeline) dependencyCounts() map[string]int {
+	counts := make(map[string]int, len(p.steps))
+	for _, step := range p.steps {
+		counts[step.Name] = len(step.DependsOn)
+	}
+
+	return counts
+}
+
+// initiallyReady returns the steps without dependencies, in declaration order.
+func (p *Pipeline) initiallyReady(remaining map[string]int) []string {
+	var ready []string
+
+	for _, step := range p.steps {
+		if remaining[step.Name] == 0 {
+			ready = append(ready, step.Name)
+		}
+	}
+
+	return ready
+}
+
+// release marks name as finished and adds the dependents it unblocks to ready, keeping ready
+// in declaration order.
+func (p *Pipeline) release(ready []string, name string, remaining map[string]int) []string {
+	for _, dependent := range p.dependents[name] {
+		remaining[dependent]--
+		if remaining[dependent] == 0 {
+			ready = append(ready, dependent)
+		}
+	}
+
+	slices.SortFunc(ready, func(a, b string) int {
+		return p.index[a] - p.index[b]
+	})
+
+	return ready
+}
@@ -0,0 +1,192 @@
+package installer_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/installer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStepFailed = errors.New("step failed")
+
+// recorder records the order steps ran in.
+type recorder struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (r *recorder) step(name string, dependsOn ...string) installer.Step {
+	return installer.Step{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func(context.Context) error {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			r.names = append(r.names, name)
+
+			return nil
+		},
+	}
+}
+
+func TestPipelineRunsInDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	pipeline, err := installer.NewPipeline([]installer.Step{
+		rec.step("flux", "kyverno"),
+		rec.step("kyverno", "cni"),
+		rec.step("metrics-server", "cni"),
+		rec.step("cni", "gateway-api"),
+		rec.step("gateway-api"),
+	}, installer.WithMaxConcurrency(1))
+	require.NoError(t, err)
+
+	expected := []string{"gateway-api", "cni", "kyverno", "flux", "metrics-server"}
+	assert.Equal(t, expected, pipeline.Order())
+
+	require.NoError(t, pipeline.Run(context.Background()))
+	assert.Equal(t, expected, rec.names)
+}
+
+func TestPipelineRunsIndependentStepsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var started sync.WaitGroup
+
+	started.Add(2)
+
+	// Each step only returns once both have started, so a sequential run would deadlock.
+	concurrent := func(name string) installer.Step {
+		return installer.Step{
+			Name: name,
+			Run: func(context.Context) error {
+				started.Done()
+				started.Wait()
+
+				return nil
+			},
+		}
+	}
+
+	pipeline, err := installer.NewPipeline([]installer.Step{concurrent("gitea"), concurrent("vault")})
+	require.NoError(t, err)
+
+	require.NoError(t, pipeline.Run(context.Background()))
+}
+
+func TestPipelineStopsAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	var ran atomic.Bool
+
+	pipeline, err := installer.NewPipeline([]installer.Step{
+		{Name: "cni", Run: func(context.Context) error { return errStepFailed }},
+		{Name: "flux", DependsOn: []string{"cni"}, Run: func(context.Context) error {
+			ran.Store(true)
+
+			return nil
+		}},
+	})
+	require.NoError(t, err)
+
+	err = pipeline.Run(context.Background())
+
+	require.ErrorIs(t, err, errStepFailed)
+	assert.False(t, ran.Load(), "dependent of a failed step must not run")
+}
+
+func TestReverseDependenciesRunsDependentsFirst(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	steps := installer.ReverseDependencies([]installer.Step{
+		rec.step("gateway-api"),
+		rec.step("cni", "gateway-api"),
+		rec.step("metrics-server", "cni"),
+		rec.step("kyverno", "cni", "metrics-server"),
+		rec.step("flux", "kyverno", "cert-manager"),
+	})
+
+	pipeline, err := installer.NewPipeline(steps, installer.WithMaxConcurrency(1))
+	require.NoError(t, err, "dependencies on steps that are not reversed must be dropped")
+
+	expected := []string{"flux", "kyverno", "metrics-server", "cni", "gateway-api"}
+	assert.Equal(t, expected, pipeline.Order())
+
+	require.NoError(t, pipeline.Run(context.Background()))
+	assert.Equal(t, expected, rec.names)
+}
+
+func TestSelectStepsKeepsOrderThroughStepsLeftOut(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{}
+	steps := installer.SelectSteps([]installer.Step{
+		rec.step("gateway-api"),
+		rec.step("cni", "gateway-api"),
+		rec.step("metrics-server", "cni"),
+		rec.step("vault", "cni"),
+		rec.step("kyverno", "metrics-server", "vault"),
+		rec.step("flux", "kyverno"),
+		rec.step("argocd", "kyverno"),
+	}, func(name string) bool {
+		return name == "cni" || name == "flux" || name == "argocd"
+	})
+
+	require.Len(t, steps, 3)
+	assert.Equal(t, []string{"cni"}, steps[1].DependsOn)
+	assert.Equal(t, []string{"cni"}, steps[2].DependsOn)
+
+	pipeline, err := installer.NewPipeline(installer.ReverseDependencies(steps))
+	require.NoError(t, err)
+
+	require.NoError(t, pipeline.Run(context.Background()))
+	assert.Equal(t, "cni", rec.names[len(rec.names)-1], "cni must be removed after flux and argocd")
+}
+
+func TestNewPipelineRejectsInvalidGraphs(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name     string
+		steps    []installer.Step
+		expected error
+	}{
+		{
+			name:     "duplicate",
+			steps:    []installer.Step{{Name: "cni", Run: noop}, {Name: "cni", Run: noop}},
+			expected: installer.ErrDuplicateStep,
+		},
+		{
+			name:     "unknown dependency",
+			steps:    []installer.Step{{Name: "flux", DependsOn: []string{"cert-manager"}, Run: noop}},
+			expected: installer.ErrUnknownDependency,
+		},
+		{
+			name: "cycle",
+			steps: []installer.Step{
+				{Name: "cni", DependsOn: []string{"flux"}, Run: noop},
+				{Name: "flux", DependsOn: []string{"cni"}, Run: noop},
+			},
+			expected: installer.ErrDependencyCycle,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := installer.NewPipeline(test.steps)
+			require.ErrorIs(t, err, test.expected)
+		})
+	}
+}
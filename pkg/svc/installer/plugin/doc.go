@@ -0,0 +1,21 @@
+// Package plugininstaller runs third-party installers declared under spec.plugins in
+// ksail.yaml, so teams can install components KSail does not ship without forking it.
+//
+// A plugin is any executable. KSail runs it as
+//
+//	<command> install [args...]
+//
+// during cluster create, once the components it depends on are installed, and as
+// `<command> uninstall [args...]` during ksail cluster clean to remove what it installed. The
+// command runs in the project directory, the working directory of ksail, with the environment
+// of KSail plus:
+//
+//   - KUBECONFIG, the kubeconfig of the cluster
+//   - KSAIL_CONTEXT, the kubeconfig context of the cluster
+//   - KSAIL_DISTRIBUTION, the distribution, e.g. Kind
+//   - KSAIL_PLUGIN_NAME, the name of the plugin in ksail.yaml
+//   - KSAIL_TIMEOUT, how long the plugin may take, as a Go duration such as 5m0s
+//   - the variables of the plugin's env
+//
+// A plugin succeeds by exiting with status zero. Its output is shown when it fails.
+package plugininstaller
@@ -0,0 +1,133 @@
+package plugininstaller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	actionInstall   = "install"
+	actionUninstall = "uninstall"
+)
+
+// ErrPluginFailed is returned when a plugin exits with a non-zero status or cannot be started.
+var ErrPluginFailed = errors.New("plugin failed")
+
+// Cluster describes the cluster a plugin installs into.
+type Cluster struct {
+	// Kubeconfig is the kubeconfig file of the cluster.
+	Kubeconfig string
+	// Context is the kubeconfig context of the cluster.
+	Context string
+	// Distribution is the distribution the cluster runs.
+	Distribution v1alpha1.Distribution
+	// ProjectDir is the directory plugins run in and relative commands are resolved against.
+	ProjectDir string
+}
+
+// PluginInstaller implements the installer.Installer interface by running a plugin's command.
+type PluginInstaller struct {
+	plugin  v1alpha1.Plugin
+	cluster Cluster
+	timeout time.Duration
+}
+
+// NewPluginInstaller creates an installer that runs plugin against cluster, stopping it once
+// timeout has passed.
+func NewPluginInstaller(plugin v1alpha1.Plugin, cluster Cluster, timeout time.Duration) *PluginInstaller {
+	return &PluginInstaller{
+		plugin:  plugin,
+		cluster: cluster,
+		timeout: timeout,
+	}
+}
+
+// Install runs `<command> install`.
+func (p *PluginInstaller) Install(ctx context.Context) error {
+	return p.run(ctx, actionInstall)
+}
+
+// Uninstall runs `<command> uninstall`.
+func (p *PluginInstaller) Uninstall(ctx context.Context) error {
+	return p.run(ctx, actionUninstall)
+}
+
+func (p *PluginInstaller) run(ctx context.Context, action string) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	args := append([]string{action}, p.plugin.Args...)
+
+	//nolint:gosec // Running the command configured in ksail.yaml is the purpose of plugins.
+	command := exec.CommandContext(timeoutCtx, p.command(), args...)
+	command.Dir = p.cluster.ProjectDir
+	command.Env = p.environment()
+
+	var output bytes.Buffer
+
+	command.Stdout = &output
+	command.Stderr = &output
+
+	err := command.Run()
+	if err != nil {
+		if timeoutCtx.Err() != nil && ctx.Err() == nil {
+			err = fmt.Errorf("timed out after %s: %w", p.timeout, err)
+		}
+
+		return fmt.Errorf("%w: %s %s: %w%s", ErrPluginFailed, p.plugin.Name, action, err,
+			formatOutput(output.String()))
+	}
+
+	return nil
+}
+
+// command resolves commands with a path separator against the project directory, and leaves
+// the rest to the PATH lookup of exec.
+func (p *PluginInstaller) command() string {
+	command := strings.TrimSpace(p.plugin.Command)
+	if !strings.ContainsRune(command, '/') && !strings.ContainsRune(command, filepath.Separator) {
+		return command
+	}
+
+	if filepath.IsAbs(command) || p.cluster.ProjectDir == "" {
+		return command
+	}
+
+	return filepath.Join(p.cluster.ProjectDir, command)
+}
+
+func (p *PluginInstaller) environment() []string {
+	env := append(os.Environ(),
+		"KUBECONFIG="+p.cluster.Kubeconfig,
+		"KSAIL_CONTEXT="+p.cluster.Context,
+		"KSAIL_DISTRIBUTION="+string(p.cluster.Distribution),
+		"KSAIL_PLUGIN_NAME="+p.plugin.Name,
+		"KSAIL_TIMEOUT="+p.timeout.String(),
+	)
+
+	for _, key := range slices.Sorted(maps.Keys(p.plugin.Env)) {
+		env = append(env, key+"="+p.plugin.Env[key])
+	}
+
+	return env
+}
+
+func formatOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return ""
+	}
+
+	return "\n" + output
+}
@@ -0,0 +1,96 @@
+package plugininstaller_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	plugininstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes an executable shell script to dir/plugins/name.
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+
+	path := filepath.Join(dir, "plugins", name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	//nolint:gosec // The plugin must be executable.
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700))
+}
+
+func TestPluginInstallerRunsCommandWithClusterEnvironment(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "platform", `printf '%s %s %s %s %s %s %s' "$1" "$2" "$KUBECONFIG" "$KSAIL_CONTEXT" `+
+		`"$KSAIL_DISTRIBUTION" "$KSAIL_PLUGIN_NAME" "$TEAM" > result`)
+
+	installer := plugininstaller.NewPluginInstaller(
+		v1alpha1.Plugin{
+			Name:    "platform",
+			Command: "./plugins/platform",
+			Args:    []string{"--charts"},
+			Env:     map[string]string{"TEAM": "platform"},
+		},
+		plugininstaller.Cluster{
+			Kubeconfig:   "/tmp/kubeconfig",
+			Context:      "kind-local",
+			Distribution: v1alpha1.DistributionKind,
+			ProjectDir:   dir,
+		},
+		5*time.Second,
+	)
+
+	require.NoError(t, installer.Install(context.Background()))
+
+	result, err := os.ReadFile(filepath.Join(dir, "result")) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+	assert.Equal(t, "install --charts /tmp/kubeconfig kind-local Kind platform platform", string(result))
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+
+	result, err = os.ReadFile(filepath.Join(dir, "result")) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+	assert.Contains(t, string(result), "uninstall --charts")
+}
+
+func TestPluginInstallerReportsOutputOfFailedPlugin(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "broken", "echo 'chart platform-ingress not found' >&2\nexit 3\n")
+
+	installer := plugininstaller.NewPluginInstaller(
+		v1alpha1.Plugin{Name: "broken", Command: "plugins/broken"},
+		plugininstaller.Cluster{ProjectDir: dir},
+		5*time.Second,
+	)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, plugininstaller.ErrPluginFailed)
+	assert.ErrorContains(t, err, "broken install: exit status 3\nchart platform-ingress not found")
+}
+
+func TestPluginInstallerStopsPluginAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "slow", "exec sleep 10\n")
+
+	installer := plugininstaller.NewPluginInstaller(
+		v1alpha1.Plugin{Name: "slow", Command: "./plugins/slow"},
+		plugininstaller.Cluster{ProjectDir: dir},
+		100*time.Millisecond,
+	)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, plugininstaller.ErrPluginFailed)
+	assert.ErrorContains(t, err, "timed out after 100ms")
+}
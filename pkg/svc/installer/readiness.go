@@ -10,6 +10,8 @@ import (
 )
 
 // WaitForResourceReadiness waits for multiple Kubernetes resources to become ready.
+//
+// All resources are checked concurrently and every resource that is not ready is reported.
 func WaitForResourceReadiness(
 	ctx context.Context,
 	kubeconfig, context string,
@@ -27,7 +29,14 @@ func WaitForResourceReadiness(
 		return fmt.Errorf("create kubernetes client: %w", err)
 	}
 
-	err = k8s.WaitForMultipleResources(ctx, clientset, checks, timeout)
+	err = k8s.WaitForMultipleResources(
+		ctx,
+		clientset,
+		checks,
+		timeout,
+		k8s.WithMaxConcurrency(len(checks)),
+		k8s.WithFailFast(false),
+	)
 	if err != nil {
 		return fmt.Errorf("wait for %s components: %w", componentName, err)
 	}
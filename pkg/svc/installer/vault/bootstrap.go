@@ -0,0 +1,147 @@
+package vaultinstaller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TransitMount is the path the transit secrets engine is enabled at for TransitKey.
+const TransitMount = "transit"
+
+// ErrVaultRequest is returned when the Vault API rejects a bootstrap request.
+var ErrVaultRequest = errors.New("vault request failed")
+
+// Bootstrap describes what to create on a fresh Vault server.
+type Bootstrap struct {
+	// KVMount is the path to enable a KV version 2 secrets engine at, e.g. "apps".
+	KVMount string
+	// TransitKey is the name of an encryption key to create in the transit secrets engine,
+	// for use with `sops --hc-vault-transit <address>/v1/transit/keys/<key>`.
+	TransitKey string
+}
+
+// Apply creates the mounts and keys of b on the Vault server at address, authenticating with
+// token. Mounts and keys that exist are left as they are, so Apply can run on every create.
+func (b Bootstrap) Apply(ctx context.Context, address, token string) error {
+	api := &vaultAPI{address: strings.TrimSuffix(address, "/"), token: token, client: http.DefaultClient}
+
+	mounts, err := api.mounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	if b.KVMount != "" {
+		err = api.enableMount(ctx, mounts, b.KVMount, map[string]any{
+			"type":    "kv",
+			"options": map[string]string{"version": "2"},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if b.TransitKey == "" {
+		return nil
+	}
+
+	err = api.enableMount(ctx, mounts, TransitMount, map[string]any{"type": "transit"})
+	if err != nil {
+		return err
+	}
+
+	// Creating a key that exists is a no-op in Vault.
+	err = api.do(ctx, http.MethodPost, "/v1/"+TransitMount+"/keys/"+b.TransitKey, nil, nil)
+	if err != nil {
+		return fmt.Errorf("create transit key %s: %w", b.TransitKey, err)
+	}
+
+	return nil
+}
+
+type vaultAPI struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// mounts returns the paths of the enabled secrets engines, such as "secret/". Vault lists them
+// under data, and at the top level for compatibility with older clients.
+func (a *vaultAPI) mounts(ctx context.Context) (map[string]any, error) {
+	var response map[string]any
+
+	err := a.do(ctx, http.MethodGet, "/v1/sys/mounts", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("list mounts: %w", err)
+	}
+
+	if data, ok := response["data"].(map[string]any); ok {
+		return data, nil
+	}
+
+	return response, nil
+}
+
+func (a *vaultAPI) enableMount(ctx context.Context, mounts map[string]any, path string, body any) error {
+	if _, ok := mounts[strings.Trim(path, "/")+"/"]; ok {
+		return nil
+	}
+
+	err := a.do(ctx, http.MethodPost, "/v1/sys/mounts/"+strings.Trim(path, "/"), body, nil)
+	if err != nil {
+		return fmt.Errorf("enable mount %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (a *vaultAPI) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, a.address+path, reader)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", a.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+
+		return fmt.Errorf("%w: %s %s returned %s: %s",
+			ErrVaultRequest, method, path, resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package vaultinstaller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault records the requests of a bootstrap against a dev server with the default mounts.
+type fakeVault struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeVault) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.Header.Get("X-Vault-Token") != "dev-token" {
+		writer.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+
+	if req.Method == http.MethodGet && req.URL.Path == "/v1/sys/mounts" {
+		_ = json.NewEncoder(writer).Encode(map[string]any{
+			"data": map[string]any{
+				"secret/":    map[string]any{"type": "kv"},
+				"sys/":       map[string]any{"type": "system"},
+				"cubbyhole/": map[string]any{"type": "cubbyhole"},
+			},
+		})
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func TestBootstrapApply(t *testing.T) {
+	t.Parallel()
+
+	vault := &fakeVault{}
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+
+	bootstrap := vaultinstaller.Bootstrap{KVMount: "apps", TransitKey: "sops"}
+
+	require.NoError(t, bootstrap.Apply(context.Background(), server.URL, "dev-token"))
+
+	assert.Equal(t, []string{
+		"GET /v1/sys/mounts",
+		"POST /v1/sys/mounts/apps",
+		"POST /v1/sys/mounts/transit",
+		"POST /v1/transit/keys/sops",
+	}, vault.requests)
+}
+
+func TestBootstrapApplySkipsExistingMounts(t *testing.T) {
+	t.Parallel()
+
+	vault := &fakeVault{}
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+
+	bootstrap := vaultinstaller.Bootstrap{KVMount: "secret"}
+
+	require.NoError(t, bootstrap.Apply(context.Background(), server.URL, "dev-token"))
+
+	assert.Equal(t, []string{"GET /v1/sys/mounts"}, vault.requests)
+}
+
+func TestBootstrapApplyRejectedToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&fakeVault{})
+	t.Cleanup(server.Close)
+
+	bootstrap := vaultinstaller.Bootstrap{KVMount: "apps"}
+
+	err := bootstrap.Apply(context.Background(), server.URL, "wrong")
+
+	require.ErrorIs(t, err, vaultinstaller.ErrVaultRequest)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+}
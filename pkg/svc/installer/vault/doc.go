@@ -0,0 +1,8 @@
+// Package vaultinstaller provides an installer for a HashiCorp Vault dev server on a
+// Kubernetes cluster.
+//
+// The dev server keeps its data in memory, is unsealed on start and accepts a known root
+// token, which makes it unfit for production but quick to test secrets-driven applications
+// against. The installer can bootstrap a KV version 2 mount and a transit key, the encryption
+// backend of SOPS' hc_vault support, so both are ready when the cluster is.
+package vaultinstaller
@@ -0,0 +1,145 @@
+package vaultinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	"github.com/devantler-tech/ksail-go/pkg/k8s"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// ReleaseName is the Helm release name of Vault.
+	ReleaseName = "vault"
+	// Namespace is the namespace Vault is installed into.
+	Namespace = "vault"
+	// ServiceName is the service of the Vault API and web UI.
+	ServiceName = "vault"
+	// ServicePort is the port of the Vault service.
+	ServicePort = 8200
+	// DefaultToken is the root token of the dev server when none is configured.
+	DefaultToken = "root"
+)
+
+// VaultInstaller implements the installer.Installer interface for the Vault dev server.
+type VaultInstaller struct {
+	timeout    time.Duration
+	client     helm.Interface
+	restConfig *rest.Config
+	version    string
+	token      string
+	bootstrap  Bootstrap
+}
+
+// NewVaultInstaller creates a new Vault installer instance. The REST config is used to reach
+// the Vault API when there is something to bootstrap.
+func NewVaultInstaller(
+	client helm.Interface,
+	restConfig *rest.Config,
+	timeout time.Duration,
+) *VaultInstaller {
+	return &VaultInstaller{
+		client:     client,
+		restConfig: restConfig,
+		timeout:    timeout,
+		token:      DefaultToken,
+	}
+}
+
+// SetVersion pins the hashicorp/vault chart that runs the dev server. An empty version takes the
+// chart locked for the project, or the latest release.
+func (v *VaultInstaller) SetVersion(version string) {
+	v.version = version
+}
+
+// SetToken sets the root token of the dev server. An empty token keeps DefaultToken.
+func (v *VaultInstaller) SetToken(token string) {
+	if token != "" {
+		v.token = token
+	}
+}
+
+// SetBootstrap sets the mounts and keys Install creates once Vault serves.
+func (v *VaultInstaller) SetBootstrap(bootstrap Bootstrap) {
+	v.bootstrap = bootstrap
+}
+
+// Install installs or upgrades the Vault dev server via its Helm chart, and bootstraps it.
+func (v *VaultInstaller) Install(ctx context.Context) error {
+	err := v.helmInstallOrUpgradeVault(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Vault: %w", err)
+	}
+
+	if v.bootstrap == (Bootstrap{}) {
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	forward, err := k8s.ForwardService(timeoutCtx, v.restConfig, Namespace, ServiceName, ServicePort)
+	if err != nil {
+		return fmt.Errorf("failed to reach Vault: %w", err)
+	}
+
+	defer forward.Close()
+
+	err = v.bootstrap.Apply(timeoutCtx, forward.LocalURL, v.token)
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap Vault: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the Helm release for Vault.
+func (v *VaultInstaller) Uninstall(ctx context.Context) error {
+	err := v.client.UninstallRelease(ctx, ReleaseName, Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall vault release: %w", err)
+	}
+
+	return nil
+}
+
+// --- internals ---
+
+func (v *VaultInstaller) helmInstallOrUpgradeVault(ctx context.Context) error {
+	repoEntry := &helm.RepositoryEntry{
+		Name: "hashicorp",
+		URL:  "https://helm.releases.hashicorp.com",
+	}
+
+	addRepoErr := v.client.AddRepository(ctx, repoEntry)
+	if addRepoErr != nil {
+		return fmt.Errorf("failed to add hashicorp repository: %w", addRepoErr)
+	}
+
+	spec := &helm.ChartSpec{
+		ReleaseName:     ReleaseName,
+		ChartName:       "hashicorp/vault",
+		Namespace:       Namespace,
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		Version:         v.version,
+		Timeout:         v.timeout,
+		SetValues: map[string]string{
+			"server.dev.enabled":      "true",
+			"server.dev.devRootToken": v.token,
+		},
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	_, err := v.client.InstallOrUpgradeChart(timeoutCtx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to install vault chart: %w", err)
+	}
+
+	return nil
+}
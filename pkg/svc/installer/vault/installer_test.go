@@ -0,0 +1,58 @@
+package vaultinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/helm"
+	vaultinstaller "github.com/devantler-tech/ksail-go/pkg/svc/installer/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultInstallerInstallDevServer(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+			return entry.Name == "hashicorp" && entry.URL == "https://helm.releases.hashicorp.com"
+		})).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			assert.Equal(t, "hashicorp/vault", spec.ChartName)
+			assert.Equal(t, "vault", spec.Namespace)
+			assert.Equal(t, map[string]string{
+				"server.dev.enabled":      "true",
+				"server.dev.devRootToken": "dev-token",
+			}, spec.SetValues)
+
+			return true
+		})).
+		Return(&helm.ReleaseInfo{}, nil)
+
+	// Without anything to bootstrap, the installer never reaches out to the cluster.
+	installer := vaultinstaller.NewVaultInstaller(client, nil, 5*time.Second)
+	installer.SetToken("dev-token")
+
+	require.NoError(t, installer.Install(context.Background()))
+}
+
+func TestVaultInstallerInstallChartError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	client.EXPECT().AddRepository(mock.Anything, mock.Anything).Return(nil)
+	client.EXPECT().InstallOrUpgradeChart(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	installer := vaultinstaller.NewVaultInstaller(client, nil, 5*time.Second)
+	installer.SetBootstrap(vaultinstaller.Bootstrap{KVMount: "apps"})
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "failed to install vault chart")
+}
@@ -0,0 +1,9 @@
+// Package lockfile pins the exact versions of what a KSail project deploys.
+//
+// A Lock keeps a ksail.lock file next to ksail.yaml, meant to be committed with the project.
+// It records the node image clusters are created from, the version and archive digest of
+// every Helm chart installed and the digest of every component image. Later runs install the
+// locked chart versions instead of the newest ones, and frozen runs fail when anything they
+// would deploy differs from the lock, so CI reproduces the same environment until the pins
+// are refreshed on purpose with `ksail update`.
+package lockfile
@@ -0,0 +1,279 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultPath is where the lock is kept, relative to the project directory.
+const DefaultPath = "ksail.lock"
+
+const (
+	lockFilePerm = 0o644
+	lockDirPerm  = 0o750
+
+	header = "# Generated by ksail. Do not edit; refresh the pins with `ksail update`.\n"
+)
+
+// ErrOutOfDate is returned by frozen locks when a run would deploy something the lock does
+// not pin, or pins differently.
+var ErrOutOfDate = errors.New("ksail.lock is out of date")
+
+// Mode controls how a Lock treats pins that are missing or differ.
+type Mode int
+
+const (
+	// ModeRecord installs the locked versions and pins whatever is not locked yet.
+	ModeRecord Mode = iota
+	// ModeFrozen fails instead of changing the lock.
+	ModeFrozen
+	// ModeUpdate ignores the locked versions and replaces every pin it resolves.
+	ModeUpdate
+)
+
+// Chart pins a chart version and the digest of its archive.
+type Chart struct {
+	Version string `json:"version"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+// File is the content of a lock file. Charts are keyed by their source, such as
+// "https://helm.cilium.io/cilium" or "oci://ghcr.io/org/charts/app"; images by reference.
+type File struct {
+	NodeImage string            `json:"nodeImage,omitempty"`
+	Charts    map[string]Chart  `json:"charts,omitempty"`
+	Images    map[string]string `json:"images,omitempty"`
+}
+
+// Lock is a file-backed set of pins. It loads lazily on first use and is safe for concurrent
+// use. Every change is written to the file immediately.
+type Lock struct {
+	path   string
+	mode   Mode
+	mutex  sync.Mutex
+	loaded bool
+	file   File
+}
+
+// New creates a Lock backed by the file at path.
+func New(path string, mode Mode) *Lock {
+	return &Lock{path: path, mode: mode}
+}
+
+// Mode returns how the lock treats missing and differing pins.
+func (l *Lock) Mode() Mode {
+	return l.mode
+}
+
+// File returns a copy of the pins.
+func (l *Lock) File() (File, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		NodeImage: l.file.NodeImage,
+		Charts:    maps.Clone(l.file.Charts),
+		Images:    maps.Clone(l.file.Images),
+	}, nil
+}
+
+// ChartVersion returns the version of the chart from source to install, given the version
+// the caller requested, which is empty for the newest one. Locked versions win over an empty
+// request; frozen locks reject requests for other or unlocked versions.
+func (l *Lock) ChartVersion(source, requested string) (string, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return "", err
+	}
+
+	if l.mode == ModeUpdate {
+		return requested, nil
+	}
+
+	pin, ok := l.file.Charts[source]
+
+	switch {
+	case ok && (requested == "" || requested == pin.Version):
+		return pin.Version, nil
+	case l.mode != ModeFrozen:
+		return requested, nil
+	case ok:
+		return "", fmt.Errorf("%w: chart %s is locked at %s, %s requested", ErrOutOfDate, source, pin.Version, requested)
+	default:
+		return "", fmt.Errorf("%w: chart %s is not locked", ErrOutOfDate, source)
+	}
+}
+
+// RecordChart pins the version and archive digest the chart from source resolved to.
+func (l *Lock) RecordChart(source, version, digest string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return err
+	}
+
+	pin := Chart{Version: version, Digest: digest}
+
+	locked, ok := l.file.Charts[source]
+	if ok && locked == pin {
+		return nil
+	}
+
+	if l.mode == ModeFrozen {
+		return fmt.Errorf("%w: chart %s resolved to %s (%s), locked %s",
+			ErrOutOfDate, source, version, digest, describeChart(locked, ok))
+	}
+
+	if l.file.Charts == nil {
+		l.file.Charts = map[string]Chart{}
+	}
+
+	l.file.Charts[source] = pin
+
+	return l.save()
+}
+
+// Image returns the digest pinned for the image reference, if any.
+func (l *Lock) Image(ref string) (string, bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return "", false, err
+	}
+
+	digest, ok := l.file.Images[ref]
+
+	return digest, ok, nil
+}
+
+// RecordImage pins the digest the image reference resolved to.
+func (l *Lock) RecordImage(ref, digest string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return err
+	}
+
+	locked, ok := l.file.Images[ref]
+	if ok && locked == digest {
+		return nil
+	}
+
+	if l.mode == ModeFrozen {
+		return fmt.Errorf("%w: image %s resolved to %s, locked %s",
+			ErrOutOfDate, ref, digest, describe(locked, ok))
+	}
+
+	if l.file.Images == nil {
+		l.file.Images = map[string]string{}
+	}
+
+	l.file.Images[ref] = digest
+
+	return l.save()
+}
+
+// RecordNodeImage pins the image cluster nodes are created from.
+func (l *Lock) RecordNodeImage(image string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err := l.load()
+	if err != nil {
+		return err
+	}
+
+	if l.file.NodeImage == image {
+		return nil
+	}
+
+	if l.mode == ModeFrozen {
+		return fmt.Errorf("%w: node image %s, locked %s",
+			ErrOutOfDate, image, describe(l.file.NodeImage, l.file.NodeImage != ""))
+	}
+
+	l.file.NodeImage = image
+
+	return l.save()
+}
+
+func describeChart(pin Chart, ok bool) string {
+	if !ok {
+		return describe("", false)
+	}
+
+	return fmt.Sprintf("%s (%s)", pin.Version, pin.Digest)
+}
+
+func describe(value string, ok bool) string {
+	if !ok {
+		return "nothing"
+	}
+
+	return value
+}
+
+func (l *Lock) save() error {
+	data, err := yaml.Marshal(l.file)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(l.path), lockDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create lock file directory: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(l.path, append([]byte(header), data...), lockFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	return nil
+}
+
+func (l *Lock) load() error {
+	if l.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(l.path) // #nosec G304 -- path is the project's lock file
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var file File
+
+	if len(data) > 0 {
+		err = yaml.UnmarshalStrict(data, &file)
+		if err != nil {
+			return fmt.Errorf("failed to parse lock file %s: %w", l.path, err)
+		}
+	}
+
+	l.file = file
+	l.loaded = true
+
+	return nil
+}
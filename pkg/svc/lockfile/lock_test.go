@@ -0,0 +1,124 @@
+package lockfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/lockfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ciliumChart = "https://helm.cilium.io/cilium"
+	ciliumImage = "quay.io/cilium/cilium:v1.18.4"
+	nodeImage   = "kindest/node:v1.34.0"
+	digestOne   = "sha256:1111"
+	digestTwo   = "sha256:2222"
+)
+
+func TestLockRecordsAndReusesPins(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.lock")
+	lock := lockfile.New(path, lockfile.ModeRecord)
+
+	version, err := lock.ChartVersion(ciliumChart, "")
+	require.NoError(t, err)
+	assert.Empty(t, version, "unlocked charts resolve to the newest version")
+
+	require.NoError(t, lock.RecordChart(ciliumChart, "1.18.4", digestOne))
+	require.NoError(t, lock.RecordImage(ciliumImage, digestOne))
+	require.NoError(t, lock.RecordNodeImage(nodeImage))
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Generated by ksail."))
+
+	reloaded := lockfile.New(path, lockfile.ModeRecord)
+
+	version, err = reloaded.ChartVersion(ciliumChart, "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.18.4", version)
+
+	version, err = reloaded.ChartVersion(ciliumChart, "1.19.0")
+	require.NoError(t, err)
+	assert.Equal(t, "1.19.0", version, "explicit versions win outside frozen mode")
+
+	digest, ok, err := reloaded.Image(ciliumImage)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, digestOne, digest)
+
+	file, err := reloaded.File()
+	require.NoError(t, err)
+	assert.Equal(t, nodeImage, file.NodeImage)
+	assert.Equal(t, lockfile.Chart{Version: "1.18.4", Digest: digestOne}, file.Charts[ciliumChart])
+}
+
+func TestFrozenLockRejectsChanges(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.lock")
+
+	lock := lockfile.New(path, lockfile.ModeRecord)
+	require.NoError(t, lock.RecordChart(ciliumChart, "1.18.4", digestOne))
+	require.NoError(t, lock.RecordImage(ciliumImage, digestOne))
+	require.NoError(t, lock.RecordNodeImage(nodeImage))
+
+	frozen := lockfile.New(path, lockfile.ModeFrozen)
+
+	version, err := frozen.ChartVersion(ciliumChart, "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.18.4", version)
+
+	require.NoError(t, frozen.RecordChart(ciliumChart, "1.18.4", digestOne))
+	require.NoError(t, frozen.RecordImage(ciliumImage, digestOne))
+	require.NoError(t, frozen.RecordNodeImage(nodeImage))
+
+	_, err = frozen.ChartVersion(ciliumChart, "1.19.0")
+	require.ErrorIs(t, err, lockfile.ErrOutOfDate)
+
+	_, err = frozen.ChartVersion("https://dl.gitea.com/charts/gitea", "")
+	require.ErrorIs(t, err, lockfile.ErrOutOfDate)
+
+	require.ErrorIs(t, frozen.RecordChart(ciliumChart, "1.18.4", digestTwo), lockfile.ErrOutOfDate)
+	require.ErrorIs(t, frozen.RecordImage(ciliumImage, digestTwo), lockfile.ErrOutOfDate)
+	require.ErrorIs(t, frozen.RecordImage("docker.io/calico/node:v3.30.4", digestOne), lockfile.ErrOutOfDate)
+	require.ErrorIs(t, frozen.RecordNodeImage("kindest/node:v1.35.0"), lockfile.ErrOutOfDate)
+
+	file, err := lockfile.New(path, lockfile.ModeRecord).File()
+	require.NoError(t, err)
+	assert.Equal(t, digestOne, file.Images[ciliumImage], "frozen locks never write")
+}
+
+func TestUpdateLockIgnoresLockedVersions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.lock")
+	require.NoError(t, lockfile.New(path, lockfile.ModeRecord).RecordChart(ciliumChart, "1.18.4", digestOne))
+
+	update := lockfile.New(path, lockfile.ModeUpdate)
+
+	version, err := update.ChartVersion(ciliumChart, "")
+	require.NoError(t, err)
+	assert.Empty(t, version)
+
+	require.NoError(t, update.RecordChart(ciliumChart, "1.19.0", digestTwo))
+
+	version, err = lockfile.New(path, lockfile.ModeFrozen).ChartVersion(ciliumChart, "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.19.0", version)
+}
+
+func TestLockRejectsUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ksail.lock")
+	require.NoError(t, os.WriteFile(path, []byte("nodeImages: []\n"), 0o600))
+
+	_, err := lockfile.New(path, lockfile.ModeRecord).File()
+	require.Error(t, err)
+}
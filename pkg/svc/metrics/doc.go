@@ -0,0 +1,7 @@
+// Package metrics exposes Prometheus metrics for `ksail workload sync`, KSail's long-running
+// inner-loop mode.
+//
+// A Recorder counts applies and observes reconcile durations and readiness wait times.
+// Serve publishes them on a localhost /metrics endpoint so the inner development loop can be
+// graphed. All Recorder methods are safe to call on a nil Recorder, which records nothing.
+package metrics
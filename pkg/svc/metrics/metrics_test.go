@@ -0,0 +1,75 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errApply = errors.New("apply failed")
+
+func TestServeExposesRecordedMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recorder := metrics.NewRecorder()
+	recorder.ObserveApply("workload sync", nil)
+	recorder.ObserveApply("workload sync", nil)
+	recorder.ObserveApply("workload sync", errApply)
+	recorder.ObserveReconcile("workload sync", 120*time.Millisecond)
+	recorder.ObserveReadinessWait("workload sync", 2*time.Second)
+
+	addr, err := metrics.Serve(ctx, ":0", recorder)
+	require.NoError(t, err)
+	assert.Contains(t, addr, "127.0.0.1:")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+metrics.MetricsPath, nil)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `ksail_applies_total{command="workload sync",result="success"} 2`)
+	assert.Contains(t, string(body), `ksail_applies_total{command="workload sync",result="error"} 1`)
+	assert.Contains(t, string(body), `ksail_reconcile_duration_seconds_count{command="workload sync"} 1`)
+	assert.Contains(t, string(body), `ksail_readiness_wait_seconds_sum{command="workload sync"} 2`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestServeRejectsNonLoopbackAddresses(t *testing.T) {
+	t.Parallel()
+
+	for _, addr := range []string{"0.0.0.0:9090", "example.com:9090", "9090"} {
+		_, err := metrics.Serve(context.Background(), addr, metrics.NewRecorder())
+		require.Error(t, err, addr)
+	}
+
+	_, err := metrics.Serve(context.Background(), "0.0.0.0:9090", metrics.NewRecorder())
+	require.ErrorIs(t, err, metrics.ErrNonLocalAddress)
+}
+
+func TestNilRecorderIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var recorder *metrics.Recorder
+
+	assert.NotPanics(t, func() {
+		recorder.ObserveApply("workload sync", nil)
+		recorder.ObserveReconcile("workload sync", time.Second)
+		recorder.ObserveReadinessWait("workload sync", time.Second)
+	})
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "ksail"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// Recorder collects the metrics of one KSail process.
+type Recorder struct {
+	registry         *prometheus.Registry
+	applies          *prometheus.CounterVec
+	reconcileSeconds *prometheus.HistogramVec
+	readinessSeconds *prometheus.HistogramVec
+}
+
+// NewRecorder creates a Recorder with its own registry, including Go runtime and process metrics.
+func NewRecorder() *Recorder {
+	recorder := &Recorder{
+		registry: prometheus.NewRegistry(),
+		applies: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "applies_total",
+			Help:      "Number of changes applied to the cluster, by command and result.",
+		}, []string{"command", "result"}),
+		reconcileSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Time taken to reconcile local changes with the cluster.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+		}, []string{"command"}),
+		readinessSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "readiness_wait_seconds",
+			Help:      "Time spent finding the running resources a change is reconciled with.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		}, []string{"command"}),
+	}
+
+	recorder.registry.MustRegister(
+		recorder.applies,
+		recorder.reconcileSeconds,
+		recorder.readinessSeconds,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return recorder
+}
+
+// ObserveApply counts an apply made by command, labelled by whether err is nil.
+func (r *Recorder) ObserveApply(command string, err error) {
+	if r == nil {
+		return
+	}
+
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+
+	r.applies.WithLabelValues(command, result).Inc()
+}
+
+// ObserveReconcile records how long command took to reconcile a change.
+func (r *Recorder) ObserveReconcile(command string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.reconcileSeconds.WithLabelValues(command).Observe(duration.Seconds())
+}
+
+// ObserveReadinessWait records how long command spent finding the running resources it
+// reconciles a change with, such as the pods workload sync copies files into.
+func (r *Recorder) ObserveReadinessWait(command string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+
+	r.readinessSeconds.WithLabelValues(command).Observe(duration.Seconds())
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus exposition format.
+func (r *Recorder) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
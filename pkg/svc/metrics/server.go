@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	// MetricsPath is the HTTP path metrics are served on.
+	MetricsPath = "/metrics"
+
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// ErrNonLocalAddress is returned when the metrics endpoint would listen beyond localhost.
+var ErrNonLocalAddress = errors.New("metrics address must be a loopback address")
+
+// Serve starts serving recorder on addr until ctx is done and returns the address it listens on.
+//
+// A bare port such as ":9090" listens on 127.0.0.1. Only loopback hosts are accepted, since the
+// endpoint is unauthenticated.
+func Serve(ctx context.Context, addr string, recorder *Recorder) (string, error) {
+	addr, err := loopbackAddress(addr)
+	if err != nil {
+		return "", err
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, recorder.Handler())
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	return listener.Addr().String(), nil
+}
+
+func loopbackAddress(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid metrics address %q: %w", addr, err)
+	}
+
+	if host == "" {
+		return net.JoinHostPort("127.0.0.1", port), nil
+	}
+
+	if host == "localhost" {
+		return addr, nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsLoopback() {
+		return "", fmt.Errorf("%w: %q", ErrNonLocalAddress, addr)
+	}
+
+	return addr, nil
+}
@@ -0,0 +1,8 @@
+// Package offline forbids network egress for runs that must not download anything.
+//
+// A context marked with WithOffline carries the offline mode to the code that would reach
+// the network, such as Helm repository fetches, upstream registry pulls and remote
+// manifests. Those call Guard before connecting, which fails fast with ErrNetworkDisabled
+// and a description of what the run needed, so air-gapped users and deterministic CI runs
+// can trust that nothing is downloaded silently.
+package offline
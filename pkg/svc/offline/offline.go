@@ -0,0 +1,69 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ErrNetworkDisabled is returned when an offline run needs the network.
+var ErrNetworkDisabled = errors.New("network access is disabled in offline mode")
+
+type offlineKey struct{}
+
+// WithOffline returns a context that marks the run as offline.
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
+// Enabled reports whether ctx marks the run as offline.
+func Enabled(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	enabled, _ := ctx.Value(offlineKey{}).(bool)
+
+	return enabled
+}
+
+// Guard returns ErrNetworkDisabled, naming what needed the network, when ctx marks the run as
+// offline. The description completes "cannot ...", e.g. "download chart cilium".
+func Guard(ctx context.Context, format string, args ...any) error {
+	if !Enabled(ctx) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot %s", ErrNetworkDisabled, fmt.Sprintf(format, args...))
+}
+
+// GuardURL is Guard for a step that fetches location. Locations that are not URLs, such as
+// file paths, and URLs of the local host are allowed.
+func GuardURL(ctx context.Context, location, format string, args ...any) error {
+	if !Enabled(ctx) || !IsRemote(location) {
+		return nil
+	}
+
+	return Guard(ctx, format, args...)
+}
+
+// IsRemote reports whether location is a URL of another host, which is fetched over the
+// network rather than read from disk or the local host.
+func IsRemote(location string) bool {
+	parsed, err := url.Parse(location)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return false
+	}
+
+	host := parsed.Hostname()
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip == nil || !ip.IsLoopback()
+}
@@ -0,0 +1,47 @@
+package offline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardOnlyFailsOfflineRuns(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, offline.Guard(context.Background(), "download chart %s", "cilium"))
+
+	ctx := offline.WithOffline(context.Background())
+	assert.True(t, offline.Enabled(ctx))
+
+	err := offline.Guard(ctx, "download chart %s", "cilium")
+	require.ErrorIs(t, err, offline.ErrNetworkDisabled)
+	assert.Contains(t, err.Error(), "cannot download chart cilium")
+}
+
+func TestGuardURLAllowsLocalLocations(t *testing.T) {
+	t.Parallel()
+
+	ctx := offline.WithOffline(context.Background())
+
+	for _, location := range []string{
+		"k8s/deployment.yaml",
+		"/tmp/charts/app",
+		"http://localhost:5000/charts",
+		"http://127.0.0.1:8080/index.yaml",
+		"oci://registry.localhost/charts/app",
+	} {
+		require.NoError(t, offline.GuardURL(ctx, location, "fetch %s", location), location)
+	}
+
+	for _, location := range []string{
+		"https://helm.cilium.io",
+		"oci://ghcr.io/org/charts/app",
+		"https://raw.githubusercontent.com/org/repo/main/app.yaml",
+	} {
+		require.ErrorIs(t, offline.GuardURL(ctx, location, "fetch %s", location), offline.ErrNetworkDisabled, location)
+	}
+}
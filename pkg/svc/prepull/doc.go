@@ -0,0 +1,7 @@
+// Package prepull warms the mirror registries with the images of declared cluster components.
+//
+// While the cluster nodes boot, a PrePuller fetches the images that the CNI, ingress and
+// GitOps engine installers will need through the local pull-through mirror registries. The
+// nodes pull through the same mirrors, so by the time the components are installed their
+// images are served from the local cache instead of the upstream registry.
+package prepull
@@ -0,0 +1,97 @@
+package prepull
+
+import (
+	"slices"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+)
+
+// Component images follow the defaults of the charts KSail installs. A stale tag only costs
+// a wasted pull, so the lists are refreshed together with the installers rather than pinned
+// to them.
+//
+//nolint:gochecknoglobals // Static image catalog shared by ComponentImages.
+var (
+	ciliumImages = []string{
+		"quay.io/cilium/cilium:v1.18.4",
+		"quay.io/cilium/operator-generic:v1.18.4",
+	}
+	calicoImages = []string{
+		"quay.io/tigera/operator:v1.38.7",
+		"docker.io/calico/node:v3.30.4",
+		"docker.io/calico/cni:v3.30.4",
+		"docker.io/calico/kube-controllers:v3.30.4",
+		"docker.io/calico/typha:v3.30.4",
+		"docker.io/calico/pod2daemon-flexvol:v3.30.4",
+		"docker.io/calico/csi:v3.30.4",
+		"docker.io/calico/node-driver-registrar:v3.30.4",
+	}
+	metricsServerImages = []string{
+		"registry.k8s.io/metrics-server/metrics-server:v0.8.0",
+	}
+	metalLBImages = []string{
+		"quay.io/metallb/controller:v0.15.2",
+		"quay.io/metallb/speaker:v0.15.2",
+	}
+	kyvernoImages = []string{
+		"reg.kyverno.io/kyverno/kyverno:v1.15.2",
+		"reg.kyverno.io/kyverno/kyvernopre:v1.15.2",
+		"reg.kyverno.io/kyverno/background-controller:v1.15.2",
+		"reg.kyverno.io/kyverno/cleanup-controller:v1.15.2",
+		"reg.kyverno.io/kyverno/reports-controller:v1.15.2",
+	}
+	k3sIngressImages = []string{
+		"docker.io/rancher/mirrored-library-traefik:3.3.6",
+	}
+	fluxImages = []string{
+		"ghcr.io/controlplaneio-fluxcd/flux-operator:v0.33.0",
+		"ghcr.io/fluxcd/source-controller:v1.7.3",
+		"ghcr.io/fluxcd/kustomize-controller:v1.7.2",
+		"ghcr.io/fluxcd/helm-controller:v1.4.3",
+		"ghcr.io/fluxcd/notification-controller:v1.7.4",
+	}
+)
+
+// ComponentImages returns the images required by the components declared in the cluster
+// configuration, in install order and without duplicates.
+func ComponentImages(clusterCfg *v1alpha1.Cluster) []string {
+	// Simulated clusters never run the components, so there is nothing to pull
+	if clusterCfg == nil || clusterCfg.Spec.Distribution.IsSimulated() {
+		return nil
+	}
+
+	spec := clusterCfg.Spec
+
+	var images []string
+
+	switch spec.CNI {
+	case v1alpha1.CNICilium:
+		images = append(images, ciliumImages...)
+	case v1alpha1.CNICalico:
+		images = append(images, calicoImages...)
+	case v1alpha1.CNIDefault:
+	}
+
+	if spec.MetricsServer == v1alpha1.MetricsServerEnabled &&
+		!spec.Distribution.ProvidesMetricsServerByDefault() {
+		images = append(images, metricsServerImages...)
+	}
+
+	if spec.Options.MetalLB.Enabled {
+		images = append(images, metalLBImages...)
+	}
+
+	if spec.Distribution == v1alpha1.DistributionK3d {
+		images = append(images, k3sIngressImages...)
+	}
+
+	if spec.Options.Kyverno.Enabled {
+		images = append(images, kyvernoImages...)
+	}
+
+	if spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
+		images = append(images, fluxImages...)
+	}
+
+	return slices.Compact(images)
+}
@@ -0,0 +1,330 @@
+package prepull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	goruntime "runtime"
+	"strings"
+	"sync"
+
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// DefaultConcurrency is the number of images fetched at the same time.
+const DefaultConcurrency = 4
+
+// dockerHubRegistry is the registry name go-containerregistry resolves docker.io images to.
+const dockerHubRegistry = "index.docker.io"
+
+// ErrNoMirror is returned for images whose registry has no local mirror.
+var ErrNoMirror = errors.New("no mirror registry for image")
+
+// Mirror is a local pull-through registry reachable from the host.
+type Mirror struct {
+	// Host is the upstream registry host the mirror caches, e.g. docker.io.
+	Host string
+	// Endpoint is the host:port the mirror listens on, e.g. 127.0.0.1:5001.
+	Endpoint string
+}
+
+// Fetcher fetches an image by reference so the registry serving it caches every blob.
+type Fetcher func(ctx context.Context, ref string) error
+
+// Result reports the outcome of a pre-pull run.
+type Result struct {
+	// Pulled lists the images fetched through a mirror.
+	Pulled []string
+	// Skipped lists the images whose registry has no mirror.
+	Skipped []string
+	// Failed maps images that could not be fetched to their error.
+	Failed map[string]error
+}
+
+// PrePuller fetches component images through mirror registries concurrently.
+type PrePuller struct {
+	fetch       Fetcher
+	concurrency int
+}
+
+// Option configures a PrePuller.
+type Option func(*PrePuller)
+
+// WithFetcher replaces the fetcher, primarily for tests.
+func WithFetcher(fetch Fetcher) Option {
+	return func(p *PrePuller) {
+		if fetch != nil {
+			p.fetch = fetch
+		}
+	}
+}
+
+// WithConcurrency sets how many images are fetched at the same time.
+func WithConcurrency(concurrency int) Option {
+	return func(p *PrePuller) {
+		if concurrency > 0 {
+			p.concurrency = concurrency
+		}
+	}
+}
+
+// NewPrePuller creates a PrePuller that fetches images over plain HTTP from the mirrors.
+func NewPrePuller(opts ...Option) *PrePuller {
+	puller := &PrePuller{
+		fetch:       fetchImage,
+		concurrency: DefaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(puller)
+	}
+
+	return puller
+}
+
+// Run fetches every image through the mirror for its registry. Images without a mirror are
+// skipped, and fetch failures are collected rather than aborting the run.
+func (p *PrePuller) Run(ctx context.Context, images []string, mirrors []Mirror) Result {
+	result := Result{Failed: map[string]error{}}
+
+	var (
+		mutex   sync.Mutex
+		waiting sync.WaitGroup
+	)
+
+	slots := make(chan struct{}, p.concurrency)
+
+	for _, image := range images {
+		mirrorRef, err := MirrorReference(image, mirrors)
+		if err != nil {
+			if errors.Is(err, ErrNoMirror) {
+				result.Skipped = append(result.Skipped, image)
+			} else {
+				result.Failed[image] = err
+			}
+
+			continue
+		}
+
+		waiting.Add(1)
+
+		go func() {
+			defer waiting.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			fetchErr := p.fetch(ctx, mirrorRef)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if fetchErr != nil {
+				result.Failed[image] = fetchErr
+
+				return
+			}
+
+			result.Pulled = append(result.Pulled, image)
+		}()
+	}
+
+	waiting.Wait()
+
+	return result
+}
+
+// MirrorReference rewrites image to be pulled from the mirror caching its registry.
+func MirrorReference(image string, mirrors []Mirror) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %w", image, err)
+	}
+
+	host := ref.Context().RegistryStr()
+	if host == dockerHubRegistry {
+		host = "docker.io"
+	}
+
+	for _, mirror := range mirrors {
+		if !strings.EqualFold(mirror.Host, host) || mirror.Endpoint == "" {
+			continue
+		}
+
+		separator := ":"
+		if _, isDigest := ref.(name.Digest); isDigest {
+			separator = "@"
+		}
+
+		return mirror.Endpoint + "/" + ref.Context().RepositoryStr() + separator + ref.Identifier(), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrNoMirror, image)
+}
+
+// DiscoverMirrors finds the running mirror registries for hosts and the host ports they
+// publish. Mirrors are matched by container name, which KSail derives from the host with an
+// optional distribution prefix.
+func DiscoverMirrors(
+	ctx context.Context,
+	dockerClient client.APIClient,
+	hosts []string,
+) ([]Mirror, error) {
+	containers, err := dockerClient.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", dockerclient.RegistryLabelKey)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registry containers: %w", err)
+	}
+
+	mirrors := make([]Mirror, 0, len(hosts))
+
+	for _, host := range hosts {
+		sanitized := registry.SanitizeHostIdentifier(host)
+
+		for _, summary := range containers {
+			if !matchesMirrorName(summary.Names, sanitized) {
+				continue
+			}
+
+			port := publishedRegistryPort(summary)
+			if port == 0 {
+				continue
+			}
+
+			mirrors = append(mirrors, Mirror{
+				Host:     host,
+				Endpoint: fmt.Sprintf("%s:%d", dockerclient.RegistryHostIP, port),
+			})
+
+			break
+		}
+	}
+
+	return mirrors, nil
+}
+
+// RegistryHosts returns the distinct registry hosts of images.
+func RegistryHosts(images []string) []string {
+	seen := map[string]struct{}{}
+	hosts := make([]string, 0, len(images))
+
+	for _, image := range images {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			continue
+		}
+
+		host := ref.Context().RegistryStr()
+		if host == dockerHubRegistry {
+			host = "docker.io"
+		}
+
+		if _, ok := seen[host]; ok {
+			continue
+		}
+
+		seen[host] = struct{}{}
+		hosts = append(hosts, host)
+	}
+
+	return hosts
+}
+
+func matchesMirrorName(names []string, sanitizedHost string) bool {
+	for _, containerName := range names {
+		trimmed := strings.TrimPrefix(containerName, "/")
+		if trimmed == sanitizedHost || strings.HasSuffix(trimmed, "-"+sanitizedHost) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func publishedRegistryPort(summary container.Summary) int {
+	for _, port := range summary.Ports {
+		if port.PrivatePort == dockerclient.DefaultRegistryPort && port.PublicPort > 0 {
+			return int(port.PublicPort)
+		}
+	}
+
+	return 0
+}
+
+// fetchImage reads the manifest, config and layers of ref for the node platform, which
+// makes a pull-through registry cache all of them.
+func fetchImage(ctx context.Context, ref string) error {
+	parsed, err := name.ParseReference(ref, name.Insecure)
+	if err != nil {
+		return fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+
+	img, err := remote.Image(
+		parsed,
+		remote.WithContext(ctx),
+		remote.WithPlatform(v1.Platform{OS: "linux", Architecture: goruntime.GOARCH}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to fetch manifest for %s: %w", ref, err)
+	}
+
+	_, err = img.RawConfigFile()
+	if err != nil {
+		return fmt.Errorf("failed to fetch config for %s: %w", ref, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return fmt.Errorf("failed to list layers for %s: %w", ref, err)
+	}
+
+	for _, layer := range layers {
+		err = drainLayer(layer)
+		if err != nil {
+			return fmt.Errorf("failed to fetch layer for %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+func drainLayer(layer v1.Layer) error {
+	reader, err := layer.Compressed()
+	if err != nil {
+		return fmt.Errorf("open layer: %w", err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	_, err = io.Copy(io.Discard, reader)
+	if err != nil {
+		return fmt.Errorf("read layer: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveDigest returns the digest of the manifest ref points to in its registry, in
+// "sha256:<hex>" form, without fetching the image.
+func ResolveDigest(ctx context.Context, ref string) (string, error) {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+
+	descriptor, err := remote.Head(parsed, remote.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve digest of %s: %w", ref, err)
+	}
+
+	return descriptor.Digest.String(), nil
+}
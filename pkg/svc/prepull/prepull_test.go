@@ -0,0 +1,155 @@
+package prepull_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/svc/prepull"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestMirrorReference(t *testing.T) {
+	t.Parallel()
+
+	mirrors := []prepull.Mirror{
+		{Host: "docker.io", Endpoint: "127.0.0.1:5001"},
+		{Host: "ghcr.io", Endpoint: "127.0.0.1:5002"},
+	}
+
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{"docker hub short name", "nginx:1.27", "127.0.0.1:5001/library/nginx:1.27"},
+		{"docker hub explicit host", "docker.io/calico/node:v3.30.4", "127.0.0.1:5001/calico/node:v3.30.4"},
+		{"other registry", "ghcr.io/fluxcd/source-controller:v1.7.3", "127.0.0.1:5002/fluxcd/source-controller:v1.7.3"},
+		{
+			"digest",
+			"ghcr.io/fluxcd/helm-controller@sha256:" + testDigest,
+			"127.0.0.1:5002/fluxcd/helm-controller@sha256:" + testDigest,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := prepull.MirrorReference(testCase.image, mirrors)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestMirrorReferenceWithoutMirror(t *testing.T) {
+	t.Parallel()
+
+	_, err := prepull.MirrorReference("quay.io/cilium/cilium:v1.18.4", nil)
+
+	require.ErrorIs(t, err, prepull.ErrNoMirror)
+}
+
+func TestRunFetchesThroughMirrorsAndSkipsUnmirrored(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex   sync.Mutex
+		fetched []string
+	)
+
+	puller := prepull.NewPrePuller(prepull.WithFetcher(func(_ context.Context, ref string) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		fetched = append(fetched, ref)
+		if ref == "127.0.0.1:5002/fluxcd/helm-controller:v1.4.3" {
+			return assert.AnError
+		}
+
+		return nil
+	}))
+
+	result := puller.Run(context.Background(), []string{
+		"ghcr.io/fluxcd/source-controller:v1.7.3",
+		"ghcr.io/fluxcd/helm-controller:v1.4.3",
+		"quay.io/cilium/cilium:v1.18.4",
+	}, []prepull.Mirror{{Host: "ghcr.io", Endpoint: "127.0.0.1:5002"}})
+
+	assert.ElementsMatch(t, []string{
+		"127.0.0.1:5002/fluxcd/source-controller:v1.7.3",
+		"127.0.0.1:5002/fluxcd/helm-controller:v1.4.3",
+	}, fetched)
+	assert.Equal(t, []string{"ghcr.io/fluxcd/source-controller:v1.7.3"}, result.Pulled)
+	assert.Equal(t, []string{"quay.io/cilium/cilium:v1.18.4"}, result.Skipped)
+	require.ErrorIs(t, result.Failed["ghcr.io/fluxcd/helm-controller:v1.4.3"], assert.AnError)
+}
+
+func TestComponentImages(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Distribution = v1alpha1.DistributionKind
+	cluster.Spec.CNI = v1alpha1.CNICilium
+	cluster.Spec.MetricsServer = v1alpha1.MetricsServerEnabled
+	cluster.Spec.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+
+	images := prepull.ComponentImages(cluster)
+
+	assert.Contains(t, images, "quay.io/cilium/cilium:v1.18.4")
+	assert.Contains(t, images, "registry.k8s.io/metrics-server/metrics-server:v0.8.0")
+	assert.Contains(t, images, "ghcr.io/fluxcd/source-controller:v1.7.3")
+	assert.Equal(
+		t,
+		[]string{"quay.io", "registry.k8s.io", "ghcr.io"},
+		prepull.RegistryHosts(images),
+	)
+
+	cluster.Spec.CNI = v1alpha1.CNIDefault
+	cluster.Spec.GitOpsEngine = v1alpha1.GitOpsEngineNone
+	cluster.Spec.MetricsServer = v1alpha1.MetricsServerDisabled
+
+	assert.Empty(t, prepull.ComponentImages(cluster))
+}
+
+func TestDiscoverMirrors(t *testing.T) {
+	t.Parallel()
+
+	docker := dockerclient.NewMockAPIClient(t)
+	docker.EXPECT().
+		ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{
+			{
+				Names: []string{"/local-registry"},
+				Ports: []container.Port{{PrivatePort: 5000, PublicPort: 5000}},
+			},
+			{
+				Names: []string{"/k3d-docker.io"},
+				Ports: []container.Port{{PrivatePort: 5000, PublicPort: 5001}},
+			},
+			{
+				Names: []string{"/ghcr.io"},
+				Ports: []container.Port{{PrivatePort: 5000, PublicPort: 5002}},
+			},
+		}, nil)
+
+	mirrors, err := prepull.DiscoverMirrors(
+		context.Background(),
+		docker,
+		[]string{"docker.io", "ghcr.io", "quay.io"},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, []prepull.Mirror{
+		{Host: "docker.io", Endpoint: "127.0.0.1:5001"},
+		{Host: "ghcr.io", Endpoint: "127.0.0.1:5002"},
+	}, mirrors)
+}
@@ -0,0 +1,7 @@
+// Package progress records which steps of a multi-step command have completed.
+//
+// A Tracker keeps the completed steps in a JSON file under the project's .ksail directory,
+// tagged with a fingerprint of the inputs the steps were run with. Re-running the command
+// with the same inputs after a partial failure skips the recorded steps and continues from
+// the one that failed; changed inputs start over.
+package progress
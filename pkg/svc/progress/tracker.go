@@ -0,0 +1,123 @@
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ksailio "github.com/devantler-tech/ksail-go/pkg/io"
+)
+
+const (
+	stateDirPerm  = 0o750
+	stateFilePerm = 0o600
+)
+
+type trackerFile struct {
+	Fingerprint string   `json:"fingerprint"`
+	Completed   []string `json:"completed"`
+}
+
+// Tracker records completed steps for one set of inputs. It is safe for concurrent use.
+type Tracker struct {
+	path        string
+	fingerprint string
+	mutex       sync.Mutex
+	completed   []string
+}
+
+// Load reads the steps recorded at path for fingerprint. A missing file, unreadable JSON or a
+// different fingerprint yields a tracker with no completed steps.
+func Load(path, fingerprint string) (*Tracker, error) {
+	tracker := &Tracker{path: path, fingerprint: fingerprint}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the command's state file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return tracker, nil
+		}
+
+		return nil, fmt.Errorf("failed to read progress file: %w", err)
+	}
+
+	var file trackerFile
+
+	if json.Unmarshal(data, &file) == nil && file.Fingerprint == fingerprint {
+		tracker.completed = file.Completed
+	}
+
+	return tracker, nil
+}
+
+// Completed reports whether step was recorded as completed.
+func (t *Tracker) Completed(step string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, done := range t.completed {
+		if done == step {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resuming reports whether any step was recorded by a previous run.
+func (t *Tracker) Resuming() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.completed) > 0
+}
+
+// Complete records step as completed and saves the file.
+func (t *Tracker) Complete(step string) error {
+	if t.Completed(step) {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.completed = append(t.completed, step)
+
+	data, err := json.MarshalIndent(
+		trackerFile{Fingerprint: t.fingerprint, Completed: t.completed},
+		"",
+		"  ",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to encode progress: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(t.path), stateDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create progress directory: %w", err)
+	}
+
+	err = ksailio.WriteFileAtomic(t.path, append(data, '\n'), stateFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write progress file: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets all completed steps and removes the file.
+func (t *Tracker) Reset() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.completed = nil
+
+	err := os.Remove(t.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove progress file: %w", err)
+	}
+
+	return nil
+}
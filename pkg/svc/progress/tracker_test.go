@@ -0,0 +1,71 @@
+package progress_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerRemembersCompletedSteps(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", "progress.json")
+
+	tracker, err := progress.Load(path, "config-a")
+	require.NoError(t, err)
+	assert.False(t, tracker.Resuming())
+
+	require.NoError(t, tracker.Complete("cluster"))
+	require.NoError(t, tracker.Complete("cluster"))
+
+	reloaded, err := progress.Load(path, "config-a")
+	require.NoError(t, err)
+	assert.True(t, reloaded.Resuming())
+	assert.True(t, reloaded.Completed("cluster"))
+	assert.False(t, reloaded.Completed("cni"))
+}
+
+func TestTrackerIgnoresOtherFingerprints(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "progress.json")
+
+	tracker, err := progress.Load(path, "config-a")
+	require.NoError(t, err)
+	require.NoError(t, tracker.Complete("cluster"))
+
+	other, err := progress.Load(path, "config-b")
+	require.NoError(t, err)
+	assert.False(t, other.Resuming())
+	assert.False(t, other.Completed("cluster"))
+}
+
+func TestTrackerIgnoresCorruptFiles(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "progress.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	tracker, err := progress.Load(path, "config-a")
+	require.NoError(t, err)
+	assert.False(t, tracker.Resuming())
+}
+
+func TestTrackerReset(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "progress.json")
+
+	tracker, err := progress.Load(path, "config-a")
+	require.NoError(t, err)
+	require.NoError(t, tracker.Complete("cluster"))
+	require.NoError(t, tracker.Reset())
+	require.NoError(t, tracker.Reset())
+
+	assert.False(t, tracker.Completed("cluster"))
+	assert.NoFileExists(t, path)
+}
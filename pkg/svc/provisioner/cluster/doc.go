@@ -2,6 +2,7 @@
 // for provisioning clusters in different providers.
 //
 // This package contains the core provisioner interface, factory for creating
-// provider-specific provisioners, and implementations for Kind and K3d cluster
-// lifecycle management (create, delete, start, stop, list, exists).
+// provider-specific provisioners, and implementations for Kind, K3d, Talos, k0s and simulated kwok cluster
+// lifecycle management (create, delete, start, stop, list, exists), as well as for external
+// clusters KSail attaches to without managing their lifecycle.
 package clusterprovisioner
@@ -0,0 +1,8 @@
+// Package externalprovisioner provides the ClusterProvisioner implementation for clusters KSail
+// attaches to instead of provisioning, such as shared remote development clusters.
+//
+// The cluster is identified by a context in an existing kubeconfig. Creating the cluster only
+// verifies that the context exists; deleting, starting and stopping it are no-ops, so the
+// installers and workload commands run against the cluster without KSail ever changing its
+// lifecycle.
+package externalprovisioner
@@ -0,0 +1,149 @@
+package externalprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	iopath "github.com/devantler-tech/ksail-go/pkg/io"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ErrContextNotFound is returned when the kubeconfig has no context for the cluster.
+var ErrContextNotFound = errors.New("kube context not found")
+
+// Config identifies the existing cluster KSail attaches to.
+type Config struct {
+	// Name is how KSail refers to the cluster; it defaults to the kube context.
+	Name string
+	// Kubeconfig is the path of the kubeconfig that holds the context.
+	Kubeconfig string
+	// Context is the kube context of the cluster.
+	Context string
+}
+
+// LoadConfig resolves the cluster in the kubeconfig at kubeconfigPath. An empty contextName
+// selects the current context of the kubeconfig and an empty clusterName names the cluster
+// after its context. A missing kubeconfig is not an error; Create reports it.
+func LoadConfig(kubeconfigPath, contextName, clusterName string) (*Config, error) {
+	path, kubeconfig, err := loadKubeconfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if contextName == "" {
+		contextName = kubeconfig.CurrentContext
+	}
+
+	if clusterName == "" {
+		clusterName = contextName
+	}
+
+	return &Config{Name: clusterName, Kubeconfig: path, Context: contextName}, nil
+}
+
+// ExternalClusterProvisioner attaches to an existing cluster through its kube context.
+type ExternalClusterProvisioner struct {
+	config *Config
+}
+
+// NewExternalClusterProvisioner constructs a provisioner for the cluster described by config.
+func NewExternalClusterProvisioner(config *Config) *ExternalClusterProvisioner {
+	if config == nil {
+		config = &Config{}
+	}
+
+	return &ExternalClusterProvisioner{config: config}
+}
+
+// Create verifies that the kube context of the cluster exists. The cluster is not changed.
+func (e *ExternalClusterProvisioner) Create(_ context.Context, _ string) error {
+	exists, err := e.contextExists()
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: '%s' in %s", ErrContextNotFound, e.config.Context, e.config.Kubeconfig)
+	}
+
+	return nil
+}
+
+// Delete is a no-op; KSail does not own the lifecycle of external clusters.
+func (e *ExternalClusterProvisioner) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+// Start is a no-op; KSail does not own the lifecycle of external clusters.
+func (e *ExternalClusterProvisioner) Start(_ context.Context, _ string) error {
+	return nil
+}
+
+// Stop is a no-op; KSail does not own the lifecycle of external clusters.
+func (e *ExternalClusterProvisioner) Stop(_ context.Context, _ string) error {
+	return nil
+}
+
+// List returns the name of the cluster when its kube context exists.
+func (e *ExternalClusterProvisioner) List(_ context.Context) ([]string, error) {
+	exists, err := e.contextExists()
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	return []string{e.config.Name}, nil
+}
+
+// Exists returns whether the kube context of the cluster exists. Names other than the
+// cluster's never exist.
+func (e *ExternalClusterProvisioner) Exists(_ context.Context, name string) (bool, error) {
+	if name != "" && name != e.config.Name {
+		return false, nil
+	}
+
+	return e.contextExists()
+}
+
+func (e *ExternalClusterProvisioner) contextExists() (bool, error) {
+	if e.config.Context == "" {
+		return false, nil
+	}
+
+	_, kubeconfig, err := loadKubeconfig(e.config.Kubeconfig)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := kubeconfig.Contexts[e.config.Context]
+
+	return ok, nil
+}
+
+func loadKubeconfig(path string) (string, *clientcmdapi.Config, error) {
+	if path == "" {
+		path = clientcmd.RecommendedHomeFile
+	}
+
+	path, err := iopath.ExpandHomePath(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expand kubeconfig path: %w", err)
+	}
+
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, clientcmdapi.NewConfig(), nil
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	return path, kubeconfig, nil
+}
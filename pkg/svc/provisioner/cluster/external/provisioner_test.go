@@ -0,0 +1,103 @@
+package externalprovisioner_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	externalprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: shared
+  cluster:
+    server: https://dev.example.com:6443
+users:
+- name: shared
+  user:
+    token: secret
+contexts:
+- name: shared-dev
+  context:
+    cluster: shared
+    user: shared
+current-context: shared-dev
+`
+
+func writeKubeconfig(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+
+	return path
+}
+
+func TestLoadConfigDefaultsToCurrentContext(t *testing.T) {
+	t.Parallel()
+
+	path := writeKubeconfig(t)
+
+	config, err := externalprovisioner.LoadConfig(path, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, &externalprovisioner.Config{Name: "shared-dev", Kubeconfig: path, Context: "shared-dev"}, config)
+
+	config, err = externalprovisioner.LoadConfig(path, "shared-dev", "team")
+	require.NoError(t, err)
+	assert.Equal(t, "team", config.Name)
+}
+
+func TestLifecycleLeavesTheClusterAlone(t *testing.T) {
+	t.Parallel()
+
+	path := writeKubeconfig(t)
+
+	config, err := externalprovisioner.LoadConfig(path, "shared-dev", "")
+	require.NoError(t, err)
+
+	provisioner := externalprovisioner.NewExternalClusterProvisioner(config)
+	ctx := context.Background()
+
+	require.NoError(t, provisioner.Create(ctx, ""))
+	require.NoError(t, provisioner.Stop(ctx, ""))
+	require.NoError(t, provisioner.Start(ctx, ""))
+	require.NoError(t, provisioner.Delete(ctx, ""))
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfig, string(data))
+
+	clusters, err := provisioner.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shared-dev"}, clusters)
+
+	exists, err := provisioner.Exists(ctx, "shared-dev")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = provisioner.Exists(ctx, "other")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCreateFailsWithoutContext(t *testing.T) {
+	t.Parallel()
+
+	config, err := externalprovisioner.LoadConfig(writeKubeconfig(t), "missing", "")
+	require.NoError(t, err)
+
+	err = externalprovisioner.NewExternalClusterProvisioner(config).Create(context.Background(), "")
+	require.ErrorIs(t, err, externalprovisioner.ErrContextNotFound)
+
+	config, err = externalprovisioner.LoadConfig(filepath.Join(t.TempDir(), "absent"), "", "")
+	require.NoError(t, err)
+
+	clusters, err := externalprovisioner.NewExternalClusterProvisioner(config).List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
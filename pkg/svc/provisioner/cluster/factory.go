@@ -6,10 +6,18 @@ import (
 	"fmt"
 
 	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	configmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager"
+	k0sconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k0s"
 	k3dconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/k3d"
 	kindconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kind"
+	kwokconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/kwok"
+	"github.com/devantler-tech/ksail-go/pkg/io/wsl"
+	externalprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/external"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
 	k3dprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k3d"
 	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
 	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
@@ -27,7 +35,9 @@ type Factory interface {
 // DefaultFactory implements Factory using the existing CreateClusterProvisioner helper.
 type DefaultFactory struct{}
 
-// Create selects the correct distribution provisioner for the KSail cluster configuration.
+// Create selects the correct distribution provisioner for the KSail cluster configuration and
+// applies the node counts, node customizations, host mounts and Docker network of the KSail
+// configuration to the distribution configuration.
 func (DefaultFactory) Create(
 	_ context.Context,
 	cluster *v1alpha1.Cluster,
@@ -39,15 +49,58 @@ func (DefaultFactory) Create(
 		)
 	}
 
+	provisioner, distributionConfig, err := createProvisioner(cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configmanager.ApplyNodeCounts(distributionConfig, cluster.Spec.ControlPlaneNodes, cluster.Spec.WorkerNodes)
+	configmanager.ApplyNodes(distributionConfig, cluster.Spec.Nodes)
+	configmanager.ApplyMounts(distributionConfig, cluster.Spec.Mounts)
+
+	dockerNetwork := cluster.Spec.Networking.DockerNetwork
+	configmanager.ApplyDockerNetwork(distributionConfig, dockerNetwork.Name, dockerNetwork.Subnet)
+
+	return provisioner, distributionConfig, nil
+}
+
+func createProvisioner(cluster *v1alpha1.Cluster) (ClusterProvisioner, any, error) {
 	switch cluster.Spec.Distribution {
 	case v1alpha1.DistributionKind:
 		return createKindProvisioner(
 			cluster.Spec.DistributionConfig,
 			cluster.Spec.Connection.Kubeconfig,
+			cluster.Spec.ClusterName,
+			cluster.Spec.Networking.DockerNetwork.Name,
 		)
 	case v1alpha1.DistributionK3d:
 		return createK3dProvisioner(
 			cluster.Spec.DistributionConfig,
+			cluster.Spec.ClusterName,
+		)
+	case v1alpha1.DistributionTalos:
+		return createTalosProvisioner(
+			cluster.Spec.DistributionConfig,
+			cluster.Spec.Connection.Kubeconfig,
+			cluster.Spec.ClusterName,
+		)
+	case v1alpha1.DistributionK0s:
+		return createK0sProvisioner(
+			cluster.Spec.DistributionConfig,
+			cluster.Spec.Connection.Kubeconfig,
+			cluster.Spec.ClusterName,
+		)
+	case v1alpha1.DistributionKwok:
+		return createKwokProvisioner(
+			cluster.Spec.DistributionConfig,
+			cluster.Spec.Connection.Kubeconfig,
+			cluster.Spec.ClusterName,
+		)
+	case v1alpha1.DistributionExternal:
+		return createExternalProvisioner(
+			cluster.Spec.Connection.Kubeconfig,
+			cluster.Spec.Connection.Context,
+			cluster.Spec.ClusterName,
 		)
 	default:
 		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedDistribution, cluster.Spec.Distribution)
@@ -57,6 +110,8 @@ func (DefaultFactory) Create(
 func createKindProvisioner(
 	distributionConfigPath string,
 	kubeconfigPath string,
+	clusterName string,
+	networkName string,
 ) (*kindprovisioner.KindClusterProvisioner, *v1alpha4.Cluster, error) {
 	kindConfigMgr := kindconfigmanager.NewConfigManager(distributionConfigPath)
 
@@ -65,11 +120,20 @@ func createKindProvisioner(
 		return nil, nil, fmt.Errorf("failed to load Kind configuration: %w", err)
 	}
 
+	configmanager.ApplyClusterName(kindConfig, clusterName)
+
+	if wsl.Detect() {
+		AdaptKindConfigForWSL(kindConfig)
+	}
+
 	provisioner, err := createKindProvisionerFromConfig(kindConfig, kubeconfigPath)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Kind reads its network from the environment, so the provisioner sets it for its calls only
+	provisioner.SetNetwork(networkName)
+
 	return provisioner, kindConfig, nil
 }
 
@@ -98,6 +162,7 @@ func createKindProvisionerFromConfig(
 
 func createK3dProvisioner(
 	distributionConfigPath string,
+	clusterName string,
 ) (*k3dprovisioner.K3dClusterProvisioner, *k3dv1alpha5.SimpleConfig, error) {
 	k3dConfigMgr := k3dconfigmanager.NewConfigManager(distributionConfigPath)
 
@@ -106,6 +171,18 @@ func createK3dProvisioner(
 		return nil, nil, fmt.Errorf("failed to load K3d configuration: %w", err)
 	}
 
+	// The name is passed to k3d as an argument, which takes precedence over the config file.
+	configmanager.ApplyClusterName(k3dConfig, clusterName)
+
+	if wsl.Detect() {
+		AdaptK3dConfigForWSL(k3dConfig)
+
+		distributionConfigPath, err = writeWSLK3dConfig(k3dConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	provisioner := k3dprovisioner.NewK3dClusterProvisioner(
 		k3dConfig,
 		distributionConfigPath,
@@ -113,3 +190,98 @@ func createK3dProvisioner(
 
 	return provisioner, k3dConfig, nil
 }
+
+func createTalosProvisioner(
+	distributionConfigPath string,
+	kubeconfigPath string,
+	clusterName string,
+) (*talosprovisioner.TalosClusterProvisioner, *talosprovisioner.Config, error) {
+	talosConfig, err := talosprovisioner.LoadConfig(distributionConfigPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load Talos configuration: %w", err)
+	}
+
+	configmanager.ApplyClusterName(talosConfig, clusterName)
+
+	dockerClient, err := kindprovisioner.NewDefaultDockerClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+
+	if kubeconfigPath == "" {
+		kubeconfigPath = defaultKubeconfigPath
+	}
+
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(talosConfig, kubeconfigPath, dockerClient)
+
+	return provisioner, talosConfig, nil
+}
+
+func createK0sProvisioner(
+	distributionConfigPath string,
+	kubeconfigPath string,
+	clusterName string,
+) (*k0sprovisioner.K0sClusterProvisioner, *k0sprovisioner.Config, error) {
+	k0sConfig, err := k0sconfigmanager.NewConfigManager(distributionConfigPath).LoadConfig(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load k0s configuration: %w", err)
+	}
+
+	configmanager.ApplyClusterName(k0sConfig, clusterName)
+
+	dockerClient, err := kindprovisioner.NewDefaultDockerClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+
+	if kubeconfigPath == "" {
+		kubeconfigPath = defaultKubeconfigPath
+	}
+
+	provisioner := k0sprovisioner.NewK0sClusterProvisioner(k0sConfig, kubeconfigPath, dockerClient)
+
+	return provisioner, k0sConfig, nil
+}
+
+func createKwokProvisioner(
+	distributionConfigPath string,
+	kubeconfigPath string,
+	clusterName string,
+) (*kwokprovisioner.KwokClusterProvisioner, *kwokprovisioner.Config, error) {
+	kwokConfig, err := kwokconfigmanager.NewConfigManager(distributionConfigPath).LoadConfig(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kwok configuration: %w", err)
+	}
+
+	configmanager.ApplyClusterName(kwokConfig, clusterName)
+
+	dockerClient, err := kindprovisioner.NewDefaultDockerClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+
+	if kubeconfigPath == "" {
+		kubeconfigPath = defaultKubeconfigPath
+	}
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(kwokConfig, kubeconfigPath, dockerClient)
+
+	return provisioner, kwokConfig, nil
+}
+
+func createExternalProvisioner(
+	kubeconfigPath string,
+	contextName string,
+	clusterName string,
+) (*externalprovisioner.ExternalClusterProvisioner, *externalprovisioner.Config, error) {
+	if kubeconfigPath == "" {
+		kubeconfigPath = defaultKubeconfigPath
+	}
+
+	externalConfig, err := externalprovisioner.LoadConfig(kubeconfigPath, contextName, clusterName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve external cluster: %w", err)
+	}
+
+	return externalprovisioner.NewExternalClusterProvisioner(externalConfig), externalConfig, nil
+}
@@ -0,0 +1,55 @@
+package k0sprovisioner
+
+import "strings"
+
+const (
+	// APIVersion is the API version of k0s.yaml.
+	APIVersion = "k0s.ksail.dev/v1alpha1"
+	// Kind is the kind of k0s.yaml.
+	Kind = "Cluster"
+	// DefaultClusterName is the cluster name used when none is configured.
+	DefaultClusterName = "k0s-default"
+	// DefaultImage is the k0s image nodes run when none is configured.
+	DefaultImage = "docker.io/k0sproject/k0s:v1.34.1-k0s.0"
+	// ContextPrefix prefixes the cluster name in the kube context of k0s clusters.
+	ContextPrefix = "k0s-"
+)
+
+// Config is the content of k0s.yaml, the distribution configuration of k0s clusters.
+type Config struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	// Name is the cluster name.
+	Name string `json:"name,omitempty"`
+	// Image is the k0s image the nodes run.
+	Image string `json:"image,omitempty"`
+	// Workers is the number of worker nodes joined to the controller, which runs workloads too.
+	Workers int `json:"workers,omitempty"`
+	// APIServerPort is the host port the API server is published on; 0 picks a free port.
+	APIServerPort int32 `json:"apiServerPort,omitempty"`
+	// DisableDefaultCNI skips the kube-router CNI k0s installs, so another CNI can be installed.
+	DisableDefaultCNI bool `json:"disableDefaultCNI,omitempty"`
+	// ControllerArgs are extra arguments for `k0s controller`, e.g.
+	// --disable-components=metrics-server.
+	ControllerArgs []string `json:"controllerArgs,omitempty"`
+}
+
+// NewConfig returns the configuration of a single node cluster named name, or
+// DefaultClusterName when name is empty.
+func NewConfig(name string) *Config {
+	if strings.TrimSpace(name) == "" {
+		name = DefaultClusterName
+	}
+
+	return &Config{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Name:       name,
+		Image:      DefaultImage,
+	}
+}
+
+// ContextName returns the kube context the provisioner creates for the cluster name.
+func ContextName(clusterName string) string {
+	return ContextPrefix + clusterName
+}
@@ -0,0 +1,7 @@
+// Package k0sprovisioner provides the ClusterProvisioner implementation for k0s clusters
+// running in Docker.
+//
+// Every node is a container of the k0s image: a controller that also runs workloads, joined by
+// optional worker containers on a shared Docker network. The package also defines Config, the
+// k0s.yaml distribution configuration KSail scaffolds and loads.
+package k0sprovisioner
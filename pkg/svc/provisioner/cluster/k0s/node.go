@@ -0,0 +1,237 @@
+package k0sprovisioner
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+)
+
+const (
+	apiServerPort nat.Port = "6443/tcp"
+
+	k0sConfigPath  = "/etc/k0s/k0s.yaml"
+	dataDir        = "/var/lib/k0s"
+	configFileMode = 0o644
+
+	// noCNIConfig is the k0s cluster configuration that skips the default CNI.
+	noCNIConfig = `apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: k0s
+spec:
+  network:
+    provider: custom
+`
+)
+
+var errExecFailed = errors.New("command failed")
+
+func (k *K0sClusterProvisioner) ensureImage(ctx context.Context) error {
+	_, err := k.client.ImageInspect(ctx, k.image())
+	if err == nil {
+		return nil
+	}
+
+	reader, err := k.client.ImagePull(ctx, k.image(), image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull k0s image %s: %w", k.image(), err)
+	}
+
+	_, err = io.Copy(io.Discard, reader)
+	closeErr := reader.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to read image pull output: %w", err)
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close image pull reader: %w", closeErr)
+	}
+
+	return nil
+}
+
+// createController creates and starts the controller node, which runs workloads too.
+func (k *K0sClusterProvisioner) createController(ctx context.Context, target string) (string, error) {
+	cmd := []string{"k0s", "controller", "--enable-worker", "--no-taints"}
+	if k.config.DisableDefaultCNI {
+		cmd = append(cmd, "--config", k0sConfigPath)
+	}
+
+	cmd = append(cmd, k.config.ControllerArgs...)
+
+	hostPort := ""
+	if k.config.APIServerPort > 0 {
+		hostPort = strconv.Itoa(int(k.config.APIServerPort))
+	}
+
+	hostConfig := k.nodeHostConfig(target)
+	hostConfig.PortBindings = nat.PortMap{
+		apiServerPort: {{HostIP: "127.0.0.1", HostPort: hostPort}},
+	}
+
+	config := k.nodeConfig(target, target+"-controller", roleController, cmd)
+	config.ExposedPorts = nat.PortSet{apiServerPort: {}}
+
+	created, err := k.client.ContainerCreate(ctx, config, hostConfig, &network.NetworkingConfig{}, nil, config.Hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	if k.config.DisableDefaultCNI {
+		err = k.client.CopyToContainer(ctx, created.ID, "/", k0sConfigArchive(), container.CopyToContainerOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to copy k0s configuration to controller: %w", err)
+		}
+	}
+
+	err = k.client.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to start controller: %w", err)
+	}
+
+	return created.ID, nil
+}
+
+// createWorker creates and starts a worker node that joins the controller with a fresh token.
+func (k *K0sClusterProvisioner) createWorker(ctx context.Context, target, controller string, index int) error {
+	token, err := k.waitForOutput(ctx, controller, "k0s", "token", "create", "--role=worker")
+	if err != nil {
+		return fmt.Errorf("failed to create worker join token: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-worker-%d", target, index)
+	config := k.nodeConfig(target, name, roleWorker, []string{"k0s", "worker", strings.TrimSpace(token)})
+
+	created, err := k.client.ContainerCreate(ctx, config, k.nodeHostConfig(target), &network.NetworkingConfig{}, nil, name)
+	if err != nil {
+		return fmt.Errorf("failed to create worker %s: %w", name, err)
+	}
+
+	err = k.client.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start worker %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (k *K0sClusterProvisioner) nodeConfig(target, name, role string, cmd []string) *container.Config {
+	return &container.Config{
+		Image:    k.image(),
+		Hostname: name,
+		Cmd:      cmd,
+		Labels:   map[string]string{clusterLabel: target, roleLabel: role},
+		Volumes:  map[string]struct{}{dataDir: {}},
+	}
+}
+
+// nodeHostConfig returns the settings k0s documents for running nodes in Docker.
+func (k *K0sClusterProvisioner) nodeHostConfig(target string) *container.HostConfig {
+	return &container.HostConfig{
+		Privileged:   true,
+		CgroupnsMode: container.CgroupnsModeHost,
+		Binds:        []string{"/sys/fs/cgroup:/sys/fs/cgroup:rw"},
+		Tmpfs:        map[string]string{"/run": ""},
+		NetworkMode:  container.NetworkMode(target),
+	}
+}
+
+func (k *K0sClusterProvisioner) image() string {
+	if k.config.Image != "" {
+		return k.config.Image
+	}
+
+	return DefaultImage
+}
+
+// waitForOutput runs cmd in the container until it succeeds, which it does once the controller
+// serves its API, and returns its output.
+func (k *K0sClusterProvisioner) waitForOutput(ctx context.Context, containerID string, cmd ...string) (string, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, k.readyTimeout)
+	defer cancel()
+
+	for {
+		output, err := k.exec(timeoutCtx, containerID, cmd...)
+		if err == nil {
+			return output, nil
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return "", fmt.Errorf("%s: %w (last error: %w)", strings.Join(cmd, " "), timeoutCtx.Err(), err)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (k *K0sClusterProvisioner) exec(ctx context.Context, containerID string, cmd ...string) (string, error) {
+	created, err := k.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	attached, err := k.client.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach exec: %w", err)
+	}
+	defer attached.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	_, err = stdcopy.StdCopy(&stdout, &stderr, attached.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspect, err := k.client.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect exec: %w", err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return "", fmt.Errorf("%w with exit code %d: %s", errExecFailed, inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// k0sConfigArchive returns a tar archive holding the k0s configuration at k0sConfigPath.
+func k0sConfigArchive() io.Reader {
+	var archive bytes.Buffer
+
+	writer := tar.NewWriter(&archive)
+
+	// Writes to a bytes.Buffer cannot fail.
+	_ = writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     "etc/k0s/",
+		Mode:     0o755,
+	})
+	_ = writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     strings.TrimPrefix(k0sConfigPath, "/"),
+		Mode:     configFileMode,
+		Size:     int64(len(noCNIConfig)),
+	})
+	_, _ = writer.Write([]byte(noCNIConfig))
+	_ = writer.Close()
+
+	return &archive
+}
@@ -0,0 +1,314 @@
+package k0sprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+const (
+	clusterLabel = "io.ksail.k0s.cluster"
+	roleLabel    = "io.ksail.k0s.role"
+
+	roleController = "controller"
+	roleWorker     = "worker"
+
+	defaultReadyTimeout = 3 * time.Minute
+	pollInterval        = 2 * time.Second
+	dockerStartTimeout  = 30 * time.Second
+	dockerStopTimeout   = 60 * time.Second
+)
+
+// ErrClusterNotFound is returned when no node containers exist for the cluster.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// Option configures the k0s provisioner.
+type Option func(*K0sClusterProvisioner)
+
+// WithReadyTimeout sets how long Create waits for the controller to serve its API.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(provisioner *K0sClusterProvisioner) {
+		if timeout > 0 {
+			provisioner.readyTimeout = timeout
+		}
+	}
+}
+
+// K0sClusterProvisioner manages k0s clusters whose nodes run as Docker containers.
+type K0sClusterProvisioner struct {
+	config         *Config
+	kubeconfigPath string
+	client         client.APIClient
+	readyTimeout   time.Duration
+}
+
+// NewK0sClusterProvisioner constructs a provisioner for the clusters described by config.
+func NewK0sClusterProvisioner(
+	config *Config,
+	kubeconfigPath string,
+	dockerClient client.APIClient,
+	opts ...Option,
+) *K0sClusterProvisioner {
+	if config == nil {
+		config = NewConfig("")
+	}
+
+	prov := &K0sClusterProvisioner{
+		config:         config,
+		kubeconfigPath: kubeconfigPath,
+		client:         dockerClient,
+		readyTimeout:   defaultReadyTimeout,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(prov)
+		}
+	}
+
+	return prov
+}
+
+// Create starts the controller, joins the workers and merges the admin kubeconfig of the
+// cluster into the configured kubeconfig file as context k0s-<name>.
+func (k *K0sClusterProvisioner) Create(ctx context.Context, name string) error {
+	target := k.resolveName(name)
+
+	err := k.ensureImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = k.ensureNetwork(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	controller, err := k.createController(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := k.waitForOutput(ctx, controller, "k0s", "kubeconfig", "admin")
+	if err != nil {
+		return fmt.Errorf("controller did not become ready: %w", err)
+	}
+
+	for index := 1; index <= k.config.Workers; index++ {
+		err = k.createWorker(ctx, target, controller, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	server, err := k.apiServerURL(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	return mergeKubeconfig(k.kubeconfigPath, kubeconfig, ContextName(target), server)
+}
+
+// Delete removes the node containers and network of the cluster and its kube context.
+func (k *K0sClusterProvisioner) Delete(ctx context.Context, name string) error {
+	target := k.resolveName(name)
+
+	nodes, err := k.listNodes(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		err := k.client.ContainerRemove(ctx, node.ID, container.RemoveOptions{
+			RemoveVolumes: true,
+			Force:         true,
+		})
+		if err != nil {
+			return fmt.Errorf("docker remove failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	err = k.client.NetworkRemove(ctx, target)
+	if err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to remove network %s: %w", target, err)
+	}
+
+	return removeKubeconfigContext(k.kubeconfigPath, ContextName(target))
+}
+
+// Start starts the node containers of a stopped cluster, the controller first.
+func (k *K0sClusterProvisioner) Start(ctx context.Context, name string) error {
+	nodes, err := k.listNodes(ctx, k.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStartTimeout)
+	defer cancel()
+
+	for _, node := range nodes {
+		err := k.client.ContainerStart(timeoutCtx, node.ID, container.StartOptions{})
+		if err != nil {
+			return fmt.Errorf("docker start failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the node containers of a cluster, the workers first.
+func (k *K0sClusterProvisioner) Stop(ctx context.Context, name string) error {
+	nodes, err := k.listNodes(ctx, k.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStopTimeout)
+	defer cancel()
+
+	for _, node := range slices.Backward(nodes) {
+		err := k.client.ContainerStop(timeoutCtx, node.ID, container.StopOptions{})
+		if err != nil {
+			return fmt.Errorf("docker stop failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	return nil
+}
+
+// List returns the names of the k0s clusters that have node containers.
+func (k *K0sClusterProvisioner) List(ctx context.Context) ([]string, error) {
+	containers, err := k.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", clusterLabel)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list k0s containers: %w", err)
+	}
+
+	var names []string
+
+	for _, node := range containers {
+		name := node.Labels[clusterLabel]
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Exists returns whether the target cluster has node containers.
+func (k *K0sClusterProvisioner) Exists(ctx context.Context, name string) (bool, error) {
+	clusters, err := k.List(ctx)
+	if err != nil {
+		return false, fmt.Errorf("list: %w", err)
+	}
+
+	return slices.Contains(clusters, k.resolveName(name)), nil
+}
+
+// listNodes returns the node containers of the cluster, the controller first.
+func (k *K0sClusterProvisioner) listNodes(ctx context.Context, target string) ([]container.Summary, error) {
+	containers, err := k.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", clusterLabel+"="+target)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for cluster '%s': %w", target, err)
+	}
+
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("cluster '%s': %w", target, ErrClusterNotFound)
+	}
+
+	slices.SortStableFunc(containers, func(a, b container.Summary) int {
+		return strings.Compare(nodeSortKey(a), nodeSortKey(b))
+	})
+
+	return containers, nil
+}
+
+func (k *K0sClusterProvisioner) resolveName(name string) string {
+	if strings.TrimSpace(name) != "" {
+		return name
+	}
+
+	if strings.TrimSpace(k.config.Name) != "" {
+		return k.config.Name
+	}
+
+	return DefaultClusterName
+}
+
+func (k *K0sClusterProvisioner) ensureNetwork(ctx context.Context, target string) error {
+	_, err := k.client.NetworkInspect(ctx, target, network.InspectOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to inspect network %s: %w", target, err)
+	}
+
+	_, err = k.client.NetworkCreate(ctx, target, network.CreateOptions{
+		Driver: "bridge",
+		Labels: map[string]string{clusterLabel: target},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create network %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// apiServerURL returns the address the API server of the controller is published on.
+func (k *K0sClusterProvisioner) apiServerURL(ctx context.Context, controller string) (string, error) {
+	inspect, err := k.client.ContainerInspect(ctx, controller)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect controller: %w", err)
+	}
+
+	if inspect.NetworkSettings != nil {
+		for _, binding := range inspect.NetworkSettings.Ports[apiServerPort] {
+			if binding.HostPort != "" {
+				return "https://127.0.0.1:" + binding.HostPort, nil
+			}
+		}
+	}
+
+	if k.config.APIServerPort > 0 {
+		return "https://127.0.0.1:" + strconv.Itoa(int(k.config.APIServerPort)), nil
+	}
+
+	return "", fmt.Errorf("%w: API server port of %s is not published", ErrClusterNotFound, controller)
+}
+
+func nodeSortKey(node container.Summary) string {
+	role := "1"
+	if node.Labels[roleLabel] == roleController {
+		role = "0"
+	}
+
+	return role + nodeName(node)
+}
+
+func nodeName(node container.Summary) string {
+	if len(node.Names) == 0 {
+		return node.ID
+	}
+
+	return strings.TrimPrefix(node.Names[0], "/")
+}
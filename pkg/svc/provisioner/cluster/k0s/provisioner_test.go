@@ -0,0 +1,136 @@
+package k0sprovisioner_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/docker"
+	k0sprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k0s"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const kubeconfigWithContext = `apiVersion: v1
+kind: Config
+clusters:
+- name: k0s-k0s-default
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: k0s-k0s-default
+  user:
+    token: secret
+contexts:
+- name: k0s-k0s-default
+  context:
+    cluster: k0s-k0s-default
+    user: k0s-k0s-default
+- name: kind-kind
+  context:
+    cluster: k0s-k0s-default
+    user: k0s-k0s-default
+current-context: k0s-k0s-default
+`
+
+func newNode(id, cluster, role string) container.Summary {
+	return container.Summary{
+		ID:     id,
+		Names:  []string{"/" + id},
+		Labels: map[string]string{"io.ksail.k0s.cluster": cluster, "io.ksail.k0s.role": role},
+	}
+}
+
+func TestListReturnsDistinctClusterNames(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newNode("dev-worker-1", "dev", "worker"),
+		newNode("dev-controller", "dev", "controller"),
+		newNode("ci-controller", "ci", "controller"),
+	}, nil)
+
+	provisioner := k0sprovisioner.NewK0sClusterProvisioner(nil, "", client)
+
+	clusters, err := provisioner.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci", "dev"}, clusters)
+
+	exists, err := provisioner.Exists(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestStartAndStopOrderNodes(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newNode("dev-worker-1", "dev", "worker"),
+		newNode("dev-controller", "dev", "controller"),
+	}, nil)
+
+	var started, stopped []string
+
+	client.EXPECT().ContainerStart(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string, _ container.StartOptions) error {
+			started = append(started, id)
+
+			return nil
+		})
+	client.EXPECT().ContainerStop(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string, _ container.StopOptions) error {
+			stopped = append(stopped, id)
+
+			return nil
+		})
+
+	provisioner := k0sprovisioner.NewK0sClusterProvisioner(k0sprovisioner.NewConfig("dev"), "", client)
+
+	require.NoError(t, provisioner.Start(context.Background(), ""))
+	require.NoError(t, provisioner.Stop(context.Background(), ""))
+
+	assert.Equal(t, []string{"dev-controller", "dev-worker-1"}, started)
+	assert.Equal(t, []string{"dev-worker-1", "dev-controller"}, stopped)
+}
+
+func TestStartReturnsErrClusterNotFound(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return(nil, nil)
+
+	provisioner := k0sprovisioner.NewK0sClusterProvisioner(nil, "", client)
+
+	err := provisioner.Start(context.Background(), "missing")
+	require.ErrorIs(t, err, k0sprovisioner.ErrClusterNotFound)
+}
+
+func TestDeleteRemovesNodesNetworkAndContext(t *testing.T) {
+	t.Parallel()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(kubeconfigWithContext), 0o600))
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newNode("k0s-default-controller", k0sprovisioner.DefaultClusterName, "controller"),
+	}, nil)
+	client.EXPECT().ContainerRemove(mock.Anything, "k0s-default-controller", mock.Anything).Return(nil)
+	client.EXPECT().NetworkRemove(mock.Anything, k0sprovisioner.DefaultClusterName).Return(nil)
+
+	provisioner := k0sprovisioner.NewK0sClusterProvisioner(nil, kubeconfigPath, client)
+
+	require.NoError(t, provisioner.Delete(context.Background(), ""))
+
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.NotContains(t, kubeconfig.Contexts, "k0s-k0s-default")
+	assert.Contains(t, kubeconfig.Contexts, "kind-kind")
+	assert.Empty(t, kubeconfig.CurrentContext)
+}
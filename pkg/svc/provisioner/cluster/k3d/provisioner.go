@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	runner "github.com/devantler-tech/ksail-go/pkg/cmd/runner"
 	clustercommand "github.com/k3d-io/k3d/v5/cmd/cluster"
+	nodecommand "github.com/k3d-io/k3d/v5/cmd/node"
 	v1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -22,6 +24,10 @@ type CommandBuilders struct {
 	Start  func() *cobra.Command
 	Stop   func() *cobra.Command
 	List   func() *cobra.Command
+
+	NodeCreate func() *cobra.Command
+	NodeDelete func() *cobra.Command
+	NodeList   func() *cobra.Command
 }
 
 // Option configures the k3d command provisioner.
@@ -62,6 +68,10 @@ func NewK3dClusterProvisioner(
 			Start:  clustercommand.NewCmdClusterStart,
 			Stop:   clustercommand.NewCmdClusterStop,
 			List:   clustercommand.NewCmdClusterList,
+
+			NodeCreate: nodecommand.NewCmdNodeCreate,
+			NodeDelete: nodecommand.NewCmdNodeDelete,
+			NodeList:   nodecommand.NewCmdNodeList,
 		},
 	}
 
@@ -105,12 +115,25 @@ func WithCommandBuilders(builders CommandBuilders) Option {
 		if builders.List != nil {
 			provisioner.builders.List = builders.List
 		}
+
+		if builders.NodeCreate != nil {
+			provisioner.builders.NodeCreate = builders.NodeCreate
+		}
+
+		if builders.NodeDelete != nil {
+			provisioner.builders.NodeDelete = builders.NodeDelete
+		}
+
+		if builders.NodeList != nil {
+			provisioner.builders.NodeList = builders.NodeList
+		}
 	}
 }
 
-// Create provisions a k3d cluster using the native Cobra command.
+// Create provisions a k3d cluster using the native Cobra command. The server and agent counts
+// of the config are passed as flags, which take precedence over the config file.
 func (k *K3dClusterProvisioner) Create(ctx context.Context, name string) error {
-	args := k.appendConfigFlag(nil)
+	args := k.appendNodeCountFlags(k.appendConfigFlag(nil))
 
 	return k.runLifecycleCommand(
 		ctx,
@@ -200,6 +223,22 @@ func (k *K3dClusterProvisioner) appendConfigFlag(args []string) []string {
 	return append(args, "--config", k.configPath)
 }
 
+func (k *K3dClusterProvisioner) appendNodeCountFlags(args []string) []string {
+	if k.simpleCfg == nil {
+		return args
+	}
+
+	if k.simpleCfg.Servers > 0 {
+		args = append(args, "--servers", strconv.Itoa(k.simpleCfg.Servers))
+	}
+
+	if k.simpleCfg.Agents > 0 {
+		args = append(args, "--agents", strconv.Itoa(k.simpleCfg.Agents))
+	}
+
+	return args
+}
+
 func (k *K3dClusterProvisioner) resolveName(name string) string {
 	if strings.TrimSpace(name) != "" {
 		return name
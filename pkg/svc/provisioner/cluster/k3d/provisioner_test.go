@@ -83,6 +83,28 @@ func TestCreateUsesConfigFlag(t *testing.T) {
 	)
 }
 
+//nolint:paralleltest
+func TestCreatePassesNodeCounts(t *testing.T) {
+	cfg := buildSimpleConfig("ha")
+	cfg.Servers = 3
+	cfg.Agents = 2
+	runner := &stubRunner{}
+	prov := k3dprovisioner.NewK3dClusterProvisioner(
+		cfg,
+		"k3d.yaml",
+		k3dprovisioner.WithCommandRunner(runner),
+	)
+
+	err := prov.Create(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]string{"--config", "k3d.yaml", "--servers", "3", "--agents", "2", "ha"},
+		runner.lastArgs(),
+	)
+}
+
 //nolint:paralleltest
 func TestDeleteDefaultsToConfigName(t *testing.T) {
 	cfg := buildSimpleConfig("from-config")
@@ -14,12 +14,25 @@ import (
 )
 
 // SetupRegistries creates mirror registries based on the K3d simple configuration.
+// Concurrent ksail processes on the same host take turns, so shared mirrors are created once.
 func SetupRegistries(
 	ctx context.Context,
 	simpleCfg *k3dv1alpha5.SimpleConfig,
 	clusterName string,
 	dockerClient client.APIClient,
 	writer io.Writer,
+) error {
+	return registry.WithSharedRegistriesLock(ctx, writer, func() error {
+		return setupRegistries(ctx, simpleCfg, clusterName, dockerClient, writer)
+	})
+}
+
+func setupRegistries(
+	ctx context.Context,
+	simpleCfg *k3dv1alpha5.SimpleConfig,
+	clusterName string,
+	dockerClient client.APIClient,
+	writer io.Writer,
 ) error {
 	registryMgr, registryInfos, err := setupRegistryManager(ctx, simpleCfg, dockerClient)
 	if err != nil {
@@ -30,7 +43,7 @@ func SetupRegistries(
 		return nil
 	}
 
-	networkName := resolveK3dNetworkName(clusterName)
+	networkName := resolveK3dNetworkName(simpleCfg, clusterName)
 
 	errRegistry := registry.SetupRegistries(
 		ctx,
@@ -64,7 +77,7 @@ func ConnectRegistriesToNetwork(
 		return nil
 	}
 
-	networkName := resolveK3dNetworkName(clusterName)
+	networkName := resolveK3dNetworkName(simpleCfg, clusterName)
 
 	errConnect := registry.ConnectRegistriesToNetwork(
 		ctx,
@@ -88,6 +101,19 @@ func CleanupRegistries(
 	dockerClient client.APIClient,
 	deleteVolumes bool,
 	writer io.Writer,
+) error {
+	return registry.WithSharedRegistriesLock(ctx, writer, func() error {
+		return cleanupRegistries(ctx, simpleCfg, clusterName, dockerClient, deleteVolumes, writer)
+	})
+}
+
+func cleanupRegistries(
+	ctx context.Context,
+	simpleCfg *k3dv1alpha5.SimpleConfig,
+	clusterName string,
+	dockerClient client.APIClient,
+	deleteVolumes bool,
+	writer io.Writer,
 ) error {
 	registryMgr, registryInfos, err := setupRegistryManager(ctx, simpleCfg, dockerClient)
 	if err != nil {
@@ -98,7 +124,7 @@ func CleanupRegistries(
 		return nil
 	}
 
-	networkName := resolveK3dNetworkName(clusterName)
+	networkName := resolveK3dNetworkName(simpleCfg, clusterName)
 
 	errCleanup := registry.CleanupRegistries(
 		ctx,
@@ -139,7 +165,16 @@ func setupRegistryManager(
 	return registryMgr, infos, nil
 }
 
-func resolveK3dNetworkName(clusterName string) string {
+// resolveK3dNetworkName returns the network of the simple config, or the network k3d creates
+// for the cluster when the config names none.
+func resolveK3dNetworkName(simpleCfg *k3dv1alpha5.SimpleConfig, clusterName string) string {
+	if simpleCfg != nil {
+		network := strings.TrimSpace(simpleCfg.Network)
+		if network != "" {
+			return network
+		}
+	}
+
 	trimmed := strings.TrimSpace(clusterName)
 	if trimmed == "" {
 		return "k3d"
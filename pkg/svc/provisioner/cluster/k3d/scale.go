@@ -0,0 +1,104 @@
+package k3dprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	k3dtypes "github.com/k3d-io/k3d/v5/pkg/types"
+)
+
+// ErrClusterNameRequired is returned when neither the caller nor the config names the cluster.
+var ErrClusterNameRequired = errors.New("cluster name is required")
+
+// nodeListEntry is the part of a node in the JSON output of k3d node list that Scale uses.
+type nodeListEntry struct {
+	Name          string            `json:"name"`
+	Role          string            `json:"role"`
+	Created       string            `json:"created"`
+	RuntimeLabels map[string]string `json:"runtimeLabels"`
+}
+
+// Scale adds agent nodes to, or removes the most recently added agent nodes from, a running
+// k3d cluster until it has the given number of agents.
+func (k *K3dClusterProvisioner) Scale(ctx context.Context, name string, workers int) error {
+	target := k.resolveName(name)
+	if target == "" {
+		return ErrClusterNameRequired
+	}
+
+	agents, err := k.listAgents(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	for index := len(agents); len(agents) < workers; index++ {
+		// k3d names the node container k3d-<name>-0, so pick a name no agent uses yet.
+		nodeName := target + "-agent-" + strconv.Itoa(index)
+		if slices.Contains(agents, k3dtypes.DefaultObjectNamePrefix+"-"+nodeName+"-0") {
+			continue
+		}
+
+		_, err = k.runner.Run(ctx, k.builders.NodeCreate(), []string{
+			nodeName,
+			"--cluster", target,
+			"--role", string(k3dtypes.AgentRole),
+		})
+		if err != nil {
+			return fmt.Errorf("node create: %w", err)
+		}
+
+		agents = append(agents, k3dtypes.DefaultObjectNamePrefix+"-"+nodeName+"-0")
+	}
+
+	for len(agents) > workers {
+		last := agents[len(agents)-1]
+
+		_, err = k.runner.Run(ctx, k.builders.NodeDelete(), []string{last})
+		if err != nil {
+			return fmt.Errorf("node delete: %w", err)
+		}
+
+		agents = agents[:len(agents)-1]
+	}
+
+	return nil
+}
+
+// listAgents returns the names of the agent nodes of the cluster, ordered by creation.
+func (k *K3dClusterProvisioner) listAgents(ctx context.Context, target string) ([]string, error) {
+	res, err := k.runner.Run(ctx, k.builders.NodeList(), []string{"--output", "json"})
+	if err != nil {
+		return nil, fmt.Errorf("node list: %w", err)
+	}
+
+	output := strings.TrimSpace(res.Stdout)
+	if output == "" {
+		return nil, nil
+	}
+
+	var nodes []nodeListEntry
+
+	err = json.Unmarshal([]byte(output), &nodes)
+	if err != nil {
+		return nil, fmt.Errorf("node list: parse output: %w", err)
+	}
+
+	slices.SortStableFunc(nodes, func(a, b nodeListEntry) int {
+		return strings.Compare(a.Created, b.Created)
+	})
+
+	var agents []string
+
+	for _, node := range nodes {
+		if node.Role == string(k3dtypes.AgentRole) && node.RuntimeLabels[k3dtypes.LabelClusterName] == target {
+			agents = append(agents, strings.TrimPrefix(node.Name, "/"))
+		}
+	}
+
+	return agents, nil
+}
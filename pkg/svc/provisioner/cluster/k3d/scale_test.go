@@ -0,0 +1,70 @@
+package k3dprovisioner_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/cmd/runner"
+	k3dprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/k3d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nodeListOutput = `[
+  {"name": "k3d-dev-server-0", "role": "server", "created": "1", "runtimeLabels": {"k3d.cluster": "dev"}},
+  {"name": "k3d-dev-agent-1", "role": "agent", "created": "3", "runtimeLabels": {"k3d.cluster": "dev"}},
+  {"name": "k3d-dev-agent-0", "role": "agent", "created": "2", "runtimeLabels": {"k3d.cluster": "dev"}},
+  {"name": "k3d-other-agent-0", "role": "agent", "created": "2", "runtimeLabels": {"k3d.cluster": "other"}}
+]`
+
+func TestScaleAddsAgents(t *testing.T) {
+	t.Parallel()
+
+	runner := &stubRunner{result: runner.CommandResult{Stdout: nodeListOutput}}
+	prov := k3dprovisioner.NewK3dClusterProvisioner(
+		buildSimpleConfig("dev"),
+		"",
+		k3dprovisioner.WithCommandRunner(runner),
+	)
+
+	err := prov.Scale(context.Background(), "", 3)
+	require.NoError(t, err)
+
+	require.Len(t, runner.calls, 2)
+	assert.Equal(t, []string{"--output", "json"}, runner.calls[0].args)
+	assert.Equal(t, "create NAME", runner.calls[1].use)
+	assert.Equal(t, []string{"dev-agent-2", "--cluster", "dev", "--role", "agent"}, runner.calls[1].args)
+}
+
+func TestScaleRemovesNewestAgents(t *testing.T) {
+	t.Parallel()
+
+	runner := &stubRunner{result: runner.CommandResult{Stdout: nodeListOutput}}
+	prov := k3dprovisioner.NewK3dClusterProvisioner(
+		buildSimpleConfig("cfg-name"),
+		"",
+		k3dprovisioner.WithCommandRunner(runner),
+	)
+
+	err := prov.Scale(context.Background(), "dev", 0)
+	require.NoError(t, err)
+
+	require.Len(t, runner.calls, 3)
+	assert.Equal(t, []string{"k3d-dev-agent-1"}, runner.calls[1].args)
+	assert.Equal(t, []string{"k3d-dev-agent-0"}, runner.calls[2].args)
+}
+
+func TestScaleWrapsListErrors(t *testing.T) {
+	t.Parallel()
+
+	runner := &stubRunner{err: errBoom}
+	prov := k3dprovisioner.NewK3dClusterProvisioner(
+		buildSimpleConfig("dev"),
+		"",
+		k3dprovisioner.WithCommandRunner(runner),
+	)
+
+	err := prov.Scale(context.Background(), "", 1)
+	require.ErrorIs(t, err, errBoom)
+	assert.ErrorContains(t, err, "node list")
+}
@@ -0,0 +1,71 @@
+package kindprovisioner
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultNetworkName = "kind"
+
+	// NetworkEnvVar is the environment variable Kind reads the name of its Docker network from.
+	NetworkEnvVar = "KIND_EXPERIMENTAL_DOCKER_NETWORK"
+)
+
+// networkEnvMu serializes the Kind calls that point KIND_EXPERIMENTAL_DOCKER_NETWORK at a
+// network, so concurrent provisioners in one process do not see each other's network.
+//
+//nolint:gochecknoglobals // guards the process environment, which is global itself
+var networkEnvMu sync.Mutex
+
+// NetworkName returns the Docker network Kind attaches its nodes to: configured when set,
+// otherwise the network named by KIND_EXPERIMENTAL_DOCKER_NETWORK, or "kind".
+func NetworkName(configured string) string {
+	if name := strings.TrimSpace(configured); name != "" {
+		return name
+	}
+
+	name := strings.TrimSpace(os.Getenv(NetworkEnvVar))
+	if name == "" {
+		return defaultNetworkName
+	}
+
+	return name
+}
+
+// SetNetwork makes the provisioner create its nodes on the Docker network name instead of the
+// network of KIND_EXPERIMENTAL_DOCKER_NETWORK. An empty name keeps Kind's default.
+func (k *KindClusterProvisioner) SetNetwork(name string) {
+	k.network = strings.TrimSpace(name)
+}
+
+// withNetwork runs fn with KIND_EXPERIMENTAL_DOCKER_NETWORK set to name and restores the
+// previous value afterwards. Kind only reads its network from the environment, so the
+// variable is scoped to the call instead of being left set for the rest of the process.
+func withNetwork(name string, fn func() error) error {
+	if name == "" {
+		return fn()
+	}
+
+	networkEnvMu.Lock()
+	defer networkEnvMu.Unlock()
+
+	previous, wasSet := os.LookupEnv(NetworkEnvVar)
+
+	err := os.Setenv(NetworkEnvVar, name)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", NetworkEnvVar, err)
+	}
+
+	defer func() {
+		if wasSet {
+			_ = os.Setenv(NetworkEnvVar, previous)
+		} else {
+			_ = os.Unsetenv(NetworkEnvVar)
+		}
+	}()
+
+	return fn()
+}
@@ -130,6 +130,7 @@ type KindClusterProvisioner struct {
 	provider   KindProvider
 	client     client.ContainerAPIClient
 	runner     runner.CommandRunner
+	network    string
 }
 
 // NewKindClusterProvisioner constructs a KindClusterProvisioner with explicit dependencies
@@ -208,7 +209,11 @@ func (k *KindClusterProvisioner) Create(ctx context.Context, name string) error
 
 	args := []string{"--name", target, "--config", tmpFile.Name()}
 
-	_, err = k.runner.Run(ctx, cmd, args)
+	err = withNetwork(k.network, func() error {
+		_, runErr := k.runner.Run(ctx, cmd, args)
+
+		return runErr
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create kind cluster: %w", err)
 	}
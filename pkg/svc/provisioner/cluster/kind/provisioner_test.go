@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/devantler-tech/ksail-go/pkg/client/docker"
@@ -126,6 +127,49 @@ func TestCreateUsesConfigNameWhenEmpty(t *testing.T) {
 	}, "cfg-name")
 }
 
+// envCapturingRunner records the Docker network Kind would read while a command runs.
+type envCapturingRunner struct {
+	network string
+}
+
+func (r *envCapturingRunner) Run(
+	_ context.Context,
+	_ *cobra.Command,
+	_ []string,
+) (cmdrunner.CommandResult, error) {
+	r.network = os.Getenv(kindprovisioner.NetworkEnvVar)
+
+	return cmdrunner.CommandResult{}, nil
+}
+
+//nolint:paralleltest // inspects the process environment
+func TestCreateScopesNetworkToTheCall(t *testing.T) {
+	runner := &envCapturingRunner{}
+	provisioner := kindprovisioner.NewKindClusterProvisionerWithRunner(
+		&v1alpha4.Cluster{Name: "cfg-name"},
+		"~/.kube/config",
+		nil,
+		nil,
+		runner,
+	)
+	provisioner.SetNetwork("ksail")
+
+	previous, wasSet := os.LookupEnv(kindprovisioner.NetworkEnvVar)
+
+	require.NoError(t, provisioner.Create(context.Background(), ""))
+	assert.Equal(t, "ksail", runner.network)
+
+	current, isSet := os.LookupEnv(kindprovisioner.NetworkEnvVar)
+	assert.Equal(t, wasSet, isSet)
+	assert.Equal(t, previous, current)
+}
+
+func TestNetworkName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ksail", kindprovisioner.NetworkName(" ksail "))
+}
+
 func TestDeleteUsesProvidedName(t *testing.T) {
 	t.Parallel()
 
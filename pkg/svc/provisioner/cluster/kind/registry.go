@@ -12,8 +12,6 @@ import (
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
-const kindNetworkName = "kind"
-
 // setupRegistryManager creates a registry manager and extracts registries from Kind config.
 // Returns nil if no setup is needed.
 func setupRegistryManager(
@@ -44,11 +42,28 @@ func setupRegistryManager(
 // Registries are created without network attachment first, as the "kind" network
 // doesn't exist until after the cluster is created. mirrorSpecs should contain the
 // user-supplied mirror definitions so upstream URLs can be preserved when creating
-// local proxy registry.
+// local proxy registry. networkName is the cluster's Docker network, as returned by
+// NetworkName. Concurrent ksail processes on the same host take turns, so shared mirrors are
+// created once.
 func SetupRegistries(
 	ctx context.Context,
 	kindConfig *v1alpha4.Cluster,
 	clusterName string,
+	networkName string,
+	dockerClient client.APIClient,
+	mirrorSpecs []registry.MirrorSpec,
+	writer io.Writer,
+) error {
+	return registry.WithSharedRegistriesLock(ctx, writer, func() error {
+		return setupRegistries(ctx, kindConfig, clusterName, networkName, dockerClient, mirrorSpecs, writer)
+	})
+}
+
+func setupRegistries(
+	ctx context.Context,
+	kindConfig *v1alpha4.Cluster,
+	clusterName string,
+	networkName string,
 	dockerClient client.APIClient,
 	mirrorSpecs []registry.MirrorSpec,
 	writer io.Writer,
@@ -74,7 +89,7 @@ func SetupRegistries(
 		registryMgr,
 		registriesInfo,
 		clusterName,
-		kindNetworkName,
+		networkName,
 		writer,
 	)
 	if errSetup != nil {
@@ -84,11 +99,12 @@ func SetupRegistries(
 	return nil
 }
 
-// ConnectRegistriesToNetwork connects existing registries to the Kind network.
-// This should be called after the Kind cluster is created and the "kind" network exists.
+// ConnectRegistriesToNetwork connects existing registries to the Kind network networkName.
+// This should be called after the Kind cluster is created and its network exists.
 func ConnectRegistriesToNetwork(
 	ctx context.Context,
 	kindConfig *v1alpha4.Cluster,
+	networkName string,
 	dockerClient client.APIClient,
 	writer io.Writer,
 ) error {
@@ -105,7 +121,7 @@ func ConnectRegistriesToNetwork(
 		ctx,
 		dockerClient,
 		registriesInfo,
-		kindNetworkName,
+		networkName,
 		writer,
 	)
 	if errConnect != nil {
@@ -115,11 +131,26 @@ func ConnectRegistriesToNetwork(
 	return nil
 }
 
-// CleanupRegistries removes registries that are no longer in use.
+// CleanupRegistries removes registries that are no longer in use. networkName is the cluster's
+// Docker network, as returned by NetworkName.
 func CleanupRegistries(
 	ctx context.Context,
 	kindConfig *v1alpha4.Cluster,
 	clusterName string,
+	networkName string,
+	dockerClient client.APIClient,
+	deleteVolumes bool,
+) error {
+	return registry.WithSharedRegistriesLock(ctx, io.Discard, func() error {
+		return cleanupRegistries(ctx, kindConfig, clusterName, networkName, dockerClient, deleteVolumes)
+	})
+}
+
+func cleanupRegistries(
+	ctx context.Context,
+	kindConfig *v1alpha4.Cluster,
+	clusterName string,
+	networkName string,
 	dockerClient client.APIClient,
 	deleteVolumes bool,
 ) error {
@@ -138,7 +169,7 @@ func CleanupRegistries(
 		registriesInfo,
 		clusterName,
 		deleteVolumes,
-		kindNetworkName,
+		networkName,
 		nil,
 	)
 	if errCleanup != nil {
@@ -202,6 +233,26 @@ func extractRegistriesFromKind(
 	return registryInfos
 }
 
+// RegistryMirrors returns the registry mirrors the containerd config patches of kindConfig
+// declare, mapped from registry host to mirror endpoints. The first patch declaring a host wins.
+func RegistryMirrors(kindConfig *v1alpha4.Cluster) map[string][]string {
+	mirrors := make(map[string][]string)
+
+	if kindConfig == nil {
+		return mirrors
+	}
+
+	for _, patch := range kindConfig.ContainerdConfigPatches {
+		for host, endpoints := range parseContainerdConfig(patch) {
+			if _, seen := mirrors[host]; !seen {
+				mirrors[host] = endpoints
+			}
+		}
+	}
+
+	return mirrors
+}
+
 // ParseContainerdConfigForTesting parses containerd configuration patches to extract registry mirrors.
 // Returns a map of registry host to list of endpoint URLs.
 // This function is exported for testing purposes.
@@ -160,6 +160,22 @@ func TestExtractRegistriesFromKind(t *testing.T) {
 	}
 }
 
+func TestRegistryMirrorsKeepsFirstPatchPerHost(t *testing.T) {
+	t.Parallel()
+
+	config := &v1alpha4.Cluster{ContainerdConfigPatches: []string{
+		loadTestData(t, "containerd_single_endpoint.toml"),
+		`[plugins."io.containerd.grpc.v1.cri".registry.mirrors."ghcr.io"]
+  endpoint = ["http://ghcr.io:5000"]`,
+	}}
+
+	mirrors := kindprovisioner.RegistryMirrors(config)
+
+	assert.Len(t, mirrors, 2)
+	assert.Equal(t, []string{"http://ghcr.io:5000"}, mirrors["ghcr.io"])
+	assert.Empty(t, kindprovisioner.RegistryMirrors(nil))
+}
+
 func TestExtractQuotedString(t *testing.T) {
 	t.Parallel()
 
@@ -213,7 +229,7 @@ func TestSetupRegistries_NilKindConfig(t *testing.T) {
 
 	var buf bytes.Buffer
 
-	err := kindprovisioner.SetupRegistries(ctx, nil, "test-cluster", mockClient, nil, &buf)
+	err := kindprovisioner.SetupRegistries(ctx, nil, "test-cluster", "kind", mockClient, nil, &buf)
 	assert.NoError(t, err)
 }
 
@@ -226,7 +242,7 @@ func TestSetupRegistries_NoRegistries(t *testing.T) {
 		ContainerdConfigPatches: []string{},
 	}
 
-	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test-cluster", mockClient, nil, buf)
+	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test-cluster", "kind", mockClient, nil, buf)
 	assert.NoError(t, err)
 }
 
@@ -244,6 +260,7 @@ func TestSetupRegistries_NilDockerClient(t *testing.T) {
 		context.Background(),
 		kindConfig,
 		"test",
+		"kind",
 		nil,
 		nil,
 		io.Discard,
@@ -272,7 +289,7 @@ func TestSetupRegistries_CreateRegistryError(t *testing.T) {
 		Once()
 	mockClient.EXPECT().ContainerList(ctx, mock.Anything).Return(nil, errContainerListFailed).Once()
 
-	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", mockClient, nil, buf)
+	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", "kind", mockClient, nil, buf)
 
 	require.Error(t, err)
 	require.ErrorContains(t, err, "failed to create registry")
@@ -302,7 +319,7 @@ func runSetupRegistriesPartialFailureScenario(t *testing.T) {
 	expectMirrorProvisionFailure(mockClient, "ghcr.io", errRegistryCreateFailed)
 	expectCleanupRunningRegistry(mockClient, firstRegistryID, "docker.io")
 
-	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", mockClient, nil, buf)
+	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", "kind", mockClient, nil, buf)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "failed to create registry ghcr.io")
 	mockClient.AssertExpectations(t)
@@ -340,7 +357,7 @@ func runSetupRegistriesExistingRegistryScenario(t *testing.T) {
 
 	expectMirrorProvisionFailure(mockClient, "ghcr.io", errRegistryCreateFailed)
 
-	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", mockClient, nil, buf)
+	err := kindprovisioner.SetupRegistries(ctx, kindConfig, "test", "kind", mockClient, nil, buf)
 	require.Error(t, err)
 	require.ErrorContains(t, err, "failed to create registry ghcr.io")
 
@@ -499,7 +516,7 @@ func TestConnectRegistriesToNetwork_NilKindConfig(t *testing.T) {
 
 	var buf bytes.Buffer
 
-	err := kindprovisioner.ConnectRegistriesToNetwork(ctx, nil, mockClient, &buf)
+	err := kindprovisioner.ConnectRegistriesToNetwork(ctx, nil, "kind", mockClient, &buf)
 	assert.NoError(t, err)
 }
 
@@ -512,7 +529,7 @@ func TestConnectRegistriesToNetwork_NoRegistries(t *testing.T) {
 		ContainerdConfigPatches: []string{},
 	}
 
-	err := kindprovisioner.ConnectRegistriesToNetwork(ctx, kindConfig, mockClient, buf)
+	err := kindprovisioner.ConnectRegistriesToNetwork(ctx, kindConfig, "kind", mockClient, buf)
 	assert.NoError(t, err)
 }
 
@@ -522,7 +539,7 @@ func TestCleanupRegistries_NilKindConfig(t *testing.T) {
 	mockClient := docker.NewMockAPIClient(t)
 	ctx := context.Background()
 
-	err := kindprovisioner.CleanupRegistries(ctx, nil, "test-cluster", mockClient, false)
+	err := kindprovisioner.CleanupRegistries(ctx, nil, "test-cluster", "kind", mockClient, false)
 	assert.NoError(t, err)
 }
 
@@ -536,7 +553,7 @@ func TestCleanupRegistries_NoRegistries(t *testing.T) {
 		ContainerdConfigPatches: []string{},
 	}
 
-	err := kindprovisioner.CleanupRegistries(ctx, kindConfig, "test-cluster", mockClient, false)
+	err := kindprovisioner.CleanupRegistries(ctx, kindConfig, "test-cluster", "kind", mockClient, false)
 	assert.NoError(t, err)
 }
 
@@ -0,0 +1,277 @@
+package kindprovisioner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+const (
+	kindRoleLabel = "io.x-k8s.kind.role"
+	workerRole    = "worker"
+	adminConfig   = "--kubeconfig=/etc/kubernetes/admin.conf"
+
+	nodeReadyTimeout = 3 * time.Minute
+	nodePollInterval = 2 * time.Second
+)
+
+var errExecFailed = errors.New("command failed")
+
+// Scale adds worker nodes to, or removes the most recently added worker nodes from, a running
+// kind cluster until it has the given number of workers. New workers are cloned from an
+// existing node and joined with a fresh kubeadm token; removed workers are drained first.
+func (k *KindClusterProvisioner) Scale(ctx context.Context, name string, workers int) error {
+	target := setName(name, k.kindConfig.Name)
+
+	nodes, err := k.provider.ListNodes(target)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes for cluster '%s': %w", target, err)
+	}
+
+	controlPlane := target + "-control-plane"
+	if !slices.Contains(nodes, controlPlane) {
+		return fmt.Errorf("cluster '%s': %w", target, ErrClusterNotFound)
+	}
+
+	current := workerNodes(target, nodes)
+
+	for len(current) < workers {
+		node := nextWorkerName(target, current)
+
+		err = k.addWorker(ctx, controlPlane, templateNode(controlPlane, current), node)
+		if err != nil {
+			return err
+		}
+
+		current = workerNodes(target, append(current, node))
+	}
+
+	for len(current) > workers {
+		err = k.removeWorker(ctx, controlPlane, current[len(current)-1])
+		if err != nil {
+			return err
+		}
+
+		current = current[:len(current)-1]
+	}
+
+	return nil
+}
+
+// addWorker creates the node container from the settings of template and joins it to the
+// cluster.
+func (k *KindClusterProvisioner) addWorker(ctx context.Context, controlPlane, template, node string) error {
+	inspect, err := k.client.ContainerInspect(ctx, template)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", template, err)
+	}
+
+	if inspect.Config == nil || inspect.ContainerJSONBase == nil || inspect.HostConfig == nil {
+		return fmt.Errorf("failed to inspect %s: %w", template, ErrClusterNotFound)
+	}
+
+	config := *inspect.Config
+	config.Hostname = node
+	config.Labels = maps.Clone(inspect.Config.Labels)
+
+	if config.Labels == nil {
+		config.Labels = map[string]string{}
+	}
+
+	config.Labels[kindRoleLabel] = workerRole
+
+	// Only the control plane publishes ports, and the new node must not bind them again.
+	hostConfig := *inspect.HostConfig
+	hostConfig.PortBindings = nil
+
+	endpoints := map[string]*network.EndpointSettings{}
+
+	if inspect.NetworkSettings != nil {
+		for networkName := range inspect.NetworkSettings.Networks {
+			endpoints[networkName] = &network.EndpointSettings{}
+		}
+	}
+
+	created, err := k.client.ContainerCreate(
+		ctx,
+		&config,
+		&hostConfig,
+		&network.NetworkingConfig{EndpointsConfig: endpoints},
+		nil,
+		node,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create node %s: %w", node, err)
+	}
+
+	err = k.client.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start node %s: %w", node, err)
+	}
+
+	join, err := k.waitForOutput(ctx, controlPlane, "kubeadm", "token", "create", "--print-join-command")
+	if err != nil {
+		return fmt.Errorf("failed to create join token: %w", err)
+	}
+
+	_, err = k.waitForOutput(ctx, node, "crictl", "info")
+	if err != nil {
+		return fmt.Errorf("node %s did not become ready: %w", node, err)
+	}
+
+	joinCmd := append(strings.Fields(join), "--ignore-preflight-errors=all")
+
+	_, err = k.exec(ctx, node, joinCmd...)
+	if err != nil {
+		return fmt.Errorf("failed to join node %s: %w", node, err)
+	}
+
+	return nil
+}
+
+// removeWorker drains the node, removes it from the cluster and deletes its container.
+func (k *KindClusterProvisioner) removeWorker(ctx context.Context, controlPlane, node string) error {
+	_, err := k.exec(ctx, controlPlane, "kubectl", adminConfig, "drain", node,
+		"--ignore-daemonsets", "--delete-emptydir-data", "--force")
+	if err != nil {
+		return fmt.Errorf("failed to drain node %s: %w", node, err)
+	}
+
+	_, err = k.exec(ctx, controlPlane, "kubectl", adminConfig, "delete", "node", node)
+	if err != nil {
+		return fmt.Errorf("failed to delete node %s: %w", node, err)
+	}
+
+	err = k.client.ContainerRemove(ctx, node, container.RemoveOptions{RemoveVolumes: true, Force: true})
+	if err != nil {
+		return fmt.Errorf("docker remove failed for %s: %w", node, err)
+	}
+
+	return nil
+}
+
+// waitForOutput runs cmd in the container until it succeeds and returns its output.
+func (k *KindClusterProvisioner) waitForOutput(ctx context.Context, containerID string, cmd ...string) (string, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, nodeReadyTimeout)
+	defer cancel()
+
+	for {
+		output, err := k.exec(timeoutCtx, containerID, cmd...)
+		if err == nil {
+			return output, nil
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return "", fmt.Errorf("%s: %w (last error: %w)", strings.Join(cmd, " "), timeoutCtx.Err(), err)
+		case <-time.After(nodePollInterval):
+		}
+	}
+}
+
+func (k *KindClusterProvisioner) exec(ctx context.Context, containerID string, cmd ...string) (string, error) {
+	created, err := k.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	attached, err := k.client.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach exec: %w", err)
+	}
+	defer attached.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	_, err = stdcopy.StdCopy(&stdout, &stderr, attached.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspect, err := k.client.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect exec: %w", err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return "", fmt.Errorf("%w with exit code %d: %s", errExecFailed, inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// workerNodes returns the worker nodes of the cluster in the order kind numbers them:
+// <name>-worker, <name>-worker2, <name>-worker3, ...
+func workerNodes(target string, nodes []string) []string {
+	var workers []string
+
+	for _, node := range nodes {
+		if _, ok := workerIndex(target, node); ok {
+			workers = append(workers, node)
+		}
+	}
+
+	slices.SortFunc(workers, func(a, b string) int {
+		indexA, _ := workerIndex(target, a)
+		indexB, _ := workerIndex(target, b)
+
+		return indexA - indexB
+	})
+
+	return workers
+}
+
+func workerIndex(target, node string) (int, bool) {
+	suffix, ok := strings.CutPrefix(node, target+"-"+workerRole)
+	if !ok {
+		return 0, false
+	}
+
+	if suffix == "" {
+		return 1, true
+	}
+
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 2 {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func nextWorkerName(target string, workers []string) string {
+	index := 1
+	if len(workers) > 0 {
+		index, _ = workerIndex(target, workers[len(workers)-1])
+		index++
+	}
+
+	if index == 1 {
+		return target + "-" + workerRole
+	}
+
+	return target + "-" + workerRole + strconv.Itoa(index)
+}
+
+// templateNode returns the node new workers are cloned from, preferring an existing worker.
+func templateNode(controlPlane string, workers []string) string {
+	if len(workers) > 0 {
+		return workers[0]
+	}
+
+	return controlPlane
+}
@@ -0,0 +1,146 @@
+package kindprovisioner_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/docker"
+	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const joinCommand = "kubeadm join cfg-name-control-plane:6443 --token abc --discovery-token-ca-cert-hash sha256:123"
+
+func TestScaleRemovesNewestWorkers(t *testing.T) {
+	t.Parallel()
+
+	provisioner, provider, client, _ := newProvisionerForTest(t)
+
+	provider.On("ListNodes", "cfg-name").
+		Return([]string{"cfg-name-worker2", "cfg-name-control-plane", "cfg-name-worker"}, nil)
+
+	execs := expectExecs(t, client)
+
+	client.EXPECT().
+		ContainerRemove(mock.Anything, "cfg-name-worker2", container.RemoveOptions{RemoveVolumes: true, Force: true}).
+		Return(nil)
+
+	err := provisioner.Scale(context.Background(), "", 1)
+	require.NoError(t, err)
+
+	require.Len(t, *execs, 2)
+	assert.Contains(t, (*execs)[0], "drain cfg-name-worker2")
+	assert.Contains(t, (*execs)[1], "delete node cfg-name-worker2")
+}
+
+func TestScaleAddsWorkerClonedFromControlPlane(t *testing.T) {
+	t.Parallel()
+
+	provisioner, provider, client, _ := newProvisionerForTest(t)
+
+	provider.On("ListNodes", "cfg-name").Return([]string{"cfg-name-control-plane"}, nil)
+
+	client.EXPECT().ContainerInspect(mock.Anything, "cfg-name-control-plane").Return(container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			HostConfig: &container.HostConfig{
+				Privileged:   true,
+				PortBindings: nat.PortMap{"6443/tcp": {{HostPort: "6443"}}},
+			},
+		},
+		Config: &container.Config{
+			Image:    "kindest/node:v1.34.0",
+			Hostname: "cfg-name-control-plane",
+			Labels:   map[string]string{"io.x-k8s.kind.role": "control-plane"},
+		},
+		NetworkSettings: &container.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{"kind": {IPAddress: "172.18.0.2"}},
+		},
+	}, nil)
+
+	client.EXPECT().
+		ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "cfg-name-worker").
+		RunAndReturn(func(
+			_ context.Context,
+			config *container.Config,
+			hostConfig *container.HostConfig,
+			networking *network.NetworkingConfig,
+			_ *v1.Platform,
+			_ string,
+		) (container.CreateResponse, error) {
+			assert.Equal(t, "cfg-name-worker", config.Hostname)
+			assert.Equal(t, "worker", config.Labels["io.x-k8s.kind.role"])
+			assert.True(t, hostConfig.Privileged)
+			assert.Empty(t, hostConfig.PortBindings)
+			assert.Equal(t, &network.EndpointSettings{}, networking.EndpointsConfig["kind"])
+
+			return container.CreateResponse{ID: "new-worker"}, nil
+		})
+	client.EXPECT().ContainerStart(mock.Anything, "new-worker", container.StartOptions{}).Return(nil)
+
+	execs := expectExecs(t, client)
+
+	err := provisioner.Scale(context.Background(), "", 1)
+	require.NoError(t, err)
+
+	require.Len(t, *execs, 3)
+	assert.Equal(t, "cfg-name-control-plane: kubeadm token create --print-join-command", (*execs)[0])
+	assert.Equal(t, "cfg-name-worker: crictl info", (*execs)[1])
+	assert.Equal(t, "cfg-name-worker: "+joinCommand+" --ignore-preflight-errors=all", (*execs)[2])
+}
+
+func TestScaleErrorClusterNotFound(t *testing.T) {
+	t.Parallel()
+
+	provisioner, provider, _, _ := newProvisionerForTest(t)
+
+	provider.On("ListNodes", "cfg-name").Return([]string{}, nil)
+
+	err := provisioner.Scale(context.Background(), "", 2)
+	require.ErrorIs(t, err, kindprovisioner.ErrClusterNotFound)
+}
+
+// expectExecs makes every exec in the node containers succeed and records them as
+// "<container>: <command>". Join token requests print joinCommand.
+func expectExecs(t *testing.T, client *docker.MockContainerAPIClient) *[]string {
+	t.Helper()
+
+	var execs []string
+
+	client.EXPECT().ContainerExecCreate(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, node string, options container.ExecOptions) (container.ExecCreateResponse, error) {
+			command := strings.Join(options.Cmd, " ")
+			execs = append(execs, node+": "+command)
+
+			return container.ExecCreateResponse{ID: command}, nil
+		})
+
+	client.EXPECT().ContainerExecAttach(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, execID string, _ container.ExecAttachOptions) (types.HijackedResponse, error) {
+			var output bytes.Buffer
+
+			if strings.HasPrefix(execID, "kubeadm token create") {
+				_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte(joinCommand + "\n"))
+			}
+
+			conn, peer := net.Pipe()
+			t.Cleanup(func() { _ = peer.Close() })
+
+			return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(&output)}, nil
+		})
+
+	client.EXPECT().ContainerExecInspect(mock.Anything, mock.Anything).Return(container.ExecInspect{}, nil)
+
+	return &execs
+}
@@ -0,0 +1,53 @@
+package kwokprovisioner
+
+import "strings"
+
+const (
+	// APIVersion is the API version of kwok.yaml.
+	APIVersion = "kwok.ksail.dev/v1alpha1"
+	// Kind is the kind of kwok.yaml.
+	Kind = "Cluster"
+	// DefaultClusterName is the cluster name used when none is configured.
+	DefaultClusterName = "kwok-default"
+	// DefaultImage is the kwok cluster image the control plane runs when none is configured.
+	DefaultImage = "registry.k8s.io/kwok/cluster:v0.7.0-k8s.v1.33.0"
+	// DefaultNodes is the number of fake nodes of a new configuration.
+	DefaultNodes = 1
+	// ContextPrefix prefixes the cluster name in the kube context of kwok clusters.
+	ContextPrefix = "kwok-"
+)
+
+// Config is the content of kwok.yaml, the distribution configuration of kwok clusters.
+type Config struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	// Name is the cluster name.
+	Name string `json:"name,omitempty"`
+	// Image is the kwok cluster image the control plane runs.
+	Image string `json:"image,omitempty"`
+	// Nodes is the number of fake nodes registered with the cluster.
+	Nodes int `json:"nodes,omitempty"`
+	// APIServerPort is the host port the API server is published on; 0 picks a free port.
+	APIServerPort int32 `json:"apiServerPort,omitempty"`
+}
+
+// NewConfig returns the configuration of a cluster named name, or DefaultClusterName when
+// name is empty, with DefaultNodes fake nodes.
+func NewConfig(name string) *Config {
+	if strings.TrimSpace(name) == "" {
+		name = DefaultClusterName
+	}
+
+	return &Config{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Name:       name,
+		Image:      DefaultImage,
+		Nodes:      DefaultNodes,
+	}
+}
+
+// ContextName returns the kube context the provisioner creates for the cluster name.
+func ContextName(clusterName string) string {
+	return ContextPrefix + clusterName
+}
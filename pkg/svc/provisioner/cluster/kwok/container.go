@@ -0,0 +1,106 @@
+package kwokprovisioner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"k8s.io/client-go/kubernetes"
+)
+
+// apiServerPort is the insecure port the kwok cluster image serves the API on.
+const apiServerPort nat.Port = "8080/tcp"
+
+func (k *KwokClusterProvisioner) ensureImage(ctx context.Context) error {
+	_, err := k.client.ImageInspect(ctx, k.image())
+	if err == nil {
+		return nil
+	}
+
+	reader, err := k.client.ImagePull(ctx, k.image(), image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull kwok image %s: %w", k.image(), err)
+	}
+
+	_, err = io.Copy(io.Discard, reader)
+	closeErr := reader.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to read image pull output: %w", err)
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close image pull reader: %w", closeErr)
+	}
+
+	return nil
+}
+
+// createControlPlane creates and starts the container running etcd, the API server and the
+// kwok controller, and returns its ID.
+func (k *KwokClusterProvisioner) createControlPlane(ctx context.Context, target string) (string, error) {
+	hostPort := ""
+	if k.config.APIServerPort > 0 {
+		hostPort = strconv.Itoa(int(k.config.APIServerPort))
+	}
+
+	name := target + "-control-plane"
+
+	config := &container.Config{
+		Image:        k.image(),
+		Hostname:     name,
+		Labels:       map[string]string{clusterLabel: target},
+		ExposedPorts: nat.PortSet{apiServerPort: {}},
+	}
+
+	hostConfig := &container.HostConfig{
+		PortBindings: nat.PortMap{
+			apiServerPort: {{HostIP: "127.0.0.1", HostPort: hostPort}},
+		},
+	}
+
+	created, err := k.client.ContainerCreate(ctx, config, hostConfig, &network.NetworkingConfig{}, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create control plane: %w", err)
+	}
+
+	err = k.client.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to start control plane: %w", err)
+	}
+
+	return created.ID, nil
+}
+
+// waitForAPIServer polls the API server until it serves requests.
+func (k *KwokClusterProvisioner) waitForAPIServer(ctx context.Context, clientset kubernetes.Interface) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, k.readyTimeout)
+	defer cancel()
+
+	for {
+		_, err := clientset.Discovery().ServerVersion()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			return fmt.Errorf("%w (last error: %w)", timeoutCtx.Err(), err)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (k *KwokClusterProvisioner) image() string {
+	if k.config.Image != "" {
+		return k.config.Image
+	}
+
+	return DefaultImage
+}
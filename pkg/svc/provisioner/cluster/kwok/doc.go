@@ -0,0 +1,8 @@
+// Package kwokprovisioner provides the ClusterProvisioner implementation for simulated kwok
+// clusters running in Docker.
+//
+// A cluster is a single container of the kwok cluster image, which runs etcd, the API server
+// and the kwok controller. Its nodes are fake Node objects the kwok controller keeps ready, so
+// hundreds of nodes cost no more than their API objects. The package also defines Config, the
+// kwok.yaml distribution configuration KSail scaffolds and loads.
+package kwokprovisioner
@@ -0,0 +1,91 @@
+package kwokprovisioner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	iopath "github.com/devantler-tech/ksail-go/pkg/io"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const kubeconfigDirPerm = 0o750
+
+// addKubeconfigContext adds contextName, pointing at the insecure server, to the kubeconfig at
+// path and makes it the current context.
+func addKubeconfigContext(path, contextName, server string) error {
+	path, config, err := loadKubeconfig(path)
+	if err != nil {
+		return err
+	}
+
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = server
+
+	config.Clusters[contextName] = cluster
+	config.AuthInfos[contextName] = clientcmdapi.NewAuthInfo()
+	config.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: contextName}
+	config.CurrentContext = contextName
+
+	return writeKubeconfig(path, config)
+}
+
+// removeKubeconfigContext removes the context, cluster and user addKubeconfigContext added.
+func removeKubeconfigContext(path, contextName string) error {
+	path, config, err := loadKubeconfig(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := config.Contexts[contextName]; !ok {
+		return nil
+	}
+
+	delete(config.Contexts, contextName)
+	delete(config.Clusters, contextName)
+	delete(config.AuthInfos, contextName)
+
+	if config.CurrentContext == contextName {
+		config.CurrentContext = ""
+	}
+
+	return writeKubeconfig(path, config)
+}
+
+func loadKubeconfig(path string) (string, *clientcmdapi.Config, error) {
+	if path == "" {
+		path = clientcmd.RecommendedHomeFile
+	}
+
+	path, err := iopath.ExpandHomePath(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expand kubeconfig path: %w", err)
+	}
+
+	config, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, clientcmdapi.NewConfig(), nil
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	return path, config, nil
+}
+
+func writeKubeconfig(path string, config *clientcmdapi.Config) error {
+	err := os.MkdirAll(filepath.Dir(path), kubeconfigDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+
+	err = clientcmd.WriteToFile(*config, path)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+
+	return nil
+}
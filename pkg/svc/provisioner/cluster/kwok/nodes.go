@@ -0,0 +1,115 @@
+package kwokprovisioner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	nodePrefix = "kwok-node-"
+
+	// kwokNodeAnnotation marks the nodes the kwok controller keeps ready.
+	kwokNodeAnnotation = "kwok.x-k8s.io/node"
+	nodeTypeLabel      = "type"
+	nodeTypeKwok       = "kwok"
+)
+
+// scaleNodes registers or removes fake nodes until the cluster has count of them.
+func scaleNodes(ctx context.Context, clientset kubernetes.Interface, count int) error {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: nodeTypeLabel + "=" + nodeTypeKwok,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	indices := make([]int, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		indices = append(indices, nodeIndex(node.Name))
+	}
+
+	slices.Sort(indices)
+
+	next := 1
+	if len(indices) > 0 {
+		next = indices[len(indices)-1] + 1
+	}
+
+	for created := len(indices); created < count; created++ {
+		_, err := clientset.CoreV1().Nodes().Create(ctx, fakeNode(nodePrefix+strconv.Itoa(next)), metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create node %s%d: %w", nodePrefix, next, err)
+		}
+
+		next++
+	}
+
+	for _, index := range slices.Backward(indices) {
+		if len(indices) <= count {
+			break
+		}
+
+		name := nodePrefix + strconv.Itoa(index)
+
+		err := clientset.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete node %s: %w", name, err)
+		}
+
+		indices = indices[:len(indices)-1]
+	}
+
+	return nil
+}
+
+// fakeNode returns a node the kwok controller manages, with the capacity of a large machine.
+func fakeNode(name string) *corev1.Node {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("32"),
+		corev1.ResourceMemory: resource.MustParse("256Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{kwokNodeAnnotation: "fake"},
+			Labels: map[string]string{
+				nodeTypeLabel:                   nodeTypeKwok,
+				"kubernetes.io/hostname":        name,
+				"kubernetes.io/os":              "linux",
+				"kubernetes.io/arch":            "amd64",
+				"kubernetes.io/role":            "agent",
+				"node-role.kubernetes.io/agent": "",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Capacity:    resources,
+			Allocatable: resources,
+			NodeInfo: corev1.NodeSystemInfo{
+				Architecture:    "amd64",
+				OperatingSystem: "linux",
+				KubeletVersion:  "fake",
+			},
+		},
+	}
+}
+
+// nodeIndex returns the index in the name of a fake node, or 0 for nodes KSail did not name.
+func nodeIndex(name string) int {
+	index, err := strconv.Atoi(strings.TrimPrefix(name, nodePrefix))
+	if err != nil {
+		return 0
+	}
+
+	return index
+}
@@ -0,0 +1,317 @@
+package kwokprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	clusterLabel = "io.ksail.kwok.cluster"
+
+	defaultReadyTimeout = 2 * time.Minute
+	pollInterval        = time.Second
+	dockerStartTimeout  = 30 * time.Second
+	dockerStopTimeout   = 60 * time.Second
+
+	// Fake nodes are created in bursts far beyond the client-go default rate limit.
+	clientQPS   = 200
+	clientBurst = 400
+)
+
+// ErrClusterNotFound is returned when no control plane container exists for the cluster.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// ClientsetFactory creates a Kubernetes client for the API server at server.
+type ClientsetFactory func(server string) (kubernetes.Interface, error)
+
+// Option configures the kwok provisioner.
+type Option func(*KwokClusterProvisioner)
+
+// WithReadyTimeout sets how long Create waits for the API server to serve requests.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(provisioner *KwokClusterProvisioner) {
+		if timeout > 0 {
+			provisioner.readyTimeout = timeout
+		}
+	}
+}
+
+// WithClientsetFactory sets how the provisioner connects to the API server of a cluster.
+func WithClientsetFactory(factory ClientsetFactory) Option {
+	return func(provisioner *KwokClusterProvisioner) {
+		if factory != nil {
+			provisioner.newClientset = factory
+		}
+	}
+}
+
+// KwokClusterProvisioner manages simulated kwok clusters whose control plane runs as a
+// Docker container.
+type KwokClusterProvisioner struct {
+	config         *Config
+	kubeconfigPath string
+	client         client.APIClient
+	newClientset   ClientsetFactory
+	readyTimeout   time.Duration
+}
+
+// NewKwokClusterProvisioner constructs a provisioner for the clusters described by config.
+func NewKwokClusterProvisioner(
+	config *Config,
+	kubeconfigPath string,
+	dockerClient client.APIClient,
+	opts ...Option,
+) *KwokClusterProvisioner {
+	if config == nil {
+		config = NewConfig("")
+	}
+
+	prov := &KwokClusterProvisioner{
+		config:         config,
+		kubeconfigPath: kubeconfigPath,
+		client:         dockerClient,
+		newClientset:   newClientset,
+		readyTimeout:   defaultReadyTimeout,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(prov)
+		}
+	}
+
+	return prov
+}
+
+// Create starts the control plane, registers the fake nodes and adds the cluster to the
+// configured kubeconfig file as context kwok-<name>.
+func (k *KwokClusterProvisioner) Create(ctx context.Context, name string) error {
+	target := k.resolveName(name)
+
+	err := k.ensureImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	controlPlane, err := k.createControlPlane(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	server, err := k.apiServerURL(ctx, controlPlane)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := k.newClientset(server)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	err = k.waitForAPIServer(ctx, clientset)
+	if err != nil {
+		return fmt.Errorf("control plane did not become ready: %w", err)
+	}
+
+	err = scaleNodes(ctx, clientset, k.config.Nodes)
+	if err != nil {
+		return err
+	}
+
+	return addKubeconfigContext(k.kubeconfigPath, ContextName(target), server)
+}
+
+// Delete removes the control plane container of the cluster and its kube context.
+func (k *KwokClusterProvisioner) Delete(ctx context.Context, name string) error {
+	target := k.resolveName(name)
+
+	controlPlane, err := k.controlPlane(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	err = k.client.ContainerRemove(ctx, controlPlane.ID, container.RemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+	if err != nil {
+		return fmt.Errorf("docker remove failed for %s: %w", containerName(controlPlane), err)
+	}
+
+	return removeKubeconfigContext(k.kubeconfigPath, ContextName(target))
+}
+
+// Start starts the control plane container of a stopped cluster. The fake nodes are kept in
+// its etcd, so they come back with it.
+func (k *KwokClusterProvisioner) Start(ctx context.Context, name string) error {
+	controlPlane, err := k.controlPlane(ctx, k.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStartTimeout)
+	defer cancel()
+
+	err = k.client.ContainerStart(timeoutCtx, controlPlane.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("docker start failed for %s: %w", containerName(controlPlane), err)
+	}
+
+	return nil
+}
+
+// Stop stops the control plane container of a cluster.
+func (k *KwokClusterProvisioner) Stop(ctx context.Context, name string) error {
+	controlPlane, err := k.controlPlane(ctx, k.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStopTimeout)
+	defer cancel()
+
+	err = k.client.ContainerStop(timeoutCtx, controlPlane.ID, container.StopOptions{})
+	if err != nil {
+		return fmt.Errorf("docker stop failed for %s: %w", containerName(controlPlane), err)
+	}
+
+	return nil
+}
+
+// List returns the names of the kwok clusters that have a control plane container.
+func (k *KwokClusterProvisioner) List(ctx context.Context) ([]string, error) {
+	containers, err := k.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", clusterLabel)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kwok containers: %w", err)
+	}
+
+	var names []string
+
+	for _, controlPlane := range containers {
+		name := controlPlane.Labels[clusterLabel]
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Exists returns whether the target cluster has a control plane container.
+func (k *KwokClusterProvisioner) Exists(ctx context.Context, name string) (bool, error) {
+	clusters, err := k.List(ctx)
+	if err != nil {
+		return false, fmt.Errorf("list: %w", err)
+	}
+
+	return slices.Contains(clusters, k.resolveName(name)), nil
+}
+
+// Scale registers or removes fake nodes until the cluster has workers nodes. Removed nodes
+// are the most recently added ones.
+func (k *KwokClusterProvisioner) Scale(ctx context.Context, name string, workers int) error {
+	controlPlane, err := k.controlPlane(ctx, k.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	server, err := k.apiServerURL(ctx, controlPlane.ID)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := k.newClientset(server)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	return scaleNodes(ctx, clientset, workers)
+}
+
+// controlPlane returns the control plane container of the cluster.
+func (k *KwokClusterProvisioner) controlPlane(ctx context.Context, target string) (container.Summary, error) {
+	containers, err := k.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", clusterLabel+"="+target)),
+	})
+	if err != nil {
+		return container.Summary{}, fmt.Errorf("failed to list containers for cluster '%s': %w", target, err)
+	}
+
+	if len(containers) == 0 {
+		return container.Summary{}, fmt.Errorf("cluster '%s': %w", target, ErrClusterNotFound)
+	}
+
+	return containers[0], nil
+}
+
+func (k *KwokClusterProvisioner) resolveName(name string) string {
+	if strings.TrimSpace(name) != "" {
+		return name
+	}
+
+	if strings.TrimSpace(k.config.Name) != "" {
+		return k.config.Name
+	}
+
+	return DefaultClusterName
+}
+
+// apiServerURL returns the address the API server of the control plane is published on.
+func (k *KwokClusterProvisioner) apiServerURL(ctx context.Context, controlPlane string) (string, error) {
+	inspect, err := k.client.ContainerInspect(ctx, controlPlane)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect control plane: %w", err)
+	}
+
+	if inspect.NetworkSettings != nil {
+		for _, binding := range inspect.NetworkSettings.Ports[apiServerPort] {
+			if binding.HostPort != "" {
+				return "http://127.0.0.1:" + binding.HostPort, nil
+			}
+		}
+	}
+
+	if k.config.APIServerPort > 0 {
+		return "http://127.0.0.1:" + strconv.Itoa(int(k.config.APIServerPort)), nil
+	}
+
+	return "", fmt.Errorf("%w: API server port of %s is not published", ErrClusterNotFound, controlPlane)
+}
+
+func newClientset(server string) (kubernetes.Interface, error) {
+	clientset, err := kubernetes.NewForConfig(&rest.Config{
+		Host:  server,
+		QPS:   clientQPS,
+		Burst: clientBurst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	return clientset, nil
+}
+
+func containerName(summary container.Summary) string {
+	if len(summary.Names) == 0 {
+		return summary.ID
+	}
+
+	return strings.TrimPrefix(summary.Names[0], "/")
+}
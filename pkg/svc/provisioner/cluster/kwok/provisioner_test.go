@@ -0,0 +1,212 @@
+package kwokprovisioner_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/client/docker"
+	kwokprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kwok"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func newControlPlane(id, cluster string) container.Summary {
+	return container.Summary{
+		ID:     id,
+		Names:  []string{"/" + id},
+		Labels: map[string]string{"io.ksail.kwok.cluster": cluster},
+	}
+}
+
+func inspectWithPort(port string) container.InspectResponse {
+	return container.InspectResponse{
+		NetworkSettings: &container.NetworkSettings{
+			NetworkSettingsBase: container.NetworkSettingsBase{
+				Ports: nat.PortMap{"8080/tcp": {{HostIP: "127.0.0.1", HostPort: port}}},
+			},
+		},
+	}
+}
+
+func fakeClientsetFactory(t *testing.T, clientset kubernetes.Interface) kwokprovisioner.Option {
+	t.Helper()
+
+	return kwokprovisioner.WithClientsetFactory(func(server string) (kubernetes.Interface, error) {
+		assert.Equal(t, "http://127.0.0.1:32768", server)
+
+		return clientset, nil
+	})
+}
+
+func nodeNames(t *testing.T, clientset kubernetes.Interface) []string {
+	t.Helper()
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		names = append(names, node.Name)
+	}
+
+	return names
+}
+
+func TestCreateRegistersFakeNodesAndContext(t *testing.T) {
+	t.Parallel()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	clientset := fake.NewClientset()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ImageInspect(mock.Anything, kwokprovisioner.DefaultImage).Return(image.InspectResponse{}, nil)
+	client.EXPECT().
+		ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "dev-control-plane").
+		RunAndReturn(func(
+			_ context.Context,
+			config *container.Config,
+			hostConfig *container.HostConfig,
+			_ *network.NetworkingConfig,
+			_ *v1.Platform,
+			_ string,
+		) (container.CreateResponse, error) {
+			assert.Equal(t, "dev", config.Labels["io.ksail.kwok.cluster"])
+			assert.Equal(t, "127.0.0.1", hostConfig.PortBindings["8080/tcp"][0].HostIP)
+
+			return container.CreateResponse{ID: "dev-control-plane"}, nil
+		})
+	client.EXPECT().ContainerStart(mock.Anything, "dev-control-plane", mock.Anything).Return(nil)
+	client.EXPECT().ContainerInspect(mock.Anything, "dev-control-plane").Return(inspectWithPort("32768"), nil)
+
+	config := kwokprovisioner.NewConfig("dev")
+	config.Nodes = 3
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(
+		config,
+		kubeconfigPath,
+		client,
+		fakeClientsetFactory(t, clientset),
+	)
+
+	require.NoError(t, provisioner.Create(context.Background(), ""))
+
+	assert.ElementsMatch(t, []string{"kwok-node-1", "kwok-node-2", "kwok-node-3"}, nodeNames(t, clientset))
+
+	node, err := clientset.CoreV1().Nodes().Get(context.Background(), "kwok-node-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fake", node.Annotations["kwok.x-k8s.io/node"])
+
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, "kwok-dev", kubeconfig.CurrentContext)
+	assert.Equal(t, "http://127.0.0.1:32768", kubeconfig.Clusters["kwok-dev"].Server)
+}
+
+func TestScaleAddsAndRemovesNewestNodes(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{newControlPlane("dev-control-plane", "dev")}, nil)
+	client.EXPECT().ContainerInspect(mock.Anything, "dev-control-plane").Return(inspectWithPort("32768"), nil)
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(
+		kwokprovisioner.NewConfig("dev"),
+		"",
+		client,
+		fakeClientsetFactory(t, clientset),
+	)
+
+	require.NoError(t, provisioner.Scale(context.Background(), "", 12))
+	assert.Len(t, nodeNames(t, clientset), 12)
+
+	require.NoError(t, provisioner.Scale(context.Background(), "", 2))
+	assert.ElementsMatch(t, []string{"kwok-node-1", "kwok-node-2"}, nodeNames(t, clientset))
+
+	require.NoError(t, provisioner.Scale(context.Background(), "", 3))
+	assert.ElementsMatch(t, []string{"kwok-node-1", "kwok-node-2", "kwok-node-3"}, nodeNames(t, clientset))
+}
+
+func TestListReturnsDistinctClusterNames(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newControlPlane("dev-control-plane", "dev"),
+		newControlPlane("ci-control-plane", "ci"),
+	}, nil)
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(nil, "", client)
+
+	clusters, err := provisioner.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci", "dev"}, clusters)
+
+	exists, err := provisioner.Exists(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestStartReturnsErrClusterNotFound(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return(nil, nil)
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(nil, "", client)
+
+	err := provisioner.Start(context.Background(), "missing")
+	require.ErrorIs(t, err, kwokprovisioner.ErrClusterNotFound)
+}
+
+func TestDeleteRemovesControlPlaneAndContext(t *testing.T) {
+	t.Parallel()
+
+	contextName := kwokprovisioner.ContextName(kwokprovisioner.DefaultClusterName)
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfigPath, fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: http://127.0.0.1:32768
+users:
+- name: %[1]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+current-context: %[1]s
+`, contextName), 0o600))
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newControlPlane("kwok-default-control-plane", kwokprovisioner.DefaultClusterName),
+	}, nil)
+	client.EXPECT().ContainerRemove(mock.Anything, "kwok-default-control-plane", mock.Anything).Return(nil)
+
+	provisioner := kwokprovisioner.NewKwokClusterProvisioner(nil, kubeconfigPath, client)
+
+	require.NoError(t, provisioner.Delete(context.Background(), ""))
+
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.NotContains(t, kubeconfig.Contexts, contextName)
+	assert.Empty(t, kubeconfig.CurrentContext)
+}
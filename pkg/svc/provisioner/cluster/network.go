@@ -0,0 +1,41 @@
+package clusterprovisioner
+
+import (
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	kindprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/kind"
+)
+
+const (
+	k3dNetworkPrefix  = "k3d-"
+	k3dDefaultCluster = "k3d"
+)
+
+// DockerNetworkName returns the Docker network the nodes of the cluster clusterName are attached
+// to. Kind and K3d use spec.networking.dockerNetwork.name when it is set and their default
+// network otherwise; Talos and k0s use a network named after the cluster. Distributions without
+// a Docker network return "".
+func DockerNetworkName(cluster *v1alpha1.Cluster, clusterName string) string {
+	configured := strings.TrimSpace(cluster.Spec.Networking.DockerNetwork.Name)
+
+	switch cluster.Spec.Distribution {
+	case v1alpha1.DistributionKind:
+		return kindprovisioner.NetworkName(configured)
+	case v1alpha1.DistributionK3d:
+		if configured != "" {
+			return configured
+		}
+
+		trimmed := strings.TrimSpace(clusterName)
+		if trimmed == "" {
+			trimmed = k3dDefaultCluster
+		}
+
+		return k3dNetworkPrefix + trimmed
+	case v1alpha1.DistributionTalos, v1alpha1.DistributionK0s:
+		return clusterName
+	default:
+		return ""
+	}
+}
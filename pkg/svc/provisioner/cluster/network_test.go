@@ -0,0 +1,35 @@
+package clusterprovisioner_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerNetworkName(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1alpha1.NewCluster()
+
+	cluster.Spec.Distribution = v1alpha1.DistributionK3d
+	assert.Equal(t, "k3d-dev", clusterprovisioner.DockerNetworkName(cluster, "dev"))
+	assert.Equal(t, "k3d-k3d", clusterprovisioner.DockerNetworkName(cluster, ""))
+
+	cluster.Spec.Distribution = v1alpha1.DistributionTalos
+	assert.Equal(t, "dev", clusterprovisioner.DockerNetworkName(cluster, "dev"))
+
+	cluster.Spec.Distribution = v1alpha1.DistributionExternal
+	assert.Empty(t, clusterprovisioner.DockerNetworkName(cluster, "dev"))
+
+	cluster.Spec.Networking.DockerNetwork.Name = "ksail"
+
+	for _, distribution := range []v1alpha1.Distribution{
+		v1alpha1.DistributionKind,
+		v1alpha1.DistributionK3d,
+	} {
+		cluster.Spec.Distribution = distribution
+		assert.Equal(t, "ksail", clusterprovisioner.DockerNetworkName(cluster, "dev"), distribution)
+	}
+}
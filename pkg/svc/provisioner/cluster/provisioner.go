@@ -1,6 +1,9 @@
 package clusterprovisioner
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // ClusterProvisioner defines methods for managing Kubernetes clusters.
 type ClusterProvisioner interface {
@@ -22,3 +25,15 @@ type ClusterProvisioner interface {
 	// Exists checks if a Kubernetes cluster exists by name or config default when name is empty.
 	Exists(ctx context.Context, name string) (bool, error)
 }
+
+// ErrScalingNotSupported is returned when the provisioner of a distribution cannot change the
+// worker nodes of a running cluster.
+var ErrScalingNotSupported = errors.New("scaling worker nodes is not supported for this distribution")
+
+// NodeScaler is implemented by provisioners that can add or remove worker nodes of a running
+// cluster.
+type NodeScaler interface {
+	// Scale adds or removes worker nodes until the cluster has the given number of workers.
+	// If name is non-empty, target that name; otherwise use config defaults.
+	Scale(ctx context.Context, name string, workers int) error
+}
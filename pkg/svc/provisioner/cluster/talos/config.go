@@ -0,0 +1,80 @@
+package talosprovisioner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultClusterName is the cluster name used when none is configured.
+	DefaultClusterName = "talos-default"
+	// DefaultCIDR is the subnet the cluster network is created with.
+	DefaultCIDR = "10.5.0.0/24"
+	// DefaultImage is the Talos image of the machinery release the configurations are
+	// generated with.
+	DefaultImage = "ghcr.io/siderolabs/talos:v1.11.6"
+
+	defaultControlPlanes = 1
+	defaultWorkers       = 1
+)
+
+// Config is the content of talos.yaml, the distribution configuration of Talos clusters.
+// Zero values leave the defaults of Talos in place.
+type Config struct {
+	// Name is the cluster name.
+	Name string `json:"name,omitempty"`
+	// ControlPlanes is the number of control plane nodes.
+	ControlPlanes int `json:"controlPlanes,omitempty"`
+	// Workers is the number of worker nodes.
+	Workers int `json:"workers,omitempty"`
+	// Image is the Talos image the nodes run, e.g. ghcr.io/siderolabs/talos:v1.11.5.
+	Image string `json:"image,omitempty"`
+	// KubernetesVersion is the Kubernetes version to install, e.g. 1.34.1.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// CIDR is the subnet of the cluster network.
+	CIDR string `json:"cidr,omitempty"`
+	// DisableDefaultCNI skips the Flannel CNI Talos installs, so another CNI can be installed.
+	DisableDefaultCNI bool `json:"disableDefaultCNI,omitempty"`
+	// ConfigPatches are machine configuration patches applied to every node, as strategic
+	// merge or JSON patches, inline or as @file references.
+	ConfigPatches []string `json:"configPatches,omitempty"`
+}
+
+// NewDefaultConfig returns the configuration of a single control plane and worker cluster.
+func NewDefaultConfig() *Config {
+	return &Config{
+		Name:          DefaultClusterName,
+		ControlPlanes: defaultControlPlanes,
+		Workers:       defaultWorkers,
+		CIDR:          DefaultCIDR,
+	}
+}
+
+// LoadConfig reads the configuration at path over the defaults. A missing file yields the
+// defaults.
+func LoadConfig(path string) (*Config, error) {
+	config := NewDefaultConfig()
+
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the project's distribution config
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Talos configuration: %w", err)
+	}
+
+	err = yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Talos configuration %s: %w", path, err)
+	}
+
+	return config, nil
+}
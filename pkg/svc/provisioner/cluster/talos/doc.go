@@ -0,0 +1,8 @@
+// Package talosprovisioner provides the ClusterProvisioner implementation for Talos clusters
+// running in Docker.
+//
+// Machine configurations are generated with the Talos machinery, and each node runs as a
+// container on a network of the cluster, booting from its configuration. Etcd is bootstrapped
+// and the kubeconfig retrieved through the Talos API of the first control plane. Starting,
+// stopping and listing use the Docker API, through the labels put on the node containers.
+package talosprovisioner
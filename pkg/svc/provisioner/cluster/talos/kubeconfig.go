@@ -0,0 +1,100 @@
+package talosprovisioner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	iopath "github.com/devantler-tech/ksail-go/pkg/io"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const kubeconfigDirPerm = 0o750
+
+var errInvalidAdminKubeconfig = errors.New("talos admin kubeconfig has no context")
+
+// mergeKubeconfig merges the admin kubeconfig Talos generated into the kubeconfig at path as
+// contextName, and makes it the current context.
+func mergeKubeconfig(path string, adminKubeconfig []byte, contextName string) error {
+	admin, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse Talos admin kubeconfig: %w", err)
+	}
+
+	adminContext, ok := admin.Contexts[admin.CurrentContext]
+	if !ok || admin.Clusters[adminContext.Cluster] == nil || admin.AuthInfos[adminContext.AuthInfo] == nil {
+		return errInvalidAdminKubeconfig
+	}
+
+	path, config, err := loadKubeconfig(path)
+	if err != nil {
+		return err
+	}
+
+	config.Clusters[contextName] = admin.Clusters[adminContext.Cluster]
+	config.AuthInfos[contextName] = admin.AuthInfos[adminContext.AuthInfo]
+	config.Contexts[contextName] = &clientcmdapi.Context{Cluster: contextName, AuthInfo: contextName}
+	config.CurrentContext = contextName
+
+	return writeKubeconfig(path, config)
+}
+
+// removeKubeconfigContext removes the context, cluster and user mergeKubeconfig added.
+func removeKubeconfigContext(path, contextName string) error {
+	path, config, err := loadKubeconfig(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := config.Contexts[contextName]; !ok {
+		return nil
+	}
+
+	delete(config.Contexts, contextName)
+	delete(config.Clusters, contextName)
+	delete(config.AuthInfos, contextName)
+
+	if config.CurrentContext == contextName {
+		config.CurrentContext = ""
+	}
+
+	return writeKubeconfig(path, config)
+}
+
+func loadKubeconfig(path string) (string, *clientcmdapi.Config, error) {
+	if path == "" {
+		path = clientcmd.RecommendedHomeFile
+	}
+
+	path, err := iopath.ExpandHomePath(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expand kubeconfig path: %w", err)
+	}
+
+	config, err := clientcmd.LoadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, clientcmdapi.NewConfig(), nil
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	return path, config, nil
+}
+
+func writeKubeconfig(path string, config *clientcmdapi.Config) error {
+	err := os.MkdirAll(filepath.Dir(path), kubeconfigDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create kubeconfig directory: %w", err)
+	}
+
+	err = clientcmd.WriteToFile(*config, path)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+
+	return nil
+}
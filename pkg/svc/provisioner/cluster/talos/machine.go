@@ -0,0 +1,218 @@
+package talosprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	machineapi "github.com/siderolabs/talos/pkg/machinery/api/machine"
+	"github.com/siderolabs/talos/pkg/machinery/client"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	"github.com/siderolabs/talos/pkg/machinery/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/bundle"
+	"github.com/siderolabs/talos/pkg/machinery/config/configpatcher"
+	"github.com/siderolabs/talos/pkg/machinery/config/encoder"
+	"github.com/siderolabs/talos/pkg/machinery/config/generate"
+	"github.com/siderolabs/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/siderolabs/talos/pkg/machinery/constants"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const kubernetesAPIPort = "6443"
+
+var (
+	errSubnetTooSmall = errors.New("talos cluster subnet is too small")
+	errNotReady       = errors.New("talos API did not become ready")
+)
+
+// MachineClient is the subset of the Talos API Create uses to bring up the cluster.
+type MachineClient interface {
+	Bootstrap(ctx context.Context, req *machineapi.BootstrapRequest) error
+	Kubeconfig(ctx context.Context) ([]byte, error)
+	Close() error
+}
+
+// MachineClientFactory connects to the Talos API at endpoint with the client configuration
+// generated for the cluster.
+type MachineClientFactory func(
+	ctx context.Context,
+	talosConfig *clientconfig.Config,
+	endpoint string,
+) (MachineClient, error)
+
+// machineConfigs are the generated configurations of the cluster.
+type machineConfigs struct {
+	controlPlane []byte
+	worker       []byte
+	talosConfig  *clientconfig.Config
+}
+
+//nolint:ireturn // Factories return the MachineClient abstraction.
+func newMachineClient(
+	ctx context.Context,
+	talosConfig *clientconfig.Config,
+	endpoint string,
+) (MachineClient, error) {
+	machineClient, err := client.New(ctx, client.WithConfig(talosConfig), client.WithEndpoints(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the Talos API at %s: %w", endpoint, err)
+	}
+
+	return machineClient, nil
+}
+
+// generateConfigs generates the machine configurations of the control planes and workers of
+// the cluster, and the client configuration to reach their Talos API.
+func generateConfigs(cfg *Config, target string, nodes addresses) (*machineConfigs, error) {
+	endpoints := make([]string, 0, len(nodes.controlPlanes))
+	for _, address := range nodes.controlPlanes {
+		endpoints = append(endpoints, address.String())
+	}
+
+	genOptions := []generate.Option{generate.WithEndpointList(endpoints)}
+
+	if contract := versionContract(cfg.Image); contract != nil {
+		genOptions = append(genOptions, generate.WithVersionContract(contract))
+	}
+
+	if cfg.DisableDefaultCNI {
+		genOptions = append(genOptions, generate.WithClusterCNIConfig(&v1alpha1.CNIConfig{
+			CNIName: constants.NoneCNI,
+		}))
+	}
+
+	patches, err := configpatcher.LoadPatches(cfg.ConfigPatches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Talos config patches: %w", err)
+	}
+
+	configBundle, err := bundle.NewBundle(
+		bundle.WithInputOptions(&bundle.InputOptions{
+			ClusterName: target,
+			Endpoint:    "https://" + net.JoinHostPort(endpoints[0], kubernetesAPIPort),
+			KubeVersion: kubernetesVersion(cfg),
+			GenOptions:  genOptions,
+		}),
+		bundle.WithPatch(patches),
+		bundle.WithVerbose(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Talos machine configurations: %w", err)
+	}
+
+	controlPlane, err := configBundle.ControlPlane().EncodeBytes(encoder.WithComments(encoder.CommentsDisabled))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode control plane configuration: %w", err)
+	}
+
+	worker, err := configBundle.Worker().EncodeBytes(encoder.WithComments(encoder.CommentsDisabled))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode worker configuration: %w", err)
+	}
+
+	return &machineConfigs{
+		controlPlane: controlPlane,
+		worker:       worker,
+		talosConfig:  configBundle.TalosConfig(),
+	}, nil
+}
+
+// bootstrap bootstraps etcd on the control plane at endpoint and returns the admin kubeconfig
+// of the cluster, retrying while its Talos API comes up.
+func (t *TalosClusterProvisioner) bootstrap(
+	ctx context.Context,
+	configs *machineConfigs,
+	endpoint netip.Addr,
+) ([]byte, error) {
+	readyCtx, cancel := context.WithTimeout(ctx, t.readyTimeout)
+	defer cancel()
+
+	machineClient, err := t.machineClients(readyCtx, configs.talosConfig, endpoint.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = machineClient.Close() }()
+
+	err = retry(readyCtx, func() error {
+		err := machineClient.Bootstrap(readyCtx, &machineapi.BootstrapRequest{})
+		if status.Code(err) == codes.AlreadyExists {
+			return nil
+		}
+
+		return err //nolint:wrapcheck // Wrapped once retrying gives up.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bootstrap %s: %w", endpoint, err)
+	}
+
+	var kubeconfig []byte
+
+	err = retry(readyCtx, func() error {
+		kubeconfig, err = machineClient.Kubeconfig(readyCtx)
+
+		return err //nolint:wrapcheck // Wrapped once retrying gives up.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve kubeconfig from %s: %w", endpoint, err)
+	}
+
+	return kubeconfig, nil
+}
+
+// retry calls attempt every pollInterval until it succeeds or ctx is done, returning the last
+// error of attempt then.
+func retry(ctx context.Context, attempt func() error) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return ctx.Err()
+			}
+
+			return fmt.Errorf("%w: %w", errNotReady, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// versionContract returns the configuration contract of the Talos version the image is tagged
+// with, or nil for the current one when the tag is not a version.
+func versionContract(image string) *config.VersionContract {
+	if image == "" {
+		image = DefaultImage
+	}
+
+	_, tag, found := strings.Cut(image[strings.LastIndex(image, "/")+1:], ":")
+	if !found {
+		return nil
+	}
+
+	contract, err := config.ParseContractFromVersion(tag)
+	if err != nil {
+		return nil
+	}
+
+	return contract
+}
+
+func kubernetesVersion(cfg *Config) string {
+	if cfg.KubernetesVersion == "" {
+		return constants.DefaultKubernetesVersion
+	}
+
+	return strings.TrimPrefix(cfg.KubernetesVersion, "v")
+}
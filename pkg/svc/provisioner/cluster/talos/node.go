@@ -0,0 +1,225 @@
+package talosprovisioner
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/netip"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/siderolabs/talos/pkg/machinery/config/machine"
+)
+
+// controlPlaneType is the talos.type label of control plane nodes.
+const controlPlaneType = "controlplane"
+
+// Directories Talos keeps in memory, and those it needs to persist across restarts of a
+// node container; the same layout talosctl gives Docker nodes.
+//
+//nolint:gochecknoglobals // read-only container layout
+var (
+	tmpfsPaths  = []string{"/run", "/system", "/tmp"}
+	volumePaths = []string{"/var", "/system/state", "/etc/cni", "/etc/kubernetes", "/usr/libexec/kubernetes", "/opt"}
+)
+
+// addresses are the cluster network and the addresses of its nodes.
+type addresses struct {
+	subnet        netip.Prefix
+	gateway       netip.Addr
+	controlPlanes []netip.Addr
+	workers       []netip.Addr
+}
+
+// nodeAddresses assigns the nodes of config the addresses after the gateway of the cluster
+// subnet, the control planes first.
+func nodeAddresses(config *Config) (addresses, error) {
+	cidr := config.CIDR
+	if cidr == "" {
+		cidr = DefaultCIDR
+	}
+
+	subnet, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return addresses{}, fmt.Errorf("invalid Talos cluster CIDR %q: %w", cidr, err)
+	}
+
+	subnet = subnet.Masked()
+	result := addresses{subnet: subnet, gateway: subnet.Addr().Next()}
+
+	next := result.gateway.Next()
+
+	for range max(config.ControlPlanes, 1) {
+		result.controlPlanes = append(result.controlPlanes, next)
+		next = next.Next()
+	}
+
+	for range config.Workers {
+		result.workers = append(result.workers, next)
+		next = next.Next()
+	}
+
+	last := result.controlPlanes[len(result.controlPlanes)-1]
+	if len(result.workers) > 0 {
+		last = result.workers[len(result.workers)-1]
+	}
+
+	if !subnet.Contains(last) {
+		return addresses{}, fmt.Errorf("%w: %s has no address for every node", errSubnetTooSmall, subnet)
+	}
+
+	return result, nil
+}
+
+func (t *TalosClusterProvisioner) ensureImage(ctx context.Context) error {
+	_, err := t.client.ImageInspect(ctx, t.image())
+	if err == nil {
+		return nil
+	}
+
+	reader, err := t.client.ImagePull(ctx, t.image(), image.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull Talos image %s: %w", t.image(), err)
+	}
+
+	_, err = io.Copy(io.Discard, reader)
+	closeErr := reader.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to read image pull output: %w", err)
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close image pull reader: %w", closeErr)
+	}
+
+	return nil
+}
+
+// ensureNetwork creates the network of the cluster, named after it, on the cluster subnet.
+func (t *TalosClusterProvisioner) ensureNetwork(ctx context.Context, target string, nodes addresses) error {
+	_, err := t.client.NetworkInspect(ctx, target, network.InspectOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to inspect network %s: %w", target, err)
+	}
+
+	_, err = t.client.NetworkCreate(ctx, target, network.CreateOptions{
+		Driver: "bridge",
+		Labels: map[string]string{ownedLabel: "true", clusterNameLabel: target},
+		IPAM: &network.IPAM{Config: []network.IPAMConfig{{
+			Subnet:  nodes.subnet.String(),
+			Gateway: nodes.gateway.String(),
+		}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create network %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// createNodes creates and starts a container per node, each booting from its machine
+// configuration.
+func (t *TalosClusterProvisioner) createNodes(
+	ctx context.Context,
+	target string,
+	nodes addresses,
+	configs *machineConfigs,
+) error {
+	for index, address := range nodes.controlPlanes {
+		name := fmt.Sprintf("%s-controlplane-%d", target, index+1)
+
+		err := t.createNode(ctx, target, name, machine.TypeControlPlane, address, configs.controlPlane)
+		if err != nil {
+			return err
+		}
+	}
+
+	for index, address := range nodes.workers {
+		name := fmt.Sprintf("%s-worker-%d", target, index+1)
+
+		err := t.createNode(ctx, target, name, machine.TypeWorker, address, configs.worker)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *TalosClusterProvisioner) createNode(
+	ctx context.Context,
+	target, name string,
+	machineType machine.Type,
+	address netip.Addr,
+	machineConfig []byte,
+) error {
+	volumes := make(map[string]struct{}, len(volumePaths))
+	mounts := make([]mount.Mount, 0, len(tmpfsPaths)+len(volumePaths))
+
+	for _, path := range tmpfsPaths {
+		mounts = append(mounts, mount.Mount{Type: mount.TypeTmpfs, Target: path})
+	}
+
+	for _, path := range volumePaths {
+		volumes[path] = struct{}{}
+		mounts = append(mounts, mount.Mount{Type: mount.TypeVolume, Target: path})
+	}
+
+	config := &container.Config{
+		Image:    t.image(),
+		Hostname: name,
+		Env: []string{
+			"PLATFORM=container",
+			"USERDATA=" + base64.StdEncoding.EncodeToString(machineConfig),
+		},
+		Labels: map[string]string{
+			ownedLabel:       "true",
+			clusterNameLabel: target,
+			typeLabel:        machineType.String(),
+		},
+		Volumes: volumes,
+	}
+
+	hostConfig := &container.HostConfig{
+		Privileged:     true,
+		SecurityOpt:    []string{"seccomp:unconfined"},
+		ReadonlyRootfs: true,
+		Mounts:         mounts,
+		NetworkMode:    container.NetworkMode(target),
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			target: {IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: address.String()}},
+		},
+	}
+
+	created, err := t.client.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, name)
+	if err != nil {
+		return fmt.Errorf("failed to create node %s: %w", name, err)
+	}
+
+	err = t.client.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start node %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (t *TalosClusterProvisioner) image() string {
+	if t.config.Image != "" {
+		return t.config.Image
+	}
+
+	return DefaultImage
+}
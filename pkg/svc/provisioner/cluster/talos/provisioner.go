@@ -0,0 +1,285 @@
+package talosprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+const (
+	ownedLabel       = "talos.owned"
+	clusterNameLabel = "talos.cluster.name"
+	typeLabel        = "talos.type"
+
+	defaultReadyTimeout = 5 * time.Minute
+	pollInterval        = 2 * time.Second
+	dockerStartTimeout  = 30 * time.Second
+	dockerStopTimeout   = 60 * time.Second
+)
+
+// ErrClusterNotFound is returned when no node containers exist for the cluster.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// Option configures the Talos provisioner.
+type Option func(*TalosClusterProvisioner)
+
+// WithMachineClientFactory replaces how Create connects to the Talos API of the nodes
+// (primarily for tests).
+func WithMachineClientFactory(factory MachineClientFactory) Option {
+	return func(provisioner *TalosClusterProvisioner) {
+		if factory != nil {
+			provisioner.machineClients = factory
+		}
+	}
+}
+
+// WithReadyTimeout sets how long Create waits for the Talos API to bootstrap the cluster and
+// serve its kubeconfig.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(provisioner *TalosClusterProvisioner) {
+		if timeout > 0 {
+			provisioner.readyTimeout = timeout
+		}
+	}
+}
+
+// TalosClusterProvisioner manages Talos clusters whose nodes run as Docker containers.
+type TalosClusterProvisioner struct {
+	config         *Config
+	kubeconfigPath string
+	client         client.APIClient
+	machineClients MachineClientFactory
+	readyTimeout   time.Duration
+}
+
+// NewTalosClusterProvisioner constructs a provisioner for the clusters described by config.
+func NewTalosClusterProvisioner(
+	config *Config,
+	kubeconfigPath string,
+	dockerClient client.APIClient,
+	opts ...Option,
+) *TalosClusterProvisioner {
+	if config == nil {
+		config = NewDefaultConfig()
+	}
+
+	prov := &TalosClusterProvisioner{
+		config:         config,
+		kubeconfigPath: kubeconfigPath,
+		client:         dockerClient,
+		machineClients: newMachineClient,
+		readyTimeout:   defaultReadyTimeout,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(prov)
+		}
+	}
+
+	return prov
+}
+
+// Create generates the machine configurations of the cluster, starts a container per node,
+// bootstraps etcd on the first control plane and merges the admin kubeconfig of the cluster into
+// the configured kubeconfig file as context admin@<name>.
+func (t *TalosClusterProvisioner) Create(ctx context.Context, name string) error {
+	target := t.resolveName(name)
+
+	addresses, err := nodeAddresses(t.config)
+	if err != nil {
+		return err
+	}
+
+	configs, err := generateConfigs(t.config, target, addresses)
+	if err != nil {
+		return err
+	}
+
+	err = t.ensureImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = t.ensureNetwork(ctx, target, addresses)
+	if err != nil {
+		return err
+	}
+
+	err = t.createNodes(ctx, target, addresses, configs)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := t.bootstrap(ctx, configs, addresses.controlPlanes[0])
+	if err != nil {
+		return err
+	}
+
+	return mergeKubeconfig(t.kubeconfigPath, kubeconfig, ContextName(target))
+}
+
+// Delete removes the node containers and network of the cluster and its kube context.
+func (t *TalosClusterProvisioner) Delete(ctx context.Context, name string) error {
+	target := t.resolveName(name)
+
+	nodes, err := t.listNodes(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		err := t.client.ContainerRemove(ctx, node.ID, container.RemoveOptions{
+			RemoveVolumes: true,
+			Force:         true,
+		})
+		if err != nil {
+			return fmt.Errorf("docker remove failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	err = t.client.NetworkRemove(ctx, target)
+	if err != nil && !cerrdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to remove network %s: %w", target, err)
+	}
+
+	return removeKubeconfigContext(t.kubeconfigPath, ContextName(target))
+}
+
+// Start starts the node containers of a stopped cluster, the control planes first.
+func (t *TalosClusterProvisioner) Start(ctx context.Context, name string) error {
+	nodes, err := t.listNodes(ctx, t.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStartTimeout)
+	defer cancel()
+
+	for _, node := range nodes {
+		err := t.client.ContainerStart(timeoutCtx, node.ID, container.StartOptions{})
+		if err != nil {
+			return fmt.Errorf("docker start failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the node containers of a cluster, the workers first.
+func (t *TalosClusterProvisioner) Stop(ctx context.Context, name string) error {
+	nodes, err := t.listNodes(ctx, t.resolveName(name))
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, dockerStopTimeout)
+	defer cancel()
+
+	for _, node := range slices.Backward(nodes) {
+		err := t.client.ContainerStop(timeoutCtx, node.ID, container.StopOptions{})
+		if err != nil {
+			return fmt.Errorf("docker stop failed for %s: %w", nodeName(node), err)
+		}
+	}
+
+	return nil
+}
+
+// List returns the names of the Talos clusters that have node containers.
+func (t *TalosClusterProvisioner) List(ctx context.Context) ([]string, error) {
+	containers, err := t.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", ownedLabel+"=true")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Talos containers: %w", err)
+	}
+
+	var names []string
+
+	for _, node := range containers {
+		name := node.Labels[clusterNameLabel]
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// Exists returns whether the target cluster has node containers.
+func (t *TalosClusterProvisioner) Exists(ctx context.Context, name string) (bool, error) {
+	clusters, err := t.List(ctx)
+	if err != nil {
+		return false, fmt.Errorf("list: %w", err)
+	}
+
+	return slices.Contains(clusters, t.resolveName(name)), nil
+}
+
+// ContextName returns the kube context Create merges the kubeconfig of the cluster as, the
+// admin context Talos generates.
+func ContextName(clusterName string) string {
+	return "admin@" + clusterName
+}
+
+// listNodes returns the node containers of the cluster, the control planes first.
+func (t *TalosClusterProvisioner) listNodes(ctx context.Context, target string) ([]container.Summary, error) {
+	containers, err := t.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", clusterNameLabel+"="+target)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for cluster '%s': %w", target, err)
+	}
+
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("cluster '%s': %w", target, ErrClusterNotFound)
+	}
+
+	slices.SortStableFunc(containers, func(a, b container.Summary) int {
+		return strings.Compare(nodeSortKey(a), nodeSortKey(b))
+	})
+
+	return containers, nil
+}
+
+func (t *TalosClusterProvisioner) resolveName(name string) string {
+	if strings.TrimSpace(name) != "" {
+		return name
+	}
+
+	if strings.TrimSpace(t.config.Name) != "" {
+		return t.config.Name
+	}
+
+	return DefaultClusterName
+}
+
+func nodeSortKey(node container.Summary) string {
+	role := "1"
+	if node.Labels[typeLabel] == controlPlaneType {
+		role = "0"
+	}
+
+	return role + nodeName(node)
+}
+
+func nodeName(node container.Summary) string {
+	if len(node.Names) == 0 {
+		return node.ID
+	}
+
+	return strings.TrimPrefix(node.Names[0], "/")
+}
@@ -0,0 +1,318 @@
+package talosprovisioner_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/devantler-tech/ksail-go/pkg/client/docker"
+	talosprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster/talos"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	machineapi "github.com/siderolabs/talos/pkg/machinery/api/machine"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const adminKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://10.5.0.2:6443
+users:
+- name: admin@dev
+  user:
+    token: secret
+contexts:
+- name: admin@dev
+  context:
+    cluster: dev
+    user: admin@dev
+current-context: admin@dev
+`
+
+var errBoom = errors.New("boom")
+
+type fakeMachineClient struct {
+	endpoint       string
+	bootstrapErrs  []error
+	bootstrapCalls int
+	closed         bool
+}
+
+func (f *fakeMachineClient) Bootstrap(context.Context, *machineapi.BootstrapRequest) error {
+	f.bootstrapCalls++
+
+	if len(f.bootstrapErrs) == 0 {
+		return nil
+	}
+
+	err := f.bootstrapErrs[0]
+	f.bootstrapErrs = f.bootstrapErrs[1:]
+
+	return err
+}
+
+func (f *fakeMachineClient) Kubeconfig(context.Context) ([]byte, error) {
+	return []byte(adminKubeconfig), nil
+}
+
+func (f *fakeMachineClient) Close() error {
+	f.closed = true
+
+	return nil
+}
+
+func (f *fakeMachineClient) factory(
+	_ context.Context,
+	_ *clientconfig.Config,
+	endpoint string,
+) (talosprovisioner.MachineClient, error) {
+	f.endpoint = endpoint
+
+	return f, nil
+}
+
+func newNode(id, cluster, machineType string) container.Summary {
+	return container.Summary{
+		ID:    id,
+		Names: []string{"/" + id},
+		Labels: map[string]string{
+			"talos.owned":        "true",
+			"talos.cluster.name": cluster,
+			"talos.type":         machineType,
+		},
+	}
+}
+
+//nolint:funlen // Follows a whole cluster creation through the Docker API.
+func TestCreateStartsNodesFromGeneratedConfigsAndMergesKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	config := talosprovisioner.NewDefaultConfig()
+	config.Workers = 2
+	config.DisableDefaultCNI = true
+	config.ConfigPatches = []string{`machine: {network: {hostname: patched}}`}
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ImageInspect(mock.Anything, talosprovisioner.DefaultImage).
+		Return(image.InspectResponse{}, nil)
+	client.EXPECT().NetworkInspect(mock.Anything, "dev", mock.Anything).
+		Return(network.Inspect{}, cerrdefs.ErrNotFound)
+
+	var networkOptions network.CreateOptions
+
+	client.EXPECT().NetworkCreate(mock.Anything, "dev", mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, options network.CreateOptions) (network.CreateResponse, error) {
+			networkOptions = options
+
+			return network.CreateResponse{}, nil
+		})
+
+	created := map[string]string{}
+
+	var workerConfig *container.Config
+
+	client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(
+			_ context.Context,
+			config *container.Config,
+			hostConfig *container.HostConfig,
+			networkConfig *network.NetworkingConfig,
+			_ *ocispec.Platform,
+			name string,
+		) (container.CreateResponse, error) {
+			assert.True(t, hostConfig.Privileged)
+			assert.Equal(t, container.NetworkMode("dev"), hostConfig.NetworkMode)
+
+			created[name] = networkConfig.EndpointsConfig["dev"].IPAMConfig.IPv4Address
+			if name == "dev-worker-1" {
+				workerConfig = config
+			}
+
+			return container.CreateResponse{ID: name}, nil
+		})
+	client.EXPECT().ContainerStart(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	machineClient := &fakeMachineClient{bootstrapErrs: []error{status.Error(codes.AlreadyExists, "bootstrapped")}}
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(
+		config,
+		kubeconfigPath,
+		client,
+		talosprovisioner.WithMachineClientFactory(machineClient.factory),
+	)
+
+	require.NoError(t, provisioner.Create(context.Background(), "dev"))
+
+	assert.Equal(t, "10.5.0.0/24", networkOptions.IPAM.Config[0].Subnet)
+	assert.Equal(t, map[string]string{
+		"dev-controlplane-1": "10.5.0.2",
+		"dev-worker-1":       "10.5.0.3",
+		"dev-worker-2":       "10.5.0.4",
+	}, created)
+	assert.Equal(t, "10.5.0.2", machineClient.endpoint)
+	assert.Equal(t, 1, machineClient.bootstrapCalls)
+	assert.True(t, machineClient.closed)
+
+	require.NotNil(t, workerConfig)
+	assert.Equal(t, "worker", workerConfig.Labels["talos.type"])
+
+	userData, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(workerConfig.Env[1], "USERDATA="))
+	require.NoError(t, err)
+
+	machineConfig, err := configloader.NewFromBytes(userData)
+	require.NoError(t, err)
+	assert.Equal(t, "none", machineConfig.Cluster().Network().CNI().Name())
+	assert.Equal(t, "https://10.5.0.2:6443", machineConfig.Cluster().Endpoint().String())
+	assert.Equal(t, "patched", machineConfig.RawV1Alpha1().MachineConfig.MachineNetwork.NetworkHostname)
+
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, talosprovisioner.ContextName("dev"), kubeconfig.CurrentContext)
+	assert.Equal(t, "https://10.5.0.2:6443", kubeconfig.Clusters["admin@dev"].Server)
+}
+
+func TestCreateRejectsSubnetWithoutRoomForEveryNode(t *testing.T) {
+	t.Parallel()
+
+	config := talosprovisioner.NewDefaultConfig()
+	config.CIDR = "10.5.0.0/30"
+	config.Workers = 3
+
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(config, "", docker.NewMockAPIClient(t))
+
+	err := provisioner.Create(context.Background(), "dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "10.5.0.0/30")
+}
+
+func TestCreateReportsDockerErrors(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ImageInspect(mock.Anything, mock.Anything).Return(image.InspectResponse{}, errBoom)
+	client.EXPECT().ImagePull(mock.Anything, talosprovisioner.DefaultImage, mock.Anything).Return(nil, errBoom)
+
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(nil, "", client)
+
+	err := provisioner.Create(context.Background(), "")
+	require.ErrorIs(t, err, errBoom)
+}
+
+func TestDeleteRemovesNodesNetworkAndContext(t *testing.T) {
+	t.Parallel()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(adminKubeconfig), 0o600))
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		newNode("dev-worker-1", "dev", "worker"),
+		newNode("dev-controlplane-1", "dev", "controlplane"),
+	}, nil)
+	client.EXPECT().ContainerRemove(mock.Anything, "dev-worker-1", mock.Anything).Return(nil)
+	client.EXPECT().ContainerRemove(mock.Anything, "dev-controlplane-1", mock.Anything).Return(nil)
+	client.EXPECT().NetworkRemove(mock.Anything, "dev").Return(cerrdefs.ErrNotFound)
+
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(nil, kubeconfigPath, client)
+
+	require.NoError(t, provisioner.Delete(context.Background(), "dev"))
+
+	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
+	require.NoError(t, err)
+	assert.NotContains(t, kubeconfig.Contexts, "admin@dev")
+	assert.Empty(t, kubeconfig.CurrentContext)
+}
+
+func TestLifecycleUsesNodeContainers(t *testing.T) {
+	t.Parallel()
+
+	client := docker.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, options container.ListOptions) ([]container.Summary, error) {
+			var matches []container.Summary
+
+			for _, node := range []container.Summary{
+				newNode("dev-worker-1", "dev", "worker"),
+				newNode("dev-controlplane-1", "dev", "controlplane"),
+				newNode("other-controlplane-1", "other", "controlplane"),
+			} {
+				if options.Filters.ExactMatch("label", "talos.owned=true") ||
+					options.Filters.ExactMatch("label", "talos.cluster.name="+node.Labels["talos.cluster.name"]) {
+					matches = append(matches, node)
+				}
+			}
+
+			return matches, nil
+		})
+
+	var started, stopped []string
+
+	client.EXPECT().ContainerStart(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string, _ container.StartOptions) error {
+			started = append(started, id)
+
+			return nil
+		})
+	client.EXPECT().ContainerStop(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string, _ container.StopOptions) error {
+			stopped = append(stopped, id)
+
+			return nil
+		})
+
+	provisioner := talosprovisioner.NewTalosClusterProvisioner(nil, "", client)
+
+	clusters, err := provisioner.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "other"}, clusters)
+
+	exists, err := provisioner.Exists(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, exists, "the default cluster has no nodes")
+
+	require.NoError(t, provisioner.Start(context.Background(), "dev"))
+	assert.Equal(t, []string{"dev-controlplane-1", "dev-worker-1"}, started)
+
+	require.NoError(t, provisioner.Stop(context.Background(), "dev"))
+	assert.Equal(t, []string{"dev-worker-1", "dev-controlplane-1"}, stopped)
+
+	require.ErrorIs(t, provisioner.Start(context.Background(), "missing"), talosprovisioner.ErrClusterNotFound)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := talosprovisioner.LoadConfig(filepath.Join(t.TempDir(), "talos.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, talosprovisioner.NewDefaultConfig(), config)
+
+	path := filepath.Join(t.TempDir(), "talos.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: dev\nworkers: 0\ncontrolPlanes: 3\n"), 0o600))
+
+	config, err = talosprovisioner.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", config.Name)
+	assert.Equal(t, 3, config.ControlPlanes)
+	assert.Equal(t, talosprovisioner.DefaultCIDR, config.CIDR)
+
+	require.NoError(t, os.WriteFile(path, []byte("nodes: 3\n"), 0o600))
+
+	_, err = talosprovisioner.LoadConfig(path)
+	require.Error(t, err)
+}
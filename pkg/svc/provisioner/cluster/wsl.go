@@ -0,0 +1,142 @@
+package clusterprovisioner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/io/wsl"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// WSLLoopbackAddress is the address wildcard bindings are pinned to under WSL2. WSL forwards
+// ports bound to the distribution's loopback interface to localhost on Windows, so browsers,
+// kubectl.exe and IDEs reach them without extra setup.
+const WSLLoopbackAddress = "127.0.0.1"
+
+const (
+	wslK3dConfigPrefix = "ksail-wsl-k3d-"
+	wslK3dConfigPerm   = 0o600
+	k3dPortSeparator   = ":"
+)
+
+//nolint:gochecknoglobals // compiled once for volume parsing
+var windowsVolumeSource = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+
+// AdaptKindConfigForWSL rewrites a Kind configuration for a WSL2 host.
+//
+// The API server address and extra port mappings that listen on all interfaces are pinned
+// to WSLLoopbackAddress, and Windows host paths of extra mounts are translated to their
+// location inside the distribution.
+func AdaptKindConfigForWSL(cfg *v1alpha4.Cluster) {
+	if cfg == nil {
+		return
+	}
+
+	cfg.Networking.APIServerAddress = pinWildcardAddress(cfg.Networking.APIServerAddress)
+
+	for i := range cfg.Nodes {
+		node := &cfg.Nodes[i]
+
+		for j := range node.ExtraPortMappings {
+			mapping := &node.ExtraPortMappings[j]
+			mapping.ListenAddress = pinWildcardAddress(mapping.ListenAddress)
+		}
+
+		for j := range node.ExtraMounts {
+			mount := &node.ExtraMounts[j]
+			mount.HostPath = wsl.ToLinuxPath(mount.HostPath)
+		}
+	}
+}
+
+// AdaptK3dConfigForWSL rewrites a K3d simple configuration for a WSL2 host.
+//
+// The API server and port mappings without a host IP are pinned to WSLLoopbackAddress, so
+// the generated kubeconfig no longer points at 0.0.0.0, and Windows volume sources are
+// translated to their location inside the distribution.
+func AdaptK3dConfigForWSL(cfg *k3dv1alpha5.SimpleConfig) {
+	if cfg == nil {
+		return
+	}
+
+	cfg.ExposeAPI.HostIP = pinWildcardAddress(cfg.ExposeAPI.HostIP)
+
+	for i := range cfg.Ports {
+		cfg.Ports[i].Port = pinK3dPort(cfg.Ports[i].Port)
+	}
+
+	for i := range cfg.Volumes {
+		cfg.Volumes[i].Volume = translateK3dVolume(cfg.Volumes[i].Volume)
+	}
+}
+
+// writeWSLK3dConfig writes cfg to a temporary file so the k3d CLI, which reads its
+// configuration from disk, sees the WSL adaptations.
+func writeWSLK3dConfig(cfg *k3dv1alpha5.SimpleConfig) (string, error) {
+	content, err := yamlmarshaller.NewMarshaller[k3dv1alpha5.SimpleConfig]().Marshal(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("marshal WSL k3d config: %w", err)
+	}
+
+	name := strings.ToLower(strings.TrimSpace(cfg.Name))
+	if name == "" {
+		name = "default"
+	}
+
+	path := filepath.Join(os.TempDir(), wslK3dConfigPrefix+name+".yaml")
+
+	err = os.WriteFile(path, []byte(content), wslK3dConfigPerm)
+	if err != nil {
+		return "", fmt.Errorf("write WSL k3d config: %w", err)
+	}
+
+	return path, nil
+}
+
+func pinWildcardAddress(address string) string {
+	switch strings.TrimSpace(address) {
+	case "", "0.0.0.0", "::", "[::]":
+		return WSLLoopbackAddress
+	default:
+		return address
+	}
+}
+
+// pinK3dPort pins a [HOST:][HOSTPORT:]CONTAINERPORT[/PROTOCOL] mapping that publishes a host
+// port on all interfaces to the loopback address.
+func pinK3dPort(port string) string {
+	parts := strings.Split(port, k3dPortSeparator)
+
+	switch len(parts) {
+	case 2: //nolint:mnd // HOSTPORT:CONTAINERPORT
+		return WSLLoopbackAddress + k3dPortSeparator + port
+	case 3: //nolint:mnd // HOST:HOSTPORT:CONTAINERPORT
+		parts[0] = pinWildcardAddress(parts[0])
+
+		return strings.Join(parts, k3dPortSeparator)
+	default:
+		return port
+	}
+}
+
+// translateK3dVolume translates the Windows source of a SOURCE:DEST[:MODE] volume.
+func translateK3dVolume(volume string) string {
+	if !windowsVolumeSource.MatchString(volume) {
+		return volume
+	}
+
+	// The drive letter is followed by a colon, so the source ends at the next one.
+	end := strings.Index(volume[2:], k3dPortSeparator)
+	if end < 0 {
+		return wsl.ToLinuxPath(volume)
+	}
+
+	end += 2
+
+	return wsl.ToLinuxPath(volume[:end]) + volume[end:]
+}
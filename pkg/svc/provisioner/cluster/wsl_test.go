@@ -0,0 +1,70 @@
+package clusterprovisioner_test
+
+import (
+	"testing"
+
+	clusterprovisioner "github.com/devantler-tech/ksail-go/pkg/svc/provisioner/cluster"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+func TestAdaptKindConfigForWSL(t *testing.T) {
+	t.Parallel()
+
+	cfg := &v1alpha4.Cluster{
+		Networking: v1alpha4.Networking{APIServerAddress: "0.0.0.0"},
+		Nodes: []v1alpha4.Node{{
+			Role: v1alpha4.ControlPlaneRole,
+			ExtraPortMappings: []v1alpha4.PortMapping{
+				{ContainerPort: 80, HostPort: 8080},
+				{ContainerPort: 443, HostPort: 8443, ListenAddress: "192.168.1.10"},
+			},
+			ExtraMounts: []v1alpha4.Mount{
+				{HostPath: `C:\src\app`, ContainerPath: "/app"},
+				{HostPath: "/home/me/data", ContainerPath: "/data"},
+			},
+		}},
+	}
+
+	clusterprovisioner.AdaptKindConfigForWSL(cfg)
+
+	assert.Equal(t, clusterprovisioner.WSLLoopbackAddress, cfg.Networking.APIServerAddress)
+	assert.Equal(t, clusterprovisioner.WSLLoopbackAddress, cfg.Nodes[0].ExtraPortMappings[0].ListenAddress)
+	assert.Equal(t, "192.168.1.10", cfg.Nodes[0].ExtraPortMappings[1].ListenAddress)
+	assert.Equal(t, "/mnt/c/src/app", cfg.Nodes[0].ExtraMounts[0].HostPath)
+	assert.Equal(t, "/home/me/data", cfg.Nodes[0].ExtraMounts[1].HostPath)
+}
+
+func TestAdaptK3dConfigForWSL(t *testing.T) {
+	t.Parallel()
+
+	cfg := &k3dv1alpha5.SimpleConfig{
+		Ports: []k3dv1alpha5.PortWithNodeFilters{
+			{Port: "8080:80"},
+			{Port: "0.0.0.0:8443:443/tcp"},
+			{Port: "10.0.0.5:9000:9000"},
+			{Port: "7000"},
+		},
+		Volumes: []k3dv1alpha5.VolumeWithNodeFilters{
+			{Volume: `C:\data:/data:ro`},
+			{Volume: `D:\cache`},
+			{Volume: "/srv/shared:/shared"},
+		},
+	}
+
+	clusterprovisioner.AdaptK3dConfigForWSL(cfg)
+
+	assert.Equal(t, clusterprovisioner.WSLLoopbackAddress, cfg.ExposeAPI.HostIP)
+	assert.Equal(t, []string{
+		"127.0.0.1:8080:80",
+		"127.0.0.1:8443:443/tcp",
+		"10.0.0.5:9000:9000",
+		"7000",
+	}, []string{cfg.Ports[0].Port, cfg.Ports[1].Port, cfg.Ports[2].Port, cfg.Ports[3].Port})
+	assert.Equal(t, []string{
+		"/mnt/c/data:/data:ro",
+		"/mnt/d/cache",
+		"/srv/shared:/shared",
+	}, []string{cfg.Volumes[0].Volume, cfg.Volumes[1].Volume, cfg.Volumes[2].Volume})
+}
@@ -0,0 +1,97 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStubCreateFailed = errors.New("create failed")
+
+func newStubManager(t *testing.T) (*registry.Manager, *testutils.StubRegistryManager) {
+	t.Helper()
+
+	backend := testutils.NewStubRegistryManager()
+
+	manager, err := registry.NewManager(backend)
+	require.NoError(t, err)
+
+	return manager, backend
+}
+
+func mirrorInfos() []registry.Info {
+	return []registry.Info{
+		{Host: "docker.io", Name: "kind-docker.io", Upstream: "https://registry-1.docker.io", Port: 5000},
+		{Host: "ghcr.io", Name: "kind-ghcr.io", Upstream: "https://ghcr.io", Port: 5001},
+	}
+}
+
+func TestManagerEnsureBatch_CreatesRegistriesAndVolumes(t *testing.T) {
+	t.Parallel()
+
+	manager, backend := newStubManager(t)
+	ctx := context.Background()
+
+	err := manager.EnsureBatch(ctx, mirrorInfos(), "dev", "kind", io.Discard)
+	require.NoError(t, err)
+
+	names, err := backend.ListRegistries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kind-docker.io", "kind-ghcr.io"}, names)
+	assert.Equal(t, []string{"docker.io", "ghcr.io"}, backend.Volumes())
+
+	ports, err := registry.CollectExistingRegistryPorts(ctx, backend)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{5000: {}, 5001: {}}, ports)
+
+	// Ensuring again must reuse the existing registries.
+	err = manager.EnsureBatch(ctx, mirrorInfos(), "dev", "kind", io.Discard)
+	require.NoError(t, err)
+	assert.Len(t, backend.CreateCalls, 4)
+	assert.Len(t, backend.Volumes(), 2)
+}
+
+func TestManagerEnsureBatch_RollsBackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	manager, backend := newStubManager(t)
+	backend.CreateErrs["kind-ghcr.io"] = errStubCreateFailed
+	ctx := context.Background()
+
+	err := manager.EnsureBatch(ctx, mirrorInfos(), "dev", "kind", io.Discard)
+	require.ErrorIs(t, err, errStubCreateFailed)
+
+	names, err := backend.ListRegistries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, names, "registries created before the failure should be rolled back")
+	assert.Equal(t, []string{"kind-docker.io"}, backend.DeleteCalls)
+}
+
+func TestManagerCleanup_KeepsRegistriesSharedWithOtherClusters(t *testing.T) {
+	t.Parallel()
+
+	manager, backend := newStubManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.EnsureBatch(ctx, mirrorInfos(), "dev", "kind", io.Discard))
+	require.NoError(t, backend.ConnectNetwork("kind-docker.io", "kind"))
+	require.NoError(t, backend.ConnectNetwork("kind-docker.io", "k3d-other"))
+	require.NoError(t, backend.ConnectNetwork("kind-ghcr.io", "kind"))
+
+	err := manager.Cleanup(ctx, mirrorInfos(), "dev", true, "kind", io.Discard)
+	require.NoError(t, err)
+
+	shared, exists := backend.Registry("kind-docker.io")
+	require.True(t, exists)
+	assert.Equal(t, []string{"k3d-other"}, shared.Networks)
+
+	_, exists = backend.Registry("kind-ghcr.io")
+	assert.False(t, exists)
+	assert.Equal(t, []string{"docker.io"}, backend.Volumes())
+}
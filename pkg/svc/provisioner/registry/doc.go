@@ -6,4 +6,7 @@
 //     handle pull-through caching containers consistently, and
 //   - the developer-facing registry service abstraction that provisions the
 //     localhost-only OCI registry leveraged by the CLI.
+//
+// Changes to shared registry containers, networks and volumes are serialized across ksail
+// processes on the same host with a file lock (see AcquireLock and WithSharedRegistriesLock).
 package registry
@@ -200,12 +200,16 @@ func (s *service) Create(ctx context.Context, opts CreateOptions) (v1alpha1.OCIR
 
 	resolved := opts.WithDefaults()
 
-	_, ensureErr := s.manager.EnsureOne(
-		ctx,
-		resolved.toRegistryInfo(),
-		resolved.ClusterName,
-		io.Discard,
-	)
+	ensureErr := WithSharedRegistriesLock(ctx, io.Discard, func() error {
+		_, err := s.manager.EnsureOne(
+			ctx,
+			resolved.toRegistryInfo(),
+			resolved.ClusterName,
+			io.Discard,
+		)
+
+		return err
+	})
 	if ensureErr != nil {
 		model := buildRegistryModel(
 			resolved.Name,
@@ -256,13 +260,15 @@ func (s *service) Stop(ctx context.Context, opts StopOptions) error {
 		return validateErr
 	}
 
-	cleanupErr := s.manager.CleanupOne(
-		ctx,
-		Info{Name: opts.Name, Volume: strings.TrimSpace(opts.VolumeName)},
-		opts.ClusterName,
-		opts.DeleteVolume,
-		opts.NetworkName,
-	)
+	cleanupErr := WithSharedRegistriesLock(ctx, io.Discard, func() error {
+		return s.manager.CleanupOne(
+			ctx,
+			Info{Name: opts.Name, Volume: strings.TrimSpace(opts.VolumeName)},
+			opts.ClusterName,
+			opts.DeleteVolume,
+			opts.NetworkName,
+		)
+	})
 	if cleanupErr != nil {
 		if errors.Is(cleanupErr, dockerclient.ErrRegistryNotFound) {
 			return nil
@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+)
+
+// Cross-process Locking
+// These helpers serialize changes to shared registry containers, networks and volumes across
+// concurrent ksail invocations on the same host, such as parallel CI jobs on one runner.
+
+const (
+	// sharedRegistriesLock is the lock taken while shared registry resources are changed.
+	sharedRegistriesLock = "registries"
+	// lockRetryInterval is how often a contended lock is retried.
+	lockRetryInterval = 200 * time.Millisecond
+	// lockStaleAfter is how long a lock may go without a heartbeat before it is considered
+	// abandoned by a process that was killed while holding it.
+	lockStaleAfter = 2 * time.Minute
+	// lockHeartbeatInterval is how often a held lock is refreshed.
+	lockHeartbeatInterval = lockStaleAfter / 4
+)
+
+// ErrLockNameEmpty is returned when a lock is requested without a name.
+var ErrLockNameEmpty = errors.New("lock name is empty")
+
+// LockDir returns the directory that holds KSail's cross-process lock files.
+func LockDir() string {
+	return filepath.Join(os.TempDir(), "ksail-locks")
+}
+
+// AcquireLock takes the named cross-process lock, waiting until it is free or ctx ends.
+//
+// The lock is a file created exclusively in LockDir that records the pid of its holder and a
+// token unique to the acquisition. While held, its modification time is refreshed so other
+// processes can tell a live holder from one that was killed; locks that have not been refreshed
+// for a while are taken over. The returned function releases the lock if it is still held.
+func AcquireLock(ctx context.Context, name string, onWait func()) (func(), error) {
+	if name == "" {
+		return nil, ErrLockNameEmpty
+	}
+
+	dir := LockDir()
+
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("create lock directory: %w", err)
+	}
+
+	path := filepath.Join(dir, name+".lock")
+	waiting := false
+
+	owner := newLockOwner()
+
+	for {
+		acquired, err := tryCreateLockFile(path, owner)
+		if err != nil {
+			return nil, err
+		}
+
+		if acquired {
+			return startLockHeartbeat(path, owner), nil
+		}
+
+		removeStaleLockFile(path)
+
+		if !waiting && onWait != nil {
+			onWait()
+		}
+
+		waiting = true
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for lock %s: %w", name, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// WithSharedRegistriesLock runs fn while holding the lock for shared registry resources.
+func WithSharedRegistriesLock(ctx context.Context, writer io.Writer, fn func() error) error {
+	unlock, err := AcquireLock(ctx, sharedRegistriesLock, func() {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "waiting for another ksail process to finish changing registries",
+			Writer:  writer,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("acquire registry lock: %w", err)
+	}
+
+	defer unlock()
+
+	return fn()
+}
+
+// newLockOwner returns the content of a lock file, the pid of this process and a random token
+// that tells this acquisition apart from later ones.
+func newLockOwner() []byte {
+	return []byte(strconv.Itoa(os.Getpid()) + " " + randomToken() + "\n")
+}
+
+func randomToken() string {
+	token := make([]byte, 8)
+	_, _ = rand.Read(token)
+
+	return hex.EncodeToString(token)
+}
+
+// tryCreateLockFile creates the lock file at path with owner as its content, reporting false
+// when another process holds the lock.
+func tryCreateLockFile(path string, owner []byte) (bool, error) {
+	// #nosec G304 -- path is built from LockDir and a fixed lock name
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("create lock file %s: %w", path, err)
+	}
+
+	_, writeErr := file.Write(owner)
+	closeErr := file.Close()
+
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(path)
+
+		return false, fmt.Errorf("write lock file %s: %w", path, errors.Join(writeErr, closeErr))
+	}
+
+	return true, nil
+}
+
+// removeStaleLockFile removes the lock file at path when its holder stopped refreshing it.
+//
+// Another process may take over the same stale lock and create a fresh one between the check
+// and the removal, so the file is first renamed to a name only this process uses. If what was
+// renamed is not the stale lock that was checked, it is linked back in place, which fails
+// rather than replaces a lock created in the meantime.
+func removeStaleLockFile(path string) {
+	owner, ok := staleLockOwner(path)
+	if !ok {
+		return
+	}
+
+	claimed := path + ".stale-" + randomToken()
+
+	err := os.Rename(path, claimed)
+	if err != nil {
+		return
+	}
+
+	current, ok := staleLockOwner(claimed)
+	if !ok || !bytes.Equal(current, owner) {
+		_ = os.Link(claimed, path)
+	}
+
+	_ = os.Remove(claimed)
+}
+
+// staleLockOwner returns the content of the lock file at path if it has not been refreshed for
+// lockStaleAfter.
+func staleLockOwner(path string) ([]byte, bool) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) < lockStaleAfter {
+		return nil, false
+	}
+
+	// #nosec G304 -- path is built from LockDir and a fixed lock name
+	owner, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return owner, true
+}
+
+// startLockHeartbeat refreshes the lock file at path until the returned function is called,
+// which removes the file if it is still the one owner created.
+func startLockHeartbeat(path string, owner []byte) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(lockHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				_ = os.Chtimes(path, now, now)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+
+		// #nosec G304 -- path is built from LockDir and a fixed lock name
+		current, err := os.ReadFile(path)
+		if err == nil && bytes.Equal(current, owner) {
+			_ = os.Remove(path)
+		}
+	}
+}
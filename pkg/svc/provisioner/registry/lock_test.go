@@ -0,0 +1,124 @@
+package registry_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uniqueLockName(t *testing.T) string {
+	t.Helper()
+
+	return strings.ReplaceAll(t.Name(), "/", "-") + "-" + strconv.Itoa(os.Getpid())
+}
+
+func TestAcquireLockRejectsEmptyName(t *testing.T) {
+	t.Parallel()
+
+	_, err := registry.AcquireLock(context.Background(), "", nil)
+
+	require.ErrorIs(t, err, registry.ErrLockNameEmpty)
+}
+
+func TestAcquireLockWaitsForHolder(t *testing.T) {
+	t.Parallel()
+
+	name := uniqueLockName(t)
+
+	unlock, err := registry.AcquireLock(context.Background(), name, nil)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(registry.LockDir(), name+".lock"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	waited := false
+	_, err = registry.AcquireLock(ctx, name, func() { waited = true })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, waited, "expected the wait callback to run while the lock is held")
+
+	unlock()
+	assert.NoFileExists(t, filepath.Join(registry.LockDir(), name+".lock"))
+
+	unlockAgain, err := registry.AcquireLock(context.Background(), name, nil)
+	require.NoError(t, err)
+	unlockAgain()
+}
+
+func TestAcquireLockTakesOverStaleLock(t *testing.T) {
+	t.Parallel()
+
+	name := uniqueLockName(t)
+	path := filepath.Join(registry.LockDir(), name+".lock")
+
+	require.NoError(t, os.MkdirAll(registry.LockDir(), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte("12345\n"), 0o600))
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	unlock, err := registry.AcquireLock(ctx, name, nil)
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestAcquireLockLeavesAFreshLockInPlace(t *testing.T) {
+	t.Parallel()
+
+	name := uniqueLockName(t)
+	path := filepath.Join(registry.LockDir(), name+".lock")
+
+	unlock, err := registry.AcquireLock(context.Background(), name, nil)
+	require.NoError(t, err)
+
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, err = registry.AcquireLock(ctx, name, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.FileExists(t, path)
+
+	entries, err := filepath.Glob(path + ".stale-*")
+	require.NoError(t, err)
+	assert.Empty(t, entries, "expected no claimed lock files to be left behind")
+}
+
+func TestReleaseKeepsLockTakenOverByAnotherProcess(t *testing.T) {
+	t.Parallel()
+
+	name := uniqueLockName(t)
+	path := filepath.Join(registry.LockDir(), name+".lock")
+
+	unlock, err := registry.AcquireLock(context.Background(), name, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("12345 successor\n"), 0o600))
+
+	unlock()
+
+	assert.FileExists(t, path, "expected release to keep the lock of its successor")
+	require.NoError(t, os.Remove(path))
+}
+
+func TestWithSharedRegistriesLockReturnsCallbackError(t *testing.T) {
+	t.Parallel()
+
+	err := registry.WithSharedRegistriesLock(context.Background(), nil, func() error {
+		return os.ErrPermission
+	})
+
+	require.ErrorIs(t, err, os.ErrPermission)
+}
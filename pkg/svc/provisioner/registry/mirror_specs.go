@@ -5,6 +5,8 @@ import (
 	"net"
 	"strconv"
 	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
 )
 
 // MirrorSpec represents a parsed mirror registry specification entry.
@@ -50,6 +52,56 @@ func ParseMirrorSpecs(specs []string) []MirrorSpec {
 	return parsed
 }
 
+// MirrorSpecsFromConfig converts the mirrors declared in the KSail configuration into specs.
+// Mirrors without a host are ignored, and mirrors without an upstream use the default upstream
+// of their host.
+func MirrorSpecsFromConfig(mirrors []v1alpha1.Mirror) []MirrorSpec {
+	specs := make([]MirrorSpec, 0, len(mirrors))
+
+	for _, mirror := range mirrors {
+		host := strings.TrimSpace(mirror.Host)
+		if host == "" {
+			continue
+		}
+
+		remote := strings.TrimSpace(mirror.Upstream)
+		if remote == "" {
+			remote = GenerateUpstreamURL(host)
+		}
+
+		specs = append(specs, MirrorSpec{
+			Host:   host,
+			Remote: remote,
+		})
+	}
+
+	return specs
+}
+
+// MergeMirrorSpecs combines spec lists into one with a single spec per host. A spec replaces
+// the spec of the same host from an earlier list, e.g. so --mirror-registry flags override
+// the mirrors of the KSail configuration, while hosts keep the position they first appeared at.
+func MergeMirrorSpecs(lists ...[]MirrorSpec) []MirrorSpec {
+	var merged []MirrorSpec
+
+	positions := make(map[string]int)
+
+	for _, specs := range lists {
+		for _, spec := range specs {
+			if position, exists := positions[spec.Host]; exists {
+				merged[position] = spec
+
+				continue
+			}
+
+			positions[spec.Host] = len(merged)
+			merged = append(merged, spec)
+		}
+	}
+
+	return merged
+}
+
 // BuildMirrorEntries converts mirror specs into registry entries using the provided prefix.
 // Prefix should exclude the trailing hyphen (e.g., "kind", "k3d"). An empty prefix results in
 // container names that match the sanitized host directly, which is useful when sharing mirrors across distributions.
@@ -0,0 +1,48 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorSpecsFromConfig(t *testing.T) {
+	t.Parallel()
+
+	specs := registry.MirrorSpecsFromConfig([]v1alpha1.Mirror{
+		{Host: "docker.io"},
+		{Host: " ghcr.io ", Upstream: " https://ghcr.example.com "},
+		{Host: "quay.io"},
+		{Upstream: "https://ignored.example.com"},
+	})
+
+	assert.Equal(t, []registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+		{Host: "ghcr.io", Remote: "https://ghcr.example.com"},
+		{Host: "quay.io", Remote: "https://quay.io"},
+	}, specs)
+}
+
+func TestMergeMirrorSpecs(t *testing.T) {
+	t.Parallel()
+
+	merged := registry.MergeMirrorSpecs(
+		[]registry.MirrorSpec{
+			{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+			{Host: "ghcr.io", Remote: "https://ghcr.io"},
+		},
+		[]registry.MirrorSpec{
+			{Host: "registry.k8s.io", Remote: "https://registry.k8s.io"},
+			{Host: "docker.io", Remote: "https://mirror.gcr.io"},
+		},
+	)
+
+	assert.Equal(t, []registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://mirror.gcr.io"},
+		{Host: "ghcr.io", Remote: "https://ghcr.io"},
+		{Host: "registry.k8s.io", Remote: "https://registry.k8s.io"},
+	}, merged)
+	assert.Empty(t, registry.MergeMirrorSpecs())
+}
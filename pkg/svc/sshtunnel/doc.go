@@ -0,0 +1,7 @@
+// Package sshtunnel forwards ports of a remote Docker host to localhost over SSH.
+//
+// When KSail provisions clusters on a Docker host reached via an ssh:// URL, the API server
+// and local registry ports are published on the remote machine. A Tunnel keeps a background
+// ssh master connection that forwards those ports back to the same ports on localhost, so
+// kubeconfigs and registry references written by the distributions keep working unchanged.
+package sshtunnel
@@ -0,0 +1,200 @@
+package sshtunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+	"github.com/docker/cli/cli/connhelper/ssh"
+)
+
+const (
+	defaultBinary     = "ssh"
+	sshScheme         = "ssh://"
+	loopbackAddress   = "127.0.0.1"
+	controlPathPrefix = "ksail-tunnel-"
+	controlPathSuffix = ".sock"
+)
+
+var (
+	// ErrNotSSHHost is returned when a Docker host is not an ssh:// URL.
+	ErrNotSSHHost = errors.New("docker host is not an ssh:// URL")
+	// ErrNoPorts is returned when a tunnel has no ports to forward.
+	ErrNoPorts = errors.New("no ports to forward")
+	// ErrInvalidPort is returned when a port is outside 1-65535.
+	ErrInvalidPort = errors.New("invalid port")
+)
+
+// Tunnel describes the ports of one cluster forwarded from a remote Docker host.
+type Tunnel struct {
+	// Host is the Docker host URL, e.g. ssh://user@build-box:2222.
+	Host string
+	// Ports are forwarded from the remote loopback interface to the same port on localhost.
+	Ports []int
+	// ControlPath is the ssh control socket of the background connection; see ControlPath.
+	ControlPath string
+}
+
+// Service opens and closes tunnels with the OpenSSH client.
+type Service struct {
+	binary string
+	runner execrunner.Runner
+}
+
+// Option customizes a Service.
+type Option func(*Service)
+
+// WithRunner replaces the function used to execute ssh.
+func WithRunner(runner execrunner.Runner) Option {
+	return func(s *Service) {
+		s.runner = runner
+	}
+}
+
+// WithBinary sets the ssh client binary.
+func WithBinary(binary string) Option {
+	return func(s *Service) {
+		s.binary = binary
+	}
+}
+
+// NewService creates a Service that runs `ssh`. Its output is captured in a file rather than a
+// pipe, since `ssh -f` leaves a background process holding the output open after it returns.
+func NewService(opts ...Option) *Service {
+	service := &Service{binary: defaultBinary, runner: execrunner.ExecToFile}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// IsSSHHost reports whether host is an ssh:// Docker host URL.
+func IsSSHHost(host string) bool {
+	return strings.HasPrefix(strings.TrimSpace(host), sshScheme)
+}
+
+// ControlPath returns the control socket path used for the tunnel of clusterName.
+func ControlPath(clusterName string) string {
+	return filepath.Join(os.TempDir(), controlPathPrefix+strings.ToLower(clusterName)+controlPathSuffix)
+}
+
+// Open starts a background ssh connection forwarding the tunnel's ports.
+//
+// A tunnel already running on the same control path is closed first, so reopening after a
+// cluster restart picks up ports that changed. Open returns once the forwards are established.
+func (s *Service) Open(ctx context.Context, tunnel Tunnel) error {
+	destination, err := destinationArgs(tunnel.Host)
+	if err != nil {
+		return err
+	}
+
+	if len(tunnel.Ports) == 0 {
+		return ErrNoPorts
+	}
+
+	err = s.Close(ctx, tunnel)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"-f", "-N", "-M",
+		"-S", tunnel.ControlPath,
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "ServerAliveInterval=30",
+	}
+
+	for _, port := range tunnel.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("%w: %d", ErrInvalidPort, port)
+		}
+
+		forward := fmt.Sprintf("%s:%d:%s:%d", loopbackAddress, port, loopbackAddress, port)
+		args = append(args, "-L", forward)
+	}
+
+	args = append(args, destination...)
+
+	output, err := s.runner(ctx, s.binary, args...)
+	if err != nil {
+		return fmt.Errorf("open ssh tunnel to %s: %w: %s", tunnel.Host, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// Close stops the tunnel's background connection. It is a no-op when none is running.
+func (s *Service) Close(ctx context.Context, tunnel Tunnel) error {
+	if !s.IsOpen(ctx, tunnel) {
+		return nil
+	}
+
+	destination, err := destinationArgs(tunnel.Host)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"-S", tunnel.ControlPath, "-O", "exit"}, destination...)
+
+	output, err := s.runner(ctx, s.binary, args...)
+	if err != nil {
+		return fmt.Errorf("close ssh tunnel to %s: %w: %s", tunnel.Host, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// IsOpen reports whether a background connection is running on the tunnel's control path.
+func (s *Service) IsOpen(ctx context.Context, tunnel Tunnel) bool {
+	_, err := os.Stat(tunnel.ControlPath)
+	if err != nil {
+		return false
+	}
+
+	destination, err := destinationArgs(tunnel.Host)
+	if err != nil {
+		return false
+	}
+
+	args := append([]string{"-S", tunnel.ControlPath, "-O", "check"}, destination...)
+
+	_, err = s.runner(ctx, s.binary, args...)
+
+	return err == nil
+}
+
+// destinationArgs converts an ssh:// URL into ssh client arguments selecting the host.
+func destinationArgs(host string) ([]string, error) {
+	if !IsSSHHost(host) {
+		return nil, fmt.Errorf("%w: %q", ErrNotSSHHost, host)
+	}
+
+	spec, err := ssh.ParseURL(strings.TrimSpace(host))
+	if err != nil {
+		return nil, fmt.Errorf("parse docker host: %w", err)
+	}
+
+	var args []string
+
+	if spec.User != "" {
+		args = append(args, "-l", spec.User)
+	}
+
+	if spec.Port != "" {
+		_, err = strconv.Atoi(spec.Port)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPort, spec.Port)
+		}
+
+		args = append(args, "-p", spec.Port)
+	}
+
+	return append(args, "--", spec.Host), nil
+}
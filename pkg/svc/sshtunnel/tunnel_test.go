@@ -0,0 +1,159 @@
+package sshtunnel_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/execrunner"
+	"github.com/devantler-tech/ksail-go/pkg/svc/sshtunnel"
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSSHFailed = errors.New("exit status 255")
+
+type fakeSSH struct {
+	testutils.RecordingRunner
+
+	open    bool
+	openErr error
+	openOut string
+}
+
+// runner answers ssh commands from the state of the fake control master.
+func (f *fakeSSH) runner() execrunner.Runner {
+	f.Respond = f.respond
+
+	return f.Run
+}
+
+func (f *fakeSSH) respond(_ string, args []string) ([]byte, error) {
+	if len(args) > 3 && args[2] == "-O" {
+		switch args[3] {
+		case "check":
+			if !f.open {
+				return nil, errSSHFailed
+			}
+		case "exit":
+			f.open = false
+		}
+
+		return nil, nil
+	}
+
+	if f.openErr != nil {
+		return []byte(f.openOut), f.openErr
+	}
+
+	f.open = true
+
+	return nil, nil
+}
+
+func newTunnel(t *testing.T) sshtunnel.Tunnel {
+	t.Helper()
+
+	return sshtunnel.Tunnel{
+		Host:        "ssh://dev@build-box:2222",
+		Ports:       []int{6443, 5111},
+		ControlPath: filepath.Join(t.TempDir(), "tunnel.sock"),
+	}
+}
+
+func TestOpenForwardsPortsOverSSH(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSH{}
+	tunnel := newTunnel(t)
+
+	err := sshtunnel.NewService(sshtunnel.WithRunner(fake.runner())).Open(context.Background(), tunnel)
+	require.NoError(t, err)
+
+	require.Len(t, fake.Calls(), 1, "no control socket exists, so no check is expected")
+	assert.Equal(t, []string{
+		"ssh",
+		"-f", "-N", "-M",
+		"-S", tunnel.ControlPath,
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "ServerAliveInterval=30",
+		"-L", "127.0.0.1:6443:127.0.0.1:6443",
+		"-L", "127.0.0.1:5111:127.0.0.1:5111",
+		"-l", "dev", "-p", "2222", "--", "build-box",
+	}, fake.Calls()[0])
+}
+
+func TestOpenReplacesRunningTunnel(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSH{open: true}
+	tunnel := newTunnel(t)
+	require.NoError(t, os.WriteFile(tunnel.ControlPath, nil, 0o600))
+
+	err := sshtunnel.NewService(sshtunnel.WithRunner(fake.runner())).Open(context.Background(), tunnel)
+	require.NoError(t, err)
+
+	require.Len(t, fake.Calls(), 3)
+	assert.Equal(t, []string{"-O", "check"}, fake.Calls()[0][3:5])
+	assert.Equal(t, []string{"-O", "exit"}, fake.Calls()[1][3:5])
+	assert.Equal(t, "-f", fake.Calls()[2][1])
+}
+
+func TestOpenReportsSSHOutput(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSH{openErr: errSSHFailed, openOut: "Permission denied (publickey).\n"}
+
+	err := sshtunnel.NewService(sshtunnel.WithRunner(fake.runner())).Open(context.Background(), newTunnel(t))
+
+	require.ErrorIs(t, err, errSSHFailed)
+	assert.Contains(t, err.Error(), "Permission denied (publickey).")
+}
+
+func TestOpenValidatesTunnel(t *testing.T) {
+	t.Parallel()
+
+	service := sshtunnel.NewService(sshtunnel.WithRunner((&fakeSSH{}).runner()))
+
+	tunnel := newTunnel(t)
+	tunnel.Host = "tcp://build-box:2376"
+	require.ErrorIs(t, service.Open(context.Background(), tunnel), sshtunnel.ErrNotSSHHost)
+
+	tunnel = newTunnel(t)
+	tunnel.Ports = nil
+	require.ErrorIs(t, service.Open(context.Background(), tunnel), sshtunnel.ErrNoPorts)
+
+	tunnel = newTunnel(t)
+	tunnel.Ports = []int{70000}
+	require.ErrorIs(t, service.Open(context.Background(), tunnel), sshtunnel.ErrInvalidPort)
+}
+
+func TestCloseIsNoOpWithoutTunnel(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSSH{}
+
+	err := sshtunnel.NewService(sshtunnel.WithRunner(fake.runner())).Close(context.Background(), newTunnel(t))
+
+	require.NoError(t, err)
+	assert.Empty(t, fake.Calls())
+}
+
+func TestIsSSHHost(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, sshtunnel.IsSSHHost("ssh://dev@build-box"))
+	assert.True(t, sshtunnel.IsSSHHost(" ssh://build-box "))
+	assert.False(t, sshtunnel.IsSSHHost("unix:///var/run/docker.sock"))
+	assert.False(t, sshtunnel.IsSSHHost(""))
+}
+
+func TestControlPathIsPerCluster(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEqual(t, sshtunnel.ControlPath("dev"), sshtunnel.ControlPath("staging"))
+	assert.Equal(t, sshtunnel.ControlPath("Dev"), sshtunnel.ControlPath("dev"))
+}
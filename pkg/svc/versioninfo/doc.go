@@ -0,0 +1,7 @@
+// Package versioninfo describes the versions KSail is built from and checks for newer releases.
+//
+// A Matrix lists the KSail build, the Go toolchain, the Go modules KSail embeds such as
+// client-go, Helm and SOPS, the node images new clusters start from and the Helm charts the
+// component installers deploy. CheckForUpdate compares the running version with the latest
+// KSail release on GitHub.
+package versioninfo
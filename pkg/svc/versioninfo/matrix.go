@@ -0,0 +1,127 @@
+package versioninfo
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	kinddefaults "sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+// UnknownVersion is reported for modules missing from the binary's build information.
+const UnknownVersion = "unknown"
+
+// LatestChartVersion is reported for charts installed without a pinned version, which resolve
+// to the newest chart in their repository at install time.
+const LatestChartVersion = "latest"
+
+// Build identifies the KSail binary.
+type Build struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// Component is a versioned dependency of KSail.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source,omitempty"`
+}
+
+// Matrix lists the versions of KSail and the components it embeds or deploys.
+type Matrix struct {
+	KSail      Build        `json:"ksail"`
+	Go         string       `json:"go"`
+	Libraries  []Component  `json:"libraries"`
+	NodeImages []Component  `json:"nodeImages"`
+	Charts     []Component  `json:"charts"`
+	Update     *UpdateCheck `json:"update,omitempty"`
+}
+
+// embeddedModule maps a display name to the Go module whose version is reported for it.
+type embeddedModule struct {
+	name string
+	path string
+}
+
+//nolint:gochecknoglobals // fixed list of the modules reported by `ksail version`
+var embeddedModules = []embeddedModule{
+	{name: "client-go", path: "k8s.io/client-go"},
+	{name: "helm", path: "helm.sh/helm/v3"},
+	{name: "sops", path: "github.com/getsops/sops/v3"},
+	{name: "kind", path: "sigs.k8s.io/kind"},
+	{name: "k3d", path: "github.com/k3d-io/k3d/v5"},
+	{name: "docker", path: "github.com/docker/docker"},
+}
+
+//nolint:gochecknoglobals // fixed list of the charts the component installers deploy
+var defaultCharts = []Component{
+	{Name: "flux-operator", Source: "oci://ghcr.io/controlplaneio-fluxcd/charts/flux-operator"},
+	{Name: "argo-cd", Source: "https://argoproj.github.io/argo-helm"},
+	{Name: "cilium", Source: "https://helm.cilium.io"},
+	{Name: "tigera-operator", Source: "https://docs.tigera.io/calico/charts"},
+	{Name: "metrics-server", Source: "https://kubernetes-sigs.github.io/metrics-server/"},
+	{Name: "gitea", Source: "https://dl.gitea.com/charts/"},
+	{Name: "localstack", Source: "https://localstack.github.io/helm-charts"},
+	{Name: "cloudnative-pg", Source: "https://cloudnative-pg.github.io/charts"},
+	{Name: "traefik", Source: "https://traefik.github.io/charts"},
+	{Name: "istio", Source: "https://istio-release.storage.googleapis.com/charts"},
+}
+
+// NewMatrix collects the version matrix for the running binary.
+func NewMatrix(build Build) Matrix {
+	return Matrix{
+		KSail:     build,
+		Go:        runtime.Version(),
+		Libraries: libraryVersions(),
+		NodeImages: []Component{
+			{Name: "kind", Version: kinddefaults.Image},
+			{Name: "k3d", Version: v1alpha1.DefaultK3sImage},
+		},
+		Charts: chartVersions(),
+	}
+}
+
+func libraryVersions() []Component {
+	versions := map[string]string{}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			module := dep
+			if module.Replace != nil {
+				module = module.Replace
+			}
+
+			versions[dep.Path] = module.Version
+		}
+	}
+
+	libraries := make([]Component, 0, len(embeddedModules))
+
+	for _, module := range embeddedModules {
+		version := versions[module.path]
+		if version == "" {
+			version = UnknownVersion
+		}
+
+		libraries = append(libraries, Component{
+			Name:    module.name,
+			Version: version,
+			Source:  module.path,
+		})
+	}
+
+	return libraries
+}
+
+func chartVersions() []Component {
+	charts := make([]Component, 0, len(defaultCharts))
+
+	for _, chart := range defaultCharts {
+		chart.Version = LatestChartVersion
+		charts = append(charts, chart)
+	}
+
+	return charts
+}
@@ -0,0 +1,87 @@
+package versioninfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// LatestReleaseURL is the GitHub API endpoint describing the latest KSail release.
+const LatestReleaseURL = "https://api.github.com/repos/devantler-tech/ksail-go/releases/latest"
+
+// ErrUnexpectedStatus is returned when the release endpoint answers with a non-200 status.
+var ErrUnexpectedStatus = errors.New("unexpected status from release endpoint")
+
+// UpdateCheck is the result of comparing the running version with the latest release.
+type UpdateCheck struct {
+	Latest          string `json:"latest"`
+	ReleaseURL      string `json:"releaseUrl,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
+type latestRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CheckForUpdate fetches the latest release from url and reports whether it is newer than
+// current. Development builds, whose version is not a semantic version, never report an update.
+func CheckForUpdate(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	current string,
+) (*UpdateCheck, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create release request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch latest release: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+
+	var release latestRelease
+
+	err = json.NewDecoder(resp.Body).Decode(&release)
+	if err != nil {
+		return nil, fmt.Errorf("decode latest release: %w", err)
+	}
+
+	return &UpdateCheck{
+		Latest:          release.TagName,
+		ReleaseURL:      release.HTMLURL,
+		UpdateAvailable: isNewer(release.TagName, current),
+	}, nil
+}
+
+func isNewer(latest, current string) bool {
+	latestVersion, err := semver.NewVersion(latest)
+	if err != nil {
+		return false
+	}
+
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return false
+	}
+
+	return latestVersion.GreaterThan(currentVersion)
+}
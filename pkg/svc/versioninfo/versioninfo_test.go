@@ -0,0 +1,83 @@
+package versioninfo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/svc/versioninfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMatrixListsComponents(t *testing.T) {
+	t.Parallel()
+
+	build := versioninfo.Build{Version: "v1.0.0", Commit: "abc", Date: "today"}
+
+	matrix := versioninfo.NewMatrix(build)
+
+	assert.Equal(t, build, matrix.KSail)
+	assert.NotEmpty(t, matrix.Go)
+	assert.NotEmpty(t, matrix.Libraries)
+	assert.Len(t, matrix.NodeImages, 2)
+
+	for _, library := range matrix.Libraries {
+		assert.NotEmpty(t, library.Version, library.Name)
+	}
+
+	for _, chart := range matrix.Charts {
+		assert.Equal(t, versioninfo.LatestChartVersion, chart.Version, chart.Name)
+		assert.NotEmpty(t, chart.Source, chart.Name)
+	}
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte(`{"tag_name":"v1.5.0","html_url":"https://example.com/v1.5.0"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := map[string]struct {
+		current string
+		want    bool
+	}{
+		"older release":     {current: "v1.4.2", want: true},
+		"same release":      {current: "1.5.0", want: false},
+		"newer release":     {current: "v2.0.0", want: false},
+		"development build": {current: "dev", want: false},
+	}
+
+	for name, testCase := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			update, err := versioninfo.CheckForUpdate(
+				context.Background(),
+				server.Client(),
+				server.URL,
+				testCase.current,
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, "v1.5.0", update.Latest)
+			assert.Equal(t, "https://example.com/v1.5.0", update.ReleaseURL)
+			assert.Equal(t, testCase.want, update.UpdateAvailable)
+		})
+	}
+}
+
+func TestCheckForUpdateRejectsErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := versioninfo.CheckForUpdate(context.Background(), server.Client(), server.URL, "v1.0.0")
+	require.ErrorIs(t, err, versioninfo.ErrUnexpectedStatus)
+}
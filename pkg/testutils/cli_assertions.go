@@ -0,0 +1,183 @@
+package testutils
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	errorhandler "github.com/devantler-tech/ksail-go/pkg/ui/error-handler"
+	"github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// CLI invocation assertions.
+
+// ExitCategory classifies how a command invocation ended.
+type ExitCategory int
+
+const (
+	// ExitSuccess means the command completed without error (exit code 0).
+	ExitSuccess ExitCategory = iota
+	// ExitUsageError means Cobra rejected the invocation: unknown commands or flags, or invalid arguments.
+	ExitUsageError
+	// ExitRuntimeError means the command ran and returned an error.
+	ExitRuntimeError
+)
+
+//nolint:gochecknoglobals // fixed list of Cobra usage error prefixes
+var usageErrorPrefixes = []string{
+	"unknown command",
+	"unknown flag",
+	"unknown shorthand flag",
+	"invalid argument",
+	"flag needs an argument",
+	"required flag(s)",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+	"if any flags in the group",
+}
+
+// String returns a readable name for the category.
+func (c ExitCategory) String() string {
+	switch c {
+	case ExitSuccess:
+		return "success"
+	case ExitUsageError:
+		return "usage error"
+	case ExitRuntimeError:
+		return "runtime error"
+	default:
+		return "unknown"
+	}
+}
+
+// RunCLI runs a full root command invocation the way main does: errors are normalized by the
+// error handler and the root cause is reported on stderr as an error notification.
+func RunCLI(t *testing.T, root *cobra.Command, args ...string) CommandResult {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(args)
+
+	err := errorhandler.NewExecutor().Execute(root)
+
+	notify.EndGroup(root.OutOrStdout())
+
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ErrorType,
+			Content: "%v",
+			Args:    []any{rootCause(err)},
+			Writer:  notify.ErrorWriter(root.OutOrStdout(), root.ErrOrStderr()),
+		})
+	}
+
+	return CommandResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+		Err:    err,
+	}
+}
+
+// ExitCode returns the process exit code main would report for the result.
+func (r CommandResult) ExitCode() int {
+	return errorhandler.ExitCode(r.Err)
+}
+
+// ExitCategory classifies the result as success, usage error or runtime error.
+func (r CommandResult) ExitCategory() ExitCategory {
+	if r.Err == nil {
+		return ExitSuccess
+	}
+
+	message := rootCause(r.Err).Error()
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return ExitUsageError
+		}
+	}
+
+	return ExitRuntimeError
+}
+
+// CLIExpectation describes the expected outcome of a CLI invocation.
+// Zero-valued fields other than Category are not asserted.
+type CLIExpectation struct {
+	// Category is the expected exit category.
+	Category ExitCategory
+	// Stderr is the expected stderr after scrubbing and trimming surrounding whitespace.
+	Stderr string
+	// StderrContains lists substrings the scrubbed stderr must contain.
+	StderrContains []string
+	// Stdout is the expected structured stdout. It is compared after decoding stdout as
+	// YAML or JSON into the same shape, so formatting and key order do not matter.
+	Stdout any
+}
+
+// AssertCLIResult asserts exit category, normalized stderr and structured stdout together.
+// Stderr is scrubbed with DefaultCLIScrubbers followed by scrubbers.
+func AssertCLIResult(
+	t *testing.T,
+	result CommandResult,
+	want CLIExpectation,
+	scrubbers ...GoldenNormalizer,
+) {
+	t.Helper()
+
+	assert.Equal(t, want.Category.String(), result.ExitCategory().String(),
+		"unexpected exit category (exit code %d, error: %v)", result.ExitCode(), result.Err)
+
+	stderr := strings.TrimSpace(ScrubCLIOutput(t, result.Stderr, scrubbers...))
+
+	if want.Stderr != "" {
+		assert.Equal(t, strings.TrimSpace(want.Stderr), stderr, "unexpected stderr")
+	}
+
+	for _, substring := range want.StderrContains {
+		assert.Contains(t, stderr, substring, "stderr is missing expected content")
+	}
+
+	if want.Stdout != nil {
+		AssertStructuredOutput(t, result.Stdout, want.Stdout)
+	}
+}
+
+// AssertStructuredOutput decodes output as YAML or JSON and compares it with want after
+// round-tripping want through the same encoding.
+func AssertStructuredOutput(t *testing.T, output string, want any) {
+	t.Helper()
+
+	var got any
+
+	err := yaml.Unmarshal([]byte(output), &got)
+	require.NoError(t, err, "stdout is not valid YAML or JSON:\n%s", output)
+
+	wantYAML, err := yaml.Marshal(want)
+	require.NoError(t, err, "failed to encode expected output")
+
+	var expected any
+
+	err = yaml.Unmarshal(wantYAML, &expected)
+	require.NoError(t, err, "failed to decode expected output")
+
+	assert.Equal(t, expected, got, "unexpected structured output")
+}
+
+func rootCause(err error) error {
+	for {
+		unwrapped := errors.Unwrap(err)
+		if unwrapped == nil {
+			return err
+		}
+
+		err = unwrapped
+	}
+}
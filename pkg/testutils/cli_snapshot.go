@@ -0,0 +1,149 @@
+package testutils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/spf13/cobra"
+)
+
+// CLI snapshot helpers.
+
+//nolint:gochecknoglobals // compiled once and shared by CLI scrubbers
+var (
+	versionPattern = regexp.MustCompile(`(^|[^\w.])(v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?)($|[^\w.])`)
+	gitSHAPattern  = regexp.MustCompile(`\b(Git SHA|commit) [0-9a-f]{7,40}\b`)
+)
+
+// CommandResult holds everything a Cobra command wrote and returned.
+type CommandResult struct {
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+// String renders the result in the layout stored in CLI snapshots.
+func (r CommandResult) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("stdout:\n")
+	builder.WriteString(r.Stdout)
+	builder.WriteString("\nstderr:\n")
+	builder.WriteString(r.Stderr)
+	builder.WriteString("\nerror: ")
+
+	if r.Err != nil {
+		builder.WriteString(r.Err.Error())
+	} else {
+		builder.WriteString("<nil>")
+	}
+
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// ExecuteCommand runs cmd with args and captures stdout, stderr and the returned error.
+func ExecuteCommand(t *testing.T, cmd *cobra.Command, args ...string) CommandResult {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return CommandResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+		Err:    err,
+	}
+}
+
+// MatchCLISnapshot executes cmd with args and snapshots its full output.
+//
+// Timings, timestamps, temporary paths and versions are scrubbed with DefaultCLIScrubbers
+// before comparison; additional scrubbers run afterwards. Snapshots are stored with
+// go-snaps, so run tests with UPDATE_SNAPS=true to accept changes.
+func MatchCLISnapshot(
+	t *testing.T,
+	cmd *cobra.Command,
+	args []string,
+	scrubbers ...GoldenNormalizer,
+) CommandResult {
+	t.Helper()
+
+	result := ExecuteCommand(t, cmd, args...)
+
+	snaps.MatchSnapshot(t, ScrubCLIOutput(t, result.String(), scrubbers...))
+
+	return result
+}
+
+// ScrubCLIOutput applies DefaultCLIScrubbers followed by scrubbers to output.
+func ScrubCLIOutput(t *testing.T, output string, scrubbers ...GoldenNormalizer) string {
+	t.Helper()
+
+	for _, scrub := range append(DefaultCLIScrubbers(t), scrubbers...) {
+		output = scrub(output)
+	}
+
+	return output
+}
+
+// DefaultCLIScrubbers returns the scrubbers applied by MatchCLISnapshot.
+func DefaultCLIScrubbers(t *testing.T) []GoldenNormalizer {
+	t.Helper()
+
+	return []GoldenNormalizer{
+		NormalizeLineEndings,
+		ScrubTempDir(),
+		ScrubWorkingDir(t),
+		NormalizeTimestamps,
+		NormalizeDurations,
+		ScrubVersions,
+	}
+}
+
+// ScrubTempDir returns a scrubber replacing the system temporary directory with <TMP>.
+// It covers paths created with t.TempDir and os.MkdirTemp.
+func ScrubTempDir() GoldenNormalizer {
+	tempDir := filepath.Clean(os.TempDir())
+
+	resolved, err := filepath.EvalSymlinks(tempDir)
+	if err != nil || resolved == tempDir {
+		return NormalizePath(tempDir, "<TMP>")
+	}
+
+	// Resolve symlinks first so the longer path is replaced before its alias (macOS /var -> /private/var).
+	return func(content string) string {
+		return NormalizePath(tempDir, "<TMP>")(NormalizePath(resolved, "<TMP>")(content))
+	}
+}
+
+// ScrubWorkingDir returns a scrubber replacing the current working directory with <CWD>.
+func ScrubWorkingDir(t *testing.T) GoldenNormalizer {
+	t.Helper()
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	return NormalizePath(workingDir, "<CWD>")
+}
+
+// ScrubVersions replaces semantic versions and Git commit SHAs with stable placeholders.
+// Dotted IP addresses and API versions such as v1alpha4 are left untouched.
+func ScrubVersions(content string) string {
+	content = gitSHAPattern.ReplaceAllString(content, "$1 <SHA>")
+
+	return versionPattern.ReplaceAllString(content, "${1}<VERSION>${3}")
+}
@@ -48,6 +48,7 @@ type StubProvisioner struct {
 	StartCalls    int
 	StopErr       error
 	StopCalls     int
+	ClusterExists bool
 	ReceivedNames []string
 }
 
@@ -89,8 +90,9 @@ func (p *StubProvisioner) List(context.Context) ([]string, error) {
 }
 
 // Exists implements clusterprovisioner.ClusterProvisioner.
+// It reports ClusterExists for every name.
 func (p *StubProvisioner) Exists(context.Context, string) (bool, error) {
-	return false, nil
+	return p.ClusterExists, nil
 }
 
 // Command creation helpers.
@@ -9,6 +9,12 @@
 //   - StubFactory: Test double for clusterprovisioner.Factory
 //   - StubProvisioner: Test double for clusterprovisioner.ClusterProvisioner
 //   - RecordingTimer: Test double for timer.Timer interface
+//   - FakeClock: RecordingTimer backed by a manually advanced virtual clock; pass
+//     FakeClock.Now to timer.NewWithClock to test timing without real sleeps
+//   - StubRegistryManager: In-memory test double for registry.Backend with inspectable
+//     registries, networks and volumes
+//   - RecordingRunner: Test double for execrunner.Runner that records commands and answers
+//     them with a Respond function
 //
 // # Assertion Helpers
 //
@@ -18,12 +24,55 @@
 //   - String assertions: AssertStringContains, AssertStringContainsOneOf
 //   - File assertions: AssertFileEquals
 //
+// # Golden Files
+//
+// Golden-file comparison for rendered output stored under testdata/:
+//   - AssertGolden: Compares output with testdata/<name>.golden; run tests with -update to rewrite
+//   - Normalizers: NormalizeTimestamps, NormalizeDurations, NormalizeLineEndings, NormalizePath
+//
+// # Configuration Fixtures
+//
+// Generators for valid ksail.yaml variants used by table-driven and property-based tests:
+//   - KSailConfigMatrix: Every distribution × CNI × GitOps engine combination as KSailConfigVariant
+//   - RandomKSailConfigVariant: Picks a variant from the matrix with a caller-supplied rand.Rand
+//   - KSailConfigVariant: Builds the Cluster, renders YAML, or scaffolds a full project with WriteProject
+//
+// # Temporary Projects
+//
+// Parallel-safe project scaffolding for integration tests:
+//   - NewTempProject: Writes ksail.yaml, the distribution config, the source directory and a
+//     kubeconfig into t.TempDir, using absolute paths so tests need not change directory
+//   - TempProject: Exposes the unique ClusterName, Context and RegistryName plus every file path
+//   - UniqueClusterName: Derives a short, DNS-safe cluster name from the test name
+//   - Options: WithTempProjectVariant, WithTempProjectServer, WithTempProjectConfig
+//
+// # CLI Snapshots
+//
+// Snapshot helpers for locking down command output:
+//   - MatchCLISnapshot: Executes a Cobra command and snapshots stdout, stderr and the error
+//   - ExecuteCommand: Captures a command's output as a CommandResult
+//   - Scrubbers: DefaultCLIScrubbers, ScrubTempDir, ScrubWorkingDir, ScrubVersions
+//   - RunCLI: Runs a root command invocation the way main does, reporting errors on stderr
+//   - AssertCLIResult: Asserts exit category (ExitSuccess, ExitUsageError, ExitRuntimeError),
+//     normalized stderr and structured stdout together via CLIExpectation
+//   - AssertStructuredOutput: Compares YAML or JSON output independent of formatting
+//
+// # Fuzzing
+//
+// Corpus helpers for testing.F harnesses that parse configuration:
+//   - Seeds: KSailConfigSeeds, KindConfigSeeds
+//   - Corpus builders: AddYAMLFuzzCorpus adds seeds plus MalformedYAMLVariants; AddFuzzCorpusDir
+//     adds files from a directory
+//   - File inputs: WriteFuzzInput writes fuzz data to a temporary file for file-based loaders
+//
 // # Kubernetes Test Helpers
 //
 // Utilities for creating fake Kubernetes clients and mock resources:
 //   - Deployment clients: CreateReadyDeploymentClient, CreateUnreadyDeploymentClient
 //   - DaemonSet clients: CreateReadyDaemonSetClient, CreateUnreadyDaemonSetClient
 //   - Kubeconfig writers: WriteKubeconfig, WriteServerBackedKubeconfig
+//   - Real API servers: StartEnvtest starts kube-apiserver and etcd via controller-runtime envtest
+//     (skipped unless KUBEBUILDER_ASSETS points at the binaries)
 //
 // # HTTP Test Helpers
 //
@@ -32,6 +81,12 @@
 //   - ServeDeployment, ServeDaemonSet: Mock Kubernetes API responses
 //   - EncodeJSON: Encodes and writes JSON responses
 //
+// # Docker Test Helpers
+//
+// An in-memory Docker Engine API for exercising docker clients without a daemon:
+//   - NewFakeDockerServer: Starts a fake daemon supporting containers, networks, volumes and image pulls
+//   - FakeDockerServer.Client: Returns a docker API client connected to the fake daemon
+//
 // # Configuration and File Helpers
 //
 // Utilities for test configuration and file operations:
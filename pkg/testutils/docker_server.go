@@ -0,0 +1,825 @@
+package testutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+)
+
+const (
+	fakeDockerStateCreated = "created"
+	fakeDockerStateRunning = "running"
+	fakeDockerStateExited  = "exited"
+	fakeDockerIDLength     = 64
+)
+
+//nolint:gochecknoglobals // compiled once and shared by all fake Docker servers
+var apiVersionPrefix = regexp.MustCompile(`^/v[0-9]+(\.[0-9]+)?`)
+
+// FakeDockerServer is an in-memory Docker Engine API served over httptest.
+//
+// It implements the subset of endpoints used by RegistryManager and the registry
+// provisioners (containers, networks, volumes and image pulls) so that full
+// lifecycle tests can run against a real docker client without a daemon.
+type FakeDockerServer struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	nextID     int
+	containers map[string]*fakeContainer
+	networks   map[string]*network.Inspect
+	volumes    map[string]*volume.Volume
+	images     map[string]struct{}
+	requests   []string
+}
+
+type fakeContainer struct {
+	id         string
+	name       string
+	state      string
+	config     container.Config
+	hostConfig container.HostConfig
+	networks   map[string]*network.EndpointSettings
+}
+
+// NewFakeDockerServer starts a fake Docker Engine API server that is closed when the test ends.
+func NewFakeDockerServer(t *testing.T) *FakeDockerServer {
+	t.Helper()
+
+	fake := &FakeDockerServer{
+		containers: map[string]*fakeContainer{},
+		networks:   map[string]*network.Inspect{},
+		volumes:    map[string]*volume.Volume{},
+		images:     map[string]struct{}{},
+	}
+
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+// Client returns a docker API client connected to the fake server.
+func (f *FakeDockerServer) Client(t *testing.T) client.APIClient {
+	t.Helper()
+
+	dockerClient, err := client.NewClientWithOpts(
+		client.WithHost("tcp://"+strings.TrimPrefix(f.server.URL, "http://")),
+		client.WithHTTPClient(f.server.Client()),
+		client.WithVersion(api.DefaultVersion),
+	)
+	if err != nil {
+		t.Fatalf("failed to create docker client for fake server: %v", err)
+	}
+
+	t.Cleanup(func() { _ = dockerClient.Close() })
+
+	return dockerClient
+}
+
+// URL returns the base URL of the fake server.
+func (f *FakeDockerServer) URL() string {
+	return f.server.URL
+}
+
+// AddImage marks an image reference as already present locally.
+func (f *FakeDockerServer) AddImage(ref string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.images[familiarImageName(ref)] = struct{}{}
+}
+
+// AddNetwork registers a network with the given name and returns its ID.
+func (f *FakeDockerServer) AddNetwork(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.createNetworkLocked(name)
+}
+
+// AddNetworkWithSubnet registers a network with the given name and subnet and returns its ID.
+func (f *FakeDockerServer) AddNetworkWithSubnet(name, subnet string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.createNetworkLocked(name)
+	f.networks[id].IPAM.Config = []network.IPAMConfig{{Subnet: subnet}}
+
+	return id
+}
+
+// HasNetwork reports whether a network with the given name exists.
+func (f *FakeDockerServer) HasNetwork(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.findNetworkLocked(name) != nil
+}
+
+// HasImage reports whether an image reference is present.
+func (f *FakeDockerServer) HasImage(ref string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.images[familiarImageName(ref)]
+
+	return ok
+}
+
+// HasVolume reports whether a volume with the given name exists.
+func (f *FakeDockerServer) HasVolume(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.volumes[name]
+
+	return ok
+}
+
+// ContainerState returns the state of the named container and whether it exists.
+func (f *FakeDockerServer) ContainerState(name string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ctr := f.findContainerLocked(name)
+	if ctr == nil {
+		return "", false
+	}
+
+	return ctr.state, true
+}
+
+// ContainerNetworks returns the sorted network names the named container is attached to.
+func (f *FakeDockerServer) ContainerNetworks(name string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ctr := f.findContainerLocked(name)
+	if ctr == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(ctr.networks))
+	for networkName := range ctr.networks {
+		names = append(names, networkName)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Requests returns the "METHOD /path" lines received so far, without API version prefixes.
+func (f *FakeDockerServer) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.requests)
+}
+
+// --- routing ---
+
+func (f *FakeDockerServer) serveHTTP(writer http.ResponseWriter, req *http.Request) {
+	path := apiVersionPrefix.ReplaceAllString(req.URL.Path, "")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, req.Method+" "+path)
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch segments[0] {
+	case "_ping":
+		writer.Header().Set("Api-Version", api.DefaultVersion)
+		writer.Header().Set("Ostype", "linux")
+		_, _ = writer.Write([]byte("OK"))
+	case "containers":
+		f.routeContainers(writer, req, segments[1:])
+	case "networks":
+		f.routeNetworks(writer, req, segments[1:])
+	case "volumes":
+		f.routeVolumes(writer, req, segments[1:])
+	case "images":
+		f.routeImages(writer, req, strings.TrimPrefix(path, "/images/"))
+	default:
+		writeDockerError(writer, http.StatusNotFound, "page not found: "+path)
+	}
+}
+
+func (f *FakeDockerServer) routeContainers(
+	writer http.ResponseWriter,
+	req *http.Request,
+	segments []string,
+) {
+	switch {
+	case len(segments) == 1 && segments[0] == "json" && req.Method == http.MethodGet:
+		f.listContainers(writer, req)
+	case len(segments) == 1 && segments[0] == "create" && req.Method == http.MethodPost:
+		f.createContainer(writer, req)
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		f.removeContainer(writer, req, segments[0])
+	case len(segments) == 2 && segments[1] == "json" && req.Method == http.MethodGet:
+		f.inspectContainer(writer, segments[0])
+	case len(segments) == 2 && segments[1] == "start" && req.Method == http.MethodPost:
+		f.setContainerState(writer, segments[0], fakeDockerStateRunning)
+	case len(segments) == 2 && segments[1] == "stop" && req.Method == http.MethodPost:
+		f.setContainerState(writer, segments[0], fakeDockerStateExited)
+	default:
+		writeDockerError(writer, http.StatusNotFound, "unsupported container endpoint")
+	}
+}
+
+func (f *FakeDockerServer) routeNetworks(
+	writer http.ResponseWriter,
+	req *http.Request,
+	segments []string,
+) {
+	switch {
+	case len(segments) == 0 || segments[0] == "":
+		f.listNetworks(writer)
+	case len(segments) == 1 && segments[0] == "create" && req.Method == http.MethodPost:
+		f.createNetwork(writer, req)
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		f.inspectNetwork(writer, segments[0])
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		f.removeNetwork(writer, segments[0])
+	case len(segments) == 2 && segments[1] == "connect" && req.Method == http.MethodPost:
+		f.connectNetwork(writer, req, segments[0])
+	case len(segments) == 2 && segments[1] == "disconnect" && req.Method == http.MethodPost:
+		f.disconnectNetwork(writer, req, segments[0])
+	default:
+		writeDockerError(writer, http.StatusNotFound, "unsupported network endpoint")
+	}
+}
+
+func (f *FakeDockerServer) routeVolumes(
+	writer http.ResponseWriter,
+	req *http.Request,
+	segments []string,
+) {
+	switch {
+	case len(segments) == 0 || segments[0] == "":
+		f.listVolumes(writer)
+	case len(segments) == 1 && segments[0] == "create" && req.Method == http.MethodPost:
+		f.createVolume(writer, req)
+	case len(segments) == 1 && req.Method == http.MethodGet:
+		f.inspectVolume(writer, segments[0])
+	case len(segments) == 1 && req.Method == http.MethodDelete:
+		f.removeVolume(writer, segments[0])
+	default:
+		writeDockerError(writer, http.StatusNotFound, "unsupported volume endpoint")
+	}
+}
+
+// routeImages handles image endpoints manually because references contain slashes and colons.
+func (f *FakeDockerServer) routeImages(writer http.ResponseWriter, req *http.Request, rest string) {
+	switch {
+	case rest == "create" && req.Method == http.MethodPost:
+		ref := familiarImageName(req.URL.Query().Get("fromImage"))
+		if tag := req.URL.Query().Get("tag"); tag != "" {
+			ref += ":" + tag
+		}
+
+		f.images[ref] = struct{}{}
+
+		writeDockerJSON(writer, http.StatusOK, map[string]string{"status": "Pulled " + ref})
+	case strings.HasSuffix(rest, "/json") && req.Method == http.MethodGet:
+		ref := familiarImageName(strings.TrimSuffix(rest, "/json"))
+		if _, ok := f.images[ref]; !ok {
+			writeDockerError(writer, http.StatusNotFound, "No such image: "+ref)
+
+			return
+		}
+
+		writeDockerJSON(writer, http.StatusOK, map[string]any{"Id": "sha256:" + ref, "RepoTags": []string{ref}})
+	default:
+		writeDockerError(writer, http.StatusNotFound, "unsupported image endpoint")
+	}
+}
+
+// --- containers ---
+
+func (f *FakeDockerServer) createContainer(writer http.ResponseWriter, req *http.Request) {
+	var body container.CreateRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	name := req.URL.Query().Get("name")
+	if name != "" && f.findContainerLocked(name) != nil {
+		writeDockerError(writer, http.StatusConflict, "container name already in use: "+name)
+
+		return
+	}
+
+	ctr := &fakeContainer{
+		id:       f.newIDLocked(),
+		name:     name,
+		state:    fakeDockerStateCreated,
+		networks: map[string]*network.EndpointSettings{},
+	}
+
+	if body.Config != nil {
+		ctr.config = *body.Config
+	}
+
+	if body.HostConfig != nil {
+		ctr.hostConfig = *body.HostConfig
+	}
+
+	if body.NetworkingConfig != nil {
+		for networkName, settings := range body.NetworkingConfig.EndpointsConfig {
+			if f.findNetworkLocked(networkName) == nil {
+				writeDockerError(writer, http.StatusNotFound, "network "+networkName+" not found")
+
+				return
+			}
+
+			ctr.networks[networkName] = settings
+		}
+	}
+
+	f.containers[ctr.id] = ctr
+
+	writeDockerJSON(writer, http.StatusCreated, container.CreateResponse{ID: ctr.id, Warnings: []string{}})
+}
+
+func (f *FakeDockerServer) listContainers(writer http.ResponseWriter, req *http.Request) {
+	args, err := filters.FromJSON(req.URL.Query().Get("filters"))
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	all := req.URL.Query().Get("all") == "1" || req.URL.Query().Get("all") == "true"
+
+	ids := make([]string, 0, len(f.containers))
+	for id := range f.containers {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	summaries := make([]container.Summary, 0, len(ids))
+
+	for _, id := range ids {
+		ctr := f.containers[id]
+		if !all && ctr.state != fakeDockerStateRunning {
+			continue
+		}
+
+		if !ctr.matches(args) {
+			continue
+		}
+
+		summaries = append(summaries, ctr.summary())
+	}
+
+	writeDockerJSON(writer, http.StatusOK, summaries)
+}
+
+func (f *FakeDockerServer) inspectContainer(writer http.ResponseWriter, ref string) {
+	ctr := f.findContainerLocked(ref)
+	if ctr == nil {
+		writeDockerError(writer, http.StatusNotFound, "No such container: "+ref)
+
+		return
+	}
+
+	writeDockerJSON(writer, http.StatusOK, ctr.inspect())
+}
+
+func (f *FakeDockerServer) setContainerState(writer http.ResponseWriter, ref, state string) {
+	ctr := f.findContainerLocked(ref)
+	if ctr == nil {
+		writeDockerError(writer, http.StatusNotFound, "No such container: "+ref)
+
+		return
+	}
+
+	ctr.state = state
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (f *FakeDockerServer) removeContainer(writer http.ResponseWriter, req *http.Request, ref string) {
+	ctr := f.findContainerLocked(ref)
+	if ctr == nil {
+		writeDockerError(writer, http.StatusNotFound, "No such container: "+ref)
+
+		return
+	}
+
+	force := req.URL.Query().Get("force") == "1" || req.URL.Query().Get("force") == "true"
+	if ctr.state == fakeDockerStateRunning && !force {
+		writeDockerError(writer, http.StatusConflict, "cannot remove a running container: "+ref)
+
+		return
+	}
+
+	delete(f.containers, ctr.id)
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (f *FakeDockerServer) findContainerLocked(ref string) *fakeContainer {
+	if ctr, ok := f.containers[ref]; ok {
+		return ctr
+	}
+
+	name := strings.TrimPrefix(ref, "/")
+	for _, ctr := range f.containers {
+		if ctr.name == name {
+			return ctr
+		}
+	}
+
+	return nil
+}
+
+func (c *fakeContainer) matches(args filters.Args) bool {
+	if args.Contains("name") && !args.Match("name", c.name) && !args.Match("name", "/"+c.name) {
+		return false
+	}
+
+	if args.Contains("ancestor") && !args.ExactMatch("ancestor", c.config.Image) {
+		return false
+	}
+
+	if args.Contains("status") && !args.ExactMatch("status", c.state) {
+		return false
+	}
+
+	if args.Contains("id") && !args.Match("id", c.id) {
+		return false
+	}
+
+	return !args.Contains("label") || args.MatchKVList("label", c.config.Labels)
+}
+
+func (c *fakeContainer) summary() container.Summary {
+	summary := container.Summary{
+		ID:     c.id,
+		Names:  []string{"/" + c.name},
+		Image:  c.config.Image,
+		State:  c.state,
+		Labels: c.config.Labels,
+		NetworkSettings: &container.NetworkSettingsSummary{
+			Networks: c.networks,
+		},
+	}
+
+	for privatePort, bindings := range c.hostConfig.PortBindings {
+		for _, binding := range bindings {
+			publicPort, _ := strconv.ParseUint(binding.HostPort, 10, 16)
+			summary.Ports = append(summary.Ports, container.Port{
+				IP:          binding.HostIP,
+				PrivatePort: uint16(privatePort.Int()), //nolint:gosec // port numbers fit in uint16
+				PublicPort:  uint16(publicPort),
+				Type:        privatePort.Proto(),
+			})
+		}
+	}
+
+	summary.Mounts = c.mountPoints()
+
+	return summary
+}
+
+func (c *fakeContainer) inspect() container.InspectResponse {
+	hostConfig := c.hostConfig
+	config := c.config
+
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:   c.id,
+			Name: "/" + c.name,
+			State: &container.State{
+				Status:  container.ContainerState(c.state),
+				Running: c.state == fakeDockerStateRunning,
+			},
+			HostConfig: &hostConfig,
+		},
+		Config: &config,
+		Mounts: c.mountPoints(),
+		NetworkSettings: &container.NetworkSettings{
+			Networks: c.networks,
+		},
+	}
+}
+
+func (c *fakeContainer) mountPoints() []container.MountPoint {
+	points := make([]container.MountPoint, 0, len(c.hostConfig.Mounts))
+
+	for _, hostMount := range c.hostConfig.Mounts {
+		point := container.MountPoint{
+			Type:        hostMount.Type,
+			Source:      hostMount.Source,
+			Destination: hostMount.Target,
+			RW:          !hostMount.ReadOnly,
+		}
+
+		if hostMount.Type == mount.TypeVolume {
+			point.Name = hostMount.Source
+		}
+
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// --- networks ---
+
+func (f *FakeDockerServer) listNetworks(writer http.ResponseWriter) {
+	summaries := make([]network.Summary, 0, len(f.networks))
+	for _, inspect := range f.networks {
+		summaries = append(summaries, *inspect)
+	}
+
+	slices.SortFunc(summaries, func(a, b network.Summary) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	writeDockerJSON(writer, http.StatusOK, summaries)
+}
+
+func (f *FakeDockerServer) createNetwork(writer http.ResponseWriter, req *http.Request) {
+	var body network.CreateRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if f.findNetworkLocked(body.Name) != nil {
+		writeDockerError(writer, http.StatusConflict, "network with name "+body.Name+" already exists")
+
+		return
+	}
+
+	id := f.createNetworkLocked(body.Name)
+
+	inspect := f.networks[id]
+	inspect.Labels = body.Labels
+	inspect.EnableIPv6 = body.EnableIPv6 != nil && *body.EnableIPv6
+
+	if body.IPAM != nil {
+		inspect.IPAM = *body.IPAM
+	}
+
+	writeDockerJSON(writer, http.StatusCreated, network.CreateResponse{ID: id})
+}
+
+func (f *FakeDockerServer) inspectNetwork(writer http.ResponseWriter, ref string) {
+	inspect := f.findNetworkLocked(ref)
+	if inspect == nil {
+		writeDockerError(writer, http.StatusNotFound, "network "+ref+" not found")
+
+		return
+	}
+
+	result := *inspect
+	result.Containers = map[string]network.EndpointResource{}
+
+	for _, ctr := range f.containers {
+		if _, ok := ctr.networks[inspect.Name]; ok {
+			result.Containers[ctr.id] = network.EndpointResource{Name: ctr.name}
+		}
+	}
+
+	writeDockerJSON(writer, http.StatusOK, result)
+}
+
+func (f *FakeDockerServer) removeNetwork(writer http.ResponseWriter, ref string) {
+	inspect := f.findNetworkLocked(ref)
+	if inspect == nil {
+		writeDockerError(writer, http.StatusNotFound, "network "+ref+" not found")
+
+		return
+	}
+
+	delete(f.networks, inspect.ID)
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (f *FakeDockerServer) connectNetwork(writer http.ResponseWriter, req *http.Request, ref string) {
+	var body network.ConnectOptions
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	inspect := f.findNetworkLocked(ref)
+	if inspect == nil {
+		writeDockerError(writer, http.StatusNotFound, "network "+ref+" not found")
+
+		return
+	}
+
+	ctr := f.findContainerLocked(body.Container)
+	if ctr == nil {
+		writeDockerError(writer, http.StatusNotFound, "No such container: "+body.Container)
+
+		return
+	}
+
+	if _, ok := ctr.networks[inspect.Name]; ok {
+		writeDockerError(
+			writer,
+			http.StatusForbidden,
+			fmt.Sprintf("endpoint with name %s already exists in network %s", ctr.name, inspect.Name),
+		)
+
+		return
+	}
+
+	ctr.networks[inspect.Name] = body.EndpointConfig
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (f *FakeDockerServer) disconnectNetwork(
+	writer http.ResponseWriter,
+	req *http.Request,
+	ref string,
+) {
+	var body network.DisconnectOptions
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	inspect := f.findNetworkLocked(ref)
+	if inspect == nil {
+		writeDockerError(writer, http.StatusNotFound, "network "+ref+" not found")
+
+		return
+	}
+
+	ctr := f.findContainerLocked(body.Container)
+	if ctr == nil {
+		writeDockerError(writer, http.StatusNotFound, "No such container: "+body.Container)
+
+		return
+	}
+
+	delete(ctr.networks, inspect.Name)
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (f *FakeDockerServer) createNetworkLocked(name string) string {
+	id := f.newIDLocked()
+	f.networks[id] = &network.Inspect{ID: id, Name: name, Driver: "bridge", Scope: "local"}
+
+	return id
+}
+
+func (f *FakeDockerServer) findNetworkLocked(ref string) *network.Inspect {
+	if inspect, ok := f.networks[ref]; ok {
+		return inspect
+	}
+
+	for _, inspect := range f.networks {
+		if inspect.Name == ref {
+			return inspect
+		}
+	}
+
+	return nil
+}
+
+// --- volumes ---
+
+func (f *FakeDockerServer) listVolumes(writer http.ResponseWriter) {
+	volumes := make([]*volume.Volume, 0, len(f.volumes))
+	for _, vol := range f.volumes {
+		volumes = append(volumes, vol)
+	}
+
+	slices.SortFunc(volumes, func(a, b *volume.Volume) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	writeDockerJSON(writer, http.StatusOK, volume.ListResponse{Volumes: volumes, Warnings: []string{}})
+}
+
+func (f *FakeDockerServer) createVolume(writer http.ResponseWriter, req *http.Request) {
+	var body volume.CreateOptions
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeDockerError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if body.Name == "" {
+		body.Name = f.newIDLocked()
+	}
+
+	vol, ok := f.volumes[body.Name]
+	if !ok {
+		vol = &volume.Volume{
+			Name:       body.Name,
+			Driver:     "local",
+			Labels:     body.Labels,
+			Mountpoint: "/var/lib/docker/volumes/" + body.Name + "/_data",
+			Scope:      "local",
+		}
+		f.volumes[body.Name] = vol
+	}
+
+	writeDockerJSON(writer, http.StatusCreated, vol)
+}
+
+func (f *FakeDockerServer) inspectVolume(writer http.ResponseWriter, name string) {
+	vol, ok := f.volumes[name]
+	if !ok {
+		writeDockerError(writer, http.StatusNotFound, "get "+name+": no such volume")
+
+		return
+	}
+
+	writeDockerJSON(writer, http.StatusOK, vol)
+}
+
+func (f *FakeDockerServer) removeVolume(writer http.ResponseWriter, name string) {
+	if _, ok := f.volumes[name]; !ok {
+		writeDockerError(writer, http.StatusNotFound, "get "+name+": no such volume")
+
+		return
+	}
+
+	for _, ctr := range f.containers {
+		for _, hostMount := range ctr.hostConfig.Mounts {
+			if hostMount.Type == mount.TypeVolume && hostMount.Source == name {
+				writeDockerError(writer, http.StatusConflict, "remove "+name+": volume is in use")
+
+				return
+			}
+		}
+	}
+
+	delete(f.volumes, name)
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// --- helpers ---
+
+func (f *FakeDockerServer) newIDLocked() string {
+	f.nextID++
+
+	return fmt.Sprintf("%0*x", fakeDockerIDLength, f.nextID)
+}
+
+// familiarImageName strips the default Docker Hub prefixes the client adds to image references.
+func familiarImageName(ref string) string {
+	ref = strings.TrimPrefix(ref, "docker.io/")
+
+	return strings.TrimPrefix(ref, "library/")
+}
+
+func writeDockerError(writer http.ResponseWriter, status int, message string) {
+	writeDockerJSON(writer, status, map[string]string{"message": message})
+}
+
+func writeDockerJSON(writer http.ResponseWriter, status int, payload any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	_ = json.NewEncoder(writer).Encode(payload)
+}
@@ -0,0 +1,172 @@
+package testutils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Fuzzing helpers for configuration parsing.
+
+const (
+	fuzzDeepNestingLevels = 64
+	fuzzLongScalarLength  = 4096
+)
+
+// KSailConfigSeeds returns representative ksail.yaml documents for seeding fuzz corpora.
+func KSailConfigSeeds() []string {
+	return []string{
+		"apiVersion: ksail.dev/v1alpha1\nkind: Cluster\n",
+		"apiVersion: ksail.dev/v1alpha1\n" +
+			"kind: Cluster\n" +
+			"spec:\n" +
+			"  distribution: Kind\n" +
+			"  distributionConfig: kind.yaml\n" +
+			"  sourceDirectory: k8s\n",
+		"apiVersion: ksail.dev/v1alpha1\n" +
+			"kind: Cluster\n" +
+			"spec:\n" +
+			"  distribution: K3d\n" +
+			"  distributionConfig: k3d.yaml\n" +
+			"  cni: Cilium\n" +
+			"  gitOpsEngine: Flux\n" +
+			"  localRegistry: Enabled\n" +
+			"  localRegistryHostPort: 5111\n" +
+			"  connection:\n" +
+			"    context: k3d-k3s-default\n" +
+			"    timeout: 5m\n",
+	}
+}
+
+// KindConfigSeeds returns representative kind.yaml documents for seeding fuzz corpora.
+func KindConfigSeeds() []string {
+	return []string{
+		"apiVersion: kind.x-k8s.io/v1alpha4\nkind: Cluster\n",
+		"apiVersion: kind.x-k8s.io/v1alpha4\n" +
+			"kind: Cluster\n" +
+			"name: kind\n" +
+			"networking:\n" +
+			"  disableDefaultCNI: true\n" +
+			"  podSubnet: 10.244.0.0/16\n" +
+			"nodes:\n" +
+			"  - role: control-plane\n" +
+			"  - role: worker\n" +
+			"    extraPortMappings:\n" +
+			"      - containerPort: 80\n" +
+			"        hostPort: 8080\n" +
+			"containerdConfigPatches:\n" +
+			"  - |\n" +
+			"    [plugins.\"io.containerd.grpc.v1.cri\".registry]\n" +
+			"      config_path = \"/etc/containerd/certs.d\"\n",
+	}
+}
+
+// MalformedYAMLVariants derives malformed inputs from a valid YAML document.
+//
+// The variants cover the shapes that commonly break parsers: truncation, tabs used
+// for indentation, unbalanced quotes and brackets, type confusion, anchors and aliases,
+// deep nesting, oversized scalars, invalid UTF-8 and NUL bytes.
+func MalformedYAMLVariants(seed string) []string {
+	variants := []string{
+		"",
+		"\n",
+		"---\n---\n",
+		"null\n",
+		"[]\n",
+		"- a\n- b\n",
+		"\"unterminated\n",
+		"{unbalanced: [\n",
+		"key: &anchor value\nother: *anchor\nmissing: *unknown\n",
+		strings.Repeat("a:\n  ", fuzzDeepNestingLevels) + "leaf\n",
+		strings.Repeat("[", fuzzDeepNestingLevels),
+		"spec: " + strings.Repeat("x", fuzzLongScalarLength) + "\n",
+		"spec:\n  distribution: 12345\n  connection: []\n",
+		"spec:\n  connection:\n    timeout: not-a-duration\n",
+		"\xff\xfe\xfd\n",
+		"kind: Cluster\x00\n",
+	}
+
+	if seed == "" {
+		return variants
+	}
+
+	variants = append(variants,
+		seed[:len(seed)/2],
+		strings.ReplaceAll(seed, "  ", "\t"),
+		strings.ReplaceAll(seed, ":", ""),
+		strings.ReplaceAll(seed, "\n", "\r\n"),
+		seed+seed,
+		seed+"\n: orphan\n",
+		"%YAML 9.9\n"+seed,
+	)
+
+	return variants
+}
+
+// AddYAMLFuzzCorpus seeds f with every seed document and its malformed variants.
+func AddYAMLFuzzCorpus(f *testing.F, seeds ...string) {
+	f.Helper()
+
+	corpus := make([]string, 0, len(seeds))
+
+	for _, seed := range seeds {
+		corpus = append(corpus, seed)
+		corpus = append(corpus, MalformedYAMLVariants(seed)...)
+	}
+
+	if len(seeds) == 0 {
+		corpus = append(corpus, MalformedYAMLVariants("")...)
+	}
+
+	slices.Sort(corpus)
+
+	for _, entry := range slices.Compact(corpus) {
+		f.Add([]byte(entry))
+	}
+}
+
+// AddFuzzCorpusDir seeds f with the contents of every regular file in dir.
+// Missing directories are ignored so corpora can be added incrementally.
+func AddFuzzCorpusDir(f *testing.F, dir string) {
+	f.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+
+		f.Fatalf("failed to read fuzz corpus dir %s: %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name())) //nolint:gosec // test corpus path
+		if err != nil {
+			f.Fatalf("failed to read fuzz corpus file %s: %v", entry.Name(), err)
+		}
+
+		f.Add(bytes.Clone(data))
+	}
+}
+
+// WriteFuzzInput writes data to name inside a fresh temporary directory and returns its path.
+// It lets fuzz targets exercise file-based config loaders.
+func WriteFuzzInput(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	err := os.WriteFile(path, data, testFilePerm)
+	if err != nil {
+		t.Fatalf("failed to write fuzz input %s: %v", path, err)
+	}
+
+	return path
+}
@@ -0,0 +1,117 @@
+package testutils
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const (
+	goldenDirectory     = "testdata"
+	goldenFileExtension = ".golden"
+	goldenUpdateEnvVar  = "UPDATE_GOLDEN"
+)
+
+//nolint:gochecknoglobals // test flag must be registered at package init to be parsed by `go test`
+var updateGolden = flag.Bool("update", false, "rewrite golden files with the current test output")
+
+//nolint:gochecknoglobals // compiled once and shared by golden normalizers
+var (
+	rfc3339Pattern  = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	durationPattern = regexp.MustCompile(`\b(\d+h)?(\d+m)?\d+(\.\d+)?(ns|µs|us|ms|s|m|h)\b`)
+)
+
+// GoldenNormalizer rewrites volatile parts of test output before it is compared with a golden file.
+type GoldenNormalizer func(string) string
+
+// AssertGolden compares got with testdata/<name>.golden relative to the test package.
+//
+// Normalizers are applied to got before comparison, so golden files stay stable across
+// machines and runs. Run `go test ./... -update` (or set UPDATE_GOLDEN=true) to rewrite
+// golden files with the current output.
+func AssertGolden(t *testing.T, name string, got string, normalizers ...GoldenNormalizer) {
+	t.Helper()
+
+	for _, normalize := range normalizers {
+		got = normalize(got)
+	}
+
+	path := GoldenPath(name)
+
+	if shouldUpdateGolden() {
+		err := os.MkdirAll(filepath.Dir(path), testDirectoryPerm)
+		if err != nil {
+			t.Fatalf("failed to create golden directory for %s: %v", path, err)
+		}
+
+		err = os.WriteFile(path, []byte(got), testFilePerm)
+		if err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec // golden paths are controlled by tests
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist; run tests with -update to create it", path)
+	}
+
+	if err != nil {
+		t.Fatalf("failed to read golden file %s: %v", path, err)
+	}
+
+	if string(want) != got {
+		t.Fatalf(
+			"output does not match golden file %s (run with -update to accept)\nwant:\n%s\ngot:\n%s",
+			path,
+			string(want),
+			got,
+		)
+	}
+}
+
+// GoldenPath returns the path of the golden file for name, relative to the test package.
+// Test names containing slashes (from subtests) are flattened into a single file name.
+func GoldenPath(name string) string {
+	replacer := strings.NewReplacer("/", "__", " ", "_")
+
+	return filepath.Join(goldenDirectory, replacer.Replace(name)+goldenFileExtension)
+}
+
+// NormalizeTimestamps replaces RFC 3339 timestamps with a stable placeholder.
+func NormalizeTimestamps(content string) string {
+	return rfc3339Pattern.ReplaceAllString(content, "<TIMESTAMP>")
+}
+
+// NormalizeDurations replaces Go duration strings such as 1.5s or 2m3s with a stable placeholder.
+func NormalizeDurations(content string) string {
+	return durationPattern.ReplaceAllString(content, "<DURATION>")
+}
+
+// NormalizeLineEndings converts CRLF line endings to LF.
+func NormalizeLineEndings(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
+// NormalizePath returns a normalizer replacing every occurrence of path with placeholder.
+// Paths are compared in slash form so golden files are portable across operating systems.
+func NormalizePath(path, placeholder string) GoldenNormalizer {
+	return func(content string) string {
+		if path == "" {
+			return content
+		}
+
+		content = strings.ReplaceAll(content, path, placeholder)
+
+		return strings.ReplaceAll(content, filepath.ToSlash(path), placeholder)
+	}
+}
+
+func shouldUpdateGolden() bool {
+	return *updateGolden || strings.EqualFold(os.Getenv(goldenUpdateEnvVar), "true")
+}
@@ -0,0 +1,109 @@
+package testutils
+
+import (
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/io/scaffolder"
+)
+
+// ksail.yaml fixture generation.
+
+// KSailConfigVariant is one combination of the ksail.yaml test matrix.
+type KSailConfigVariant struct {
+	Distribution v1alpha1.Distribution
+	CNI          v1alpha1.CNI
+	GitOpsEngine v1alpha1.GitOpsEngine
+}
+
+// KSailConfigOption customizes the clusters produced by a KSailConfigVariant.
+type KSailConfigOption func(*v1alpha1.Cluster)
+
+// KSailConfigMatrix returns every distribution × CNI × GitOps engine combination.
+func KSailConfigMatrix() []KSailConfigVariant {
+	distributions := v1alpha1.ValidDistributions()
+	cnis := v1alpha1.ValidCNIs()
+	engines := v1alpha1.ValidGitOpsEngines()
+
+	variants := make([]KSailConfigVariant, 0, len(distributions)*len(cnis)*len(engines))
+
+	for _, distribution := range distributions {
+		for _, cni := range cnis {
+			for _, engine := range engines {
+				variants = append(variants, KSailConfigVariant{
+					Distribution: distribution,
+					CNI:          cni,
+					GitOpsEngine: engine,
+				})
+			}
+		}
+	}
+
+	return variants
+}
+
+// RandomKSailConfigVariant picks a variant from the matrix using rng, for property-based tests.
+func RandomKSailConfigVariant(rng *rand.Rand) KSailConfigVariant {
+	variants := KSailConfigMatrix()
+
+	return variants[rng.IntN(len(variants))]
+}
+
+// Name returns a stable name for the variant, suitable for t.Run.
+func (v KSailConfigVariant) Name() string {
+	return string(v.Distribution) + "/" + string(v.CNI) + "/" + string(v.GitOpsEngine)
+}
+
+// Cluster builds a valid cluster configuration for the variant.
+//
+// The distribution config file name and kube context follow the distribution defaults,
+// and GitOps engines get the local registry and reconcile interval they require, except on
+// External clusters, which cannot reach a local registry, and simulated clusters, which never
+// pull images.
+// Options are applied last.
+func (v KSailConfigVariant) Cluster(opts ...KSailConfigOption) *v1alpha1.Cluster {
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Distribution = v.Distribution
+	cluster.Spec.DistributionConfig = v1alpha1.ExpectedDistributionConfigName(v.Distribution)
+	cluster.Spec.SourceDirectory = v1alpha1.DefaultSourceDirectory
+	cluster.Spec.Connection.Context = v1alpha1.ExpectedContextName(v.Distribution)
+	cluster.Spec.CNI = v.CNI
+	cluster.Spec.GitOpsEngine = v.GitOpsEngine
+
+	if v.GitOpsEngine != v1alpha1.GitOpsEngineNone && v.Distribution != v1alpha1.DistributionExternal &&
+		!v.Distribution.IsSimulated() {
+		cluster.Spec.LocalRegistry = v1alpha1.LocalRegistryEnabled
+		cluster.Spec.Options.LocalRegistry.HostPort = v1alpha1.DefaultLocalRegistryPort
+	}
+
+	if v.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
+		cluster.Spec.Options.Flux.Interval = v1alpha1.DefaultFluxInterval
+	}
+
+	for _, opt := range opts {
+		opt(cluster)
+	}
+
+	return cluster
+}
+
+// YAML renders the variant's cluster configuration as ksail.yaml content.
+func (v KSailConfigVariant) YAML(t *testing.T, opts ...KSailConfigOption) string {
+	t.Helper()
+
+	return MustMarshal(t, yamlmarshaller.NewMarshaller[v1alpha1.Cluster](), *v.Cluster(opts...))
+}
+
+// WriteProject scaffolds ksail.yaml, the matching distribution config, and the source
+// directory for the variant into dir using the project scaffolder.
+func (v KSailConfigVariant) WriteProject(t *testing.T, dir string, opts ...KSailConfigOption) {
+	t.Helper()
+
+	err := scaffolder.NewScaffolder(*v.Cluster(opts...), io.Discard).Scaffold(dir, true)
+	if err != nil {
+		t.Fatalf("failed to scaffold %s project: %v", v.Name(), err)
+	}
+}
@@ -0,0 +1,267 @@
+package testutils
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	dockerclient "github.com/devantler-tech/ksail-go/pkg/client/docker"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+)
+
+// Test doubles for registry backends.
+
+// StubRegistry is a snapshot of a registry tracked by StubRegistryManager.
+type StubRegistry struct {
+	Name        string
+	Port        int
+	UpstreamURL string
+	Volume      string
+	Networks    []string
+	Running     bool
+}
+
+// StubRegistryManager is an in-memory test double for registry.Backend.
+//
+// It mirrors the semantics of the Docker-backed RegistryManager: creating an existing
+// registry is a no-op, volumes default to the normalized registry name, and deleting a
+// registry that is still attached to another cluster network only detaches it.
+// All state is inspectable so mirror-registry flows can be tested without Docker.
+type StubRegistryManager struct {
+	// CreateErrs makes CreateRegistry fail for the named registries.
+	CreateErrs map[string]error
+	// DeleteErrs makes DeleteRegistry fail for the named registries.
+	DeleteErrs map[string]error
+	// ListErr makes ListRegistries fail.
+	ListErr error
+
+	CreateCalls []dockerclient.RegistryConfig
+	DeleteCalls []string
+
+	mu         sync.Mutex
+	registries map[string]*StubRegistry
+	volumes    map[string]struct{}
+}
+
+// NewStubRegistryManager creates an empty in-memory registry manager.
+func NewStubRegistryManager() *StubRegistryManager {
+	return &StubRegistryManager{
+		CreateErrs: map[string]error{},
+		DeleteErrs: map[string]error{},
+		registries: map[string]*StubRegistry{},
+		volumes:    map[string]struct{}{},
+	}
+}
+
+// CreateRegistry implements registry.Backend.
+func (m *StubRegistryManager) CreateRegistry(
+	_ context.Context,
+	config dockerclient.RegistryConfig,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.CreateCalls = append(m.CreateCalls, config)
+
+	if err := m.CreateErrs[config.Name]; err != nil {
+		return err
+	}
+
+	if _, exists := m.registries[config.Name]; exists {
+		return nil
+	}
+
+	volume := config.VolumeName
+	if volume == "" {
+		volume = dockerclient.NormalizeVolumeName(config.Name)
+	}
+
+	if volume == "" {
+		volume = config.Name
+	}
+
+	m.volumes[volume] = struct{}{}
+
+	var networks []string
+	if network := strings.TrimSpace(config.NetworkName); network != "" {
+		networks = append(networks, network)
+	}
+
+	m.registries[config.Name] = &StubRegistry{
+		Name:        config.Name,
+		Port:        config.Port,
+		UpstreamURL: config.UpstreamURL,
+		Volume:      volume,
+		Networks:    networks,
+		Running:     true,
+	}
+
+	return nil
+}
+
+// DeleteRegistry implements registry.Backend.
+func (m *StubRegistryManager) DeleteRegistry(
+	_ context.Context,
+	name, _ string,
+	deleteVolume bool,
+	networkName string,
+	volumeName string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.DeleteCalls = append(m.DeleteCalls, name)
+
+	if err := m.DeleteErrs[name]; err != nil {
+		return err
+	}
+
+	reg, exists := m.registries[name]
+	if !exists {
+		if deleteVolume {
+			m.removeFirstVolume(volumeName, dockerclient.NormalizeVolumeName(name), name)
+		}
+
+		return dockerclient.ErrRegistryNotFound
+	}
+
+	network := strings.TrimSpace(networkName)
+	if network != "" {
+		reg.Networks = slices.DeleteFunc(reg.Networks, func(candidate string) bool {
+			return strings.EqualFold(candidate, network)
+		})
+	}
+
+	if slices.ContainsFunc(reg.Networks, isStubClusterNetwork) {
+		return nil
+	}
+
+	delete(m.registries, name)
+
+	if deleteVolume {
+		volume := strings.TrimSpace(volumeName)
+		if volume == "" {
+			volume = reg.Volume
+		}
+
+		delete(m.volumes, volume)
+	}
+
+	return nil
+}
+
+// ListRegistries implements registry.Backend.
+func (m *StubRegistryManager) ListRegistries(context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ListErr != nil {
+		return nil, m.ListErr
+	}
+
+	names := make([]string, 0, len(m.registries))
+	for name := range m.registries {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// GetRegistryPort implements registry.Backend.
+func (m *StubRegistryManager) GetRegistryPort(_ context.Context, name string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reg, exists := m.registries[name]
+	if !exists {
+		return 0, dockerclient.ErrRegistryNotFound
+	}
+
+	if reg.Port == 0 {
+		return 0, dockerclient.ErrRegistryPortNotFound
+	}
+
+	return reg.Port, nil
+}
+
+// ConnectNetwork attaches a registry to a network, emulating a Docker network connect.
+// It returns dockerclient.ErrRegistryNotFound when the registry does not exist.
+func (m *StubRegistryManager) ConnectNetwork(name, network string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reg, exists := m.registries[name]
+	if !exists {
+		return dockerclient.ErrRegistryNotFound
+	}
+
+	if !slices.Contains(reg.Networks, network) {
+		reg.Networks = append(reg.Networks, network)
+	}
+
+	return nil
+}
+
+// SetRunning marks a registry as running or stopped.
+func (m *StubRegistryManager) SetRunning(name string, running bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if reg, exists := m.registries[name]; exists {
+		reg.Running = running
+	}
+}
+
+// Registry returns a snapshot of the named registry.
+func (m *StubRegistryManager) Registry(name string) (StubRegistry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reg, exists := m.registries[name]
+	if !exists {
+		return StubRegistry{}, false
+	}
+
+	snapshot := *reg
+	snapshot.Networks = slices.Clone(reg.Networks)
+
+	return snapshot, true
+}
+
+// Volumes returns the sorted names of volumes currently tracked.
+func (m *StubRegistryManager) Volumes() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	volumes := make([]string, 0, len(m.volumes))
+	for volume := range m.volumes {
+		volumes = append(volumes, volume)
+	}
+
+	slices.Sort(volumes)
+
+	return volumes
+}
+
+func (m *StubRegistryManager) removeFirstVolume(candidates ...string) {
+	for _, candidate := range candidates {
+		trimmed := strings.TrimSpace(candidate)
+		if _, exists := m.volumes[trimmed]; trimmed != "" && exists {
+			delete(m.volumes, trimmed)
+
+			return
+		}
+	}
+}
+
+func isStubClusterNetwork(network string) bool {
+	lower := strings.ToLower(strings.TrimSpace(network))
+
+	return lower == "kind" || lower == "k3d" ||
+		strings.HasPrefix(lower, "kind-") || strings.HasPrefix(lower, "k3d-")
+}
+
+var _ registry.Backend = (*StubRegistryManager)(nil)
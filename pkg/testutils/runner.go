@@ -0,0 +1,48 @@
+package testutils
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// RecordingRunner is a test double for execrunner.Runner that records every command it is
+// asked to run and answers it with Respond.
+type RecordingRunner struct {
+	// Respond returns the output and error of a command; nil answers every command with no
+	// output and no error.
+	Respond func(name string, args []string) ([]byte, error)
+
+	mu    sync.Mutex
+	calls [][]string
+}
+
+// Run records name and args and returns the answer of Respond. Pass it where an
+// execrunner.Runner is expected.
+func (r *RecordingRunner) Run(_ context.Context, name string, args ...string) ([]byte, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, append([]string{name}, args...))
+	r.mu.Unlock()
+
+	if r.Respond == nil {
+		return nil, nil
+	}
+
+	return r.Respond(name, args)
+}
+
+// Calls returns the recorded commands, each the binary followed by its arguments.
+func (r *RecordingRunner) Calls() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.calls)
+}
+
+// Reset forgets the recorded commands.
+func (r *RecordingRunner) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
@@ -0,0 +1,282 @@
+package testutils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail-go/pkg/apis/cluster/v1alpha1"
+	ksailconfigmanager "github.com/devantler-tech/ksail-go/pkg/io/config-manager/ksail"
+	yamlmarshaller "github.com/devantler-tech/ksail-go/pkg/io/marshaller/yaml"
+	"github.com/devantler-tech/ksail-go/pkg/svc/provisioner/registry"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// Isolated temporary projects.
+
+const (
+	tempProjectNamePrefix   = "ksail-"
+	tempProjectSuffixBytes  = 4
+	tempProjectMaxSlugLen   = 17
+	tempProjectServerURL    = "https://127.0.0.1:6443"
+	tempProjectKubeconfig   = "kubeconfig"
+	tempProjectKSailConfig  = "ksail.yaml"
+	tempProjectRegistryName = "-" + registry.LocalRegistryContainerName
+)
+
+// TempProject is an isolated KSail project living in its own temporary directory.
+//
+// Every project gets a cluster name that is unique across tests and test processes, a
+// matching registry container name, and a kubeconfig whose only context points at the
+// project's cluster. ksail.yaml references the distribution config, source directory and
+// kubeconfig by absolute path, so tests can load it via Viper.SetConfigFile without
+// changing the working directory and can therefore run with t.Parallel.
+// The directory is removed automatically when the test finishes.
+type TempProject struct {
+	// Dir is the project root.
+	Dir string
+	// ConfigPath is the absolute path to ksail.yaml.
+	ConfigPath string
+	// DistributionConfigPath is the absolute path to kind.yaml or k3d.yaml, or empty for
+	// External clusters.
+	DistributionConfigPath string
+	// SourceDir is the absolute path to the workload source directory.
+	SourceDir string
+	// KubeconfigPath is the absolute path to the project kubeconfig.
+	KubeconfigPath string
+	// ClusterName is the unique cluster name written to the distribution config.
+	ClusterName string
+	// Context is the kube context for ClusterName.
+	Context string
+	// RegistryName is the unique local registry container name for the project.
+	RegistryName string
+	// Cluster is the configuration written to ksail.yaml.
+	Cluster *v1alpha1.Cluster
+}
+
+// TempProjectOption customizes a TempProject before it is written to disk.
+type TempProjectOption func(*tempProjectOptions)
+
+type tempProjectOptions struct {
+	variant       KSailConfigVariant
+	serverURL     string
+	configOptions []KSailConfigOption
+}
+
+// WithTempProjectVariant selects the distribution, CNI and GitOps engine of the project.
+// The default is Kind with the default CNI and no GitOps engine.
+func WithTempProjectVariant(variant KSailConfigVariant) TempProjectOption {
+	return func(o *tempProjectOptions) {
+		o.variant = variant
+	}
+}
+
+// WithTempProjectServer sets the API server URL written to the project kubeconfig.
+func WithTempProjectServer(serverURL string) TempProjectOption {
+	return func(o *tempProjectOptions) {
+		o.serverURL = serverURL
+	}
+}
+
+// WithTempProjectConfig applies opts to the cluster configuration before ksail.yaml is written.
+func WithTempProjectConfig(opts ...KSailConfigOption) TempProjectOption {
+	return func(o *tempProjectOptions) {
+		o.configOptions = append(o.configOptions, opts...)
+	}
+}
+
+// NewTempProject scaffolds an isolated project for t in a fresh temporary directory.
+func NewTempProject(t *testing.T, opts ...TempProjectOption) *TempProject {
+	t.Helper()
+
+	options := tempProjectOptions{
+		variant: KSailConfigVariant{
+			Distribution: v1alpha1.DistributionKind,
+			CNI:          v1alpha1.CNIDefault,
+			GitOpsEngine: v1alpha1.GitOpsEngineNone,
+		},
+		serverURL: tempProjectServerURL,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dir := t.TempDir()
+	clusterName := UniqueClusterName(t)
+
+	contextName := ksailconfigmanager.ContextNameForCluster(options.variant.Distribution, clusterName)
+	if contextName == "" {
+		contextName = clusterName
+	}
+
+	project := &TempProject{
+		Dir:            dir,
+		ConfigPath:     filepath.Join(dir, tempProjectKSailConfig),
+		SourceDir:      filepath.Join(dir, v1alpha1.DefaultSourceDirectory),
+		KubeconfigPath: filepath.Join(dir, tempProjectKubeconfig),
+		ClusterName:    clusterName,
+		Context:        contextName,
+		RegistryName:   clusterName + tempProjectRegistryName,
+	}
+
+	options.variant.WriteProject(t, dir, options.configOptions...)
+
+	if configName := v1alpha1.ExpectedDistributionConfigName(options.variant.Distribution); configName != "" {
+		project.DistributionConfigPath = filepath.Join(dir, configName)
+		renameDistributionConfig(t, options.variant.Distribution, project.DistributionConfigPath, clusterName)
+	}
+
+	project.Cluster = options.variant.Cluster(options.configOptions...)
+	project.Cluster.Spec.DistributionConfig = project.DistributionConfigPath
+	project.Cluster.Spec.SourceDirectory = project.SourceDir
+	project.Cluster.Spec.Connection.Kubeconfig = project.KubeconfigPath
+	project.Cluster.Spec.Connection.Context = project.Context
+
+	project.writeFile(t, project.ConfigPath, MustMarshal(
+		t,
+		yamlmarshaller.NewMarshaller[v1alpha1.Cluster](),
+		*project.Cluster,
+	))
+	project.writeFile(t, project.KubeconfigPath, project.kubeconfig(options.serverURL))
+
+	return project
+}
+
+// UniqueClusterName returns a cluster name derived from the test name with a random suffix.
+//
+// Names are lowercase DNS labels of at most 32 characters, which keeps them valid for Kind
+// and K3d clusters as well as for the container names derived from them.
+func UniqueClusterName(t *testing.T) string {
+	t.Helper()
+
+	suffix := make([]byte, tempProjectSuffixBytes)
+
+	_, err := rand.Read(suffix)
+	if err != nil {
+		t.Fatalf("failed to generate cluster name suffix: %v", err)
+	}
+
+	slug := sanitizeClusterSlug(t.Name())
+	if slug == "" {
+		return tempProjectNamePrefix + hex.EncodeToString(suffix)
+	}
+
+	return tempProjectNamePrefix + slug + "-" + hex.EncodeToString(suffix)
+}
+
+// WriteFile writes content to a path relative to the project root, creating parent directories.
+func (p *TempProject) WriteFile(t *testing.T, relPath, content string) string {
+	t.Helper()
+
+	path := filepath.Join(p.Dir, relPath)
+
+	err := os.MkdirAll(filepath.Dir(path), testDirectoryPerm)
+	if err != nil {
+		t.Fatalf("failed to create directory for %s: %v", relPath, err)
+	}
+
+	p.writeFile(t, path, content)
+
+	return path
+}
+
+func (p *TempProject) writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), testFilePerm)
+	if err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func (p *TempProject) kubeconfig(serverURL string) string {
+	return "apiVersion: v1\n" +
+		"kind: Config\n" +
+		"clusters:\n" +
+		"- name: " + p.Context + "\n" +
+		"  cluster:\n" +
+		"    server: " + serverURL + "\n" +
+		"    insecure-skip-tls-verify: true\n" +
+		"contexts:\n" +
+		"- name: " + p.Context + "\n" +
+		"  context:\n" +
+		"    cluster: " + p.Context + "\n" +
+		"    user: " + p.Context + "\n" +
+		"current-context: " + p.Context + "\n" +
+		"users:\n" +
+		"- name: " + p.Context + "\n" +
+		"  user: {}\n"
+}
+
+func renameDistributionConfig(
+	t *testing.T,
+	distribution v1alpha1.Distribution,
+	path string,
+	clusterName string,
+) {
+	t.Helper()
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is inside the test's temp dir
+	if err != nil {
+		t.Fatalf("failed to read distribution config %s: %v", path, err)
+	}
+
+	var content string
+
+	switch distribution {
+	case v1alpha1.DistributionKind:
+		marshaller := yamlmarshaller.NewMarshaller[v1alpha4.Cluster]()
+
+		var cluster v1alpha4.Cluster
+
+		MustUnmarshal(t, marshaller, data, &cluster)
+		cluster.Name = clusterName
+		content = MustMarshal(t, marshaller, cluster)
+	case v1alpha1.DistributionK3d:
+		marshaller := yamlmarshaller.NewMarshaller[k3dv1alpha5.SimpleConfig]()
+
+		var cluster k3dv1alpha5.SimpleConfig
+
+		MustUnmarshal(t, marshaller, data, &cluster)
+		cluster.Name = clusterName
+		content = MustMarshal(t, marshaller, cluster)
+	default:
+		return
+	}
+
+	err = os.WriteFile(path, []byte(content), testFilePerm)
+	if err != nil {
+		t.Fatalf("failed to write distribution config %s: %v", path, err)
+	}
+}
+
+func sanitizeClusterSlug(name string) string {
+	var builder strings.Builder
+
+	lastDash := true
+
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			builder.WriteRune(r)
+
+			lastDash = false
+		case !lastDash:
+			builder.WriteRune('-')
+
+			lastDash = true
+		}
+	}
+
+	slug := builder.String()
+	if len(slug) > tempProjectMaxSlugLen {
+		slug = slug[len(slug)-tempProjectMaxSlugLen:]
+	}
+
+	return strings.Trim(slug, "-")
+}
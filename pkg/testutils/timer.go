@@ -1,6 +1,10 @@
 package testutils
 
-import "time"
+import (
+	"slices"
+	"sync"
+	"time"
+)
 
 // RecordingTimer is a lightweight test implementation of the timer.Timer interface
 // that captures the number of Start() and NewStage() calls and returns a fixed
@@ -31,3 +35,140 @@ func (r *RecordingTimer) Stop() {}
 func (r *RecordingTimer) GetTiming() (time.Duration, time.Duration) {
 	return time.Millisecond, time.Millisecond
 }
+
+// fakeClockEpoch is the virtual time a FakeClock starts at.
+//
+//nolint:gochecknoglobals // fixed reference time shared by all fake clocks
+var fakeClockEpoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeClock is a RecordingTimer backed by a virtual clock that only moves when the
+// test advances it.
+//
+// It implements timer.Timer with durations computed from virtual time, records
+// the virtual time of every Start and NewStage call as stage boundaries, and can
+// be passed to timer.NewWithClock via Now. Timeout and ETA logic can therefore be
+// tested deterministically without real sleeps.
+type FakeClock struct {
+	RecordingTimer
+
+	mu         sync.Mutex
+	now        time.Time
+	boundaries []time.Time
+	waiters    []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+// NewFakeClock creates a FakeClock positioned at a fixed reference time.
+func NewFakeClock() *FakeClock {
+	return &FakeClock{now: fakeClockEpoch}
+}
+
+// Now returns the current virtual time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Since returns the virtual time elapsed since start.
+func (c *FakeClock) Since(start time.Time) time.Duration {
+	return c.Now().Sub(start)
+}
+
+// Advance moves the virtual clock forward by d and fires every After channel whose
+// deadline has been reached. Negative durations are ignored.
+func (c *FakeClock) Advance(d time.Duration) {
+	if d < 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.waiters[:0]
+
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(c.now) {
+			pending = append(pending, waiter)
+
+			continue
+		}
+
+		waiter.channel <- c.now
+	}
+
+	c.waiters = pending
+}
+
+// After returns a channel that receives the virtual time once the clock has been
+// advanced by at least d. A non-positive d fires immediately.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	channel := make(chan time.Time, 1)
+
+	if d <= 0 {
+		channel <- c.now
+
+		return channel
+	}
+
+	c.waiters = append(c.waiters, fakeClockWaiter{deadline: c.now.Add(d), channel: channel})
+
+	return channel
+}
+
+// Start records a Start invocation and resets the stage boundaries to the current virtual time.
+func (c *FakeClock) Start() {
+	c.RecordingTimer.Start()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.boundaries = []time.Time{c.now}
+}
+
+// NewStage records a NewStage invocation and adds a stage boundary at the current virtual time.
+func (c *FakeClock) NewStage() {
+	c.RecordingTimer.NewStage()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.boundaries = append(c.boundaries, c.now)
+}
+
+// AdvanceStage advances the clock by d and then starts a new stage.
+func (c *FakeClock) AdvanceStage(d time.Duration) {
+	c.Advance(d)
+	c.NewStage()
+}
+
+// StageBoundaries returns the virtual times at which Start and each NewStage were called.
+func (c *FakeClock) StageBoundaries() []time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.boundaries)
+}
+
+// GetTiming returns the virtual durations since Start and since the last stage boundary.
+// It returns (0, 0) when Start has not been called.
+func (c *FakeClock) GetTiming() (time.Duration, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.boundaries) == 0 {
+		return 0, 0
+	}
+
+	return c.now.Sub(c.boundaries[0]), c.now.Sub(c.boundaries[len(c.boundaries)-1])
+}
@@ -7,7 +7,8 @@
 // The executor intercepts Cobra's error stream, applies normalization rules
 // (such as removing redundant "Error:" prefixes), and wraps the result in a
 // CommandError that preserves both the formatted message and the original error
-// for proper error chain semantics.
+// for proper error chain semantics. Commands return an ExitCodeError to exit with a
+// code other than 1.
 //
 // Example usage:
 //
@@ -17,7 +18,7 @@
 //	if err != nil {
 //	    // Error is a *CommandError with normalized message
 //	    fmt.Fprintln(os.Stderr, err)
-//	    os.Exit(1)
+//	    os.Exit(errorhandler.ExitCode(err))
 //	}
 //
 //	// Create an executor with custom normalizer
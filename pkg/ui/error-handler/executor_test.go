@@ -224,3 +224,35 @@ func commandErrorString(err *errorhandler.CommandError) string {
 
 	return err.Error()
 }
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	codeErr := &errorhandler.ExitCodeError{Code: 3, Err: errTestBoom}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: 0},
+		{name: "plain error", err: errTestBoom, want: 1},
+		{name: "exit code error", err: codeErr, want: 3},
+		{name: "wrapped exit code error", err: errorhandler.NewCommandError("failed", codeErr), want: 3},
+		{name: "no code", err: &errorhandler.ExitCodeError{Code: -1, Err: errTestBoom}, want: 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := errorhandler.ExitCode(testCase.err); got != testCase.want {
+				t.Errorf("expected exit code %d, got %d", testCase.want, got)
+			}
+		})
+	}
+
+	if !errors.Is(codeErr, errTestBoom) {
+		t.Error("expected the exit code error to unwrap to its cause")
+	}
+}
@@ -0,0 +1,35 @@
+package errorhandler
+
+import "errors"
+
+// ExitCodeError makes the CLI exit with Code instead of the default failure code, e.g. to pass
+// through the exit code of a child process a command ran.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap exposes the underlying error for errors.Is/errors.As consumers.
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit code for the error of a command: 0 for nil, the code of an
+// ExitCodeError in the chain of err, or 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var codeErr *ExitCodeError
+	if errors.As(err, &codeErr) && codeErr.Code > 0 {
+		return codeErr.Code
+	}
+
+	return 1
+}
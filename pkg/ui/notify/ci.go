@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// CIFormat selects how messages are rendered for a continuous integration system.
+type CIFormat string
+
+const (
+	// CIFormatNone renders messages with the default styled output.
+	CIFormatNone CIFormat = ""
+	// CIFormatGitHub renders errors and warnings as GitHub Actions workflow commands
+	// and wraps each titled stage in a collapsible log group.
+	CIFormatGitHub CIFormat = "github"
+)
+
+// ErrUnsupportedCIFormat is returned when an unknown CI format is requested.
+var ErrUnsupportedCIFormat = errors.New("unsupported CI format")
+
+// ValidCIFormats returns the CI formats accepted by ParseCIFormat.
+func ValidCIFormats() []CIFormat {
+	return []CIFormat{CIFormatGitHub}
+}
+
+// ParseCIFormat converts a flag value into a CIFormat.
+// The empty string selects CIFormatNone.
+func ParseCIFormat(value string) (CIFormat, error) {
+	format := CIFormat(strings.ToLower(strings.TrimSpace(value)))
+
+	switch format {
+	case CIFormatNone, CIFormatGitHub:
+		return format, nil
+	default:
+		return CIFormatNone, fmt.Errorf(
+			"%w: %q (valid options: %s)",
+			ErrUnsupportedCIFormat,
+			value,
+			CIFormatGitHub,
+		)
+	}
+}
+
+// messageWriter is implemented by writers that render messages themselves.
+type messageWriter interface {
+	io.Writer
+	writeMessage(msg Message, content string)
+}
+
+// GitHubActionsWriter renders messages as GitHub Actions workflow commands.
+//
+// Errors and warnings become ::error:: and ::warning:: annotations so they surface inline
+// on pull requests, and every title message opens a ::group:: that lasts until the next
+// title, the next error, or EndGroup. All other messages and raw writes are passed through
+// to the underlying writer unchanged.
+type GitHubActionsWriter struct {
+	out   io.Writer
+	group *githubGroupState
+}
+
+type githubGroupState struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewGitHubActionsWriter wraps out with GitHub Actions annotation rendering.
+func NewGitHubActionsWriter(out io.Writer) *GitHubActionsWriter {
+	return &GitHubActionsWriter{out: out, group: &githubGroupState{}}
+}
+
+// Wrap returns a writer for another stream that shares this writer's log group state,
+// so a group opened on stdout is closed before an error is annotated on stderr.
+func (w *GitHubActionsWriter) Wrap(out io.Writer) *GitHubActionsWriter {
+	return &GitHubActionsWriter{out: out, group: w.group}
+}
+
+// Write passes raw output through to the underlying writer.
+func (w *GitHubActionsWriter) Write(p []byte) (int, error) {
+	n, err := w.out.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("write github actions output: %w", err)
+	}
+
+	return n, nil
+}
+
+// EndGroup closes the currently open log group, if any.
+func (w *GitHubActionsWriter) EndGroup() {
+	w.group.mu.Lock()
+	defer w.group.mu.Unlock()
+
+	w.group.end()
+}
+
+// EndGroup closes the open log group when writer renders GitHub Actions output.
+// It is a no-op for any other writer.
+func EndGroup(writer io.Writer) {
+	if githubWriter, ok := writer.(*GitHubActionsWriter); ok {
+		githubWriter.EndGroup()
+	}
+}
+
+// ErrorWriter returns stderr rendered in the same CI format as stdout.
+// It lets callers report a final error with the format selected for a command after the
+// command's own writers have been restored.
+func ErrorWriter(stdout, stderr io.Writer) io.Writer {
+	if githubWriter, ok := stdout.(*GitHubActionsWriter); ok {
+		if _, wrapped := stderr.(*GitHubActionsWriter); !wrapped {
+			return githubWriter.Wrap(stderr)
+		}
+	}
+
+	return stderr
+}
+
+func (w *GitHubActionsWriter) writeMessage(msg Message, content string) {
+	switch msg.Type {
+	case ErrorType:
+		w.EndGroup()
+		w.annotate("error", content)
+	case WarningType:
+		w.annotate("warning", content)
+	case TitleType:
+		w.startGroup(msg, content)
+	case ActivityType, GenerateType, SuccessType, InfoType:
+		w.passThrough(msg)
+	default:
+		w.passThrough(msg)
+	}
+}
+
+// passThrough renders msg with the default styling on the underlying writer.
+func (w *GitHubActionsWriter) passThrough(msg Message) {
+	msg.Writer = w.out
+	WriteMessage(msg)
+}
+
+func (w *GitHubActionsWriter) annotate(command, content string) {
+	_, err := fmt.Fprintf(w.out, "::%s::%s\n", command, escapeWorkflowCommandData(content))
+	handleNotifyError(err)
+}
+
+func (w *GitHubActionsWriter) startGroup(msg Message, content string) {
+	w.group.mu.Lock()
+	defer w.group.mu.Unlock()
+
+	w.group.end()
+
+	emoji := msg.Emoji
+	if emoji == "" {
+		emoji = "ℹ️"
+	}
+
+	_, err := fmt.Fprintf(w.out, "::group::%s %s\n", emoji, escapeWorkflowCommandData(content))
+	handleNotifyError(err)
+
+	w.group.writer = w.out
+}
+
+// end closes the open group. Callers must hold mu.
+func (s *githubGroupState) end() {
+	if s.writer == nil {
+		return
+	}
+
+	_, err := fmt.Fprintln(s.writer, "::endgroup::")
+	handleNotifyError(err)
+
+	s.writer = nil
+}
+
+// escapeWorkflowCommandData escapes message data as required by GitHub Actions workflow
+// commands, keeping multi-line messages in a single annotation.
+func escapeWorkflowCommandData(data string) string {
+	replacer := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+	return replacer.Replace(strings.TrimRight(data, "\n"))
+}
@@ -0,0 +1,81 @@
+package notify_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	notify "github.com/devantler-tech/ksail-go/pkg/ui/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCIFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := notify.ParseCIFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, notify.CIFormatNone, format)
+
+	format, err = notify.ParseCIFormat(" GitHub ")
+	require.NoError(t, err)
+	assert.Equal(t, notify.CIFormatGitHub, format)
+
+	_, err = notify.ParseCIFormat("jenkins")
+	require.ErrorIs(t, err, notify.ErrUnsupportedCIFormat)
+}
+
+func TestGitHubActionsWriter_RendersAnnotationsAndGroups(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	out := notify.NewGitHubActionsWriter(&stdout)
+	errOut := out.Wrap(&stderr)
+	clock := testutils.NewFakeClock()
+	clock.Start()
+
+	notify.WriteMessage(notify.Message{Type: notify.TitleType, Content: "Create cluster...", Emoji: "🚀", Writer: out})
+	notify.WriteMessage(notify.Message{Type: notify.ActivityType, Content: "creating cluster", Writer: out})
+	notify.WriteMessage(notify.Message{
+		Type:    notify.WarningType,
+		Content: "disk usage at %d%%\nconsider pruning",
+		Args:    []any{90},
+		Writer:  out,
+	})
+	clock.Advance(1500 * time.Millisecond)
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "cluster created",
+		Timer:   clock,
+		Writer:  out,
+	})
+	notify.WriteMessage(notify.Message{Type: notify.TitleType, Content: "Install CNI...", Emoji: "🌐", Writer: out})
+	notify.WriteMessage(notify.Message{Type: notify.ErrorType, Content: "install failed", Writer: errOut})
+	notify.EndGroup(out)
+
+	assert.Equal(t,
+		"::group::🚀 Create cluster...\n"+
+			"► creating cluster\n"+
+			"::warning::disk usage at 90%25%0Aconsider pruning\n"+
+			"✔ cluster created\n"+
+			"⏲ current: 1.5s\n"+
+			"  total:  1.5s\n"+
+			"::endgroup::\n"+
+			"::group::🌐 Install CNI...\n"+
+			"::endgroup::\n",
+		stdout.String(),
+	)
+	assert.Equal(t, "::error::install failed\n", stderr.String())
+}
+
+func TestEndGroup_IgnoresPlainWriters(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	notify.EndGroup(&buffer)
+
+	assert.Empty(t, buffer.String())
+}
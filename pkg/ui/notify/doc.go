@@ -3,4 +3,7 @@
 // This package contains functions for displaying formatted messages to the user,
 // including success, error, warning, info, and activity messages with appropriate
 // symbols and colors, as well as timing information formatting.
+//
+// GitHubActionsWriter renders the same messages as GitHub Actions workflow commands
+// (annotations and log groups) for use in CI jobs.
 package notify
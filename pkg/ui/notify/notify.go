@@ -64,6 +64,13 @@ func WriteMessage(msg Message) {
 		content = fmt.Sprintf(msg.Content, msg.Args...)
 	}
 
+	// Let CI-aware writers render the message themselves
+	if writer, ok := msg.Writer.(messageWriter); ok {
+		writer.writeMessage(msg, content)
+
+		return
+	}
+
 	// Get message configuration based on type
 	config := getMessageConfig(msg.Type)
 
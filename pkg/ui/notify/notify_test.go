@@ -10,180 +10,99 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	notify "github.com/devantler-tech/ksail-go/pkg/ui/notify"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 )
 
-func TestWriteMessage_ErrorType(t *testing.T) {
+func TestWriteMessage_Golden(t *testing.T) {
 	t.Parallel()
 
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ErrorType,
-		Content: "test error",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "✗ test error\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_ErrorType_WithFormatting(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ErrorType,
-		Content: "error: %s (%d)",
-		Args:    []any{"failed", 42},
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "✗ error: failed (42)\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_WarningType(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.WarningType,
-		Content: "test warning",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "⚠ test warning\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_SuccessType(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.SuccessType,
-		Content: "test success",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "✔ test success\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_ActivityType(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.ActivityType,
-		Content: "test activity",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "► test activity\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_InfoType(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.InfoType,
-		Content: "test info",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "ℹ test info\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_MultiLineContentIndented(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.SuccessType,
-		Content: "first line\nsecond line\n\nthird line",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "✔ first line\n  second line\n\n  third line\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
-	}
-}
-
-func TestWriteMessage_TitleType(t *testing.T) {
-	t.Parallel()
-
-	var out bytes.Buffer
-
-	notify.WriteMessage(notify.Message{
-		Type:    notify.TitleType,
-		Content: "test title",
-		Emoji:   "🚀",
-		Writer:  &out,
-	})
-
-	got := out.String()
-	want := "🚀 test title\n"
-
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
+	tests := []struct {
+		name    string
+		message notify.Message
+	}{
+		{
+			name:    "error",
+			message: notify.Message{Type: notify.ErrorType, Content: "test error"},
+		},
+		{
+			name: "error_with_formatting",
+			message: notify.Message{
+				Type:    notify.ErrorType,
+				Content: "error: %s (%d)",
+				Args:    []any{"failed", 42},
+			},
+		},
+		{
+			name:    "warning",
+			message: notify.Message{Type: notify.WarningType, Content: "test warning"},
+		},
+		{
+			name:    "success",
+			message: notify.Message{Type: notify.SuccessType, Content: "test success"},
+		},
+		{
+			name:    "activity",
+			message: notify.Message{Type: notify.ActivityType, Content: "test activity"},
+		},
+		{
+			name:    "info",
+			message: notify.Message{Type: notify.InfoType, Content: "test info"},
+		},
+		{
+			name: "multi_line_content_indented",
+			message: notify.Message{
+				Type:    notify.SuccessType,
+				Content: "first line\nsecond line\n\nthird line",
+			},
+		},
+		{
+			name: "title",
+			message: notify.Message{
+				Type:    notify.TitleType,
+				Content: "test title",
+				Emoji:   "🚀",
+			},
+		},
+		{
+			name: "title_default_emoji",
+			message: notify.Message{
+				Type:    notify.TitleType,
+				Content: "test title with default emoji",
+			},
+		},
+		{
+			name: "success_renders_timing_block",
+			message: notify.Message{
+				Type:    notify.SuccessType,
+				Content: "completion message",
+				Timer:   &fixedTimer{total: 3 * time.Second, stage: 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "error_does_not_render_timing_block",
+			message: notify.Message{
+				Type:    notify.ErrorType,
+				Content: "test error",
+				Timer:   &fixedTimer{total: time.Second, stage: 10 * time.Millisecond},
+			},
+		},
 	}
-}
 
-func TestWriteMessage_TitleType_DefaultEmoji(t *testing.T) {
-	t.Parallel()
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
 
-	var out bytes.Buffer
+			var out bytes.Buffer
 
-	notify.WriteMessage(notify.Message{
-		Type:    notify.TitleType,
-		Content: "test title with default emoji",
-		Writer:  &out,
-	})
+			message := testCase.message
+			message.Writer = &out
 
-	got := out.String()
-	want := "ℹ️ test title with default emoji\n"
+			notify.WriteMessage(message)
 
-	if got != want {
-		t.Fatalf("output mismatch. want %q, got %q", want, got)
+			testutils.AssertGolden(t, t.Name(), out.String())
+		})
 	}
 }
 
@@ -192,10 +111,13 @@ func TestWriteMessage_WithTimer(t *testing.T) {
 
 	var out bytes.Buffer
 
-	tmr := timer.New()
+	clock := testutils.NewFakeClock()
+	tmr := timer.NewWithClock(clock.Now)
 	tmr.Start()
 
-	time.Sleep(10 * time.Millisecond)
+	clock.Advance(1500 * time.Millisecond)
+	tmr.NewStage()
+	clock.Advance(250 * time.Millisecond)
 
 	notify.WriteMessage(notify.Message{
 		Type:    notify.SuccessType,
@@ -204,14 +126,7 @@ func TestWriteMessage_WithTimer(t *testing.T) {
 		Writer:  &out,
 	})
 
-	got := out.String()
-	if !strings.HasPrefix(got, "✔ operation complete\n⏲ current: ") {
-		t.Fatalf("output should start with success line and timing block, got %q", got)
-	}
-
-	if !strings.Contains(got, "\n  total:  ") {
-		t.Fatalf("output should include total timing line, got %q", got)
-	}
+	testutils.AssertGolden(t, t.Name(), out.String())
 }
 
 type fixedTimer struct {
@@ -0,0 +1,134 @@
+package timer
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Forked is a Timer for a stage that runs concurrently with other stages. It reports the
+// total elapsed time of its parent but tracks its own stage time, so concurrent stages do
+// not reset each other's stage timing.
+type Forked struct {
+	parent         Timer
+	stageStartTime time.Time
+	now            func() time.Time
+}
+
+// Fork creates a Forked timer whose stage starts now.
+func Fork(parent Timer) *Forked {
+	return ForkWithClock(parent, nil)
+}
+
+// ForkWithClock creates a Forked timer that reads the current time from now.
+func ForkWithClock(parent Timer, now func() time.Time) *Forked {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Forked{parent: parent, stageStartTime: now(), now: now}
+}
+
+// Start restarts the stage of the forked timer. The parent is left untouched.
+func (f *Forked) Start() {
+	f.stageStartTime = f.now()
+}
+
+// NewStage restarts the stage of the forked timer. The parent is left untouched.
+func (f *Forked) NewStage() {
+	f.stageStartTime = f.now()
+}
+
+// GetTiming returns the total elapsed time of the parent and the elapsed time of the
+// forked stage.
+func (f *Forked) GetTiming() (time.Duration, time.Duration) {
+	stage := f.now().Sub(f.stageStartTime)
+
+	if f.parent == nil {
+		return stage, stage
+	}
+
+	total, _ := f.parent.GetTiming()
+
+	return total, stage
+}
+
+// Stop is a no-op, like Impl.Stop.
+func (f *Forked) Stop() {}
+
+// Stage is the time one named stage of a command took.
+type Stage struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Breakdown collects how long the named stages of a command took. Unlike Timer it is safe
+// for concurrent use, so stages running in parallel can record themselves.
+type Breakdown struct {
+	mutex  sync.Mutex
+	stages []Stage
+	now    func() time.Time
+}
+
+// NewBreakdown creates an empty Breakdown.
+func NewBreakdown() *Breakdown {
+	return NewBreakdownWithClock(nil)
+}
+
+// NewBreakdownWithClock creates an empty Breakdown that reads the current time from now.
+func NewBreakdownWithClock(now func() time.Time) *Breakdown {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Breakdown{now: now}
+}
+
+// Track starts timing the named stage and returns the function that records it.
+func (b *Breakdown) Track(name string) func() {
+	if b == nil {
+		return func() {}
+	}
+
+	started := b.now()
+
+	return func() {
+		b.Record(name, b.now().Sub(started))
+	}
+}
+
+// Record adds the duration of the named stage. Stages are kept in the order they finish.
+func (b *Breakdown) Record(name string, duration time.Duration) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.stages = append(b.stages, Stage{Name: name, Duration: duration})
+}
+
+// Stages returns a copy of the recorded stages.
+func (b *Breakdown) Stages() []Stage {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return append([]Stage(nil), b.stages...)
+}
+
+// String renders the stages as "name duration" pairs, e.g. "registries 1.2s, cluster 41s".
+func (b *Breakdown) String() string {
+	stages := b.Stages()
+	parts := make([]string, 0, len(stages))
+
+	for _, stage := range stages {
+		parts = append(parts, stage.Name+" "+stage.Duration.Round(time.Millisecond).String())
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,92 @@
+package timer_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
+	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
+)
+
+// TestForkedTimerKeepsParentTotal validates that forked stages share the parent's total time.
+func TestForkedTimerKeepsParentTotal(t *testing.T) {
+	t.Parallel()
+
+	clock := testutils.NewFakeClock()
+	parent := timer.NewWithClock(clock.Now)
+	parent.Start()
+
+	clock.Advance(time.Second)
+
+	first := timer.ForkWithClock(parent, clock.Now)
+	second := timer.ForkWithClock(parent, clock.Now)
+
+	clock.Advance(500 * time.Millisecond)
+	second.NewStage()
+	clock.Advance(250 * time.Millisecond)
+
+	total, stage := first.GetTiming()
+	if total != 1750*time.Millisecond || stage != 750*time.Millisecond {
+		t.Errorf("Expected first fork at 1.75s total|750ms stage, got %v|%v", total, stage)
+	}
+
+	total, stage = second.GetTiming()
+	if total != 1750*time.Millisecond || stage != 250*time.Millisecond {
+		t.Errorf("Expected second fork at 1.75s total|250ms stage, got %v|%v", total, stage)
+	}
+
+	_, parentStage := parent.GetTiming()
+	if parentStage != 1750*time.Millisecond {
+		t.Errorf("Expected forks to leave the parent stage alone, got %v", parentStage)
+	}
+}
+
+// TestBreakdownRecordsConcurrentStages validates that stages can record themselves in parallel.
+func TestBreakdownRecordsConcurrentStages(t *testing.T) {
+	t.Parallel()
+
+	clock := testutils.NewFakeClock()
+	breakdown := timer.NewBreakdownWithClock(clock.Now)
+
+	stopRegistries := breakdown.Track("registries")
+
+	clock.Advance(1200 * time.Millisecond)
+	stopRegistries()
+
+	var waiting sync.WaitGroup
+
+	for range 8 {
+		waiting.Go(func() {
+			breakdown.Record("node image", time.Second)
+		})
+	}
+
+	waiting.Wait()
+
+	stages := breakdown.Stages()
+	if len(stages) != 9 {
+		t.Fatalf("Expected 9 stages, got %d", len(stages))
+	}
+
+	if stages[0] != (timer.Stage{Name: "registries", Duration: 1200 * time.Millisecond}) {
+		t.Errorf("Expected registries to take 1.2s, got %+v", stages[0])
+	}
+}
+
+// TestBreakdownString validates the rendering of recorded stages.
+func TestBreakdownString(t *testing.T) {
+	t.Parallel()
+
+	if got := timer.NewBreakdown().String(); got != "" {
+		t.Errorf("Expected empty breakdown to render empty, got %q", got)
+	}
+
+	breakdown := timer.NewBreakdown()
+	breakdown.Record("registries", 1234567*time.Microsecond)
+	breakdown.Record("cluster", 41*time.Second)
+
+	if got := breakdown.String(); got != "registries 1.235s, cluster 41s" {
+		t.Errorf("Unexpected breakdown %q", got)
+	}
+}
@@ -8,6 +8,10 @@
 // methods to start timing, mark stage transitions, and retrieve current timing information.
 // Implementations are safe for sequential use within a single goroutine.
 //
+// Stages that run concurrently time themselves with Fork, which shares the total elapsed
+// time of the command but keeps its own stage time, and record their durations in a
+// Breakdown, which is safe for concurrent use.
+//
 // Example usage for single-stage command:
 //
 //	timer := timer.New()
@@ -33,19 +33,30 @@ type Timer interface {
 type Impl struct {
 	startTime      time.Time
 	stageStartTime time.Time
+	now            func() time.Time
 }
 
 // New creates a new Timer instance.
 // The timer must be started with Start() before use.
 func New() *Impl {
-	return &Impl{}
+	return &Impl{now: time.Now}
+}
+
+// NewWithClock creates a new Timer instance that reads the current time from now.
+// It allows tests to drive the timer with a virtual clock instead of real sleeps.
+func NewWithClock(now func() time.Time) *Impl {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Impl{now: now}
 }
 
 // Start initializes the timer and begins tracking elapsed time.
 // Sets both total and stage start times to the current time.
 // Can be called multiple times to reset the timer.
 func (t *Impl) Start() {
-	now := time.Now()
+	now := t.currentTime()
 	t.startTime = now
 	t.stageStartTime = now
 }
@@ -53,7 +64,7 @@ func (t *Impl) Start() {
 // NewStage marks a transition to a new stage.
 // Resets the stage timer while preserving total elapsed time.
 func (t *Impl) NewStage() {
-	t.stageStartTime = time.Now()
+	t.stageStartTime = t.currentTime()
 }
 
 // GetTiming returns the current elapsed durations.
@@ -69,7 +80,7 @@ func (t *Impl) GetTiming() (time.Duration, time.Duration) {
 		return 0, 0
 	}
 
-	now := time.Now()
+	now := t.currentTime()
 	total := now.Sub(t.startTime)
 	stage := now.Sub(t.stageStartTime)
 
@@ -82,3 +93,11 @@ func (t *Impl) GetTiming() (time.Duration, time.Duration) {
 func (t *Impl) Stop() {
 	// No-op: timer state remains accessible via GetTiming()
 }
+
+func (t *Impl) currentTime() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+
+	return t.now()
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/devantler-tech/ksail-go/pkg/testutils"
 	"github.com/devantler-tech/ksail-go/pkg/ui/timer"
 )
 
@@ -315,22 +316,18 @@ func TestCR007_DurationPrecision(t *testing.T) {
 	t.Run("Duration.String() formats correctly", func(t *testing.T) {
 		t.Parallel()
 
-		tmr := timer.New()
+		clock := testutils.NewFakeClock()
+		tmr := timer.NewWithClock(clock.Now)
 		tmr.Start()
 
-		time.Sleep(1500 * time.Millisecond)
+		clock.Advance(1500 * time.Millisecond)
 
 		total, _ := tmr.GetTiming()
 
 		// Verify Duration.String() produces readable format
 		str := total.String()
-		if str == "" {
-			t.Error("Expected non-empty string from Duration.String()")
-		}
-
-		// Should contain "s" for seconds
-		if len(str) < 2 {
-			t.Errorf("Expected formatted duration string, got %q", str)
+		if str != "1.5s" {
+			t.Errorf("Expected formatted duration string %q, got %q", "1.5s", str)
 		}
 	})
 
@@ -356,3 +353,42 @@ func TestCR007_DurationPrecision(t *testing.T) {
 		}
 	})
 }
+
+// TestCR008_VirtualClock validates that a timer driven by a virtual clock reports exact durations.
+func TestCR008_VirtualClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stages follow clock advancement", func(t *testing.T) {
+		t.Parallel()
+
+		clock := testutils.NewFakeClock()
+		tmr := timer.NewWithClock(clock.Now)
+		tmr.Start()
+
+		clock.Advance(2 * time.Second)
+		tmr.NewStage()
+		clock.Advance(300 * time.Millisecond)
+
+		total, stage := tmr.GetTiming()
+
+		if total != 2300*time.Millisecond {
+			t.Errorf("Expected total 2.3s, got %v", total)
+		}
+
+		if stage != 300*time.Millisecond {
+			t.Errorf("Expected stage 300ms, got %v", stage)
+		}
+	})
+
+	t.Run("Nil clock falls back to wall time", func(t *testing.T) {
+		t.Parallel()
+
+		tmr := timer.NewWithClock(nil)
+		tmr.Start()
+
+		total, _ := tmr.GetTiming()
+		if total < 0 || total > 10*time.Millisecond {
+			t.Errorf("Expected near-zero duration, got %v", total)
+		}
+	})
+}